@@ -71,6 +71,21 @@ export interface SignatureItem {
   imageScale?: number // Escala (1 = 100%)
   imageX?: number // Offset X en px
   imageY?: number // Offset Y en px
+  lineWidth?: number // Ancho de línea propio en px (reemplaza el del bloque)
+  captions?: SignatureCaption[] // Líneas adicionales bajo la firma
+  dateLine?: { label?: string } // Línea "Fecha: ____", se completa al firmar
+  signedAt?: string // Fecha de firma (ISO 8601)
+}
+
+/**
+ * Línea adicional bajo la firma (nombre, cargo, etc.)
+ */
+export interface SignatureCaption {
+  text: string
+  size?: number // pt
+  bold?: boolean
+  italic?: boolean
+  color?: string // Hex
 }
 
 /**
@@ -39,6 +39,7 @@ export function usePreviewPDF({
 
         const blob = await previewApi.generate(templateId, versionId, {
           injectables,
+          showPlaceholders: true,
         })
         setPdfBlob(blob)
       } catch (err) {
@@ -270,6 +270,14 @@ export const SignatureLayoutSchema = z.union([
   QuadSignatureLayoutSchema,
 ])
 
+export const SignatureCaptionSchema = z.object({
+  text: z.string(),
+  size: z.number().positive().optional(),
+  bold: z.boolean().optional(),
+  italic: z.boolean().optional(),
+  color: z.string().optional(),
+})
+
 export const SignatureItemSchema = z.object({
   id: z.string(),
   roleId: z.string().optional(),
@@ -282,6 +290,10 @@ export const SignatureItemSchema = z.object({
   imageScale: z.number().positive().optional(),
   imageX: z.number().optional(),
   imageY: z.number().optional(),
+  lineWidth: z.number().positive().optional(),
+  captions: z.array(SignatureCaptionSchema).optional(),
+  dateLine: z.object({ label: z.string().optional() }).optional(),
+  signedAt: z.string().optional(),
 })
 
 // =============================================================================
@@ -11,6 +11,10 @@ export interface PreviewRequest {
    * - "activo" → true
    */
   injectables: Record<string, unknown>
+  /**
+   * Muestra [[variableId]] en lugar de los inyectores sin valor
+   */
+  showPlaceholders?: boolean
 }
 
 /**
@@ -13,13 +13,53 @@ import (
 //go:embed templates/*
 var templatesFS embed.FS
 
+// examplesFS holds the optional example extensions written by --with-examples.
+// Files here replace the template with the same path.
+//
+//go:embed examples/*
+var examplesFS embed.FS
+
 type projectData struct {
 	ProjectName string
 	ModulePath  string
+	DB          string // dbDocker or dbExternal
+}
+
+// Database setups selectable with --db.
+const (
+	// dbDocker scaffolds a docker-compose.yaml with Postgres and matching settings.
+	dbDocker = "docker"
+	// dbExternal expects an existing PostgreSQL server configured in settings/app.yaml.
+	dbExternal = "external"
+)
+
+// parseDBMode validates the --db value.
+func parseDBMode(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case dbDocker:
+		return dbDocker, nil
+	case dbExternal, "postgres":
+		return dbExternal, nil
+	case "sqlite", "sqlite3":
+		return "", fmt.Errorf("--db sqlite is not supported: doc-assembly requires PostgreSQL 16+ " +
+			"(it uses schemas, enums and a Postgres-backed job queue). Use --db docker for a zero-setup local database")
+	default:
+		return "", fmt.Errorf("unknown --db value %q (expected %q or %q)", value, dbDocker, dbExternal)
+	}
+}
+
+// includeFile reports whether an output file belongs in the project for the selected options.
+func includeFile(relPath string, data projectData) bool {
+	if relPath == "docker-compose.yaml" {
+		return data.DB == dbDocker
+	}
+	return true
 }
 
 func main() {
 	force := false
+	withExamples := false
+	dbMode := dbDocker
 	args := os.Args[1:]
 
 	// Parse flags
@@ -29,6 +69,18 @@ func main() {
 		switch args[i] {
 		case "--force":
 			force = true
+		case "--with-examples":
+			withExamples = true
+		case "--db":
+			if i+1 >= len(args) {
+				fatalf("--db requires a value (%s or %s)", dbDocker, dbExternal)
+			}
+			i++
+			mode, err := parseDBMode(args[i])
+			if err != nil {
+				fatalf("%v", err)
+			}
+			dbMode = mode
 		case "--module":
 			if i+1 < len(args) {
 				i++
@@ -45,7 +97,7 @@ func main() {
 	}
 
 	if len(positional) != 1 {
-		fmt.Fprintln(os.Stderr, "Usage: go run github.com/rendis/doc-assembly/cmd/init@latest <project-name> --module <module-path> [--force]")
+		fmt.Fprintln(os.Stderr, "Usage: go run github.com/rendis/doc-assembly/cmd/init@latest <project-name> --module <module-path> [--db docker|external] [--force] [--with-examples]")
 		os.Exit(1)
 	}
 
@@ -58,9 +110,10 @@ func main() {
 	data := projectData{
 		ProjectName: displayName,
 		ModulePath:  modulePath,
+		DB:          dbMode,
 	}
 
-	if err := scaffold(projectName, data, force); err != nil {
+	if err := scaffold(projectName, data, force, withExamples); err != nil {
 		fatalf("scaffold error: %v", err)
 	}
 
@@ -69,23 +122,46 @@ func main() {
 	fmt.Println("Next steps:")
 	fmt.Printf("  cd %s\n", absPath)
 	fmt.Println("  go mod tidy")
-	fmt.Println("  # Edit settings/app.yaml with your database config")
+	if dbMode == dbDocker {
+		fmt.Println("  make db-up    # starts Postgres from docker-compose.yaml")
+	} else {
+		fmt.Println("  # Edit settings/app.yaml with your database config")
+	}
 	fmt.Println("  go run . migrate")
 	fmt.Println("  go run .")
+	if withExamples {
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  Import examples/order-confirmation.json in the template editor and publish it,")
+		fmt.Println("  then POST examples/render-request.json to /api/v1/internal/documents/create.")
+	}
 }
 
-func scaffold(projectName string, data projectData, force bool) error {
-	return fs.WalkDir(templatesFS, "templates", func(path string, d fs.DirEntry, err error) error {
+func scaffold(projectName string, data projectData, force, withExamples bool) error {
+	written := make(map[string]bool)
+	if withExamples {
+		if err := scaffoldFS(examplesFS, "examples", projectName, data, force, written); err != nil {
+			return err
+		}
+	}
+	return scaffoldFS(templatesFS, "templates", projectName, data, force, written)
+}
+
+// scaffoldFS writes every file under root into projectName, skipping output
+// paths already present in written (used to let examples replace templates).
+func scaffoldFS(fsys embed.FS, root, projectName string, data projectData, force bool, written map[string]bool) error {
+	return fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Strip "templates/" prefix
-		relPath := strings.TrimPrefix(path, "templates/")
-		if relPath == "" {
+		if path == root {
 			return nil
 		}
 
+		// Strip "<root>/" prefix
+		relPath := strings.TrimPrefix(path, root+"/")
+
 		// Convert .tmpl extension
 		outPath := filepath.Join(projectName, strings.TrimSuffix(relPath, ".tmpl"))
 
@@ -93,6 +169,11 @@ func scaffold(projectName string, data projectData, force bool) error {
 			return os.MkdirAll(outPath, 0o755)
 		}
 
+		if written[outPath] || !includeFile(filepath.ToSlash(strings.TrimSuffix(relPath, ".tmpl")), data) {
+			return nil
+		}
+		written[outPath] = true
+
 		// Skip existing files unless --force
 		if !force {
 			if _, statErr := os.Stat(outPath); statErr == nil {
@@ -101,7 +182,7 @@ func scaffold(projectName string, data projectData, force bool) error {
 			}
 		}
 
-		content, readErr := fs.ReadFile(templatesFS, path)
+		content, readErr := fs.ReadFile(fsys, path)
 		if readErr != nil {
 			return readErr
 		}
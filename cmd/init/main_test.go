@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func scaffoldProject(t *testing.T, withExamples bool) string {
+	t.Helper()
+	return scaffoldProjectWithDB(t, dbDocker, withExamples)
+}
+
+func scaffoldProjectWithDB(t *testing.T, db string, withExamples bool) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "acme")
+	data := projectData{ProjectName: "acme", ModulePath: "github.com/acme/docs", DB: db}
+	if err := scaffold(dir, data, false, withExamples); err != nil {
+		t.Fatalf("scaffold: %v", err)
+	}
+	return dir
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return string(content)
+}
+
+func parseGoFile(t *testing.T, path string) *ast.File {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("%s is not valid Go: %v", path, err)
+	}
+	return file
+}
+
+// markedTypes returns the type names in a Go file whose doc comment carries
+// the given //docengine: directive.
+func markedTypes(t *testing.T, path, marker string) []string {
+	t.Helper()
+	file := parseGoFile(t, path)
+
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE || gen.Doc == nil {
+			continue
+		}
+		for _, c := range gen.Doc.List {
+			if c.Text == marker {
+				names = append(names, gen.Specs[0].(*ast.TypeSpec).Name.Name)
+			}
+		}
+	}
+	return names
+}
+
+func TestScaffold_DefaultIsMinimal(t *testing.T) {
+	dir := scaffoldProject(t, false)
+
+	for _, rel := range []string{"main.go", "go.mod", "extensions/register.go", "extensions/injectors/example.go"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"extensions/mappers", "extensions/providers", "examples", "templates"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err == nil {
+			t.Errorf("default scaffold should not create %s", rel)
+		}
+	}
+	if strings.Contains(readFile(t, filepath.Join(dir, "extensions/register.go")), "mappers.") {
+		t.Error("default register.go should not register a mapper")
+	}
+}
+
+func TestScaffold_WithExamples(t *testing.T) {
+	dir := scaffoldProject(t, true)
+
+	for _, rel := range []string{
+		"extensions/injectors/example.go",
+		"extensions/injectors/order.go",
+		"extensions/mappers/order.go",
+		"extensions/providers/workspace.go",
+		"examples/order-confirmation.json",
+		"examples/render-request.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+
+	injectors := markedTypes(t, filepath.Join(dir, "extensions/injectors/order.go"), "//docengine:injector")
+	if strings.Join(injectors, ",") != "CustomerNameInjector,OrderTotalInjector" {
+		t.Errorf("injector markers = %v", injectors)
+	}
+	mappers := markedTypes(t, filepath.Join(dir, "extensions/mappers/order.go"), "//docengine:mapper")
+	if strings.Join(mappers, ",") != "OrderMapper" {
+		t.Errorf("mapper markers = %v", mappers)
+	}
+	parseGoFile(t, filepath.Join(dir, "extensions/providers/workspace.go"))
+
+	register := readFile(t, filepath.Join(dir, "extensions/register.go"))
+	for _, want := range []string{
+		`"github.com/acme/docs/extensions/mappers"`,
+		"&injectors.CustomerNameInjector{}",
+		"&injectors.OrderTotalInjector{}",
+		"SetMapper(&mappers.OrderMapper{})",
+		"SetWorkspaceInjectableProvider(&providers.WorkspaceProvider{})",
+	} {
+		if !strings.Contains(register, want) {
+			t.Errorf("register.go missing %s", want)
+		}
+	}
+	parseGoFile(t, filepath.Join(dir, "extensions/register.go"))
+
+	i18n := readFile(t, filepath.Join(dir, "settings/injectors.i18n.yaml"))
+	for _, code := range []string{"example_greeting:", "customer_name:", "order_total:"} {
+		if !strings.Contains(i18n, code) {
+			t.Errorf("injectors.i18n.yaml missing %s", code)
+		}
+	}
+}
+
+func TestScaffold_ExampleTemplateReferencesExampleInjectables(t *testing.T) {
+	dir := scaffoldProject(t, true)
+
+	var doc struct {
+		Version     string   `json:"version"`
+		VariableIDs []string `json:"variableIds"`
+	}
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "examples/order-confirmation.json"))), &doc); err != nil {
+		t.Fatalf("sample template is not valid JSON: %v", err)
+	}
+	if doc.Version == "" {
+		t.Error("sample template has no version")
+	}
+
+	sources := readFile(t, filepath.Join(dir, "extensions/injectors/order.go")) +
+		readFile(t, filepath.Join(dir, "extensions/injectors/example.go")) +
+		readFile(t, filepath.Join(dir, "extensions/providers/workspace.go"))
+	for _, id := range doc.VariableIDs {
+		if !strings.Contains(sources, `"`+id+`"`) {
+			t.Errorf("sample template references %q, which no example defines", id)
+		}
+	}
+
+	var req map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "examples/render-request.json"))), &req); err != nil {
+		t.Fatalf("sample request is not valid JSON: %v", err)
+	}
+	if _, ok := req["payload"]; !ok {
+		t.Error("sample request has no payload")
+	}
+}
+
+func TestParseDBMode(t *testing.T) {
+	for input, want := range map[string]string{
+		"docker":   dbDocker,
+		"DOCKER":   dbDocker,
+		"external": dbExternal,
+		"postgres": dbExternal,
+	} {
+		got, err := parseDBMode(input)
+		if err != nil || got != want {
+			t.Errorf("parseDBMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"sqlite", "mysql", ""} {
+		if _, err := parseDBMode(input); err == nil {
+			t.Errorf("parseDBMode(%q) should fail", input)
+		}
+	}
+	if _, err := parseDBMode("sqlite"); err == nil || !strings.Contains(err.Error(), "PostgreSQL") {
+		t.Errorf("sqlite error should explain that PostgreSQL is required, got %v", err)
+	}
+}
+
+func TestScaffold_DBDocker(t *testing.T) {
+	dir := scaffoldProjectWithDB(t, dbDocker, false)
+
+	compose := readFile(t, filepath.Join(dir, "docker-compose.yaml"))
+	appYAML := readFile(t, filepath.Join(dir, "settings/app.yaml"))
+	for _, want := range []string{"POSTGRES_DB: doc_assembly", "POSTGRES_PASSWORD: postgres", `"5432:5432"`} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yaml missing %s", want)
+		}
+	}
+	for _, want := range []string{"name: doc_assembly", `password: "postgres"`, "port: 5432", "docker-compose.yaml"} {
+		if !strings.Contains(appYAML, want) {
+			t.Errorf("app.yaml should match the compose database, missing %s", want)
+		}
+	}
+
+	makefile := readFile(t, filepath.Join(dir, "Makefile"))
+	if !strings.Contains(makefile, "db-up:") || !strings.Contains(makefile, "dev: db-up migrate") {
+		t.Errorf("Makefile should start Postgres before dev:\n%s", makefile)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(dir, ".env.example")), "DOC_ENGINE_DATABASE_PASSWORD=postgres") {
+		t.Error(".env.example should carry the compose password")
+	}
+}
+
+func TestScaffold_DBExternal(t *testing.T) {
+	dir := scaffoldProjectWithDB(t, dbExternal, false)
+
+	if _, err := os.Stat(filepath.Join(dir, "docker-compose.yaml")); err == nil {
+		t.Error("external database setup should not scaffold docker-compose.yaml")
+	}
+
+	appYAML := readFile(t, filepath.Join(dir, "settings/app.yaml"))
+	if !strings.Contains(appYAML, `password: ""`) || strings.Contains(appYAML, "docker-compose") {
+		t.Errorf("app.yaml should leave credentials to the user:\n%s", appYAML)
+	}
+
+	makefile := readFile(t, filepath.Join(dir, "Makefile"))
+	if strings.Contains(makefile, "db-up") || strings.Contains(makefile, "docker compose") {
+		t.Errorf("Makefile should not manage a Postgres container:\n%s", makefile)
+	}
+	if !strings.Contains(makefile, "dev: migrate") {
+		t.Errorf("Makefile dev target should still migrate first:\n%s", makefile)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(dir, ".env.example")), "DOC_ENGINE_DATABASE_PASSWORD=\n") {
+		t.Error(".env.example should not ship a database password")
+	}
+}
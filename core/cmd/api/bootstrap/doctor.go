@@ -0,0 +1,187 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	"github.com/rendis/doc-assembly/core/internal/infra/config"
+)
+
+// doctorCheckTimeout bounds each connectivity check so an unreachable host
+// cannot hang the report.
+const doctorCheckTimeout = 10 * time.Second
+
+// doctorProbeKey is looked up (never written) to verify storage is reachable.
+const doctorProbeKey = ".doc-assembly-doctor-probe"
+
+// signingProviderPinger is implemented by signing providers that can verify
+// their remote API is reachable.
+type signingProviderPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// doctorCheck is a single named check in the doctor report.
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// RunDoctor validates settings and checks connectivity to every external
+// dependency without starting the server. It prints a pass/fail report to
+// stdout and returns an error when any check fails.
+func (e *Engine) RunDoctor() error {
+	return e.runDoctor(context.Background(), os.Stdout)
+}
+
+func (e *Engine) runDoctor(ctx context.Context, out io.Writer) error {
+	// Keep the report readable: only surface errors from the checks' own logging.
+	configureDefaultLogger(slog.LevelError)
+
+	fmt.Fprintln(out, "doc-assembly doctor")
+	fmt.Fprintln(out)
+
+	if err := e.loadConfig(); err != nil {
+		printDoctorResult(out, "config", "", err)
+		return errors.New("doctor: configuration could not be loaded")
+	}
+
+	checks := []doctorCheck{
+		{"config", e.doctorConfig},
+		{"typst", e.doctorTypst},
+		{"database", e.doctorDatabase},
+		{"signing session auth", e.doctorSigningSessionAuth},
+		{"storage", e.doctorStorage},
+		{"signing provider", e.doctorSigningProvider},
+	}
+
+	failed := 0
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+		detail, err := check.run(checkCtx)
+		cancel()
+		if err != nil {
+			failed++
+		}
+		printDoctorResult(out, check.name, detail, err)
+	}
+
+	fmt.Fprintln(out)
+	if failed > 0 {
+		fmt.Fprintf(out, "%d of %d checks failed\n", failed, len(checks))
+		return fmt.Errorf("doctor: %d check(s) failed", failed)
+	}
+	fmt.Fprintf(out, "all %d checks passed\n", len(checks))
+	return nil
+}
+
+func printDoctorResult(out io.Writer, name, detail string, err error) {
+	status := "PASS"
+	if err != nil {
+		status = "FAIL"
+		detail = err.Error()
+	}
+	lines := strings.Split(strings.TrimSpace(detail), "\n")
+	fmt.Fprintf(out, "  [%s] %-22s %s\n", status, name, lines[0])
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fmt.Fprintf(out, "         %-22s %s\n", "", line)
+	}
+}
+
+// doctorConfig validates required settings. Signing settings are ignored when a
+// custom provider was registered, since the engine never builds Documenso then.
+func (e *Engine) doctorConfig(_ context.Context) (string, error) {
+	cfg := *e.config
+	if e.signingProvider != nil {
+		cfg.Signing = config.SigningConfig{Provider: "mock"}
+	}
+
+	if errs := cfg.Validate(); len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return "settings valid", nil
+}
+
+func (e *Engine) doctorTypst(ctx context.Context) (string, error) {
+	version, err := checkTypst(ctx, e.config.Typst.BinPath)
+	if err != nil {
+		return "", err
+	}
+	binPath := e.config.Typst.BinPath
+	if binPath == "" {
+		binPath = "typst"
+	}
+	return binPath + " " + version + " found", nil
+}
+
+func (e *Engine) doctorDatabase(ctx context.Context) (string, error) {
+	pool, err := checkDatabase(ctx, e)
+	if err != nil {
+		return "", err
+	}
+	pool.Close()
+
+	if err := checkSchema(ctx, e); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d/%s reachable, schema initialized",
+		e.config.Database.Host, e.config.Database.Port, e.config.Database.Name), nil
+}
+
+func (e *Engine) doctorSigningSessionAuth(ctx context.Context) (string, error) {
+	if err := e.config.Auth.DiscoverAll(ctx); err != nil {
+		slog.WarnContext(ctx, "OIDC discovery failed", slog.String("error", err.Error()))
+	}
+	if err := checkSigningSessionAuth(ctx, e); err != nil {
+		return "", err
+	}
+	mode := strings.TrimSpace(e.signingSessionMode)
+	if mode == "" {
+		mode = e.config.SigningSessionAuth.Mode
+	}
+	return "mode " + strings.ToLower(strings.TrimSpace(mode)), nil
+}
+
+func (e *Engine) doctorStorage(ctx context.Context) (string, error) {
+	adapter, err := e.resolveStorageAdapter(e.config)
+	if err != nil {
+		return "", fmt.Errorf("storage adapter: %w", err)
+	}
+	if _, err := adapter.Exists(ctx, &port.StorageRequest{Key: doctorProbeKey}); err != nil {
+		return "", fmt.Errorf("storage unreachable: %w", err)
+	}
+
+	switch {
+	case e.storageAdapter != nil:
+		return "custom adapter reachable", nil
+	case e.config.Storage.Enabled && e.config.Storage.Provider == "s3":
+		return "s3 bucket " + e.config.Storage.Bucket + " reachable", nil
+	default:
+		return "local directory " + e.config.Storage.LocalDir + " reachable", nil
+	}
+}
+
+func (e *Engine) doctorSigningProvider(ctx context.Context) (string, error) {
+	provider, err := e.resolveSigningProvider(e.config)
+	if err != nil {
+		return "", fmt.Errorf("signing provider: %w", err)
+	}
+
+	pinger, ok := provider.(signingProviderPinger)
+	if !ok {
+		return provider.ProviderName() + " configured (no remote API to check)", nil
+	}
+	if err := pinger.Ping(ctx); err != nil {
+		return "", err
+	}
+	return provider.ProviderName() + " reachable", nil
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/gin-gonic/gin"
@@ -17,6 +18,7 @@ import (
 	"github.com/rendis/doc-assembly/core/internal/core/service/rendering/pdfrenderer"
 	"github.com/rendis/doc-assembly/core/internal/infra/config"
 	"github.com/rendis/doc-assembly/core/internal/infra/logging"
+	"github.com/rendis/doc-assembly/core/internal/infra/outbox"
 	"github.com/rendis/doc-assembly/core/internal/migrations"
 )
 
@@ -36,6 +38,7 @@ type Engine struct {
 	signingSessionAuth port.SigningSessionAuthenticator
 	signingSessionMode string
 	designTokens       *pdfrenderer.TypstDesignTokens
+	themes             map[string]pdfrenderer.TypstDesignTokens
 	frontendFS         fs.FS // Embedded SPA filesystem; nil = no frontend served
 	frontendOverridden bool  // True if SetFrontendFS was called (even with nil)
 
@@ -46,6 +49,9 @@ type Engine struct {
 	signingProvider      port.SigningProvider
 	storageAdapter       port.StorageAdapter
 	notificationProvider port.NotificationProvider
+	eventConsumers       []outbox.Consumer
+	renderCache          port.RenderCache
+	sealCertificates     port.SealCertificateProvider
 	webhookHandlers      map[string]port.WebhookHandler
 
 	// Middleware
@@ -58,6 +64,10 @@ type Engine struct {
 	// Lifecycle hooks
 	onStartHooks    []func(ctx context.Context) error // Run after config/preflight, before HTTP server
 	onShutdownHooks []func(ctx context.Context) error // Run after HTTP server stops, before exit
+
+	// Library rendering (Render/RenderDocument), opened on first use
+	renderMu sync.Mutex
+	render   *renderRuntime
 }
 
 // OnDocumentCompleted registers a handler that is called when a document
@@ -187,6 +197,17 @@ func (e *Engine) SetDesignTokens(tokens pdfrenderer.TypstDesignTokens) *Engine {
 	return e
 }
 
+// RegisterTheme registers a named set of design tokens. Renders select it with
+// their theme option, and workspaces can make it their default theme.
+// Renders without a theme keep using the tokens from SetDesignTokens.
+func (e *Engine) RegisterTheme(name string, tokens pdfrenderer.TypstDesignTokens) *Engine {
+	if e.themes == nil {
+		e.themes = make(map[string]pdfrenderer.TypstDesignTokens)
+	}
+	e.themes[name] = tokens
+	return e
+}
+
 // SetSigningProvider overrides the signing provider.
 // Default: auto-selected from config (mock/documenso).
 func (e *Engine) SetSigningProvider(sp port.SigningProvider) *Engine {
@@ -208,6 +229,28 @@ func (e *Engine) SetNotificationProvider(np port.NotificationProvider) *Engine {
 	return e
 }
 
+// RegisterEventConsumer adds a destination for outbox events (e.g. template version published).
+// Multiple consumers can be registered; each is delivered every event at least once, in order
+// per aggregate. Names must be unique across registered and configured (events.consumers) consumers.
+func (e *Engine) RegisterEventConsumer(name string, sink port.EventSink) *Engine {
+	e.eventConsumers = append(e.eventConsumers, outbox.Consumer{Name: name, Sink: sink})
+	return e
+}
+
+// SetRenderCache sets an external cache (e.g. Redis) for rendered PDFs.
+// Default: auto-selected from config (render_cache.mode off/memory).
+func (e *Engine) SetRenderCache(cache port.RenderCache) *Engine {
+	e.renderCache = cache
+	return e
+}
+
+// SetSealCertificateProvider sets where PAdES seal certificates come from (e.g. a KMS or vault).
+// Default: PKCS#12 files listed in config (pades.certificates).
+func (e *Engine) SetSealCertificateProvider(provider port.SealCertificateProvider) *Engine {
+	e.sealCertificates = provider
+	return e
+}
+
 // SetWebhookHandlers overrides the webhook handlers by provider name.
 // Default: auto-selected from signing config.
 func (e *Engine) SetWebhookHandlers(handlers map[string]port.WebhookHandler) *Engine {
@@ -312,6 +355,44 @@ func (e *Engine) RunMigrations() error {
 	return migrations.Run(&e.config.Database)
 }
 
+// RunMigrationsDown loads config and rolls back the given number of applied
+// migrations, newest first. Rolling back drops schema objects and their data.
+func (e *Engine) RunMigrationsDown(steps int) error {
+	if err := e.loadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return migrations.Down(&e.config.Database, steps)
+}
+
+// MigrateTo loads config and migrates up or down to the given version.
+func (e *Engine) MigrateTo(version uint) error {
+	if err := e.loadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return migrations.To(&e.config.Database, version)
+}
+
+// MigrationStatus is the applied version, dirty flag and pending count of the database.
+type MigrationStatus = migrations.Status
+
+// MigrationStatus loads config and reports the database migration state.
+func (e *Engine) MigrationStatus() (*MigrationStatus, error) {
+	if err := e.loadConfig(); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return migrations.GetStatus(&e.config.Database)
+}
+
+// ForceMigrationVersion loads config, records version as applied and clears
+// the dirty flag without running migrations. Use it after repairing a
+// migration that failed midway.
+func (e *Engine) ForceMigrationVersion(version uint) error {
+	if err := e.loadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return migrations.Force(&e.config.Database, version)
+}
+
 // loadConfig loads configuration from file or uses the provided config.
 func (e *Engine) loadConfig() error {
 	if e.config != nil {
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -26,6 +28,7 @@ import (
 	folderrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/folder_repo"
 	galleryassetrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/gallery_asset_repo"
 	injectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/injectable_repo"
+	outboxrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/outbox_repo"
 	processrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/process_repo"
 	signingattemptrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/signing_attempt_repo"
 	systeminjectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/system_injectable_repo"
@@ -40,9 +43,12 @@ import (
 	tenantrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/tenant_repo"
 	useraccesshistoryrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/user_access_history_repo"
 	userrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/user_repo"
+	workspaceassetrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_asset_repo"
 	workspaceinjectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_injectable_repo"
 	workspacememberrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_member_repo"
 	workspacerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_repo"
+	noopevents "github.com/rendis/doc-assembly/core/internal/adapters/secondary/events/noop"
+	webhookevents "github.com/rendis/doc-assembly/core/internal/adapters/secondary/events/webhook"
 	gmailnotification "github.com/rendis/doc-assembly/core/internal/adapters/secondary/notification/gmail"
 	noopnotification "github.com/rendis/doc-assembly/core/internal/adapters/secondary/notification/noop"
 	smtpnotification "github.com/rendis/doc-assembly/core/internal/adapters/secondary/notification/smtp"
@@ -57,18 +63,22 @@ import (
 	gallerysvc "github.com/rendis/doc-assembly/core/internal/core/service/gallery"
 	injectablesvc "github.com/rendis/doc-assembly/core/internal/core/service/injectable"
 	organizationsvc "github.com/rendis/doc-assembly/core/internal/core/service/organization"
+	renderingsvc "github.com/rendis/doc-assembly/core/internal/core/service/rendering"
 	"github.com/rendis/doc-assembly/core/internal/core/service/rendering/pdfrenderer"
 	templatesvc "github.com/rendis/doc-assembly/core/internal/core/service/template"
 	"github.com/rendis/doc-assembly/core/internal/core/service/template/contentvalidator"
 	automationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/automation"
 	documentuc "github.com/rendis/doc-assembly/core/internal/core/usecase/document"
+	templateuc "github.com/rendis/doc-assembly/core/internal/core/usecase/template"
 	"github.com/rendis/doc-assembly/core/internal/extensions/injectors/datetime"
 	"github.com/rendis/doc-assembly/core/internal/frontend"
 	"github.com/rendis/doc-assembly/core/internal/infra/config"
+	"github.com/rendis/doc-assembly/core/internal/infra/outbox"
 	"github.com/rendis/doc-assembly/core/internal/infra/registry"
 	"github.com/rendis/doc-assembly/core/internal/infra/riverqueue"
 	"github.com/rendis/doc-assembly/core/internal/infra/scheduler"
 	"github.com/rendis/doc-assembly/core/internal/infra/server"
+	"github.com/rendis/doc-assembly/core/internal/infra/tracing"
 )
 
 // appComponents holds all initialized components.
@@ -78,6 +88,8 @@ type appComponents struct {
 	scheduler   *scheduler.Scheduler
 	riverSvc    *riverqueue.RiverService
 	hasFrontend bool
+
+	shutdownTracing func(context.Context) error
 }
 
 func (a *appComponents) cleanup() {
@@ -89,6 +101,11 @@ func (a *appComponents) cleanup() {
 	}
 	a.scheduler.Stop()
 	postgres.Close(a.dbPool)
+	if a.shutdownTracing != nil {
+		if err := a.shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", slog.Any("error", err))
+		}
+	}
 	slog.Info("cleanup complete")
 }
 
@@ -99,6 +116,12 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		cfg.SigningSessionAuth.Mode = strings.TrimSpace(e.signingSessionMode)
 	}
 
+	// --- Tracing ---
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
 	// --- Database ---
 	pool, err := postgres.NewPool(ctx, &cfg.Database)
 	if err != nil {
@@ -120,6 +143,7 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	systemRoleRepo := systemrolerepo.New(pool)
 	workspaceRepo := workspacerepo.New(pool)
 	workspaceMemberRepo := workspacememberrepo.New(pool)
+	workspaceAssetRepo := workspaceassetrepo.New(pool)
 	tenantMemberRepo := tenantmemberrepo.New(pool)
 	tenantRepo := tenantrepo.New(pool)
 	userAccessHistoryRepo := useraccesshistoryrepo.New(pool)
@@ -149,6 +173,9 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	documentAccessTokenRepo := documentaccesstokenrepo.New(pool)
 	signingAttemptRepo := signingattemptrepo.New(pool)
 
+	// --- Repositories: Outbox ---
+	outboxRepo := outboxrepo.New(pool)
+
 	// --- Repositories: Automation ---
 	automationAPIKeyRepo := automationapikeyrepo.New(pool)
 	automationAuditLogRepo := automationauditlogrepo.New(pool)
@@ -160,7 +187,11 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	)
 
 	// --- Extensibility: Registries ---
-	injReg, mapReg, err := e.buildRegistries()
+	i18nCfg, err := e.loadI18n()
+	if err != nil {
+		return nil, err
+	}
+	injReg, mapReg, err := e.buildRegistries(i18nCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +204,7 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		tenantMemberRepo,
 		systemRoleRepo,
 		userAccessHistoryRepo,
+		pdfrenderer.Themes(e.themes),
 	)
 	tenantSvc := organizationsvc.NewTenantService(tenantRepo, workspaceRepo, tenantMemberRepo, systemRoleRepo, userAccessHistoryRepo)
 	workspaceMemberSvc := organizationsvc.NewWorkspaceMemberService(
@@ -198,13 +230,17 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	// --- Services: Injectable ---
 	injectableSvc := injectablesvc.NewInjectableService(
 		injectableRepo, systemInjectableRepo, injReg,
-		workspaceRepo, tenantRepo, e.workspaceProvider,
+		workspaceRepo, tenantRepo, templateVersionSignerRoleRepo, e.workspaceProvider,
 	)
 	workspaceInjectableSvc := injectablesvc.NewWorkspaceInjectableService(workspaceInjectableRepo)
 	systemInjectableSvc := injectablesvc.NewSystemInjectableService(systemInjectableRepo, injReg)
 
 	// --- Services: Template ---
-	templateSvc := templatesvc.NewTemplateService(templateRepo, templateVersionRepo, templateTagRepo, e.processResolver)
+	templateSvc := templatesvc.NewTemplateService(
+		templateRepo, templateVersionRepo, templateTagRepo, tagRepo,
+		templateVersionInjectableRepo, injectableRepo,
+		e.processResolver,
+	)
 	contentValidator := contentvalidator.New(injectableSvc)
 	templateVersionSvc := templatesvc.NewTemplateVersionService(
 		templateVersionRepo, templateVersionInjectableRepo, templateVersionSignerRoleRepo,
@@ -218,10 +254,14 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	}
 
 	// --- PDF Renderer ---
-	pdfRenderer, err := buildPDFRenderer(cfg, e.designTokens, storageAdapter)
+	pdfRenderer, err := e.buildRenderPipeline(
+		cfg, i18nCfg.GetLocales(), storageAdapter,
+		templateRepo, templateVersionRepo, workspaceRepo, workspaceAssetRepo,
+	)
 	if err != nil {
 		return nil, err
 	}
+	renderSvc := renderingsvc.New(templateVersionRepo, templateRepo, injectableSvc, pdfRenderer)
 
 	// --- Signing Provider ---
 	signingProvider, err := e.resolveSigningProvider(cfg)
@@ -229,17 +269,29 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		return nil, err
 	}
 
-	// --- Gallery ---
-	var galleryCtrl *controller.GalleryController
+	// --- Gallery & Workspace Assets ---
+	var (
+		galleryCtrl        *controller.GalleryController
+		workspaceAssetCtrl *controller.WorkspaceAssetController
+	)
 	if cfg.Storage.Enabled {
 		galleryAssetRepo := galleryassetrepo.New(pool)
 		gallerySvc := gallerysvc.New(storageAdapter, galleryAssetRepo, cfg.Server.PublicURL)
 		galleryCtrl = controller.NewGalleryController(gallerySvc)
+		workspaceAssetCtrl = controller.NewWorkspaceAssetController(
+			organizationsvc.NewWorkspaceAssetService(workspaceAssetRepo, storageAdapter),
+		)
 	}
 
 	// --- Notification Provider ---
 	notificationProvider := e.resolveNotificationProvider(cfg)
 
+	// --- Event Consumers ---
+	eventConsumers, err := e.resolveEventConsumers(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// --- Webhook Handlers ---
 	webhookHandlers, err := e.resolveWebhookHandlers(cfg)
 	if err != nil {
@@ -330,7 +382,8 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		workspaceSvc, folderSvc, tagSvc, workspaceMemberSvc, workspaceInjectableSvc, injectableMapper,
 	)
 	injectableCtrl := controller.NewContentInjectableController(injectableSvc, injectableMapper)
-	renderCtrl := controller.NewRenderController(templateVersionSvc, pdfRenderer)
+	renderRateLimiter := middleware.NewRenderRateLimiter(cfg.RateLimit)
+	renderCtrl := controller.NewRenderController(templateVersionSvc, renderSvc, renderRateLimiter)
 	templateVersionCtrl := controller.NewTemplateVersionController(
 		templateVersionSvc, templateVersionMapper, templateMapper, renderCtrl,
 	)
@@ -362,7 +415,7 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		notificationSvc, publicURL, rateLimitMax, rateLimitWindowMin, tokenTTLHours,
 	)
 	publicDocAccessCtrl := controller.NewPublicDocumentAccessController(documentAccessSvc)
-	publicSigningCtrl := controller.NewPublicSigningController(preSigningSvc, documentAccessSvc, publicURL)
+	publicSigningCtrl := controller.NewPublicSigningController(preSigningSvc, documentAccessSvc, publicURL, renderRateLimiter)
 	signingSessionCtrl := controller.NewSigningSessionController(signingSessionSvc)
 	automationKeyCtrl := controller.NewAutomationKeyController(automationAPIKeyUC)
 	automationCtrl := controller.NewAutomationController(
@@ -378,6 +431,7 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 	frontendFS := e.resolveFrontendFS()
 
 	// --- HTTP Server ---
+	renderMonitor, _ := pdfRenderer.(port.RenderPoolMonitor)
 	httpServer := server.NewHTTPServer(
 		cfg,
 		middlewareProvider,
@@ -398,15 +452,30 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		automationKeyCtrl,
 		automationCtrl,
 		galleryCtrl,
+		workspaceAssetCtrl,
 		publicDocAuth,
 		e.signingSessionAuth,
 		automationAPIKeyRepo,
+		renderMonitor,
 		frontendFS,
 	)
 
 	// --- Background Scheduler ---
 	sched := scheduler.New(cfg.Scheduler.Enabled)
-	registerSchedulerJobs(sched, &cfg.Scheduler, documentSvc)
+	registerSchedulerJobs(sched, &cfg.Scheduler, documentSvc, templateVersionSvc)
+	outboxRelay := outbox.NewRelay(outboxRepo, eventConsumers, outbox.RelayOptions{
+		BatchSize: cfg.Events.BatchSize,
+		RetryBase: cfg.Events.RetryBaseDuration(),
+		RetryMax:  cfg.Events.RetryMaxDuration(),
+		Retention: cfg.Events.RetentionDuration(),
+	})
+	if len(eventConsumers) > 0 {
+		sched.RegisterJob("relay-outbox-events", cfg.Events.PollIntervalDuration(), outboxRelay.RelayPending)
+	}
+	if cfg.Events.RetentionDays > 0 {
+		// Without consumers nothing is waiting on the events, so they only age out.
+		sched.RegisterJob("purge-outbox-events", cfg.Events.PurgeIntervalDuration(), outboxRelay.PurgeDelivered)
+	}
 
 	return &appComponents{
 		httpServer:  httpServer,
@@ -414,18 +483,74 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) { //nol
 		scheduler:   sched,
 		riverSvc:    riverSvc,
 		hasFrontend: frontendFS != nil,
+
+		shutdownTracing: shutdownTracing,
 	}, nil
 }
 
+// buildRenderPipeline creates the PDF renderer wrapped with the render cache, template
+// includes, workspace brand assets, workspace themes and sealing.
+func (e *Engine) buildRenderPipeline(
+	cfg *config.Config,
+	locales map[string]config.LocaleDefaults,
+	storageAdapter port.StorageAdapter,
+	templateRepo port.TemplateRepository,
+	templateVersionRepo port.TemplateVersionRepository,
+	workspaceRepo port.WorkspaceRepository,
+	workspaceAssetRepo port.WorkspaceAssetRepository,
+) (port.PDFRenderer, error) {
+	pdfRenderer, err := buildPDFRenderer(cfg, e.designTokens, e.themes, locales, storageAdapter)
+	if err != nil {
+		return nil, err
+	}
+	renderCache, err := e.resolveRenderCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if renderCache != nil {
+		pdfRenderer = pdfrenderer.NewCachedRenderer(pdfRenderer, renderCache, cfg.RenderCache.TTLDuration())
+	}
+	// Includes are expanded outside the cache so its key covers the included content
+	pdfRenderer = pdfrenderer.NewIncludingRenderer(pdfRenderer, templateRepo, templateVersionRepo)
+	// Workspace brand assets and default theme are resolved outside the cache too, so changing them is a miss
+	pdfRenderer = pdfrenderer.NewWorkspaceAssetRenderer(pdfRenderer, workspaceAssetRepo, templateRepo, templateVersionRepo)
+	pdfRenderer = pdfrenderer.NewThemingRenderer(pdfRenderer, pdfrenderer.Themes(e.themes), templateRepo, templateVersionRepo, workspaceRepo)
+	sealCertificates, err := e.resolveSealCertificates(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if sealCertificates != nil {
+		pdfRenderer = pdfrenderer.NewSealingRenderer(pdfRenderer, sealCertificates, pdfrenderer.SealOptions{
+			Reason:   cfg.PAdES.Reason,
+			Location: cfg.PAdES.Location,
+		})
+	}
+	return pdfRenderer, nil
+}
+
 // buildPDFRenderer creates the Typst-based PDF renderer service.
-func buildPDFRenderer(cfg *config.Config, customTokens *pdfrenderer.TypstDesignTokens, storageAdapter port.StorageAdapter) (port.PDFRenderer, error) {
+func buildPDFRenderer(
+	cfg *config.Config,
+	customTokens *pdfrenderer.TypstDesignTokens,
+	themes map[string]pdfrenderer.TypstDesignTokens,
+	locales map[string]config.LocaleDefaults,
+	storageAdapter port.StorageAdapter,
+) (port.PDFRenderer, error) {
 	typstCfg := &cfg.Typst
 	opts := pdfrenderer.TypstOptions{
 		BinPath:        typstCfg.BinPath,
 		Timeout:        typstCfg.TimeoutDuration(),
 		FontDirs:       resolveTypstFontDirs(typstCfg.FontDirs),
 		MaxConcurrent:  typstCfg.MaxConcurrent,
+		MaxQueue:       typstCfg.MaxQueue,
 		AcquireTimeout: typstCfg.AcquireTimeoutDuration(),
+		Limits: pdfrenderer.DocumentLimits{
+			MaxNodes: typstCfg.MaxDocumentNodes,
+			MaxDepth: typstCfg.MaxDocumentDepth,
+		},
+		DebugAnchors:      typstCfg.DebugAnchors,
+		MaxImageDimension: typstCfg.MaxImageDimension,
+		WarmupRenders:     typstCfg.WarmupRenders,
 	}
 
 	var imageCache *pdfrenderer.ImageCache
@@ -435,6 +560,7 @@ func buildPDFRenderer(cfg *config.Config, customTokens *pdfrenderer.TypstDesignT
 			Dir:             typstCfg.ImageCacheDir,
 			MaxAge:          typstCfg.ImageCacheMaxAgeDuration(),
 			CleanupInterval: typstCfg.ImageCacheCleanupIntervalDuration(),
+			MaxDimension:    typstCfg.MaxImageDimension,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("creating image cache: %w", err)
@@ -445,9 +571,33 @@ func buildPDFRenderer(cfg *config.Config, customTokens *pdfrenderer.TypstDesignT
 	if customTokens != nil {
 		tokens = *customTokens
 	}
+	tokens = applyRenderSettings(tokens, typstCfg, locales)
+	opts.Themes = make(pdfrenderer.Themes, len(themes))
+	for name, themeTokens := range themes {
+		opts.Themes[name] = applyRenderSettings(themeTokens, typstCfg, locales)
+	}
 
 	factory := pdfrenderer.NewTypstConverterFactory(tokens)
-	return pdfrenderer.NewService(opts, imageCache, factory, tokens, storageAdapter)
+	service, err := pdfrenderer.NewService(opts, imageCache, factory, tokens, storageAdapter)
+	if err != nil {
+		return nil, err
+	}
+	// Warm up in the background so startup is not held back by it
+	go func() {
+		if err := service.Warmup(context.Background()); err != nil {
+			slog.Warn("typst warmup failed", slog.String("error", err.Error()))
+		}
+	}()
+	return service, nil
+}
+
+// applyRenderSettings merges configured locale defaults and rounding mode into design tokens.
+func applyRenderSettings(tokens pdfrenderer.TypstDesignTokens, typstCfg *config.TypstConfig, locales map[string]config.LocaleDefaults) pdfrenderer.TypstDesignTokens {
+	tokens.Locales = mergeLocaleDefaults(tokens.Locales, locales)
+	if mode := strings.TrimSpace(typstCfg.RoundingMode); mode != "" {
+		tokens.RoundingMode = pdfrenderer.RoundingMode(mode)
+	}
+	return tokens
 }
 
 func resolveTypstFontDirs(fontDirs []string) []string {
@@ -545,6 +695,82 @@ func (e *Engine) resolveNotificationProvider(cfg *config.Config) port.Notificati
 	}
 }
 
+// resolveRenderCache returns the engine override or builds the cache selected by config.
+// Returns nil when caching is off.
+func (e *Engine) resolveRenderCache(cfg *config.Config) (port.RenderCache, error) {
+	if e.renderCache != nil {
+		return e.renderCache, nil
+	}
+	switch cfg.RenderCache.Mode {
+	case "memory":
+		return pdfrenderer.NewMemoryRenderCache(cfg.RenderCache.MaxEntries), nil
+	case "external":
+		return nil, fmt.Errorf("render_cache.mode external requires engine.SetRenderCache")
+	default:
+		return nil, nil
+	}
+}
+
+// resolveSealCertificates returns the engine override or loads the PKCS#12 files listed in config.
+// Returns nil when no certificates are configured.
+func (e *Engine) resolveSealCertificates(cfg *config.Config) (port.SealCertificateProvider, error) {
+	if e.sealCertificates != nil {
+		return e.sealCertificates, nil
+	}
+	if len(cfg.PAdES.Certificates) == 0 {
+		return nil, nil
+	}
+	certs := make(pdfrenderer.StaticSealCertificates, len(cfg.PAdES.Certificates))
+	for _, c := range cfg.PAdES.Certificates {
+		p12, err := os.ReadFile(c.P12Path)
+		if err != nil {
+			return nil, fmt.Errorf("pades certificate for workspace %s: %w", c.WorkspaceID, err)
+		}
+		cert, err := pdfrenderer.LoadSealCertificate(p12, c.P12Password)
+		if err != nil {
+			return nil, fmt.Errorf("pades certificate for workspace %s: %w", c.WorkspaceID, err)
+		}
+		certs[c.WorkspaceID] = cert
+	}
+	return certs, nil
+}
+
+// resolveEventConsumers returns the configured consumers followed by the engine-registered ones.
+func (e *Engine) resolveEventConsumers(cfg *config.Config) ([]outbox.Consumer, error) {
+	consumers := make([]outbox.Consumer, 0, len(cfg.Events.Consumers)+len(e.eventConsumers))
+	for _, c := range cfg.Events.Consumers {
+		var sink port.EventSink
+		switch c.Sink {
+		case "webhook":
+			webhook, err := webhookevents.New(webhookevents.Config{
+				URL:     c.WebhookURL,
+				Secret:  c.WebhookSecret,
+				Timeout: c.WebhookTimeoutDuration(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("event consumer %s: %w", c.Name, err)
+			}
+			sink = webhook
+		default:
+			sink = noopevents.New()
+		}
+		consumers = append(consumers, outbox.Consumer{Name: c.Name, Sink: sink})
+	}
+	consumers = append(consumers, e.eventConsumers...)
+
+	seen := make(map[string]bool, len(consumers))
+	for _, c := range consumers {
+		if c.Name == "" || c.Sink == nil {
+			return nil, fmt.Errorf("event consumer %q: name and sink are required", c.Name)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("event consumer %q registered more than once", c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return consumers, nil
+}
+
 // resolveWebhookHandlers returns the engine override or auto-selects from config.
 func (e *Engine) resolveWebhookHandlers(cfg *config.Config) (map[string]port.WebhookHandler, error) {
 	if e.webhookHandlers != nil {
@@ -569,20 +795,56 @@ func (e *Engine) resolveWebhookHandlers(cfg *config.Config) (map[string]port.Web
 	}
 }
 
-// buildRegistries creates and populates injector/mapper registries with built-in and user extensions.
-func (e *Engine) buildRegistries() (port.InjectorRegistry, port.MapperRegistry, error) {
+// mergeLocaleDefaults overlays the i18n file's locale defaults on the design token locales.
+func mergeLocaleDefaults(
+	base map[string]pdfrenderer.LocaleFormat,
+	overrides map[string]config.LocaleDefaults,
+) map[string]pdfrenderer.LocaleFormat {
+	merged := make(map[string]pdfrenderer.LocaleFormat, len(base)+len(overrides))
+	maps.Copy(merged, base)
+	for lang, o := range overrides {
+		lf := merged[lang]
+		if o.Boolean != "" {
+			lf.Boolean = o.Boolean
+		}
+		if o.DecimalSeparator != "" {
+			lf.DecimalSeparator = o.DecimalSeparator
+		}
+		if o.ThousandsSeparator != "" {
+			lf.ThousandsSeparator = o.ThousandsSeparator
+		}
+		if o.DateFormat != "" {
+			lf.DateFormat = o.DateFormat
+		}
+		if o.SignatureLabel != "" {
+			lf.SignatureLabel = o.SignatureLabel
+		}
+		if o.DateLabel != "" {
+			lf.DateLabel = o.DateLabel
+		}
+		merged[lang] = lf
+	}
+	return merged
+}
+
+// loadI18n loads the built-in i18n settings and merges the user-provided file, if any.
+func (e *Engine) loadI18n() (*config.InjectorI18nConfig, error) {
 	i18nCfg, err := config.LoadInjectorI18n()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	if e.i18nFilePath != "" {
-		userI18n, mergeErr := config.LoadInjectorI18nFromFile(e.i18nFilePath)
-		if mergeErr != nil {
-			return nil, nil, mergeErr
+		userI18n, err := config.LoadInjectorI18nFromFile(e.i18nFilePath)
+		if err != nil {
+			return nil, err
 		}
 		i18nCfg.Merge(userI18n)
 	}
+	return i18nCfg, nil
+}
 
+// buildRegistries creates and populates injector/mapper registries with built-in and user extensions.
+func (e *Engine) buildRegistries(i18nCfg *config.InjectorI18nConfig) (port.InjectorRegistry, port.MapperRegistry, error) {
 	mapReg := registry.NewMapperRegistry()
 	injReg := registry.NewInjectorRegistry(i18nCfg)
 
@@ -638,13 +900,24 @@ func (e *Engine) resolveFrontendFS() fs.FS {
 }
 
 // registerSchedulerJobs registers background polling jobs.
-func registerSchedulerJobs(s *scheduler.Scheduler, cfg *config.SchedulerConfig, docUC documentuc.DocumentUseCase) {
+func registerSchedulerJobs(
+	s *scheduler.Scheduler,
+	cfg *config.SchedulerConfig,
+	docUC documentuc.DocumentUseCase,
+	versionUC templateuc.TemplateVersionUseCase,
+) {
 	// Signing provider upload, retry, polling and reconciliation are intentionally
 	// not registered here. The clean-slate signing model uses attempt-scoped River
 	// jobs as the only durable engine for signing side effects.
 	s.RegisterJob("expire-documents", cfg.PollingIntervalDuration(), func(ctx context.Context) error {
 		return docUC.ExpireDocuments(ctx, cfg.PollingBatchSize)
 	})
+	s.RegisterJob("publish-scheduled-versions", cfg.PollingIntervalDuration(), func(ctx context.Context) error {
+		return versionUC.ProcessScheduledPublications(ctx, time.Now().UTC())
+	})
+	s.RegisterJob("archive-scheduled-versions", cfg.PollingIntervalDuration(), func(ctx context.Context) error {
+		return versionUC.ProcessScheduledArchivals(ctx, time.Now().UTC())
+	})
 }
 
 // seedDummyUser ensures a default admin user exists in the DB for dummy auth mode.
@@ -0,0 +1,147 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/rendis/doc-assembly/core/internal/migrations"
+)
+
+// migrateUsage describes the arguments accepted by RunMigrateCommand.
+const migrateUsage = `usage:
+  migrate [up]             apply all pending migrations
+  migrate down [n] --yes   roll back the last n migrations (default 1)
+  migrate to <v> [--yes]   migrate to version v (--yes required to roll back)
+  migrate status           show the applied version, dirty flag and pending count
+  migrate force <v> --yes  mark version v as applied and clear the dirty flag`
+
+// migrateConfirmFlag must be passed to confirm a rollback, which drops schema
+// objects and data, or a forced version, which skips running migrations.
+const migrateConfirmFlag = "--yes"
+
+// migrateCommand is a parsed `migrate` subcommand.
+type migrateCommand struct {
+	action    string // "up", "down", "to", "status" or "force"
+	steps     int
+	version   uint
+	confirmed bool
+}
+
+// RunMigrateCommand runs the `migrate` subcommand with the arguments that follow it.
+// Rollbacks and forced versions are refused unless the --yes confirmation flag is present.
+func (e *Engine) RunMigrateCommand(args []string) error {
+	cmd, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.action {
+	case "down":
+		if !cmd.confirmed {
+			return fmt.Errorf("refusing to roll back %d migration(s) without %s: rollbacks drop tables and data", cmd.steps, migrateConfirmFlag)
+		}
+		return e.RunMigrationsDown(cmd.steps)
+	case "to":
+		if !cmd.confirmed {
+			if err := e.loadConfig(); err != nil {
+				return fmt.Errorf("config: %w", err)
+			}
+			current, _, err := migrations.Version(&e.config.Database)
+			if err != nil {
+				return err
+			}
+			if cmd.version < current {
+				return fmt.Errorf("refusing to roll back from version %d to %d without %s: rollbacks drop tables and data", current, cmd.version, migrateConfirmFlag)
+			}
+		}
+		return e.MigrateTo(cmd.version)
+	case "status":
+		status, err := e.MigrationStatus()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(os.Stdout, status)
+		return nil
+	case "force":
+		if !cmd.confirmed {
+			return fmt.Errorf("refusing to force version %d without %s: make sure the schema matches that version first", cmd.version, migrateConfirmFlag)
+		}
+		return e.ForceMigrationVersion(cmd.version)
+	default:
+		return e.RunMigrations()
+	}
+}
+
+func parseMigrateArgs(args []string) (migrateCommand, error) {
+	cmd := migrateCommand{action: "up"}
+
+	positional := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch arg {
+		case migrateConfirmFlag, "-y":
+			cmd.confirmed = true
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return cmd, nil
+	}
+
+	cmd.action = positional[0]
+	rest := positional[1:]
+	switch cmd.action {
+	case "up":
+		if len(rest) > 0 {
+			return cmd, fmt.Errorf("migrate up takes no arguments\n\n%s", migrateUsage)
+		}
+	case "down":
+		cmd.steps = 1
+		if len(rest) > 1 {
+			return cmd, fmt.Errorf("migrate down takes at most one argument\n\n%s", migrateUsage)
+		}
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil || n < 1 {
+				return cmd, fmt.Errorf("invalid number of steps %q: must be a positive integer", rest[0])
+			}
+			cmd.steps = n
+		}
+	case "to":
+		if len(rest) != 1 {
+			return cmd, fmt.Errorf("migrate to requires a target version\n\n%s", migrateUsage)
+		}
+		v, err := strconv.ParseUint(rest[0], 10, 0)
+		if err != nil || v == 0 {
+			return cmd, fmt.Errorf("invalid migration version %q: must be a positive integer", rest[0])
+		}
+		cmd.version = uint(v)
+	case "status":
+		if len(rest) > 0 {
+			return cmd, fmt.Errorf("migrate status takes no arguments\n\n%s", migrateUsage)
+		}
+	case "force":
+		if len(rest) != 1 {
+			return cmd, fmt.Errorf("migrate force requires a version\n\n%s", migrateUsage)
+		}
+		v, err := strconv.ParseUint(rest[0], 10, 0)
+		if err != nil {
+			return cmd, fmt.Errorf("invalid migration version %q: must be a non-negative integer", rest[0])
+		}
+		cmd.version = uint(v)
+	default:
+		return cmd, fmt.Errorf("unknown migrate command %q\n\n%s", cmd.action, migrateUsage)
+	}
+	return cmd, nil
+}
+
+func printMigrationStatus(out io.Writer, status *MigrationStatus) {
+	fmt.Fprintf(out, "version: %d\n", status.Version)
+	fmt.Fprintf(out, "dirty:   %t\n", status.Dirty)
+	fmt.Fprintf(out, "pending: %d (latest: %d)\n", status.Pending, status.Latest)
+	if status.Dirty {
+		fmt.Fprintf(out, "\nVersion %d failed midway. Repair the schema by hand, then run:\n  migrate force <version> %s\n", status.Version, migrateConfirmFlag)
+	}
+}
@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want migrateCommand
+	}{
+		{"no args migrates up", nil, migrateCommand{action: "up"}},
+		{"explicit up", []string{"up"}, migrateCommand{action: "up"}},
+		{"down defaults to one step", []string{"down"}, migrateCommand{action: "down", steps: 1}},
+		{"down with steps and confirmation", []string{"down", "3", "--yes"}, migrateCommand{action: "down", steps: 3, confirmed: true}},
+		{"confirmation flag before command", []string{"-y", "down"}, migrateCommand{action: "down", steps: 1, confirmed: true}},
+		{"to version", []string{"to", "12"}, migrateCommand{action: "to", version: 12}},
+		{"status", []string{"status"}, migrateCommand{action: "status"}},
+		{"force version", []string{"force", "7", "--yes"}, migrateCommand{action: "force", version: 7, confirmed: true}},
+		{"force to no version", []string{"force", "0"}, migrateCommand{action: "force"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMigrateArgs(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseMigrateArgs_Invalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"sideways"},
+		{"up", "2"},
+		{"down", "0"},
+		{"down", "-1"},
+		{"down", "1", "2"},
+		{"to"},
+		{"to", "abc"},
+		{"to", "0"},
+		{"status", "now"},
+		{"force"},
+		{"force", "-1"},
+	} {
+		_, err := parseMigrateArgs(args)
+		assert.Error(t, err, "args %v", args)
+	}
+}
+
+func TestRunMigrateCommand_RequiresConfirmationForDown(t *testing.T) {
+	e := New()
+
+	err := e.RunMigrateCommand([]string{"down", "2"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), migrateConfirmFlag)
+}
+
+func TestRunMigrateCommand_RequiresConfirmationForForce(t *testing.T) {
+	e := New()
+
+	err := e.RunMigrateCommand([]string{"force", "5"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), migrateConfirmFlag)
+}
+
+func TestPrintMigrationStatus(t *testing.T) {
+	var clean bytes.Buffer
+	printMigrationStatus(&clean, &MigrationStatus{Version: 17, Latest: 19, Pending: 2})
+	assert.Equal(t, "version: 17\ndirty:   false\npending: 2 (latest: 19)\n", clean.String())
+
+	var dirty bytes.Buffer
+	printMigrationStatus(&dirty, &MigrationStatus{Version: 18, Dirty: true, Latest: 19, Pending: 1})
+	assert.Contains(t, dirty.String(), "dirty:   true")
+	assert.Contains(t, dirty.String(), "migrate force <version> --yes")
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,9 +18,11 @@ import (
 
 // preflightChecks runs all startup validations.
 func (e *Engine) preflightChecks(ctx context.Context) error {
-	if err := checkTypst(ctx, e.config.Typst.BinPath); err != nil {
+	version, err := checkTypst(ctx, e.config.Typst.BinPath)
+	if err != nil {
 		return err
 	}
+	e.config.Typst.DetectedVersion = version
 
 	pool, err := checkDatabase(ctx, e)
 	if err != nil {
@@ -43,15 +47,53 @@ func (e *Engine) preflightChecks(ctx context.Context) error {
 	return nil
 }
 
-// checkTypst verifies the Typst CLI is installed and accessible.
-func checkTypst(ctx context.Context, binPath string) error {
+// minTypstVersion is the oldest Typst CLI the renderer supports (--pdf-standard, used for PDF/A).
+var minTypstVersion = typstVersion{0, 12, 0}
+
+// typstVersionPattern matches the version in `typst --version` output, e.g. "typst 0.13.1 (8ace67d9)".
+var typstVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+
+// typstVersion is a parsed major.minor.patch Typst version.
+type typstVersion [3]int
+
+// parseTypstVersion extracts the version from `typst --version` output.
+// Pre-release suffixes (e.g. "-rc1") are ignored.
+func parseTypstVersion(output string) (typstVersion, error) {
+	m := typstVersionPattern.FindStringSubmatch(output)
+	if m == nil {
+		return typstVersion{}, fmt.Errorf("unrecognized typst version output %q", strings.TrimSpace(output))
+	}
+	var v typstVersion
+	for i := range v {
+		v[i], _ = strconv.Atoi(m[i+1])
+	}
+	return v, nil
+}
+
+// less reports whether v is older than other.
+func (v typstVersion) less(other typstVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+func (v typstVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// checkTypst verifies the Typst CLI is installed and meets minTypstVersion.
+// Returns the detected version.
+func checkTypst(ctx context.Context, binPath string) (string, error) {
 	if binPath == "" {
 		binPath = "typst"
 	}
 
 	out, err := exec.CommandContext(ctx, binPath, "--version").CombinedOutput()
 	if err != nil {
-		return fmt.Errorf(`typst CLI not found (%s)
+		return "", fmt.Errorf(`typst CLI not found (%s)
 
 Typst is required for PDF rendering. Install it:
 
@@ -63,9 +105,20 @@ Typst is required for PDF rendering. Install it:
 More info: https://github.com/typst/typst#installation`, binPath)
 	}
 
-	version := strings.TrimSpace(string(out))
-	slog.InfoContext(ctx, "typst CLI found", slog.String("version", version), slog.String("os", runtime.GOOS))
-	return nil
+	version, err := parseTypstVersion(string(out))
+	if err != nil {
+		return "", fmt.Errorf("typst CLI (%s): %w", binPath, err)
+	}
+	if version.less(minTypstVersion) {
+		return "", fmt.Errorf("typst CLI (%s) is version %s, doc-assembly requires %s or newer", binPath, version, minTypstVersion)
+	}
+
+	slog.InfoContext(ctx, "typst CLI found",
+		slog.String("version", version.String()),
+		slog.String("min_version", minTypstVersion.String()),
+		slog.String("os", runtime.GOOS),
+	)
+	return version.String(), nil
 }
 
 // checkDatabase verifies the database is reachable.
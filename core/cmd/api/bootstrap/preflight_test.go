@@ -0,0 +1,48 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypstVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   typstVersion
+	}{
+		{"typst 0.13.1 (8ace67d9)\n", typstVersion{0, 13, 1}},
+		{"typst 0.12.0", typstVersion{0, 12, 0}},
+		{"typst 0.14.0-rc1 (a1b2c3d4)", typstVersion{0, 14, 0}},
+		{"typst 1.2.10", typstVersion{1, 2, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			got, err := parseTypstVersion(tt.output)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := parseTypstVersion("typst: command not recognized")
+	assert.Error(t, err)
+}
+
+func TestTypstVersionLess(t *testing.T) {
+	tests := []struct {
+		v, other typstVersion
+		want     bool
+	}{
+		{typstVersion{0, 11, 1}, minTypstVersion, true},
+		{typstVersion{0, 12, 0}, minTypstVersion, false},
+		{typstVersion{0, 13, 1}, minTypstVersion, false},
+		{typstVersion{1, 0, 0}, typstVersion{0, 99, 99}, false},
+		{typstVersion{0, 12, 9}, typstVersion{0, 13, 0}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.String()+"<"+tt.other.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.v.less(tt.other))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres"
+	injectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/injectable_repo"
+	systeminjectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/system_injectable_repo"
+	templaterepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateversionrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_repo"
+	templateversionsignerrolerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_signer_role_repo"
+	tenantrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/tenant_repo"
+	workspaceassetrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_asset_repo"
+	workspacerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_repo"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	injectablesvc "github.com/rendis/doc-assembly/core/internal/core/service/injectable"
+	renderingsvc "github.com/rendis/doc-assembly/core/internal/core/service/rendering"
+	renderinguc "github.com/rendis/doc-assembly/core/internal/core/usecase/rendering"
+)
+
+// RenderOptions selects what Render and RenderDocument produce. The zero value renders
+// the whole document as a PDF.
+type RenderOptions = renderinguc.RenderOptions
+
+// RenderResult is a rendered PDF, or a PNG page for image renders, with its signature fields.
+type RenderResult = port.RenderPreviewResult
+
+// renderRuntime holds what Render needs when the engine is embedded as a library
+// rather than run as a server.
+type renderRuntime struct {
+	renderUC renderinguc.RenderUseCase
+	close    func() error
+}
+
+// Render renders a template version with the given injectable values without going
+// through the HTTP API. Values missing from inputs fall back to the version's defaults.
+// The first call loads config and connects to the database; call Close when done.
+func (e *Engine) Render(ctx context.Context, versionID string, inputs map[string]any, opts RenderOptions) (*RenderResult, error) {
+	rt, err := e.renderRuntime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rt.renderUC.RenderVersion(ctx, renderinguc.RenderVersionCmd{
+		VersionID:   versionID,
+		Environment: entity.EnvironmentProd,
+		Injectables: inputs,
+		Options:     opts,
+	})
+}
+
+// RenderDocument renders a portable document (the JSON content of a template version)
+// that is not stored, e.g. a generated draft. No defaults are applied.
+func (e *Engine) RenderDocument(ctx context.Context, content []byte, inputs map[string]any, opts RenderOptions) (*RenderResult, error) {
+	doc, err := portabledoc.Parse(content)
+	if err != nil {
+		return nil, entity.WithCategory(entity.ErrValidation, fmt.Errorf("parsing document: %w", err))
+	}
+	rt, err := e.renderRuntime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rt.renderUC.RenderDocument(ctx, renderinguc.RenderDocumentCmd{
+		Document:    doc,
+		Injectables: inputs,
+		Options:     opts,
+	})
+}
+
+// Close releases the database pool and renderer opened by Render. It is a no-op
+// when Render was never called.
+func (e *Engine) Close() error {
+	e.renderMu.Lock()
+	defer e.renderMu.Unlock()
+	if e.render == nil {
+		return nil
+	}
+	err := e.render.close()
+	e.render = nil
+	return err
+}
+
+// renderRuntime returns the render runtime, opening it on first use.
+func (e *Engine) renderRuntime(ctx context.Context) (*renderRuntime, error) {
+	e.renderMu.Lock()
+	defer e.renderMu.Unlock()
+	if e.render != nil {
+		return e.render, nil
+	}
+
+	if err := e.loadConfig(); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	entity.InitEnvironmentAliases(e.config.EnvironmentAliases)
+	pool, err := postgres.NewPool(ctx, &e.config.Database)
+	if err != nil {
+		return nil, err
+	}
+	rt, err := e.newRenderRuntime(pool)
+	if err != nil {
+		postgres.Close(pool)
+		return nil, err
+	}
+	e.render = rt
+	return rt, nil
+}
+
+// newRenderRuntime builds the render pipeline and its use case on an open pool.
+// The runtime takes ownership of the pool.
+func (e *Engine) newRenderRuntime(pool *pgxpool.Pool) (*renderRuntime, error) {
+	cfg := e.config
+	templateRepo := templaterepo.New(pool)
+	templateVersionRepo := templateversionrepo.New(pool)
+	workspaceRepo := workspacerepo.New(pool)
+
+	i18nCfg, err := e.loadI18n()
+	if err != nil {
+		return nil, err
+	}
+	injReg, _, err := e.buildRegistries(i18nCfg)
+	if err != nil {
+		return nil, err
+	}
+	injectableSvc := injectablesvc.NewInjectableService(
+		injectablerepo.New(pool), systeminjectablerepo.New(pool), injReg,
+		workspaceRepo, tenantrepo.New(pool), templateversionsignerrolerepo.New(pool), e.workspaceProvider,
+	)
+	storageAdapter, err := e.resolveStorageAdapter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pdfRenderer, err := e.buildRenderPipeline(
+		cfg, i18nCfg.GetLocales(), storageAdapter,
+		templateRepo, templateVersionRepo, workspaceRepo, workspaceassetrepo.New(pool),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &renderRuntime{
+		renderUC: renderingsvc.New(templateVersionRepo, templateRepo, injectableSvc, pdfRenderer),
+		close: func() error {
+			err := pdfRenderer.Close()
+			postgres.Close(pool)
+			return err
+		},
+	}, nil
+}
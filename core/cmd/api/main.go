@@ -9,16 +9,27 @@ import (
 )
 
 func main() {
-	// Subcommand: migrate
+	// Subcommand: migrate [up | down [n] --yes | to <version> [--yes]]
 	if len(os.Args) > 1 && os.Args[1] == "migrate" {
 		engine := bootstrap.New()
-		if err := engine.RunMigrations(); err != nil {
+		if err := engine.RunMigrateCommand(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "migration error: %v\n", err)
 			os.Exit(1)
 		}
 		return
 	}
 
+	// Subcommand: doctor (alias: check)
+	if len(os.Args) > 1 && (os.Args[1] == "doctor" || os.Args[1] == "check") {
+		engine := bootstrap.New()
+		extensions.Register(engine)
+		if err := engine.RunDoctor(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Normal startup
 	engine := bootstrap.New()
 	extensions.Register(engine)
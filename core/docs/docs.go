@@ -1672,6 +1672,62 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/content/injectables/autocomplete": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injectables"
+                ],
+                "summary": "Autocomplete injectable keys and signer roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive prefix matched against keys and labels",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of suggestions (1-200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.InjectableAutocompleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/content/injectables/{injectableId}": {
             "get": {
                 "consumes": [
@@ -1709,6 +1765,156 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/content/library": {
+            "get": {
+                "description": "Lists published templates flagged as public library from every active workspace of the tenant, so they can be discovered and cloned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List public library templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enable sandbox mode (operates on sandbox workspace)",
+                        "name": "X-Sandbox-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by folder ID. Use 'root' to get only root-level templates (no folder)",
+                        "name": "folderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by tag IDs",
+                        "name": "tagIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by title",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset results",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListTemplatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/content/library/{templateId}/clone": {
+            "post": {
+                "description": "Clones the published version of a public library template from any active workspace of the tenant into the current workspace. Tags are matched by name and workspace injectables by key, creating the missing ones in the current workspace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Clone template from public library",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enable sandbox mode (operates on sandbox workspace)",
+                        "name": "X-Sandbox-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Library template ID",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.CloneFromLibraryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.TemplateCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found in the public library",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Template title already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/content/templates": {
             "get": {
                 "consumes": [
@@ -2812,6 +3018,68 @@ const docTemplate = `{
             }
         },
         "/api/v1/content/templates/{templateId}/versions/{versionId}/injectables": {
+            "get": {
+                "description": "Walks the version content and returns each referenced injectable with its type, label, default value, required flag and role binding, for form generation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Versions"
+                ],
+                "summary": "List injectables used by a version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enable sandbox mode (operates on sandbox workspace)",
+                        "name": "X-Sandbox-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "versionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListVersionInjectableFieldsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "consumes": [
                     "application/json"
@@ -2946,11 +3214,14 @@ const docTemplate = `{
         },
         "/api/v1/content/templates/{templateId}/versions/{versionId}/preview": {
             "post": {
+                "description": "Renders the version to PDF. With image set, returns a single page as PNG instead (e.g. for thumbnails). With extractSignaturePages set, returns JSON with the PDF and its signature pages as a separate PDF, base64-encoded.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/pdf"
+                    "application/pdf",
+                    "image/png",
+                    "application/json"
                 ],
                 "tags": [
                     "Template Versions"
@@ -3007,11 +3278,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3128,6 +3414,14 @@ const docTemplate = `{
                         "name": "versionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Optional change notes",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishVersionRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3149,6 +3443,87 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/content/templates/{templateId}/versions/{versionId}/publish-preview": {
+            "post": {
+                "description": "Compares the version with the template's currently published version and returns a structured change summary. Set includePdfs to also receive both rendered PDFs, base64-encoded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Versions"
+                ],
+                "summary": "Preview publish changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "versionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Preview options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishPreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/content/templates/{templateId}/versions/{versionId}/schedule": {
             "delete": {
                 "consumes": [
@@ -3347,6 +3722,58 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/content/validate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Versions"
+                ],
+                "summary": "Validate content structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enable sandbox mode (operates on sandbox workspace)",
+                        "name": "X-Sandbox-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Content structure",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ValidateContentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/documents": {
             "get": {
                 "consumes": [
@@ -7132,15 +7559,127 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/tenant/workspaces/{workspaceId}/status": {
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+            }
+        },
+        "/api/v1/tenant/workspaces/{workspaceId}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "Update workspace status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.UpdateWorkspaceStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get current workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -7148,31 +7687,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
+                    "Workspaces"
                 ],
-                "summary": "Update workspace status",
+                "summary": "Update current workspace",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Workspace ID",
-                        "name": "workspaceId",
-                        "in": "path",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Status data",
+                        "description": "Workspace data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.UpdateWorkspaceStatusRequest"
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
                         }
                     }
                 ],
@@ -7189,14 +7721,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Archive current workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -7210,18 +7768,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace": {
+        "/api/v1/workspace/assets": {
             "get": {
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists the workspace logo and fonts. The logo renders wherever a template uses the workspace.logo injectable.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Get current workspace",
+                "summary": "List workspace assets",
                 "parameters": [
                     {
                         "type": "string",
@@ -7235,26 +7796,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto_WorkspaceAssetResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads the workspace logo (an image, replacing the current one) or a font (TrueType or OpenType) made available to every render in the workspace.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -7262,7 +7829,7 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Update current workspace",
+                "summary": "Upload workspace asset",
                 "parameters": [
                     {
                         "type": "string",
@@ -7272,20 +7839,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Workspace data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
-                        }
+                        "enum": [
+                            "LOGO",
+                            "FONT"
+                        ],
+                        "type": "string",
+                        "description": "Asset kind",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo image or font file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceAssetResponse"
                         }
                     },
                     "400": {
@@ -7294,17 +7870,27 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/workspace/assets/{assetId}": {
             "delete": {
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
@@ -7312,7 +7898,7 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Archive current workspace",
+                "summary": "Delete workspace asset",
                 "parameters": [
                     {
                         "type": "string",
@@ -7320,14 +7906,21 @@ const docTemplate = `{
                         "name": "X-Workspace-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -7337,6 +7930,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -9271,6 +9870,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -9861,6 +10469,24 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.BlockChangeResponse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "added, removed, modified",
+                    "type": "string"
+                },
+                "nodeId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.BulkOperationError": {
             "type": "object",
             "properties": {
@@ -9904,6 +10530,22 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.CloneFromLibraryRequest": {
+            "type": "object",
+            "required": [
+                "newTitle"
+            ],
+            "properties": {
+                "newTitle": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "targetFolderId": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.CloneTemplateRequest": {
             "type": "object",
             "required": [
@@ -9924,6 +10566,54 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationErrorDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationResultDTO": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationErrorDTO"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationWarningDTO"
+                    }
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ContentValidationWarningDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.CreateAssignmentRequest": {
             "type": "object",
             "required": [
@@ -10579,6 +11269,10 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string"
+                },
+                "requestId": {
+                    "description": "X-Operation-ID, for correlating a failure with server logs",
+                    "type": "string"
                 }
             }
         },
@@ -10720,7 +11414,43 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "order": {
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.InjectableAutocompleteItem": {
+            "type": "object",
+            "properties": {
+                "dataType": {
+                    "description": "Empty for roles",
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "\"injectable\" or \"role\"",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.InjectableAutocompleteResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.InjectableAutocompleteItem"
+                    }
+                },
+                "total": {
                     "type": "integer"
                 }
             }
@@ -10765,6 +11495,10 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": {}
                 },
+                "namespace": {
+                    "description": "Key prefix before the last \".\" (e.g. \"crm\" in \"crm.first_name\")",
+                    "type": "string"
+                },
                 "sourceType": {
                     "type": "string"
                 },
@@ -11058,6 +11792,20 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto_WorkspaceAssetResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceAssetResponse"
+                    }
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto_WorkspaceResponse": {
             "type": "object",
             "properties": {
@@ -11117,6 +11865,20 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListVersionInjectableFieldsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.VersionInjectableFieldResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ListWorkspaceInjectablesResponse": {
             "type": "object",
             "properties": {
@@ -11193,6 +11955,27 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PDFEncryptionRequest": {
+            "type": "object",
+            "properties": {
+                "noCopy": {
+                    "description": "NoCopy disallows copying text and graphics.",
+                    "type": "boolean"
+                },
+                "noPrint": {
+                    "description": "NoPrint disallows printing.",
+                    "type": "boolean"
+                },
+                "ownerPassword": {
+                    "description": "OwnerPassword lifts the permission restrictions. Defaults to a random password.",
+                    "type": "string"
+                },
+                "userPassword": {
+                    "description": "UserPassword is required to open the PDF.",
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PaginatedDocumentTypesResponse": {
             "type": "object",
             "properties": {
@@ -11439,6 +12222,84 @@ const docTemplate = `{
                 "PromotionModeNewVersion"
             ]
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishPreviewRequest": {
+            "type": "object",
+            "properties": {
+                "includePdfs": {
+                    "description": "IncludePDFs also renders the version and the currently published version.",
+                    "type": "boolean"
+                },
+                "injectables": {
+                    "description": "Injectables are the values used to render both PDFs, so they differ only by content.",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.BlockChangeResponse"
+                    }
+                },
+                "hasChanges": {
+                    "type": "boolean"
+                },
+                "publishedPdf": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "publishedVersionId": {
+                    "description": "PublishedVersionID is omitted when the template has no published version,\nin which case every block is reported as added.",
+                    "type": "string"
+                },
+                "sections": {
+                    "description": "meta, pageConfig, signingWorkflow, header, coverPage, watermark, textFlow, headingNumbering",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "signerRoles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.SignerRoleChangeResponse"
+                    }
+                },
+                "variablesAdded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variablesRemoved": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "versionId": {
+                    "type": "string"
+                },
+                "versionPdf": {
+                    "description": "VersionPDF and PublishedPDF are base64-encoded and only set when includePdfs is requested.",
+                    "type": "string",
+                    "format": "base64"
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PublishVersionRequest": {
+            "type": "object",
+            "properties": {
+                "changeNotes": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.RecipientResponse": {
             "type": "object",
             "properties": {
@@ -11489,13 +12350,88 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.RenderImageRequest": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "Page is the 1-indexed page to render. Defaults to the first page.",
+                    "type": "integer"
+                },
+                "ppi": {
+                    "description": "PPI is the resolution in pixels per inch, up to 600. Defaults to 72.",
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.RenderPreviewRequest": {
             "type": "object",
             "properties": {
+                "blockId": {
+                    "description": "BlockID addresses the block to render by its node id (attrs.nodeId) instead of index.\nIgnored when BlockIndex is set.",
+                    "type": "string"
+                },
+                "blockIndex": {
+                    "description": "BlockIndex, when set, renders only the top-level content block at this index\nso the editor can refresh just the changed region.",
+                    "type": "integer"
+                },
+                "draftMode": {
+                    "description": "DraftMode renders reviewer comments as notes in the PDF.",
+                    "type": "boolean"
+                },
+                "encryption": {
+                    "description": "Encryption password-protects the PDF. Cannot be combined with pdfA.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.PDFEncryptionRequest"
+                        }
+                    ]
+                },
+                "extractSignaturePages": {
+                    "description": "ExtractSignaturePages also returns the pages holding signature fields as a separate PDF.\nThe response is then a RenderSignaturePagesResponse instead of the PDF binary.\nCannot be combined with image.",
+                    "type": "boolean"
+                },
+                "image": {
+                    "description": "Image returns a single page as PNG instead of the PDF, e.g. for thumbnails.\nCannot be combined with pdfA or encryption.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.RenderImageRequest"
+                        }
+                    ]
+                },
                 "injectables": {
                     "description": "Injectables contains the values to inject into the document.\nKeys are variable IDs, values are the actual values.",
                     "type": "object",
                     "additionalProperties": {}
+                },
+                "pdfA": {
+                    "description": "PDFA produces a PDF/A-2b archival PDF.",
+                    "type": "boolean"
+                },
+                "showHiddenConditionals": {
+                    "description": "ShowHiddenConditionals renders conditionals that evaluate to false as a visible note with their condition.",
+                    "type": "boolean"
+                },
+                "showPlaceholders": {
+                    "description": "ShowPlaceholders renders injectors without a value as a visible [[variableId]].",
+                    "type": "boolean"
+                },
+                "slots": {
+                    "description": "Slots fills the template's insertion points with content, keyed by slot name.\nInsertion points without content render nothing.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_core_entity_portabledoc.Node"
+                        }
+                    }
+                },
+                "theme": {
+                    "description": "Theme names a registered design theme. Defaults to the workspace's default theme.",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone system.now and system.today are resolved in. Defaults to UTC.",
+                    "type": "string"
                 }
             }
         },
@@ -11558,6 +12494,21 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.SignerRoleChangeResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "added, removed, modified",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.SigningURLResponse": {
             "type": "object",
             "properties": {
@@ -11864,6 +12815,9 @@ const docTemplate = `{
                 "archivedBy": {
                     "type": "string"
                 },
+                "changeNotes": {
+                    "type": "string"
+                },
                 "contentStructure": {
                     "type": "array",
                     "items": {
@@ -11955,6 +12909,9 @@ const docTemplate = `{
                 "archivedBy": {
                     "type": "string"
                 },
+                "changeNotes": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -12031,6 +12988,9 @@ const docTemplate = `{
                 "archivedBy": {
                     "type": "string"
                 },
+                "changeNotes": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -12518,6 +13478,10 @@ const docTemplate = `{
                 "code": {
                     "type": "string"
                 },
+                "defaultTheme": {
+                    "description": "DefaultTheme is the design theme for renders that don't select one. Empty clears it; omitted leaves it unchanged.",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 255,
@@ -12558,6 +13522,72 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.ValidateContentRequest": {
+            "type": "object",
+            "required": [
+                "contentStructure"
+            ],
+            "properties": {
+                "contentStructure": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.VersionInjectableFieldResponse": {
+            "type": "object",
+            "properties": {
+                "defaultValue": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "propertyKey": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "roleId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceAssetResponse": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse": {
             "type": "object",
             "properties": {
@@ -12612,6 +13642,9 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "defaultTheme": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -12832,6 +13865,45 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_doc-assembly_core_internal_core_entity_portabledoc.Mark": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_doc-assembly_core_internal_core_entity_portabledoc.Node": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_core_entity_portabledoc.Node"
+                    }
+                },
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_doc-assembly_core_internal_core_entity_portabledoc.Mark"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_doc-assembly_core_internal_core_usecase_document.DocumentStatistics": {
             "type": "object",
             "properties": {
@@ -13007,7 +14079,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "step": {
-                    "description": "Step indicates the current signing flow step.\n\"preview\" = show form (Path B) or PDF preview (Path A)\n\"signing\" = show embedded signing iframe\n\"waiting\" = waiting for previous signers\n\"completed\" = signing completed\n\"declined\" = document was declined",
+                    "description": "Step indicates the current signing flow step.\n\"preview\" = show form (Path B) or PDF preview (Path A)\n\"processing\" = River is preparing/retrying/reconciling the active attempt\n\"signing\" = show embedded signing iframe\n\"waiting\" = waiting for previous signers\n\"completed\" = signing completed\n\"declined\" = document was declined\n\"document_updated\" = token points at a superseded/invalidated attempt\n\"unavailable\" = active attempt failed or requires review",
                     "type": "string"
                 },
                 "totalSigners": {
@@ -809,7 +809,7 @@ func (ctrl *AutomationController) updateVersion(c *gin.Context) {
 func (ctrl *AutomationController) publishVersion(c *gin.Context) {
 	versionID := c.Param("versionId")
 
-	if err := ctrl.templateVersionUC.PublishVersion(c.Request.Context(), versionID, ""); err != nil {
+	if err := ctrl.templateVersionUC.PublishVersion(c.Request.Context(), templateuc.PublishVersionCommand{VersionID: versionID}); err != nil {
 		HandleError(c, err)
 		return
 	}
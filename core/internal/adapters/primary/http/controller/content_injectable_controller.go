@@ -5,7 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	_ "github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto" // for swagger
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/mapper"
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
 	injectableuc "github.com/rendis/doc-assembly/core/internal/core/usecase/injectable"
@@ -39,8 +39,9 @@ func (c *ContentInjectableController) RegisterRoutes(rg *gin.RouterGroup, middle
 		// Injectable routes (read-only)
 		injectables := content.Group("/injectables")
 		{
-			injectables.GET("", c.ListInjectables)             // VIEWER+
-			injectables.GET("/:injectableId", c.GetInjectable) // VIEWER+
+			injectables.GET("", c.ListInjectables)               // VIEWER+
+			injectables.GET("/autocomplete", c.AutocompleteKeys) // VIEWER+
+			injectables.GET("/:injectableId", c.GetInjectable)   // VIEWER+
 		}
 	}
 }
@@ -68,6 +69,42 @@ func (c *ContentInjectableController) ListInjectables(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.injectableMapper.ToListResponse(result.Injectables, result.Groups))
 }
 
+// AutocompleteKeys lists injectable keys and signer roles for editor autocomplete.
+// Unlike the per-template injectables, this covers every definition known to the workspace
+// (database, system and provider) plus the signer role names used by its templates.
+// @Summary Autocomplete injectable keys and signer roles
+// @Tags Injectables
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param q query string false "Case-insensitive prefix matched against keys and labels"
+// @Param limit query int false "Maximum number of suggestions (1-200)" default(20)
+// @Success 200 {object} dto.InjectableAutocompleteResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/content/injectables/autocomplete [get]
+func (c *ContentInjectableController) AutocompleteKeys(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.InjectableAutocompleteRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	suggestions, err := c.injectableUC.Autocomplete(ctx.Request.Context(), &injectableuc.AutocompleteRequest{
+		WorkspaceID: workspaceID,
+		Prefix:      req.Query,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.injectableMapper.ToAutocompleteResponse(suggestions))
+}
+
 // GetInjectable retrieves an injectable by ID.
 // @Summary Get injectable
 // @Tags Injectables
@@ -128,6 +128,131 @@ func TestContentInjectableController_ListInjectables(t *testing.T) {
 	})
 }
 
+// =============================================================================
+// Injectable Autocomplete Tests
+// =============================================================================
+
+// TestContentInjectableController_Autocomplete tests the GET /content/injectables/autocomplete endpoint.
+func TestContentInjectableController_Autocomplete(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Injectable Autocomplete Tenant", "IACT01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Injectable Autocomplete Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	viewer := testhelper.CreateTestUser(t, pool, "viewer-inj-ac@test.com", "Viewer User", nil)
+	defer testhelper.CleanupUser(t, pool, viewer.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, viewer.ID, entity.WorkspaceRoleViewer, nil)
+
+	customerName := testhelper.CreateTestInjectable(t, pool, &workspaceID, "ac_customer_name", "Customer Name", entity.InjectableDataTypeText)
+	defer testhelper.CleanupInjectable(t, pool, customerName)
+	customerID := testhelper.CreateTestInjectable(t, pool, &workspaceID, "ac_customer_id", "Customer ID", entity.InjectableDataTypeText)
+	defer testhelper.CleanupInjectable(t, pool, customerID)
+	total := testhelper.CreateTestInjectable(t, pool, &workspaceID, "ac_total_amount", "Zum Total", entity.InjectableDataTypeNumber)
+	defer testhelper.CleanupInjectable(t, pool, total)
+
+	// Signer roles come from the workspace's template versions; duplicates collapse.
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Autocomplete Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+	v1 := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	v2 := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2", entity.VersionStatusDraft)
+	testhelper.CreateTestSignerRole(t, pool, v1, "ac_Client", "__sig_client__", 1)
+	testhelper.CreateTestSignerRole(t, pool, v1, "ac_Witness", "__sig_witness__", 2)
+	testhelper.CreateTestSignerRole(t, pool, v2, "ac_Client", "__sig_client__", 1)
+
+	autocomplete := func(t *testing.T, query string) dto.InjectableAutocompleteResponse {
+		t.Helper()
+		resp, body := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/injectables/autocomplete" + query)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var result dto.InjectableAutocompleteResponse
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, len(result.Items), result.Total)
+		return result
+	}
+
+	keysOf := func(items []*dto.InjectableAutocompleteItem, kind string) []string {
+		var keys []string
+		for _, item := range items {
+			if item.Kind == kind {
+				keys = append(keys, item.Key)
+			}
+		}
+		return keys
+	}
+
+	t.Run("filters injectables by key prefix", func(t *testing.T) {
+		result := autocomplete(t, "?q=ac_cust")
+
+		assert.Equal(t, []string{"ac_customer_id", "ac_customer_name"}, keysOf(result.Items, "injectable"))
+		assert.Empty(t, keysOf(result.Items, "role"))
+		assert.Equal(t, string(entity.InjectableDataTypeText), result.Items[0].DataType)
+		assert.Equal(t, "Customer ID", result.Items[0].Label["_"])
+	})
+
+	t.Run("matches label prefix case-insensitively", func(t *testing.T) {
+		result := autocomplete(t, "?q=zum")
+
+		assert.Equal(t, []string{"ac_total_amount"}, keysOf(result.Items, "injectable"))
+	})
+
+	t.Run("includes distinct signer roles after injectables", func(t *testing.T) {
+		result := autocomplete(t, "?q=AC_")
+
+		assert.Equal(t, []string{"ac_Client", "ac_Witness"}, keysOf(result.Items, "role"))
+		last := result.Items[len(result.Items)-1]
+		assert.Equal(t, "role", last.Kind)
+		assert.Empty(t, last.DataType)
+		assert.Contains(t, keysOf(result.Items, "injectable"), "ac_total_amount")
+	})
+
+	t.Run("filters roles by prefix", func(t *testing.T) {
+		result := autocomplete(t, "?q=ac_wit")
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "role", result.Items[0].Kind)
+		assert.Equal(t, "ac_Witness", result.Items[0].Key)
+	})
+
+	t.Run("limit is shared between injectables and roles", func(t *testing.T) {
+		result := autocomplete(t, "?q=ac_&limit=2")
+
+		assert.Equal(t, []string{"ac_customer_id"}, keysOf(result.Items, "injectable"))
+		assert.Equal(t, []string{"ac_Client"}, keysOf(result.Items, "role"))
+		assert.Equal(t, 2, result.Total)
+	})
+
+	t.Run("roles keep their share when injectable matches exceed the limit", func(t *testing.T) {
+		result := autocomplete(t, "?q=ac_&limit=3")
+
+		assert.Equal(t, []string{"ac_customer_id", "ac_customer_name"}, keysOf(result.Items, "injectable"))
+		assert.Equal(t, []string{"ac_Client"}, keysOf(result.Items, "role"))
+	})
+
+	t.Run("no match returns empty list", func(t *testing.T) {
+		result := autocomplete(t, "?q=does_not_exist")
+
+		assert.NotNil(t, result.Items)
+		assert.Zero(t, result.Total)
+	})
+
+	t.Run("bad request with invalid limit", func(t *testing.T) {
+		resp, _ := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/injectables/autocomplete?limit=0")
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 // =============================================================================
 // Injectable Get Tests
 // =============================================================================
@@ -41,6 +41,13 @@ func (c *ContentTemplateController) RegisterRoutes(rg *gin.RouterGroup, middlewa
 	content.Use(middlewareProvider.WorkspaceContext())
 	content.Use(middlewareProvider.SandboxContext()) // Sandbox support for templates
 	{
+		// Content lint (no persistence)
+		content.POST("/validate", middleware.RequireEditor(), c.versionController.ValidateContent) // EDITOR+
+
+		// Public library across the tenant's workspaces
+		content.GET("/library", c.ListPublicLibrary)                                               // VIEWER+
+		content.POST("/library/:templateId/clone", middleware.RequireEditor(), c.CloneFromLibrary) // EDITOR+
+
 		// Template routes
 		templates := content.Group("/templates")
 		{
@@ -107,6 +114,45 @@ func (c *ContentTemplateController) ListTemplates(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.templateMapper.ToListResponse(templates, filtersReq.Limit, filtersReq.Offset))
 }
 
+// ListPublicLibrary lists the published public library templates shared across the tenant.
+// @Summary List public library templates
+// @Description Lists published templates flagged as public library from every active workspace of the tenant, so they can be discovered and cloned.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param X-Sandbox-Mode header string false "Enable sandbox mode (operates on sandbox workspace)"
+// @Param folderId query string false "Filter by folder ID. Use 'root' to get only root-level templates (no folder)"
+// @Param tagIds query []string false "Filter by tag IDs"
+// @Param search query string false "Search by title"
+// @Param limit query int false "Limit results"
+// @Param offset query int false "Offset results"
+// @Success 200 {object} dto.ListTemplatesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/content/library [get]
+func (c *ContentTemplateController) ListPublicLibrary(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var filtersReq dto.TemplateFiltersRequest
+	if err := ctx.ShouldBindQuery(&filtersReq); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := c.templateMapper.ToFilters(&filtersReq)
+	templates, err := c.templateUC.ListPublicLibrary(ctx.Request.Context(), workspaceID, filters)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list public library",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.templateMapper.ToListResponse(templates, filtersReq.Limit, filtersReq.Offset))
+}
+
 // CreateTemplate creates a new template with an initial draft version.
 // @Summary Create template
 // @Tags Templates
@@ -275,6 +321,42 @@ func (c *ContentTemplateController) CloneTemplate(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, c.templateMapper.ToCreateResponse(template, version))
 }
 
+// CloneFromLibrary clones a public library template into the current workspace.
+// @Summary Clone template from public library
+// @Description Clones the published version of a public library template from any active workspace of the tenant into the current workspace. Tags are matched by name and workspace injectables by key, creating the missing ones in the current workspace.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param X-Sandbox-Mode header string false "Enable sandbox mode (operates on sandbox workspace)"
+// @Param templateId path string true "Library template ID"
+// @Param request body dto.CloneFromLibraryRequest true "Clone data"
+// @Success 201 {object} dto.TemplateCreateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Template not found in the public library"
+// @Failure 409 {object} dto.ErrorResponse "Template title already exists"
+// @Router /api/v1/content/library/{templateId}/clone [post]
+func (c *ContentTemplateController) CloneFromLibrary(ctx *gin.Context) {
+	templateID := ctx.Param("templateId")
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.CloneFromLibraryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.templateMapper.ToCloneFromLibraryCommand(templateID, workspaceID, &req, userID)
+	template, version, err := c.templateUC.CloneFromLibrary(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.templateMapper.ToCreateResponse(template, version))
+}
+
 // AddTemplateTags adds tags to a template.
 // @Summary Add tags to template
 // @Tags Templates
@@ -1778,3 +1778,247 @@ func TestContentTemplateController_CloneTemplate_PreservesTags(t *testing.T) {
 	// Verify tags were preserved
 	assert.GreaterOrEqual(t, len(tplResp.Tags), 2, "cloned template should have at least 2 tags")
 }
+
+// =============================================================================
+// Public Library Tests
+// =============================================================================
+
+// TestContentTemplateController_ListPublicLibrary tests the GET /content/library endpoint.
+func TestContentTemplateController_ListPublicLibrary(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	// Setup: tenant with two workspaces + another tenant
+	tenantID := testhelper.CreateTestTenant(t, pool, "Public Library Tenant", "PLBT01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Library Consumer Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	sourceWsID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Library Source Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, sourceWsID)
+
+	otherTenantID := testhelper.CreateTestTenant(t, pool, "Other Library Tenant", "PLBT02")
+	defer testhelper.CleanupTenant(t, pool, otherTenantID)
+
+	otherWsID := testhelper.CreateTestWorkspace(t, pool, &otherTenantID, "Other Tenant Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, otherWsID)
+
+	viewer := testhelper.CreateTestUser(t, pool, "viewer-tpl-library@test.com", "Viewer User", nil)
+	defer testhelper.CleanupUser(t, pool, viewer.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, viewer.ID, entity.WorkspaceRoleViewer, nil)
+
+	// Public template with a published version (listed)
+	publicTpl := testhelper.CreateTestTemplate(t, pool, sourceWsID, "Library Service Agreement", nil)
+	defer testhelper.CleanupTemplate(t, pool, publicTpl)
+	testhelper.CreateTestTemplateVersion(t, pool, publicTpl, 1, "v1.0", entity.VersionStatusPublished)
+	testhelper.SetTemplatePublicLibrary(t, pool, publicTpl, true)
+
+	// Private template with a published version (excluded)
+	privateTpl := testhelper.CreateTestTemplate(t, pool, sourceWsID, "Library Private Agreement", nil)
+	defer testhelper.CleanupTemplate(t, pool, privateTpl)
+	testhelper.CreateTestTemplateVersion(t, pool, privateTpl, 1, "v1.0", entity.VersionStatusPublished)
+
+	// Public template without a published version (excluded)
+	draftTpl := testhelper.CreateTestTemplate(t, pool, sourceWsID, "Library Draft Agreement", nil)
+	defer testhelper.CleanupTemplate(t, pool, draftTpl)
+	testhelper.CreateTestTemplateVersion(t, pool, draftTpl, 1, "v1.0", entity.VersionStatusDraft)
+	testhelper.SetTemplatePublicLibrary(t, pool, draftTpl, true)
+
+	// Public template in another tenant (excluded)
+	foreignTpl := testhelper.CreateTestTemplate(t, pool, otherWsID, "Library Foreign Agreement", nil)
+	defer testhelper.CleanupTemplate(t, pool, foreignTpl)
+	testhelper.CreateTestTemplateVersion(t, pool, foreignTpl, 1, "v1.0", entity.VersionStatusPublished)
+	testhelper.SetTemplatePublicLibrary(t, pool, foreignTpl, true)
+
+	listIDs := func(t *testing.T, path string) []string {
+		t.Helper()
+		resp, body := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET(path)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var listResp dto.ListTemplatesResponse
+		require.NoError(t, json.Unmarshal(body, &listResp))
+
+		ids := make([]string, 0, len(listResp.Items))
+		for _, item := range listResp.Items {
+			assert.True(t, item.IsPublicLibrary)
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	t.Run("lists published public templates with VIEWER", func(t *testing.T) {
+		ids := listIDs(t, "/api/v1/content/library")
+
+		assert.Contains(t, ids, publicTpl)
+		assert.NotContains(t, ids, privateTpl)
+		assert.NotContains(t, ids, draftTpl)
+		assert.NotContains(t, ids, foreignTpl)
+	})
+
+	t.Run("success with search filter", func(t *testing.T) {
+		assert.Contains(t, listIDs(t, "/api/v1/content/library?search=Service"), publicTpl)
+		assert.NotContains(t, listIDs(t, "/api/v1/content/library?search=Nonexistent"), publicTpl)
+	})
+
+	t.Run("excludes inactive workspaces", func(t *testing.T) {
+		testhelper.UpdateWorkspaceStatus(t, pool, sourceWsID, entity.WorkspaceStatusArchived)
+		defer testhelper.UpdateWorkspaceStatus(t, pool, sourceWsID, entity.WorkspaceStatusActive)
+
+		assert.NotContains(t, listIDs(t, "/api/v1/content/library"), publicTpl)
+	})
+
+	t.Run("unauthorized without token", func(t *testing.T) {
+		resp, _ := client.
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/library")
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+// TestContentTemplateController_CloneFromLibrary tests the POST /content/library/{templateId}/clone endpoint.
+func TestContentTemplateController_CloneFromLibrary(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Library Clone Tenant", "LBCL01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	sourceWsID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Library Clone Source", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, sourceWsID)
+
+	targetWsID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Library Clone Target", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, targetWsID)
+
+	editor := testhelper.CreateTestUser(t, pool, "editor-lib-clone@test.com", "Editor User", nil)
+	defer testhelper.CleanupUser(t, pool, editor.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, targetWsID, editor.ID, entity.WorkspaceRoleEditor, nil)
+
+	viewer := testhelper.CreateTestUser(t, pool, "viewer-lib-clone@test.com", "Viewer User", nil)
+	defer testhelper.CleanupUser(t, pool, viewer.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, targetWsID, viewer.ID, entity.WorkspaceRoleViewer, nil)
+
+	// Library template with a workspace injectable, a provider injectable and a tag
+	libraryTpl := testhelper.CreateTestTemplate(t, pool, sourceWsID, "Library Lease", nil)
+	defer testhelper.CleanupTemplate(t, pool, libraryTpl)
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, libraryTpl, 1, "v1.0", entity.VersionStatusPublished)
+	testhelper.SetTemplatePublicLibrary(t, pool, libraryTpl, true)
+
+	sourceInjectable := testhelper.CreateTestInjectable(t, pool, &sourceWsID, "lib_clone_tenant_name", "Tenant Name", entity.InjectableDataTypeText)
+	defer testhelper.CleanupInjectable(t, pool, sourceInjectable)
+	testhelper.CreateTestVersionInjectable(t, pool, versionID, sourceInjectable, true)
+
+	providerKey := "lib_clone_provider_code"
+	defer testhelper.CleanupSystemInjectableDefinition(t, pool, providerKey)
+	testhelper.CreateTestSystemVersionInjectable(t, pool, versionID, providerKey)
+
+	sourceTag := testhelper.CreateTestTag(t, pool, sourceWsID, "Leases", "#00AA00")
+	defer testhelper.CleanupTag(t, pool, sourceTag)
+	testhelper.CreateTestTemplateTag(t, pool, libraryTpl, sourceTag)
+
+	cloneFromLibrary := func(t *testing.T, title string) dto.TemplateCreateResponse {
+		t.Helper()
+		resp, body := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(targetWsID).
+			POST(fmt.Sprintf("/api/v1/content/library/%s/clone", libraryTpl), map[string]interface{}{
+				"newTitle": title,
+			})
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		var createResp dto.TemplateCreateResponse
+		require.NoError(t, json.Unmarshal(body, &createResp))
+		return createResp
+	}
+
+	t.Run("clones into the caller workspace with remapped injectables", func(t *testing.T) {
+		createResp := cloneFromLibrary(t, "Cloned Lease")
+		defer testhelper.CleanupTemplate(t, pool, createResp.Template.ID)
+
+		assert.Equal(t, targetWsID, createResp.Template.WorkspaceID)
+		assert.False(t, createResp.Template.IsPublicLibrary)
+
+		// Workspace injectable is copied into the target workspace
+		var targetInjectable string
+		err := pool.QueryRow(ctx, `
+			SELECT tvi.injectable_definition_id
+			FROM content.template_version_injectables tvi
+			JOIN content.injectable_definitions d ON d.id = tvi.injectable_definition_id
+			WHERE tvi.template_version_id = $1 AND d.key = $2 AND d.workspace_id = $3`,
+			createResp.InitialVersion.ID, "lib_clone_tenant_name", targetWsID,
+		).Scan(&targetInjectable)
+		require.NoError(t, err)
+		assert.NotEqual(t, sourceInjectable, targetInjectable)
+
+		// Provider injectable keeps its key, backed by its definition
+		var providerRefs int
+		err = pool.QueryRow(ctx, `
+			SELECT COUNT(*) FROM content.template_version_injectables
+			WHERE template_version_id = $1 AND system_injectable_key = $2`,
+			createResp.InitialVersion.ID, providerKey,
+		).Scan(&providerRefs)
+		require.NoError(t, err)
+		assert.Equal(t, 1, providerRefs)
+
+		// Tag is recreated by name in the target workspace
+		var targetTagWs string
+		err = pool.QueryRow(ctx, `
+			SELECT tg.workspace_id FROM content.template_tags tt
+			JOIN organizer.tags tg ON tg.id = tt.tag_id
+			WHERE tt.template_id = $1 AND tg.name = 'Leases'`,
+			createResp.Template.ID,
+		).Scan(&targetTagWs)
+		require.NoError(t, err)
+		assert.Equal(t, targetWsID, targetTagWs)
+	})
+
+	t.Run("cloning again reuses the target injectable and definitions", func(t *testing.T) {
+		first := cloneFromLibrary(t, "Cloned Lease A")
+		defer testhelper.CleanupTemplate(t, pool, first.Template.ID)
+		second := cloneFromLibrary(t, "Cloned Lease B")
+		defer testhelper.CleanupTemplate(t, pool, second.Template.ID)
+
+		var copies int
+		err := pool.QueryRow(ctx, `
+			SELECT COUNT(*) FROM content.injectable_definitions
+			WHERE workspace_id = $1 AND key = $2`,
+			targetWsID, "lib_clone_tenant_name",
+		).Scan(&copies)
+		require.NoError(t, err)
+		assert.Equal(t, 1, copies)
+	})
+
+	t.Run("not found for private template", func(t *testing.T) {
+		privateTpl := testhelper.CreateTestTemplate(t, pool, sourceWsID, "Private Lease", nil)
+		defer testhelper.CleanupTemplate(t, pool, privateTpl)
+		testhelper.CreateTestTemplateVersion(t, pool, privateTpl, 1, "v1.0", entity.VersionStatusPublished)
+
+		resp, _ := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(targetWsID).
+			POST(fmt.Sprintf("/api/v1/content/library/%s/clone", privateTpl), map[string]interface{}{
+				"newTitle": "Cloned Private Lease",
+			})
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("forbidden for VIEWER", func(t *testing.T) {
+		resp, _ := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(targetWsID).
+			POST(fmt.Sprintf("/api/v1/content/library/%s/clone", libraryTpl), map[string]interface{}{
+				"newTitle": "Viewer Lease",
+			})
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
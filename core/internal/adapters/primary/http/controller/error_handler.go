@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,122 +9,30 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
-	galleryuc "github.com/rendis/doc-assembly/core/internal/core/usecase/gallery"
 )
 
+// The status lists hold error categories (see entity.ErrNotFound); domain errors
+// match their category with errors.Is.
 var notFoundErrors = []error{
-	entity.ErrGalleryAssetNotFound,
-	entity.ErrInjectableNotFound,
-	entity.ErrTemplateNotFound,
-	entity.ErrTagNotFound,
-	entity.ErrVersionNotFound,
-	entity.ErrSignerRoleNotFound,
-	entity.ErrVersionInjectableNotFound,
-	entity.ErrWorkspaceNotFound,
-	entity.ErrFolderNotFound,
-	entity.ErrUserNotFound,
-	entity.ErrMemberNotFound,
-	entity.ErrTenantNotFound,
-	entity.ErrTenantMemberNotFound,
-	entity.ErrSystemRoleNotFound,
-	entity.ErrDocumentTypeNotFound,
-	entity.ErrDocumentNotFound,
-	entity.ErrSigningAttemptNotFound,
-	entity.ErrProcessNotFound,
-	entity.ErrAPIKeyNotFound,
-	entity.ErrInternalTemplateResolutionNotFound,
+	entity.ErrNotFound,
 }
 
 var conflictErrors = []error{
-	entity.ErrInjectableAlreadyExists,
-	entity.ErrTemplateAlreadyExists,
-	entity.ErrVersionAlreadyExists,
-	entity.ErrVersionNameExists,
-	entity.ErrDuplicateSignerAnchor,
-	entity.ErrDuplicateSignerOrder,
-	entity.ErrWorkspaceAlreadyExists,
-	entity.ErrWorkspaceCodeExists,
-	entity.ErrFolderAlreadyExists,
-	entity.ErrTagAlreadyExists,
-	entity.ErrSystemWorkspaceExists,
-	entity.ErrMemberAlreadyExists,
-	entity.ErrTenantAlreadyExists,
-	entity.ErrGlobalWorkspaceExists,
-	entity.ErrTenantMemberExists,
-	entity.ErrScheduledTimeConflict,
-	entity.ErrDocumentTypeCodeExists,
-	entity.ErrDocumentTypeAlreadyAssigned,
-	entity.ErrProcessCodeExists,
-	entity.ErrInvalidDocumentState,
+	entity.ErrConflict,
 }
 
 var badRequestErrors = []error{
-	galleryuc.ErrQueryRequired,
-	galleryuc.ErrAssetKeyRequired,
-	galleryuc.ErrUploadEmpty,
-	entity.ErrGalleryInvalidContentType,
-	entity.ErrGalleryFileTooLarge,
-	entity.ErrInjectableInUse,
-	entity.ErrNoPublishedVersion,
-	entity.ErrInvalidInjectableKey,
-	entity.ErrRequiredField,
-	entity.ErrFieldTooLong,
-	entity.ErrInvalidDataType,
-	entity.ErrCannotEditPublished,
-	entity.ErrCannotEditArchived,
-	entity.ErrVersionNotPublished,
-	entity.ErrVersionAlreadyPublished,
-	entity.ErrCannotArchiveWithoutReplacement,
-	entity.ErrInvalidVersionStatus,
-	entity.ErrInvalidVersionNumber,
-	entity.ErrScheduledTimeInPast,
-	entity.ErrInvalidSignerRole,
-	entity.ErrFolderHasChildren,
-	entity.ErrFolderHasTemplates,
-	entity.ErrTagInUse,
-	entity.ErrCircularReference,
-	entity.ErrCannotArchiveSystem,
-	entity.ErrInvalidParentFolder,
-	entity.ErrCannotRemoveOwner,
-	entity.ErrCannotRemoveLastSystemMember,
-	entity.ErrInvalidRole,
-	entity.ErrInvalidTenantCode,
-	entity.ErrInvalidWorkspaceType,
-	entity.ErrInvalidWorkspaceCode,
-	entity.ErrInvalidSystemRole,
-	entity.ErrMissingTenantID,
-	entity.ErrCannotRemoveTenantOwner,
-	entity.ErrInvalidTenantRole,
-	entity.ErrVersionDoesNotBelongToTemplate,
-	entity.ErrTargetTemplateRequired,
-	entity.ErrTargetTemplateNotInWorkspace,
-	entity.ErrOnlyTextTypeAllowed,
-	entity.ErrWorkspaceIDRequired,
-	entity.ErrCannotModifyGlobal,
-	entity.ErrDocumentTypeCodeImmutable,
-	entity.ErrDocumentTypeHasTemplates,
-	entity.ErrProcessHasTemplates,
-	entity.ErrCannotModifyGlobalProcess,
-	entity.ErrCannotDeleteDefaultProcess,
-	entity.ErrInvalidOperationType,
-	entity.ErrDocumentNotCompleted,
-	entity.ErrDocumentNotTerminal,
-	entity.ErrRelatedDocumentRequired,
-	entity.ErrRelatedDocumentSameWorkspace,
+	entity.ErrValidation,
 }
 
 var forbiddenErrors = []error{
-	entity.ErrWorkspaceAccessDenied,
 	entity.ErrForbidden,
-	entity.ErrInsufficientRole,
-	entity.ErrTenantAccessDenied,
 }
 
 var unauthorizedErrors = []error{
 	entity.ErrUnauthorized,
-	entity.ErrMissingAPIKey,
-	entity.ErrInvalidAPIKey,
 }
 
 var tooManyRequestErrors = []error{
@@ -135,9 +44,15 @@ var unavailableErrors = []error{
 	entity.ErrRendererBusy,
 }
 
+// statusClientClosedRequest is reported when the client disconnected before the
+// response was ready (nginx's 499); nobody reads it, but it keeps access logs honest.
+const statusClientClosedRequest = 499
+
 // respondError sends an error response.
 func respondError(ctx *gin.Context, statusCode int, err error) {
-	ctx.JSON(statusCode, dto.NewErrorResponse(err))
+	resp := dto.NewErrorResponse(err)
+	resp.RequestID = middleware.GetOperationID(ctx)
+	ctx.JSON(statusCode, resp)
 }
 
 // HandleError maps domain errors to HTTP status codes.
@@ -165,6 +80,16 @@ func HandleError(ctx *gin.Context, err error) {
 		return
 	}
 
+	// The client went away mid-request (e.g. closed a render preview); not a server fault
+	if errors.Is(err, context.Canceled) {
+		slog.InfoContext(ctx.Request.Context(), "request canceled by client",
+			slog.String("error", err.Error()),
+			slog.String("path", ctx.Request.URL.Path),
+		)
+		respondError(ctx, statusClientClosedRequest, err)
+		return
+	}
+
 	statusCode := mapErrorToStatusCode(err)
 	//nolint:staticcheck
 	switch {
@@ -196,6 +121,8 @@ func mapErrorToStatusCode(err error) int {
 		return http.StatusTooManyRequests
 	case is503Error(err):
 		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
 	default:
 		return http.StatusInternalServerError
 	}
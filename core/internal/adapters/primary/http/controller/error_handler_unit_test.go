@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	galleryuc "github.com/rendis/doc-assembly/core/internal/core/usecase/gallery"
+)
+
+func TestHandleError_MapsCategoriesToStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found category", entity.ErrNotFound, http.StatusNotFound},
+		{"not found domain error", entity.ErrTemplateNotFound, http.StatusNotFound},
+		{"wrapped not found", fmt.Errorf("loading template: %w", entity.ErrVersionNotFound), http.StatusNotFound},
+		{"validation category", entity.ErrValidation, http.StatusBadRequest},
+		{"validation domain error", entity.ErrInvalidTenantCode, http.StatusBadRequest},
+		{"validation usecase error", galleryuc.ErrQueryRequired, http.StatusBadRequest},
+		{"validation failed alias", entity.ErrValidationFailed, http.StatusBadRequest},
+		{"invalid uuid", entity.ErrInvalidUUID, http.StatusBadRequest},
+		{"content required for publishing", entity.ErrMissingRequiredContent, http.StatusBadRequest},
+		{"sandbox not found", entity.ErrSandboxNotFound, http.StatusNotFound},
+		{"recipient not found", entity.ErrDocumentRecipientNotFound, http.StatusNotFound},
+		{"conflict", entity.ErrTemplateAlreadyExists, http.StatusConflict},
+		{"user already exists", entity.ErrUserAlreadyExists, http.StatusConflict},
+		{"process slot conflict", entity.ErrProcessSlotConflict, http.StatusConflict},
+		{"unauthorized", entity.ErrUnauthorized, http.StatusUnauthorized},
+		{"unauthorized domain error", entity.ErrTokenExpired, http.StatusUnauthorized},
+		{"forbidden", entity.ErrWorkspaceAccessDenied, http.StatusForbidden},
+		{"render failed", entity.WithCategory(entity.ErrRenderFailed, errors.New("typst exited 1")), http.StatusInternalServerError},
+		{"extension error", fmt.Errorf("%w: client 42", entity.ErrNotFound), http.StatusNotFound},
+		{"uncategorized", errors.New("boom"), http.StatusInternalServerError},
+		{"client canceled", fmt.Errorf("typst compile aborted: %w", context.Canceled), statusClientClosedRequest},
+		{"deadline exceeded", fmt.Errorf("downloading logo: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			HandleError(ctx, tt.err)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	assert.ErrorIs(t, entity.ErrTemplateNotFound, entity.ErrNotFound)
+	assert.NotErrorIs(t, entity.ErrTemplateNotFound, entity.ErrValidation)
+	assert.ErrorIs(t, entity.ErrTemplateNotFound, entity.ErrTemplateNotFound)
+	assert.NotErrorIs(t, entity.ErrVersionNotFound, entity.ErrTemplateNotFound)
+	assert.Equal(t, "template not found", entity.ErrTemplateNotFound.Error(), "categories must not change messages")
+
+	render := entity.WithCategory(entity.ErrRenderFailed, fmt.Errorf("failed to generate PDF: %w", context.DeadlineExceeded))
+	assert.ErrorIs(t, render, entity.ErrRenderFailed)
+	assert.ErrorIs(t, render, context.DeadlineExceeded)
+}
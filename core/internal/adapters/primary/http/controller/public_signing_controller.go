@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	documentuc "github.com/rendis/doc-assembly/core/internal/core/usecase/document"
 )
@@ -33,18 +34,22 @@ type PublicSigningController struct {
 	preSigningUC documentuc.PreSigningUseCase
 	accessUC     documentuc.DocumentAccessUseCase
 	publicURL    string
+	rateLimiter  *middleware.RenderRateLimiter
 }
 
 // NewPublicSigningController creates a new public signing controller.
+// rateLimiter may be nil to disable rate limiting of the preview PDF.
 func NewPublicSigningController(
 	preSigningUC documentuc.PreSigningUseCase,
 	accessUC documentuc.DocumentAccessUseCase,
 	publicURL string,
+	rateLimiter *middleware.RenderRateLimiter,
 ) *PublicSigningController {
 	return &PublicSigningController{
 		preSigningUC: preSigningUC,
 		accessUC:     accessUC,
 		publicURL:    publicURL,
+		rateLimiter:  rateLimiter,
 	}
 }
 
@@ -58,7 +63,7 @@ func (c *PublicSigningController) RegisterRoutes(router gin.IRouter) {
 		public.POST("/:token/request-access", c.RequestAccessFromToken)
 		public.POST("/:token/proceed", c.ProceedToSigning)
 		public.POST("/:token/complete", c.CompleteEmbeddedSigning)
-		public.GET("/:token/pdf", c.RenderPreviewPDF)
+		public.GET("/:token/pdf", c.rateLimiter.Handler(), c.RenderPreviewPDF)
 		public.GET("/:token/download", c.DownloadCompletedPDF)
 		public.GET("/:token/refresh", c.RefreshEmbeddedURL)
 		public.GET("/:token/signing-callback", c.SigningCallback)
@@ -185,6 +190,7 @@ func (c *PublicSigningController) ProceedToSigning(ctx *gin.Context) {
 // @Param token path string true "Access token"
 // @Success 200 {file} binary
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /public/sign/{token}/pdf [get]
 func (c *PublicSigningController) RenderPreviewPDF(ctx *gin.Context) {
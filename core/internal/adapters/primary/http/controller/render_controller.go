@@ -1,49 +1,61 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/mapper"
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
-	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
+	renderinguc "github.com/rendis/doc-assembly/core/internal/core/usecase/rendering"
 	templateuc "github.com/rendis/doc-assembly/core/internal/core/usecase/template"
+	"github.com/rendis/doc-assembly/core/internal/infra/tracing"
 )
 
 // RenderController handles document rendering HTTP requests.
 type RenderController struct {
 	versionUC   templateuc.TemplateVersionUseCase
-	pdfRenderer port.PDFRenderer
+	renderUC    renderinguc.RenderUseCase
+	rateLimiter *middleware.RenderRateLimiter
 }
 
 // NewRenderController creates a new render controller.
+// rateLimiter may be nil to disable render rate limiting.
 func NewRenderController(
 	versionUC templateuc.TemplateVersionUseCase,
-	pdfRenderer port.PDFRenderer,
+	renderUC renderinguc.RenderUseCase,
+	rateLimiter *middleware.RenderRateLimiter,
 ) *RenderController {
 	return &RenderController{
 		versionUC:   versionUC,
-		pdfRenderer: pdfRenderer,
+		renderUC:    renderUC,
+		rateLimiter: rateLimiter,
 	}
 }
 
 // RegisterRoutes registers all render routes.
 // These routes are nested under /content/templates/:templateId/versions/:versionId
 func (c *RenderController) RegisterRoutes(versions *gin.RouterGroup) {
-	// Preview route requires EDITOR+ role
-	versions.POST("/:versionId/preview", middleware.RequireEditor(), c.PreviewVersion)
+	// Preview route requires EDITOR+ role and is rate limited per IP and workspace
+	versions.POST("/:versionId/preview", middleware.RequireEditor(), c.rateLimiter.Handler(), c.PreviewVersion)
+	versions.POST("/:versionId/publish-preview", middleware.RequireEditor(), c.rateLimiter.Handler(), c.PreviewPublish)
 }
 
 // PreviewVersion generates a preview PDF for a template version.
 // @Summary Generate preview PDF
+// @Description Renders the version to PDF. With image set, returns a single page as PNG instead (e.g. for thumbnails). With extractSignaturePages set, returns JSON with the PDF and its signature pages as a separate PDF, base64-encoded.
 // @Tags Template Versions
 // @Accept json
-// @Produce application/pdf
+// @Produce application/pdf,image/png,json
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param templateId path string true "Template ID"
 // @Param versionId path string true "Version ID"
@@ -51,10 +63,21 @@ func (c *RenderController) RegisterRoutes(versions *gin.RouterGroup) {
 // @Success 200 {file} application/pdf
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
 // @Router /api/v1/content/templates/{templateId}/versions/{versionId}/preview [post]
 func (c *RenderController) PreviewVersion(ctx *gin.Context) {
 	versionID := ctx.Param("versionId")
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	spanCtx, span := tracing.Start(ctx.Request.Context(), "render.preview",
+		attribute.String("workspace.id", workspaceID),
+		attribute.String("template.id", ctx.Param("templateId")),
+		attribute.String("version.id", versionID),
+	)
+	defer span.End()
+	ctx.Request = ctx.Request.WithContext(spanCtx)
 
 	// Parse request body
 	var req dto.RenderPreviewRequest
@@ -67,44 +90,47 @@ func (c *RenderController) PreviewVersion(ctx *gin.Context) {
 		req.Injectables = make(map[string]any)
 	}
 
-	// Get version with details
-	details, err := c.versionUC.GetVersionWithDetails(ctx.Request.Context(), versionID)
-	if err != nil {
-		HandleError(ctx, err)
-		return
-	}
-
-	// Parse content structure into portable document
-	doc, err := portabledoc.Parse(details.ContentStructure)
+	result, err := c.renderUC.RenderVersion(ctx.Request.Context(), renderinguc.RenderVersionCmd{
+		VersionID:   versionID,
+		WorkspaceID: workspaceID,
+		Environment: middleware.GetEnvironment(ctx),
+		Injectables: req.Injectables,
+		Options: renderinguc.RenderOptions{
+			Slots:                  req.Slots,
+			BlockIndex:             req.BlockIndex,
+			BlockID:                req.BlockID,
+			DraftMode:              req.DraftMode,
+			ShowPlaceholders:       req.ShowPlaceholders,
+			ShowHiddenConditionals: req.ShowHiddenConditionals,
+			Timezone:               req.Timezone,
+			PDFA:                   req.PDFA,
+			Theme:                  req.Theme,
+			Encryption:             toPDFEncryption(req.Encryption),
+			Image:                  toImageOptions(req.Image),
+			ExtractSignaturePages:  req.ExtractSignaturePages,
+		},
+	})
 	if err != nil {
-		slog.ErrorContext(ctx.Request.Context(), "failed to parse content structure",
-			slog.String("version_id", versionID),
-			slog.Any("error", err),
-		)
-		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("invalid content structure"))
+		c.respondRenderError(ctx, versionID, err)
 		return
 	}
 
-	if doc == nil {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("version has no content"))
+	if result.Image != nil {
+		ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", result.Filename))
+		ctx.Data(http.StatusOK, "image/png", result.Image)
 		return
 	}
 
-	// Build injectable defaults map from version injectables
-	injectableDefaults := buildInjectableDefaults(details.Injectables)
-
-	// Render PDF
-	result, err := c.pdfRenderer.RenderPreview(ctx.Request.Context(), &port.RenderPreviewRequest{
-		Document:           doc,
-		Injectables:        req.Injectables,
-		InjectableDefaults: injectableDefaults,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx.Request.Context(), "failed to render PDF",
-			slog.String("version_id", versionID),
-			slog.Any("error", err),
-		)
-		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate PDF"))
+	if req.ExtractSignaturePages {
+		signaturePages := result.SignaturePages
+		if signaturePages == nil {
+			signaturePages = []int{}
+		}
+		ctx.JSON(http.StatusOK, dto.RenderSignaturePagesResponse{
+			PDF:               result.PDF,
+			SignaturePages:    signaturePages,
+			SignaturePagesPDF: result.SignaturePagesPDF,
+		})
 		return
 	}
 
@@ -117,33 +143,124 @@ func (c *RenderController) PreviewVersion(ctx *gin.Context) {
 	ctx.Data(http.StatusOK, "application/pdf", result.PDF)
 }
 
-// buildInjectableDefaults builds a map of default values from version injectables.
-// Priority: TemplateVersionInjectable.DefaultValue > InjectableDefinition.DefaultValue
-func buildInjectableDefaults(injectables []*entity.VersionInjectableWithDefinition) map[string]string {
-	defaults := make(map[string]string)
-
-	for _, injectable := range injectables {
-		// Get the variable ID (either from definition key or system key)
-		var variableID string
-		if injectable.Definition != nil {
-			variableID = injectable.Definition.Key
-		} else if injectable.SystemInjectableKey != nil {
-			variableID = *injectable.SystemInjectableKey
-		} else {
-			continue
-		}
+// PreviewPublish shows what publishing a version would change relative to the published version.
+// @Summary Preview publish changes
+// @Description Compares the version with the template's currently published version and returns a structured change summary. Set includePdfs to also receive both rendered PDFs, base64-encoded.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.PublishPreviewRequest false "Preview options"
+// @Success 200 {object} dto.PublishPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/publish-preview [post]
+func (c *RenderController) PreviewPublish(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
 
-		// First, try template version specific default
-		if injectable.DefaultValue != nil && *injectable.DefaultValue != "" {
-			defaults[variableID] = *injectable.DefaultValue
-			continue
+	var req dto.PublishPreviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.versionUC.PreviewPublish(ctx.Request.Context(), versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	resp := mapper.PublishPreviewToResponse(result)
+	if req.IncludePDFs {
+		if resp.VersionPDF, err = c.renderVersionPDF(ctx, result.Version, req.Injectables); err != nil {
+			c.respondRenderError(ctx, versionID, err)
+			return
+		}
+		if result.Published != nil {
+			if resp.PublishedPDF, err = c.renderVersionPDF(ctx, result.Published, req.Injectables); err != nil {
+				c.respondRenderError(ctx, result.Published.ID, err)
+				return
+			}
 		}
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// renderVersionPDF renders a version with the given injectable values and its defaults.
+// Versions without content render no PDF.
+func (c *RenderController) renderVersionPDF(
+	ctx *gin.Context,
+	details *entity.TemplateVersionWithDetails,
+	injectables map[string]any,
+) ([]byte, error) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	result, err := c.renderUC.RenderVersion(ctx.Request.Context(), renderinguc.RenderVersionCmd{
+		VersionID:   details.ID,
+		WorkspaceID: workspaceID,
+		Environment: middleware.GetEnvironment(ctx),
+		Injectables: injectables,
+	})
+	if errors.Is(err, entity.ErrVersionHasNoContent) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.PDF, nil
+}
 
-		// Fallback to injectable definition default
-		if injectable.Definition != nil && injectable.Definition.DefaultValue != nil && *injectable.Definition.DefaultValue != "" {
-			defaults[variableID] = *injectable.Definition.DefaultValue
+// respondRenderError reports request-caused render errors as-is and anything else as a generic 500.
+func (c *RenderController) respondRenderError(ctx *gin.Context, versionID string, err error) {
+	if isRenderRequestError(err) {
+		HandleError(ctx, err)
+		return
+	}
+	slog.ErrorContext(ctx.Request.Context(), "failed to render PDF",
+		slog.String("version_id", versionID),
+		slog.Any("error", err),
+	)
+	respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate PDF"))
+}
+
+// renderRequestErrors are render failures caused by the request, its deadline or
+// capacity, reported as-is; any other render error is a generic 500.
+var renderRequestErrors = []error{
+	entity.ErrNotFound,
+	entity.ErrValidation,
+	entity.ErrRendererBusy,
+	context.Canceled,
+	context.DeadlineExceeded,
+}
+
+func isRenderRequestError(err error) bool {
+	for _, target := range renderRequestErrors {
+		if errors.Is(err, target) {
+			return true
 		}
 	}
+	return false
+}
+
+func toPDFEncryption(req *dto.PDFEncryptionRequest) *port.PDFEncryption {
+	if req == nil {
+		return nil
+	}
+	return &port.PDFEncryption{
+		UserPassword:  req.UserPassword,
+		OwnerPassword: req.OwnerPassword,
+		NoPrint:       req.NoPrint,
+		NoCopy:        req.NoCopy,
+	}
+}
 
-	return defaults
+func toImageOptions(req *dto.RenderImageRequest) *port.ImageOptions {
+	if req == nil {
+		return nil
+	}
+	return &port.ImageOptions{Page: req.Page, PPI: req.PPI}
 }
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	"github.com/rendis/doc-assembly/core/internal/core/service/rendering/pdfrenderer"
+	renderinguc "github.com/rendis/doc-assembly/core/internal/core/usecase/rendering"
+	"github.com/rendis/doc-assembly/core/internal/infra/logging"
+)
+
+type fakeRenderUseCase struct {
+	render   func(ctx context.Context) (*port.RenderPreviewResult, error)
+	received []renderinguc.RenderVersionCmd
+}
+
+func (f *fakeRenderUseCase) RenderVersion(ctx context.Context, cmd renderinguc.RenderVersionCmd) (*port.RenderPreviewResult, error) {
+	f.received = append(f.received, cmd)
+	return f.render(ctx)
+}
+
+func (f *fakeRenderUseCase) RenderDocument(ctx context.Context, _ renderinguc.RenderDocumentCmd) (*port.RenderPreviewResult, error) {
+	return f.render(ctx)
+}
+
+// servePreview posts body to a RenderController's preview route backed by renderUC.
+func servePreview(renderUC renderinguc.RenderUseCase, body string, header http.Header) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/versions/:versionId/preview", middleware.Operation(), NewRenderController(nil, renderUC, nil).PreviewVersion)
+
+	req := httptest.NewRequest(http.MethodPost, "/versions/v1/preview", strings.NewReader(body))
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// captureLogs routes the default logger through the context handler into a buffer.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil))))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func TestRenderPipeline_RequestIDInLogsAndErrorResponse(t *testing.T) {
+	logs := captureLogs(t)
+
+	imageServer := httptest.NewServer(http.NotFoundHandler())
+	defer imageServer.Close()
+	imageCache, err := pdfrenderer.NewImageCache(pdfrenderer.ImageCacheOptions{Dir: t.TempDir(), CleanupInterval: time.Hour})
+	require.NoError(t, err)
+	defer imageCache.Close()
+
+	// The renderer fetches an image, which fails and logs, then fails itself.
+	renderUC := &fakeRenderUseCase{render: func(ctx context.Context) (*port.RenderPreviewResult, error) {
+		imageCache.ResolveImages(ctx, map[string]string{imageServer.URL + "/logo.png": "logo.png"}, imageServer.Client())
+		return nil, errors.New("typst exited with status 1")
+	}}
+
+	w := servePreview(renderUC, `{}`, http.Header{middleware.OperationIDHeader: {"op-123"}})
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "op-123", resp.RequestID)
+
+	records := map[string]map[string]any{}
+	for line := range strings.Lines(logs.String()) {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if msg, _ := record["msg"].(string); msg != "" {
+			records[msg] = record
+		}
+	}
+	fetchLog := records["failed to download image, using placeholder"]
+	require.NotNil(t, fetchLog, "image fetch failure should be logged")
+	assert.Equal(t, "op-123", fetchLog["operation_id"])
+	renderLog := records["failed to render PDF"]
+	require.NotNil(t, renderLog, "render failure should be logged")
+	assert.Equal(t, "op-123", renderLog["operation_id"])
+	assert.Equal(t, "v1", renderLog["version_id"])
+}
+
+func TestPreviewVersion_ExtractSignaturePages(t *testing.T) {
+	renderUC := &fakeRenderUseCase{render: func(context.Context) (*port.RenderPreviewResult, error) {
+		return &port.RenderPreviewResult{
+			PDF:               []byte("%PDF-full"),
+			Filename:          "doc.pdf",
+			SignaturePages:    []int{3},
+			SignaturePagesPDF: []byte("%PDF-signatures"),
+		}, nil
+	}}
+
+	w := servePreview(renderUC, `{"extractSignaturePages":true}`, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, renderUC.received, 1)
+	assert.True(t, renderUC.received[0].Options.ExtractSignaturePages)
+	var resp dto.RenderSignaturePagesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []byte("%PDF-full"), resp.PDF)
+	assert.Equal(t, []int{3}, resp.SignaturePages)
+	assert.Equal(t, []byte("%PDF-signatures"), resp.SignaturePagesPDF)
+}
+
+func TestPreviewVersion_ReturnsPDFWithoutSignaturePages(t *testing.T) {
+	renderUC := &fakeRenderUseCase{render: func(context.Context) (*port.RenderPreviewResult, error) {
+		return &port.RenderPreviewResult{PDF: []byte("%PDF-full"), Filename: "doc.pdf"}, nil
+	}}
+
+	w := servePreview(renderUC, `{}`, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "%PDF-full", w.Body.String())
+}
+
+func TestPreviewVersion_ContextErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"client went away", context.Canceled, statusClientClosedRequest},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderUC := &fakeRenderUseCase{render: func(context.Context) (*port.RenderPreviewResult, error) {
+				return nil, fmt.Errorf("compiling: %w", tt.err)
+			}}
+
+			w := servePreview(renderUC, `{}`, nil)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.NotContains(t, w.Body.String(), "failed to generate PDF")
+		})
+	}
+}
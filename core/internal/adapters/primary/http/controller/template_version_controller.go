@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -55,6 +57,7 @@ func (c *TemplateVersionController) RegisterRoutes(templates *gin.RouterGroup) {
 		versions.DELETE("/:versionId/schedule", middleware.RequireAdmin(), c.CancelSchedule)
 
 		// Injectables - EDITOR+
+		versions.GET("/:versionId/injectables", c.ListVersionInjectables) // VIEWER+
 		versions.POST("/:versionId/injectables", middleware.RequireEditor(), c.AddInjectable)
 		versions.DELETE("/:versionId/injectables/:injectableId", middleware.RequireEditor(), c.RemoveInjectable)
 
@@ -255,6 +258,7 @@ func (c *TemplateVersionController) DeleteVersion(ctx *gin.Context) {
 // @Param X-Sandbox-Mode header string false "Enable sandbox mode (operates on sandbox workspace)"
 // @Param templateId path string true "Template ID"
 // @Param versionId path string true "Version ID"
+// @Param request body dto.PublishVersionRequest false "Optional change notes"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
@@ -263,7 +267,15 @@ func (c *TemplateVersionController) PublishVersion(ctx *gin.Context) {
 	versionID := ctx.Param("versionId")
 	userID, _ := middleware.GetInternalUserID(ctx)
 
-	if err := c.versionUC.PublishVersion(ctx.Request.Context(), versionID, userID); err != nil {
+	// The body is optional: publishing without change notes sends none
+	var req dto.PublishVersionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.versionMapper.ToPublishCommand(versionID, &req, userID)
+	if err := c.versionUC.PublishVersion(ctx.Request.Context(), cmd); err != nil {
 		HandleError(ctx, err)
 		return
 	}
@@ -296,6 +308,33 @@ func (c *TemplateVersionController) ArchiveVersion(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// ValidateContent lints a document tree without saving or rendering it.
+// @Summary Validate content structure
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param X-Sandbox-Mode header string false "Enable sandbox mode (operates on sandbox workspace)"
+// @Param request body dto.ValidateContentRequest true "Content structure"
+// @Success 200 {object} dto.ContentValidationResultDTO
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/content/validate [post]
+func (c *TemplateVersionController) ValidateContent(ctx *gin.Context) {
+	var req dto.ValidateContentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := c.versionUC.ValidateContent(ctx.Request.Context(), req.ContentStructure)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewContentValidationResultDTO(report))
+}
+
 // SchedulePublish schedules a version for future publication.
 // @Summary Schedule version publication
 // @Tags Template Versions
@@ -386,6 +425,32 @@ func (c *TemplateVersionController) CancelSchedule(ctx *gin.Context) {
 
 // --- Injectable Handlers ---
 
+// ListVersionInjectables lists the injectables referenced in a version's content.
+// @Summary List injectables used by a version
+// @Description Walks the version content and returns each referenced injectable with its type, label, default value, required flag and role binding, for form generation.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param X-Sandbox-Mode header string false "Enable sandbox mode (operates on sandbox workspace)"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {object} dto.ListVersionInjectableFieldsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/injectables [get]
+func (c *TemplateVersionController) ListVersionInjectables(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+
+	fields, err := c.versionUC.ListInjectableFields(ctx.Request.Context(), versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.InjectableFieldsToResponse(fields))
+}
+
 // AddInjectable adds an injectable to a version.
 // @Summary Add injectable to version
 // @Tags Template Versions
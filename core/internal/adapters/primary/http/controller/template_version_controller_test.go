@@ -3,8 +3,11 @@
 package controller_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	outboxrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/outbox_repo"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
 )
 
@@ -368,7 +373,7 @@ func TestTemplateVersionController_PublishVersion(t *testing.T) {
 		resp, _ := client.
 			WithAuth(admin.BearerHeader).
 			WithWorkspaceID(workspaceID).
-			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", "")
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", nil)
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 	})
@@ -380,7 +385,7 @@ func TestTemplateVersionController_PublishVersion(t *testing.T) {
 		resp, _ := client.
 			WithAuth(editor.BearerHeader).
 			WithWorkspaceID(workspaceID).
-			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", "")
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", nil)
 
 		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 	})
@@ -392,12 +397,320 @@ func TestTemplateVersionController_PublishVersion(t *testing.T) {
 		resp, _ := client.
 			WithAuth(admin.BearerHeader).
 			WithWorkspaceID(workspaceID).
-			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", "")
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", nil)
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
 
+func TestTemplateVersionController_PublishVersionChangeNotes(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVPN01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	admin := testhelper.CreateTestUser(t, pool, "admin-pub-notes@test.com", "Admin", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Test Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1.0", entity.VersionStatusDraft)
+	defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+
+	notes := "Updated the termination clause"
+	resp, body := client.
+		WithAuth(admin.BearerHeader).
+		WithWorkspaceID(workspaceID).
+		POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", map[string]interface{}{
+			"changeNotes": "  " + notes + "\n",
+		})
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+
+	t.Run("notes appear in version list", func(t *testing.T) {
+		resp, body := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/templates/" + templateID + "/versions")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var listResp dto.ListTemplateVersionsResponse
+		require.NoError(t, json.Unmarshal(body, &listResp))
+		require.Len(t, listResp.Items, 1)
+		require.NotNil(t, listResp.Items[0].ChangeNotes)
+		assert.Equal(t, notes, *listResp.Items[0].ChangeNotes)
+	})
+
+	t.Run("notes appear in all-versions", func(t *testing.T) {
+		resp, body := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/templates/" + templateID + "/all-versions")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var tplResp dto.TemplateWithAllVersionsResponse
+		require.NoError(t, json.Unmarshal(body, &tplResp))
+		require.Len(t, tplResp.Versions, 1)
+		require.NotNil(t, tplResp.Versions[0].ChangeNotes)
+		assert.Equal(t, notes, *tplResp.Versions[0].ChangeNotes)
+	})
+
+	t.Run("notes too long", func(t *testing.T) {
+		draftID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, draftID)
+
+		resp, _ := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+draftID+"/publish", map[string]interface{}{
+				"changeNotes": strings.Repeat("x", 5001),
+			})
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestTemplateVersionController_PublishVersionRecordsEvent(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+	outbox := outboxrepo.New(pool)
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVPE01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	admin := testhelper.CreateTestUser(t, pool, "admin-pub-event@test.com", "Admin", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+
+	editor := testhelper.CreateTestUser(t, pool, "editor-pub-event@test.com", "Editor", nil)
+	defer testhelper.CleanupUser(t, pool, editor.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, editor.ID, entity.WorkspaceRoleEditor, nil)
+
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Event Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+
+	publish := func(user *testhelper.TestUser, versionID string) int {
+		resp, _ := client.
+			WithAuth(user.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish", nil)
+		return resp.StatusCode
+	}
+
+	events := func(t *testing.T) []*entity.OutboxEvent {
+		t.Helper()
+		list, err := outbox.FindByAggregate(context.Background(), entity.AggregateTemplate, templateID)
+		require.NoError(t, err)
+		return list
+	}
+
+	v1 := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1.0", entity.VersionStatusDraft)
+	v2 := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2.0", entity.VersionStatusDraft)
+
+	t.Run("publish records event", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, publish(admin, v1))
+
+		list := events(t)
+		require.Len(t, list, 1)
+		event := list[0]
+		assert.Equal(t, entity.EventTemplateVersionPublished, event.EventType)
+
+		deliveries, err := outbox.FindDeliveries(context.Background(), event.ID)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries, "events wait for the relay")
+
+		var payload entity.TemplateVersionPublishedPayload
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		assert.Equal(t, templateID, payload.TemplateID)
+		assert.Equal(t, v1, payload.VersionID)
+		assert.Equal(t, 1, payload.VersionNumber)
+		assert.Equal(t, workspaceID, payload.WorkspaceID)
+		require.NotNil(t, payload.PublishedBy)
+		assert.Equal(t, admin.ID, *payload.PublishedBy)
+		assert.WithinDuration(t, time.Now(), payload.PublishedAt, time.Minute)
+	})
+
+	t.Run("failed publish records nothing", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, publish(editor, v2))
+		assert.Equal(t, http.StatusBadRequest, publish(admin, v1), "already published")
+
+		assert.Len(t, events(t), 1)
+	})
+
+	t.Run("publishing a new version archives the previous one and records its own event", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, publish(admin, v2))
+
+		list := events(t)
+		require.Len(t, list, 2)
+		var payload entity.TemplateVersionPublishedPayload
+		require.NoError(t, json.Unmarshal(list[1].Payload, &payload))
+		assert.Equal(t, v2, payload.VersionID)
+
+		var status string
+		require.NoError(t, pool.QueryRow(context.Background(),
+			"SELECT status FROM content.template_versions WHERE id = $1", v1).Scan(&status))
+		assert.Equal(t, string(entity.VersionStatusArchived), status)
+	})
+}
+
+func TestTemplateVersionController_PublishPinsIncludes(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVPI01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	admin := testhelper.CreateTestUser(t, pool, "admin-pub-include@test.com", "Admin", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+
+	clauseID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Clause", nil)
+	defer testhelper.CleanupTemplate(t, pool, clauseID)
+	clauseVersionID := testhelper.CreateTestTemplateVersion(t, pool, clauseID, 1, "v1.0", entity.VersionStatusPublished)
+
+	contractID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Contract", nil)
+	defer testhelper.CleanupTemplate(t, pool, contractID)
+
+	publish := func(versionID string) int {
+		resp, _ := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/templates/"+contractID+"/versions/"+versionID+"/publish", nil)
+		return resp.StatusCode
+	}
+
+	storedInclude := func(t *testing.T, versionID string) portabledoc.IncludeAttrs {
+		t.Helper()
+		var content []byte
+		require.NoError(t, pool.QueryRow(ctx,
+			"SELECT content_structure FROM content.template_versions WHERE id = $1", versionID).Scan(&content))
+		nodes := portabledoc.MustParse(content).CollectNodesOfType(portabledoc.NodeTypeInclude)
+		require.Len(t, nodes, 1)
+		attrs, err := portabledoc.ParseIncludeAttrs(nodes[0].Attrs)
+		require.NoError(t, err)
+		return *attrs
+	}
+
+	contractContent := func(includeAttrs string) []byte {
+		return fmt.Appendf(nil, `{"version":"1.1.0","meta":{"title":"Contract","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":[],"signerRoles":[],"content":{"type":"doc","content":[{"type":"include","attrs":%s}]}}`, includeAttrs)
+	}
+
+	t.Run("pins the published version", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, contractID, 1, "v1.0", entity.VersionStatusDraft)
+		testhelper.SetTestVersionContent(t, pool, versionID, contractContent(fmt.Sprintf(`{"templateId":%q}`, clauseID)))
+
+		require.Equal(t, http.StatusNoContent, publish(versionID))
+
+		assert.Equal(t, portabledoc.IncludeAttrs{TemplateID: clauseID, VersionID: clauseVersionID}, storedInclude(t, versionID))
+	})
+
+	t.Run("always latest is not pinned", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, contractID, 2, "v2.0", entity.VersionStatusDraft)
+		testhelper.SetTestVersionContent(t, pool, versionID, contractContent(fmt.Sprintf(`{"templateId":%q,"latest":true}`, clauseID)))
+
+		require.Equal(t, http.StatusNoContent, publish(versionID))
+
+		assert.Equal(t, portabledoc.IncludeAttrs{TemplateID: clauseID, Latest: true}, storedInclude(t, versionID))
+	})
+
+	t.Run("unpublished include fails", func(t *testing.T) {
+		draftID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Draft clause", nil)
+		defer testhelper.CleanupTemplate(t, pool, draftID)
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, contractID, 3, "v3.0", entity.VersionStatusDraft)
+		testhelper.SetTestVersionContent(t, pool, versionID, contractContent(fmt.Sprintf(`{"templateId":%q}`, draftID)))
+
+		assert.Equal(t, http.StatusBadRequest, publish(versionID))
+	})
+}
+
+func TestTemplateVersionController_PreviewPublish(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	// Setup
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVPP01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	editor := testhelper.CreateTestUser(t, pool, "editor-pubpreview@test.com", "Editor", nil)
+	defer testhelper.CleanupUser(t, pool, editor.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, editor.ID, entity.WorkspaceRoleEditor, nil)
+
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Test Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+
+	publishedID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1.0", entity.VersionStatusPublished)
+	defer testhelper.CleanupTemplateVersion(t, pool, publishedID)
+
+	preview := func(t *testing.T, versionID string) (int, dto.PublishPreviewResponse) {
+		t.Helper()
+		resp, body := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/publish-preview", nil)
+		var previewResp dto.PublishPreviewResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(body, &previewResp))
+		}
+		return resp.StatusCode, previewResp
+	}
+
+	t.Run("draft differs from published", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+		testhelper.SetTestVersionContent(t, pool, versionID, []byte(`{"version":"1.1.0","meta":{"title":"Test Document","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":["client_name"],"signerRoles":[{"id":"role-001","label":"Signer","order":1,"name":{"type":"text","value":"Test Signer"},"email":{"type":"text","value":"signer@test.com"}}],"content":{"type":"doc","content":[{"type":"paragraph","attrs":{"nodeId":"p-1"},"content":[{"type":"text","text":"New clause"}]},{"type":"signature","attrs":{"count":1,"layout":"single-center","lineWidth":"md","signatures":[{"id":"sig-001","roleId":"role-001","label":"Signer"}]}}]},"exportInfo":{"exportedAt":"2026-01-01T00:00:00Z","sourceApp":"integration-test"}}`))
+
+		status, previewResp := preview(t, versionID)
+
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, versionID, previewResp.VersionID)
+		require.NotNil(t, previewResp.PublishedVersionID)
+		assert.Equal(t, publishedID, *previewResp.PublishedVersionID)
+		assert.True(t, previewResp.HasChanges)
+		assert.Equal(t, []string{"client_name"}, previewResp.VariablesAdded)
+		assert.Empty(t, previewResp.SignerRoles)
+		assert.Contains(t, previewResp.Blocks, dto.BlockChangeResponse{Kind: "added", NodeID: "p-1", Type: "paragraph", Index: 0})
+		assert.Empty(t, previewResp.VersionPDF, "PDFs are only rendered on request")
+	})
+
+	t.Run("draft identical to published", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 3, "v3.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+
+		status, previewResp := preview(t, versionID)
+
+		require.Equal(t, http.StatusOK, status)
+		assert.False(t, previewResp.HasChanges)
+		assert.Empty(t, previewResp.Sections)
+		assert.Empty(t, previewResp.Blocks)
+		assert.Empty(t, previewResp.VariablesAdded)
+		assert.Empty(t, previewResp.VariablesRemoved)
+	})
+
+	t.Run("published version cannot be previewed", func(t *testing.T) {
+		status, _ := preview(t, publishedID)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
 func TestTemplateVersionController_ArchiveVersion(t *testing.T) {
 	pool := testhelper.GetTestPool(t)
 	ts := testhelper.NewTestServer(t, pool)
@@ -632,6 +945,83 @@ func TestTemplateVersionController_CancelSchedule(t *testing.T) {
 	})
 }
 
+func TestTemplateVersionController_ProcessScheduledPublications(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+	ctx := context.Background()
+
+	// Setup
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVPS01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	admin := testhelper.CreateTestUser(t, pool, "admin-procsched@test.com", "Admin", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Test Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+
+	schedule := func(t *testing.T, versionID string, publishAt time.Time) {
+		t.Helper()
+		resp, _ := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/templates/"+templateID+"/versions/"+versionID+"/schedule-publish",
+				dto.SchedulePublishRequest{PublishAt: publishAt})
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	versionStatus := func(t *testing.T, versionID string) (string, *string) {
+		t.Helper()
+		var status string
+		var publishedBy *string
+		err := pool.QueryRow(ctx,
+			`SELECT status, published_by FROM content.template_versions WHERE id = $1`, versionID,
+		).Scan(&status, &publishedBy)
+		require.NoError(t, err)
+		return status, publishedBy
+	}
+
+	t.Run("publishes only once the scheduled time is reached", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+
+		publishAt := time.Now().Add(time.Hour).UTC()
+		schedule(t, versionID, publishAt)
+
+		require.NoError(t, ts.TemplateVersionUC.ProcessScheduledPublications(ctx, publishAt.Add(-time.Minute)))
+		status, _ := versionStatus(t, versionID)
+		assert.Equal(t, string(entity.VersionStatusScheduled), status)
+
+		require.NoError(t, ts.TemplateVersionUC.ProcessScheduledPublications(ctx, publishAt))
+		status, publishedBy := versionStatus(t, versionID)
+		assert.Equal(t, string(entity.VersionStatusPublished), status)
+		assert.Nil(t, publishedBy, "scheduled publication has no acting user")
+	})
+
+	t.Run("cancelled schedule is not published", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+
+		publishAt := time.Now().Add(time.Hour).UTC()
+		schedule(t, versionID, publishAt)
+
+		resp, _ := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			DELETE("/api/v1/content/templates/" + templateID + "/versions/" + versionID + "/schedule")
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		require.NoError(t, ts.TemplateVersionUC.ProcessScheduledPublications(ctx, publishAt.Add(time.Hour)))
+		status, _ := versionStatus(t, versionID)
+		assert.Equal(t, string(entity.VersionStatusDraft), status)
+	})
+}
+
 // --- Injectable Tests ---
 
 func TestTemplateVersionController_AddInjectable(t *testing.T) {
@@ -762,6 +1152,117 @@ func TestTemplateVersionController_RemoveInjectable(t *testing.T) {
 	})
 }
 
+func TestTemplateVersionController_ListVersionInjectables(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	// Setup
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVLI01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	viewer := testhelper.CreateTestUser(t, pool, "viewer-listinj@test.com", "Viewer", nil)
+	defer testhelper.CleanupUser(t, pool, viewer.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, viewer.ID, entity.WorkspaceRoleViewer, nil)
+
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Test Template", nil)
+	defer testhelper.CleanupTemplate(t, pool, templateID)
+
+	amountID := testhelper.CreateTestInjectable(t, pool, &workspaceID, "contract_amount", "Contract Amount", entity.InjectableDataTypeCurrency)
+	defer testhelper.CleanupInjectable(t, pool, amountID)
+
+	t.Run("returns metadata in document order", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+		testhelper.CreateTestVersionInjectable(t, pool, versionID, amountID, true)
+
+		setTemplateVersionContentInternal(t, pool, versionID, `{
+			"version": "1.1.0",
+			"content": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [
+					{"type": "injector", "attrs": {"type": "TEXT", "label": "Client Name", "variableId": "client_name", "required": true, "defaultValue": "N/A"}},
+					{"type": "injector", "attrs": {"type": "CURRENCY", "variableId": "contract_amount", "format": "$"}},
+					{"type": "injector", "attrs": {"type": "TEXT", "label": "Client Name", "variableId": "client_name"}}
+				]},
+				{"type": "listInjector", "attrs": {"variableId": "line_items", "label": "Line Items"}},
+				{"type": "paragraph", "content": [
+					{"type": "injector", "attrs": {"type": "ROLE_TEXT", "label": "Buyer.email", "variableId": "ROLE.Buyer.email", "isRoleVariable": true, "roleId": "role-001", "roleLabel": "Buyer", "propertyKey": "email"}}
+				]}
+			]}
+		}`)
+
+		resp, body := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/templates/" + templateID + "/versions/" + versionID + "/injectables")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result dto.ListVersionInjectableFieldsResponse
+		require.NoError(t, json.Unmarshal(body, &result))
+		require.Equal(t, 4, result.Total)
+		require.Len(t, result.Items, 4)
+
+		text := result.Items[0]
+		assert.Equal(t, "client_name", text.Key)
+		assert.Equal(t, "TEXT", text.Type)
+		assert.Equal(t, "Client Name", text.Label)
+		assert.True(t, text.Required)
+		require.NotNil(t, text.DefaultValue)
+		assert.Equal(t, "N/A", *text.DefaultValue)
+		assert.Nil(t, text.RoleID)
+
+		currency := result.Items[1]
+		assert.Equal(t, "contract_amount", currency.Key)
+		assert.Equal(t, "CURRENCY", currency.Type)
+		assert.Equal(t, "Contract Amount", currency.Label)
+		assert.True(t, currency.Required)
+		assert.Nil(t, currency.DefaultValue)
+
+		list := result.Items[2]
+		assert.Equal(t, "line_items", list.Key)
+		assert.Equal(t, "LIST", list.Type)
+		assert.Equal(t, "Line Items", list.Label)
+		assert.False(t, list.Required)
+
+		role := result.Items[3]
+		assert.Equal(t, "ROLE.Buyer.email", role.Key)
+		assert.Equal(t, "ROLE_TEXT", role.Type)
+		require.NotNil(t, role.RoleID)
+		assert.Equal(t, "role-001", *role.RoleID)
+		require.NotNil(t, role.PropertyKey)
+		assert.Equal(t, "email", *role.PropertyKey)
+	})
+
+	t.Run("empty content", func(t *testing.T) {
+		versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2.0", entity.VersionStatusDraft)
+		defer testhelper.CleanupTemplateVersion(t, pool, versionID)
+
+		resp, body := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/templates/" + templateID + "/versions/" + versionID + "/injectables")
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result dto.ListVersionInjectableFieldsResponse
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.Empty(t, result.Items)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp, _ := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/content/templates/" + templateID + "/versions/00000000-0000-0000-0000-000000000000/injectables")
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
 // --- Signer Role Tests ---
 
 func TestTemplateVersionController_AddSignerRole(t *testing.T) {
@@ -957,3 +1458,90 @@ func TestTemplateVersionController_RemoveSignerRole(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 }
+
+func TestTemplateVersionController_ValidateContent(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	// Setup
+	tenantID := testhelper.CreateTestTenant(t, pool, "Test Tenant", "TVVC01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Test Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	editor := testhelper.CreateTestUser(t, pool, "editor-validate@test.com", "Editor", nil)
+	defer testhelper.CleanupUser(t, pool, editor.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, editor.ID, entity.WorkspaceRoleEditor, nil)
+
+	viewer := testhelper.CreateTestUser(t, pool, "viewer-validate@test.com", "Viewer", nil)
+	defer testhelper.CleanupUser(t, pool, viewer.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, viewer.ID, entity.WorkspaceRoleViewer, nil)
+
+	t.Run("valid content", func(t *testing.T) {
+		req := dto.ValidateContentRequest{ContentStructure: json.RawMessage(`{
+			"version": "1.1.0",
+			"content": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Hello"}]}
+			]}
+		}`)}
+		resp, body := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/validate", req)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result dto.ContentValidationResultDTO
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.True(t, result.Valid)
+		assert.Empty(t, result.Errors)
+	})
+
+	t.Run("reports problems with node paths", func(t *testing.T) {
+		req := dto.ValidateContentRequest{ContentStructure: json.RawMessage(`{
+			"content": {"type": "doc", "content": [
+				{"type": "image", "attrs": {}},
+				{"type": "signature", "attrs": {"count": 1, "signatures": [{"id": "s1", "label": "Client"}]}}
+			]}
+		}`)}
+		resp, body := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/validate", req)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result dto.ContentValidationResultDTO
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.False(t, result.Valid)
+
+		paths := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			paths = append(paths, e.Path)
+		}
+		assert.Contains(t, paths, "content[0].attrs.src")
+		assert.Contains(t, paths, "content[1].attrs.signatures[0].roleId")
+	})
+
+	t.Run("unparseable content", func(t *testing.T) {
+		req := dto.ValidateContentRequest{ContentStructure: json.RawMessage(`{"content": []}`)}
+		resp, _ := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/validate", req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("forbidden for VIEWER", func(t *testing.T) {
+		req := dto.ValidateContentRequest{ContentStructure: json.RawMessage(`{}`)}
+		resp, _ := client.
+			WithAuth(viewer.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			POST("/api/v1/content/validate", req)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	organizationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/organization"
+)
+
+const workspaceAssetMaxUploadBytes = 11 * 1024 * 1024 // 11 MB multipart limit (10 MB file + overhead)
+
+// WorkspaceAssetController handles workspace brand asset (logo, fonts) HTTP requests.
+type WorkspaceAssetController struct {
+	assetUC organizationuc.WorkspaceAssetUseCase
+}
+
+// NewWorkspaceAssetController creates a new workspace asset controller.
+func NewWorkspaceAssetController(assetUC organizationuc.WorkspaceAssetUseCase) *WorkspaceAssetController {
+	return &WorkspaceAssetController{assetUC: assetUC}
+}
+
+// RegisterRoutes registers all workspace asset routes under /workspace/assets.
+func (c *WorkspaceAssetController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	assets := rg.Group("/workspace/assets")
+	assets.Use(middlewareProvider.WorkspaceContext())
+	{
+		assets.GET("", c.ListAssets)                                         // VIEWER+
+		assets.POST("", middleware.RequireAdmin(), c.UploadAsset)            // ADMIN+
+		assets.DELETE("/:assetId", middleware.RequireAdmin(), c.DeleteAsset) // ADMIN+
+	}
+}
+
+// ListAssets lists the workspace's brand assets.
+// @Summary List workspace assets
+// @Description Lists the workspace logo and fonts. The logo renders wherever a template uses the workspace.logo injectable.
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.WorkspaceAssetResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets [get]
+func (c *WorkspaceAssetController) ListAssets(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	assets, err := c.assetUC.ListAssets(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	responses := make([]*dto.WorkspaceAssetResponse, len(assets))
+	for i, a := range assets {
+		responses[i] = workspaceAssetToResponse(a)
+	}
+	ctx.JSON(http.StatusOK, dto.NewListResponse(responses))
+}
+
+// UploadAsset uploads a workspace logo or font.
+// @Summary Upload workspace asset
+// @Description Uploads the workspace logo (an image, replacing the current one) or a font (TrueType or OpenType) made available to every render in the workspace.
+// @Tags Workspaces
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param kind formData string true "Asset kind" Enums(LOGO, FONT)
+// @Param file formData file true "Logo image or font file"
+// @Success 201 {object} dto.WorkspaceAssetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets [post]
+func (c *WorkspaceAssetController) UploadAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	if err := ctx.Request.ParseMultipartForm(workspaceAssetMaxUploadBytes); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	file, header, err := ctx.Request.FormFile("file")
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	asset, err := c.assetUC.UploadAsset(ctx.Request.Context(), organizationuc.UploadWorkspaceAssetCommand{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Kind:        entity.WorkspaceAssetKind(ctx.Request.FormValue("kind")),
+		Filename:    header.Filename,
+		ContentType: contentType,
+		Data:        data,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, workspaceAssetToResponse(asset))
+}
+
+// DeleteAsset removes a workspace asset.
+// @Summary Delete workspace asset
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId} [delete]
+func (c *WorkspaceAssetController) DeleteAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.assetUC.DeleteAsset(ctx.Request.Context(), workspaceID, ctx.Param("assetId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func workspaceAssetToResponse(a *entity.WorkspaceAsset) *dto.WorkspaceAssetResponse {
+	return &dto.WorkspaceAssetResponse{
+		ID:          a.ID,
+		Kind:        string(a.Kind),
+		Key:         a.Key,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
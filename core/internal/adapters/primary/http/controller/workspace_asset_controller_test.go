@@ -0,0 +1,150 @@
+//go:build integration
+
+package controller_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+var (
+	testPNG  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	testFont = append([]byte("\x00\x01\x00\x00"), make([]byte, 64)...)
+)
+
+func TestWorkspaceAssetController_Lifecycle(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Asset Tenant", "WSAS01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Asset Workspace", entity.WorkspaceTypeClient)
+
+	admin := testhelper.CreateTestUser(t, pool, "asset-admin@test.com", "Asset Admin", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	editor := testhelper.CreateTestUser(t, pool, "asset-editor@test.com", "Asset Editor", nil)
+	defer testhelper.CleanupUser(t, pool, editor.ID)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, editor.ID, entity.WorkspaceRoleEditor, nil)
+
+	listAssets := func() []*dto.WorkspaceAssetResponse {
+		resp, body := client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			GET("/api/v1/workspace/assets")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return testhelper.ParseJSON[dto.ListResponse[*dto.WorkspaceAssetResponse]](t, body).Data
+	}
+
+	resp, body := uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "LOGO", "logo.png", testPNG, "image/png")
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	first := testhelper.ParseJSON[dto.WorkspaceAssetResponse](t, body)
+	assert.Equal(t, "LOGO", first.Kind)
+	assert.Equal(t, "logo.png", first.Filename)
+
+	resp, body = uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "LOGO", "logo-v2.png", append(bytes.Clone(testPNG), 'x'), "image/png")
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	logo := testhelper.ParseJSON[dto.WorkspaceAssetResponse](t, body)
+
+	resp, body = uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "FONT", "brand.ttf", testFont, "application/octet-stream")
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	font := testhelper.ParseJSON[dto.WorkspaceAssetResponse](t, body)
+	assert.Equal(t, "font/ttf", font.ContentType)
+
+	assets := listAssets()
+	require.Len(t, assets, 2, "a new logo replaces the previous one")
+	assert.Equal(t, logo.ID, assets[0].ID)
+	assert.Equal(t, font.ID, assets[1].ID)
+
+	t.Run("rejects invalid uploads", func(t *testing.T) {
+		resp, _ := uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "FONT", "brand.ttf", []byte("not a font"), "font/ttf")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, _ = uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "LOGO", "logo.txt", []byte("text"), "text/plain")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, _ = uploadWorkspaceAsset(t, ts.URL(), admin.BearerHeader, workspaceID, "BANNER", "banner.png", testPNG, "image/png")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("editor cannot manage assets", func(t *testing.T) {
+		resp, _ := uploadWorkspaceAsset(t, ts.URL(), editor.BearerHeader, workspaceID, "LOGO", "logo.png", testPNG, "image/png")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, _ = client.
+			WithAuth(editor.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			DELETE("/api/v1/workspace/assets/" + font.ID)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	resp, _ = client.
+		WithAuth(admin.BearerHeader).
+		WithWorkspaceID(workspaceID).
+		DELETE("/api/v1/workspace/assets/" + font.ID)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, _ = client.
+		WithAuth(admin.BearerHeader).
+		WithWorkspaceID(workspaceID).
+		DELETE("/api/v1/workspace/assets/" + font.ID)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assets = listAssets()
+	require.Len(t, assets, 1)
+	assert.Equal(t, logo.ID, assets[0].ID)
+}
+
+func uploadWorkspaceAsset(
+	t *testing.T,
+	baseURL, authHeader, workspaceID, kind, filename string,
+	content []byte,
+	contentType string,
+) (*http.Response, []byte) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("kind", kind))
+
+	headers := textproto.MIMEHeader{}
+	headers.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	headers.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(headers)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/v1/workspace/assets", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("X-Workspace-ID", workspaceID)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	copyResp := *resp
+	copyResp.Body = http.NoBody
+	return &copyResp, respBody
+}
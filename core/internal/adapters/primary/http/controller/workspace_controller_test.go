@@ -1476,6 +1476,60 @@ func TestWorkspaceController_UpdateWorkspace_Validation(t *testing.T) {
 	})
 }
 
+// TestWorkspaceController_UpdateWorkspace_DefaultTheme tests setting, validating and clearing the default theme.
+func TestWorkspaceController_UpdateWorkspace_DefaultTheme(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ts := testhelper.NewTestServer(t, pool)
+	client := testhelper.NewHTTPClient(t, ts.URL())
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Theme Tenant", "UWDT01")
+	defer testhelper.CleanupTenant(t, pool, tenantID)
+
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Workspace", entity.WorkspaceTypeClient)
+	defer testhelper.CleanupWorkspace(t, pool, workspaceID)
+
+	admin := testhelper.CreateTestUser(t, pool, "admin-uwdt@test.com", "Admin User", nil)
+	defer testhelper.CleanupUser(t, pool, admin.ID)
+	testhelper.CreateTestWorkspaceMember(t, pool, workspaceID, admin.ID, entity.WorkspaceRoleAdmin, nil)
+
+	update := func(body map[string]interface{}) (int, dto.WorkspaceResponse) {
+		resp, raw := client.
+			WithAuth(admin.BearerHeader).
+			WithWorkspaceID(workspaceID).
+			PUT("/api/v1/workspace", body)
+		var wsResp dto.WorkspaceResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(raw, &wsResp))
+		}
+		return resp.StatusCode, wsResp
+	}
+
+	t.Run("sets registered theme", func(t *testing.T) {
+		status, wsResp := update(map[string]interface{}{"name": "Workspace", "defaultTheme": testhelper.TestThemeName})
+		assert.Equal(t, http.StatusOK, status)
+		require.NotNil(t, wsResp.DefaultTheme)
+		assert.Equal(t, testhelper.TestThemeName, *wsResp.DefaultTheme)
+	})
+
+	t.Run("keeps theme when omitted", func(t *testing.T) {
+		status, wsResp := update(map[string]interface{}{"name": "Renamed"})
+		assert.Equal(t, http.StatusOK, status)
+		require.NotNil(t, wsResp.DefaultTheme)
+		assert.Equal(t, testhelper.TestThemeName, *wsResp.DefaultTheme)
+	})
+
+	t.Run("rejects unknown theme", func(t *testing.T) {
+		status, _ := update(map[string]interface{}{"name": "Workspace", "defaultTheme": "unknown"})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("clears theme", func(t *testing.T) {
+		status, wsResp := update(map[string]interface{}{"name": "Workspace", "defaultTheme": ""})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Nil(t, wsResp.DefaultTheme)
+	})
+}
+
 // TestWorkspaceController_UpdateFolder_Validation tests validation errors.
 func TestWorkspaceController_UpdateFolder_Validation(t *testing.T) {
 	pool := testhelper.GetTestPool(t)
@@ -4,9 +4,10 @@ import "time"
 
 // ErrorResponse represents a standard error response.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    string `json:"code,omitempty"`
+	Error     string `json:"error"`
+	Message   string `json:"message,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"requestId,omitempty"` // X-Operation-ID, for correlating a failure with server logs
 }
 
 // ValidationError represents a field-level validation error.
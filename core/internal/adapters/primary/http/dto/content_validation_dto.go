@@ -1,6 +1,15 @@
 package dto
 
-import "github.com/rendis/doc-assembly/core/internal/core/entity"
+import (
+	"encoding/json"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// ValidateContentRequest is the request body for POST /content/validate.
+type ValidateContentRequest struct {
+	ContentStructure json.RawMessage `json:"contentStructure" binding:"required"`
+}
 
 // ContentValidationErrorDTO represents a single content validation error.
 type ContentValidationErrorDTO struct {
@@ -16,6 +16,7 @@ type InjectableResponse struct {
 	ID           string                `json:"id"`
 	WorkspaceID  *string               `json:"workspaceId,omitempty"`
 	Key          string                `json:"key"`
+	Namespace    string                `json:"namespace,omitempty"` // Key prefix before the last "." (e.g. "crm" in "crm.first_name")
 	Label        map[string]string     `json:"label"`
 	Description  map[string]string     `json:"description,omitempty"`
 	DataType     string                `json:"dataType"`
@@ -43,6 +44,26 @@ type ListInjectablesResponse struct {
 	Total  int                   `json:"total"`
 }
 
+// InjectableAutocompleteRequest represents query params for editor autocomplete.
+type InjectableAutocompleteRequest struct {
+	Query string `form:"q"`                                        // Optional case-insensitive prefix for keys and labels
+	Limit int    `form:"limit,default=20" binding:"min=1,max=200"` // Maximum number of suggestions
+}
+
+// InjectableAutocompleteItem is a compact autocomplete suggestion.
+type InjectableAutocompleteItem struct {
+	Kind     string            `json:"kind"` // "injectable" or "role"
+	Key      string            `json:"key"`
+	Label    map[string]string `json:"label"`
+	DataType string            `json:"dataType,omitempty"` // Empty for roles
+}
+
+// InjectableAutocompleteResponse represents the list of autocomplete suggestions.
+type InjectableAutocompleteResponse struct {
+	Items []*InjectableAutocompleteItem `json:"items"`
+	Total int                           `json:"total"`
+}
+
 // WorkspaceInjectableResponse represents a workspace-owned injectable in API responses.
 type WorkspaceInjectableResponse struct {
 	ID           string                `json:"id"`
@@ -178,6 +199,13 @@ type CloneTemplateRequest struct {
 	TargetFolderID *string `json:"targetFolderId,omitempty"`
 }
 
+// CloneFromLibraryRequest represents the request to clone a public library template
+// into the current workspace.
+type CloneFromLibraryRequest struct {
+	NewTitle       string  `json:"newTitle" binding:"required,min=1,max=255"`
+	TargetFolderID *string `json:"targetFolderId,omitempty"`
+}
+
 // ListTemplatesResponse represents the list of templates.
 type ListTemplatesResponse struct {
 	Items  []*TemplateListItemResponse `json:"items"`
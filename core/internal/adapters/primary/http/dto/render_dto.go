@@ -1,10 +1,74 @@
 package dto
 
+import "github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+
 // RenderPreviewRequest represents the request to generate a preview PDF.
 type RenderPreviewRequest struct {
 	// Injectables contains the values to inject into the document.
 	// Keys are variable IDs, values are the actual values.
 	Injectables map[string]any `json:"injectables"`
+
+	// BlockIndex, when set, renders only the top-level content block at this index
+	// so the editor can refresh just the changed region.
+	BlockIndex *int `json:"blockIndex,omitempty"`
+
+	// BlockID addresses the block to render by its node id (attrs.nodeId) instead of index.
+	// Ignored when BlockIndex is set.
+	BlockID string `json:"blockId,omitempty"`
+
+	// Slots fills the template's insertion points with content, keyed by slot name.
+	// Insertion points without content render nothing.
+	Slots map[string][]portabledoc.Node `json:"slots,omitempty"`
+
+	// DraftMode renders reviewer comments as notes in the PDF.
+	DraftMode bool `json:"draftMode,omitempty"`
+
+	// ShowPlaceholders renders injectors without a value as a visible [[variableId]].
+	ShowPlaceholders bool `json:"showPlaceholders,omitempty"`
+
+	// ShowHiddenConditionals renders conditionals that evaluate to false as a visible note with their condition.
+	ShowHiddenConditionals bool `json:"showHiddenConditionals,omitempty"`
+
+	// Timezone is the IANA time zone system.now and system.today are resolved in. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+
+	// PDFA produces a PDF/A-2b archival PDF.
+	PDFA bool `json:"pdfA,omitempty"`
+
+	// Theme names a registered design theme. Defaults to the workspace's default theme.
+	Theme string `json:"theme,omitempty"`
+
+	// Encryption password-protects the PDF. Cannot be combined with pdfA.
+	Encryption *PDFEncryptionRequest `json:"encryption,omitempty"`
+
+	// Image returns a single page as PNG instead of the PDF, e.g. for thumbnails.
+	// Cannot be combined with pdfA or encryption.
+	Image *RenderImageRequest `json:"image,omitempty"`
+
+	// ExtractSignaturePages also returns the pages holding signature fields as a separate PDF.
+	// The response is then a RenderSignaturePagesResponse instead of the PDF binary.
+	// Cannot be combined with image.
+	ExtractSignaturePages bool `json:"extractSignaturePages,omitempty"`
+}
+
+// RenderImageRequest selects the page and resolution of a PNG preview.
+type RenderImageRequest struct {
+	// Page is the 1-indexed page to render. Defaults to the first page.
+	Page int `json:"page,omitempty"`
+	// PPI is the resolution in pixels per inch, up to 600. Defaults to 72.
+	PPI int `json:"ppi,omitempty"`
+}
+
+// PDFEncryptionRequest configures password protection of the rendered PDF.
+type PDFEncryptionRequest struct {
+	// UserPassword is required to open the PDF.
+	UserPassword string `json:"userPassword,omitempty"`
+	// OwnerPassword lifts the permission restrictions. Defaults to a random password.
+	OwnerPassword string `json:"ownerPassword,omitempty"`
+	// NoPrint disallows printing.
+	NoPrint bool `json:"noPrint,omitempty"`
+	// NoCopy disallows copying text and graphics.
+	NoCopy bool `json:"noCopy,omitempty"`
 }
 
 // RenderPreviewResponse is empty as the response is the PDF binary.
@@ -16,3 +80,57 @@ type RenderPreviewResponse struct {
 	//   Content-Type: application/pdf
 	//   Content-Disposition: attachment; filename="<document-title>.pdf"
 }
+
+// RenderSignaturePagesResponse is returned instead of the PDF binary when
+// extractSignaturePages is requested.
+type RenderSignaturePagesResponse struct {
+	// PDF is the whole rendered document, base64-encoded.
+	PDF []byte `json:"pdf" swaggertype:"string" format:"base64"`
+	// SignaturePages lists the 1-indexed pages holding signature fields.
+	SignaturePages []int `json:"signaturePages"`
+	// SignaturePagesPDF holds just the signature pages, base64-encoded.
+	// Omitted when the document has no signature fields.
+	SignaturePagesPDF []byte `json:"signaturePagesPdf,omitempty" swaggertype:"string" format:"base64"`
+}
+
+// PublishPreviewRequest configures a preview of what publishing a version would change.
+type PublishPreviewRequest struct {
+	// IncludePDFs also renders the version and the currently published version.
+	IncludePDFs bool `json:"includePdfs,omitempty"`
+
+	// Injectables are the values used to render both PDFs, so they differ only by content.
+	Injectables map[string]any `json:"injectables,omitempty"`
+}
+
+// PublishPreviewResponse summarizes what publishing a version would change
+// relative to the template's currently published version.
+type PublishPreviewResponse struct {
+	VersionID string `json:"versionId"`
+	// PublishedVersionID is omitted when the template has no published version,
+	// in which case every block is reported as added.
+	PublishedVersionID *string                    `json:"publishedVersionId,omitempty"`
+	HasChanges         bool                       `json:"hasChanges"`
+	Sections           []string                   `json:"sections"` // meta, pageConfig, signingWorkflow, header, coverPage, watermark, textFlow, headingNumbering
+	Blocks             []BlockChangeResponse      `json:"blocks"`
+	VariablesAdded     []string                   `json:"variablesAdded"`
+	VariablesRemoved   []string                   `json:"variablesRemoved"`
+	SignerRoles        []SignerRoleChangeResponse `json:"signerRoles"`
+	// VersionPDF and PublishedPDF are base64-encoded and only set when includePdfs is requested.
+	VersionPDF   []byte `json:"versionPdf,omitempty" swaggertype:"string" format:"base64"`
+	PublishedPDF []byte `json:"publishedPdf,omitempty" swaggertype:"string" format:"base64"`
+}
+
+// BlockChangeResponse describes a top-level content block that publishing would change.
+type BlockChangeResponse struct {
+	Kind   string `json:"kind"` // added, removed, modified
+	NodeID string `json:"nodeId,omitempty"`
+	Type   string `json:"type"`
+	Index  int    `json:"index"`
+}
+
+// SignerRoleChangeResponse describes a signer role that publishing would change.
+type SignerRoleChangeResponse struct {
+	Kind  string `json:"kind"` // added, removed, modified
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
@@ -21,6 +21,7 @@ type TemplateVersionResponse struct {
 	ArchivedAt         *time.Time `json:"archivedAt,omitempty"`
 	PublishedBy        *string    `json:"publishedBy,omitempty"`
 	ArchivedBy         *string    `json:"archivedBy,omitempty"`
+	ChangeNotes        *string    `json:"changeNotes,omitempty"`
 	CreatedBy          *string    `json:"createdBy,omitempty"`
 	CreatedAt          time.Time  `json:"createdAt"`
 	UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
@@ -68,6 +69,23 @@ type TemplateVersionSignerRoleResponse struct {
 	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
 }
 
+// VersionInjectableFieldResponse describes an injectable referenced in a version's content.
+type VersionInjectableFieldResponse struct {
+	Key          string  `json:"key"`
+	Type         string  `json:"type"`
+	Label        string  `json:"label"`
+	DefaultValue *string `json:"defaultValue,omitempty"`
+	Required     bool    `json:"required"`
+	RoleID       *string `json:"roleId,omitempty"`
+	PropertyKey  *string `json:"propertyKey,omitempty"`
+}
+
+// ListVersionInjectableFieldsResponse represents the injectables referenced in a version's content.
+type ListVersionInjectableFieldsResponse struct {
+	Items []*VersionInjectableFieldResponse `json:"items"`
+	Total int                               `json:"total"`
+}
+
 // --- Template Version Request DTOs ---
 
 // CreateVersionRequest represents the request to create a new template version.
@@ -90,6 +108,11 @@ type UpdateVersionRequest struct {
 	ContentStructure json.RawMessage `json:"contentStructure,omitempty"`
 }
 
+// PublishVersionRequest represents the optional body of a publish request.
+type PublishVersionRequest struct {
+	ChangeNotes *string `json:"changeNotes,omitempty" binding:"omitempty,max=5000"`
+}
+
 // SchedulePublishRequest represents the request to schedule version publication.
 type SchedulePublishRequest struct {
 	PublishAt time.Time `json:"publishAt" binding:"required"`
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// WorkspaceAssetResponse represents a workspace brand asset in API responses.
+type WorkspaceAssetResponse struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	Key         string    `json:"key"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -11,6 +11,7 @@ type WorkspaceResponse struct {
 	Type           string     `json:"type"`
 	Status         string     `json:"status"`
 	Role           string     `json:"role,omitempty"`
+	DefaultTheme   *string    `json:"defaultTheme,omitempty"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
 	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
@@ -28,6 +29,8 @@ type CreateWorkspaceRequest struct {
 type UpdateWorkspaceRequest struct {
 	Name string  `json:"name" binding:"required,min=1,max=255"`
 	Code *string `json:"code"`
+	// DefaultTheme is the design theme for renders that don't select one. Empty clears it; omitted leaves it unchanged.
+	DefaultTheme *string `json:"defaultTheme,omitempty"`
 }
 
 // Validate validates the CreateWorkspaceRequest.
@@ -4,6 +4,7 @@ import (
 	"github.com/rendis/doc-assembly/core/internal/adapters/primary/http/dto"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
+	injectableuc "github.com/rendis/doc-assembly/core/internal/core/usecase/injectable"
 )
 
 // InjectableMapper handles mapping between injectable entities and DTOs.
@@ -45,6 +46,7 @@ func (m *InjectableMapper) ToResponse(injectable *entity.InjectableDefinition) *
 		ID:           injectable.ID,
 		WorkspaceID:  injectable.WorkspaceID,
 		Key:          injectable.Key,
+		Namespace:    injectable.Namespace(),
 		Label:        labels,
 		Description:  descriptions,
 		DataType:     string(injectable.DataType),
@@ -82,6 +84,23 @@ func (m *InjectableMapper) ToListResponse(injectables []*entity.InjectableDefini
 	}
 }
 
+// ToAutocompleteResponse converts autocomplete suggestions to a response DTO.
+func (m *InjectableMapper) ToAutocompleteResponse(suggestions []*injectableuc.AutocompleteSuggestion) *dto.InjectableAutocompleteResponse {
+	items := make([]*dto.InjectableAutocompleteItem, len(suggestions))
+	for i, s := range suggestions {
+		items[i] = &dto.InjectableAutocompleteItem{
+			Kind:     string(s.Kind),
+			Key:      s.Key,
+			Label:    s.Labels,
+			DataType: string(s.DataType),
+		}
+	}
+	return &dto.InjectableAutocompleteResponse{
+		Items: items,
+		Total: len(items),
+	}
+}
+
 // ToGroupResponseList converts a list of GroupConfig to GroupResponse DTOs.
 func (m *InjectableMapper) ToGroupResponseList(groups []port.GroupConfig) []*dto.GroupResponse {
 	if groups == nil {
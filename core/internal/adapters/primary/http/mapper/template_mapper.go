@@ -206,6 +206,17 @@ func (m *TemplateMapper) ToCloneCommand(sourceID string, req *dto.CloneTemplateR
 	}
 }
 
+// ToCloneFromLibraryCommand converts a library clone request to a command.
+func (m *TemplateMapper) ToCloneFromLibraryCommand(sourceID, workspaceID string, req *dto.CloneFromLibraryRequest, userID string) templateuc.CloneFromLibraryCommand {
+	return templateuc.CloneFromLibraryCommand{
+		SourceTemplateID:  sourceID,
+		TargetWorkspaceID: workspaceID,
+		NewTitle:          req.NewTitle,
+		TargetFolderID:    req.TargetFolderID,
+		ClonedBy:          userID,
+	}
+}
+
 // ToFilters converts filter request parameters to port filters.
 func (m *TemplateMapper) ToFilters(req *dto.TemplateFiltersRequest) port.TemplateFilters {
 	filters := port.TemplateFilters{
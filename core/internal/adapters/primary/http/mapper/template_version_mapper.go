@@ -37,6 +37,7 @@ func (m *TemplateVersionMapper) ToResponse(version *entity.TemplateVersion) *dto
 		ArchivedAt:         version.ArchivedAt,
 		PublishedBy:        version.PublishedBy,
 		ArchivedBy:         version.ArchivedBy,
+		ChangeNotes:        version.ChangeNotes,
 		CreatedBy:          version.CreatedBy,
 		CreatedAt:          version.CreatedAt,
 		UpdatedAt:          version.UpdatedAt,
@@ -164,6 +165,23 @@ func (m *TemplateVersionMapper) SignerRolesToResponse(roles []*entity.TemplateVe
 	return responses
 }
 
+// InjectableFieldsToResponse converts the injectables referenced in a version's content to a list response.
+func (m *TemplateVersionMapper) InjectableFieldsToResponse(fields []*entity.VersionInjectableField) *dto.ListVersionInjectableFieldsResponse {
+	items := make([]*dto.VersionInjectableFieldResponse, len(fields))
+	for i, f := range fields {
+		items[i] = &dto.VersionInjectableFieldResponse{
+			Key:          f.Key,
+			Type:         f.Type,
+			Label:        f.Label,
+			DefaultValue: f.DefaultValue,
+			Required:     f.Required,
+			RoleID:       f.RoleID,
+			PropertyKey:  f.PropertyKey,
+		}
+	}
+	return &dto.ListVersionInjectableFieldsResponse{Items: items, Total: len(items)}
+}
+
 // ToCreateCommand converts a create version request to a command.
 func (m *TemplateVersionMapper) ToCreateCommand(templateID string, req *dto.CreateVersionRequest, userID string) templateuc.CreateVersionCommand {
 	return templateuc.CreateVersionCommand{
@@ -194,6 +212,15 @@ func (m *TemplateVersionMapper) ToAddInjectableCommand(versionID string, req *dt
 	}
 }
 
+// ToPublishCommand converts a publish request to a command.
+func (m *TemplateVersionMapper) ToPublishCommand(versionID string, req *dto.PublishVersionRequest, userID string) templateuc.PublishVersionCommand {
+	return templateuc.PublishVersionCommand{
+		VersionID:   versionID,
+		UserID:      userID,
+		ChangeNotes: req.ChangeNotes,
+	}
+}
+
 // ToSchedulePublishCommand converts a schedule publish request to a command.
 func (m *TemplateVersionMapper) ToSchedulePublishCommand(versionID string, req *dto.SchedulePublishRequest) templateuc.SchedulePublishCommand {
 	return templateuc.SchedulePublishCommand{
@@ -227,3 +254,36 @@ func (m *TemplateVersionMapper) ToPromoteCommand(
 		PromotedBy:        userID,
 	}
 }
+
+// PublishPreviewToResponse converts a publish preview to a response DTO without PDFs.
+func PublishPreviewToResponse(result *templateuc.PublishPreviewResult) *dto.PublishPreviewResponse {
+	diff := result.Diff
+	resp := &dto.PublishPreviewResponse{
+		VersionID:        result.Version.ID,
+		HasChanges:       !diff.IsEmpty(),
+		Sections:         append([]string{}, diff.Sections...),
+		Blocks:           make([]dto.BlockChangeResponse, 0, len(diff.Blocks)),
+		VariablesAdded:   append([]string{}, diff.VariablesAdded...),
+		VariablesRemoved: append([]string{}, diff.VariablesRemoved...),
+		SignerRoles:      make([]dto.SignerRoleChangeResponse, 0, len(diff.SignerRoles)),
+	}
+	if result.Published != nil {
+		resp.PublishedVersionID = &result.Published.ID
+	}
+	for _, change := range diff.Blocks {
+		resp.Blocks = append(resp.Blocks, dto.BlockChangeResponse{
+			Kind:   change.Kind,
+			NodeID: change.NodeID,
+			Type:   change.Type,
+			Index:  change.Index,
+		})
+	}
+	for _, change := range diff.SignerRoles {
+		resp.SignerRoles = append(resp.SignerRoles, dto.SignerRoleChangeResponse{
+			Kind:  change.Kind,
+			ID:    change.ID,
+			Label: change.Label,
+		})
+	}
+	return resp
+}
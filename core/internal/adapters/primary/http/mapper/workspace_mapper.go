@@ -17,6 +17,7 @@ func WorkspaceToResponse(ws *entity.Workspace) dto.WorkspaceResponse {
 		Type:           string(ws.Type),
 		Status:         string(ws.Status),
 		Role:           string(ws.CurrentRole),
+		DefaultTheme:   ws.DefaultTheme,
 		CreatedAt:      ws.CreatedAt,
 		UpdatedAt:      ws.UpdatedAt,
 		LastAccessedAt: ws.LastAccessedAt,
@@ -46,9 +47,10 @@ func CreateWorkspaceRequestToCommand(req dto.CreateWorkspaceRequest, createdBy s
 // UpdateWorkspaceRequestToCommand converts an update request to a usecase command.
 func UpdateWorkspaceRequestToCommand(id string, req dto.UpdateWorkspaceRequest) organizationuc.UpdateWorkspaceCommand {
 	return organizationuc.UpdateWorkspaceCommand{
-		ID:   id,
-		Name: &req.Name,
-		Code: req.Code,
+		ID:           id,
+		Name:         &req.Name,
+		Code:         req.Code,
+		DefaultTheme: req.DefaultTheme,
 	}
 }
 
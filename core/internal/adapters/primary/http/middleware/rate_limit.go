@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/doc-assembly/core/internal/infra/config"
+)
+
+// RenderRateLimiter throttles CPU-heavy render and preview endpoints per client IP and
+// per workspace. Limits are in-memory token buckets, so each replica enforces its own.
+type RenderRateLimiter struct {
+	perIP        *rateLimiter
+	perWorkspace *rateLimiter
+}
+
+// NewRenderRateLimiter creates a render rate limiter from config.
+// Returns nil when rate limiting is disabled; a nil limiter lets every request through.
+func NewRenderRateLimiter(cfg config.RateLimitConfig) *RenderRateLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &RenderRateLimiter{
+		perIP:        newRateLimiter(cfg.PerIP.Requests, cfg.PerIP.WindowDuration(), time.Now),
+		perWorkspace: newRateLimiter(cfg.PerWorkspace.Requests, cfg.PerWorkspace.WindowDuration(), time.Now),
+	}
+}
+
+// Handler returns the middleware. Requests over either limit are rejected with
+// 429 Too Many Requests and a Retry-After header (seconds).
+// The workspace limit only applies on routes that resolve a workspace context.
+func (l *RenderRateLimiter) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil {
+			c.Next()
+			return
+		}
+
+		if ok, retryAfter := l.perIP.allow(c.ClientIP()); !ok {
+			rejectRateLimited(c, "ip", retryAfter)
+			return
+		}
+		if workspaceID, found := GetWorkspaceID(c); found {
+			if ok, retryAfter := l.perWorkspace.allow(workspaceID); !ok {
+				rejectRateLimited(c, "workspace", retryAfter)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+func rejectRateLimited(c *gin.Context, scope string, retryAfter time.Duration) {
+	seconds := max(1, int(math.Ceil(retryAfter.Seconds())))
+	slog.WarnContext(c.Request.Context(), "render rate limit exceeded",
+		slog.String("scope", scope),
+		slog.String("path", c.FullPath()),
+		slog.Int("retry_after_seconds", seconds),
+	)
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded, retry later"})
+}
+
+// rateLimiter is a keyed token bucket: each key holds up to `requests` tokens,
+// refilled continuously at requests/window. A limiter with requests <= 0 allows everything.
+type rateLimiter struct {
+	requests  float64
+	window    time.Duration
+	perSecond float64
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newRateLimiter(requests int, window time.Duration, now func() time.Time) *rateLimiter {
+	l := &rateLimiter{
+		requests: float64(requests),
+		window:   window,
+		now:      now,
+		buckets:  make(map[string]*tokenBucket),
+	}
+	if requests > 0 && window > 0 {
+		l.perSecond = float64(requests) / window.Seconds()
+	}
+	return l
+}
+
+// allow takes a token for key. When none is left it returns false and the time until one is.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	if l.perSecond == 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.requests, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.requests, b.tokens+now.Sub(b.updated).Seconds()*l.perSecond)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.perSecond * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops buckets idle for a full window (and so refilled) to bound memory.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.window {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rendis/doc-assembly/core/internal/infra/config"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestRenderLimiter(clock *fakeClock, perIP, perWorkspace int) *RenderRateLimiter {
+	return &RenderRateLimiter{
+		perIP:        newRateLimiter(perIP, time.Minute, clock.now),
+		perWorkspace: newRateLimiter(perWorkspace, time.Minute, clock.now),
+	}
+}
+
+func newRateLimitedRouter(limiter *RenderRateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/render", func(c *gin.Context) {
+		if ws := c.GetHeader("X-Workspace-ID"); ws != "" {
+			c.Set(workspaceIDKey, ws)
+		}
+		c.Next()
+	}, limiter.Handler(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func render(r *gin.Engine, ip, workspaceID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/render", nil)
+	req.RemoteAddr = ip + ":12345"
+	if workspaceID != "" {
+		req.Header.Set("X-Workspace-ID", workspaceID)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRenderRateLimiter_TripsAfterLimitPerIP(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := newRateLimitedRouter(newTestRenderLimiter(clock, 3, 0))
+
+	for i := range 3 {
+		assert.Equal(t, http.StatusOK, render(r, "10.0.0.1", "").Code, "request %d", i+1)
+	}
+
+	w := render(r, "10.0.0.1", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "20", w.Header().Get("Retry-After"), "one token refills every 20s at 3/min")
+
+	// Other clients keep their own budget.
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.2", "").Code)
+}
+
+func TestRenderRateLimiter_ResetsOverTime(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := newRateLimitedRouter(newTestRenderLimiter(clock, 3, 0))
+
+	for range 3 {
+		render(r, "10.0.0.1", "")
+	}
+	assert.Equal(t, http.StatusTooManyRequests, render(r, "10.0.0.1", "").Code)
+
+	// A partial refill allows exactly one more request.
+	clock.advance(20 * time.Second)
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.1", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, render(r, "10.0.0.1", "").Code)
+
+	// A full window restores the whole budget.
+	clock.advance(time.Minute)
+	for i := range 3 {
+		assert.Equal(t, http.StatusOK, render(r, "10.0.0.1", "").Code, "request %d", i+1)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, render(r, "10.0.0.1", "").Code)
+}
+
+func TestRenderRateLimiter_LimitsPerWorkspaceAcrossIPs(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := newRateLimitedRouter(newTestRenderLimiter(clock, 100, 2))
+
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.1", "ws-1").Code)
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.2", "ws-1").Code)
+
+	w := render(r, "10.0.0.3", "ws-1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.3", "ws-2").Code)
+	assert.Equal(t, http.StatusOK, render(r, "10.0.0.3", "").Code, "routes without a workspace skip the workspace limit")
+}
+
+func TestRenderRateLimiter_DisabledAllowsAll(t *testing.T) {
+	assert.Nil(t, NewRenderRateLimiter(config.RateLimitConfig{Enabled: false}))
+
+	r := newRateLimitedRouter(nil)
+	for range 50 {
+		assert.Equal(t, http.StatusOK, render(r, "10.0.0.1", "ws-1").Code)
+	}
+}
+
+func TestRateLimiter_SweepsIdleKeys(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(5, time.Minute, clock.now)
+
+	l.allow("a")
+	l.allow("b")
+	clock.advance(2 * time.Minute)
+	l.allow("c")
+
+	assert.Len(t, l.buckets, 1)
+}
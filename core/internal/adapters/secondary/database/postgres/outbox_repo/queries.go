@@ -0,0 +1,108 @@
+package outboxrepo
+
+// SQL queries for outbox event operations.
+const (
+	queryInsert = `
+		INSERT INTO outbox.events (aggregate_type, aggregate_id, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, seq`
+
+	// queryFindDeliverable selects events not yet delivered to consumer $1 whose retry
+	// is due, skipping any event behind an earlier failed event of the same aggregate.
+	// Only events above the consumer's watermark are scanned.
+	queryFindDeliverable = `
+		WITH w AS (
+			SELECT COALESCE((SELECT delivered_seq FROM outbox.consumer_watermarks WHERE consumer = $1), 0) AS seq
+		)
+		SELECT e.id, e.seq, e.aggregate_type, e.aggregate_id, e.event_type, e.payload, e.created_at,
+			COALESCE(d.attempts, 0)
+		FROM w
+		JOIN outbox.events e ON e.seq > w.seq
+		LEFT JOIN outbox.deliveries d ON d.event_id = e.id AND d.consumer = $1
+		WHERE d.delivered_at IS NULL
+			AND (d.next_attempt_at IS NULL OR d.next_attempt_at <= NOW())
+			AND NOT EXISTS (
+				SELECT 1
+				FROM outbox.events prev
+				JOIN outbox.deliveries pd ON pd.event_id = prev.id AND pd.consumer = $1
+				WHERE prev.aggregate_type = e.aggregate_type
+					AND prev.aggregate_id = e.aggregate_id
+					AND prev.seq > w.seq
+					AND prev.seq < e.seq
+					AND pd.delivered_at IS NULL
+			)
+		ORDER BY e.seq
+		LIMIT $2`
+
+	// queryAdvanceWatermark moves consumer $1's watermark up to the event before its first
+	// undelivered one, but no further than the last event recorded before $2: a lower seq
+	// may still belong to a transaction that has not committed yet.
+	queryAdvanceWatermark = `
+		WITH w AS (
+			SELECT COALESCE((SELECT delivered_seq FROM outbox.consumer_watermarks WHERE consumer = $1), 0) AS seq
+		), settled AS (
+			SELECT COALESCE(MAX(e.seq), w.seq) AS seq
+			FROM w
+			LEFT JOIN outbox.events e ON e.seq > w.seq AND e.recorded_at < $2
+			GROUP BY w.seq
+		), undelivered AS (
+			SELECT MIN(e.seq) - 1 AS seq
+			FROM w
+			JOIN outbox.events e ON e.seq > w.seq
+			LEFT JOIN outbox.deliveries d ON d.event_id = e.id AND d.consumer = $1
+			WHERE d.delivered_at IS NULL
+		)
+		INSERT INTO outbox.consumer_watermarks (consumer, delivered_seq, updated_at)
+		SELECT $1, LEAST(settled.seq, COALESCE(undelivered.seq, settled.seq)), NOW()
+		FROM settled, undelivered
+		ON CONFLICT (consumer) DO UPDATE
+		SET delivered_seq = GREATEST(outbox.consumer_watermarks.delivered_seq, EXCLUDED.delivered_seq),
+			updated_at = NOW()`
+
+	// queryDeleteDelivered deletes up to $3 events recorded before $2 that every consumer
+	// in $1 has delivered. With no consumers, every event recorded before $2 qualifies.
+	queryDeleteDelivered = `
+		DELETE FROM outbox.events
+		WHERE id IN (
+			SELECT e.id
+			FROM outbox.events e
+			WHERE e.recorded_at < $2
+				AND e.seq <= COALESCE(
+					(SELECT MIN(COALESCE(w.delivered_seq, 0))
+					FROM unnest($1::text[]) AS c(name)
+					LEFT JOIN outbox.consumer_watermarks w ON w.consumer = c.name),
+					(SELECT MAX(seq) FROM outbox.events)
+				)
+			ORDER BY e.seq
+			LIMIT $3
+		)`
+
+	queryFindByAggregate = `
+		SELECT id, seq, aggregate_type, aggregate_id, event_type, payload, created_at
+		FROM outbox.events
+		WHERE aggregate_type = $1 AND aggregate_id = $2
+		ORDER BY seq`
+
+	queryFindDeliveries = `
+		SELECT event_id, consumer, attempts, last_error, next_attempt_at, delivered_at
+		FROM outbox.deliveries
+		WHERE event_id = $1
+		ORDER BY consumer`
+
+	queryMarkDelivered = `
+		INSERT INTO outbox.deliveries (event_id, consumer, attempts, delivered_at, updated_at)
+		VALUES ($1, $2, 1, NOW(), NOW())
+		ON CONFLICT (event_id, consumer) DO UPDATE
+		SET attempts = outbox.deliveries.attempts + 1, last_error = NULL, next_attempt_at = NULL,
+			delivered_at = NOW(), updated_at = NOW()`
+
+	queryMarkFailed = `
+		INSERT INTO outbox.deliveries (event_id, consumer, attempts, last_error, next_attempt_at, updated_at)
+		VALUES ($1, $2, 1, $3, $4, NOW())
+		ON CONFLICT (event_id, consumer) DO UPDATE
+		SET attempts = outbox.deliveries.attempts + 1, last_error = $3, next_attempt_at = $4, updated_at = NOW()`
+
+	queryTryLock = `SELECT pg_try_advisory_lock(hashtext('outbox:' || $1))`
+
+	queryUnlock = `SELECT pg_advisory_unlock(hashtext('outbox:' || $1))`
+)
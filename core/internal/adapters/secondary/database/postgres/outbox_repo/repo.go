@@ -0,0 +1,202 @@
+package outboxrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// New creates a new outbox repository.
+func New(pool *pgxpool.Pool) port.OutboxRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements port.OutboxRepository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// InsertTx records an event inside the caller's transaction and sets its ID and Seq.
+func (r *Repository) InsertTx(ctx context.Context, tx pgx.Tx, event *entity.OutboxEvent) error {
+	return InsertTx(ctx, tx, event)
+}
+
+// InsertTx records an event inside the caller's transaction and sets its ID and Seq.
+// Repositories without an OutboxRepository call it next to the state change the event describes.
+func InsertTx(ctx context.Context, tx pgx.Tx, event *entity.OutboxEvent) error {
+	err := tx.QueryRow(ctx, queryInsert,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		event.Payload,
+		event.CreatedAt,
+	).Scan(&event.ID, &event.Seq)
+	if err != nil {
+		return fmt.Errorf("inserting outbox event: %w", err)
+	}
+	return nil
+}
+
+// FindDeliverable lists events due for delivery to a consumer, in Seq order.
+func (r *Repository) FindDeliverable(ctx context.Context, consumer string, limit int) ([]*port.DeliverableEvent, error) {
+	rows, err := r.pool.Query(ctx, queryFindDeliverable, consumer, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying deliverable outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*port.DeliverableEvent
+	for rows.Next() {
+		event := &entity.OutboxEvent{}
+		d := &port.DeliverableEvent{Event: event}
+		if err := rows.Scan(
+			&event.ID,
+			&event.Seq,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Payload,
+			&event.CreatedAt,
+			&d.Attempts,
+		); err != nil {
+			return nil, fmt.Errorf("scanning deliverable outbox event: %w", err)
+		}
+		pending = append(pending, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating deliverable outbox events: %w", err)
+	}
+
+	return pending, nil
+}
+
+// FindByAggregate lists all events for an aggregate in Seq order.
+func (r *Repository) FindByAggregate(ctx context.Context, aggregateType, aggregateID string) ([]*entity.OutboxEvent, error) {
+	rows, err := r.pool.Query(ctx, queryFindByAggregate, aggregateType, aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("querying outbox events: %w", err)
+	}
+	return scanEvents(rows)
+}
+
+// FindDeliveries lists the delivery state of an event for every consumer that attempted it.
+func (r *Repository) FindDeliveries(ctx context.Context, eventID string) ([]*entity.OutboxDelivery, error) {
+	rows, err := r.pool.Query(ctx, queryFindDeliveries, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("querying outbox deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.OutboxDelivery
+	for rows.Next() {
+		d := &entity.OutboxDelivery{}
+		if err := rows.Scan(&d.EventID, &d.Consumer, &d.Attempts, &d.LastError, &d.NextAttemptAt, &d.DeliveredAt); err != nil {
+			return nil, fmt.Errorf("scanning outbox delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating outbox deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful delivery to a consumer.
+func (r *Repository) MarkDelivered(ctx context.Context, eventID, consumer string) error {
+	if _, err := r.pool.Exec(ctx, queryMarkDelivered, eventID, consumer); err != nil {
+		return fmt.Errorf("marking outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt; the event is retried at nextAttemptAt.
+func (r *Repository) MarkFailed(ctx context.Context, eventID, consumer, reason string, nextAttemptAt time.Time) error {
+	if _, err := r.pool.Exec(ctx, queryMarkFailed, eventID, consumer, reason, nextAttemptAt); err != nil {
+		return fmt.Errorf("marking outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// AdvanceWatermark moves the consumer's watermark past the events it has delivered
+// without a gap, considering only events recorded before settledBefore.
+func (r *Repository) AdvanceWatermark(ctx context.Context, consumer string, settledBefore time.Time) error {
+	if _, err := r.pool.Exec(ctx, queryAdvanceWatermark, consumer, settledBefore); err != nil {
+		return fmt.Errorf("advancing outbox watermark for %s: %w", consumer, err)
+	}
+	return nil
+}
+
+// DeleteDelivered deletes up to limit events recorded before the cutoff and delivered
+// to every consumer, with their delivery rows. It returns how many events were deleted.
+func (r *Repository) DeleteDelivered(ctx context.Context, consumers []string, recordedBefore time.Time, limit int) (int64, error) {
+	tag, err := r.pool.Exec(ctx, queryDeleteDelivered, consumers, recordedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("deleting delivered outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// WithConsumerLock runs fn while holding a session advisory lock for the consumer.
+func (r *Repository) WithConsumerLock(ctx context.Context, consumer string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquiring outbox lock connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, queryTryLock, consumer).Scan(&locked); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("acquiring outbox lock for %s: %w", consumer, err)
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+
+	fnErr := fn(ctx)
+
+	// Unlock on a fresh context: the caller's may already be cancelled. A session that
+	// cannot be unlocked is closed rather than returned to the pool still holding the lock.
+	if _, err := conn.Exec(context.Background(), queryUnlock, consumer); err != nil {
+		_ = conn.Hijack().Close(context.Background())
+	} else {
+		conn.Release()
+	}
+	return true, fnErr
+}
+
+func scanEvents(rows pgx.Rows) ([]*entity.OutboxEvent, error) {
+	defer rows.Close()
+
+	var events []*entity.OutboxEvent
+	for rows.Next() {
+		event := &entity.OutboxEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.Seq,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Payload,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating outbox events: %w", err)
+	}
+
+	return events, nil
+}
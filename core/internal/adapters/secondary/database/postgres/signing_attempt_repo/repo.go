@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	outboxrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/outbox_repo"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 )
@@ -64,6 +65,8 @@ func (r *Repository) CreateRecipientTx(ctx context.Context, tx pgx.Tx, rec *enti
 	return id, nil
 }
 
+// InsertEventTx records an attempt event. Status transitions are also written to the
+// outbox as SIGNING_STATUS_CHANGED in the same transaction.
 func (r *Repository) InsertEventTx(ctx context.Context, tx pgx.Tx, ev *entity.SigningAttemptEvent) error {
 	_, err := tx.Exec(ctx, `
 		INSERT INTO execution.signing_attempt_events (
@@ -76,6 +79,15 @@ func (r *Repository) InsertEventTx(ctx context.Context, tx pgx.Tx, ev *entity.Si
 	if err != nil {
 		return fmt.Errorf("inserting signing attempt event: %w", err)
 	}
+
+	// Status transitions are also published to other services through the outbox.
+	outboxEvent, err := entity.NewSigningStatusChangedEvent(ev)
+	if err != nil {
+		return err
+	}
+	if outboxEvent != nil {
+		return outboxrepo.InsertTx(ctx, tx, outboxEvent)
+	}
 	return nil
 }
 
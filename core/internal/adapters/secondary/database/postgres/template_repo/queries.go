@@ -17,7 +17,7 @@ const (
 	queryPublishedVersion = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE template_id = $1 AND status = 'PUBLISHED'`
 
@@ -56,7 +56,7 @@ const (
 	queryAllVersions = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE template_id = $1
 		ORDER BY version_number DESC`
@@ -89,7 +89,7 @@ const (
 		WHERE t.folder_id = $1
 		ORDER BY t.title`
 
-	queryFindPublicLibrary = `
+	queryFindPublicLibraryBase = `
 		SELECT
 			t.id, t.workspace_id, t.folder_id, t.document_type_id,
 			(SELECT dt.code FROM content.document_types dt WHERE dt.id = t.document_type_id) as document_type_code,
@@ -100,9 +100,12 @@ const (
 			(SELECT COUNT(*) FROM content.template_versions WHERE template_id = t.id AND status = 'SCHEDULED') as scheduled_version_count,
 			(SELECT version_number FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED' LIMIT 1) as published_version_number
 		FROM content.templates t
+		JOIN tenancy.workspaces w ON t.workspace_id = w.id
+		LEFT JOIN organizer.folders f ON t.folder_id = f.id
 		WHERE t.is_public_library = true
-			AND EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED')
-		ORDER BY t.title`
+			AND w.status = 'ACTIVE' AND w.is_sandbox = false
+			AND w.tenant_id IS NOT DISTINCT FROM (SELECT tenant_id FROM tenancy.workspaces WHERE id = $1)
+			AND EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED')`
 
 	queryUpdate = `
 		UPDATE content.templates
@@ -111,6 +114,17 @@ const (
 
 	queryDelete = `DELETE FROM content.templates WHERE id = $1`
 
+	queryExistsInPublicLibrary = `
+		SELECT EXISTS(
+			SELECT 1
+			FROM content.templates t
+			JOIN tenancy.workspaces w ON t.workspace_id = w.id
+			WHERE t.id = $2 AND t.is_public_library = true
+				AND w.status = 'ACTIVE' AND w.is_sandbox = false
+				AND w.tenant_id IS NOT DISTINCT FROM (SELECT tenant_id FROM tenancy.workspaces WHERE id = $1)
+				AND EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED')
+		)`
+
 	queryExistsByTitle = `SELECT EXISTS(SELECT 1 FROM content.templates WHERE workspace_id = $1 AND title = $2)`
 
 	queryExistsByTitleExcluding = `SELECT EXISTS(SELECT 1 FROM content.templates WHERE workspace_id = $1 AND title = $2 AND id != $3)`
@@ -154,4 +168,43 @@ const (
 		UPDATE content.templates
 		SET document_type_id = $2, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1`
+
+	// Library clone queries, run in one transaction by CreateClone.
+
+	queryCloneVersion = `
+		INSERT INTO content.template_versions (
+			template_id, version_number, name, description, content_structure,
+			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
+			created_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	queryCloneDefinition = `
+		INSERT INTO content.injectable_definitions
+			(id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, is_active, is_deleted, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	// queryEnsureSystemDefinitions backs system keys with definitions so they can be referenced.
+	// New definitions are inactive: a clone must not enable a key for every workspace.
+	// Existing definitions are left untouched, including their is_active status.
+	queryEnsureSystemDefinitions = `
+		INSERT INTO content.system_injectable_definitions (key, is_active, created_at, updated_at)
+		SELECT key, false, NOW(), NOW() FROM UNNEST($1::varchar[]) AS key
+		ON CONFLICT (key) DO NOTHING`
+
+	queryCloneVersionInjectable = `
+		INSERT INTO content.template_version_injectables (
+			template_version_id, injectable_definition_id, system_injectable_key, is_required, default_value, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	queryCloneTag = `
+		INSERT INTO organizer.tags (id, workspace_id, name, color, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	queryCloneTemplateTag = `
+		INSERT INTO content.template_tags (template_id, tag_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
 )
@@ -164,9 +164,14 @@ func (r *Repository) FindByFolder(ctx context.Context, folderID string) ([]*enti
 	return templates, nil
 }
 
-// FindPublicLibrary lists all public library templates (that have a published version).
-func (r *Repository) FindPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error) {
-	rows, err := r.pool.Query(ctx, queryFindPublicLibrary)
+// FindPublicLibrary lists the public library templates (that have a published version)
+// of the workspace's tenant, skipping sandbox and inactive workspaces.
+func (r *Repository) FindPublicLibrary(ctx context.Context, workspaceID string, filters port.TemplateFilters) ([]*entity.TemplateListItem, error) {
+	filterQuery, filterArgs := buildTemplateFilters(filters, 2)
+	query := queryFindPublicLibraryBase + filterQuery
+	args := append([]any{workspaceID}, filterArgs...)
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("querying public library templates: %w", err)
 	}
@@ -185,6 +190,17 @@ func (r *Repository) FindPublicLibrary(ctx context.Context, workspaceID string)
 	return templates, nil
 }
 
+// ExistsInPublicLibrary checks if a template is listed in the public library of the workspace's tenant.
+func (r *Repository) ExistsInPublicLibrary(ctx context.Context, workspaceID, templateID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, queryExistsInPublicLibrary, workspaceID, templateID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("checking public library template: %w", err)
+	}
+
+	return exists, nil
+}
+
 // loadPublishedVersion loads the published version for a template.
 func (r *Repository) loadPublishedVersion(ctx context.Context, templateID string) (*entity.TemplateVersion, error) {
 	version := &entity.TemplateVersion{}
@@ -202,6 +218,7 @@ func (r *Repository) loadPublishedVersion(ctx context.Context, templateID string
 		&version.ArchivedAt,
 		&version.PublishedBy,
 		&version.ArchivedBy,
+		&version.ChangeNotes,
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
@@ -326,7 +343,7 @@ func (r *Repository) loadAllVersions(ctx context.Context, templateID string) ([]
 			&v.ID, &v.TemplateID, &v.VersionNumber, &v.Name, &v.Description,
 			&v.ContentStructure, &v.Status, &v.ScheduledPublishAt, &v.ScheduledArchiveAt,
 			&v.PublishedAt, &v.ArchivedAt, &v.PublishedBy, &v.ArchivedBy,
-			&v.CreatedBy, &v.CreatedAt, &v.UpdatedAt,
+			&v.ChangeNotes, &v.CreatedBy, &v.CreatedAt, &v.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scanning template version: %w", err)
 		}
@@ -463,6 +480,74 @@ func (r *Repository) loadTagsForTemplates(ctx context.Context, templates []*enti
 	return nil
 }
 
+// CreateClone creates a cloned template with its version, copied definitions, version
+// injectables and tags in one transaction. It sets the template and version IDs.
+func (r *Repository) CreateClone(ctx context.Context, clone *entity.TemplateClone) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning clone transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	tmpl, version := clone.Template, clone.Version
+	if err := tx.QueryRow(ctx, queryCreate,
+		tmpl.WorkspaceID, tmpl.FolderID, tmpl.DocumentTypeID, tmpl.Title,
+		tmpl.IsPublicLibrary, tmpl.Process, tmpl.ProcessType, tmpl.CreatedAt,
+	).Scan(&tmpl.ID); err != nil {
+		return fmt.Errorf("creating cloned template: %w", err)
+	}
+
+	version.TemplateID = tmpl.ID
+	if err := tx.QueryRow(ctx, queryCloneVersion,
+		version.TemplateID, version.VersionNumber, version.Name, version.Description, version.ContentStructure,
+		version.Status, version.ScheduledPublishAt, version.ScheduledArchiveAt, version.SigningWorkflowConfig,
+		version.CreatedBy, version.CreatedAt,
+	).Scan(&version.ID); err != nil {
+		return fmt.Errorf("creating cloned version: %w", err)
+	}
+
+	for _, def := range clone.Definitions {
+		if _, err := tx.Exec(ctx, queryCloneDefinition,
+			def.ID, def.WorkspaceID, def.Key, def.Label, def.Description, def.DataType, def.Metadata,
+			def.FormatConfig, def.DefaultValue, def.IsActive, def.IsDeleted, def.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("copying injectable %s: %w", def.Key, err)
+		}
+	}
+
+	if len(clone.SystemKeys) > 0 {
+		if _, err := tx.Exec(ctx, queryEnsureSystemDefinitions, clone.SystemKeys); err != nil {
+			return fmt.Errorf("backing system injectables: %w", err)
+		}
+	}
+
+	for _, injectable := range clone.Injectables {
+		injectable.TemplateVersionID = version.ID
+		if _, err := tx.Exec(ctx, queryCloneVersionInjectable,
+			injectable.TemplateVersionID, injectable.InjectableDefinitionID, injectable.SystemInjectableKey,
+			injectable.IsRequired, injectable.DefaultValue, injectable.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("creating cloned injectable: %w", err)
+		}
+	}
+
+	for _, tag := range clone.Tags {
+		if _, err := tx.Exec(ctx, queryCloneTag, tag.ID, tag.WorkspaceID, tag.Name, tag.Color, tag.CreatedAt); err != nil {
+			return fmt.Errorf("creating tag %s: %w", tag.Name, err)
+		}
+	}
+	for _, tagID := range clone.TagIDs {
+		if _, err := tx.Exec(ctx, queryCloneTemplateTag, tmpl.ID, tagID); err != nil {
+			return fmt.Errorf("adding tag to cloned template: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing clone transaction: %w", err)
+	}
+	return nil
+}
+
 // Update updates a template.
 func (r *Repository) Update(ctx context.Context, template *entity.Template) error {
 	result, err := r.pool.Exec(ctx, queryUpdate,
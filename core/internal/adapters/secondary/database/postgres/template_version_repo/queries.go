@@ -14,7 +14,7 @@ const (
 	queryFindByID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
-			published_at, archived_at, published_by, archived_by, created_by, created_at, updated_at
+			published_at, archived_at, published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE id = $1`
 
@@ -22,7 +22,7 @@ const (
 		SELECT
 			tvi.id, tvi.template_version_id, tvi.injectable_definition_id, tvi.system_injectable_key,
 			tvi.is_required, tvi.default_value, tvi.created_at,
-			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.created_at, id.updated_at
+			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.metadata, id.created_at, id.updated_at
 		FROM content.template_version_injectables tvi
 		LEFT JOIN content.injectable_definitions id ON tvi.injectable_definition_id = id.id
 		WHERE tvi.template_version_id = $1
@@ -37,7 +37,7 @@ const (
 	queryFindByTemplateID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
-			published_at, archived_at, published_by, archived_by, created_by, created_at, updated_at
+			published_at, archived_at, published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE template_id = $1
 		ORDER BY version_number DESC`
@@ -45,14 +45,14 @@ const (
 	queryFindPublishedByTemplateID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
-			published_at, archived_at, published_by, archived_by, created_by, created_at, updated_at
+			published_at, archived_at, published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE template_id = $1 AND status = 'PUBLISHED'`
 
 	queryFindScheduledToPublish = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
-			published_at, archived_at, published_by, archived_by, created_by, created_at, updated_at
+			published_at, archived_at, published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE status = 'SCHEDULED' AND scheduled_publish_at <= $1
 		ORDER BY scheduled_publish_at`
@@ -60,7 +60,7 @@ const (
 	queryFindScheduledToArchive = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, signing_workflow_config,
-			published_at, archived_at, published_by, archived_by, created_by, created_at, updated_at
+			published_at, archived_at, published_by, archived_by, change_notes, created_by, created_at, updated_at
 		FROM content.template_versions
 		WHERE status = 'PUBLISHED' AND scheduled_archive_at IS NOT NULL AND scheduled_archive_at <= $1
 		ORDER BY scheduled_archive_at`
@@ -70,7 +70,7 @@ const (
 		SET name = $2, description = $3, content_structure = $4, status = $5,
 			scheduled_publish_at = $6, scheduled_archive_at = $7, signing_workflow_config = $8,
 			published_at = $9, archived_at = $10, published_by = $11, archived_by = $12,
-			updated_at = $13
+			updated_at = $13, change_notes = $14
 		WHERE id = $1`
 
 	queryUpdateStatusPublished = `
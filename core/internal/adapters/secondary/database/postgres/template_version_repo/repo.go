@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/common"
+	outboxrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/outbox_repo"
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 )
@@ -121,12 +122,13 @@ type injectableRow interface {
 func scanVersionInjectable(row injectableRow) (*entity.VersionInjectableWithDefinition, error) {
 	iwd := &entity.VersionInjectableWithDefinition{}
 	var defID, defWorkspaceID, defKey, defLabel, defDescription, defDataType *string
+	var defMetadata map[string]any
 	var defCreatedAt, defUpdatedAt *time.Time
 
 	if err := row.Scan(
 		&iwd.ID, &iwd.TemplateVersionID, &iwd.InjectableDefinitionID, &iwd.SystemInjectableKey,
 		&iwd.IsRequired, &iwd.DefaultValue, &iwd.CreatedAt,
-		&defID, &defWorkspaceID, &defKey, &defLabel, &defDescription, &defDataType, &defCreatedAt, &defUpdatedAt,
+		&defID, &defWorkspaceID, &defKey, &defLabel, &defDescription, &defDataType, &defMetadata, &defCreatedAt, &defUpdatedAt,
 	); err != nil {
 		return nil, fmt.Errorf("scanning version injectable: %w", err)
 	}
@@ -139,6 +141,7 @@ func scanVersionInjectable(row injectableRow) (*entity.VersionInjectableWithDefi
 			Label:       common.SafeString(defLabel),
 			Description: common.SafeString(defDescription),
 			DataType:    entity.InjectableDataType(common.SafeString(defDataType)),
+			Metadata:    defMetadata,
 			CreatedAt:   common.SafeTime(defCreatedAt),
 			UpdatedAt:   defUpdatedAt,
 		}
@@ -270,6 +273,7 @@ func scanTemplateVersion(row templateVersionRow) (*entity.TemplateVersion, error
 		&version.ArchivedAt,
 		&version.PublishedBy,
 		&version.ArchivedBy,
+		&version.ChangeNotes,
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
@@ -298,7 +302,60 @@ func collectTemplateVersions(rows pgx.Rows, scanErrMsg, iterateErrMsg string) ([
 
 // Update updates a template version.
 func (r *Repository) Update(ctx context.Context, version *entity.TemplateVersion) error {
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	result, err := r.pool.Exec(ctx, queryUpdate, updateArgs(version)...)
+	if err != nil {
+		return fmt.Errorf("updating template version: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// Publish persists a published version, the previously published version it archives (nil on
+// first publish) and the publish event in a single transaction.
+func (r *Repository) Publish(ctx context.Context, version, archived *entity.TemplateVersion, event *entity.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin publish tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if archived != nil {
+		if err := updateTx(ctx, tx, archived); err != nil {
+			return fmt.Errorf("archiving current version: %w", err)
+		}
+	}
+	if err := updateTx(ctx, tx, version); err != nil {
+		return fmt.Errorf("publishing version: %w", err)
+	}
+	if event != nil {
+		if err := outboxrepo.InsertTx(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit publish tx: %w", err)
+	}
+	return nil
+}
+
+func updateTx(ctx context.Context, tx pgx.Tx, version *entity.TemplateVersion) error {
+	result, err := tx.Exec(ctx, queryUpdate, updateArgs(version)...)
+	if err != nil {
+		return fmt.Errorf("updating template version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrVersionNotFound
+	}
+	return nil
+}
+
+func updateArgs(version *entity.TemplateVersion) []any {
+	return []any{
 		version.ID,
 		version.Name,
 		version.Description,
@@ -312,16 +369,8 @@ func (r *Repository) Update(ctx context.Context, version *entity.TemplateVersion
 		version.PublishedBy,
 		version.ArchivedBy,
 		version.UpdatedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("updating template version: %w", err)
-	}
-
-	if result.RowsAffected() == 0 {
-		return entity.ErrVersionNotFound
+		version.ChangeNotes,
 	}
-
-	return nil
 }
 
 // UpdateStatus updates a version's status with optional user tracking.
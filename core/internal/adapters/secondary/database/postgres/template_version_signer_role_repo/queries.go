@@ -44,4 +44,12 @@ const (
 		SELECT $2, role_name, anchor_string, signer_order, NOW()
 		FROM content.template_version_signer_roles
 		WHERE template_version_id = $1`
+
+	queryFindRoleNamesByWorkspace = `
+		SELECT DISTINCT r.role_name
+		FROM content.template_version_signer_roles r
+		JOIN content.template_versions v ON v.id = r.template_version_id
+		JOIN content.templates t ON t.id = v.template_id
+		WHERE t.workspace_id = $1
+		ORDER BY r.role_name`
 )
@@ -189,3 +189,27 @@ func (r *Repository) CopyFromVersion(ctx context.Context, sourceVersionID, targe
 
 	return nil
 }
+
+// FindRoleNamesByWorkspace lists the distinct role names used by any template version in a workspace.
+func (r *Repository) FindRoleNamesByWorkspace(ctx context.Context, workspaceID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, queryFindRoleNamesByWorkspace, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace signer role names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scanning workspace signer role name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating workspace signer role names: %w", err)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,28 @@
+package workspaceassetrepo
+
+const (
+	querySave = `
+		INSERT INTO tenancy.workspace_assets
+			(workspace_id, kind, key, filename, content_type, size, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	queryFindByID = `
+		SELECT id, workspace_id, kind, key, filename, content_type, size, created_by, created_at
+		FROM tenancy.workspace_assets
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryList = `
+		SELECT id, workspace_id, kind, key, filename, content_type, size, created_by, created_at
+		FROM tenancy.workspace_assets
+		WHERE workspace_id = $1
+		ORDER BY kind DESC, filename, created_at`
+
+	queryDelete = `
+		DELETE FROM tenancy.workspace_assets
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryDeleteLogo = `
+		DELETE FROM tenancy.workspace_assets
+		WHERE workspace_id = $1 AND kind = 'LOGO'`
+)
@@ -0,0 +1,126 @@
+package workspaceassetrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// Repository implements port.WorkspaceAssetRepository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// New creates a new workspace asset repository.
+func New(pool *pgxpool.Pool) port.WorkspaceAssetRepository {
+	return &Repository{pool: pool}
+}
+
+// Save persists a new workspace asset and sets its generated ID.
+func (r *Repository) Save(ctx context.Context, asset *entity.WorkspaceAsset) error {
+	return insert(ctx, r.pool, asset)
+}
+
+// ReplaceLogo removes the workspace's current logo record, if any, and saves asset as
+// its logo in a single transaction, so a failed save keeps the old logo.
+func (r *Repository) ReplaceLogo(ctx context.Context, asset *entity.WorkspaceAsset) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin replace logo tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, queryDeleteLogo, asset.WorkspaceID); err != nil {
+		return fmt.Errorf("deleting workspace logo: %w", err)
+	}
+	if err := insert(ctx, tx, asset); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit replace logo tx: %w", err)
+	}
+	return nil
+}
+
+// rowQuerier is the query method shared by the pool and a transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insert(ctx context.Context, q rowQuerier, asset *entity.WorkspaceAsset) error {
+	err := q.QueryRow(ctx, querySave,
+		asset.WorkspaceID,
+		asset.Kind,
+		asset.Key,
+		asset.Filename,
+		asset.ContentType,
+		asset.Size,
+		asset.CreatedBy,
+		asset.CreatedAt,
+	).Scan(&asset.ID)
+	if err != nil {
+		return fmt.Errorf("inserting workspace asset: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds an asset by ID within a workspace.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.WorkspaceAsset, error) {
+	var a entity.WorkspaceAsset
+	err := r.pool.QueryRow(ctx, queryFindByID, workspaceID, id).Scan(
+		&a.ID, &a.WorkspaceID, &a.Kind, &a.Key, &a.Filename,
+		&a.ContentType, &a.Size, &a.CreatedBy, &a.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrWorkspaceAssetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace asset: %w", err)
+	}
+
+	return &a, nil
+}
+
+// List returns the workspace's assets, logo first, then fonts by filename.
+func (r *Repository) List(ctx context.Context, workspaceID string) ([]*entity.WorkspaceAsset, error) {
+	rows, err := r.pool.Query(ctx, queryList, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace assets: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.WorkspaceAsset
+	for rows.Next() {
+		var a entity.WorkspaceAsset
+		if err := rows.Scan(
+			&a.ID, &a.WorkspaceID, &a.Kind, &a.Key, &a.Filename,
+			&a.ContentType, &a.Size, &a.CreatedBy, &a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning workspace asset: %w", err)
+		}
+		result = append(result, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating workspace assets: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete removes an asset record.
+func (r *Repository) Delete(ctx context.Context, workspaceID, id string) error {
+	_, err := r.pool.Exec(ctx, queryDelete, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("deleting workspace asset: %w", err)
+	}
+
+	return nil
+}
@@ -9,27 +9,27 @@ const (
 
 	queryFindByID = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE id = $1`
 
 	queryFindByCode = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE tenant_id = $1 AND code = $2 AND is_sandbox = FALSE
 		LIMIT 1`
 
 	queryFindByCodeIncludingSandbox = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE tenant_id = $1 AND code = $2
 		LIMIT 1`
 
 	queryFindSandboxByParentID = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE sandbox_of_id = $1 AND is_sandbox = TRUE`
 
@@ -38,7 +38,7 @@ const (
 	// When query is empty: orders by access history (most recent), then by name.
 	queryFindByTenantPaginated = `
 		SELECT w.id, w.tenant_id, w.name, w.code, w.type, w.status,
-		       w.is_sandbox, w.sandbox_of_id, w.created_at, w.updated_at
+		       w.is_sandbox, w.sandbox_of_id, w.created_at, w.updated_at, w.default_theme
 		FROM tenancy.workspaces w
 		LEFT JOIN identity.user_access_history h
 			ON w.id = h.entity_id
@@ -110,7 +110,7 @@ const (
 
 	queryFindByUser = `
 		SELECT w.id, w.tenant_id, w.name, w.code, w.type, w.status,
-		       w.is_sandbox, w.sandbox_of_id, w.created_at, w.updated_at, m.role
+		       w.is_sandbox, w.sandbox_of_id, w.created_at, w.updated_at, w.default_theme, m.role
 		FROM tenancy.workspaces w
 		INNER JOIN identity.workspace_members m ON w.id = m.workspace_id
 		WHERE m.user_id = $1 AND m.membership_status = 'ACTIVE' AND w.status != 'ARCHIVED' AND w.is_sandbox = FALSE
@@ -118,19 +118,19 @@ const (
 
 	queryFindSystemByTenantNull = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE tenant_id IS NULL AND type = 'SYSTEM'`
 
 	queryFindSystemByTenant = `
 		SELECT id, tenant_id, name, code, type, status,
-		       is_sandbox, sandbox_of_id, created_at, updated_at
+		       is_sandbox, sandbox_of_id, created_at, updated_at, default_theme
 		FROM tenancy.workspaces
 		WHERE tenant_id = $1 AND type = 'SYSTEM'`
 
 	queryUpdate = `
 		UPDATE tenancy.workspaces
-		SET name = $2, code = $3, updated_at = $4
+		SET name = $2, code = $3, updated_at = $4, default_theme = $5
 		WHERE id = $1`
 
 	queryUpdateStatus = `
@@ -92,6 +92,7 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.Workspace
 		&ws.SandboxOfID,
 		&ws.CreatedAt,
 		&ws.UpdatedAt,
+		&ws.DefaultTheme,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, entity.ErrWorkspaceNotFound
@@ -117,6 +118,7 @@ func (r *Repository) FindByCode(ctx context.Context, tenantID, code string) (*en
 		&ws.SandboxOfID,
 		&ws.CreatedAt,
 		&ws.UpdatedAt,
+		&ws.DefaultTheme,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, entity.ErrWorkspaceNotFound
@@ -142,6 +144,7 @@ func (r *Repository) FindByCodeIncludingSandbox(ctx context.Context, tenantID, c
 		&ws.SandboxOfID,
 		&ws.CreatedAt,
 		&ws.UpdatedAt,
+		&ws.DefaultTheme,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, entity.ErrWorkspaceNotFound
@@ -167,6 +170,7 @@ func (r *Repository) FindSandboxByParentID(ctx context.Context, parentID string)
 		&ws.SandboxOfID,
 		&ws.CreatedAt,
 		&ws.UpdatedAt,
+		&ws.DefaultTheme,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, entity.ErrSandboxNotFound
@@ -244,6 +248,7 @@ func (r *Repository) FindSystemByTenant(ctx context.Context, tenantID *string) (
 		&ws.SandboxOfID,
 		&ws.CreatedAt,
 		&ws.UpdatedAt,
+		&ws.DefaultTheme,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, entity.ErrWorkspaceNotFound
@@ -262,6 +267,7 @@ func (r *Repository) Update(ctx context.Context, workspace *entity.Workspace) er
 		workspace.Name,
 		workspace.Code,
 		workspace.UpdatedAt,
+		workspace.DefaultTheme,
 	)
 	if err != nil {
 		return fmt.Errorf("updating workspace: %w", err)
@@ -336,6 +342,7 @@ func scanWorkspaces(rows pgx.Rows) ([]*entity.Workspace, error) {
 			&ws.SandboxOfID,
 			&ws.CreatedAt,
 			&ws.UpdatedAt,
+			&ws.DefaultTheme,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning workspace: %w", err)
@@ -362,6 +369,7 @@ func scanWorkspacesWithRole(rows pgx.Rows) ([]*entity.WorkspaceWithRole, error)
 			&ws.SandboxOfID,
 			&ws.CreatedAt,
 			&ws.UpdatedAt,
+			&ws.DefaultTheme,
 			&role,
 		)
 		if err != nil {
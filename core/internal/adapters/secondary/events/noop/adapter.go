@@ -0,0 +1,27 @@
+package noop
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// Adapter implements port.EventSink as a no-op (logs only).
+type Adapter struct{}
+
+// New creates a new no-op event sink.
+func New() port.EventSink {
+	return &Adapter{}
+}
+
+// Deliver logs the event but does not send it anywhere.
+func (a *Adapter) Deliver(ctx context.Context, event *entity.OutboxEvent) error {
+	slog.InfoContext(ctx, "event (noop)",
+		slog.String("event_id", event.ID),
+		slog.String("event_type", event.EventType),
+		slog.String("aggregate_id", event.AggregateID),
+	)
+	return nil
+}
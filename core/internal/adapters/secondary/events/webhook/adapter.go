@@ -0,0 +1,114 @@
+// Package webhook delivers outbox events as signed JSON POST requests.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// Header names set on every delivery.
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Config contains the configuration for the webhook event sink.
+type Config struct {
+	// URL receives a POST per event.
+	URL string
+
+	// Secret signs the request body with HMAC-SHA256 when set.
+	// The hex digest is sent as "sha256=<digest>" in the X-Webhook-Signature header.
+	Secret string //nolint:gosec
+
+	// Timeout bounds each delivery. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// Envelope is the JSON body posted for each event.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Adapter implements port.EventSink by POSTing events to a webhook URL.
+type Adapter struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New creates a new webhook event sink.
+func New(cfg Config) (port.EventSink, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, errors.New("webhook event sink: url is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Adapter{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}, nil
+}
+
+// Deliver posts the event. Any non-2xx response is treated as a failed delivery.
+func (a *Adapter) Deliver(ctx context.Context, event *entity.OutboxEvent) error {
+	body, err := json.Marshal(Envelope{
+		ID:            event.ID,
+		Type:          event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		OccurredAt:    event.CreatedAt,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling event envelope: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, event.ID)
+	req.Header.Set(HeaderEventType, event.EventType)
+	if a.cfg.Secret != "" {
+		req.Header.Set(HeaderSignature, "sha256="+Sign(a.cfg.Secret, body))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivering event %s: %w", event.ID, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("delivering event %s: webhook responded %d", event.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body, as sent in the signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+func testEvent() *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		ID:            "evt-1",
+		AggregateType: entity.AggregateTemplate,
+		AggregateID:   "tpl-1",
+		EventType:     entity.EventTemplateVersionPublished,
+		Payload:       json.RawMessage(`{"templateId":"tpl-1","versionNumber":2}`),
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestAdapter_DeliverPostsSignedEnvelope(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink, err := New(Config{URL: srv.URL, Secret: "s3cret"})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Deliver(context.Background(), testEvent()))
+
+	assert.Equal(t, "evt-1", headers.Get(HeaderEventID))
+	assert.Equal(t, entity.EventTemplateVersionPublished, headers.Get(HeaderEventType))
+	assert.Equal(t, "sha256="+Sign("s3cret", body), headers.Get(HeaderSignature))
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal(body, &envelope))
+	assert.Equal(t, "evt-1", envelope.ID)
+	assert.Equal(t, "tpl-1", envelope.AggregateID)
+	assert.JSONEq(t, `{"templateId":"tpl-1","versionNumber":2}`, string(envelope.Data))
+}
+
+func TestAdapter_DeliverWithoutSecretOmitsSignature(t *testing.T) {
+	var headers http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	sink, err := New(Config{URL: srv.URL})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Deliver(context.Background(), testEvent()))
+	assert.Empty(t, headers.Get(HeaderSignature))
+}
+
+func TestAdapter_DeliverFailsOnNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sink, err := New(Config{URL: srv.URL})
+	require.NoError(t, err)
+
+	err = sink.Deliver(context.Background(), testEvent())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+func TestNew_RequiresURL(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
@@ -70,6 +70,30 @@ func (a *Adapter) setAuthHeader(req *http.Request) {
 	req.Header.Set("Authorization", a.config.APIKey)
 }
 
+// Ping verifies the Documenso API is reachable and accepts the configured API key.
+// Any response other than a server error or an auth rejection counts as reachable.
+func (a *Adapter) Ping(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, a.config.BaseURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	a.setAuthHeader(httpReq)
+
+	resp, err := a.httpClient.Do(httpReq) //nolint:gosec // URL is built from configured provider base URL
+	if err != nil {
+		return fmt.Errorf("documenso unreachable at %s: %w", a.config.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("documenso rejected the API key (status %d)", resp.StatusCode)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("documenso API error (status %d)", resp.StatusCode)
+	}
+	return nil
+}
+
 // SubmitAttemptDocument uploads a PDF document to Documenso and creates a signing envelope.
 func (a *Adapter) SubmitAttemptDocument(ctx context.Context, req *port.SubmitAttemptDocumentRequest) (*port.SubmitAttemptDocumentResult, error) {
 	envelopeID, err := a.createEnvelope(ctx, req.Title, req.CorrelationKey, req.PDF)
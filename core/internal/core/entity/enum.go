@@ -234,12 +234,13 @@ type InjectableSourceType string
 const (
 	InjectableSourceTypeInternal InjectableSourceType = "INTERNAL"
 	InjectableSourceTypeExternal InjectableSourceType = "EXTERNAL"
+	InjectableSourceTypeComputed InjectableSourceType = "COMPUTED" // Derived from an expression over other injectables
 )
 
 // IsValid checks if the injectable source type is valid.
 func (i InjectableSourceType) IsValid() bool {
 	switch i {
-	case InjectableSourceTypeInternal, InjectableSourceTypeExternal:
+	case InjectableSourceTypeInternal, InjectableSourceTypeExternal, InjectableSourceTypeComputed:
 		return true
 	}
 	return false
@@ -6,28 +6,60 @@ import (
 	"strings"
 )
 
+// Error categories. Each domain error below belongs to at most one category and
+// matches it with errors.Is, so callers can tell a missing resource from invalid
+// input or a failed render without knowing every specific error. ErrUnauthorized
+// and ErrForbidden are the authentication and authorization categories.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrConflict     = errors.New("conflict")
+	ErrRenderFailed = errors.New("render failed")
+)
+
+// categoryError is an error that also matches its category with errors.Is.
+type categoryError struct {
+	err      error
+	category error
+}
+
+func (e *categoryError) Error() string { return e.err.Error() }
+
+func (e *categoryError) Unwrap() []error { return []error{e.err, e.category} }
+
+// WithCategory returns err unchanged in message, additionally matching category with errors.Is.
+func WithCategory(category, err error) error {
+	return &categoryError{err: err, category: category}
+}
+
+func newNotFound(msg string) error     { return WithCategory(ErrNotFound, errors.New(msg)) }
+func newInvalid(msg string) error      { return WithCategory(ErrValidation, errors.New(msg)) }
+func newConflict(msg string) error     { return WithCategory(ErrConflict, errors.New(msg)) }
+func newUnauthorized(msg string) error { return WithCategory(ErrUnauthorized, errors.New(msg)) }
+func newForbidden(msg string) error    { return WithCategory(ErrForbidden, errors.New(msg)) }
+
 // Authentication and Authorization errors.
 var (
 	ErrUnauthorized     = errors.New("unauthorized")
 	ErrForbidden        = errors.New("access denied")
 	ErrTooManyRequests  = errors.New("too many requests")
-	ErrInvalidToken     = errors.New("invalid token")
-	ErrTokenExpired     = errors.New("token expired")
-	ErrMissingToken     = errors.New("missing authorization token")
-	ErrInsufficientRole = errors.New("insufficient role permissions")
+	ErrInvalidToken     = newUnauthorized("invalid token")
+	ErrTokenExpired     = newUnauthorized("token expired")
+	ErrMissingToken     = newUnauthorized("missing authorization token")
+	ErrInsufficientRole = newForbidden("insufficient role permissions")
 	ErrUnknownIssuer    = errors.New("unknown token issuer")
 )
 
 // API Key errors (for internal service-to-service authentication).
 var (
-	ErrMissingAPIKey = errors.New("missing API key")
-	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrMissingAPIKey = newUnauthorized("missing API key")
+	ErrInvalidAPIKey = newUnauthorized("invalid API key")
 )
 
 // Context errors.
 var (
 	ErrMissingWorkspaceID = errors.New("missing workspace ID")
-	ErrMissingTenantID    = errors.New("missing tenant ID")
+	ErrMissingTenantID    = newInvalid("missing tenant ID")
 	ErrMissingUserID      = errors.New("missing user ID")
 	ErrInvalidWorkspaceID = errors.New("invalid workspace ID format")
 	ErrInvalidTenantID    = errors.New("invalid tenant ID format")
@@ -36,53 +68,53 @@ var (
 
 // System Role errors.
 var (
-	ErrSystemRoleNotFound = errors.New("system role not found")
-	ErrSystemRoleExists   = errors.New("user already has a system role")
-	ErrInvalidSystemRole  = errors.New("invalid system role")
+	ErrSystemRoleNotFound = newNotFound("system role not found")
+	ErrSystemRoleExists   = newConflict("user already has a system role")
+	ErrInvalidSystemRole  = newInvalid("invalid system role")
 )
 
 // Tenant Member errors.
 var (
-	ErrTenantMemberNotFound    = errors.New("tenant member not found")
-	ErrTenantMemberExists      = errors.New("user is already a member of this tenant")
-	ErrTenantAccessDenied      = errors.New("tenant access denied")
-	ErrInvalidTenantRole       = errors.New("invalid tenant role")
-	ErrCannotRemoveTenantOwner = errors.New("cannot remove tenant owner")
+	ErrTenantMemberNotFound    = newNotFound("tenant member not found")
+	ErrTenantMemberExists      = newConflict("user is already a member of this tenant")
+	ErrTenantAccessDenied      = newForbidden("tenant access denied")
+	ErrInvalidTenantRole       = newInvalid("invalid tenant role")
+	ErrCannotRemoveTenantOwner = newInvalid("cannot remove tenant owner")
 )
 
 // Tenant errors.
 var (
-	ErrTenantNotFound           = errors.New("tenant not found")
-	ErrTenantAlreadyExists      = errors.New("tenant already exists")
-	ErrInvalidTenantCode        = errors.New("invalid tenant code")
+	ErrTenantNotFound           = newNotFound("tenant not found")
+	ErrTenantAlreadyExists      = newConflict("tenant already exists")
+	ErrInvalidTenantCode        = newInvalid("invalid tenant code")
 	ErrInvalidTenantStatus      = errors.New("invalid tenant status")
 	ErrCannotModifySystemTenant = errors.New("cannot modify system tenant")
 )
 
 // Workspace errors.
 var (
-	ErrWorkspaceNotFound           = errors.New("workspace not found")
-	ErrWorkspaceAlreadyExists      = errors.New("workspace already exists")
-	ErrWorkspaceAccessDenied       = errors.New("workspace access denied")
+	ErrWorkspaceNotFound           = newNotFound("workspace not found")
+	ErrWorkspaceAlreadyExists      = newConflict("workspace already exists")
+	ErrWorkspaceAccessDenied       = newForbidden("workspace access denied")
 	ErrWorkspaceSuspended          = errors.New("workspace is suspended")
 	ErrWorkspaceArchived           = errors.New("workspace is archived")
-	ErrSystemWorkspaceExists       = errors.New("system workspace already exists for this tenant")
-	ErrGlobalWorkspaceExists       = errors.New("global system workspace already exists")
-	ErrInvalidWorkspaceType        = errors.New("invalid workspace type")
+	ErrSystemWorkspaceExists       = newConflict("system workspace already exists for this tenant")
+	ErrGlobalWorkspaceExists       = newConflict("global system workspace already exists")
+	ErrInvalidWorkspaceType        = newInvalid("invalid workspace type")
 	ErrInvalidWorkspaceStatus      = errors.New("invalid workspace status")
-	ErrCannotArchiveSystem         = errors.New("cannot archive system workspace")
+	ErrCannotArchiveSystem         = newInvalid("cannot archive system workspace")
 	ErrCannotModifySystemWorkspace = errors.New("cannot modify system workspace status")
-	ErrWorkspaceCodeExists         = errors.New("workspace with this code already exists in tenant")
-	ErrInvalidWorkspaceCode        = errors.New("invalid workspace code")
-	ErrSandboxNotFound             = errors.New("sandbox workspace not found")
+	ErrWorkspaceCodeExists         = newConflict("workspace with this code already exists in tenant")
+	ErrInvalidWorkspaceCode        = newInvalid("invalid workspace code")
+	ErrSandboxNotFound             = newNotFound("sandbox workspace not found")
 	ErrSandboxNotSupported         = errors.New("this workspace type does not support sandbox mode")
 	ErrCannotPromoteToSandbox      = errors.New("cannot promote to a sandbox workspace")
 )
 
 // User errors.
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserNotFound      = newNotFound("user not found")
+	ErrUserAlreadyExists = newConflict("user already exists")
 	ErrUserSuspended     = errors.New("user is suspended")
 	ErrUserNotInvited    = errors.New("user has not been invited to the system")
 	ErrInvalidUserStatus = errors.New("invalid user status")
@@ -92,45 +124,47 @@ var (
 
 // Workspace Member errors.
 var (
-	ErrMemberNotFound               = errors.New("workspace member not found")
-	ErrMemberAlreadyExists          = errors.New("user is already a member of this workspace")
+	ErrMemberNotFound               = newNotFound("workspace member not found")
+	ErrMemberAlreadyExists          = newConflict("user is already a member of this workspace")
 	ErrMembershipPending            = errors.New("membership is pending")
-	ErrCannotRemoveOwner            = errors.New("cannot remove workspace owner")
-	ErrCannotRemoveLastSystemMember = errors.New("cannot remove the last direct member from the global system workspace")
-	ErrInvalidRole                  = errors.New("invalid workspace role")
+	ErrCannotRemoveOwner            = newInvalid("cannot remove workspace owner")
+	ErrCannotRemoveLastSystemMember = newInvalid("cannot remove the last direct member from the global system workspace")
+	ErrInvalidRole                  = newInvalid("invalid workspace role")
 	ErrInvalidMembershipStatus      = errors.New("invalid membership status")
 )
 
 // Folder errors.
 var (
-	ErrFolderNotFound      = errors.New("folder not found")
-	ErrFolderAlreadyExists = errors.New("folder with this name already exists")
-	ErrFolderHasChildren   = errors.New("folder has child folders")
-	ErrFolderHasTemplates  = errors.New("folder contains templates")
-	ErrInvalidParentFolder = errors.New("invalid parent folder")
-	ErrCircularReference   = errors.New("circular folder reference detected")
+	ErrFolderNotFound      = newNotFound("folder not found")
+	ErrFolderAlreadyExists = newConflict("folder with this name already exists")
+	ErrFolderHasChildren   = newInvalid("folder has child folders")
+	ErrFolderHasTemplates  = newInvalid("folder contains templates")
+	ErrInvalidParentFolder = newInvalid("invalid parent folder")
+	ErrCircularReference   = newInvalid("circular folder reference detected")
 )
 
 // Tag errors.
 var (
-	ErrTagNotFound      = errors.New("tag not found")
-	ErrTagAlreadyExists = errors.New("tag with this name already exists")
-	ErrTagInUse         = errors.New("tag is in use by templates")
-	ErrInvalidTagColor  = errors.New("invalid tag color format")
+	ErrTagNotFound      = newNotFound("tag not found")
+	ErrTagAlreadyExists = newConflict("tag with this name already exists")
+	ErrTagInUse         = newInvalid("tag is in use by templates")
+	ErrInvalidTagColor  = newInvalid("invalid tag color format")
 )
 
 // Injectable Definition errors.
 var (
-	ErrInjectableNotFound         = errors.New("injectable definition not found")
-	ErrInjectableAlreadyExists    = errors.New("injectable with this key already exists")
-	ErrInjectableInUse            = errors.New("injectable is in use by templates")
-	ErrInvalidInjectableKey       = errors.New("invalid injectable key")
-	ErrInvalidDataType            = errors.New("invalid injectable data type")
+	ErrInjectableNotFound         = newNotFound("injectable definition not found")
+	ErrInjectableAlreadyExists    = newConflict("injectable with this key already exists")
+	ErrInjectableInUse            = newInvalid("injectable is in use by templates")
+	ErrInvalidInjectableKey       = newInvalid("invalid injectable key")
+	ErrReservedInjectableKey      = newInvalid("injectable key uses a reserved namespace")
+	ErrInvalidDataType            = newInvalid("invalid injectable data type")
 	ErrInvalidInjectableSource    = errors.New("must specify either injectable definition ID or system key, not both")
-	ErrTemplateInjectableNotFound = errors.New("template injectable not found")
-	ErrOnlyTextTypeAllowed        = errors.New("only TEXT type injectables can be created by workspaces")
-	ErrWorkspaceIDRequired        = errors.New("workspace ID is required for this injectable")
-	ErrCannotModifyGlobal         = errors.New("cannot modify global injectable definitions")
+	ErrTemplateInjectableNotFound = newNotFound("template injectable not found")
+	ErrOnlyTextTypeAllowed        = newInvalid("only TEXT type injectables can be created by workspaces")
+	ErrWorkspaceIDRequired        = newInvalid("workspace ID is required for this injectable")
+	ErrCannotModifyGlobal         = newInvalid("cannot modify global injectable definitions")
+	ErrInvalidComputedExpression  = newInvalid("invalid computed injectable expression")
 )
 
 // System Injectable errors.
@@ -146,7 +180,7 @@ var (
 	ErrNoMapperRegistered = errors.New("no mapper registered in registry")
 	// ErrInternalTemplateResolutionNotFound indicates no published template version
 	// could be resolved for the internal create request.
-	ErrInternalTemplateResolutionNotFound = errors.New("no published template version resolved")
+	ErrInternalTemplateResolutionNotFound = newNotFound("no published template version resolved")
 )
 
 // MissingInjectablesError indicates that required injectables are not available.
@@ -171,85 +205,85 @@ func (e *RecipientValidationError) Error() string {
 
 // Document Type errors.
 var (
-	ErrDocumentTypeNotFound        = errors.New("document type not found")
-	ErrDocumentTypeCodeExists      = errors.New("document type with this code already exists")
-	ErrDocumentTypeCodeImmutable   = errors.New("document type code cannot be modified")
-	ErrDocumentTypeAlreadyAssigned = errors.New("workspace already has a template for this document type")
-	ErrDocumentTypeHasTemplates    = errors.New("document type is assigned to templates")
+	ErrDocumentTypeNotFound        = newNotFound("document type not found")
+	ErrDocumentTypeCodeExists      = newConflict("document type with this code already exists")
+	ErrDocumentTypeCodeImmutable   = newInvalid("document type code cannot be modified")
+	ErrDocumentTypeAlreadyAssigned = newConflict("workspace already has a template for this document type")
+	ErrDocumentTypeHasTemplates    = newInvalid("document type is assigned to templates")
 	ErrCannotModifyGlobalType      = errors.New("cannot modify global document type")
 )
 
 // Process errors.
 var (
-	ErrProcessNotFound            = errors.New("process not found")
-	ErrProcessCodeExists          = errors.New("process with this code already exists")
-	ErrProcessHasTemplates        = errors.New("process is assigned to templates")
-	ErrCannotModifyGlobalProcess  = errors.New("cannot modify global process")
-	ErrCannotDeleteDefaultProcess = errors.New("cannot delete the default process")
+	ErrProcessNotFound            = newNotFound("process not found")
+	ErrProcessCodeExists          = newConflict("process with this code already exists")
+	ErrProcessHasTemplates        = newInvalid("process is assigned to templates")
+	ErrCannotModifyGlobalProcess  = newInvalid("cannot modify global process")
+	ErrCannotDeleteDefaultProcess = newInvalid("cannot delete the default process")
 )
 
 // Template errors.
 var (
-	ErrTemplateNotFound      = errors.New("template not found")
-	ErrTemplateAlreadyExists = errors.New("template with this title already exists")
-	ErrInvalidProcessType    = errors.New("processType must be ID or CANONICAL_NAME")
-	ErrProcessSlotConflict   = errors.New("a template with this document type and process already exists in the workspace")
+	ErrTemplateNotFound      = newNotFound("template not found")
+	ErrTemplateAlreadyExists = newConflict("template with this title already exists")
+	ErrInvalidProcessType    = newInvalid("processType must be ID or CANONICAL_NAME")
+	ErrProcessSlotConflict   = newConflict("a template with this document type and process already exists in the workspace")
 )
 
 // Template Version errors.
 var (
-	ErrVersionNotFound                 = errors.New("template version not found")
-	ErrVersionAlreadyExists            = errors.New("version number already exists for this template")
-	ErrVersionNameExists               = errors.New("version name already exists for this template")
-	ErrVersionNotPublished             = errors.New("version must be published to promote")
-	ErrVersionAlreadyPublished         = errors.New("version is already published")
-	ErrCannotEditPublished             = errors.New("cannot edit published version")
-	ErrCannotEditArchived              = errors.New("cannot edit archived version")
-	ErrCannotEditScheduled             = errors.New("cannot edit scheduled version")
-	ErrNoPublishedVersion              = errors.New("template has no published version")
-	ErrCannotArchiveWithoutReplacement = errors.New("cannot schedule archive without scheduled replacement")
-	ErrInvalidVersionStatus            = errors.New("invalid version status")
-	ErrInvalidVersionNumber            = errors.New("invalid version number")
-	ErrScheduledTimeInPast             = errors.New("scheduled time must be in the future")
-	ErrInvalidContentStructure         = errors.New("invalid template content structure")
+	ErrVersionNotFound                 = newNotFound("template version not found")
+	ErrVersionAlreadyExists            = newConflict("version number already exists for this template")
+	ErrVersionNameExists               = newConflict("version name already exists for this template")
+	ErrVersionNotPublished             = newInvalid("version must be published to promote")
+	ErrVersionAlreadyPublished         = newInvalid("version is already published")
+	ErrCannotEditPublished             = newInvalid("cannot edit published version")
+	ErrCannotEditArchived              = newInvalid("cannot edit archived version")
+	ErrCannotEditScheduled             = newInvalid("cannot edit scheduled version")
+	ErrNoPublishedVersion              = newInvalid("template has no published version")
+	ErrCannotArchiveWithoutReplacement = newInvalid("cannot schedule archive without scheduled replacement")
+	ErrInvalidVersionStatus            = newInvalid("invalid version status")
+	ErrInvalidVersionNumber            = newInvalid("invalid version number")
+	ErrScheduledTimeInPast             = newInvalid("scheduled time must be in the future")
+	ErrInvalidContentStructure         = newInvalid("invalid template content structure")
 	ErrMissingRequiredVariable         = errors.New("missing required template variable")
-	ErrSignerRoleNotFound              = errors.New("signer role not found")
-	ErrInvalidSignerRole               = errors.New("invalid signer role configuration")
-	ErrDuplicateSignerAnchor           = errors.New("duplicate signer anchor")
-	ErrDuplicateSignerOrder            = errors.New("duplicate signer order")
-	ErrVersionInjectableNotFound       = errors.New("version injectable not found")
-	ErrContentValidationFailed         = errors.New("content validation failed")
-	ErrMissingRequiredContent          = errors.New("content structure is required for publishing")
-	ErrVersionDoesNotBelongToTemplate  = errors.New("version does not belong to the specified template")
-	ErrTargetTemplateRequired          = errors.New("target template ID is required for NEW_VERSION mode")
-	ErrTargetTemplateNotInWorkspace    = errors.New("target template does not belong to the destination workspace")
-	ErrScheduledTimeConflict           = errors.New("another version is already scheduled at this time")
+	ErrSignerRoleNotFound              = newNotFound("signer role not found")
+	ErrInvalidSignerRole               = newInvalid("invalid signer role configuration")
+	ErrDuplicateSignerAnchor           = newConflict("duplicate signer anchor")
+	ErrDuplicateSignerOrder            = newConflict("duplicate signer order")
+	ErrVersionInjectableNotFound       = newNotFound("version injectable not found")
+	ErrContentValidationFailed         = newInvalid("content validation failed")
+	ErrMissingRequiredContent          = newInvalid("content structure is required for publishing")
+	ErrVersionDoesNotBelongToTemplate  = newInvalid("version does not belong to the specified template")
+	ErrTargetTemplateRequired          = newInvalid("target template ID is required for NEW_VERSION mode")
+	ErrTargetTemplateNotInWorkspace    = newInvalid("target template does not belong to the destination workspace")
+	ErrScheduledTimeConflict           = newConflict("another version is already scheduled at this time")
 )
 
 // Document errors.
 var (
-	ErrDocumentNotFound                 = errors.New("document not found")
+	ErrDocumentNotFound                 = newNotFound("document not found")
 	ErrDocumentAlreadySent              = errors.New("document already sent for signing")
 	ErrDocumentCompleted                = errors.New("document signing already completed")
 	ErrDocumentVoided                   = errors.New("document has been voided")
-	ErrInvalidDocumentState             = errors.New("invalid document state for this operation")
+	ErrInvalidDocumentState             = newConflict("invalid document state for this operation")
 	ErrInvalidDocumentStatus            = errors.New("invalid document status")
 	ErrInvalidDocumentStatusTransition  = errors.New("invalid document status transition")
-	ErrDocumentRecipientNotFound        = errors.New("document recipient not found")
+	ErrDocumentRecipientNotFound        = newNotFound("document recipient not found")
 	ErrInvalidRecipientStatus           = errors.New("invalid recipient status")
 	ErrInvalidRecipientStatusTransition = errors.New("invalid recipient status transition")
 	ErrDuplicateRecipientRole           = errors.New("duplicate recipient role assignment")
-	ErrInvalidOperationType             = errors.New("invalid operation type")
-	ErrDocumentNotCompleted             = errors.New("related document must be completed for RENEW")
-	ErrDocumentNotTerminal              = errors.New("related document must be in a terminal state for AMEND")
-	ErrRelatedDocumentRequired          = errors.New("related document ID is required for RENEW/AMEND operations")
-	ErrRelatedDocumentSameWorkspace     = errors.New("related document must belong to the same workspace")
+	ErrInvalidOperationType             = newInvalid("invalid operation type")
+	ErrDocumentNotCompleted             = newInvalid("related document must be completed for RENEW")
+	ErrDocumentNotTerminal              = newInvalid("related document must be in a terminal state for AMEND")
+	ErrRelatedDocumentRequired          = newInvalid("related document ID is required for RENEW/AMEND operations")
+	ErrRelatedDocumentSameWorkspace     = newInvalid("related document must belong to the same workspace")
 )
 
 // Signing Provider errors.
 var (
 	ErrSigningProviderNotConfigured = errors.New("signing provider not configured")
-	ErrSigningAttemptNotFound       = errors.New("signing attempt not found")
+	ErrSigningAttemptNotFound       = newNotFound("signing attempt not found")
 	ErrSigningProviderError         = errors.New("signing provider error")
 	ErrSigningUploadFailed          = errors.New("failed to upload document to signing provider")
 	ErrSigningURLFailed             = errors.New("failed to get signing URL")
@@ -261,11 +295,12 @@ var (
 
 // Validation errors.
 var (
-	ErrValidationFailed = errors.New("validation failed")
-	ErrInvalidUUID      = errors.New("invalid UUID format")
-	ErrRequiredField    = errors.New("required field is missing")
-	ErrFieldTooLong     = errors.New("field exceeds maximum length")
-	ErrFieldTooShort    = errors.New("field is below minimum length")
+	// ErrValidationFailed is the ErrValidation category under its former name.
+	ErrValidationFailed = ErrValidation
+	ErrInvalidUUID      = newInvalid("invalid UUID format")
+	ErrRequiredField    = newInvalid("required field is missing")
+	ErrFieldTooLong     = newInvalid("field exceeds maximum length")
+	ErrFieldTooShort    = newInvalid("field is below minimum length")
 )
 
 // Database errors.
@@ -286,14 +321,27 @@ var (
 	ErrLLMServiceUnavailable = errors.New("AI generation service is temporarily unavailable")
 )
 
-// Renderer capacity errors.
+// Renderer errors.
 var (
-	ErrRendererBusy = errors.New("PDF renderer is at capacity, try again shortly")
+	ErrRendererBusy        = errors.New("PDF renderer is at capacity, try again shortly")
+	ErrRenderBlockNotFound = newInvalid("content block not found in document")
+	ErrVersionHasNoContent = newInvalid("version has no content")
+	ErrDocumentTooLarge    = newInvalid("document exceeds the render size limits")
+	ErrPDFANonConformant   = errors.New("rendered PDF does not conform to PDF/A-2b")
+	ErrPDFAEncrypted       = newInvalid("PDF/A output cannot be encrypted")
+	ErrPDFPasswordRequired = newInvalid("PDF encryption requires a user or owner password")
+	ErrSealEncrypted       = errors.New("encrypted PDFs cannot be sealed")
+	ErrIncludeNotFound     = newInvalid("included template not found or not published")
+	ErrIncludeCycle        = newInvalid("template includes form a cycle")
+	ErrUnknownTheme        = newInvalid("design theme is not registered")
+	ErrInvalidImageRender  = newInvalid("invalid image render options")
+	ErrRenderPageNotFound  = newInvalid("page not found in rendered document")
+	ErrInvalidTimezone     = newInvalid("render timezone is not a valid IANA time zone")
 )
 
 // Automation API key errors.
 var (
-	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyNotFound = newNotFound("api key not found")
 	ErrInvalidKeyType = errors.New("keyType must be 'automation' or 'internal'")
 )
 
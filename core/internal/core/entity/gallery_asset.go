@@ -1,9 +1,6 @@
 package entity
 
-import (
-	"errors"
-	"time"
-)
+import "time"
 
 // GalleryAsset represents an image stored in the workspace gallery.
 type GalleryAsset struct {
@@ -21,7 +18,7 @@ type GalleryAsset struct {
 
 // Gallery errors.
 var (
-	ErrGalleryAssetNotFound      = errors.New("gallery asset not found")
-	ErrGalleryInvalidContentType = errors.New("invalid content type: only images are allowed")
-	ErrGalleryFileTooLarge       = errors.New("file too large: maximum size is 10MB")
+	ErrGalleryAssetNotFound      = newNotFound("gallery asset not found")
+	ErrGalleryInvalidContentType = newInvalid("invalid content type: only images are allowed")
+	ErrGalleryFileTooLarge       = newInvalid("file too large: maximum size is 10MB")
 )
@@ -2,11 +2,72 @@ package entity
 
 import (
 	"regexp"
+	"strings"
 	"time"
 )
 
-// injectableKeyRegex validates injectable key format (alphanumeric with underscores).
-var injectableKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+// injectableKeyRegex validates injectable key format: lowercase segments with underscores,
+// optionally namespaced with dots (e.g. "customer_name", "crm.first_name").
+var injectableKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// InjectableNamespaceSeparator separates the namespace of an injectable key from its
+// name. Namespaces keep keys from different sources apart: "crm.first_name" and
+// "hr.first_name" are distinct injectables, while a flat "first_name" has no namespace.
+const InjectableNamespaceSeparator = "."
+
+// reservedInjectableNamespaces are the top-level namespaces of built-in injectables
+// (e.g. "system.now", "workspace.logo"); definitions cannot use them.
+var reservedInjectableNamespaces = []string{"system", "workspace"}
+
+// isReservedInjectableKey reports whether a key falls under a reserved namespace.
+func isReservedInjectableKey(key string) bool {
+	for _, namespace := range reservedInjectableNamespaces {
+		if strings.HasPrefix(key, namespace+InjectableNamespaceSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitInjectableKey splits a key into its namespace and name at the last separator.
+// Flat keys return an empty namespace.
+func SplitInjectableKey(key string) (namespace, name string) {
+	i := strings.LastIndex(key, InjectableNamespaceSeparator)
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// NamespacedInjectableKey joins a namespace and a name into a key; an empty namespace
+// returns the name unchanged.
+func NamespacedInjectableKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + InjectableNamespaceSeparator + name
+}
+
+// InjectablePathKeys returns the injectable keys a variable path may read, longest
+// first: the path itself unless it indexes into a list, then each prefix ending before
+// a "." or "[" up to the first index. "items[0].name" gives ["items"] and
+// "crm.contact.name" gives ["crm.contact.name", "crm.contact", "crm"].
+func InjectablePathKeys(path string) []string {
+	root := path
+	if i := strings.IndexByte(path, '['); i >= 0 {
+		root = path[:i]
+	}
+	if root == "" {
+		return nil
+	}
+	keys := []string{root}
+	for i := len(root) - 1; i > 0; i-- {
+		if root[i] == '.' {
+			keys = append(keys, root[:i])
+		}
+	}
+	return keys
+}
 
 // InjectableDefinition represents a variable that can be injected into templates.
 type InjectableDefinition struct {
@@ -44,11 +105,33 @@ func NewInjectableDefinition(workspaceID *string, key, label string, dataType In
 	}
 }
 
+// Namespace returns the namespace of the definition's key, or "" for flat keys.
+func (i *InjectableDefinition) Namespace() string {
+	namespace, _ := SplitInjectableKey(i.Key)
+	return namespace
+}
+
 // IsGlobal returns true if this is a global definition (available to all workspaces).
 func (i *InjectableDefinition) IsGlobal() bool {
 	return i.WorkspaceID == nil
 }
 
+// InjectableMetadataExpression is the metadata key holding a computed injectable's expression.
+const InjectableMetadataExpression = "expression"
+
+// ComputedExpression returns the arithmetic expression of a computed injectable
+// (e.g. "quantity * unit_price"). ok=false if the definition is not computed.
+func (i *InjectableDefinition) ComputedExpression() (string, bool) {
+	expr, _ := i.Metadata[InjectableMetadataExpression].(string)
+	return expr, expr != ""
+}
+
+// IsComputed returns true if the definition derives its value from other injectables.
+func (i *InjectableDefinition) IsComputed() bool {
+	_, ok := i.ComputedExpression()
+	return ok
+}
+
 // Validate checks if the injectable definition data is valid.
 func (i *InjectableDefinition) Validate() error {
 	if i.Key == "" {
@@ -57,6 +140,9 @@ func (i *InjectableDefinition) Validate() error {
 	if !injectableKeyRegex.MatchString(i.Key) {
 		return ErrInvalidInjectableKey
 	}
+	if isReservedInjectableKey(i.Key) {
+		return ErrReservedInjectableKey
+	}
 	if len(i.Key) > 100 {
 		return ErrFieldTooLong
 	}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectableDefinition_ValidateKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr error
+	}{
+		{"first_name", nil},
+		{"crm.first_name", nil},
+		{"crm.contact.first_name", nil},
+		{"crm.", ErrInvalidInjectableKey},
+		{".first_name", ErrInvalidInjectableKey},
+		{"crm..first_name", ErrInvalidInjectableKey},
+		{"CRM.first_name", ErrInvalidInjectableKey},
+		{"crm.1st_name", ErrInvalidInjectableKey},
+		{"system.now", ErrReservedInjectableKey},
+		{"workspace.logo", ErrReservedInjectableKey},
+		{"workspace.branding.color", ErrReservedInjectableKey},
+		{"systems.now", nil},
+		{"workspace_name", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			def := NewInjectableDefinition(nil, tt.key, "Label", InjectableDataTypeText)
+			assert.ErrorIs(t, def.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestSplitInjectableKey(t *testing.T) {
+	tests := []struct {
+		key, namespace, name string
+	}{
+		{"first_name", "", "first_name"},
+		{"crm.first_name", "crm", "first_name"},
+		{"crm.contact.first_name", "crm.contact", "first_name"},
+	}
+	for _, tt := range tests {
+		namespace, name := SplitInjectableKey(tt.key)
+		assert.Equal(t, tt.namespace, namespace, tt.key)
+		assert.Equal(t, tt.name, name, tt.key)
+		assert.Equal(t, tt.key, NamespacedInjectableKey(namespace, name))
+	}
+
+	def := NewInjectableDefinition(nil, "hr.first_name", "First name", InjectableDataTypeText)
+	assert.Equal(t, "hr", def.Namespace())
+}
+
+func TestInjectablePathKeys(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"first_name", []string{"first_name"}},
+		{"crm.contact.name", []string{"crm.contact.name", "crm.contact", "crm"}},
+		{"items[0].name", []string{"items"}},
+		{"crm.items[1].qty", []string{"crm.items", "crm"}},
+		{"[0]", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, InjectablePathKeys(tt.path), tt.path)
+	}
+}
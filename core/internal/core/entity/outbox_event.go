@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Outbox aggregate type constants.
+const (
+	AggregateTemplate = "TEMPLATE"
+	AggregateDocument = "DOCUMENT"
+)
+
+// Outbox event type constants.
+const (
+	EventTemplateVersionPublished = "TEMPLATE_VERSION_PUBLISHED"
+	EventSigningStatusChanged     = "SIGNING_STATUS_CHANGED"
+)
+
+// OutboxEvent is a domain event recorded in the same transaction as the state
+// change it describes and relayed to every configured consumer afterwards.
+type OutboxEvent struct {
+	ID            string          `json:"id"`
+	Seq           int64           `json:"seq"` // Total order; events of one aggregate are delivered in Seq order
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// OutboxDelivery tracks delivery of one outbox event to one consumer.
+type OutboxDelivery struct {
+	EventID       string     `json:"eventId"`
+	Consumer      string     `json:"consumer"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"lastError,omitempty"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty"`
+}
+
+// TemplateVersionPublishedPayload is the payload of EventTemplateVersionPublished.
+type TemplateVersionPublishedPayload struct {
+	TemplateID    string    `json:"templateId"`
+	VersionID     string    `json:"versionId"`
+	VersionNumber int       `json:"versionNumber"`
+	WorkspaceID   string    `json:"workspaceId"`
+	PublishedBy   *string   `json:"publishedBy,omitempty"` // nil for scheduled or automated publishes
+	PublishedAt   time.Time `json:"publishedAt"`
+}
+
+// NewTemplateVersionPublishedEvent builds the outbox event for a version that was just published.
+func NewTemplateVersionPublishedEvent(template *Template, version *TemplateVersion) (*OutboxEvent, error) {
+	publishedAt := time.Now().UTC()
+	if version.PublishedAt != nil {
+		publishedAt = *version.PublishedAt
+	}
+
+	payload, err := json.Marshal(TemplateVersionPublishedPayload{
+		TemplateID:    template.ID,
+		VersionID:     version.ID,
+		VersionNumber: version.VersionNumber,
+		WorkspaceID:   template.WorkspaceID,
+		PublishedBy:   version.PublishedBy,
+		PublishedAt:   publishedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling publish event payload: %w", err)
+	}
+
+	return &OutboxEvent{
+		AggregateType: AggregateTemplate,
+		AggregateID:   template.ID,
+		EventType:     EventTemplateVersionPublished,
+		Payload:       payload,
+		CreatedAt:     publishedAt,
+	}, nil
+}
+
+// SigningStatusChangedPayload is the payload of EventSigningStatusChanged.
+type SigningStatusChangedPayload struct {
+	DocumentID string                `json:"documentId"`
+	AttemptID  string                `json:"attemptId"`
+	Transition string                `json:"transition"` // Attempt event type, e.g. ATTEMPT_CREATED
+	OldStatus  *SigningAttemptStatus `json:"oldStatus,omitempty"`
+	NewStatus  SigningAttemptStatus  `json:"newStatus"`
+	ChangedAt  time.Time             `json:"changedAt"`
+}
+
+// NewSigningStatusChangedEvent builds the outbox event for an attempt status transition.
+// It returns nil when the attempt event does not change the status.
+func NewSigningStatusChangedEvent(ev *SigningAttemptEvent) (*OutboxEvent, error) {
+	if ev.NewStatus == nil || (ev.OldStatus != nil && *ev.OldStatus == *ev.NewStatus) {
+		return nil, nil
+	}
+
+	changedAt := ev.CreatedAt
+	if changedAt.IsZero() {
+		changedAt = time.Now().UTC()
+	}
+
+	payload, err := json.Marshal(SigningStatusChangedPayload{
+		DocumentID: ev.DocumentID,
+		AttemptID:  ev.AttemptID,
+		Transition: ev.EventType,
+		OldStatus:  ev.OldStatus,
+		NewStatus:  *ev.NewStatus,
+		ChangedAt:  changedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling signing status event payload: %w", err)
+	}
+
+	return &OutboxEvent{
+		AggregateType: AggregateDocument,
+		AggregateID:   ev.DocumentID,
+		EventType:     EventSigningStatusChanged,
+		Payload:       payload,
+		CreatedAt:     changedAt,
+	}, nil
+}
@@ -51,6 +51,21 @@ const (
 	NodeTypeTableCell        = "tableCell"
 	NodeTypeTableHeader      = "tableHeader"
 	NodeTypeInteractiveField = "interactiveField"
+	NodeTypeInsertionPoint   = "insertionPoint" // Named slot filled with nodes supplied at render time
+	NodeTypeInclude          = "include"        // Reference to another template's published content
+	NodeTypeMath             = "inlineMath"     // Inline equation: attrs.latex, or attrs.typst for Typst math source
+	NodeTypeMathBlock        = "blockMath"      // Display equation on its own line, same attrs as NodeTypeMath
+	NodeTypeCrossReference   = "crossReference" // Inline "Section 3.2" reference to a heading or figure by nodeId
+	// Collapsible section, rendered expanded: the summary (attrs.summary or a
+	// detailsSummary child) as a bold lead-in, then the body (detailsContent or other children)
+	NodeTypeDetails        = "details"
+	NodeTypeDetailsSummary = "detailsSummary"
+	NodeTypeDetailsContent = "detailsContent"
+	// Glossary of term/description pairs: each definitionTerm starts an entry and
+	// the definitionDescription children that follow it describe that term
+	NodeTypeDefinitionList        = "definitionList"
+	NodeTypeDefinitionTerm        = "definitionTerm"
+	NodeTypeDefinitionDescription = "definitionDescription"
 )
 
 // Mark type constants.
@@ -63,4 +78,9 @@ const (
 	MarkTypeHighlight = "highlight"
 	MarkTypeLink      = "link"
 	MarkTypeTextStyle = "textStyle"
+	MarkTypeComment   = "comment" // Reviewer comment anchored to a text range
+
+	// MarkTypeConditionalStyle styles its text (and injector values) only while its
+	// "conditions" hold. Attrs: conditions (as on conditional nodes), color, bold, italic.
+	MarkTypeConditionalStyle = "conditionalStyle"
 )
@@ -0,0 +1,53 @@
+package portabledoc
+
+// CoverPage is a page rendered before the body with its own margins, such as a proposal
+// cover with a full-bleed image, title and date. It carries no page number and the body
+// is numbered from 1 after it.
+type CoverPage struct {
+	Enabled           bool        `json:"enabled"`
+	ImageURL          *string     `json:"imageUrl,omitempty"` // full-bleed background; data URI or remote URL
+	ImageInjectableID *string     `json:"imageInjectableId,omitempty"`
+	Title             *FieldValue `json:"title,omitempty"`
+	Subtitle          *FieldValue `json:"subtitle,omitempty"`
+	Date              *FieldValue `json:"date,omitempty"`
+	Margins           *Margins    `json:"margins,omitempty"`   // nil = body margins
+	TextColor         string      `json:"textColor,omitempty"` // hex color; empty = base text color
+}
+
+// IsEnabled returns true when a cover page should be rendered.
+func (c *CoverPage) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// HasImage returns true when the cover has a direct image URL or an image injectable binding.
+func (c *CoverPage) HasImage() bool {
+	if c == nil {
+		return false
+	}
+	return (c.ImageURL != nil && *c.ImageURL != "") || c.HasImageInjectable()
+}
+
+// HasImageInjectable returns true when the cover image is bound to a variable.
+func (c *CoverPage) HasImageInjectable() bool {
+	return c != nil && c.ImageInjectableID != nil && *c.ImageInjectableID != ""
+}
+
+// InjectableRefs returns the injectable references used by the cover text fields, keyed by
+// JSON path relative to coverPage.
+func (c *CoverPage) InjectableRefs() map[string][]string {
+	if c == nil {
+		return nil
+	}
+	refs := make(map[string][]string)
+	add := func(path string, f *FieldValue) {
+		if f != nil && f.IsInjectable() {
+			if r := f.InjectableRefs(); len(r) > 0 {
+				refs[path] = r
+			}
+		}
+	}
+	add("title", c.Title)
+	add("subtitle", c.Subtitle)
+	add("date", c.Date)
+	return refs
+}
@@ -0,0 +1,46 @@
+package portabledoc
+
+import "strings"
+
+// Cross-reference attrs keys. A crossReference node points at the nodeId of a
+// heading, or of an image with a caption (rendered as a numbered figure).
+const (
+	AttrCrossReferenceTarget     = "targetId"
+	AttrCrossReferenceSupplement = "supplement" // replaces the "Section"/"Figure" word before the number
+	AttrCrossReferenceFallback   = "text"       // shown when the target is missing or unnumbered
+	AttrImageCaption             = "caption"    // turns an image into a referenceable figure
+)
+
+// CrossReferenceTarget returns the nodeId a crossReference node points at.
+func (n Node) CrossReferenceTarget() string {
+	id, _ := n.Attrs[AttrCrossReferenceTarget].(string)
+	return id
+}
+
+// IsCrossReferenceTarget reports whether a reference can point at the node:
+// a heading with an ID, or an image with an ID and a caption.
+func (n Node) IsCrossReferenceTarget() bool {
+	if n.ID() == "" {
+		return false
+	}
+	switch n.Type {
+	case NodeTypeHeading:
+		return true
+	case NodeTypeImage, NodeTypeCustomImage:
+		caption, _ := n.Attrs[AttrImageCaption].(string)
+		return strings.TrimSpace(caption) != ""
+	default:
+		return false
+	}
+}
+
+// CrossReferenceTargets returns the IDs of every node a cross-reference can point at.
+func (d *Document) CrossReferenceTargets() Set[string] {
+	targets := make(Set[string])
+	for node := range d.AllNodesRecursive() {
+		if node.IsCrossReferenceTarget() {
+			targets.Add(node.ID())
+		}
+	}
+	return targets
+}
@@ -0,0 +1,25 @@
+package portabledoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentCrossReferenceTargets(t *testing.T) {
+	withID := func(n Node, id string) Node {
+		n.SetID(id)
+		return n
+	}
+	doc := staticTestDocument(
+		withID(Node{Type: NodeTypeHeading}, "sec-scope"),
+		withID(Node{Type: NodeTypeImage, Attrs: map[string]any{"caption": "Revenue"}}, "fig-revenue"),
+		withID(Node{Type: NodeTypeImage}, "img-logo"),
+		withID(Node{Type: NodeTypeParagraph}, "para-1"),
+		Node{Type: NodeTypeBulletList, Content: []Node{{Type: NodeTypeListItem, Content: []Node{
+			withID(Node{Type: NodeTypeHeading}, "sec-nested"),
+		}}}},
+	)
+
+	assert.ElementsMatch(t, []string{"sec-scope", "fig-revenue", "sec-nested"}, doc.CrossReferenceTargets().ToSlice())
+}
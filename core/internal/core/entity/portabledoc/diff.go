@@ -0,0 +1,174 @@
+package portabledoc
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Change kinds reported by Diff.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Document sections compared as a whole by Diff.
+const (
+	SectionMeta             = "meta"
+	SectionPageConfig       = "pageConfig"
+	SectionSigningWorkflow  = "signingWorkflow"
+	SectionHeader           = "header"
+	SectionCoverPage        = "coverPage"
+	SectionWatermark        = "watermark"
+	SectionTextFlow         = "textFlow"
+	SectionHeadingNumbering = "headingNumbering"
+)
+
+// BlockChange describes a top-level content block that differs between two documents.
+type BlockChange struct {
+	Kind   string
+	NodeID string // empty when the block has no stable ID and was matched by position
+	Type   string
+	Index  int // position in the newer document, or in the older one for removed blocks
+}
+
+// SignerRoleChange describes a signer role that differs between two documents.
+type SignerRoleChange struct {
+	Kind  string
+	ID    string
+	Label string
+}
+
+// DocumentDiff is a structured summary of what changes going from one document to another.
+type DocumentDiff struct {
+	Sections         []string // document sections whose settings changed
+	Blocks           []BlockChange
+	VariablesAdded   []string
+	VariablesRemoved []string
+	SignerRoles      []SignerRoleChange
+}
+
+// IsEmpty reports whether the two documents compared are equivalent.
+func (d DocumentDiff) IsEmpty() bool {
+	return len(d.Sections) == 0 &&
+		len(d.Blocks) == 0 &&
+		len(d.VariablesAdded) == 0 &&
+		len(d.VariablesRemoved) == 0 &&
+		len(d.SignerRoles) == 0
+}
+
+// Diff compares two documents. A nil document is treated as empty, so diffing
+// from nil reports everything in the newer document as added.
+// Content blocks are matched by node ID; blocks without one are matched by position.
+// Export info and the format version are ignored.
+func Diff(from, to *Document) DocumentDiff {
+	if from == nil {
+		from = &Document{}
+	}
+	if to == nil {
+		to = &Document{}
+	}
+
+	var diff DocumentDiff
+	for _, section := range []struct {
+		name     string
+		from, to any
+	}{
+		{SectionMeta, from.Meta, to.Meta},
+		{SectionPageConfig, from.PageConfig, to.PageConfig},
+		{SectionSigningWorkflow, from.SigningWorkflow, to.SigningWorkflow},
+		{SectionHeader, from.Header, to.Header},
+		{SectionCoverPage, from.CoverPage, to.CoverPage},
+		{SectionWatermark, from.Watermark, to.Watermark},
+		{SectionTextFlow, from.TextFlow, to.TextFlow},
+		{SectionHeadingNumbering, from.HeadingNumbering, to.HeadingNumbering},
+	} {
+		if !reflect.DeepEqual(section.from, section.to) {
+			diff.Sections = append(diff.Sections, section.name)
+		}
+	}
+
+	diff.Blocks = diffBlocks(contentBlocks(from), contentBlocks(to))
+
+	fromVars, toVars := NewSet(from.VariableIDs), NewSet(to.VariableIDs)
+	diff.VariablesAdded = sortedSlice(toVars.Difference(fromVars))
+	diff.VariablesRemoved = sortedSlice(fromVars.Difference(toVars))
+
+	diff.SignerRoles = diffSignerRoles(from.SignerRoles, to.SignerRoles)
+	return diff
+}
+
+func contentBlocks(d *Document) []Node {
+	if d.Content == nil {
+		return nil
+	}
+	return d.Content.Content
+}
+
+// blockKey identifies a block across documents: its node ID, or its position when it has none.
+func blockKey(node Node, index int) string {
+	if id := node.ID(); id != "" {
+		return "id:" + id
+	}
+	return "pos:" + strconv.Itoa(index)
+}
+
+func diffBlocks(from, to []Node) []BlockChange {
+	fromByKey := make(map[string]Node, len(from))
+	for i, node := range from {
+		fromByKey[blockKey(node, i)] = node
+	}
+
+	var changes []BlockChange
+	seen := make(map[string]bool, len(to))
+	for i, node := range to {
+		key := blockKey(node, i)
+		seen[key] = true
+		old, ok := fromByKey[key]
+		switch {
+		case !ok:
+			changes = append(changes, BlockChange{Kind: ChangeAdded, NodeID: node.ID(), Type: node.Type, Index: i})
+		case !reflect.DeepEqual(old, node):
+			changes = append(changes, BlockChange{Kind: ChangeModified, NodeID: node.ID(), Type: node.Type, Index: i})
+		}
+	}
+	for i, node := range from {
+		if !seen[blockKey(node, i)] {
+			changes = append(changes, BlockChange{Kind: ChangeRemoved, NodeID: node.ID(), Type: node.Type, Index: i})
+		}
+	}
+	return changes
+}
+
+func diffSignerRoles(from, to []SignerRole) []SignerRoleChange {
+	fromByID := make(map[string]SignerRole, len(from))
+	for _, role := range from {
+		fromByID[role.ID] = role
+	}
+
+	var changes []SignerRoleChange
+	seen := make(map[string]bool, len(to))
+	for _, role := range to {
+		seen[role.ID] = true
+		old, ok := fromByID[role.ID]
+		switch {
+		case !ok:
+			changes = append(changes, SignerRoleChange{Kind: ChangeAdded, ID: role.ID, Label: role.Label})
+		case !reflect.DeepEqual(old, role):
+			changes = append(changes, SignerRoleChange{Kind: ChangeModified, ID: role.ID, Label: role.Label})
+		}
+	}
+	for _, role := range from {
+		if !seen[role.ID] {
+			changes = append(changes, SignerRoleChange{Kind: ChangeRemoved, ID: role.ID, Label: role.Label})
+		}
+	}
+	return changes
+}
+
+func sortedSlice(s Set[string]) []string {
+	items := s.ToSlice()
+	slices.Sort(items)
+	return items
+}
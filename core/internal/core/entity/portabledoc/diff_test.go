@@ -0,0 +1,100 @@
+package portabledoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffBaseDocument = `{
+	"version": "1.2.0",
+	"meta": {"title": "Contract"},
+	"variableIds": ["client_name", "start_date"],
+	"signerRoles": [{"id": "r-1", "label": "Client", "name": {"type": "text"}, "email": {"type": "text"}, "order": 1}],
+	"content": {
+		"type": "doc",
+		"content": [
+			{"type": "heading", "attrs": {"level": 1, "nodeId": "h-1"}, "content": [{"type": "text", "text": "Terms"}]},
+			{"type": "paragraph", "attrs": {"nodeId": "p-1"}, "content": [{"type": "text", "text": "Old clause"}]},
+			{"type": "paragraph", "attrs": {"nodeId": "p-2"}, "content": [{"type": "text", "text": "Dropped"}]}
+		]
+	}
+}`
+
+const diffChangedDocument = `{
+	"version": "1.2.0",
+	"meta": {"title": "Contract v2"},
+	"variableIds": ["client_name", "amount"],
+	"signerRoles": [
+		{"id": "r-1", "label": "Customer", "name": {"type": "text"}, "email": {"type": "text"}, "order": 1},
+		{"id": "r-2", "label": "Witness", "name": {"type": "text"}, "email": {"type": "text"}, "order": 2}
+	],
+	"content": {
+		"type": "doc",
+		"content": [
+			{"type": "heading", "attrs": {"level": 1, "nodeId": "h-1"}, "content": [{"type": "text", "text": "Terms"}]},
+			{"type": "paragraph", "attrs": {"nodeId": "p-3"}, "content": [{"type": "text", "text": "Inserted"}]},
+			{"type": "paragraph", "attrs": {"nodeId": "p-1"}, "content": [{"type": "text", "text": "New clause"}]}
+		]
+	}
+}`
+
+func TestDiff_ReportsChanges(t *testing.T) {
+	from := MustParse(json.RawMessage(diffBaseDocument))
+	to := MustParse(json.RawMessage(diffChangedDocument))
+
+	diff := Diff(from, to)
+
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []string{SectionMeta}, diff.Sections)
+	assert.Equal(t, []BlockChange{
+		{Kind: ChangeAdded, NodeID: "p-3", Type: NodeTypeParagraph, Index: 1},
+		{Kind: ChangeModified, NodeID: "p-1", Type: NodeTypeParagraph, Index: 2},
+		{Kind: ChangeRemoved, NodeID: "p-2", Type: NodeTypeParagraph, Index: 2},
+	}, diff.Blocks, "moving an identified block alone is not a change")
+	assert.Equal(t, []string{"amount"}, diff.VariablesAdded)
+	assert.Equal(t, []string{"start_date"}, diff.VariablesRemoved)
+	assert.Equal(t, []SignerRoleChange{
+		{Kind: ChangeModified, ID: "r-1", Label: "Customer"},
+		{Kind: ChangeAdded, ID: "r-2", Label: "Witness"},
+	}, diff.SignerRoles)
+}
+
+func TestDiff_IdenticalDocuments(t *testing.T) {
+	from := MustParse(json.RawMessage(diffBaseDocument))
+	to := MustParse(json.RawMessage(diffBaseDocument))
+
+	assert.True(t, Diff(from, to).IsEmpty())
+}
+
+func TestDiff_BlocksWithoutIDsMatchByPosition(t *testing.T) {
+	from := MustParse(json.RawMessage(`{"content": {"type": "doc", "content": [
+		{"type": "paragraph", "content": [{"type": "text", "text": "A"}]}
+	]}}`))
+	to := MustParse(json.RawMessage(`{"content": {"type": "doc", "content": [
+		{"type": "paragraph", "content": [{"type": "text", "text": "B"}]},
+		{"type": "horizontalRule"}
+	]}}`))
+
+	diff := Diff(from, to)
+
+	assert.Equal(t, []BlockChange{
+		{Kind: ChangeModified, Type: NodeTypeParagraph, Index: 0},
+		{Kind: ChangeAdded, Type: NodeTypeHR, Index: 1},
+	}, diff.Blocks)
+}
+
+func TestDiff_FromNilReportsEverythingAdded(t *testing.T) {
+	to := MustParse(json.RawMessage(diffBaseDocument))
+
+	diff := Diff(nil, to)
+
+	require.Len(t, diff.Blocks, 3)
+	for _, change := range diff.Blocks {
+		assert.Equal(t, ChangeAdded, change.Kind)
+	}
+	assert.Equal(t, []string{"client_name", "start_date"}, diff.VariablesAdded)
+	assert.Empty(t, diff.VariablesRemoved)
+}
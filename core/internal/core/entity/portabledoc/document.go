@@ -7,15 +7,19 @@ const CurrentVersion = "1.2.0"
 
 // Document represents the complete portable document format.
 type Document struct {
-	Version         string          `json:"version"`
-	Meta            Meta            `json:"meta"`
-	PageConfig      PageConfig      `json:"pageConfig"`
-	VariableIDs     []string        `json:"variableIds"`
-	SignerRoles     []SignerRole    `json:"signerRoles"`
-	SigningWorkflow *WorkflowConfig `json:"signingWorkflow,omitempty"`
-	Header          *DocumentHeader `json:"header,omitempty"`
-	Content         *ProseMirrorDoc `json:"content"`
-	ExportInfo      ExportInfo      `json:"exportInfo"`
+	Version          string            `json:"version"`
+	Meta             Meta              `json:"meta"`
+	PageConfig       PageConfig        `json:"pageConfig"`
+	VariableIDs      []string          `json:"variableIds"`
+	SignerRoles      []SignerRole      `json:"signerRoles"`
+	SigningWorkflow  *WorkflowConfig   `json:"signingWorkflow,omitempty"`
+	Header           *DocumentHeader   `json:"header,omitempty"`
+	CoverPage        *CoverPage        `json:"coverPage,omitempty"`
+	Watermark        *Watermark        `json:"watermark,omitempty"`
+	TextFlow         *TextFlow         `json:"textFlow,omitempty"`         // widow/orphan control; nil keeps default pagination
+	HeadingNumbering *HeadingNumbering `json:"headingNumbering,omitempty"` // nil leaves headings unnumbered
+	Content          *ProseMirrorDoc   `json:"content"`
+	ExportInfo       ExportInfo        `json:"exportInfo"`
 }
 
 // ExportInfo contains export metadata.
@@ -0,0 +1,29 @@
+package portabledoc
+
+import "strings"
+
+// DefaultHeadingNumberingPattern numbers headings as 1, 1.1, 1.1.1.
+const DefaultHeadingNumberingPattern = "1.1.1"
+
+// headingCountingSymbols are the Typst numbering counting symbols a pattern may use.
+const headingCountingSymbols = "1aAiI"
+
+// HeadingNumbering numbers headings by level with a Typst numbering pattern such as
+// "1.1.1" or "I.A.1": each counting symbol numbers one heading level and the text
+// between symbols separates them. A heading can opt out with the numbered: false attr.
+type HeadingNumbering struct {
+	Pattern string `json:"pattern,omitempty"` // empty = DefaultHeadingNumberingPattern
+}
+
+// ResolvePattern returns the configured pattern or the default when it is empty.
+func (h *HeadingNumbering) ResolvePattern() string {
+	if strings.TrimSpace(h.Pattern) == "" {
+		return DefaultHeadingNumberingPattern
+	}
+	return h.Pattern
+}
+
+// IsValidHeadingNumberingPattern reports whether pattern has at least one counting symbol.
+func IsValidHeadingNumberingPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, headingCountingSymbols)
+}
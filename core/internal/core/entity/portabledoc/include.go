@@ -0,0 +1,63 @@
+package portabledoc
+
+// Include node attrs keys.
+const (
+	AttrIncludeVersionID = "versionId"
+	AttrIncludeLatest    = "latest"
+)
+
+// IncludeAttrs represents include node attributes: a reference to another
+// template whose published content is inlined in place of the node at render time.
+// VersionID pins the include to one version of that template; it is set when the
+// including version is published so later renders reproduce the published output.
+// Latest opts out of pinning and always renders the currently published version.
+type IncludeAttrs struct {
+	TemplateID string `json:"templateId"`
+	VersionID  string `json:"versionId,omitempty"`
+	Latest     bool   `json:"latest,omitempty"`
+}
+
+// Pinned reports whether the include renders a fixed version.
+func (a IncludeAttrs) Pinned() bool {
+	return a.VersionID != "" && !a.Latest
+}
+
+// PinIncludes sets the version of every include not marked latest to the one returned
+// by resolve for its template, replacing any earlier pin. Reports whether any include changed.
+func (d *Document) PinIncludes(resolve func(templateID string) (versionID string, err error)) (bool, error) {
+	if d.Content == nil {
+		return false, nil
+	}
+	return pinIncludes(d.Content.Content, resolve)
+}
+
+func pinIncludes(nodes []Node, resolve func(string) (string, error)) (bool, error) {
+	changed := false
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Type == NodeTypeInclude {
+			attrs, err := ParseIncludeAttrs(node.Attrs)
+			if err != nil {
+				return false, err
+			}
+			if attrs.Latest || attrs.TemplateID == "" {
+				continue
+			}
+			versionID, err := resolve(attrs.TemplateID)
+			if err != nil {
+				return false, err
+			}
+			if versionID != attrs.VersionID {
+				node.Attrs[AttrIncludeVersionID] = versionID
+				changed = true
+			}
+			continue
+		}
+		nested, err := pinIncludes(node.Content, resolve)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || nested
+	}
+	return changed, nil
+}
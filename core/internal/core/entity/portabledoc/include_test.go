@@ -0,0 +1,54 @@
+package portabledoc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func includeTestNode(attrs map[string]any) Node {
+	return Node{Type: NodeTypeInclude, Attrs: attrs}
+}
+
+func TestDocumentPinIncludes(t *testing.T) {
+	published := map[string]string{"clause": "clause-v2", "footer": "footer-v1"}
+	resolve := func(templateID string) (string, error) {
+		if id, ok := published[templateID]; ok {
+			return id, nil
+		}
+		return "", errors.New("not published")
+	}
+
+	doc := &Document{Content: &ProseMirrorDoc{Type: NodeTypeDoc, Content: []Node{
+		includeTestNode(map[string]any{"templateId": "clause"}),
+		{Type: NodeTypeBulletList, Content: []Node{{Type: NodeTypeListItem, Content: []Node{
+			includeTestNode(map[string]any{"templateId": "footer", "versionId": "footer-v0"}),
+		}}}},
+		includeTestNode(map[string]any{"templateId": "clause", "versionId": "clause-v1", "latest": true}),
+	}}}
+
+	changed, err := doc.PinIncludes(resolve)
+
+	require.NoError(t, err)
+	assert.True(t, changed)
+	content := doc.Content.Content
+	assert.Equal(t, "clause-v2", content[0].Attrs[AttrIncludeVersionID])
+	assert.Equal(t, "footer-v1", content[1].Content[0].Content[0].Attrs[AttrIncludeVersionID], "earlier pins are replaced")
+	assert.Equal(t, "clause-v1", content[2].Attrs[AttrIncludeVersionID], "latest includes are left alone")
+
+	changed, err = doc.PinIncludes(resolve)
+	require.NoError(t, err)
+	assert.False(t, changed, "pinning again to the same versions changes nothing")
+
+	delete(published, "clause")
+	_, err = doc.PinIncludes(resolve)
+	assert.Error(t, err, "an include without a published version cannot be pinned")
+}
+
+func TestIncludeAttrsPinned(t *testing.T) {
+	assert.True(t, IncludeAttrs{TemplateID: "t", VersionID: "v"}.Pinned())
+	assert.False(t, IncludeAttrs{TemplateID: "t"}.Pinned())
+	assert.False(t, IncludeAttrs{TemplateID: "t", VersionID: "v", Latest: true}.Pinned())
+}
@@ -13,6 +13,11 @@ type InjectorAttrs struct {
 	RoleID         *string `json:"roleId,omitempty"`
 	RoleLabel      *string `json:"roleLabel,omitempty"`
 	PropertyKey    *string `json:"propertyKey,omitempty"` // "name" | "email"
+	Grouping       *bool   `json:"grouping,omitempty"`    // false keeps integers ungrouped (IDs, years)
+
+	// FallbackVariableIDs are tried in order when VariableID resolves empty,
+	// before the node and variable defaults.
+	FallbackVariableIDs []string `json:"fallbackVariableIds,omitempty"`
 }
 
 // IsRoleVar returns true if this is a role variable.
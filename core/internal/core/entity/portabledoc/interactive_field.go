@@ -2,15 +2,18 @@ package portabledoc
 
 // InteractiveFieldAttrs represents interactive field attributes in a document.
 type InteractiveFieldAttrs struct {
-	ID            string              `json:"id"`
-	FieldType     string              `json:"fieldType"` // "checkbox" | "radio" | "text"
-	RoleID        string              `json:"roleId"`
-	Label         string              `json:"label"` // question/title
-	Required      bool                `json:"required"`
-	Options       []InteractiveOption `json:"options,omitempty"`       // checkbox/radio
-	Placeholder   string              `json:"placeholder,omitempty"`   // text
-	MaxLength     int                 `json:"maxLength,omitempty"`     // text, 0=unlimited
-	OptionsLayout string              `json:"optionsLayout,omitempty"` // "vertical" | "inline", default: vertical
+	ID                       string              `json:"id"`
+	FieldType                string              `json:"fieldType"` // "checkbox" | "radio" | "text"
+	RoleID                   string              `json:"roleId"`
+	Label                    string              `json:"label"` // question/title
+	Required                 bool                `json:"required"`
+	Options                  []InteractiveOption `json:"options,omitempty"`                  // checkbox/radio
+	DefaultSelectedOptionIDs []string            `json:"defaultSelectedOptionIds,omitempty"` // checkbox/radio, shown until a response exists
+	Placeholder              string              `json:"placeholder,omitempty"`              // text
+	MaxLength                int                 `json:"maxLength,omitempty"`                // text, 0=unlimited
+	Multiline                bool                `json:"multiline,omitempty"`                // text, rendered as ruled rows or a bounded box
+	Lines                    int                 `json:"lines,omitempty"`                    // text, rows for multiline; 0 = derived from maxLength
+	OptionsLayout            string              `json:"optionsLayout,omitempty"`            // "vertical" | "inline", default: vertical
 }
 
 // GetOptionsLayout returns the layout, defaulting to vertical.
@@ -21,6 +24,26 @@ func (a *InteractiveFieldAttrs) GetOptionsLayout() string {
 	return InteractiveFieldLayoutVertical
 }
 
+// Multiline text layout defaults.
+const (
+	DefaultTextFieldLines = 3  // rows when neither lines nor maxLength is set
+	MaxTextFieldLines     = 20 // cap on rendered rows
+	TextFieldCharsPerLine = 80 // estimate used to derive rows from maxLength
+)
+
+// GetLines returns the number of rows a multiline text field renders: lines when set,
+// otherwise enough rows for maxLength characters, capped at MaxTextFieldLines.
+func (a *InteractiveFieldAttrs) GetLines() int {
+	lines := a.Lines
+	if lines <= 0 && a.MaxLength > 0 {
+		lines = (a.MaxLength + TextFieldCharsPerLine - 1) / TextFieldCharsPerLine
+	}
+	if lines <= 0 {
+		lines = DefaultTextFieldLines
+	}
+	return min(lines, MaxTextFieldLines)
+}
+
 // InteractiveOption represents a single option in a checkbox or radio field.
 type InteractiveOption struct {
 	ID    string `json:"id"`
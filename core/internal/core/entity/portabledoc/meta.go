@@ -1,11 +1,47 @@
 package portabledoc
 
+import "fmt"
+
 // Meta contains document metadata.
 type Meta struct {
 	Title        string            `json:"title"`
 	Description  *string           `json:"description,omitempty"`
 	Language     string            `json:"language"` // "en" | "es"
 	CustomFields map[string]string `json:"customFields,omitempty"`
+	// Properties are written to the PDF's document info. Nil keeps only the title.
+	Properties *DocumentProperties `json:"properties,omitempty"`
+}
+
+// DocumentProperties holds PDF document properties. Each field is literal text or
+// an injectable reference resolved at render time.
+type DocumentProperties struct {
+	Title    *FieldValue  `json:"title,omitempty"` // overrides Meta.Title when it resolves non-empty
+	Author   *FieldValue  `json:"author,omitempty"`
+	Subject  *FieldValue  `json:"subject,omitempty"`
+	Keywords []FieldValue `json:"keywords,omitempty"`
+}
+
+// InjectableRefs returns the injectable references used by the properties, keyed by JSON path
+// relative to meta.properties.
+func (p *DocumentProperties) InjectableRefs() map[string][]string {
+	if p == nil {
+		return nil
+	}
+	refs := make(map[string][]string)
+	add := func(path string, f *FieldValue) {
+		if f != nil && f.IsInjectable() {
+			if r := f.InjectableRefs(); len(r) > 0 {
+				refs[path] = r
+			}
+		}
+	}
+	add("title", p.Title)
+	add("author", p.Author)
+	add("subject", p.Subject)
+	for i := range p.Keywords {
+		add(fmt.Sprintf("keywords[%d]", i), &p.Keywords[i])
+	}
+	return refs
 }
 
 // PageConfig contains page configuration.
@@ -18,7 +54,8 @@ type PageConfig struct {
 	PageGap         float64 `json:"pageGap"`
 }
 
-// Margins defines page margins in pixels.
+// Margins defines page margins in pixels. In JSON a margin may also be a length
+// with a unit, such as "20mm" or "0.5in" (see ParseLength).
 type Margins struct {
 	Top    float64 `json:"top"`
 	Bottom float64 `json:"bottom"`
@@ -0,0 +1,46 @@
+package portabledoc
+
+// AttrNodeID is the attrs key holding a node's stable identifier.
+// IDs are optional and assigned by the editor; rendering uses them only as
+// cross-reference labels.
+const AttrNodeID = "nodeId"
+
+// ID returns the node's stable identifier, or "" if it has none.
+func (n Node) ID() string {
+	id, _ := n.Attrs[AttrNodeID].(string)
+	return id
+}
+
+// SetID sets the node's stable identifier.
+func (n *Node) SetID(id string) {
+	if n.Attrs == nil {
+		n.Attrs = make(map[string]any, 1)
+	}
+	n.Attrs[AttrNodeID] = id
+}
+
+// FindNode returns the node with the given ID anywhere in the content.
+func (d *Document) FindNode(id string) (Node, bool) {
+	if id == "" {
+		return Node{}, false
+	}
+	for node := range d.AllNodesRecursive() {
+		if node.ID() == id {
+			return node, true
+		}
+	}
+	return Node{}, false
+}
+
+// BlockIndex returns the index of the top-level content block with the given ID.
+func (d *Document) BlockIndex(id string) (int, bool) {
+	if d.Content == nil || id == "" {
+		return 0, false
+	}
+	for i, node := range d.Content.Content {
+		if node.ID() == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+package portabledoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nodeIDDocument = `{
+	"version": "1.2.0",
+	"meta": {"title": "Contract"},
+	"content": {
+		"type": "doc",
+		"content": [
+			{"type": "heading", "attrs": {"level": 1, "nodeId": "h-1"}, "content": [{"type": "text", "text": "Terms"}]},
+			{"type": "paragraph", "attrs": {"nodeId": "p-1"}, "content": [
+				{"type": "text", "text": "Signed by "},
+				{"type": "injector", "attrs": {"variableId": "client_name", "nodeId": "inj-1"}}
+			]},
+			{"type": "paragraph", "content": [{"type": "text", "text": "No id"}]}
+		]
+	}
+}`
+
+func TestNodeIDs_SurviveParseSerializeRoundTrip(t *testing.T) {
+	doc, err := Parse(json.RawMessage(nodeIDDocument))
+	require.NoError(t, err)
+
+	data, err := doc.Serialize()
+	require.NoError(t, err)
+	reparsed, err := Parse(data)
+	require.NoError(t, err)
+
+	blocks := reparsed.Content.Content
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "h-1", blocks[0].ID())
+	assert.Equal(t, "p-1", blocks[1].ID())
+	assert.Equal(t, "inj-1", blocks[1].Content[1].ID())
+	assert.Equal(t, "", blocks[2].ID())
+	assert.NotContains(t, blocks[2].Attrs, AttrNodeID, "nodes without an id are not given one")
+}
+
+func TestDocument_FindNodeAndBlockIndex(t *testing.T) {
+	doc := MustParse(json.RawMessage(nodeIDDocument))
+
+	node, ok := doc.FindNode("inj-1")
+	require.True(t, ok)
+	assert.Equal(t, NodeTypeInjector, node.Type)
+
+	idx, ok := doc.BlockIndex("p-1")
+	require.True(t, ok)
+	assert.Equal(t, 1, idx)
+
+	_, ok = doc.BlockIndex("inj-1")
+	assert.False(t, ok, "nested nodes are not top-level blocks")
+	_, ok = doc.FindNode("")
+	assert.False(t, ok)
+}
+
+func TestNode_SetID(t *testing.T) {
+	var n Node
+	n.SetID("p-9")
+	assert.Equal(t, "p-9", n.ID())
+}
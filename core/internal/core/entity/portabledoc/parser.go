@@ -72,6 +72,21 @@ func ParseInjectorAttrs(attrs map[string]any) (*InjectorAttrs, error) {
 	return &ia, nil
 }
 
+// ParseIncludeAttrs parses node attrs into IncludeAttrs.
+func ParseIncludeAttrs(attrs map[string]any) (*IncludeAttrs, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ia IncludeAttrs
+	if err := json.Unmarshal(data, &ia); err != nil {
+		return nil, err
+	}
+
+	return &ia, nil
+}
+
 // ParseLogicGroup parses any value into LogicGroup.
 func ParseLogicGroup(v any) (*LogicGroup, error) {
 	data, err := json.Marshal(v)
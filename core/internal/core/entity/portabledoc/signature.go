@@ -1,5 +1,10 @@
 package portabledoc
 
+import (
+	"strconv"
+	"strings"
+)
+
 // SignatureAttrs represents signature block attributes.
 type SignatureAttrs struct {
 	Count      int             `json:"count"` // 1-4
@@ -10,17 +15,38 @@ type SignatureAttrs struct {
 
 // SignatureItem represents a single signature in a block.
 type SignatureItem struct {
-	ID            string   `json:"id"`
-	RoleID        *string  `json:"roleId,omitempty"`
-	Label         string   `json:"label"`
-	Subtitle      *string  `json:"subtitle,omitempty"`
-	ImageData     *string  `json:"imageData,omitempty"`
-	ImageOriginal *string  `json:"imageOriginal,omitempty"`
-	ImageOpacity  *float64 `json:"imageOpacity,omitempty"`
-	ImageRotation *int     `json:"imageRotation,omitempty"`
-	ImageScale    *float64 `json:"imageScale,omitempty"`
-	ImageX        *float64 `json:"imageX,omitempty"`
-	ImageY        *float64 `json:"imageY,omitempty"`
+	ID            string             `json:"id"`
+	RoleID        *string            `json:"roleId,omitempty"`
+	Label         string             `json:"label"`
+	Subtitle      *string            `json:"subtitle,omitempty"`
+	ImageData     *string            `json:"imageData,omitempty"`
+	ImageOriginal *string            `json:"imageOriginal,omitempty"`
+	ImageOpacity  *float64           `json:"imageOpacity,omitempty"`
+	ImageRotation *int               `json:"imageRotation,omitempty"`
+	ImageScale    *float64           `json:"imageScale,omitempty"`
+	ImageX        *float64           `json:"imageX,omitempty"`
+	ImageY        *float64           `json:"imageY,omitempty"`
+	InjectableID  *string            `json:"injectableId,omitempty"` // variable supplying a facsimile image; ImageData wins once signed
+	LineWidth     *float64           `json:"lineWidth,omitempty"`    // px; overrides the block's line width within the layout's column
+	Captions      []SignatureCaption `json:"captions,omitempty"`
+	DateLine      *SignatureDateLine `json:"dateLine,omitempty"`
+	SignedAt      *string            `json:"signedAt,omitempty"` // RFC 3339 or YYYY-MM-DD; set when the image is applied
+}
+
+// SignatureCaption is an extra line printed beneath the signature label,
+// such as a printed name or a title.
+type SignatureCaption struct {
+	Text   string   `json:"text"`
+	Size   *float64 `json:"size,omitempty"` // pt
+	Bold   bool     `json:"bold,omitempty"`
+	Italic bool     `json:"italic,omitempty"`
+	Color  *string  `json:"color,omitempty"` // hex, e.g. "#555555"
+}
+
+// SignatureDateLine prints a "Date: ____" line beneath the signature that is
+// filled with SignedAt once the signature is signed.
+type SignatureDateLine struct {
+	Label string `json:"label,omitempty"`
 }
 
 // IsSigned returns true if signature has image data.
@@ -28,6 +54,11 @@ func (s SignatureItem) IsSigned() bool {
 	return s.ImageData != nil && *s.ImageData != ""
 }
 
+// HasInjectableImage returns true if the signature image is bound to a variable.
+func (s SignatureItem) HasInjectableImage() bool {
+	return s.InjectableID != nil && *s.InjectableID != ""
+}
+
 // HasRole returns true if signature has a role assigned.
 func (s SignatureItem) HasRole() bool {
 	return s.RoleID != nil && *s.RoleID != ""
@@ -41,6 +72,37 @@ func (s SignatureItem) GetRoleID() string {
 	return *s.RoleID
 }
 
+// Placeholders a signature's label, subtitle and captions may use, e.g.
+// "Signatory {index} of {total}". They are replaced with the signature's 1-based
+// position in its block and the block's number of signatures.
+const (
+	SignatureIndexPlaceholder = "{index}"
+	SignatureTotalPlaceholder = "{total}"
+)
+
+// Numbered returns a copy of the signature with the index and total placeholders
+// in its label, subtitle and captions replaced.
+func (s SignatureItem) Numbered(index, total int) SignatureItem {
+	r := strings.NewReplacer(
+		SignatureIndexPlaceholder, strconv.Itoa(index),
+		SignatureTotalPlaceholder, strconv.Itoa(total),
+	)
+	s.Label = r.Replace(s.Label)
+	if s.Subtitle != nil {
+		subtitle := r.Replace(*s.Subtitle)
+		s.Subtitle = &subtitle
+	}
+	if len(s.Captions) > 0 {
+		captions := make([]SignatureCaption, len(s.Captions))
+		for i, caption := range s.Captions {
+			caption.Text = r.Replace(caption.Text)
+			captions[i] = caption
+		}
+		s.Captions = captions
+	}
+	return s
+}
+
 // Signature count constraints.
 const (
 	MinSignatureCount = 1
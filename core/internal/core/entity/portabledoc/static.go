@@ -0,0 +1,62 @@
+package portabledoc
+
+// dynamicNodeTypes are nodes whose output depends on render inputs
+// (injectable values, signer roles or field responses).
+var dynamicNodeTypes = map[string]bool{
+	NodeTypeInjector:         true,
+	NodeTypeConditional:      true,
+	NodeTypeSignature:        true,
+	NodeTypeListInjector:     true,
+	NodeTypeTableInjector:    true,
+	NodeTypeInteractiveField: true,
+	NodeTypeInsertionPoint:   true,
+	NodeTypeInclude:          true,
+}
+
+// IsStatic reports whether the document renders the same for any render inputs:
+// no injectors, conditionals, signatures, interactive fields or injectable-bound
+// images in the body, header or cover page, and no injectable watermark, properties
+// or cover text.
+func (d *Document) IsStatic() bool {
+	if d.Watermark.HasInjectable() {
+		return false
+	}
+	if d.Meta.Properties != nil && len(d.Meta.Properties.InjectableRefs()) > 0 {
+		return false
+	}
+	if d.CoverPage.IsEnabled() && (d.CoverPage.HasImageInjectable() || len(d.CoverPage.InjectableRefs()) > 0) {
+		return false
+	}
+	if d.Header != nil && d.Header.Enabled {
+		if d.Header.ImageInjectableID != nil && *d.Header.ImageInjectableID != "" {
+			return false
+		}
+		if hasDynamicNode(d.Header.TextNodes()) {
+			return false
+		}
+	}
+	if d.Content == nil {
+		return true
+	}
+	return !hasDynamicNode(d.Content.Content)
+}
+
+func hasDynamicNode(nodes []Node) bool {
+	for _, node := range nodes {
+		if dynamicNodeTypes[node.Type] {
+			return true
+		}
+		if id, _ := node.Attrs["injectableId"].(string); id != "" {
+			return true
+		}
+		for _, mark := range node.Marks {
+			if mark.Type == MarkTypeConditionalStyle {
+				return true
+			}
+		}
+		if hasDynamicNode(node.Content) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package portabledoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func staticTestDocument(nodes ...Node) *Document {
+	text := "Terms"
+	base := []Node{{Type: NodeTypeHeading, Content: []Node{{Type: NodeTypeText, Text: &text}}}}
+	return &Document{Content: &ProseMirrorDoc{Type: NodeTypeDoc, Content: append(base, nodes...)}}
+}
+
+func TestDocumentIsStatic(t *testing.T) {
+	variable := "client_name"
+	nested := func(n Node) Node {
+		return Node{Type: NodeTypeBulletList, Content: []Node{{Type: NodeTypeListItem, Content: []Node{n}}}}
+	}
+
+	assert.True(t, staticTestDocument().IsStatic())
+	assert.True(t, (&Document{}).IsStatic(), "an empty document is static")
+	assert.True(t, staticTestDocument(Node{Type: NodeTypeImage, Attrs: map[string]any{"src": "https://example.com/logo.png"}}).IsStatic())
+	withTextCover := staticTestDocument()
+	withTextCover.CoverPage = &CoverPage{Enabled: true, Title: &FieldValue{Type: "text", Value: "Proposal"}}
+	assert.True(t, withTextCover.IsStatic(), "a cover with literal text is static")
+
+	dynamic := map[string]*Document{
+		"injector":         staticTestDocument(Node{Type: NodeTypeInjector, Attrs: map[string]any{"variableId": variable}}),
+		"nested injector":  staticTestDocument(nested(Node{Type: NodeTypeInjector})),
+		"conditional":      staticTestDocument(Node{Type: NodeTypeConditional}),
+		"signature":        staticTestDocument(Node{Type: NodeTypeSignature}),
+		"table injector":   staticTestDocument(Node{Type: NodeTypeTableInjector}),
+		"interactive":      staticTestDocument(Node{Type: NodeTypeInteractiveField}),
+		"injectable image": staticTestDocument(Node{Type: NodeTypeCustomImage, Attrs: map[string]any{"injectableId": "logo"}}),
+		"conditional style": staticTestDocument(Node{Type: NodeTypeParagraph, Content: []Node{
+			{Type: NodeTypeText, Text: &variable, Marks: []Mark{{Type: MarkTypeConditionalStyle}}},
+		}}),
+		"watermark": func() *Document {
+			d := staticTestDocument()
+			d.Watermark = &Watermark{Text: "DRAFT", InjectableID: &variable}
+			return d
+		}(),
+		"header text": func() *Document {
+			d := staticTestDocument()
+			d.Header = &DocumentHeader{Enabled: true, Content: &ProseMirrorDoc{Content: []Node{
+				{Type: NodeTypeParagraph, Content: []Node{{Type: NodeTypeInjector}}},
+			}}}
+			return d
+		}(),
+		"properties": func() *Document {
+			d := staticTestDocument()
+			d.Meta.Properties = &DocumentProperties{Author: &FieldValue{Type: "injectable", Value: variable}}
+			return d
+		}(),
+		"cover title": func() *Document {
+			d := staticTestDocument()
+			d.CoverPage = &CoverPage{Enabled: true, Title: &FieldValue{Type: "injectable", Value: variable}}
+			return d
+		}(),
+	}
+	for name, doc := range dynamic {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, doc.IsStatic())
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package portabledoc
+
+// TextFlow controls how paragraphs break across pages. Costs are percentages:
+// 0 allows the break freely, 100 is the renderer's default, higher values avoid it harder.
+type TextFlow struct {
+	WidowCost  *int `json:"widowCost,omitempty"`  // paragraph's last line left alone at the top of a page
+	OrphanCost *int `json:"orphanCost,omitempty"` // paragraph's first line left alone at the bottom of a page
+}
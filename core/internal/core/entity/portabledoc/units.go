@@ -0,0 +1,88 @@
+package portabledoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// pxPerUnit maps length units to CSS pixels (96 per inch), the unit of bare numbers.
+var pxPerUnit = map[string]float64{
+	"px": 1,
+	"pt": 96.0 / 72,
+	"in": 96,
+	"cm": 96 / 2.54,
+	"mm": 96 / 25.4,
+}
+
+// ParseLength converts a length such as "20mm", "0.5in", "2cm", "12pt" or "40px"
+// to pixels. A bare number is pixels. Negative, NaN and infinite lengths are rejected.
+func ParseLength(s string) (float64, error) {
+	num := strings.TrimSpace(strings.ToLower(s))
+	factor := 1.0
+	for unit, px := range pxPerUnit {
+		if n, ok := strings.CutSuffix(num, unit); ok {
+			num, factor = strings.TrimSpace(n), px
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	px := value * factor
+	if err != nil || !validLength(px) {
+		return 0, fmt.Errorf("invalid length %q", s)
+	}
+	return px, nil
+}
+
+// validLength reports whether v is a finite, non-negative length.
+func validLength(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 1)
+}
+
+// LengthPx reads a length attr in pixels: a number is pixels, a string may carry a unit.
+func LengthPx(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, validLength(v)
+	case string:
+		px, err := ParseLength(v)
+		return px, err == nil
+	}
+	return 0, false
+}
+
+// UnmarshalJSON accepts each margin as pixels or as a length with a unit.
+func (m *Margins) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Top    any `json:"top"`
+		Bottom any `json:"bottom"`
+		Left   any `json:"left"`
+		Right  any `json:"right"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	sides := []struct {
+		name string
+		raw  any
+		dst  *float64
+	}{
+		{"top", raw.Top, &m.Top},
+		{"bottom", raw.Bottom, &m.Bottom},
+		{"left", raw.Left, &m.Left},
+		{"right", raw.Right, &m.Right},
+	}
+	for _, side := range sides {
+		if side.raw == nil {
+			continue
+		}
+		px, ok := LengthPx(side.raw)
+		if !ok {
+			return fmt.Errorf("margins.%s: invalid length %v", side.name, side.raw)
+		}
+		*side.dst = px
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package portabledoc
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"40", 40},
+		{"40px", 40},
+		{"12pt", 16},
+		{"0.5in", 48},
+		{"1 IN", 96},
+		{"2.54cm", 96},
+		{"25.4mm", 96},
+		{"20mm", 75.59},
+	}
+	for _, tt := range tests {
+		got, err := ParseLength(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.InDelta(t, tt.want, got, 0.01, tt.in)
+	}
+
+	for _, in := range []string{"", "mm", "20em", "wide", "-5mm", "NaN", "nanpx", "Inf", "+infmm", "1e400", "1e308in"} {
+		_, err := ParseLength(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestLengthPx(t *testing.T) {
+	px, ok := LengthPx(float64(120))
+	assert.True(t, ok)
+	assert.Equal(t, 120.0, px)
+
+	px, ok = LengthPx("1in")
+	assert.True(t, ok)
+	assert.Equal(t, 96.0, px)
+
+	_, ok = LengthPx("20em")
+	assert.False(t, ok)
+	_, ok = LengthPx(float64(-1))
+	assert.False(t, ok)
+	_, ok = LengthPx(math.NaN())
+	assert.False(t, ok)
+	_, ok = LengthPx(nil)
+	assert.False(t, ok)
+}
+
+func TestMargins_UnmarshalUnits(t *testing.T) {
+	var pc PageConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"margins":{"top":96,"bottom":"25.4mm","left":"0.5in","right":"2.54cm"}}`), &pc))
+	assert.Equal(t, 96.0, pc.Margins.Top)
+	assert.InDelta(t, 96, pc.Margins.Bottom, 0.001)
+	assert.Equal(t, 48.0, pc.Margins.Left)
+	assert.InDelta(t, 96, pc.Margins.Right, 0.001)
+
+	err := json.Unmarshal([]byte(`{"margins":{"top":"1em"}}`), &pc)
+	assert.ErrorContains(t, err, "margins.top")
+}
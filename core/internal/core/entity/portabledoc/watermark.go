@@ -0,0 +1,15 @@
+package portabledoc
+
+// Watermark configures text stamped diagonally across every page (e.g. "DRAFT", "VOID").
+type Watermark struct {
+	Text         string   `json:"text"`                   // fallback text when InjectableID is unset or empty
+	InjectableID *string  `json:"injectableId,omitempty"` // variable whose value replaces Text (e.g. recipient name)
+	Opacity      *float64 `json:"opacity,omitempty"`      // 0-1; nil = 0.15
+	Angle        *float64 `json:"angle,omitempty"`        // degrees, clockwise (negative tilts upward); nil = -45
+	Color        string   `json:"color,omitempty"`        // hex color; empty = default
+}
+
+// HasInjectable returns true when the watermark text is bound to a variable.
+func (w *Watermark) HasInjectable() bool {
+	return w != nil && w.InjectableID != nil && *w.InjectableID != ""
+}
@@ -0,0 +1,13 @@
+package entity
+
+// Built-in injectables that every render resolves to the moment it runs, in the
+// render's timezone. A value sent with the render takes precedence.
+const (
+	RenderNowInjectable   = "system.now"   // date and time of the render
+	RenderTodayInjectable = "system.today" // date of the render
+)
+
+// IsRenderTimeInjectable reports whether key is one of the built-in render time injectables.
+func IsRenderTimeInjectable(key string) bool {
+	return key == RenderNowInjectable || key == RenderTodayInjectable
+}
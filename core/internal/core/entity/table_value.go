@@ -12,11 +12,12 @@ type TableStyles struct {
 
 // TableColumn defines a column in a dynamic table.
 type TableColumn struct {
-	Key      string            `json:"key"`              // unique column identifier
-	Labels   map[string]string `json:"labels"`           // i18n labels: {"es":"Nombre","en":"Name"}
-	DataType ValueType         `json:"dataType"`         // expected cell value type
-	Width    *string           `json:"width,omitempty"`  // e.g., "100px", "20%"
-	Format   *string           `json:"format,omitempty"` // format string for the column
+	Key       string            `json:"key"`                 // unique column identifier
+	Labels    map[string]string `json:"labels"`              // i18n labels: {"es":"Nombre","en":"Name"}
+	DataType  ValueType         `json:"dataType"`            // expected cell value type
+	Width     *string           `json:"width,omitempty"`     // e.g., "100px", "20%"
+	Format    *string           `json:"format,omitempty"`    // format string for the column
+	Precision *int              `json:"precision,omitempty"` // decimals shown for number cells when Format is unset
 }
 
 // TableCell represents a single cell in a table row.
@@ -79,6 +80,17 @@ func (t *TableValue) AddColumnWithFormat(key string, labels map[string]string, d
 	return t
 }
 
+// AddColumnWithPrecision adds a number column shown with a fixed number of decimals.
+func (t *TableValue) AddColumnWithPrecision(key string, labels map[string]string, precision int) *TableValue {
+	t.Columns = append(t.Columns, TableColumn{
+		Key:       key,
+		Labels:    labels,
+		DataType:  ValueTypeNumber,
+		Precision: &precision,
+	})
+	return t
+}
+
 // AddRow adds a row of cells to the table.
 func (t *TableValue) AddRow(cells ...TableCell) *TableValue {
 	t.Rows = append(t.Rows, TableRow{Cells: cells})
@@ -54,6 +54,18 @@ func (t *Template) Validate() error {
 	return nil
 }
 
+// TemplateClone holds everything a library clone writes into the target workspace,
+// so it can be persisted atomically.
+type TemplateClone struct {
+	Template    *Template
+	Version     *TemplateVersion
+	Definitions []*InjectableDefinition      // Workspace definitions copied into the target workspace
+	SystemKeys  []string                     // System keys to back with definitions (FK)
+	Injectables []*TemplateVersionInjectable // Version injectables; TemplateVersionID is set on create
+	Tags        []*Tag                       // Tags created in the target workspace
+	TagIDs      []string                     // Tags attached to the template, existing or created
+}
+
 // TemplateTag represents the many-to-many relationship between templates and tags.
 type TemplateTag struct {
 	TemplateID string `json:"templateId"`
@@ -3,6 +3,7 @@ package entity
 import (
 	"encoding/json"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -25,6 +26,7 @@ type TemplateVersion struct {
 	PublishedBy           *string         `json:"publishedBy,omitempty"`
 	SigningWorkflowConfig json.RawMessage `json:"signingWorkflowConfig,omitempty"`
 	ArchivedBy            *string         `json:"archivedBy,omitempty"`
+	ChangeNotes           *string         `json:"changeNotes,omitempty"` // What changed, recorded at publish time
 	CreatedBy             *string         `json:"createdBy,omitempty"`
 	CreatedAt             time.Time       `json:"createdAt"`
 	UpdatedAt             *time.Time      `json:"updatedAt,omitempty"`
@@ -118,6 +120,16 @@ func (tv *TemplateVersion) Publish(userID string) {
 	tv.UpdatedAt = &now
 }
 
+// SetChangeNotes records what changed in this version. Blank notes clear them.
+func (tv *TemplateVersion) SetChangeNotes(notes string) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		tv.ChangeNotes = nil
+		return
+	}
+	tv.ChangeNotes = &notes
+}
+
 // Archive changes the version status to ARCHIVED.
 func (tv *TemplateVersion) Archive(userID string) {
 	now := time.Now().UTC()
@@ -243,6 +255,18 @@ type TemplateVersionWithDetails struct {
 	SignerRoles []*TemplateVersionSignerRole       `json:"signerRoles,omitempty"`
 }
 
+// VersionInjectableField describes an injectable referenced in a version's content,
+// with the metadata a client needs to generate a data-entry form for it.
+type VersionInjectableField struct {
+	Key          string  `json:"key"`
+	Type         string  `json:"type"`
+	Label        string  `json:"label"`
+	DefaultValue *string `json:"defaultValue,omitempty"`
+	Required     bool    `json:"required"`
+	RoleID       *string `json:"roleId,omitempty"`      // Only for role variables
+	PropertyKey  *string `json:"propertyKey,omitempty"` // Only for role variables ("name" | "email")
+}
+
 // TemplateWithDetails represents a template with its published version and metadata.
 type TemplateWithDetails struct {
 	Template
@@ -15,7 +15,8 @@ type Workspace struct {
 	Type           WorkspaceType   `json:"type"`
 	Status         WorkspaceStatus `json:"status"`
 	IsSandbox      bool            `json:"isSandbox"`
-	SandboxOfID    *string         `json:"sandboxOfId,omitempty"` // ID of parent workspace if is_sandbox = true
+	SandboxOfID    *string         `json:"sandboxOfId,omitempty"`  // ID of parent workspace if is_sandbox = true
+	DefaultTheme   *string         `json:"defaultTheme,omitempty"` // Design theme used for renders that don't request one
 	CreatedAt      time.Time       `json:"createdAt"`
 	UpdatedAt      *time.Time      `json:"updatedAt,omitempty"`
 	LastAccessedAt *time.Time      `json:"-"` // Access metadata, not persisted
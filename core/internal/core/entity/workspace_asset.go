@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// WorkspaceAssetKind identifies what a workspace brand asset is used for.
+type WorkspaceAssetKind string
+
+// Workspace asset kinds.
+const (
+	WorkspaceAssetKindLogo WorkspaceAssetKind = "LOGO" // At most one per workspace; uploading replaces it
+	WorkspaceAssetKindFont WorkspaceAssetKind = "FONT"
+)
+
+// IsValid checks if the asset kind is valid.
+func (k WorkspaceAssetKind) IsValid() bool {
+	switch k {
+	case WorkspaceAssetKindLogo, WorkspaceAssetKindFont:
+		return true
+	}
+	return false
+}
+
+// WorkspaceLogoInjectable is the injectable renders of a workspace's templates resolve to its logo.
+const WorkspaceLogoInjectable = "workspace.logo"
+
+// IsBuiltinInjectable reports whether key is resolved by every render on its own rather
+// than by a definition: the render time injectables and the workspace logo.
+func IsBuiltinInjectable(key string) bool {
+	return IsRenderTimeInjectable(key) || key == WorkspaceLogoInjectable
+}
+
+// WorkspaceAsset is a brand asset (logo or font) shared by all templates of a workspace.
+type WorkspaceAsset struct {
+	ID          string
+	WorkspaceID string
+	Kind        WorkspaceAssetKind
+	Key         string // Storage key
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// Workspace asset errors.
+var (
+	ErrWorkspaceAssetNotFound     = newNotFound("workspace asset not found")
+	ErrInvalidWorkspaceAssetKind  = newInvalid("invalid asset kind: must be LOGO or FONT")
+	ErrWorkspaceAssetContentType  = newInvalid("invalid content type: logos must be images and fonts must be TrueType or OpenType")
+	ErrWorkspaceAssetFileTooLarge = newInvalid("file too large: maximum size is 10MB")
+)
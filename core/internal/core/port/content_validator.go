@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 )
 
 // ContentValidationResult holds the result of content validation.
@@ -51,6 +52,11 @@ type ContentValidator interface {
 	// - Conditional expression validation
 	// - Signing workflow validation
 	ValidateForPublish(ctx context.Context, workspaceID, versionID string, content []byte) *ContentValidationResult
+
+	// LintNodes checks a node tree for structural problems (malformed conditionals,
+	// signatures without roles, images without a source, unknown node types)
+	// without loading the template version or rendering it.
+	LintNodes(ctx context.Context, nodes []portabledoc.Node) *ContentValidationResult
 }
 
 // NewValidationResult creates a new validation result.
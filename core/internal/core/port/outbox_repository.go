@@ -0,0 +1,62 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// OutboxRepository defines the interface for the transactional outbox.
+// Events are written inside the transaction of the state change they describe
+// and relayed to each consumer independently afterwards.
+type OutboxRepository interface {
+	// InsertTx records an event inside the caller's transaction and sets its ID and Seq.
+	InsertTx(ctx context.Context, tx pgx.Tx, event *entity.OutboxEvent) error
+
+	// FindDeliverable lists events due for delivery to a consumer, in Seq order.
+	// Events queued behind an earlier undelivered event of the same aggregate that
+	// already failed are excluded, so each aggregate is delivered in order.
+	FindDeliverable(ctx context.Context, consumer string, limit int) ([]*DeliverableEvent, error)
+
+	// FindByAggregate lists all events for an aggregate in Seq order.
+	FindByAggregate(ctx context.Context, aggregateType, aggregateID string) ([]*entity.OutboxEvent, error)
+
+	// FindDeliveries lists the delivery state of an event for every consumer that attempted it.
+	FindDeliveries(ctx context.Context, eventID string) ([]*entity.OutboxDelivery, error)
+
+	// MarkDelivered records a successful delivery to a consumer.
+	MarkDelivered(ctx context.Context, eventID, consumer string) error
+
+	// MarkFailed records a failed delivery attempt; the event is retried at nextAttemptAt.
+	MarkFailed(ctx context.Context, eventID, consumer, reason string, nextAttemptAt time.Time) error
+
+	// AdvanceWatermark moves the consumer's watermark past the events it has delivered
+	// without a gap, so later FindDeliverable calls skip them. Only events recorded before
+	// settledBefore count: a lower seq may still be committed by a transaction in flight.
+	AdvanceWatermark(ctx context.Context, consumer string, settledBefore time.Time) error
+
+	// DeleteDelivered deletes up to limit events recorded before the cutoff that are below
+	// the watermark of every listed consumer, and returns how many were deleted.
+	DeleteDelivered(ctx context.Context, consumers []string, recordedBefore time.Time, limit int) (int64, error)
+
+	// WithConsumerLock runs fn while holding a cluster-wide lock for the consumer, so only one
+	// relay delivers to it at a time. It returns false without running fn if the lock is held elsewhere.
+	WithConsumerLock(ctx context.Context, consumer string, fn func(ctx context.Context) error) (bool, error)
+}
+
+// DeliverableEvent is an outbox event due for delivery to a consumer.
+type DeliverableEvent struct {
+	Event    *entity.OutboxEvent
+	Attempts int // Failed attempts so far for this consumer
+}
+
+// EventSink delivers outbox events to another service (webhook, message broker, ...).
+// Delivery is at-least-once: sinks may receive the same event more than once and
+// should deduplicate on the event ID.
+type EventSink interface {
+	// Deliver sends a single event. A returned error schedules a retry with backoff.
+	Deliver(ctx context.Context, event *entity.OutboxEvent) error
+}
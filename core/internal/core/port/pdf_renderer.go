@@ -3,12 +3,18 @@ package port
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
+	"time"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 )
 
 // RenderPreviewRequest contains the data needed to render a preview PDF.
 type RenderPreviewRequest struct {
+	// VersionID identifies the template version the document comes from.
+	// Optional; renders without it are never cached.
+	VersionID string
+
 	// Document is the parsed portable document to render.
 	Document *portabledoc.Document
 
@@ -29,19 +35,107 @@ type RenderPreviewRequest struct {
 	// Keys are field IDs, values are the response JSON
 	// (e.g., {"selectedOptionIds":["id1"]} or {"text":"value"}).
 	FieldResponses map[string]json.RawMessage
+
+	// SealWorkspaceID, when set, applies a PAdES digital seal with that workspace's
+	// certificate after rendering. Skipped when the workspace has no certificate.
+	SealWorkspaceID string
+
+	// Encryption, when set, password-protects the output PDF. Never log it.
+	Encryption *PDFEncryption
+
+	// PDFA produces a PDF/A-2b archival PDF and verifies its conformance claim.
+	PDFA bool
+
+	// Theme names a registered design theme to render with. Empty uses the
+	// workspace's default theme, falling back to the built-in design tokens.
+	// Unknown names fail with entity.ErrUnknownTheme.
+	Theme string
+
+	// FontKeys are storage keys of extra font files (e.g. workspace brand fonts)
+	// available to the render alongside the configured font directories.
+	FontKeys []string
+
+	// DraftMode renders reviewer comments as notes beside their anchored text.
+	// Final renders (the default) omit them.
+	DraftMode bool
+
+	// ShowPlaceholders renders unresolved inline injectors as a muted [[variableId]]
+	// so missing data stands out in editor previews. Final renders leave them empty.
+	ShowPlaceholders bool
+
+	// ShowHiddenConditionals renders conditionals whose conditions are false as a muted
+	// note with the condition, for template QA. Final renders leave them empty.
+	ShowHiddenConditionals bool
+
+	// Timezone is the IANA time zone (e.g. "America/Santiago") the render time
+	// injectables (entity.RenderNowInjectable, entity.RenderTodayInjectable) are
+	// resolved in. Empty uses UTC.
+	Timezone string
+
+	// Slots fills the document's insertion points (portabledoc.NodeTypeInsertionPoint)
+	// with nodes keyed by slot name. Unfilled slots render nothing.
+	Slots map[string][]portabledoc.Node
+
+	// ExtractSignaturePages also returns the pages holding signature fields as a
+	// separate PDF (RenderPreviewResult.SignaturePagesPDF), e.g. for archival.
+	ExtractSignaturePages bool
+
+	// Image, when set, rasterizes a single page to PNG (RenderPreviewResult.Image)
+	// instead of producing a PDF, e.g. for template thumbnails. PDF-only options
+	// (PDFA, Encryption, sealing, signature page extraction) cannot be combined with it.
+	Image *ImageOptions
+
+	// BlockIndex, when set, renders only the top-level content block at this index,
+	// without header, on a page sized to the block. Used for editor live preview.
+	BlockIndex *int
+
+	// DefaultResolver optionally supplies live values for injectables that have
+	// neither an injected value nor a static default (e.g. provider injectables).
+	// Consulted at most once per code within a render. May be nil.
+	DefaultResolver InjectableDefaultResolver
+}
+
+// ImageOptions selects the page and resolution of a PNG render.
+type ImageOptions struct {
+	Page int // 1-indexed; 0 renders the first page
+	PPI  int // pixels per inch; 0 uses the renderer's default
 }
 
+// InjectableDefaultResolver resolves a fallback value for an injectable code at render time.
+// Returns false when no value is available.
+type InjectableDefaultResolver func(ctx context.Context, code string) (any, bool)
+
 // SignerRoleValue contains the resolved name and email for a signer role.
 type SignerRoleValue struct {
 	Name  string
 	Email string
 }
 
+// PDFEncryption configures password protection of a rendered PDF.
+// At least one password is required. Passwords are redacted from logs.
+type PDFEncryption struct {
+	UserPassword  string // required to open the PDF; empty opens without a password
+	OwnerPassword string // lifts the restrictions below; empty uses a random password
+	NoPrint       bool
+	NoCopy        bool
+}
+
+// LogValue keeps passwords out of structured logs.
+func (e *PDFEncryption) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Bool("no_print", e.NoPrint),
+		slog.Bool("no_copy", e.NoCopy),
+	)
+}
+
 // RenderPreviewResult contains the result of rendering a preview PDF.
 type RenderPreviewResult struct {
-	// PDF contains the raw PDF bytes.
+	// PDF contains the raw PDF bytes. Empty for image renders.
 	PDF []byte
 
+	// Image contains the PNG bytes of the requested page. Set only for image renders.
+	Image []byte
+
 	// Filename is the suggested filename for the PDF.
 	Filename string
 
@@ -50,6 +144,12 @@ type RenderPreviewResult struct {
 
 	// SignatureFields contains position information for each signature field.
 	SignatureFields []SignatureField
+
+	// SignaturePages lists the 1-indexed pages holding signature fields, and
+	// SignaturePagesPDF holds just those pages. Set only when ExtractSignaturePages
+	// was requested and the document has signature fields.
+	SignaturePages    []int
+	SignaturePagesPDF []byte
 }
 
 // SignatureField contains position information for a signature field in the PDF.
@@ -96,3 +196,33 @@ type PDFRenderer interface {
 	// This should be called when the renderer is no longer needed.
 	Close() error
 }
+
+// ThemeRegistry reports which design themes the renderer was configured with.
+type ThemeRegistry interface {
+	HasTheme(name string) bool
+}
+
+// RenderPoolStats is a snapshot of the render worker pool.
+type RenderPoolStats struct {
+	MaxConcurrent int    `json:"maxConcurrent"` // 0 = unlimited
+	MaxQueue      int    `json:"maxQueue"`      // 0 = unbounded
+	InFlight      int    `json:"inFlight"`
+	Queued        int    `json:"queued"`
+	Rejected      uint64 `json:"rejected"`  // Since startup: queue full or timed out waiting
+	Completed     uint64 `json:"completed"` // Since startup
+}
+
+// RenderPoolMonitor is implemented by renderers that bound concurrent renders.
+type RenderPoolMonitor interface {
+	RenderPoolStats() RenderPoolStats
+}
+
+// RenderCache stores rendered PDFs by a key derived from the version and all render inputs.
+// Implementations must be safe for concurrent use. Errors are logged and treated as misses.
+type RenderCache interface {
+	// Get returns the cached result for key, or false when absent or expired.
+	Get(ctx context.Context, key string) (*RenderPreviewResult, bool, error)
+
+	// Set stores a result for key, expiring after ttl.
+	Set(ctx context.Context, key string, result *RenderPreviewResult, ttl time.Duration) error
+}
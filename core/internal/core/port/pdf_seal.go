@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+)
+
+// SealCertificate is the certificate and key used to apply a PAdES seal to a PDF.
+type SealCertificate struct {
+	Certificate *x509.Certificate
+	Chain       []*x509.Certificate // intermediates, embedded so verifiers can build the path
+	Signer      crypto.Signer
+}
+
+// SealCertificateProvider resolves the sealing certificate for a workspace.
+type SealCertificateProvider interface {
+	// SealCertificate returns the workspace's certificate, or nil when it has none.
+	SealCertificate(ctx context.Context, workspaceID string) (*SealCertificate, error)
+}
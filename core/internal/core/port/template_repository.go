@@ -40,8 +40,16 @@ type TemplateRepository interface {
 	// FindByFolder lists all templates in a folder.
 	FindByFolder(ctx context.Context, folderID string) ([]*entity.TemplateListItem, error)
 
-	// FindPublicLibrary lists all public library templates.
-	FindPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error)
+	// FindPublicLibrary lists the published public library templates of the workspace's tenant,
+	// from active non-sandbox workspaces, with optional filters.
+	FindPublicLibrary(ctx context.Context, workspaceID string, filters TemplateFilters) ([]*entity.TemplateListItem, error)
+
+	// ExistsInPublicLibrary checks if a template is listed in the public library of the workspace's tenant.
+	ExistsInPublicLibrary(ctx context.Context, workspaceID, templateID string) (bool, error)
+
+	// CreateClone creates a cloned template with its version, copied definitions, version
+	// injectables and tags in one transaction. It sets the template and version IDs.
+	CreateClone(ctx context.Context, clone *entity.TemplateClone) error
 
 	// Update updates a template.
 	Update(ctx context.Context, template *entity.Template) error
@@ -46,6 +46,10 @@ type TemplateVersionRepository interface {
 	// Update updates a template version.
 	Update(ctx context.Context, version *entity.TemplateVersion) error
 
+	// Publish persists a published version, the previously published version it archives (nil on
+	// first publish) and the publish event in a single transaction.
+	Publish(ctx context.Context, version, archived *entity.TemplateVersion, event *entity.OutboxEvent) error
+
 	// UpdateStatus updates a version's status with optional user tracking.
 	UpdateStatus(ctx context.Context, id string, status entity.VersionStatus, userID *string) error
 
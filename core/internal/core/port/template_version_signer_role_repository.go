@@ -40,4 +40,7 @@ type TemplateVersionSignerRoleRepository interface {
 
 	// CopyFromVersion copies all signer roles from one version to another.
 	CopyFromVersion(ctx context.Context, sourceVersionID, targetVersionID string) error
+
+	// FindRoleNamesByWorkspace lists the distinct role names used by any template version in a workspace.
+	FindRoleNamesByWorkspace(ctx context.Context, workspaceID string) ([]string, error)
 }
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// WorkspaceAssetRepository defines the output port for workspace brand asset persistence.
+type WorkspaceAssetRepository interface {
+	// Save persists a new workspace asset and sets its ID.
+	Save(ctx context.Context, asset *entity.WorkspaceAsset) error
+
+	// ReplaceLogo atomically replaces the workspace's logo record with asset and sets its ID.
+	ReplaceLogo(ctx context.Context, asset *entity.WorkspaceAsset) error
+
+	// FindByID finds an asset by ID within a workspace.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.WorkspaceAsset, error)
+
+	// List returns the workspace's assets, logo first, then fonts by filename.
+	List(ctx context.Context, workspaceID string) ([]*entity.WorkspaceAsset, error)
+
+	// Delete removes an asset record.
+	Delete(ctx context.Context, workspaceID, id string) error
+}
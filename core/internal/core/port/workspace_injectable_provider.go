@@ -42,7 +42,8 @@ type GetInjectablesResult struct {
 // ProviderInjectable represents an injectable definition from the provider.
 type ProviderInjectable struct {
 	// Code is the unique identifier for this injectable.
-	// REQUIRED. Must not collide with registry-defined injector codes.
+	// REQUIRED. Must not collide with registry-defined injector codes; prefix it with a
+	// namespace (e.g. "crm.first_name") to keep it apart from other sources' codes.
 	Code string `json:"code"`
 
 	// Label is the display name shown in the editor.
@@ -128,4 +129,9 @@ type ResolveInjectablesResult struct {
 
 	// Errors maps injectable codes to error messages for non-critical failures.
 	Errors map[string]string
+
+	// GlobalCodes lists codes whose values depend only on the workspace (not on the
+	// payload, headers or template). These may be reused across all documents of a
+	// batch. Optional; codes not listed are resolved again for every document.
+	GlobalCodes []string
 }
@@ -77,6 +77,7 @@ type generationContext struct {
 	version         *entity.TemplateVersionWithDetails
 	portableDoc     *portable_doc.Document
 	referencedCodes []string
+	computed        map[string]string // computed injectable code -> expression
 	payload         any
 	resolvedValues  map[string]any
 	resolveErrors   map[string]error
@@ -209,6 +210,7 @@ func (g *DocumentGenerator) fetchTemplateData(ctx context.Context, mapCtx *port.
 	versionCodes := collectVersionInjectableCodes(genCtx.version.Injectables)
 	roleCodes := collectSignerRoleInjectableCodes(genCtx.portableDoc.SignerRoles)
 	genCtx.referencedCodes = mergeUniqueCodes(versionCodes, roleCodes)
+	genCtx.computed = injectable_svc.CollectComputedExpressions(genCtx.injectables, genCtx.referencedCodes)
 	slog.InfoContext(ctx, "collected referenced injectables",
 		"template_id", genCtx.version.TemplateID,
 		"version_id", genCtx.version.ID,
@@ -264,6 +266,7 @@ func (g *DocumentGenerator) resolveAndBuildRecipients(
 		mapCtx,
 		genCtx.payload,
 		genCtx.referencedCodes,
+		genCtx.computed,
 	)
 	if err != nil {
 		return err
@@ -486,6 +489,7 @@ func (g *DocumentGenerator) resolveInjectables(
 	mapCtx *port.MapperContext,
 	payload any,
 	referencedCodes []string,
+	computed map[string]string,
 ) (map[string]any, map[string]error, error) {
 	var injCtx *entity.InjectorContext
 	if mapCtx.TenantCode != "" || mapCtx.WorkspaceCode != "" {
@@ -519,10 +523,11 @@ func (g *DocumentGenerator) resolveInjectables(
 	)
 	slog.DebugContext(ctx, "resolver requested codes", "referenced_codes", referencedCodes)
 
-	resolveResult, err := g.resolver.Resolve(ctx, injCtx, referencedCodes)
+	resolveResult, err := g.resolver.Resolve(ctx, injCtx, resolvableCodes(referencedCodes, computed))
 	if err != nil {
 		return nil, nil, fmt.Errorf("resolving injectors: %w", err)
 	}
+	g.resolver.ResolveComputed(ctx, computed, resolveResult)
 
 	slog.InfoContext(ctx, "injectables resolved for generation",
 		"resolved_values_count", len(resolveResult.Values),
@@ -547,6 +552,20 @@ func (g *DocumentGenerator) resolveInjectables(
 	return resolvedValues, resolveErrors, nil
 }
 
+// resolvableCodes replaces computed codes with the injectables their expressions depend on.
+func resolvableCodes(referencedCodes []string, computed map[string]string) []string {
+	if len(computed) == 0 {
+		return referencedCodes
+	}
+	codes := make([]string, 0, len(referencedCodes))
+	for _, code := range referencedCodes {
+		if _, isComputed := computed[code]; !isComputed {
+			codes = append(codes, code)
+		}
+	}
+	return mergeUniqueCodes(codes, injectable_svc.ComputedDependencies(computed))
+}
+
 // buildRecipientsFromSignerRoles builds and validates DocumentRecipient entities from portable_doc SignerRoles.
 func (g *DocumentGenerator) buildRecipientsFromSignerRoles(
 	ctx context.Context,
@@ -15,6 +15,7 @@ import (
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
+	injectable_svc "github.com/rendis/doc-assembly/core/internal/core/service/injectable"
 	documentuc "github.com/rendis/doc-assembly/core/internal/core/usecase/document"
 )
 
@@ -734,6 +735,9 @@ func (s *DocumentService) expireSingleDocument(ctx context.Context, doc *entity.
 func (s *DocumentService) CreateDocumentsBatch(ctx context.Context, cmds []documentuc.CreateDocumentCommand) ([]documentuc.BatchDocumentResult, error) {
 	results := make([]documentuc.BatchDocumentResult, len(cmds))
 
+	// Workspace-global provider values are resolved once for the whole batch.
+	ctx = injectable_svc.WithProviderCache(ctx)
+
 	for i, cmd := range cmds {
 		doc, err := s.CreateAndSendDocument(ctx, cmd)
 		results[i] = documentuc.BatchDocumentResult{
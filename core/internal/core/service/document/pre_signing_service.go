@@ -335,6 +335,7 @@ func (s *PreSigningService) RenderPreviewPDF(ctx context.Context, token string)
 	fieldResponses := loadFieldResponseMap(ctx, s.fieldResponseRepo, doc.ID)
 
 	renderResult, err := s.pdfRenderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+		VersionID:        doc.TemplateVersionID,
 		Document:         portableDoc,
 		Injectables:      injectables,
 		SignerRoleValues: signerRoleValues,
@@ -905,6 +906,17 @@ func resolvePreviewInjector(
 		}
 	}
 
+	fallbackIDs, _ := attrs["fallbackVariableIds"].([]any)
+	for _, raw := range fallbackIDs {
+		if value != "" {
+			break
+		}
+		fallbackID, _ := raw.(string)
+		if v, ok := injectables[fallbackID]; ok {
+			value = formatPreviewInjectableValue(v, injectorType, format)
+		}
+	}
+
 	if value == "" {
 		value = defaultValue
 	}
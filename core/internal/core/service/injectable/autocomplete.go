@@ -0,0 +1,116 @@
+package injectable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	injectableuc "github.com/rendis/doc-assembly/core/internal/core/usecase/injectable"
+)
+
+// Autocomplete lists the workspace's injectable keys and signer role names matching a prefix.
+// Injectables come from the same merged catalog as ListInjectables (DB, system and provider);
+// roles are the distinct names used across the workspace's template versions. The limit is
+// shared between both kinds so neither crowds the other out.
+func (s *InjectableService) Autocomplete(
+	ctx context.Context, req *injectableuc.AutocompleteRequest,
+) ([]*injectableuc.AutocompleteSuggestion, error) {
+	listed, err := s.ListInjectables(ctx, &injectableuc.ListInjectablesRequest{
+		WorkspaceID: req.WorkspaceID,
+		Environment: req.Environment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.ToLower(strings.TrimSpace(req.Prefix))
+
+	injectables := make([]*injectableuc.AutocompleteSuggestion, 0, len(listed.Injectables))
+	for _, inj := range listed.Injectables {
+		labels := inj.Labels
+		if labels == nil {
+			labels = map[string]string{"_": inj.Label}
+		}
+		if !matchesPrefix(prefix, inj.Key, labels) {
+			continue
+		}
+		injectables = append(injectables, &injectableuc.AutocompleteSuggestion{
+			Kind:     injectableuc.AutocompleteKindInjectable,
+			Key:      inj.Key,
+			Labels:   labels,
+			DataType: inj.DataType,
+		})
+	}
+	sortSuggestions(injectables)
+
+	roles, err := s.roleSuggestions(ctx, req.WorkspaceID, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return limitSuggestions(injectables, roles, req.Limit), nil
+}
+
+// limitSuggestions joins injectables and roles, capped at limit (<= 0 means no limit).
+// Roles get up to half of the slots and injectables the rest; slots one kind leaves
+// unused go to the other.
+func limitSuggestions(
+	injectables, roles []*injectableuc.AutocompleteSuggestion, limit int,
+) []*injectableuc.AutocompleteSuggestion {
+	if limit <= 0 {
+		return append(injectables, roles...)
+	}
+
+	roleSlots := min(len(roles), limit/2)
+	injectableSlots := min(len(injectables), limit-roleSlots)
+	roleSlots = min(len(roles), limit-injectableSlots)
+	return append(injectables[:injectableSlots:injectableSlots], roles[:roleSlots]...)
+}
+
+// roleSuggestions returns the workspace's signer role names matching the lowercased prefix.
+func (s *InjectableService) roleSuggestions(
+	ctx context.Context, workspaceID, prefix string,
+) ([]*injectableuc.AutocompleteSuggestion, error) {
+	if s.signerRoleRepo == nil {
+		return nil, nil
+	}
+
+	names, err := s.signerRoleRepo.FindRoleNamesByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing signer roles: %w", err)
+	}
+
+	roles := make([]*injectableuc.AutocompleteSuggestion, 0, len(names))
+	for _, name := range names {
+		if !strings.HasPrefix(strings.ToLower(name), prefix) {
+			continue
+		}
+		roles = append(roles, &injectableuc.AutocompleteSuggestion{
+			Kind:   injectableuc.AutocompleteKindRole,
+			Key:    name,
+			Labels: map[string]string{"_": name},
+		})
+	}
+	sortSuggestions(roles)
+	return roles, nil
+}
+
+// matchesPrefix reports whether the key or any label starts with the lowercased prefix.
+func matchesPrefix(prefix, key string, labels map[string]string) bool {
+	if prefix == "" || strings.HasPrefix(strings.ToLower(key), prefix) {
+		return true
+	}
+	for _, label := range labels {
+		if strings.HasPrefix(strings.ToLower(label), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortSuggestions(suggestions []*injectableuc.AutocompleteSuggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Key < suggestions[j].Key
+	})
+}
@@ -0,0 +1,50 @@
+package injectable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	injectableuc "github.com/rendis/doc-assembly/core/internal/core/usecase/injectable"
+)
+
+func suggestions(kind injectableuc.AutocompleteKind, keys ...string) []*injectableuc.AutocompleteSuggestion {
+	out := make([]*injectableuc.AutocompleteSuggestion, len(keys))
+	for i, key := range keys {
+		out[i] = &injectableuc.AutocompleteSuggestion{Kind: kind, Key: key}
+	}
+	return out
+}
+
+func keys(items []*injectableuc.AutocompleteSuggestion) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Key
+	}
+	return out
+}
+
+func TestLimitSuggestions_SharesLimitBetweenKinds(t *testing.T) {
+	injectables := suggestions(injectableuc.AutocompleteKindInjectable, "a", "b", "c", "d", "e")
+	roles := suggestions(injectableuc.AutocompleteKindRole, "Client", "Witness")
+
+	tests := []struct {
+		name        string
+		injectables []*injectableuc.AutocompleteSuggestion
+		roles       []*injectableuc.AutocompleteSuggestion
+		limit       int
+		want        []string
+	}{
+		{"injectables exceed the limit", injectables, roles, 4, []string{"a", "b", "Client", "Witness"}},
+		{"odd limit favours injectables", injectables, roles, 3, []string{"a", "b", "Client"}},
+		{"single slot", injectables, roles, 1, []string{"a"}},
+		{"unused role slots go to injectables", injectables, roles[:1], 4, []string{"a", "b", "c", "Client"}},
+		{"unused injectable slots go to roles", injectables[:1], roles, 4, []string{"a", "Client", "Witness"}},
+		{"no limit", suggestions(injectableuc.AutocompleteKindInjectable, "a", "b"), roles, 0, []string{"a", "b", "Client", "Witness"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keys(limitSuggestions(tt.injectables, tt.roles, tt.limit)))
+		})
+	}
+}
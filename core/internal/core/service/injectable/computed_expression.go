@@ -0,0 +1,268 @@
+package injectable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// maxExpressionLength bounds the size of a computed injectable expression.
+const maxExpressionLength = 512
+
+var (
+	// ErrDivisionByZero is returned when a computed expression divides by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+
+	// ErrComputedCycle is returned when computed injectables reference each other in a loop.
+	ErrComputedCycle = errors.New("computed injectable cycle")
+)
+
+// computedExpr is a parsed arithmetic expression over injectable keys.
+// Only numbers, identifiers, parentheses and + - * / are supported; there is
+// no function call or member access, so evaluation cannot run arbitrary code.
+// Dots inside an identifier are part of a namespaced key such as crm.total.
+type computedExpr interface {
+	eval(lookup func(key string) (float64, error)) (float64, error)
+	collectRefs(refs []string) []string
+}
+
+type numberExpr float64
+
+func (n numberExpr) eval(func(string) (float64, error)) (float64, error) { return float64(n), nil }
+
+func (n numberExpr) collectRefs(refs []string) []string { return refs }
+
+type refExpr string
+
+func (r refExpr) eval(lookup func(string) (float64, error)) (float64, error) {
+	return lookup(string(r))
+}
+
+func (r refExpr) collectRefs(refs []string) []string { return append(refs, string(r)) }
+
+type negateExpr struct{ operand computedExpr }
+
+func (u negateExpr) eval(lookup func(string) (float64, error)) (float64, error) {
+	v, err := u.operand.eval(lookup)
+	return -v, err
+}
+
+func (u negateExpr) collectRefs(refs []string) []string { return u.operand.collectRefs(refs) }
+
+type binaryExpr struct {
+	op          byte
+	left, right computedExpr
+}
+
+func (b binaryExpr) eval(lookup func(string) (float64, error)) (float64, error) {
+	l, err := b.left.eval(lookup)
+	if err != nil {
+		return 0, err
+	}
+	r, err := b.right.eval(lookup)
+	if err != nil {
+		return 0, err
+	}
+
+	switch b.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	default:
+		if r == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return l / r, nil
+	}
+}
+
+func (b binaryExpr) collectRefs(refs []string) []string {
+	return b.right.collectRefs(b.left.collectRefs(refs))
+}
+
+// parseComputedExpression parses an arithmetic expression such as
+// "quantity * unit_price - discount". Identifiers are injectable keys.
+func parseComputedExpression(src string) (computedExpr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, fmt.Errorf("%w: empty expression", entity.ErrInvalidComputedExpression)
+	}
+	if len(src) > maxExpressionLength {
+		return nil, fmt.Errorf("%w: expression exceeds %d characters", entity.ErrInvalidComputedExpression, maxExpressionLength)
+	}
+
+	p := &exprParser{src: src}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return expr, nil
+}
+
+// exprParser is a recursive-descent parser for:
+//
+//	sum     := product (('+' | '-') product)*
+//	product := unary (('*' | '/') unary)*
+//	unary   := '-' unary | primary
+//	primary := number | identifier | '(' sum ')'
+type exprParser struct {
+	src   string
+	pos   int
+	depth int
+}
+
+// maxExpressionDepth bounds nesting so hostile input cannot exhaust the stack.
+const maxExpressionDepth = 32
+
+func (p *exprParser) parseSum() (computedExpr, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.consumeOperator("+-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseProduct() (computedExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.consumeOperator("*/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (computedExpr, error) {
+	if _, ok := p.consumeOperator("-"); ok {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negateExpr{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (computedExpr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.consumeOperator(")"); !ok {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	case isDigit(c) || c == '.':
+		return p.parseNumber()
+	case isIdentStart(c):
+		start := p.pos
+		for p.pos < len(p.src) {
+			if isIdentPart(p.src[p.pos]) {
+				p.pos++
+				continue
+			}
+			// A dot continues the key only when another segment follows it.
+			if p.src[p.pos] == '.' && p.pos+1 < len(p.src) && isIdentStart(p.src[p.pos+1]) {
+				p.pos++
+				continue
+			}
+			break
+		}
+		return refExpr(p.src[start:p.pos]), nil
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *exprParser) parseNumber() (computedExpr, error) {
+	start := p.pos
+	for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", p.src[start:p.pos])
+	}
+	return numberExpr(n), nil
+}
+
+// consumeOperator advances past the next non-space character if it is one of ops.
+func (p *exprParser) consumeOperator(ops string) (byte, bool) {
+	p.skipSpaces()
+	if p.pos < len(p.src) && strings.IndexByte(ops, p.src[p.pos]) >= 0 {
+		op := p.src[p.pos]
+		p.pos++
+		return op, true
+	}
+	return 0, false
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *exprParser) enter() error {
+	p.depth++
+	if p.depth > maxExpressionDepth {
+		return p.errorf("expression nested too deeply")
+	}
+	return nil
+}
+
+func (p *exprParser) leave() { p.depth-- }
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w at position %d: %s", entity.ErrInvalidComputedExpression, p.pos, fmt.Sprintf(format, args...))
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentStart(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func isIdentPart(c byte) bool { return isIdentStart(c) || isDigit(c) }
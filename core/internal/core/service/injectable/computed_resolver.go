@@ -0,0 +1,233 @@
+package injectable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// CollectComputedExpressions returns the expressions of the computed definitions
+// among the referenced codes (code -> expression), following references from one
+// computed injectable to another.
+func CollectComputedExpressions(defs []*entity.InjectableDefinition, referencedCodes []string) map[string]string {
+	byKey := make(map[string]string)
+	for _, def := range defs {
+		if expr, ok := def.ComputedExpression(); ok {
+			byKey[def.Key] = expr
+		}
+	}
+
+	computed := make(map[string]string)
+	pending := append([]string(nil), referencedCodes...)
+	for len(pending) > 0 {
+		code := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		expr, ok := byKey[code]
+		if !ok {
+			continue
+		}
+		if _, seen := computed[code]; seen {
+			continue
+		}
+		computed[code] = expr
+
+		if parsed, err := parseComputedExpression(expr); err == nil {
+			pending = append(pending, parsed.collectRefs(nil)...)
+		}
+	}
+	return computed
+}
+
+// ComputedDependencies returns the non-computed keys referenced by the computed
+// expressions, sorted. These must be resolved before ResolveComputed is called.
+func ComputedDependencies(computed map[string]string) []string {
+	seen := make(map[string]bool)
+	for _, expr := range computed {
+		parsed, err := parseComputedExpression(expr)
+		if err != nil {
+			continue
+		}
+		for _, ref := range parsed.collectRefs(nil) {
+			if _, isComputed := computed[ref]; !isComputed {
+				seen[ref] = true
+			}
+		}
+	}
+
+	deps := make([]string, 0, len(seen))
+	for ref := range seen {
+		deps = append(deps, ref)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// ResolveComputed evaluates computed injectables over the values already in result.
+// Computed injectables may reference each other; they are evaluated on demand in
+// dependency order. Failures (parse errors, cycles, division by zero, missing or
+// non-numeric operands) are recorded as non-critical errors and leave the value
+// empty, so the document still renders.
+func (s *InjectableResolverService) ResolveComputed(
+	ctx context.Context,
+	computed map[string]string,
+	result *ResolveResult,
+) {
+	resolveComputed(ctx, computed, result)
+}
+
+// EvaluateComputed evaluates computed injectables over raw input values, for previews
+// that render without running the resolver pipeline. Numbers and numeric strings are
+// accepted as operands; codes that fail to evaluate are left out of the result.
+func EvaluateComputed(ctx context.Context, computed map[string]string, inputs map[string]any) map[string]float64 {
+	result := &ResolveResult{
+		Values: make(map[string]entity.InjectableValue, len(inputs)),
+		Errors: make(map[string]error),
+	}
+	for key, raw := range inputs {
+		if val, ok := computedInput(raw); ok {
+			result.Values[key] = val
+		}
+	}
+
+	resolveComputed(ctx, computed, result)
+
+	values := make(map[string]float64, len(computed))
+	for code := range computed {
+		if n, ok := result.Values[code].Number(); ok {
+			values[code] = n
+		}
+	}
+	return values
+}
+
+// computedInput converts a raw preview value into an operand value.
+func computedInput(raw any) (entity.InjectableValue, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return entity.NumberValue(v), true
+	case int:
+		return entity.NumberValue(float64(v)), true
+	case int64:
+		return entity.NumberValue(float64(v)), true
+	case json.Number:
+		n, err := v.Float64()
+		return entity.NumberValue(n), err == nil
+	case string:
+		return entity.StringValue(v), true
+	default:
+		return entity.InjectableValue{}, false
+	}
+}
+
+func resolveComputed(ctx context.Context, computed map[string]string, result *ResolveResult) {
+	if len(computed) == 0 {
+		return
+	}
+
+	ev := &computedEvaluator{
+		computed: computed,
+		result:   result,
+		state:    make(map[string]computedState, len(computed)),
+		values:   make(map[string]float64, len(computed)),
+		errs:     make(map[string]error),
+	}
+
+	codes := make([]string, 0, len(computed))
+	for code := range computed {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if _, err := ev.evaluate(code, nil); err != nil {
+			ev.errs[code] = err
+		}
+	}
+
+	for _, code := range codes {
+		if err, failed := ev.errs[code]; failed {
+			slog.WarnContext(ctx, "computed injectable yielded no value", "code", code, "error", err)
+			result.Errors[code] = err
+			continue
+		}
+		result.Values[code] = entity.NumberValue(ev.values[code])
+	}
+}
+
+type computedState int
+
+const (
+	computedPending computedState = iota
+	computedVisiting
+	computedDone
+)
+
+// computedEvaluator evaluates computed injectables with memoization and cycle detection.
+type computedEvaluator struct {
+	computed map[string]string
+	result   *ResolveResult
+	state    map[string]computedState
+	values   map[string]float64
+	errs     map[string]error
+}
+
+func (ev *computedEvaluator) evaluate(code string, path []string) (float64, error) {
+	switch ev.state[code] {
+	case computedDone:
+		if err, failed := ev.errs[code]; failed {
+			return 0, err
+		}
+		return ev.values[code], nil
+	case computedVisiting:
+		return 0, fmt.Errorf("%w: %s", ErrComputedCycle, strings.Join(append(path, code), " -> "))
+	}
+
+	ev.state[code] = computedVisiting
+	value, err := ev.evaluateExpression(code, append(path, code))
+	ev.state[code] = computedDone
+	if err != nil {
+		ev.errs[code] = err
+		return 0, err
+	}
+	ev.values[code] = value
+	return value, nil
+}
+
+func (ev *computedEvaluator) evaluateExpression(code string, path []string) (float64, error) {
+	parsed, err := parseComputedExpression(ev.computed[code])
+	if err != nil {
+		return 0, err
+	}
+
+	return parsed.eval(func(ref string) (float64, error) {
+		if _, isComputed := ev.computed[ref]; isComputed {
+			return ev.evaluate(ref, path)
+		}
+		val, ok := ev.result.Values[ref]
+		if !ok {
+			return 0, fmt.Errorf("operand %q has no value", ref)
+		}
+		return numericOperand(ref, val)
+	})
+}
+
+// numericOperand converts a resolved value into a number. Numeric strings are accepted
+// because workspace and provider injectables commonly carry amounts as text.
+func numericOperand(ref string, val entity.InjectableValue) (float64, error) {
+	if n, ok := val.Number(); ok {
+		return n, nil
+	}
+	if s, ok := val.String(); ok {
+		if n, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("operand %q is not numeric", ref)
+}
@@ -0,0 +1,215 @@
+package injectable
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+func newComputedResult(values map[string]entity.InjectableValue) *ResolveResult {
+	return &ResolveResult{
+		Values:   values,
+		Errors:   make(map[string]error),
+		Metadata: make(map[string]map[string]any),
+	}
+}
+
+func computedDefinition(key, expr string) *entity.InjectableDefinition {
+	return &entity.InjectableDefinition{
+		Key:      key,
+		DataType: entity.InjectableDataTypeText,
+		Metadata: map[string]any{entity.InjectableMetadataExpression: expr},
+	}
+}
+
+func TestParseComputedExpression_Arithmetic(t *testing.T) {
+	vars := map[string]float64{"quantity": 3, "unit_price": 12.5, "discount": 5, "crm.order.total": 100}
+	lookup := func(key string) (float64, error) { return vars[key], nil }
+
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"quantity * unit_price", 37.5},
+		{"quantity * unit_price - discount", 32.5},
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"-discount + 10", 5},
+		{"10 / 4", 2.5},
+		{"  1.5+.5 ", 2},
+		{"quantity - -1", 4},
+		{"crm.order.total - discount", 95},
+		{"(crm.order.total)*0.1", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			parsed, err := parseComputedExpression(tt.expr)
+			require.NoError(t, err)
+
+			got, err := parsed.eval(lookup)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestParseComputedExpression_RejectsInvalidInput(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"quantity *",
+		"(1 + 2",
+		"1 + 2)",
+		"price.",
+		"price..amount",
+		"price.1",
+		"os.Exit(1)",
+		"1..2",
+		"a ^ b",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseComputedExpression(expr)
+			assert.ErrorIs(t, err, entity.ErrInvalidComputedExpression)
+		})
+	}
+}
+
+func TestResolveComputed_EvaluatesInDependencyOrder(t *testing.T) {
+	resolver := NewInjectableResolverService(&stubRegistry{})
+	result := newComputedResult(map[string]entity.InjectableValue{
+		"quantity":   entity.NumberValue(4),
+		"unit_price": entity.StringValue("2.50"),
+	})
+
+	resolver.ResolveComputed(context.Background(), map[string]string{
+		"total":         "subtotal + tax",
+		"subtotal":      "quantity * unit_price",
+		"tax":           "subtotal * 0.2",
+		"unused_helper": "1",
+	}, result)
+
+	require.Empty(t, result.Errors)
+	total, ok := result.Values["total"].Number()
+	require.True(t, ok)
+	assert.InDelta(t, 12.0, total, 1e-9)
+	subtotal, _ := result.Values["subtotal"].Number()
+	assert.InDelta(t, 10.0, subtotal, 1e-9)
+}
+
+func TestResolveComputed_ReferencesNamespacedKeys(t *testing.T) {
+	resolver := NewInjectableResolverService(&stubRegistry{})
+	result := newComputedResult(map[string]entity.InjectableValue{
+		"crm.order.total": entity.NumberValue(200),
+		"discount":        entity.NumberValue(20),
+	})
+
+	resolver.ResolveComputed(context.Background(), map[string]string{
+		"billing.net":   "crm.order.total - discount",
+		"billing.gross": "billing.net * 1.5",
+	}, result)
+
+	require.Empty(t, result.Errors)
+	gross, ok := result.Values["billing.gross"].Number()
+	require.True(t, ok)
+	assert.InDelta(t, 270.0, gross, 1e-9)
+}
+
+func TestResolveComputed_DetectsCycles(t *testing.T) {
+	resolver := NewInjectableResolverService(&stubRegistry{})
+	result := newComputedResult(map[string]entity.InjectableValue{
+		"base": entity.NumberValue(1),
+	})
+
+	resolver.ResolveComputed(context.Background(), map[string]string{
+		"a":    "b + 1",
+		"b":    "a + 1",
+		"safe": "base * 2",
+	}, result)
+
+	assert.ErrorIs(t, result.Errors["a"], ErrComputedCycle)
+	assert.ErrorIs(t, result.Errors["b"], ErrComputedCycle)
+	assert.NotContains(t, result.Values, "a")
+	assert.NotContains(t, result.Values, "b")
+
+	safe, ok := result.Values["safe"].Number()
+	require.True(t, ok, "computed injectables outside the cycle still resolve")
+	assert.InDelta(t, 2.0, safe, 1e-9)
+}
+
+func TestResolveComputed_DivisionByZeroYieldsEmpty(t *testing.T) {
+	resolver := NewInjectableResolverService(&stubRegistry{})
+	result := newComputedResult(map[string]entity.InjectableValue{
+		"total": entity.NumberValue(100),
+		"count": entity.NumberValue(0),
+	})
+
+	resolver.ResolveComputed(context.Background(), map[string]string{
+		"average": "total / count",
+		"ratio":   "average * 100",
+	}, result)
+
+	assert.NotContains(t, result.Values, "average")
+	assert.NotContains(t, result.Values, "ratio")
+	assert.ErrorIs(t, result.Errors["average"], ErrDivisionByZero)
+	assert.ErrorIs(t, result.Errors["ratio"], ErrDivisionByZero)
+}
+
+func TestResolveComputed_MissingOperandYieldsEmpty(t *testing.T) {
+	resolver := NewInjectableResolverService(&stubRegistry{})
+	result := newComputedResult(map[string]entity.InjectableValue{
+		"name": entity.StringValue("Acme"),
+	})
+
+	resolver.ResolveComputed(context.Background(), map[string]string{
+		"missing":     "absent * 2",
+		"non_numeric": "name + 1",
+	}, result)
+
+	assert.Empty(t, result.Values["missing"].AsAny())
+	assert.Error(t, result.Errors["missing"])
+	assert.Error(t, result.Errors["non_numeric"])
+}
+
+func TestCollectComputedExpressions_FollowsComputedReferences(t *testing.T) {
+	defs := []*entity.InjectableDefinition{
+		computedDefinition("total", "subtotal + 5"),
+		computedDefinition("subtotal", "quantity * unit_price"),
+		computedDefinition("unreferenced", "1 + 1"),
+		{Key: "quantity", DataType: entity.InjectableDataTypeText},
+	}
+
+	computed := CollectComputedExpressions(defs, []string{"client_name", "total"})
+
+	assert.Equal(t, map[string]string{
+		"total":    "subtotal + 5",
+		"subtotal": "quantity * unit_price",
+	}, computed)
+	assert.Equal(t, []string{"quantity", "unit_price"}, ComputedDependencies(computed))
+}
+
+func TestValidateComputedExpression(t *testing.T) {
+	assert.NoError(t, validateComputedExpression(computedDefinition("total", "a + b")))
+	assert.NoError(t, validateComputedExpression(&entity.InjectableDefinition{Key: "plain"}))
+	assert.ErrorIs(t, validateComputedExpression(computedDefinition("total", "total + 1")), entity.ErrInvalidComputedExpression)
+	assert.ErrorIs(t, validateComputedExpression(computedDefinition("total", "a +")), entity.ErrInvalidComputedExpression)
+}
+
+func TestEvaluateComputed_UsesRawInputs(t *testing.T) {
+	values := EvaluateComputed(context.Background(), map[string]string{
+		"subtotal": "quantity * unit_price",
+		"total":    "subtotal - discount",
+		"broken":   "name * 2",
+	}, map[string]any{
+		"quantity":   json.Number("4"),
+		"unit_price": 2.5,
+		"discount":   " 1 ",
+		"name":       "Acme",
+	})
+
+	assert.Equal(t, map[string]float64{"subtotal": 10, "total": 9}, values)
+}
@@ -204,6 +204,12 @@ func (s *InjectableResolverService) resolveProviderCodes(
 		return nil
 	}
 
+	cache := providerCacheFrom(ctx)
+	codes = applyCachedProviderValues(cache, injCtx, codes, result)
+	if len(codes) == 0 {
+		return nil
+	}
+
 	req := &port.ResolveInjectablesRequest{
 		TenantCode:      injCtx.TenantCode(),
 		WorkspaceCode:   injCtx.WorkspaceCode(),
@@ -246,6 +252,8 @@ func (s *InjectableResolverService) resolveProviderCodes(
 		}
 	}
 
+	storeGlobalProviderValues(cache, injCtx, providerResult)
+
 	// Merge provider errors as non-critical
 	for code, errMsg := range providerResult.Errors {
 		result.Errors[code] = fmt.Errorf("%s", errMsg)
@@ -279,6 +287,46 @@ func (s *InjectableResolverService) resolveProviderCodes(
 	return nil
 }
 
+// applyCachedProviderValues fills result with batch-cached values and returns the codes
+// that still need to be sent to the provider.
+func applyCachedProviderValues(
+	cache *ProviderCache,
+	injCtx *entity.InjectorContext,
+	codes []string,
+	result *ResolveResult,
+) []string {
+	if cache == nil {
+		return codes
+	}
+	remaining := make([]string, 0, len(codes))
+	for _, code := range codes {
+		val, ok := cache.get(newProviderCacheKey(injCtx, code))
+		if !ok {
+			remaining = append(remaining, code)
+			continue
+		}
+		result.Values[code] = val
+		injCtx.SetResolved(code, val.AsAny())
+	}
+	return remaining
+}
+
+// storeGlobalProviderValues caches the values the provider marked as workspace-global.
+func storeGlobalProviderValues(
+	cache *ProviderCache,
+	injCtx *entity.InjectorContext,
+	providerResult *port.ResolveInjectablesResult,
+) {
+	if cache == nil {
+		return
+	}
+	for _, code := range providerResult.GlobalCodes {
+		if val := providerResult.Values[code]; val != nil {
+			cache.set(newProviderCacheKey(injCtx, code), *val)
+		}
+	}
+}
+
 func findMissingRequestedProviderCodes(
 	requested []string,
 	result *port.ResolveInjectablesResult,
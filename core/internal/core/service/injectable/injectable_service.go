@@ -21,6 +21,7 @@ func NewInjectableService(
 	injectorRegistry port.InjectorRegistry,
 	workspaceRepo port.WorkspaceRepository,
 	tenantRepo port.TenantRepository,
+	signerRoleRepo port.TemplateVersionSignerRoleRepository,
 	workspaceProvider port.WorkspaceInjectableProvider, // can be nil
 ) injectableuc.InjectableUseCase {
 	return &InjectableService{
@@ -29,6 +30,7 @@ func NewInjectableService(
 		injectorRegistry:     injectorRegistry,
 		workspaceRepo:        workspaceRepo,
 		tenantRepo:           tenantRepo,
+		signerRoleRepo:       signerRoleRepo,
 		workspaceProvider:    workspaceProvider,
 	}
 }
@@ -41,6 +43,7 @@ type InjectableService struct {
 	injectorRegistry     port.InjectorRegistry
 	workspaceRepo        port.WorkspaceRepository
 	tenantRepo           port.TenantRepository
+	signerRoleRepo       port.TemplateVersionSignerRoleRepository
 	workspaceProvider    port.WorkspaceInjectableProvider // can be nil
 }
 
@@ -263,7 +266,12 @@ func (s *InjectableService) mergeInjectables(db, ext []*entity.InjectableDefinit
 
 	// Start with DB injectables
 	result := make([]*entity.InjectableDefinition, 0, len(db)+len(ext))
-	result = append(result, db...)
+	for _, inj := range db {
+		if inj.IsComputed() {
+			inj.SourceType = entity.InjectableSourceTypeComputed
+		}
+		result = append(result, inj)
+	}
 
 	// Add extension injectables that don't conflict with DB keys
 	for _, inj := range ext {
@@ -333,6 +341,63 @@ func (s *InjectableService) fetchProviderResult(
 	return result, nil
 }
 
+// NewDefaultResolver builds a render-time fallback backed by the workspace provider.
+// Registry-known codes are never sent to the provider; provider failures are logged
+// and treated as a miss so the render can continue.
+func (s *InjectableService) NewDefaultResolver(
+	ctx context.Context,
+	req *injectableuc.DefaultResolverRequest,
+) (port.InjectableDefaultResolver, error) {
+	if s.workspaceProvider == nil {
+		return nil, nil
+	}
+
+	tenantCode, workspaceCode, err := s.getWorkspaceCodes(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("getting workspace codes: %w", err)
+	}
+	env := req.Environment
+	if env == "" {
+		env = entity.EnvironmentProd
+	}
+
+	injCtx := entity.NewInjectorContextWithCodes("", req.TemplateID, "", "render", tenantCode, workspaceCode, env, nil, nil)
+
+	return func(ctx context.Context, code string) (any, bool) {
+		if _, ok := s.injectorRegistry.Get(code); ok {
+			return nil, false
+		}
+
+		cache := providerCacheFrom(ctx)
+		if cache != nil {
+			if val, ok := cache.get(newProviderCacheKey(injCtx, code)); ok {
+				return val.AsAny(), true
+			}
+		}
+
+		result, err := s.workspaceProvider.ResolveInjectables(ctx, &port.ResolveInjectablesRequest{
+			TenantCode:    tenantCode,
+			WorkspaceCode: workspaceCode,
+			TemplateID:    req.TemplateID,
+			Codes:         []string{code},
+			Environment:   env,
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "provider default resolution failed",
+				"code", code,
+				"workspace_code", workspaceCode,
+				"error", err,
+			)
+			return nil, false
+		}
+		if result == nil || result.Values[code] == nil {
+			return nil, false
+		}
+		storeGlobalProviderValues(cache, injCtx, result)
+		return result.Values[code].AsAny(), true
+	}, nil
+}
+
 // getWorkspaceCodes retrieves tenant code and workspace code from workspace ID.
 func (s *InjectableService) getWorkspaceCodes(
 	ctx context.Context, workspaceID string,
@@ -0,0 +1,28 @@
+package injectable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+func definitions(keys ...string) []*entity.InjectableDefinition {
+	defs := make([]*entity.InjectableDefinition, len(keys))
+	for i, key := range keys {
+		defs[i] = &entity.InjectableDefinition{Key: key}
+	}
+	return defs
+}
+
+func TestValidateNoDuplicateCodes_NamespacesAvoidCollisions(t *testing.T) {
+	db := definitions("first_name")
+	system := definitions("current_date")
+
+	err := validateNoDuplicateCodes(db, system, definitions("first_name"))
+	assert.Error(t, err, "a flat provider key colliding with a workspace key is rejected")
+
+	err = validateNoDuplicateCodes(db, system, definitions("crm.first_name", "hr.first_name"))
+	assert.NoError(t, err, "namespaced provider keys don't collide with the flat key or each other")
+}
@@ -0,0 +1,65 @@
+package injectable
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// providerCacheKey identifies a workspace-global provider value.
+type providerCacheKey struct {
+	tenantCode    string
+	workspaceCode string
+	environment   entity.Environment
+	code          string
+	format        string
+}
+
+// ProviderCache memoizes workspace-global provider values for the lifetime of a batch.
+// Only codes the provider reports in ResolveInjectablesResult.GlobalCodes are stored,
+// so values that vary per input are always resolved again.
+type ProviderCache struct {
+	mu     sync.RWMutex
+	values map[providerCacheKey]entity.InjectableValue
+}
+
+type providerCacheCtxKey struct{}
+
+// WithProviderCache returns a context carrying a fresh provider cache.
+// Every provider lookup made with the returned context shares the cache; it is
+// discarded with the context, which marks the batch boundary.
+func WithProviderCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, providerCacheCtxKey{}, &ProviderCache{
+		values: make(map[providerCacheKey]entity.InjectableValue),
+	})
+}
+
+// providerCacheFrom returns the batch cache attached to ctx, or nil.
+func providerCacheFrom(ctx context.Context) *ProviderCache {
+	cache, _ := ctx.Value(providerCacheCtxKey{}).(*ProviderCache)
+	return cache
+}
+
+func newProviderCacheKey(injCtx *entity.InjectorContext, code string) providerCacheKey {
+	return providerCacheKey{
+		tenantCode:    injCtx.TenantCode(),
+		workspaceCode: injCtx.WorkspaceCode(),
+		environment:   injCtx.Environment(),
+		code:          code,
+		format:        injCtx.GetSelectedFormats()[code],
+	}
+}
+
+func (c *ProviderCache) get(key providerCacheKey) (entity.InjectableValue, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.values[key]
+	return v, ok
+}
+
+func (c *ProviderCache) set(key providerCacheKey, value entity.InjectableValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+}
@@ -0,0 +1,167 @@
+package injectable
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// stubRegistry is an InjectorRegistry with no registered injectors that
+// delegates every code to the configured workspace provider.
+type stubRegistry struct {
+	port.InjectorRegistry
+	provider port.WorkspaceInjectableProvider
+}
+
+func (r *stubRegistry) Get(string) (port.Injector, bool) { return nil, false }
+
+func (r *stubRegistry) GetInitFunc() port.InitFunc { return nil }
+
+func (r *stubRegistry) GetWorkspaceInjectableProvider() port.WorkspaceInjectableProvider {
+	return r.provider
+}
+
+// countingProvider returns a workspace-global company name and a per-input
+// client name taken from the payload, counting how often each code is requested.
+type countingProvider struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (p *countingProvider) GetInjectables(context.Context, *entity.InjectorContext) (*port.GetInjectablesResult, error) {
+	return &port.GetInjectablesResult{}, nil
+}
+
+func (p *countingProvider) ResolveInjectables(_ context.Context, req *port.ResolveInjectablesRequest) (*port.ResolveInjectablesResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.calls == nil {
+		p.calls = map[string]int{}
+	}
+
+	result := &port.ResolveInjectablesResult{Values: map[string]*entity.InjectableValue{}}
+	for _, code := range req.Codes {
+		p.calls[code]++
+		switch code {
+		case "company_name":
+			v := entity.StringValue("Acme Corp (" + req.WorkspaceCode + ")")
+			result.Values[code] = &v
+			result.GlobalCodes = append(result.GlobalCodes, code)
+		case "client_name":
+			v := entity.StringValue(fmt.Sprintf("%v", req.Payload))
+			result.Values[code] = &v
+		}
+	}
+	return result, nil
+}
+
+func (p *countingProvider) callsFor(code string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls[code]
+}
+
+func newCachingTestResolver() (*InjectableResolverService, *countingProvider) {
+	provider := &countingProvider{}
+	return NewInjectableResolverService(&stubRegistry{provider: provider}), provider
+}
+
+func newWorkspaceInjCtx(workspaceCode string, payload any) *entity.InjectorContext {
+	return entity.NewInjectorContextWithCodes("", "tpl-1", "", "create", "TENANT", workspaceCode, entity.EnvironmentProd, nil, payload)
+}
+
+func TestResolve_ProviderCache_GlobalCodeResolvedOncePerBatch(t *testing.T) {
+	resolver, provider := newCachingTestResolver()
+	ctx := WithProviderCache(context.Background())
+
+	const docs = 5
+	for i := 0; i < docs; i++ {
+		result, err := resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", "same-input"), []string{"company_name"})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Corp (WS1)", result.Values["company_name"].AsAny())
+	}
+
+	assert.Equal(t, 1, provider.callsFor("company_name"))
+}
+
+func TestResolve_ProviderCache_PerInputValuesNotCached(t *testing.T) {
+	resolver, provider := newCachingTestResolver()
+	ctx := WithProviderCache(context.Background())
+
+	for _, client := range []string{"Alice", "Bob", "Alice"} {
+		result, err := resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", client), []string{"company_name", "client_name"})
+		require.NoError(t, err)
+		assert.Equal(t, client, result.Values["client_name"].AsAny())
+	}
+
+	assert.Equal(t, 1, provider.callsFor("company_name"))
+	assert.Equal(t, 3, provider.callsFor("client_name"))
+}
+
+func TestResolve_ProviderCache_ScopedPerWorkspace(t *testing.T) {
+	resolver, provider := newCachingTestResolver()
+	ctx := WithProviderCache(context.Background())
+
+	first, err := resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", nil), []string{"company_name"})
+	require.NoError(t, err)
+	second, err := resolver.Resolve(ctx, newWorkspaceInjCtx("WS2", nil), []string{"company_name"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Acme Corp (WS1)", first.Values["company_name"].AsAny())
+	assert.Equal(t, "Acme Corp (WS2)", second.Values["company_name"].AsAny())
+	assert.Equal(t, 2, provider.callsFor("company_name"))
+}
+
+func TestResolve_ProviderCache_InvalidatedAtBatchBoundary(t *testing.T) {
+	resolver, provider := newCachingTestResolver()
+
+	for batch := 0; batch < 2; batch++ {
+		ctx := WithProviderCache(context.Background())
+		for i := 0; i < 3; i++ {
+			_, err := resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", nil), []string{"company_name"})
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, 2, provider.callsFor("company_name"))
+}
+
+func TestResolve_WithoutProviderCache_ResolvesEveryTime(t *testing.T) {
+	resolver, provider := newCachingTestResolver()
+
+	for i := 0; i < 3; i++ {
+		_, err := resolver.Resolve(context.Background(), newWorkspaceInjCtx("WS1", nil), []string{"company_name"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, provider.callsFor("company_name"))
+}
+
+func BenchmarkResolve_ProviderCache(b *testing.B) {
+	codes := []string{"company_name", "client_name"}
+
+	b.Run("uncached", func(b *testing.B) {
+		resolver, _ := newCachingTestResolver()
+		ctx := context.Background()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", i), codes)
+		}
+	})
+
+	b.Run("batch_cached", func(b *testing.B) {
+		resolver, _ := newCachingTestResolver()
+		ctx := WithProviderCache(context.Background())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = resolver.Resolve(ctx, newWorkspaceInjCtx("WS1", i), codes)
+		}
+	})
+}
@@ -57,6 +57,9 @@ func (s *WorkspaceInjectableService) CreateInjectable(ctx context.Context, cmd i
 	if err := injectable.ValidateForWorkspace(); err != nil {
 		return nil, fmt.Errorf("validating injectable: %w", err)
 	}
+	if err := validateComputedExpression(injectable); err != nil {
+		return nil, fmt.Errorf("validating injectable: %w", err)
+	}
 
 	id, err := s.repo.Create(ctx, injectable)
 	if err != nil {
@@ -129,6 +132,9 @@ func (s *WorkspaceInjectableService) UpdateInjectable(ctx context.Context, cmd i
 	if err := injectable.ValidateForWorkspace(); err != nil {
 		return nil, fmt.Errorf("validating injectable: %w", err)
 	}
+	if err := validateComputedExpression(injectable); err != nil {
+		return nil, fmt.Errorf("validating injectable: %w", err)
+	}
 
 	if err := s.repo.Update(ctx, injectable); err != nil {
 		return nil, fmt.Errorf("updating injectable: %w", err)
@@ -186,3 +192,22 @@ func (s *WorkspaceInjectableService) setActiveStatus(ctx context.Context, id, wo
 
 	return injectable, nil
 }
+
+// validateComputedExpression checks the expression of a computed injectable parses
+// and does not reference the injectable itself.
+func validateComputedExpression(injectable *entity.InjectableDefinition) error {
+	expr, ok := injectable.ComputedExpression()
+	if !ok {
+		return nil
+	}
+	parsed, err := parseComputedExpression(expr)
+	if err != nil {
+		return err
+	}
+	for _, ref := range parsed.collectRefs(nil) {
+		if ref == injectable.Key {
+			return fmt.Errorf("%w: expression references itself", entity.ErrInvalidComputedExpression)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package organization
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	organizationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/organization"
+)
+
+const workspaceAssetKeyPrefix = "workspace-assets"
+
+// fontContentTypes are the sniffed font formats Typst can load.
+var fontContentTypes = map[string]bool{
+	"font/ttf":        true,
+	"font/otf":        true,
+	"font/collection": true,
+}
+
+// logoContentTypes are the sniffed image formats accepted as a logo. SVG sniffs as
+// text, so it is recognized by its markup instead (see sniffLogoContentType).
+var logoContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// NewWorkspaceAssetService creates a new workspace asset service.
+func NewWorkspaceAssetService(
+	assetRepo port.WorkspaceAssetRepository,
+	storage port.StorageAdapter,
+) organizationuc.WorkspaceAssetUseCase {
+	return &WorkspaceAssetService{
+		assetRepo: assetRepo,
+		storage:   storage,
+	}
+}
+
+// WorkspaceAssetService implements workspace brand asset business logic.
+type WorkspaceAssetService struct {
+	assetRepo port.WorkspaceAssetRepository
+	storage   port.StorageAdapter
+}
+
+// UploadAsset stores a logo or font for the workspace, replacing the current logo for logos.
+func (s *WorkspaceAssetService) UploadAsset(ctx context.Context, cmd organizationuc.UploadWorkspaceAssetCommand) (*entity.WorkspaceAsset, error) {
+	contentType, err := validateWorkspaceAsset(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousLogo *entity.WorkspaceAsset
+	if cmd.Kind == entity.WorkspaceAssetKindLogo {
+		if previousLogo, err = s.findLogo(ctx, cmd.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sha256.Sum256(cmd.Data)
+	key := fmt.Sprintf("%s/%s/%s-%s", workspaceAssetKeyPrefix, cmd.WorkspaceID, hex.EncodeToString(sum[:])[:12], sanitizeAssetFilename(cmd.Filename))
+	if err := s.storage.Upload(ctx, &port.StorageUploadRequest{
+		Key:         key,
+		Data:        cmd.Data,
+		ContentType: contentType,
+		Environment: entity.EnvironmentProd,
+	}); err != nil {
+		return nil, fmt.Errorf("uploading workspace asset: %w", err)
+	}
+
+	asset := &entity.WorkspaceAsset{
+		WorkspaceID: cmd.WorkspaceID,
+		Kind:        cmd.Kind,
+		Key:         key,
+		Filename:    cmd.Filename,
+		ContentType: contentType,
+		Size:        int64(len(cmd.Data)),
+		CreatedBy:   cmd.UserID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	// A workspace has one logo: the old record goes in the same transaction that saves the new one
+	save := s.assetRepo.Save
+	if cmd.Kind == entity.WorkspaceAssetKindLogo {
+		save = s.assetRepo.ReplaceLogo
+	}
+	if err := save(ctx, asset); err != nil {
+		return nil, fmt.Errorf("saving workspace asset: %w", err)
+	}
+
+	if previousLogo != nil && previousLogo.Key != key {
+		s.deleteStored(ctx, previousLogo)
+	}
+
+	slog.InfoContext(ctx, "workspace asset uploaded",
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("kind", string(asset.Kind)),
+		slog.String("key", asset.Key),
+		slog.Int64("size", asset.Size),
+	)
+
+	return asset, nil
+}
+
+// ListAssets lists the workspace's assets, logo first.
+func (s *WorkspaceAssetService) ListAssets(ctx context.Context, workspaceID string) ([]*entity.WorkspaceAsset, error) {
+	assets, err := s.assetRepo.List(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace assets: %w", err)
+	}
+	return assets, nil
+}
+
+// DeleteAsset removes an asset from storage and from the workspace.
+func (s *WorkspaceAssetService) DeleteAsset(ctx context.Context, workspaceID, id string) error {
+	asset, err := s.assetRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.assetRepo.Delete(ctx, workspaceID, id); err != nil {
+		return fmt.Errorf("deleting workspace asset: %w", err)
+	}
+	s.deleteStored(ctx, asset)
+
+	slog.InfoContext(ctx, "workspace asset deleted",
+		slog.String("workspace_id", workspaceID),
+		slog.String("kind", string(asset.Kind)),
+		slog.String("key", asset.Key),
+	)
+
+	return nil
+}
+
+func (s *WorkspaceAssetService) findLogo(ctx context.Context, workspaceID string) (*entity.WorkspaceAsset, error) {
+	assets, err := s.assetRepo.List(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace logo: %w", err)
+	}
+	for _, asset := range assets {
+		if asset.Kind == entity.WorkspaceAssetKindLogo {
+			return asset, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteStored removes an asset's file from storage. The record is already gone,
+// so a failure only leaves an orphaned file and is logged rather than returned.
+func (s *WorkspaceAssetService) deleteStored(ctx context.Context, asset *entity.WorkspaceAsset) {
+	if err := s.storage.Delete(ctx, &port.StorageRequest{
+		Key:         asset.Key,
+		Environment: entity.EnvironmentProd,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to delete workspace asset from storage",
+			slog.String("key", asset.Key),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// validateWorkspaceAsset checks the upload and returns the content type to store it with.
+// The content type is sniffed from the data, never taken from the client.
+func validateWorkspaceAsset(cmd organizationuc.UploadWorkspaceAssetCommand) (string, error) {
+	if !cmd.Kind.IsValid() {
+		return "", entity.ErrInvalidWorkspaceAssetKind
+	}
+	if len(cmd.Data) > organizationuc.MaxWorkspaceAssetSize {
+		return "", entity.ErrWorkspaceAssetFileTooLarge
+	}
+
+	if cmd.Kind == entity.WorkspaceAssetKindFont {
+		contentType := http.DetectContentType(cmd.Data)
+		if !fontContentTypes[contentType] {
+			return "", entity.ErrWorkspaceAssetContentType
+		}
+		return contentType, nil
+	}
+	contentType, ok := sniffLogoContentType(cmd.Data)
+	if !ok {
+		return "", entity.ErrWorkspaceAssetContentType
+	}
+	return contentType, nil
+}
+
+// sniffLogoContentType returns the image type of data, if it is an accepted logo format.
+func sniffLogoContentType(data []byte) (string, bool) {
+	if len(data) == 0 {
+		return "", false
+	}
+	contentType := http.DetectContentType(data)
+	if logoContentTypes[contentType] {
+		return contentType, true
+	}
+	if strings.HasPrefix(contentType, "text/") && bytes.Contains(bytes.ToLower(data[:min(len(data), 1024)]), []byte("<svg")) {
+		return "image/svg+xml", true
+	}
+	return "", false
+}
+
+func sanitizeAssetFilename(name string) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_", "\x00", "")
+	sanitized := strings.TrimSpace(replacer.Replace(name))
+	if sanitized == "" {
+		return "asset"
+	}
+	return sanitized
+}
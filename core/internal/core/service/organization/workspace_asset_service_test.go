@@ -0,0 +1,121 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	organizationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/organization"
+)
+
+type fakeWorkspaceAssetRepo struct {
+	port.WorkspaceAssetRepository
+	assets     []*entity.WorkspaceAsset
+	replaceErr error
+	replaced   *entity.WorkspaceAsset
+	deleted    []string
+}
+
+func (r *fakeWorkspaceAssetRepo) List(context.Context, string) ([]*entity.WorkspaceAsset, error) {
+	return r.assets, nil
+}
+
+func (r *fakeWorkspaceAssetRepo) ReplaceLogo(_ context.Context, asset *entity.WorkspaceAsset) error {
+	if r.replaceErr != nil {
+		return r.replaceErr
+	}
+	r.replaced = asset
+	return nil
+}
+
+func (r *fakeWorkspaceAssetRepo) Delete(_ context.Context, _, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type fakeAssetStorage struct {
+	port.StorageAdapter
+	deleted []string
+}
+
+func (s *fakeAssetStorage) Upload(context.Context, *port.StorageUploadRequest) error { return nil }
+
+func (s *fakeAssetStorage) Delete(_ context.Context, req *port.StorageRequest) error {
+	s.deleted = append(s.deleted, req.Key)
+	return nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestValidateWorkspaceAsset_SniffsLogoContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+		want        string
+		wantErr     bool
+	}{
+		{"png", "image/png", pngHeader, "image/png", false},
+		{"client type ignored", "application/octet-stream", pngHeader, "image/png", false},
+		{"svg", "image/svg+xml", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`), "image/svg+xml", false},
+		{"html claiming to be an image", "image/png", []byte("<html><script>alert(1)</script></html>"), "", true},
+		{"text claiming to be an image", "image/jpeg", []byte("not an image"), "", true},
+		{"empty", "image/png", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateWorkspaceAsset(organizationuc.UploadWorkspaceAssetCommand{
+				Kind:        entity.WorkspaceAssetKindLogo,
+				ContentType: tt.contentType,
+				Data:        tt.data,
+			})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrWorkspaceAssetContentType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkspaceAssetService_UploadLogoReplacesInOneStep(t *testing.T) {
+	previous := &entity.WorkspaceAsset{ID: "logo-1", Kind: entity.WorkspaceAssetKindLogo, Key: "workspace-assets/ws-1/old-logo.png"}
+	cmd := organizationuc.UploadWorkspaceAssetCommand{
+		WorkspaceID: "ws-1",
+		Kind:        entity.WorkspaceAssetKindLogo,
+		Filename:    "logo.png",
+		Data:        pngHeader,
+	}
+
+	t.Run("replaces the previous logo", func(t *testing.T) {
+		repo := &fakeWorkspaceAssetRepo{assets: []*entity.WorkspaceAsset{previous}}
+		storage := &fakeAssetStorage{}
+		svc := &WorkspaceAssetService{assetRepo: repo, storage: storage}
+
+		asset, err := svc.UploadAsset(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Same(t, asset, repo.replaced)
+		assert.Empty(t, repo.deleted, "the old record goes with the replacement, not separately")
+		assert.Equal(t, []string{previous.Key}, storage.deleted)
+	})
+
+	t.Run("failed save keeps the previous logo", func(t *testing.T) {
+		repo := &fakeWorkspaceAssetRepo{assets: []*entity.WorkspaceAsset{previous}, replaceErr: errors.New("db down")}
+		storage := &fakeAssetStorage{}
+		svc := &WorkspaceAssetService{assetRepo: repo, storage: storage}
+
+		_, err := svc.UploadAsset(context.Background(), cmd)
+
+		require.ErrorContains(t, err, "db down")
+		assert.Empty(t, repo.deleted)
+		assert.Empty(t, storage.deleted, "the previous logo file must stay in storage")
+	})
+}
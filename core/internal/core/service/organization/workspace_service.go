@@ -22,6 +22,7 @@ func NewWorkspaceService(
 	tenantMemberRepo port.TenantMemberRepository,
 	systemRoleRepo port.SystemRoleRepository,
 	accessHistoryRepo port.UserAccessHistoryRepository,
+	themes port.ThemeRegistry,
 ) organizationuc.WorkspaceUseCase {
 	return &WorkspaceService{
 		workspaceRepo:     workspaceRepo,
@@ -30,6 +31,7 @@ func NewWorkspaceService(
 		tenantMemberRepo:  tenantMemberRepo,
 		systemRoleRepo:    systemRoleRepo,
 		accessHistoryRepo: accessHistoryRepo,
+		themes:            themes,
 	}
 }
 
@@ -41,6 +43,7 @@ type WorkspaceService struct {
 	tenantMemberRepo  port.TenantMemberRepository
 	systemRoleRepo    port.SystemRoleRepository
 	accessHistoryRepo port.UserAccessHistoryRepository
+	themes            port.ThemeRegistry // nil when no themes are registered
 }
 
 // CreateWorkspace creates a new workspace.
@@ -229,6 +232,10 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, cmd organization
 		return nil, err
 	}
 
+	if err := s.applyDefaultThemeUpdate(workspace, cmd.DefaultTheme); err != nil {
+		return nil, err
+	}
+
 	now := time.Now().UTC()
 	workspace.UpdatedAt = &now
 
@@ -249,6 +256,22 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, cmd organization
 	return workspace, nil
 }
 
+// applyDefaultThemeUpdate validates and applies a default theme change to the workspace.
+// An empty theme clears the default; any other must be registered with the renderer.
+func (s *WorkspaceService) applyDefaultThemeUpdate(workspace *entity.Workspace, theme *string) error {
+	switch {
+	case theme == nil:
+		return nil
+	case *theme == "":
+		workspace.DefaultTheme = nil
+		return nil
+	case s.themes == nil || !s.themes.HasTheme(*theme):
+		return fmt.Errorf("%w: %q", entity.ErrUnknownTheme, *theme)
+	}
+	workspace.DefaultTheme = theme
+	return nil
+}
+
 // applyCodeUpdate validates and applies a code change to the workspace.
 // Returns nil if code is nil or unchanged.
 func (s *WorkspaceService) applyCodeUpdate(ctx context.Context, workspace *entity.Workspace, code *string) error {
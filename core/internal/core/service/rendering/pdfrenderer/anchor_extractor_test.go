@@ -0,0 +1,164 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// placedText is a run of text drawn at a known position, in PDF points from the bottom-left corner.
+type placedText struct {
+	text string
+	x, y float64
+}
+
+// textPDF writes an A4 PDF whose page i draws pages[i] in Courier, so anchors land
+// at known coordinates, and returns its path.
+func textPDF(t *testing.T, pages [][]placedText) string {
+	t.Helper()
+	// Courier is monospaced: every glyph is 600/1000 em, so a 10pt anchor is 6pt per character.
+	widths := strings.TrimSpace(strings.Repeat("600 ", 95))
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding /FirstChar 32 /LastChar 126 /Widths [" + widths + "] >>",
+	}
+	kids := make([]string, len(pages))
+	for i, runs := range pages {
+		var content strings.Builder
+		for _, r := range runs {
+			fmt.Fprintf(&content, "BT /F1 10 Tf %g %g Td (%s) Tj ET\n", r.x, r.y, r.text)
+		}
+		pageObj := len(objects) + 1
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Contents %d 0 R /Resources << /Font << /F1 3 0 R >> >> >>", pageObj+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	path := filepath.Join(t.TempDir(), "anchors.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	return path
+}
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1 }
+
+func TestExtractAnchorPositions_KnownPositions(t *testing.T) {
+	path := textPDF(t, [][]placedText{
+		{{text: "Terms and conditions", x: 72, y: 760}},
+		{{text: "__sig_buyer__", x: 100, y: 300}},
+		{{text: "Annex", x: 72, y: 760}, {text: "__sig_seller__", x: 320, y: 150}},
+	})
+
+	positions, err := ExtractAnchorPositions(context.Background(), path, []string{"__sig_buyer__", "__sig_seller__", "__sig_missing__"})
+	if err != nil {
+		t.Fatalf("ExtractAnchorPositions failed: %v", err)
+	}
+
+	tests := []struct {
+		anchor string
+		page   int
+		x, y   float64
+		width  float64
+	}{
+		{"__sig_buyer__", 2, 100, 300, 78},
+		{"__sig_seller__", 3, 320, 150, 84},
+	}
+	for _, tt := range tests {
+		pos, ok := positions[tt.anchor]
+		if !ok {
+			t.Errorf("anchor %s not found", tt.anchor)
+			continue
+		}
+		if pos.Page != tt.page || !near(pos.X, tt.x) || !near(pos.Y, tt.y) {
+			t.Errorf("anchor %s at page %d (%.1f, %.1f), want page %d (%.1f, %.1f)",
+				tt.anchor, pos.Page, pos.X, pos.Y, tt.page, tt.x, tt.y)
+		}
+		if pos.PageWidth != 595 || pos.PageHeight != 842 {
+			t.Errorf("anchor %s page size = %.0fx%.0f, want 595x842", tt.anchor, pos.PageWidth, pos.PageHeight)
+		}
+		if !near(pos.Width, tt.width) {
+			t.Errorf("anchor %s width = %.1f, want %.1f", tt.anchor, pos.Width, tt.width)
+		}
+	}
+	if _, ok := positions["__sig_missing__"]; ok {
+		t.Error("an anchor absent from the PDF must not be reported")
+	}
+}
+
+func TestExtractAndUpdatePositions_OverridesEstimates(t *testing.T) {
+	path := textPDF(t, [][]placedText{
+		{{text: "Terms and conditions", x: 72, y: 760}},
+		{{text: "__sig_buyer__", x: 100, y: 300}},
+	})
+	pdfBytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	fields := []port.SignatureField{
+		{RoleID: "buyer", AnchorString: "__sig_buyer__", Page: 1, PositionX: 35, PositionY: 55},
+		{RoleID: "seller", AnchorString: "__sig_seller__", Page: 1, PositionX: 35, PositionY: 55},
+	}
+
+	s := &Service{}
+	got := s.extractAndUpdatePositions(context.Background(), pdfBytes, fields)
+
+	buyer := got[0]
+	if buyer.Page != 2 || !near(buyer.PDFPointX, 100) || !near(buyer.PDFPointY, 300) {
+		t.Errorf("buyer at page %d (%.1f, %.1f), want page 2 (100, 300)", buyer.Page, buyer.PDFPointX, buyer.PDFPointY)
+	}
+	if buyer.PDFPageW != 595 || buyer.PDFPageH != 842 || !near(buyer.PDFAnchorW, 78) {
+		t.Errorf("buyer page size %.0fx%.0f, anchor width %.1f", buyer.PDFPageW, buyer.PDFPageH, buyer.PDFAnchorW)
+	}
+	if seller := got[1]; seller.Page != 1 || seller.PDFPageW != 0 {
+		t.Errorf("an unmatched field must keep its estimate, got %+v", seller)
+	}
+	if fields[0].Page != 1 {
+		t.Error("the input fields must not be modified")
+	}
+}
+
+func TestMatchBboxAnchors_ConvertsToBottomLeftOrigin(t *testing.T) {
+	words := []bboxWord{
+		{XMin: "72", YMin: "80", XMax: "120", Text: "Terms"},
+		{XMin: "100", YMin: "542", XMax: "178", Text: "__sig_buyer__"},
+		{XMin: "320", YMin: "542", XMax: "404", Text: "__sig_seller__"},
+	}
+	positions := make(map[string]AnchorPosition)
+	matchBboxAnchors(context.Background(), words, []string{"__sig_buyer__", "__sig_seller__"}, 2, 595, 842, positions)
+
+	want := map[string]AnchorPosition{
+		"__sig_buyer__":  {Page: 2, X: 100, Y: 300, Width: 78, PageWidth: 595, PageHeight: 842},
+		"__sig_seller__": {Page: 2, X: 320, Y: 300, Width: 84, PageWidth: 595, PageHeight: 842},
+	}
+	for anchor, w := range want {
+		if got := positions[anchor]; got != w {
+			t.Errorf("%s = %+v, want %+v", anchor, got, w)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+// DocumentLimits bounds the content a single render converts, so a pathological
+// document fails fast instead of exhausting memory. Zero disables a limit.
+type DocumentLimits struct {
+	// MaxNodes is the maximum number of content nodes, counted at every level.
+	MaxNodes int
+
+	// MaxDepth is the maximum nesting depth; top-level blocks are at depth 1.
+	MaxDepth int
+}
+
+// Check walks the document content and returns entity.ErrDocumentTooLarge as soon
+// as a limit is exceeded. The walk is iterative and stops at the first violation.
+func (l DocumentLimits) Check(doc *portabledoc.Document) error {
+	return l.CheckExpanded(doc, nil)
+}
+
+// CheckExpanded checks the document as it renders with slots filled: each insertion
+// point counts its slot's content again, nested one level below it. A slot inside its
+// own content is not expanded again, matching the converter.
+func (l DocumentLimits) CheckExpanded(doc *portabledoc.Document, slots map[string][]portabledoc.Node) error {
+	if doc == nil || doc.Content == nil {
+		return nil
+	}
+	return l.check(doc.Content.Content, slots)
+}
+
+// CheckNodes applies the limits to a node tree supplied outside the document,
+// such as insertion point content.
+func (l DocumentLimits) CheckNodes(nodes []portabledoc.Node) error {
+	return l.check(nodes, nil)
+}
+
+func (l DocumentLimits) check(nodes []portabledoc.Node, slots map[string][]portabledoc.Node) error {
+	if l.MaxNodes <= 0 && l.MaxDepth <= 0 {
+		return nil
+	}
+
+	type level struct {
+		nodes   []portabledoc.Node
+		depth   int
+		filling []string // slots being expanded above this level
+	}
+	stack := []level{{nodes: nodes, depth: 1}}
+	count := 0
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(top.nodes) == 0 {
+			continue
+		}
+		if l.MaxDepth > 0 && top.depth > l.MaxDepth {
+			return fmt.Errorf("%w: nested deeper than %d levels", entity.ErrDocumentTooLarge, l.MaxDepth)
+		}
+		count += len(top.nodes)
+		if l.MaxNodes > 0 && count > l.MaxNodes {
+			return fmt.Errorf("%w: more than %d nodes", entity.ErrDocumentTooLarge, l.MaxNodes)
+		}
+		for i := range top.nodes {
+			node := &top.nodes[i]
+			stack = append(stack, level{nodes: node.Content, depth: top.depth + 1, filling: top.filling})
+			if node.Type != portabledoc.NodeTypeInsertionPoint {
+				continue
+			}
+			slot, _ := node.Attrs["slot"].(string)
+			if content := slots[slot]; len(content) > 0 && !slices.Contains(top.filling, slot) {
+				stack = append(stack, level{nodes: content, depth: top.depth + 1, filling: append(slices.Clip(top.filling), slot)})
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// nestedDocument wraps a paragraph in depth-1 blockquotes.
+func nestedDocument(depth int) *portabledoc.Document {
+	doc := staticDocument()
+	node := paragraphNode(textNode("deep"))
+	for range depth - 1 {
+		node = portabledoc.Node{Type: portabledoc.NodeTypeBlockquote, Content: []portabledoc.Node{node}}
+	}
+	doc.Content.Content = []portabledoc.Node{node}
+	return doc
+}
+
+func TestDocumentLimits_Check(t *testing.T) {
+	// staticDocument has 40 paragraphs with one text node each: 80 nodes, depth 2.
+	tests := []struct {
+		name    string
+		limits  DocumentLimits
+		doc     *portabledoc.Document
+		wantErr bool
+	}{
+		{"unlimited", DocumentLimits{}, staticDocument(), false},
+		{"at node limit", DocumentLimits{MaxNodes: 80}, staticDocument(), false},
+		{"over node limit", DocumentLimits{MaxNodes: 79}, staticDocument(), true},
+		{"at depth limit", DocumentLimits{MaxDepth: 10}, nestedDocument(9), false},
+		{"over depth limit", DocumentLimits{MaxDepth: 10}, nestedDocument(10), true},
+		{"no content", DocumentLimits{MaxNodes: 1, MaxDepth: 1}, &portabledoc.Document{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Check(tt.doc)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, entity.ErrDocumentTooLarge) {
+				t.Fatalf("expected ErrDocumentTooLarge, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDocumentLimits_CheckExpanded(t *testing.T) {
+	// Three insertion points of a slot holding staticDocument's 80 nodes: 3 + 3*80 nodes.
+	doc := &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+		insertionPointNode("terms"), insertionPointNode("terms"), insertionPointNode("terms"),
+	}}}
+	slots := map[string][]portabledoc.Node{"terms": staticDocument().Content.Content}
+	// One insertion point of a slot nested 6 levels deep: the slot's deepest node lands at depth 7.
+	deep := &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{insertionPointNode("deep")}}}
+	deepSlots := map[string][]portabledoc.Node{"deep": nestedDocument(5).Content.Content}
+	// A slot that contains itself is expanded once.
+	selfSlots := map[string][]portabledoc.Node{"terms": {insertionPointNode("terms")}}
+
+	tests := []struct {
+		name    string
+		limits  DocumentLimits
+		doc     *portabledoc.Document
+		slots   map[string][]portabledoc.Node
+		wantErr bool
+	}{
+		{"at node limit", DocumentLimits{MaxNodes: 243}, doc, slots, false},
+		{"slot counted per insertion point", DocumentLimits{MaxNodes: 242}, doc, slots, true},
+		{"at depth limit", DocumentLimits{MaxDepth: 7}, deep, deepSlots, false},
+		{"slot nested below its insertion point", DocumentLimits{MaxDepth: 6}, deep, deepSlots, true},
+		{"self insertion", DocumentLimits{MaxNodes: 2}, doc, selfSlots, true},
+		{"self insertion within limits", DocumentLimits{MaxNodes: 6}, doc, selfSlots, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.CheckExpanded(tt.doc, tt.slots)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("CheckExpanded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, entity.ErrDocumentTooLarge) {
+				t.Fatalf("expected ErrDocumentTooLarge, got %v", err)
+			}
+		})
+	}
+
+	if err := (DocumentLimits{MaxDepth: 6}).CheckNodes(deepSlots["deep"]); err != nil {
+		t.Fatalf("the slot alone is within the depth limit, got %v", err)
+	}
+}
+
+func TestConvert_RejectsDocumentsOverLimits(t *testing.T) {
+	tokens := DefaultDesignTokens()
+	s := &Service{converterFactory: NewTypstConverterFactory(tokens), tokens: tokens, limits: DocumentLimits{MaxNodes: 100, MaxDepth: 8}}
+
+	_, source, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: staticDocument()})
+	if err != nil {
+		t.Fatalf("document under the limits must convert: %v", err)
+	}
+	if source == "" {
+		t.Fatal("expected Typst source for a document under the limits")
+	}
+
+	big := staticDocument()
+	big.Content.Content = append(big.Content.Content, big.Content.Content...)
+	if _, _, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: big}); !errors.Is(err, entity.ErrDocumentTooLarge) {
+		t.Fatalf("expected ErrDocumentTooLarge for too many nodes, got %v", err)
+	}
+
+	if _, _, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: nestedDocument(20)}); !errors.Is(err, entity.ErrDocumentTooLarge) {
+		t.Fatalf("expected ErrDocumentTooLarge for deep nesting, got %v", err)
+	}
+
+	slots := map[string][]portabledoc.Node{"appendix": nestedDocument(20).Content.Content}
+	if _, _, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: staticDocument(), Slots: slots}); !errors.Is(err, entity.ErrDocumentTooLarge) {
+		t.Fatalf("expected ErrDocumentTooLarge for oversized slot content, got %v", err)
+	}
+
+	// Slot content within the limits alone still counts at each insertion point that shows it
+	repeated := &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+		insertionPointNode("terms"), insertionPointNode("terms"),
+	}}}
+	slots = map[string][]portabledoc.Node{"terms": staticDocument().Content.Content[:30]}
+	if _, _, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: repeated, Slots: slots}); !errors.Is(err, entity.ErrDocumentTooLarge) {
+		t.Fatalf("expected ErrDocumentTooLarge for slot content repeated past the limit, got %v", err)
+	}
+}
@@ -25,7 +25,7 @@ var cssFontFallbacks = map[string][]string{
 func fontWithFallbacks(family string) string {
 	fallbacks := cssFontFallbacks[strings.ToLower(strings.TrimSpace(family))]
 	if len(fallbacks) == 0 {
-		return fmt.Sprintf("\"%s\"", family)
+		return fmt.Sprintf("\"%s\"", escapeTypstString(family))
 	}
 
 	quoted := make([]string, len(fallbacks))
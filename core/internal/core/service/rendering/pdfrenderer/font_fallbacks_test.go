@@ -59,6 +59,14 @@ func TestFontWithFallbacks_UnknownFont(t *testing.T) {
 	}
 }
 
+func TestFontWithFallbacks_UnknownFontIsQuoted(t *testing.T) {
+	got := fontWithFallbacks(`Evil"), fill: red, font: ("x`)
+	want := `"Evil\"), fill: red, font: (\"x"`
+	if got != want {
+		t.Errorf("fontWithFallbacks must keep the family inside its string literal, got %q", got)
+	}
+}
+
 func TestFontWithFallbacks_TrimsWhitespace(t *testing.T) {
 	got := fontWithFallbacks("  Arial  ")
 	if !strings.Contains(got, "Liberation Sans") {
@@ -248,6 +256,14 @@ func TestDefaultDesignTokens_FontStackStartsWithInter(t *testing.T) {
 	}
 }
 
+func TestDefaultDesignTokens_FontStackEndsWithEmojiFont(t *testing.T) {
+	tokens := DefaultDesignTokens()
+
+	if last := tokens.FontStack[len(tokens.FontStack)-1]; last != "Noto Color Emoji" {
+		t.Errorf("default font stack should end with an emoji font, got %v", tokens.FontStack)
+	}
+}
+
 // --- Builder integration: base typography uses font stack ---
 
 func TestTypstBuilder_TypographyIncludesFallbackFonts(t *testing.T) {
@@ -0,0 +1,46 @@
+package pdfrenderer
+
+import (
+	"fmt"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+const (
+	// DefaultImagePPI is the resolution of image renders that don't set one.
+	DefaultImagePPI = 72
+
+	// MaxImagePPI bounds image render resolution; an A4 page at 600 ppi is ~35 megapixels.
+	MaxImagePPI = 600
+)
+
+// validateImage rejects image renders with out-of-range options or PDF-only options.
+func validateImage(req *port.RenderPreviewRequest) error {
+	img := req.Image
+	if img == nil {
+		return nil
+	}
+	if img.Page < 0 {
+		return fmt.Errorf("%w: page must be 1 or greater", entity.ErrInvalidImageRender)
+	}
+	if img.PPI < 0 || img.PPI > MaxImagePPI {
+		return fmt.Errorf("%w: ppi must be between 1 and %d", entity.ErrInvalidImageRender, MaxImagePPI)
+	}
+	if req.PDFA || req.Encryption != nil || req.SealWorkspaceID != "" || req.ExtractSignaturePages {
+		return fmt.Errorf("%w: PDF/A, encryption, sealing and signature pages apply to PDFs only", entity.ErrInvalidImageRender)
+	}
+	return nil
+}
+
+// imageParams returns the page and resolution to rasterize, applying defaults.
+func imageParams(img *port.ImageOptions) (page, ppi int) {
+	page, ppi = img.Page, img.PPI
+	if page == 0 {
+		page = 1
+	}
+	if ppi == 0 {
+		ppi = DefaultImagePPI
+	}
+	return page, ppi
+}
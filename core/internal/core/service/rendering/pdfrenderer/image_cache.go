@@ -10,9 +10,11 @@ import (
 	"image"
 	"image/color"
 	_ "image/gif"
+	"image/jpeg"
 	"image/png"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -20,17 +22,20 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/image/draw"
 	_ "golang.org/x/image/webp"
 )
 
 // ImageCache provides a shared disk-based cache for downloaded images.
-// Files are keyed by SHA-256 of the URL and cleaned up periodically by age.
+// Files are keyed by SHA-256 of the URL and the max dimension they were downscaled to,
+// and cleaned up periodically by age.
 type ImageCache struct {
-	dir     string
-	maxAge  time.Duration
-	mu      sync.RWMutex
-	stopCh  chan struct{}
-	stopped chan struct{}
+	dir          string
+	maxAge       time.Duration
+	maxDimension int
+	mu           sync.RWMutex
+	stopCh       chan struct{}
+	stopped      chan struct{}
 }
 
 // ImageCacheOptions configures the image cache.
@@ -38,6 +43,9 @@ type ImageCacheOptions struct {
 	Dir             string
 	MaxAge          time.Duration
 	CleanupInterval time.Duration
+
+	// MaxDimension downscales raster images whose width or height exceeds it (0 = unlimited).
+	MaxDimension int
 }
 
 // NewImageCache creates and starts an image cache with periodic cleanup.
@@ -63,18 +71,24 @@ func NewImageCache(opts ImageCacheOptions) (*ImageCache, error) {
 	}
 
 	ic := &ImageCache{
-		dir:     opts.Dir,
-		maxAge:  opts.MaxAge,
-		stopCh:  make(chan struct{}),
-		stopped: make(chan struct{}),
+		dir:          opts.Dir,
+		maxAge:       opts.MaxAge,
+		maxDimension: opts.MaxDimension,
+		stopCh:       make(chan struct{}),
+		stopped:      make(chan struct{}),
 	}
 
 	go ic.cleanupLoop(opts.CleanupInterval)
 	return ic, nil
 }
 
-// cacheKeyForURL returns a hex-encoded SHA-256 hash of the URL.
-func cacheKeyForURL(url string) string {
+// cacheKey returns a hex-encoded SHA-256 hash of the URL. The max dimension is part of
+// the key so a cache directory reused with a different limit does not serve images
+// downscaled for the old one.
+func (ic *ImageCache) cacheKey(url string) string {
+	if ic.maxDimension > 0 {
+		url = fmt.Sprintf("%s#max=%d", url, ic.maxDimension)
+	}
 	h := sha256.Sum256([]byte(url))
 	return hex.EncodeToString(h[:])
 }
@@ -85,7 +99,7 @@ func (ic *ImageCache) Lookup(url string) (string, bool) {
 	ic.mu.RLock()
 	defer ic.mu.RUnlock()
 
-	prefix := cacheKeyForURL(url)
+	prefix := ic.cacheKey(url)
 	matches, err := filepath.Glob(filepath.Join(ic.dir, prefix+".*"))
 	if err != nil || len(matches) == 0 {
 		return "", false
@@ -103,7 +117,7 @@ func (ic *ImageCache) Store(url string, ext string, data []byte) (string, error)
 	ic.mu.Lock()
 	defer ic.mu.Unlock()
 
-	filename := cacheKeyForURL(url) + ext
+	filename := ic.cacheKey(url) + ext
 	path := filepath.Join(ic.dir, filename)
 	if err := os.WriteFile(path, data, 0o600); err != nil {
 		return "", err
@@ -172,14 +186,18 @@ func (ic *ImageCache) Close() {
 
 // ResolveImages downloads images that are not cached, stores them, and returns
 // a map of typst placeholder filenames to actual filenames in the cache dir.
-func (ic *ImageCache) ResolveImages(ctx context.Context, images map[string]string, httpClient *http.Client) map[string]string {
+// It stops with the context error once ctx is cancelled.
+func (ic *ImageCache) ResolveImages(ctx context.Context, images map[string]string, httpClient *http.Client) (map[string]string, error) {
 	renames := make(map[string]string)
 	for url, typstFilename := range images {
 		if cachedName := ic.resolveOne(ctx, url, typstFilename, httpClient); cachedName != typstFilename {
 			renames[typstFilename] = cachedName
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 	}
-	return renames
+	return renames, nil
 }
 
 // resolveOne resolves a single image, returning the actual filename in the cache dir.
@@ -190,6 +208,10 @@ func (ic *ImageCache) resolveOne(ctx context.Context, url, typstFilename string,
 
 	storedName, err := ic.downloadAndStore(ctx, url, httpClient)
 	if err != nil {
+		// A cancelled download says nothing about the image; don't cache a placeholder for it
+		if ctx.Err() != nil {
+			return typstFilename
+		}
 		slog.WarnContext(ctx, "failed to download image, using placeholder",
 			slog.String("url", url), slog.Any("error", err),
 		)
@@ -204,16 +226,7 @@ func (ic *ImageCache) downloadAndStore(ctx context.Context, url string, httpClie
 	if err != nil {
 		return "", err
 	}
-	originalData, originalExt := data, ext
-
-	data, ext, err = sanitizeTransparentRaster(data, ext)
-	if err != nil {
-		slog.WarnContext(ctx, "failed to sanitize transparent raster image; using original bytes",
-			slog.String("url", url),
-			slog.Any("error", err),
-		)
-		data, ext = originalData, originalExt
-	}
+	data, ext = transcodeImage(ctx, url, data, ext, ic.maxDimension)
 
 	storedPath, err := ic.Store(url, ext, data)
 	if err != nil {
@@ -225,7 +238,7 @@ func (ic *ImageCache) downloadAndStore(ctx context.Context, url string, httpClie
 // storePlaceholder stores a 1x1 PNG placeholder and returns its cache filename.
 func (ic *ImageCache) storePlaceholder(url string) string {
 	_, _ = ic.Store(url, ".png", getPlaceholderPNG())
-	return cacheKeyForURL(url) + ".png"
+	return ic.cacheKey(url) + ".png"
 }
 
 var (
@@ -298,6 +311,82 @@ func downloadImage(ctx context.Context, url string, httpClient *http.Client) ([]
 	return data, ext, nil
 }
 
+// transcodeImage prepares downloaded image bytes for embedding: it downscales rasters
+// larger than maxDimension and sanitizes transparent edges. A step that fails is
+// skipped with a warning so the image is still embedded.
+func transcodeImage(ctx context.Context, url string, data []byte, ext string, maxDimension int) ([]byte, string) {
+	if scaled, scaledExt, err := downscaleRaster(data, ext, maxDimension); err != nil {
+		slog.WarnContext(ctx, "failed to downscale raster image; using original size",
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+	} else {
+		data, ext = scaled, scaledExt
+	}
+
+	sanitized, sanitizedExt, err := sanitizeTransparentRaster(data, ext)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to sanitize transparent raster image; using original bytes",
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+		return data, ext
+	}
+	return sanitized, sanitizedExt
+}
+
+// downscaleRaster shrinks a raster image so neither side exceeds maxDimension,
+// preserving its aspect ratio. Images within bounds, SVGs and maxDimension <= 0
+// return the input unchanged. JPEGs stay JPEG; other formats are re-encoded as PNG.
+// Re-encoding drops EXIF data, so a JPEG's EXIF orientation is applied to the pixels.
+func downscaleRaster(data []byte, ext string, maxDimension int) ([]byte, string, error) {
+	if maxDimension <= 0 || !isRasterImageExt(ext) {
+		return data, ext, nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode raster image config: %w", err)
+	}
+	if cfg.Width <= maxDimension && cfg.Height <= maxDimension {
+		return data, ext, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode raster image: %w", err)
+	}
+
+	isJPEG := strings.EqualFold(ext, ".jpg") || strings.EqualFold(ext, ".jpeg")
+	if isJPEG {
+		src = orientImage(src, jpegOrientation(data))
+	}
+
+	width, height := scaledSize(src.Bounds().Dx(), src.Bounds().Dy(), maxDimension)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+	var buf bytes.Buffer
+	if isJPEG {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90}); err != nil {
+			return nil, "", fmt.Errorf("encode downscaled jpeg: %w", err)
+		}
+		return buf.Bytes(), ext, nil
+	}
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, "", fmt.Errorf("encode downscaled png: %w", err)
+	}
+	return buf.Bytes(), ".png", nil
+}
+
+// scaledSize fits width x height within maxDimension on its longer side.
+func scaledSize(width, height, maxDimension int) (int, int) {
+	if width >= height {
+		return maxDimension, max(1, int(math.Round(float64(height)*float64(maxDimension)/float64(width))))
+	}
+	return max(1, int(math.Round(float64(width)*float64(maxDimension)/float64(height)))), maxDimension
+}
+
 func sanitizeTransparentRaster(data []byte, ext string) ([]byte, string, error) {
 	if !isRasterImageExt(ext) || strings.EqualFold(ext, ".jpg") || strings.EqualFold(ext, ".jpeg") {
 		return data, ext, nil
@@ -446,12 +535,19 @@ func isSVG(data []byte) bool {
 // downloadImages downloads remote images to the given directory (fallback when no cache is available).
 // Returns a map of old filename to new filename for cases where the extension was corrected.
 // For failed downloads, creates a 1x1 PNG placeholder so Typst does not crash.
-func downloadImages(ctx context.Context, images map[string]string, dir string, httpClient *http.Client) (map[string]string, error) {
+// Raster images larger than maxDimension (when > 0) are downscaled before they are written.
+func downloadImages(ctx context.Context, images map[string]string, dir string, httpClient *http.Client, maxDimension int) (map[string]string, error) {
 	renames := make(map[string]string)
 	var lastErr error
 	for url, filename := range images {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		data, ext, err := downloadImage(ctx, url, httpClient)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
 			slog.WarnContext(ctx, "failed to download image, using placeholder",
 				slog.String("url", url),
 				slog.Any("error", err),
@@ -466,16 +562,7 @@ func downloadImages(ctx context.Context, images map[string]string, dir string, h
 			}
 			continue
 		}
-		originalData, originalExt := data, ext
-
-		data, ext, err = sanitizeTransparentRaster(data, ext)
-		if err != nil {
-			slog.WarnContext(ctx, "failed to sanitize transparent raster image; using original bytes",
-				slog.String("url", url),
-				slog.Any("error", err),
-			)
-			data, ext = originalData, originalExt
-		}
+		data, ext = transcodeImage(ctx, url, data, ext, maxDimension)
 
 		// Fix extension to match actual content
 		base := strings.TrimSuffix(filename, filepath.Ext(filename))
@@ -3,14 +3,18 @@ package pdfrenderer
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"image"
 	"image/color"
+	"image/jpeg"
 	"image/png"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestSanitizeTransparentRaster_BleedsTransparentEdgesWithoutChangingAlpha(t *testing.T) {
@@ -49,7 +53,7 @@ func TestDownloadImages_SanitizesTransparentRasterIntoPNG(t *testing.T) {
 	dir := t.TempDir()
 	renames, err := downloadImages(context.Background(), map[string]string{
 		server.URL + "/logo.png": "img_1.png",
-	}, dir, server.Client())
+	}, dir, server.Client(), 0)
 	if err != nil {
 		t.Fatalf("downloadImages returned error: %v", err)
 	}
@@ -96,3 +100,227 @@ func makeTransparentEdgePNG(t *testing.T) []byte {
 	}
 	return buf.Bytes()
 }
+
+func TestImageCache_ResolveImagesStopsWhenCancelled(t *testing.T) {
+	cache, err := NewImageCache(ImageCacheOptions{Dir: t.TempDir(), CleanupInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewImageCache: %v", err)
+	}
+	defer cache.Close()
+
+	transport := &slowTransport{started: make(chan string, 4)}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-transport.started
+		cancel()
+	}()
+
+	images := map[string]string{"https://example.com/a.png": "img_1.png", "https://example.com/b.png": "img_2.png"}
+	_, err = cache.ResolveImages(ctx, images, &http.Client{Transport: transport})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := len(transport.started); n != 0 {
+		t.Errorf("expected no fetches after cancellation, got %d", n)
+	}
+	for url := range images {
+		if _, found := cache.Lookup(url); found {
+			t.Errorf("cancelled download of %s must not be cached", url)
+		}
+	}
+}
+
+func TestDownloadImages_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	transport := &slowTransport{started: make(chan string, 1)}
+	_, err := downloadImages(ctx, map[string]string{"https://example.com/a.png": "img_1.png"}, t.TempDir(), &http.Client{Transport: transport}, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := len(transport.started); n != 0 {
+		t.Errorf("expected no fetches, got %d", n)
+	}
+}
+
+func TestDownloadImages_DownscalesOversizedImage(t *testing.T) {
+	input := makeOpaquePNG(t, 400, 200)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(input)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if _, err := downloadImages(context.Background(), map[string]string{
+		server.URL + "/photo.png": "img_1.png",
+	}, dir, server.Client(), 100); err != nil {
+		t.Fatalf("downloadImages returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "img_1.png"))
+	if err != nil {
+		t.Fatalf("read downscaled image: %v", err)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode downscaled png: %v", err)
+	}
+	if cfg.Width != 100 || cfg.Height != 50 {
+		t.Fatalf("expected 100x50 after downscaling, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
+
+func TestImageCache_DownscalesOversizedJPEG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 150, 300)), nil); err != nil {
+		t.Fatalf("encode test jpeg: %v", err)
+	}
+	url := "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	cache, err := NewImageCache(ImageCacheOptions{Dir: t.TempDir(), CleanupInterval: time.Hour, MaxDimension: 120})
+	if err != nil {
+		t.Fatalf("NewImageCache: %v", err)
+	}
+	defer cache.Close()
+
+	renames, err := cache.ResolveImages(context.Background(), map[string]string{url: "img_1.jpg"}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("ResolveImages: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cache.Dir(), renames["img_1.jpg"]))
+	if err != nil {
+		t.Fatalf("read cached image: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("cached image is not a jpeg: %v", err)
+	}
+	if cfg.Width != 60 || cfg.Height != 120 {
+		t.Fatalf("expected 60x120 after downscaling, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
+
+func TestDownscaleRaster_LeavesSmallImageUntouched(t *testing.T) {
+	input := makeOpaquePNG(t, 80, 40)
+
+	got, ext, err := downscaleRaster(input, ".png", 100)
+	if err != nil {
+		t.Fatalf("downscaleRaster returned error: %v", err)
+	}
+	if ext != ".png" || !bytes.Equal(got, input) {
+		t.Fatalf("expected small image bytes to be returned unchanged")
+	}
+
+	got, _, err = downscaleRaster(input, ".png", 0)
+	if err != nil || !bytes.Equal(got, input) {
+		t.Fatalf("expected no downscaling without a max dimension, err=%v", err)
+	}
+}
+
+func TestDownscaleRaster_AppliesJPEGOrientation(t *testing.T) {
+	// A 40x20 image whose top-left pixel is red, stored with EXIF orientation 6
+	// (display rotated 90° clockwise), so it displays 20x40 with red at the top right.
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("encode test jpeg: %v", err)
+	}
+	input := withEXIFOrientation(buf.Bytes(), 6)
+	if got := jpegOrientation(input); got != 6 {
+		t.Fatalf("jpegOrientation = %d, want 6", got)
+	}
+
+	got, ext, err := downscaleRaster(input, ".jpg", 20)
+	if err != nil {
+		t.Fatalf("downscaleRaster returned error: %v", err)
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(got))
+	if err != nil || ext != ".jpg" {
+		t.Fatalf("expected a jpeg, got ext %q and err %v", ext, err)
+	}
+	if b := decoded.Bounds(); b.Dx() != 10 || b.Dy() != 20 {
+		t.Fatalf("expected 10x20 after orienting and downscaling, got %dx%d", b.Dx(), b.Dy())
+	}
+	if r, g, _, _ := decoded.At(8, 1).RGBA(); r < 0xC000 || g > 0x4000 {
+		t.Fatalf("expected the red corner at the top right after rotation")
+	}
+}
+
+func TestImageCache_KeysOnMaxDimension(t *testing.T) {
+	dir := t.TempDir()
+	url := "data:image/png;base64," + base64.StdEncoding.EncodeToString(makeOpaquePNG(t, 400, 200))
+
+	resolvedWidth := func(maxDimension int) int {
+		t.Helper()
+		cache, err := NewImageCache(ImageCacheOptions{Dir: dir, CleanupInterval: time.Hour, MaxDimension: maxDimension})
+		if err != nil {
+			t.Fatalf("NewImageCache: %v", err)
+		}
+		defer cache.Close()
+
+		renames, err := cache.ResolveImages(context.Background(), map[string]string{url: "img_1.png"}, http.DefaultClient)
+		if err != nil {
+			t.Fatalf("ResolveImages: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, renames["img_1.png"]))
+		if err != nil {
+			t.Fatalf("read cached image: %v", err)
+		}
+		cfg, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decode cached png: %v", err)
+		}
+		return cfg.Width
+	}
+
+	if got := resolvedWidth(100); got != 100 {
+		t.Fatalf("expected width 100, got %d", got)
+	}
+	if got := resolvedWidth(200); got != 200 {
+		t.Fatalf("expected a new max dimension to re-downscale the image, got width %d", got)
+	}
+}
+
+// withEXIFOrientation inserts a big-endian EXIF APP1 segment carrying orientation
+// right after the JPEG start-of-image marker.
+func withEXIFOrientation(data []byte, orientation uint16) []byte {
+	payload := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08")
+	payload = append(payload, 0x00, 0x01) // one IFD entry
+	payload = append(payload, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, byte(orientation>>8), byte(orientation), 0x00, 0x00)
+	payload = append(payload, 0x00, 0x00, 0x00, 0x00) // no next IFD
+
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	out = append(out, payload...)
+	return append(out, data[2:]...)
+}
+
+func makeOpaquePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 120, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode test png: %v", err)
+	}
+	return buf.Bytes()
+}
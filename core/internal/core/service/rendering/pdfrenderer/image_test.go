@@ -0,0 +1,145 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func TestValidateImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     port.RenderPreviewRequest
+		wantErr bool
+	}{
+		{name: "no image", req: port.RenderPreviewRequest{PDFA: true}},
+		{name: "defaults", req: port.RenderPreviewRequest{Image: &port.ImageOptions{}}},
+		{name: "page and ppi", req: port.RenderPreviewRequest{Image: &port.ImageOptions{Page: 3, PPI: MaxImagePPI}}},
+		{name: "negative page", req: port.RenderPreviewRequest{Image: &port.ImageOptions{Page: -1}}, wantErr: true},
+		{name: "ppi too high", req: port.RenderPreviewRequest{Image: &port.ImageOptions{PPI: MaxImagePPI + 1}}, wantErr: true},
+		{name: "with pdfa", req: port.RenderPreviewRequest{Image: &port.ImageOptions{}, PDFA: true}, wantErr: true},
+		{name: "with encryption", req: port.RenderPreviewRequest{Image: &port.ImageOptions{}, Encryption: &port.PDFEncryption{UserPassword: "x"}}, wantErr: true},
+		{name: "with seal", req: port.RenderPreviewRequest{Image: &port.ImageOptions{}, SealWorkspaceID: "ws"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImage(&tt.req)
+			if tt.wantErr != errors.Is(err, entity.ErrInvalidImageRender) {
+				t.Fatalf("validateImage() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageParams_Defaults(t *testing.T) {
+	if page, ppi := imageParams(&port.ImageOptions{}); page != 1 || ppi != DefaultImagePPI {
+		t.Fatalf("expected first page at %d ppi, got page %d at %d ppi", DefaultImagePPI, page, ppi)
+	}
+	if page, ppi := imageParams(&port.ImageOptions{Page: 2, PPI: 150}); page != 2 || ppi != 150 {
+		t.Fatalf("expected page 2 at 150 ppi, got page %d at %d ppi", page, ppi)
+	}
+}
+
+func TestRenderCacheKey_CoversImageOptions(t *testing.T) {
+	req := &port.RenderPreviewRequest{VersionID: "v1", Document: staticDocument()}
+	keys := map[string]bool{}
+	for _, img := range []*port.ImageOptions{nil, {Page: 1}, {Page: 2}, {Page: 1, PPI: 150}} {
+		variant := *req
+		variant.Image = img
+		key, err := RenderCacheKey(&variant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[key] = true
+	}
+	if len(keys) != 4 {
+		t.Fatal("renders with different image options must not share a cache entry")
+	}
+}
+
+func TestRenderPreview_ImageRendersRequestedPage(t *testing.T) {
+	service, err := NewService(DefaultTypstOptions(), nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer service.Close()
+
+	paragraph := func(text string) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeText, Text: strPtr(text)},
+		}}
+	}
+	doc := &portabledoc.Document{
+		Version:    portabledoc.CurrentVersion,
+		Meta:       portabledoc.Meta{Title: "Thumbnail", Language: "en"},
+		PageConfig: portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123},
+		Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+			paragraph("First page"),
+			{Type: portabledoc.NodeTypePageBreak},
+			paragraph("Second page"),
+		}},
+	}
+	render := func(img *port.ImageOptions) (*port.RenderPreviewResult, error) {
+		return service.RenderPreview(context.Background(), &port.RenderPreviewRequest{Document: doc, Image: img})
+	}
+
+	// A4 is 8.27 x 11.69 in
+	tests := []struct {
+		name          string
+		img           port.ImageOptions
+		width, height int
+	}{
+		{name: "default first page", img: port.ImageOptions{}, width: 595, height: 842},
+		{name: "second page at 144 ppi", img: port.ImageOptions{Page: 2, PPI: 144}, width: 1191, height: 1684},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := render(&tt.img)
+			if err != nil {
+				t.Fatalf("RenderPreview failed: %v", err)
+			}
+			if len(result.PDF) != 0 {
+				t.Fatal("image renders must not return a PDF")
+			}
+			if result.Filename != "Thumbnail.png" {
+				t.Fatalf("unexpected filename %q", result.Filename)
+			}
+			cfg, err := png.DecodeConfig(bytes.NewReader(result.Image))
+			if err != nil {
+				t.Fatalf("result is not a PNG: %v", err)
+			}
+			if abs(cfg.Width-tt.width) > 2 || abs(cfg.Height-tt.height) > 2 {
+				t.Fatalf("expected ~%dx%d pixels, got %dx%d", tt.width, tt.height, cfg.Width, cfg.Height)
+			}
+		})
+	}
+
+	first, err := render(&port.ImageOptions{Page: 1})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+	second, err := render(&port.ImageOptions{Page: 2})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+	if bytes.Equal(first.Image, second.Image) {
+		t.Fatal("pages 1 and 2 must render different images")
+	}
+
+	if _, err := render(&port.ImageOptions{Page: 3}); !errors.Is(err, entity.ErrRenderPageNotFound) {
+		t.Fatalf("expected ErrRenderPageNotFound for a missing page, got %v", err)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,209 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// maxIncludeDepth bounds include chains that are acyclic but needlessly deep.
+const maxIncludeDepth = 8
+
+// IncludingRenderer inlines the published content of templates referenced by include
+// nodes (portabledoc.NodeTypeInclude) before rendering. A pinned include renders the
+// version it was pinned to on publish; any other renders the template's currently
+// published version. Included content shares the render's injectable values.
+// An included template must be in the rendered version's workspace or in the public
+// library of that workspace's tenant; anything else, or a template without a published
+// version, fails with entity.ErrIncludeNotFound. Cycles fail with entity.ErrIncludeCycle.
+type IncludingRenderer struct {
+	inner     port.PDFRenderer
+	templates port.TemplateRepository
+	versions  port.TemplateVersionRepository
+}
+
+// NewIncludingRenderer wraps a renderer with include expansion.
+func NewIncludingRenderer(inner port.PDFRenderer, templates port.TemplateRepository, versions port.TemplateVersionRepository) *IncludingRenderer {
+	return &IncludingRenderer{inner: inner, templates: templates, versions: versions}
+}
+
+// RenderPreview expands includes in the document, then renders it.
+func (r *IncludingRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	if req.Document == nil || req.Document.Content == nil || !req.Document.HasNodeOfType(portabledoc.NodeTypeInclude) {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	exp := &includeExpander{templates: r.templates, versions: r.versions, loaded: make(map[portabledoc.IncludeAttrs][]portabledoc.Node)}
+	var root []string
+	if req.VersionID != "" {
+		version, err := r.versions.FindByID(ctx, req.VersionID)
+		if err != nil {
+			return nil, fmt.Errorf("loading rendered version: %w", err)
+		}
+		tmpl, err := r.templates.FindByID(ctx, version.TemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("loading rendered template: %w", err)
+		}
+		exp.workspaceID = tmpl.WorkspaceID
+		root = []string{tmpl.ID}
+	}
+
+	content, err := exp.expand(ctx, req.Document.Content.Content, root)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := *req.Document
+	doc.Content = &portabledoc.ProseMirrorDoc{Type: req.Document.Content.Type, Content: content}
+	expanded := *req
+	expanded.Document = &doc
+	return r.inner.RenderPreview(ctx, &expanded)
+}
+
+// RenderPoolStats reports the wrapped renderer's pool, if it has one.
+func (r *IncludingRenderer) RenderPoolStats() port.RenderPoolStats {
+	if monitor, ok := r.inner.(port.RenderPoolMonitor); ok {
+		return monitor.RenderPoolStats()
+	}
+	return port.RenderPoolStats{}
+}
+
+// Close closes the wrapped renderer.
+func (r *IncludingRenderer) Close() error {
+	return r.inner.Close()
+}
+
+// includeExpander expands the includes of one render.
+type includeExpander struct {
+	templates   port.TemplateRepository
+	versions    port.TemplateVersionRepository
+	workspaceID string                                          // workspace of the rendered template; empty allows no includes
+	loaded      map[portabledoc.IncludeAttrs][]portabledoc.Node // expanded content, for includes repeated in the document
+}
+
+// expand returns nodes with every include replaced by the included content.
+// path holds the template IDs being expanded, outermost first. Input nodes are not modified.
+func (e *includeExpander) expand(ctx context.Context, nodes []portabledoc.Node, path []string) ([]portabledoc.Node, error) {
+	out := make([]portabledoc.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Type == portabledoc.NodeTypeInclude {
+			included, err := e.include(ctx, node, path)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, included...)
+			continue
+		}
+		if len(node.Content) > 0 {
+			content, err := e.expand(ctx, node.Content, path)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = content
+		}
+		out = append(out, node)
+	}
+	return out, nil
+}
+
+// include loads and expands the content referenced by an include node.
+func (e *includeExpander) include(ctx context.Context, node portabledoc.Node, path []string) ([]portabledoc.Node, error) {
+	attrs, err := portabledoc.ParseIncludeAttrs(node.Attrs)
+	if err != nil || attrs.TemplateID == "" {
+		return nil, fmt.Errorf("%w: include without a template", entity.ErrIncludeNotFound)
+	}
+	if slices.Contains(path, attrs.TemplateID) {
+		return nil, fmt.Errorf("%w: template %s includes itself", entity.ErrIncludeCycle, attrs.TemplateID)
+	}
+	if len(path) > maxIncludeDepth {
+		return nil, fmt.Errorf("%w: includes nested deeper than %d levels", entity.ErrDocumentTooLarge, maxIncludeDepth)
+	}
+	if content, ok := e.loaded[*attrs]; ok {
+		return content, nil
+	}
+
+	doc, err := e.load(ctx, attrs)
+	if err != nil {
+		return nil, err
+	}
+	var content []portabledoc.Node
+	if doc != nil && doc.Content != nil {
+		if content, err = e.expand(ctx, doc.Content.Content, append(slices.Clip(path), attrs.TemplateID)); err != nil {
+			return nil, err
+		}
+	}
+	e.loaded[*attrs] = content
+	return content, nil
+}
+
+// load returns the document an include renders, provided its template is visible to the render.
+func (e *includeExpander) load(ctx context.Context, attrs *portabledoc.IncludeAttrs) (*portabledoc.Document, error) {
+	templateID := attrs.TemplateID
+	tmpl, err := e.templates.FindByID(ctx, templateID)
+	if errors.Is(err, entity.ErrTemplateNotFound) {
+		return nil, fmt.Errorf("%w: template %s", entity.ErrIncludeNotFound, templateID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading included template %s: %w", templateID, err)
+	}
+	if e.workspaceID == "" || tmpl.WorkspaceID != e.workspaceID {
+		if err := e.checkPublicLibrary(ctx, tmpl); err != nil {
+			return nil, err
+		}
+	}
+
+	version, err := e.version(ctx, attrs)
+	if errors.Is(err, entity.ErrNoPublishedVersion) || errors.Is(err, entity.ErrVersionNotFound) {
+		return nil, fmt.Errorf("%w: template %s: %w", entity.ErrIncludeNotFound, templateID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading included version of template %s: %w", templateID, err)
+	}
+
+	doc, err := portabledoc.Parse(version.ContentStructure)
+	if err != nil {
+		return nil, fmt.Errorf("parsing included template %s: %w", templateID, err)
+	}
+	return doc, nil
+}
+
+// checkPublicLibrary fails with entity.ErrIncludeNotFound unless tmpl is in the public
+// library of the rendered workspace's tenant.
+func (e *includeExpander) checkPublicLibrary(ctx context.Context, tmpl *entity.Template) error {
+	if !tmpl.IsPublicLibrary || e.workspaceID == "" {
+		return fmt.Errorf("%w: template %s", entity.ErrIncludeNotFound, tmpl.ID)
+	}
+	visible, err := e.templates.ExistsInPublicLibrary(ctx, e.workspaceID, tmpl.ID)
+	if err != nil {
+		return fmt.Errorf("checking public library for template %s: %w", tmpl.ID, err)
+	}
+	if !visible {
+		return fmt.Errorf("%w: template %s", entity.ErrIncludeNotFound, tmpl.ID)
+	}
+	return nil
+}
+
+// version returns the pinned version of an include, or its template's published version.
+func (e *includeExpander) version(ctx context.Context, attrs *portabledoc.IncludeAttrs) (*entity.TemplateVersion, error) {
+	if !attrs.Pinned() {
+		return e.versions.FindPublishedByTemplateID(ctx, attrs.TemplateID)
+	}
+	version, err := e.versions.FindByID(ctx, attrs.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	if version.TemplateID != attrs.TemplateID {
+		return nil, entity.ErrVersionNotFound
+	}
+	return version, nil
+}
+
+var (
+	_ port.PDFRenderer       = (*IncludingRenderer)(nil)
+	_ port.RenderPoolMonitor = (*IncludingRenderer)(nil)
+)
@@ -0,0 +1,182 @@
+//go:build integration
+
+package pdfrenderer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	templaterepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateversionrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_repo"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	"github.com/rendis/doc-assembly/core/internal/core/service/rendering/pdfrenderer"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// recordingRenderer keeps the document it was asked to render.
+type recordingRenderer struct {
+	doc *portabledoc.Document
+}
+
+func (r *recordingRenderer) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	r.doc = req.Document
+	return &port.RenderPreviewResult{PDF: []byte("%PDF")}, nil
+}
+
+func (r *recordingRenderer) Close() error { return nil }
+
+// includeContent builds a version content structure holding the given top-level nodes.
+func includeContent(nodes ...string) []byte {
+	body := ""
+	for i, n := range nodes {
+		if i > 0 {
+			body += ","
+		}
+		body += n
+	}
+	return fmt.Appendf(nil, `{"version":"1.1.0","meta":{"title":"Doc","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":[],"signerRoles":[],"content":{"type":"doc","content":[%s]}}`, body)
+}
+
+func paragraphJSON(text string) string {
+	return fmt.Sprintf(`{"type":"paragraph","content":[{"type":"text","text":%q}]}`, text)
+}
+
+func includeJSON(templateID string) string {
+	return fmt.Sprintf(`{"type":"include","attrs":{"templateId":%q}}`, templateID)
+}
+
+func pinnedIncludeJSON(templateID, versionID string, latest bool) string {
+	return fmt.Sprintf(`{"type":"include","attrs":{"templateId":%q,"versionId":%q,"latest":%t}}`, templateID, versionID, latest)
+}
+
+type includeFixture struct {
+	t           *testing.T
+	workspaceID string
+	renderer    *pdfrenderer.IncludingRenderer
+	inner       *recordingRenderer
+}
+
+func newIncludeFixture(t *testing.T, code string) *includeFixture {
+	pool := testhelper.GetTestPool(t)
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Include Tenant", code)
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Include Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+
+	inner := &recordingRenderer{}
+	return &includeFixture{
+		t:           t,
+		workspaceID: workspaceID,
+		renderer:    pdfrenderer.NewIncludingRenderer(inner, templaterepo.New(pool), templateversionrepo.New(pool)),
+		inner:       inner,
+	}
+}
+
+// template creates a template with a published version holding content, returning both IDs.
+func (f *includeFixture) template(title string, content []byte) (templateID, versionID string) {
+	pool := testhelper.GetTestPool(f.t)
+	templateID = testhelper.CreateTestTemplate(f.t, pool, f.workspaceID, title, nil)
+	f.t.Cleanup(func() { testhelper.CleanupTemplate(f.t, pool, templateID) })
+	versionID = testhelper.CreateTestTemplateVersion(f.t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(f.t, pool, versionID, content)
+	testhelper.PublishTestVersion(f.t, pool, versionID)
+	return templateID, versionID
+}
+
+// renderedTexts returns the first text of each top-level block of the last render.
+func (f *includeFixture) renderedTexts() []string {
+	require.NotNil(f.t, f.inner.doc)
+	var texts []string
+	for _, node := range f.inner.doc.Content.Content {
+		require.NotEqual(f.t, portabledoc.NodeTypeInclude, node.Type, "includes must be expanded before rendering")
+		texts = append(texts, *node.Content[0].Text)
+	}
+	return texts
+}
+
+func (f *includeFixture) render(versionID string, content []byte) error {
+	_, err := f.renderer.RenderPreview(context.Background(), &port.RenderPreviewRequest{
+		VersionID: versionID,
+		Document:  portabledoc.MustParse(content),
+	})
+	return err
+}
+
+func TestIncludingRenderer_InlinesSharedClause(t *testing.T) {
+	f := newIncludeFixture(t, "INCL01")
+	clauseID, _ := f.template("Confidentiality clause", includeContent(paragraphJSON("Both parties keep this confidential.")))
+
+	content := includeContent(paragraphJSON("Agreement"), includeJSON(clauseID), paragraphJSON("Signatures"))
+	_, versionID := f.template("Contract", content)
+
+	require.NoError(t, f.render(versionID, content))
+
+	assert.Equal(t, []string{"Agreement", "Both parties keep this confidential.", "Signatures"}, f.renderedTexts())
+}
+
+func TestIncludingRenderer_RendersPinnedVersionAfterUpdate(t *testing.T) {
+	f := newIncludeFixture(t, "INCL04")
+	pool := testhelper.GetTestPool(t)
+
+	clauseID, oldClauseVersionID := f.template("Liability clause", includeContent(paragraphJSON("Liability is capped.")))
+
+	// The clause is revised after the contract pinned its first version.
+	newClauseVersionID := testhelper.CreateTestTemplateVersion(t, pool, clauseID, 2, "v2", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, newClauseVersionID, includeContent(paragraphJSON("Liability is unlimited.")))
+	_, err := pool.Exec(context.Background(), `UPDATE content.template_versions SET status = 'ARCHIVED' WHERE id = $1`, oldClauseVersionID)
+	require.NoError(t, err)
+	testhelper.PublishTestVersion(t, pool, newClauseVersionID)
+
+	t.Run("pinned", func(t *testing.T) {
+		content := includeContent(pinnedIncludeJSON(clauseID, oldClauseVersionID, false))
+		_, versionID := f.template("Contract pinned", content)
+		require.NoError(t, f.render(versionID, content))
+		assert.Equal(t, []string{"Liability is capped."}, f.renderedTexts())
+	})
+
+	t.Run("always latest", func(t *testing.T) {
+		content := includeContent(pinnedIncludeJSON(clauseID, oldClauseVersionID, true))
+		_, versionID := f.template("Contract latest", content)
+		require.NoError(t, f.render(versionID, content))
+		assert.Equal(t, []string{"Liability is unlimited."}, f.renderedTexts())
+	})
+}
+
+func TestIncludingRenderer_RejectsCycle(t *testing.T) {
+	f := newIncludeFixture(t, "INCL02")
+	pool := testhelper.GetTestPool(t)
+
+	aID, aVersionID := f.template("Clause A", includeContent(paragraphJSON("A")))
+	bID, _ := f.template("Clause B", includeContent(includeJSON(aID)))
+	aContent := includeContent(includeJSON(bID))
+	testhelper.SetTestVersionContent(t, pool, aVersionID, aContent)
+
+	err := f.render(aVersionID, aContent)
+
+	require.ErrorIs(t, err, entity.ErrIncludeCycle)
+	assert.Nil(t, f.inner.doc, "a cyclic document must not be rendered")
+}
+
+func TestIncludingRenderer_RejectsMissingReference(t *testing.T) {
+	f := newIncludeFixture(t, "INCL03")
+	pool := testhelper.GetTestPool(t)
+
+	draftID := testhelper.CreateTestTemplate(t, pool, f.workspaceID, "Unpublished clause", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, draftID) })
+	testhelper.CreateTestTemplateVersion(t, pool, draftID, 1, "v1", entity.VersionStatusDraft)
+
+	for name, templateID := range map[string]string{"unpublished": draftID, "unknown": "00000000-0000-0000-0000-000000000000"} {
+		t.Run(name, func(t *testing.T) {
+			content := includeContent(includeJSON(templateID))
+			_, versionID := f.template("Contract "+name, content)
+			require.ErrorIs(t, f.render(versionID, content), entity.ErrIncludeNotFound)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package pdfrenderer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// includeTemplates and includeVersions serve templates and their published content
+// from memory. Only the lookups IncludingRenderer uses are implemented.
+type includeTemplates struct {
+	port.TemplateRepository
+	templates map[string]*entity.Template
+	tenants   map[string]string // workspace ID -> tenant ID
+}
+
+func (r *includeTemplates) FindByID(_ context.Context, id string) (*entity.Template, error) {
+	if tmpl, ok := r.templates[id]; ok {
+		return tmpl, nil
+	}
+	return nil, entity.ErrTemplateNotFound
+}
+
+func (r *includeTemplates) ExistsInPublicLibrary(_ context.Context, workspaceID, templateID string) (bool, error) {
+	tmpl, ok := r.templates[templateID]
+	return ok && tmpl.IsPublicLibrary && r.tenants[tmpl.WorkspaceID] == r.tenants[workspaceID], nil
+}
+
+type includeVersions struct {
+	port.TemplateVersionRepository
+	rootTemplate string
+	published    map[string][]portabledoc.Node      // template ID -> published content
+	archived     map[string]*entity.TemplateVersion // version ID -> earlier version
+}
+
+// FindByID resolves the rendered version to the root template, and archived versions by ID.
+func (r *includeVersions) FindByID(_ context.Context, id string) (*entity.TemplateVersion, error) {
+	if id == renderedVersionID {
+		return &entity.TemplateVersion{ID: id, TemplateID: r.rootTemplate}, nil
+	}
+	if version, ok := r.archived[id]; ok {
+		return version, nil
+	}
+	return nil, entity.ErrVersionNotFound
+}
+
+func (r *includeVersions) FindPublishedByTemplateID(_ context.Context, templateID string) (*entity.TemplateVersion, error) {
+	nodes, ok := r.published[templateID]
+	if !ok {
+		return nil, entity.ErrNoPublishedVersion
+	}
+	return versionWithContent(templateID, nodes), nil
+}
+
+func versionWithContent(templateID string, nodes []portabledoc.Node) *entity.TemplateVersion {
+	content, _ := json.Marshal(portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes}})
+	return &entity.TemplateVersion{TemplateID: templateID, ContentStructure: content}
+}
+
+type capturingRenderer struct{ doc *portabledoc.Document }
+
+func (r *capturingRenderer) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	r.doc = req.Document
+	return &port.RenderPreviewResult{}, nil
+}
+
+func (r *capturingRenderer) Close() error { return nil }
+
+const renderedVersionID = "v1"
+
+func includeNode(templateID string) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeInclude, Attrs: map[string]any{"templateId": templateID}}
+}
+
+func pinnedIncludeNode(templateID, versionID string, latest bool) portabledoc.Node {
+	node := includeNode(templateID)
+	node.Attrs[portabledoc.AttrIncludeVersionID] = versionID
+	node.Attrs[portabledoc.AttrIncludeLatest] = latest
+	return node
+}
+
+func newIncludeTest(root string) (*includeVersions, *capturingRenderer, *IncludingRenderer) {
+	templates := &includeTemplates{
+		templates: map[string]*entity.Template{
+			"contract": {ID: "contract", WorkspaceID: "ws-1"},
+			"clause":   {ID: "clause", WorkspaceID: "ws-1"},
+			"nested":   {ID: "nested", WorkspaceID: "ws-1"},
+			"library":  {ID: "library", WorkspaceID: "ws-lib", IsPublicLibrary: true},
+			"foreign":  {ID: "foreign", WorkspaceID: "ws-2"},
+			"partner":  {ID: "partner", WorkspaceID: "ws-other", IsPublicLibrary: true},
+		},
+		tenants: map[string]string{"ws-1": "tenant-1", "ws-lib": "tenant-1", "ws-2": "tenant-1", "ws-other": "tenant-2"},
+	}
+	versions := &includeVersions{
+		rootTemplate: root,
+		published: map[string][]portabledoc.Node{
+			"clause":  {paragraphNode(textNode("Clause"))},
+			"nested":  {paragraphNode(textNode("Before")), includeNode("clause")},
+			"library": {paragraphNode(textNode("Library"))},
+			"foreign": {paragraphNode(textNode("Foreign"))},
+			"partner": {paragraphNode(textNode("Partner"))},
+		},
+		archived: map[string]*entity.TemplateVersion{
+			"clause-v1": versionWithContent("clause", []portabledoc.Node{paragraphNode(textNode("Old clause"))}),
+		},
+	}
+	inner := &capturingRenderer{}
+	return versions, inner, NewIncludingRenderer(inner, templates, versions)
+}
+
+func renderWithIncludes(r *IncludingRenderer, nodes ...portabledoc.Node) error {
+	_, err := r.RenderPreview(context.Background(), &port.RenderPreviewRequest{
+		VersionID: renderedVersionID,
+		Document:  &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes}},
+	})
+	return err
+}
+
+func paragraphTexts(nodes []portabledoc.Node) []string {
+	texts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		texts = append(texts, *n.Content[0].Text)
+	}
+	return texts
+}
+
+func TestIncludingRenderer_ExpandsNestedAndLibraryIncludes(t *testing.T) {
+	_, inner, r := newIncludeTest("contract")
+	original := []portabledoc.Node{includeNode("nested"), includeNode("library")}
+
+	if err := renderWithIncludes(r, original...); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	got := paragraphTexts(inner.doc.Content.Content)
+	want := []string{"Before", "Clause", "Library"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("expanded content = %v, want %v", got, want)
+	}
+	if original[0].Type != portabledoc.NodeTypeInclude {
+		t.Error("the request document must not be modified")
+	}
+}
+
+func TestIncludingRenderer_RendersPinnedVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		node portabledoc.Node
+		want string
+	}{
+		{"pinned", pinnedIncludeNode("clause", "clause-v1", false), "Old clause"},
+		{"always latest", pinnedIncludeNode("clause", "clause-v1", true), "Clause"},
+		{"not pinned", includeNode("clause"), "Clause"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, inner, r := newIncludeTest("contract")
+			if err := renderWithIncludes(r, tt.node); err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			if got := paragraphTexts(inner.doc.Content.Content); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("expanded content = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludingRenderer_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		setup   func(*includeVersions)
+		node    portabledoc.Node
+		wantErr error
+	}{
+		{"self include", "clause", nil, includeNode("clause"), entity.ErrIncludeCycle},
+		{"indirect cycle", "contract", func(r *includeVersions) {
+			r.published["clause"] = []portabledoc.Node{includeNode("nested")}
+		}, includeNode("nested"), entity.ErrIncludeCycle},
+		{"unknown template", "contract", nil, includeNode("missing"), entity.ErrIncludeNotFound},
+		{"unpublished template", "contract", func(r *includeVersions) { delete(r.published, "clause") }, includeNode("clause"), entity.ErrIncludeNotFound},
+		{"other workspace", "contract", nil, includeNode("foreign"), entity.ErrIncludeNotFound},
+		{"public library of another tenant", "contract", nil, includeNode("partner"), entity.ErrIncludeNotFound},
+		{"no template id", "contract", nil, portabledoc.Node{Type: portabledoc.NodeTypeInclude}, entity.ErrIncludeNotFound},
+		{"unknown pinned version", "contract", nil, pinnedIncludeNode("clause", "clause-v0", false), entity.ErrIncludeNotFound},
+		{"version of another template", "contract", nil, pinnedIncludeNode("nested", "clause-v1", false), entity.ErrIncludeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, inner, r := newIncludeTest(tt.root)
+			if tt.setup != nil {
+				tt.setup(versions)
+			}
+			err := renderWithIncludes(r, tt.node)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if inner.doc != nil {
+				t.Error("a failed expansion must not render")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package pdfrenderer
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Injector transforms are set in an injector's "transform" attr and applied to the
+// resolved value, in order, before the prefix and suffix are added. Several
+// transforms are chained with "|", e.g. "trim|truncate:40|upper".
+const (
+	TransformUpper    = "upper"    // "Ana María" -> "ANA MARÍA"
+	TransformLower    = "lower"    // "ANA" -> "ana"
+	TransformTitle    = "title"    // "ana maría" -> "Ana María"
+	TransformTrim     = "trim"     // Leading and trailing whitespace removed
+	TransformTruncate = "truncate" // "truncate:N" keeps N characters, ending in "…" when cut
+
+	transformSeparator = "|"
+	truncateEllipsis   = "…"
+)
+
+// transformInjectorValue applies the transforms in spec to value. Case transforms
+// follow the document language. Unknown transforms and malformed arguments are
+// skipped, so a typo never hides the value.
+func (c *typstConverter) transformInjectorValue(value, spec string) string {
+	if spec == "" {
+		return value
+	}
+	lang, err := language.Parse(c.labelLang(""))
+	if err != nil {
+		lang = language.Und
+	}
+	for _, step := range strings.Split(spec, transformSeparator) {
+		name, arg, _ := strings.Cut(strings.TrimSpace(step), ":")
+		switch name {
+		case TransformUpper:
+			value = cases.Upper(lang).String(value)
+		case TransformLower:
+			value = cases.Lower(lang).String(value)
+		case TransformTitle:
+			value = cases.Title(lang).String(value)
+		case TransformTrim:
+			value = strings.TrimSpace(value)
+		case TransformTruncate:
+			if n, err := strconv.Atoi(strings.TrimSpace(arg)); err == nil && n > 0 {
+				value = truncateRunes(value, n)
+			}
+		}
+	}
+	return value
+}
+
+// truncateRunes shortens s to at most n characters, the last being an ellipsis when cut.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimRight(string(runes[:n-1]), " ") + truncateEllipsis
+}
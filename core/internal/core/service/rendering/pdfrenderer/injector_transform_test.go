@@ -0,0 +1,65 @@
+package pdfrenderer
+
+import (
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+func transformedInjector(variableID, transform string) portabledoc.Node {
+	node := injectorNode(variableID)
+	node.Attrs["transform"] = transform
+	return node
+}
+
+func TestTypstConverter_InjectorTransforms(t *testing.T) {
+	c := newTestConverter(map[string]any{
+		"name":  "ana maría lópez",
+		"code":  "AbC-12",
+		"notes": "  signed in person  ",
+		"terms": "The customer agrees to pay all invoices within thirty days",
+	}, nil)
+
+	tests := []struct {
+		name      string
+		variable  string
+		transform string
+		want      string
+	}{
+		{"upper", "name", "upper", "ANA MARÍA LÓPEZ"},
+		{"lower", "code", "lower", "abc-12"},
+		{"title", "name", "title", "Ana María López"},
+		{"trim", "notes", "trim", "signed in person"},
+		{"truncate", "terms", "truncate:20", "The customer agrees…"},
+		{"truncate shorter value", "code", "truncate:40", "AbC-12"},
+		{"chained", "notes", "trim|upper", "SIGNED IN PERSON"},
+		{"chained with truncate", "terms", "truncate:12 | upper", "THE CUSTOME…"},
+		{"unknown is a no-op", "code", "reverse", "AbC-12"},
+		{"malformed truncate is a no-op", "code", "truncate:x", "AbC-12"},
+		{"no transform", "code", "", "AbC-12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.convertNode(transformedInjector(tt.variable, tt.transform)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypstConverter_InjectorTransformBeforePrefix(t *testing.T) {
+	c := newTestConverter(map[string]any{"city": "santiago"}, nil)
+	node := transformedInjector("city", "upper")
+	node.Attrs["prefix"] = "City: "
+
+	if got := c.convertNode(node); got != "City: SANTIAGO" {
+		t.Errorf("transform must not touch the prefix, got %q", got)
+	}
+}
+
+func TestTypstConverter_InjectorTransformAppliesToDefaults(t *testing.T) {
+	c := newTestConverter(nil, map[string]string{"city": "santiago"})
+	if got := c.convertNode(transformedInjector("city", "title")); got != "Santiago" {
+		t.Errorf("got %q, want %q", got, "Santiago")
+	}
+}
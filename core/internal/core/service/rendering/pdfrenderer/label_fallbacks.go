@@ -0,0 +1,123 @@
+package pdfrenderer
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultLabelLanguage is the last language tried before any remaining label.
+const defaultLabelLanguage = "en"
+
+// labelFallbackChain returns the ordered list of language tags tried when looking
+// up an i18n label for lang. Regional tags fall back to their base language
+// ("pt-BR" -> "pt-br", "pt"), then to the configured fallbacks for the tag or its
+// base language, and finally to English. Tags are lowercased with "_" as "-".
+func labelFallbackChain(lang string, fallbacks map[string][]string) []string {
+	chain := make([]string, 0, 4)
+	add := func(tag string) {
+		if tag != "" && !slices.Contains(chain, tag) {
+			chain = append(chain, tag)
+		}
+	}
+
+	tag := normalizeLangTag(lang)
+	base := baseLanguage(tag)
+	add(tag)
+	add(base)
+
+	configured, ok := lookupLangFallbacks(fallbacks, tag)
+	if !ok {
+		configured, _ = lookupLangFallbacks(fallbacks, base)
+	}
+	for _, fb := range configured {
+		fbTag := normalizeLangTag(fb)
+		add(fbTag)
+		add(baseLanguage(fbTag))
+	}
+
+	add(defaultLabelLanguage)
+	return chain
+}
+
+// lookupLangFallbacks finds the configured fallbacks for a normalized tag,
+// tolerating differently cased or underscored configuration keys.
+func lookupLangFallbacks(fallbacks map[string][]string, tag string) ([]string, bool) {
+	if tag == "" {
+		return nil, false
+	}
+	if fb, ok := fallbacks[tag]; ok {
+		return fb, true
+	}
+	for key, fb := range fallbacks {
+		if normalizeLangTag(key) == tag {
+			return fb, true
+		}
+	}
+	return nil, false
+}
+
+// localizedLabel picks the label for lang from an i18n label map following the
+// converter's fallback chain. For each language in the chain, a regional variant
+// matches when the exact tag is missing ("pt" matches "pt-BR"). If no chain
+// language matches, the label with the alphabetically first language key is used
+// so output does not depend on map iteration order. Empty labels are ignored.
+func (c *typstConverter) localizedLabel(labels map[string]string, lang string) (string, bool) {
+	if len(labels) == 0 {
+		return "", false
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	byTag := make(map[string]string, len(labels))
+	tags := make([]string, 0, len(labels))
+	for _, key := range keys {
+		tag := normalizeLangTag(key)
+		if _, dup := byTag[tag]; dup || labels[key] == "" {
+			continue
+		}
+		byTag[tag] = labels[key]
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return "", false
+	}
+
+	for _, tag := range labelFallbackChain(c.labelLang(lang), c.tokens.LabelFallbacks) {
+		if label, ok := byTag[tag]; ok {
+			return label, true
+		}
+		for _, candidate := range tags {
+			if baseLanguage(candidate) == tag {
+				return byTag[candidate], true
+			}
+		}
+	}
+	return byTag[tags[0]], true
+}
+
+// labelLang returns the language used for label lookups: the node's own language
+// when set, otherwise the document language.
+func (c *typstConverter) labelLang(nodeLang string) string {
+	if nodeLang != "" {
+		return nodeLang
+	}
+	if c.lang != "" {
+		return c.lang
+	}
+	return defaultLabelLanguage
+}
+
+func normalizeLangTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+func baseLanguage(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i > 0 {
+		return tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,122 @@
+package pdfrenderer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+func TestLabelFallbackChain(t *testing.T) {
+	fallbacks := map[string][]string{"pt": {"es", "en"}, "FR_ca": {"fr", "en"}}
+
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"pt", []string{"pt", "es", "en"}},
+		{"pt-BR", []string{"pt-br", "pt", "es", "en"}},
+		{"pt_BR", []string{"pt-br", "pt", "es", "en"}},
+		{"fr-CA", []string{"fr-ca", "fr", "en"}},
+		{"de", []string{"de", "en"}},
+		{"", []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got := labelFallbackChain(tt.lang, fallbacks)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labelFallbackChain(%q) = %v, want %v", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizedLabel_PortugueseFirstChain(t *testing.T) {
+	conv := newTestConverter(nil, nil)
+	labels := map[string]string{"en": "Product", "es": "Producto", "de": "Produkt"}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		lang   string
+		want   string
+	}{
+		{"exact language wins", map[string]string{"pt": "Produto", "es": "Producto", "en": "Product"}, "pt", "Produto"},
+		{"pt falls back to es before en", labels, "pt", "Producto"},
+		{"regional tag falls back through base language", labels, "pt-BR", "Producto"},
+		{"base language matches regional label", map[string]string{"pt-BR": "Produto", "en": "Product"}, "pt", "Produto"},
+		{"unconfigured language falls back to en", labels, "it", "Product"},
+		{"empty labels are skipped", map[string]string{"pt": "", "es": "Producto"}, "pt", "Producto"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := conv.localizedLabel(tt.labels, tt.lang)
+			if !ok || got != tt.want {
+				t.Errorf("localizedLabel(%v, %q) = %q, %v; want %q", tt.labels, tt.lang, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizedLabel_DeterministicFallback(t *testing.T) {
+	conv := newTestConverter(nil, nil)
+	labels := map[string]string{"ja": "製品", "de": "Produkt", "zh": "产品", "fr": "Produit", "it": "Prodotto"}
+
+	for i := 0; i < 50; i++ {
+		got, ok := conv.localizedLabel(labels, "pt")
+		if !ok || got != "Produkt" {
+			t.Fatalf("iteration %d: got %q, want alphabetically first language (de) label %q", i, got, "Produkt")
+		}
+	}
+}
+
+func TestLocalizedLabel_UsesDocumentLanguage(t *testing.T) {
+	conv := newTestConverter(nil, nil)
+	conv.SetLanguage("pt")
+	labels := map[string]string{"en": "Name", "es": "Nombre"}
+
+	if got := conv.getListHeaderLabel(labels, ""); got != "Nombre" {
+		t.Errorf("document language should drive lookup, got %q", got)
+	}
+	if got := conv.getListHeaderLabel(labels, "en"); got != "Name" {
+		t.Errorf("node language should override document language, got %q", got)
+	}
+}
+
+func TestLocalizedLabel_ConfigurableChain(t *testing.T) {
+	tokens := DefaultDesignTokens()
+	tokens.LabelFallbacks = map[string][]string{"pt": {"fr"}}
+	conv := NewTypstConverterFactory(tokens)(map[string]any{}, map[string]string{}, nil, nil, nil).(*typstConverter)
+
+	got := conv.getColumnLabel(entity.TableColumn{
+		Key:    "qty",
+		Labels: map[string]string{"en": "Quantity", "es": "Cantidad", "fr": "Quantité"},
+	}, "pt")
+	if got != "Quantité" {
+		t.Errorf("configured chain not applied, got %q", got)
+	}
+}
+
+func TestTableInjector_ColumnLabelsFollowFallbackChain(t *testing.T) {
+	table := entity.NewTableValue().
+		AddColumn("qty", map[string]string{"en": "Quantity", "es": "Cantidad"}, entity.ValueTypeNumber).
+		AddColumn("sku", map[string]string{}, entity.ValueTypeString)
+	conv := newTestConverter(map[string]any{"items": table}, nil)
+	conv.SetLanguage("pt-BR")
+
+	out := conv.tableInjector(portabledoc.Node{
+		Type:  portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{"variableId": "items"},
+	})
+
+	if !strings.Contains(out, "Cantidad") || strings.Contains(out, "Quantity") {
+		t.Errorf("expected Spanish fallback header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "sku") {
+		t.Errorf("expected column key when no labels exist, got:\n%s", out)
+	}
+}
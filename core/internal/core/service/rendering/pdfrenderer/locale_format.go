@@ -0,0 +1,103 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/formatter"
+)
+
+// Signature labels and boolean words for documents whose language has no configured locale.
+const (
+	unconfiguredSignatureLabel = "Firma"
+	unconfiguredDateLabel      = "Fecha"
+	unconfiguredBoolean        = "Sí/No"
+)
+
+// locale returns the formatting defaults for the document language. The locale is
+// picked along the label fallback chain ("pt-BR" -> "pt" -> "es" -> "en"); fields
+// it leaves empty are filled from the English defaults.
+func (c *typstConverter) locale() LocaleFormat {
+	if c.localeFormat != nil {
+		return *c.localeFormat
+	}
+
+	resolved := DefaultLocales()[defaultLabelLanguage]
+	matched := ""
+	for _, tag := range labelFallbackChain(c.labelLang(""), c.tokens.LabelFallbacks) {
+		if lf, ok := lookupLocale(c.tokens.Locales, tag); ok {
+			resolved = mergeLocaleFormat(lf, resolved)
+			matched = tag
+			break
+		}
+	}
+	// Signature lines read "Firma" and booleans "Sí"/"No" before locales existed; a
+	// document whose language has no configured locale keeps those words instead of
+	// the English ones.
+	if (matched == "" || matched == defaultLabelLanguage) && baseLanguage(normalizeLangTag(c.lang)) != defaultLabelLanguage {
+		resolved.SignatureLabel, resolved.DateLabel = unconfiguredSignatureLabel, unconfiguredDateLabel
+		resolved.Boolean = unconfiguredBoolean
+	}
+
+	c.localeFormat = &resolved
+	return resolved
+}
+
+// lookupLocale finds the locale for a normalized tag, tolerating differently
+// cased or underscored configuration keys.
+func lookupLocale(locales map[string]LocaleFormat, tag string) (LocaleFormat, bool) {
+	if lf, ok := locales[tag]; ok {
+		return lf, true
+	}
+	for key, lf := range locales {
+		if normalizeLangTag(key) == tag {
+			return lf, true
+		}
+	}
+	return LocaleFormat{}, false
+}
+
+// mergeLocaleFormat fills empty fields of lf from base.
+func mergeLocaleFormat(lf, base LocaleFormat) LocaleFormat {
+	if lf.Boolean == "" {
+		lf.Boolean = base.Boolean
+	}
+	if lf.DecimalSeparator == "" {
+		lf.DecimalSeparator = base.DecimalSeparator
+	}
+	if lf.ThousandsSeparator == "" {
+		lf.ThousandsSeparator = base.ThousandsSeparator
+	}
+	if lf.DateFormat == "" {
+		lf.DateFormat = base.DateFormat
+	}
+	if lf.SignatureLabel == "" {
+		lf.SignatureLabel = base.SignatureLabel
+	}
+	if lf.DateLabel == "" {
+		lf.DateLabel = base.DateLabel
+	}
+	return lf
+}
+
+// formatBool returns the localized word for a boolean value.
+func (lf LocaleFormat) formatBool(v bool) string {
+	return formatter.FormatBool(v, lf.Boolean)
+}
+
+// localizeDecimal swaps the "." decimal point of a formatted number for the
+// locale's decimal separator.
+func (lf LocaleFormat) localizeDecimal(s string) string {
+	if lf.DecimalSeparator == "" || lf.DecimalSeparator == "." {
+		return s
+	}
+	return strings.Replace(s, ".", lf.DecimalSeparator, 1)
+}
+
+// formatDate formats a date with the locale's default pattern.
+func (lf LocaleFormat) formatDate(t time.Time) string {
+	if lf.DateFormat == "" {
+		return t.Format("2006-01-02")
+	}
+	return formatter.FormatTime(t, lf.DateFormat)
+}
@@ -0,0 +1,156 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+func TestLocaleFormat_SwitchingLanguageChangesDefaults(t *testing.T) {
+	tests := []struct {
+		lang     string
+		wantBool string
+		wantNum  string
+		wantCur  string
+	}{
+		{"en", "Yes", "1234.5", "$ 99.50"},
+		{"es", "Sí", "1234,5", "$ 99,50"},
+		{"es-CL", "Sí", "1234,5", "$ 99,50"},
+		{"", "Sí", "1234.5", "$ 99.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			c := newTestConverter(map[string]any{"active": true, "ratio": 1234.5, "price": 99.5}, nil)
+			c.SetLanguage(tt.lang)
+
+			if got := c.convertNode(injectorNode("active")); !strings.Contains(got, tt.wantBool) {
+				t.Errorf("boolean: got %q, want %q", got, tt.wantBool)
+			}
+			if got := c.convertNode(injectorNode("ratio")); !strings.Contains(got, tt.wantNum) {
+				t.Errorf("number: got %q, want %q", got, tt.wantNum)
+			}
+			currency := portabledoc.Node{
+				Type:  portabledoc.NodeTypeInjector,
+				Attrs: map[string]any{"variableId": "price", "type": portabledoc.InjectorTypeCurrency, "format": "$"},
+			}
+			if got := c.convertNode(currency); !strings.Contains(got, tt.wantCur) {
+				t.Errorf("currency: got %q, want %q", got, tt.wantCur)
+			}
+		})
+	}
+}
+
+func TestLocaleFormat_SettingDesignTokensResetsLocale(t *testing.T) {
+	c := newTestConverter(map[string]any{"ratio": 1234.5}, nil)
+	c.SetLanguage("en")
+	if got := c.convertNode(injectorNode("ratio")); !strings.Contains(got, "1234.5") {
+		t.Fatalf("expected the default English format, got %q", got)
+	}
+
+	tokens := DefaultDesignTokens()
+	tokens.Locales = map[string]LocaleFormat{"en": {DecimalSeparator: ","}}
+	c.SetDesignTokens(tokens)
+
+	if got := c.convertNode(injectorNode("ratio")); !strings.Contains(got, "1234,5") {
+		t.Fatalf("expected the new tokens' locale after formatting once, got %q", got)
+	}
+}
+
+func TestLocaleFormat_CellDefaults(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	date := entity.TimeValue(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC))
+	no := entity.BoolValue(false)
+	amount := entity.NumberValue(10.25)
+
+	c.SetLanguage("en")
+	if got := c.formatCellValue(&no, ""); got != "No" {
+		t.Errorf("en bool cell = %q", got)
+	}
+	if got := c.formatCellValue(&date, ""); got != "2026-03-09" {
+		t.Errorf("en date cell = %q", got)
+	}
+
+	c.SetLanguage("es")
+	if got := c.formatCellValue(&amount, ""); got != "10,25" {
+		t.Errorf("es number cell = %q", got)
+	}
+	if got := c.formatCellValue(&date, ""); got != "09/03/2026" {
+		t.Errorf("es date cell = %q", got)
+	}
+	if got := c.formatCellValue(&date, "2006"); got != "2026" {
+		t.Errorf("explicit format must win over locale default, got %q", got)
+	}
+}
+
+func TestLocaleFormat_ConfiguredLocaleAndFallback(t *testing.T) {
+	tokens := DefaultDesignTokens()
+	tokens.Locales["pt"] = LocaleFormat{Boolean: "Sim/Não"}
+	c := NewTypstConverterFactory(tokens)(map[string]any{"active": false, "n": 2.5}, map[string]string{}, nil, nil, nil).(*typstConverter)
+	c.SetLanguage("pt-BR")
+
+	if got := c.convertNode(injectorNode("active")); !strings.Contains(got, "Não") {
+		t.Errorf("expected configured pt boolean words, got %q", got)
+	}
+	if got := c.convertNode(injectorNode("n")); !strings.Contains(got, "2.5") {
+		t.Errorf("unset fields should fall back to English defaults, got %q", got)
+	}
+}
+
+func TestLocaleFormat_DefaultBoolean(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"", "Sí"},
+		{"fr", "Sí"},
+		{"es", "Sí"},
+		{"en", "Yes"},
+		{"en-GB", "Yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			c := newTestConverter(map[string]any{"active": true}, nil)
+			c.SetLanguage(tt.lang)
+			if got := c.convertNode(injectorNode("active")); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFormat_DefaultSignatureLabel(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"en", "Signature"},
+		{"es", "Firma"},
+		{"es-CL", "Firma"},
+		{"en-GB", "Signature"},
+		{"fr", "Firma"},
+		{"", "Firma"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			c := newTestConverter(nil, nil)
+			c.SetLanguage(tt.lang)
+			got := c.renderTypstSignatureItemContent(&portabledoc.SignatureItem{ID: "sig_1"}, "150pt")
+			if want := "#text(size: 9pt)[" + tt.want + "]"; !strings.Contains(got, want) {
+				t.Errorf("expected %q in:\n%s", want, got)
+			}
+		})
+	}
+
+	c := newTestConverter(nil, nil)
+	c.SetLanguage("en")
+	got := c.renderTypstSignatureItemContent(&portabledoc.SignatureItem{ID: "sig_1", Label: "Tenant"}, "150pt")
+	if !strings.Contains(got, "[Tenant]") || strings.Contains(got, "[Signature]") {
+		t.Errorf("an explicit label must win over the default, got:\n%s", got)
+	}
+}
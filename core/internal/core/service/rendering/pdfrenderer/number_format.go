@@ -0,0 +1,112 @@
+package pdfrenderer
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode selects how numbers shown with a fixed number of decimals are rounded.
+// Rounding works on the shortest decimal form of the value, so 2.675 is treated as
+// written rather than as its binary approximation (2.67499...).
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"   // Ties away from zero: 2.675 -> 2.68, -2.675 -> -2.68
+	RoundHalfEven RoundingMode = "half_even" // Ties to the even digit: 2.675 -> 2.68, 2.665 -> 2.66
+	RoundTruncate RoundingMode = "truncate"  // Extra digits dropped: 2.679 -> 2.67, -2.679 -> -2.67
+)
+
+// roundDecimal formats v with exactly places decimals, rounding per mode.
+// An empty or unknown mode rounds half-up.
+func roundDecimal(v float64, places int, mode RoundingMode) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', places, 64)
+	}
+
+	whole, frac, _ := strings.Cut(strconv.FormatFloat(math.Abs(v), 'f', -1, 64), ".")
+	if len(frac) <= places {
+		frac += strings.Repeat("0", places-len(frac))
+		return signed(v < 0 && strings.Trim(whole+frac, "0") != "", joinDecimal(whole, frac))
+	}
+
+	digits := []byte(whole + frac[:places])
+	rest := frac[places:]
+	if roundsUp(digits[len(digits)-1], rest, mode) {
+		digits = incrementDigits(digits)
+	}
+
+	whole, frac = string(digits[:len(digits)-places]), string(digits[len(digits)-places:])
+	return signed(v < 0 && strings.Trim(string(digits), "0") != "", joinDecimal(whole, frac))
+}
+
+// roundsUp reports whether the kept digits, ending in last, round away from zero
+// given the discarded digits rest.
+func roundsUp(last byte, rest string, mode RoundingMode) bool {
+	switch mode {
+	case RoundTruncate:
+		return false
+	case RoundHalfEven:
+		if rest[0] != '5' {
+			return rest[0] > '5'
+		}
+		return strings.Trim(rest[1:], "0") != "" || (last-'0')%2 == 1
+	default:
+		return rest[0] >= '5'
+	}
+}
+
+// incrementDigits adds one to a string of decimal digits, carrying as needed.
+func incrementDigits(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
+
+// groupDigits inserts sep between each group of three digits of an integer,
+// keeping a leading minus sign. An empty sep leaves the digits as they are.
+func groupDigits(digits, sep string) string {
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if sep == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+func joinDecimal(whole, frac string) string {
+	if whole == "" {
+		whole = "0"
+	}
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+func signed(negative bool, s string) string {
+	if negative {
+		return "-" + s
+	}
+	return s
+}
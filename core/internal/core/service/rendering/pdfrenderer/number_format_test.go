@@ -0,0 +1,103 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+func TestRoundDecimal(t *testing.T) {
+	tests := []struct {
+		v    float64
+		mode RoundingMode
+		want string
+	}{
+		{2.675, RoundHalfUp, "2.68"},
+		{2.675, RoundHalfEven, "2.68"},
+		{2.675, RoundTruncate, "2.67"},
+		{-2.675, RoundHalfUp, "-2.68"},
+		{-2.675, RoundHalfEven, "-2.68"},
+		{-2.675, RoundTruncate, "-2.67"},
+		{2.665, RoundHalfUp, "2.67"},
+		{2.665, RoundHalfEven, "2.66"},
+		{2.6651, RoundHalfEven, "2.67"},
+		{2.679, RoundTruncate, "2.67"},
+		{9.995, RoundHalfUp, "10.00"},
+		{-0.001, RoundHalfUp, "0.00"},
+		{-0.005, RoundHalfUp, "-0.01"},
+		{1234.5, RoundHalfUp, "1234.50"},
+		{7, RoundHalfUp, "7.00"},
+		{0.125, "", "0.13"},
+	}
+
+	for _, tt := range tests {
+		if got := roundDecimal(tt.v, 2, tt.mode); got != tt.want {
+			t.Errorf("roundDecimal(%v, 2, %q) = %q, want %q", tt.v, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestRoundingMode_AppliesToCurrencyAndCells(t *testing.T) {
+	for mode, want := range map[RoundingMode]string{RoundHalfUp: "2.68", RoundHalfEven: "2.68", RoundTruncate: "2.67"} {
+		t.Run(string(mode), func(t *testing.T) {
+			tokens := DefaultDesignTokens()
+			tokens.RoundingMode = mode
+			c := NewTypstConverterFactory(tokens)(map[string]any{"fee": 2.675}, nil, nil, nil, nil).(*typstConverter)
+
+			currency := portabledoc.Node{
+				Type:  portabledoc.NodeTypeInjector,
+				Attrs: map[string]any{"variableId": "fee", "type": portabledoc.InjectorTypeCurrency},
+			}
+			if got := c.convertNode(currency); !strings.Contains(got, want) {
+				t.Errorf("currency: got %q, want %q", got, want)
+			}
+
+			cell := entity.NumberValue(-2.675)
+			if got := c.formatCellValue(&cell, ""); got != "-"+want {
+				t.Errorf("cell: got %q, want %q", got, "-"+want)
+			}
+		})
+	}
+}
+
+func TestGroupDigits(t *testing.T) {
+	tests := []struct{ digits, sep, want string }{
+		{"1234567", ",", "1,234,567"},
+		{"-1234567", ".", "-1.234.567"},
+		{"123456", ",", "123,456"},
+		{"999", ",", "999"},
+		{"-12", ",", "-12"},
+		{"1234567", "", "1234567"},
+	}
+	for _, tt := range tests {
+		if got := groupDigits(tt.digits, tt.sep); got != tt.want {
+			t.Errorf("groupDigits(%q, %q) = %q, want %q", tt.digits, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestFormatInteger_GroupsPerLocale(t *testing.T) {
+	tests := []struct {
+		lang  string
+		attrs map[string]any
+		want  string
+	}{
+		{"en", map[string]any{"variableId": "population"}, "1,234,567"},
+		{"es", map[string]any{"variableId": "population"}, "1.234.567"},
+		{"en", map[string]any{"variableId": "year", "grouping": false}, "2026"},
+		{"es", map[string]any{"variableId": "year", "grouping": false}, "2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+"/"+tt.attrs["variableId"].(string), func(t *testing.T) {
+			c := newTestConverter(map[string]any{"population": float64(1234567), "year": 2026}, nil)
+			c.SetLanguage(tt.lang)
+			got := c.convertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: tt.attrs})
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// sealContentsSize is the space reserved in the PDF for the CMS signature, in bytes.
+// It fits an RSA-4096 signature with a three-certificate chain.
+const sealContentsSize = 16384
+
+// byteRangePlaceholder is overwritten in place once the final offsets are known.
+const byteRangePlaceholder = "[0 0000000000 0000000000 0000000000]"
+
+// oidSigningCertificateV2 is the ESS signing-certificate-v2 attribute required by PAdES.
+var oidSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+type essCertIDv2 struct {
+	CertHash []byte // SHA-256, the default hash algorithm, so it is omitted
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+// SealOptions are the informational entries of the seal's signature dictionary.
+type SealOptions struct {
+	Reason   string
+	Location string
+}
+
+// SealingRenderer applies a PAdES seal to renders that request one. Workspaces without
+// a certificate get the unsealed PDF. Sealing happens after caching, so cached results
+// stay unsealed and every render gets a fresh signing time.
+type SealingRenderer struct {
+	inner port.PDFRenderer
+	certs port.SealCertificateProvider
+	opts  SealOptions
+	now   func() time.Time
+}
+
+// NewSealingRenderer wraps a renderer with PAdES sealing.
+func NewSealingRenderer(inner port.PDFRenderer, certs port.SealCertificateProvider, opts SealOptions) *SealingRenderer {
+	return &SealingRenderer{inner: inner, certs: certs, opts: opts, now: time.Now}
+}
+
+// RenderPreview renders the PDF and seals it when the request names a workspace with a certificate.
+func (r *SealingRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	if req.SealWorkspaceID == "" {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	cert, err := r.certs.SealCertificate(ctx, req.SealWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("loading seal certificate: %w", err)
+	}
+	if cert == nil {
+		slog.DebugContext(ctx, "no seal certificate for workspace, skipping PAdES seal",
+			slog.String("workspace_id", req.SealWorkspaceID))
+		return r.inner.RenderPreview(ctx, req)
+	}
+	if req.Encryption != nil {
+		return nil, entity.ErrSealEncrypted
+	}
+
+	result, err := r.inner.RenderPreview(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// Both outputs get the same seal and signing time. The signature pages are cut from
+	// the unsealed PDF: trimming a sealed file would break its signature byte range.
+	at := r.now()
+	sealed, err := SealPDF(result.PDF, cert, r.opts, at)
+	if err != nil {
+		return nil, fmt.Errorf("sealing PDF: %w", err)
+	}
+
+	out := *result
+	out.PDF = sealed
+	if len(result.SignaturePagesPDF) > 0 {
+		if out.SignaturePagesPDF, err = SealPDF(result.SignaturePagesPDF, cert, r.opts, at); err != nil {
+			return nil, fmt.Errorf("sealing signature pages: %w", err)
+		}
+	}
+	return &out, nil
+}
+
+// RenderPoolStats reports the wrapped renderer's pool, if it has one.
+func (r *SealingRenderer) RenderPoolStats() port.RenderPoolStats {
+	if monitor, ok := r.inner.(port.RenderPoolMonitor); ok {
+		return monitor.RenderPoolStats()
+	}
+	return port.RenderPoolStats{}
+}
+
+// Close closes the wrapped renderer.
+func (r *SealingRenderer) Close() error {
+	return r.inner.Close()
+}
+
+// SealPDF signs the PDF with an invisible PAdES (ETSI.CAdES.detached) signature,
+// appended as an incremental update so the original bytes stay untouched.
+func SealPDF(pdfBytes []byte, cert *port.SealCertificate, opts SealOptions, at time.Time) ([]byte, error) {
+	pdfcpuConfigOnce.Do(api.DisableConfigDir)
+
+	pdfCtx, err := api.ReadContext(bytes.NewReader(pdfBytes), model.NewDefaultConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf("reading PDF: %w", err)
+	}
+	if pdfCtx.Encrypt != nil {
+		return nil, entity.ErrSealEncrypted
+	}
+	prevXRef, err := lastStartXRef(pdfBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := buildSealUpdate(pdfCtx, pdfBytes, prevXRef, cert, opts, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fix the byte range around the /Contents hex string now that offsets are final.
+	contentsAt := bytes.Index(update, []byte("/Contents <"))
+	if contentsAt < 0 {
+		return nil, fmt.Errorf("seal update has no /Contents placeholder")
+	}
+	contentsStart := len(pdfBytes) + contentsAt + len("/Contents ")
+	contentsEnd := contentsStart + 2 + 2*sealContentsSize
+	total := len(pdfBytes) + len(update)
+	byteRange := fmt.Sprintf("[0 %010d %010d %010d]", contentsStart, contentsEnd, total-contentsEnd)
+	update = bytes.Replace(update, []byte(byteRangePlaceholder), []byte(byteRange), 1)
+
+	signed := make([]byte, 0, total)
+	signed = append(signed, pdfBytes...)
+	signed = append(signed, update...)
+
+	signedContent := make([]byte, 0, total-(contentsEnd-contentsStart))
+	signedContent = append(signedContent, signed[:contentsStart]...)
+	signedContent = append(signedContent, signed[contentsEnd:]...)
+	signature, err := cadesSignature(signedContent, cert)
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) > sealContentsSize {
+		return nil, fmt.Errorf("signature is %d bytes, exceeds reserved %d", len(signature), sealContentsSize)
+	}
+	hex.Encode(signed[contentsStart+1:], signature)
+	return signed, nil
+}
+
+// buildSealUpdate writes the signature dictionary, its widget field, the catalog with
+// an AcroForm and the first page with the widget annotation, followed by a cross-reference
+// section of the same kind (table or stream) the file already uses.
+func buildSealUpdate(pdfCtx *model.Context, pdfBytes []byte, prevXRef int, cert *port.SealCertificate, opts SealOptions, at time.Time) ([]byte, error) {
+	xref := pdfCtx.XRefTable
+	if xref.Root == nil || xref.Size == nil {
+		return nil, fmt.Errorf("PDF has no catalog")
+	}
+	if err := xref.EnsurePageCount(); err != nil {
+		return nil, fmt.Errorf("counting pages: %w", err)
+	}
+	pageDict, pageRef, _, err := xref.PageDict(1, false)
+	if err != nil || pageRef == nil {
+		return nil, fmt.Errorf("locating first page: %w", err)
+	}
+	rootDict, err := xref.DereferenceDict(*xref.Root)
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog: %w", err)
+	}
+
+	sigNum := *xref.Size
+	fieldNum := sigNum + 1
+	fieldRef := *types.NewIndirectRef(fieldNum, 0)
+
+	annots, err := xref.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		return nil, fmt.Errorf("reading page annotations: %w", err)
+	}
+	pageDict["Annots"] = append(slices.Clone(annots), fieldRef)
+
+	acroForm, err := xref.DereferenceDict(rootDict["AcroForm"])
+	if err != nil {
+		return nil, fmt.Errorf("reading AcroForm: %w", err)
+	}
+	if acroForm == nil {
+		acroForm = types.NewDict()
+	}
+	fields, err := xref.DereferenceArray(acroForm["Fields"])
+	if err != nil {
+		return nil, fmt.Errorf("reading AcroForm fields: %w", err)
+	}
+	acroForm["Fields"] = append(slices.Clone(fields), fieldRef)
+	acroForm["SigFlags"] = types.Integer(3) // SignaturesExist | AppendOnly
+	rootDict["AcroForm"] = acroForm
+
+	var buf bytes.Buffer
+	if !bytes.HasSuffix(pdfBytes, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	offsets := make(map[int]int)
+	writeObject := func(num, gen int, body string) {
+		offsets[num] = len(pdfBytes) + buf.Len()
+		fmt.Fprintf(&buf, "%d %d obj\n%s\nendobj\n", num, gen, body)
+	}
+
+	writeObject(xref.Root.ObjectNumber.Value(), xref.Root.GenerationNumber.Value(), rootDict.PDFString())
+	writeObject(pageRef.ObjectNumber.Value(), pageRef.GenerationNumber.Value(), pageDict.PDFString())
+	writeObject(sigNum, 0, fmt.Sprintf(
+		"<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /ETSI.CAdES.detached /ByteRange %s /Contents <%s> /M %s /Name %s /Reason %s /Location %s >>",
+		byteRangePlaceholder,
+		strings.Repeat("0", 2*sealContentsSize),
+		pdfLiteral(pdfDate(at)),
+		pdfLiteral(cert.Certificate.Subject.CommonName),
+		pdfLiteral(opts.Reason),
+		pdfLiteral(opts.Location),
+	))
+	writeObject(fieldNum, 0, fmt.Sprintf(
+		"<< /Type /Annot /Subtype /Widget /FT /Sig /T %s /V %d 0 R /F 132 /Rect [0 0 0 0] /P %s >>",
+		pdfLiteral(fmt.Sprintf("Seal%d", sigNum)), sigNum, pageRef.PDFString(),
+	))
+
+	trailer := fmt.Sprintf("/Prev %d /Root %s", prevXRef, xref.Root.PDFString())
+	if xref.Info != nil {
+		trailer += " /Info " + xref.Info.PDFString()
+	}
+	if len(xref.ID) > 0 {
+		trailer += " /ID " + xref.ID.PDFString()
+	}
+
+	if bytes.HasPrefix(pdfBytes[prevXRef:], []byte("xref")) {
+		writeXRefTable(&buf, offsets, fieldNum+1, trailer, len(pdfBytes))
+	} else {
+		writeXRefStream(&buf, offsets, fieldNum+1, trailer, len(pdfBytes))
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXRefTable appends a classic cross-reference table and trailer.
+func writeXRefTable(buf *bytes.Buffer, offsets map[int]int, size int, trailer string, base int) {
+	start := base + buf.Len()
+	buf.WriteString("xref\n")
+	for _, run := range xrefRuns(offsets) {
+		fmt.Fprintf(buf, "%d %d\n", run[0], len(run))
+		for _, num := range run {
+			fmt.Fprintf(buf, "%010d 00000 n \n", offsets[num])
+		}
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", size, trailer, start)
+}
+
+// writeXRefStream appends an uncompressed cross-reference stream that also indexes itself.
+func writeXRefStream(buf *bytes.Buffer, offsets map[int]int, streamNum int, trailer string, base int) {
+	start := base + buf.Len()
+	offsets[streamNum] = start
+
+	var data bytes.Buffer
+	var index []string
+	for _, run := range xrefRuns(offsets) {
+		index = append(index, strconv.Itoa(run[0]), strconv.Itoa(len(run)))
+		for _, num := range run {
+			off := offsets[num]
+			data.Write([]byte{1, byte(off >> 24), byte(off >> 16), byte(off >> 8), byte(off), 0, 0})
+		}
+	}
+	fmt.Fprintf(buf, "%d 0 obj\n<< /Type /XRef /Size %d /W [1 4 2] /Index [%s] %s /Length %d >>\nstream\n",
+		streamNum, streamNum+1, strings.Join(index, " "), trailer, data.Len())
+	buf.Write(data.Bytes())
+	fmt.Fprintf(buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", start)
+}
+
+// xrefRuns groups object numbers into runs of consecutive numbers.
+func xrefRuns(offsets map[int]int) [][]int {
+	nums := make([]int, 0, len(offsets))
+	for num := range offsets {
+		nums = append(nums, num)
+	}
+	slices.Sort(nums)
+
+	var runs [][]int
+	for _, num := range nums {
+		if n := len(runs); n > 0 && runs[n-1][len(runs[n-1])-1] == num-1 {
+			runs[n-1] = append(runs[n-1], num)
+			continue
+		}
+		runs = append(runs, []int{num})
+	}
+	return runs
+}
+
+// cadesSignature creates the detached CMS signature with the ESS signing-certificate-v2 attribute.
+func cadesSignature(content []byte, cert *port.SealCertificate) ([]byte, error) {
+	sd, err := pkcs7.NewSignedData(content)
+	if err != nil {
+		return nil, fmt.Errorf("creating CMS: %w", err)
+	}
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	certHash := sha256.Sum256(cert.Certificate.Raw)
+	config := pkcs7.SignerInfoConfig{ExtraSignedAttributes: []pkcs7.Attribute{{
+		Type:  oidSigningCertificateV2,
+		Value: signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash[:]}}},
+	}}}
+	if err := sd.AddSignerChain(cert.Certificate, cert.Signer, cert.Chain, config); err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+	sd.Detach()
+	return sd.Finish()
+}
+
+// lastStartXRef returns the offset recorded by the final startxref keyword.
+func lastStartXRef(pdfBytes []byte) (int, error) {
+	i := bytes.LastIndex(pdfBytes, []byte("startxref"))
+	if i < 0 {
+		return 0, fmt.Errorf("PDF has no startxref")
+	}
+	fields := strings.Fields(string(pdfBytes[i+len("startxref") : min(len(pdfBytes), i+len("startxref")+32)]))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("PDF has an empty startxref")
+	}
+	offset, err := strconv.Atoi(fields[0])
+	if err != nil || offset < 0 || offset >= len(pdfBytes) {
+		return 0, fmt.Errorf("PDF has an invalid startxref %q", fields[0])
+	}
+	return offset, nil
+}
+
+// pdfLiteral returns s as a PDF literal string.
+func pdfLiteral(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return "(" + r.Replace(s) + ")"
+}
+
+// pdfDate formats t as a PDF date string in UTC.
+func pdfDate(t time.Time) string {
+	return "D:" + t.UTC().Format("20060102150405") + "Z"
+}
+
+// LoadSealCertificate decodes a PKCS#12 (.p12/.pfx) bundle into a seal certificate.
+func LoadSealCertificate(p12 []byte, password string) (*port.SealCertificate, error) {
+	key, cert, chain, err := gopkcs12.DecodeChain(p12, password)
+	if err != nil {
+		return nil, fmt.Errorf("decoding PKCS#12: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("PKCS#12 key of type %T cannot sign", key)
+	}
+	return &port.SealCertificate{Certificate: cert, Chain: chain, Signer: signer}, nil
+}
+
+// StaticSealCertificates serves certificates from a fixed map keyed by workspace ID.
+// The "*" entry, if present, applies to workspaces without their own.
+type StaticSealCertificates map[string]*port.SealCertificate
+
+// SealCertificate returns the workspace's certificate, the "*" fallback, or nil.
+func (s StaticSealCertificates) SealCertificate(_ context.Context, workspaceID string) (*port.SealCertificate, error) {
+	if cert, ok := s[workspaceID]; ok {
+		return cert, nil
+	}
+	return s["*"], nil
+}
+
+var (
+	_ port.PDFRenderer             = (*SealingRenderer)(nil)
+	_ port.RenderPoolMonitor       = (*SealingRenderer)(nil)
+	_ port.SealCertificateProvider = StaticSealCertificates(nil)
+)
@@ -0,0 +1,230 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// testSealCertificate generates a self-signed certificate and round-trips it through PKCS#12.
+func testSealCertificate(t *testing.T) *port.SealCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Acme Seal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	p12, err := gopkcs12.Modern.Encode(key, cert, nil, "changeit")
+	if err != nil {
+		t.Fatalf("encoding PKCS#12: %v", err)
+	}
+
+	seal, err := LoadSealCertificate(p12, "changeit")
+	if err != nil {
+		t.Fatalf("LoadSealCertificate failed: %v", err)
+	}
+	return seal
+}
+
+// xrefStreamPDF rewrites a PDF with pdfcpu, which emits object and xref streams.
+func xrefStreamPDF(t *testing.T, pdfBytes []byte) []byte {
+	t.Helper()
+	pdfcpuConfigOnce.Do(api.DisableConfigDir)
+	var out bytes.Buffer
+	if err := api.Optimize(bytes.NewReader(pdfBytes), &out, model.NewDefaultConfiguration()); err != nil {
+		t.Fatalf("optimizing fixture: %v", err)
+	}
+	return out.Bytes()
+}
+
+// sealSignature parses the sealed PDF and returns the signature dictionary referenced by its AcroForm.
+func sealSignature(t *testing.T, pdfBytes []byte) types.Dict {
+	t.Helper()
+	pdfCtx, err := api.ReadContext(bytes.NewReader(pdfBytes), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("sealed PDF does not parse: %v", err)
+	}
+	xref := pdfCtx.XRefTable
+	root, err := xref.DereferenceDict(*xref.Root)
+	if err != nil {
+		t.Fatalf("reading catalog: %v", err)
+	}
+	acroForm, err := xref.DereferenceDict(root["AcroForm"])
+	if err != nil || acroForm == nil {
+		t.Fatalf("sealed PDF has no AcroForm: %v", err)
+	}
+	fields, err := xref.DereferenceArray(acroForm["Fields"])
+	if err != nil || len(fields) != 1 {
+		t.Fatalf("expected one form field, got %v (%v)", fields, err)
+	}
+	field, err := xref.DereferenceDict(fields[0])
+	if err != nil {
+		t.Fatalf("reading field: %v", err)
+	}
+	if ft := field.NameEntry("FT"); ft == nil || *ft != "Sig" {
+		t.Fatalf("expected a /Sig field, got %v", field)
+	}
+	sig, err := xref.DereferenceDict(field["V"])
+	if err != nil || sig == nil {
+		t.Fatalf("field has no signature value: %v", err)
+	}
+	return sig
+}
+
+func TestSealPDF_AddsVerifiableSignature(t *testing.T) {
+	cert := testSealCertificate(t)
+	fixtures := map[string][]byte{
+		"xref table":  minimalPDF(""),
+		"xref stream": xrefStreamPDF(t, minimalPDF("")),
+	}
+	for name, plain := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			sealed, err := SealPDF(plain, cert, SealOptions{Reason: "Issued by Acme"}, time.Now())
+			if err != nil {
+				t.Fatalf("SealPDF failed: %v", err)
+			}
+			if !bytes.HasPrefix(sealed, plain) {
+				t.Fatal("sealing must append an incremental update, not rewrite the file")
+			}
+
+			sig := sealSignature(t, sealed)
+			if typ := sig.Type(); typ == nil || *typ != "Sig" {
+				t.Fatalf("expected /Type /Sig, got %v", sig)
+			}
+			if sub := sig.NameEntry("SubFilter"); sub == nil || *sub != "ETSI.CAdES.detached" {
+				t.Fatalf("expected PAdES SubFilter, got %v", sub)
+			}
+
+			byteRange := sig.ArrayEntry("ByteRange")
+			if len(byteRange) != 4 {
+				t.Fatalf("expected a 4-element ByteRange, got %v", byteRange)
+			}
+			r := make([]int, 4)
+			for i, v := range byteRange {
+				r[i] = int(v.(types.Integer))
+			}
+			if r[0] != 0 || r[2]+r[3] != len(sealed) {
+				t.Fatalf("ByteRange %v does not cover the file of %d bytes", r, len(sealed))
+			}
+
+			contents, err := types.HexLiteral(sealed[r[1]+1 : r[2]-1]).Bytes()
+			if err != nil {
+				t.Fatalf("decoding /Contents: %v", err)
+			}
+			p7, err := pkcs7.Parse(bytes.TrimRight(contents, "\x00"))
+			if err != nil {
+				t.Fatalf("parsing CMS: %v", err)
+			}
+			p7.Content = append(append([]byte{}, sealed[:r[1]]...), sealed[r[2]:]...)
+			if err := p7.Verify(); err != nil {
+				t.Fatalf("signature does not verify: %v", err)
+			}
+			if got := p7.GetOnlySigner(); got == nil || got.Subject.CommonName != "Acme Seal" {
+				t.Fatalf("unexpected signer %v", got)
+			}
+		})
+	}
+}
+
+func TestSealingRenderer_SkipsWorkspacesWithoutCertificate(t *testing.T) {
+	cert := testSealCertificate(t)
+	inner := &fixedRenderer{pdf: minimalPDF("")}
+	r := NewSealingRenderer(inner, StaticSealCertificates{"ws-sealed": cert}, SealOptions{})
+	ctx := context.Background()
+
+	for _, workspaceID := range []string{"", "ws-plain"} {
+		result, err := r.RenderPreview(ctx, &port.RenderPreviewRequest{SealWorkspaceID: workspaceID})
+		if err != nil {
+			t.Fatalf("render for %q failed: %v", workspaceID, err)
+		}
+		if !bytes.Equal(result.PDF, inner.pdf) {
+			t.Fatalf("workspace %q has no certificate, PDF must be left unsealed", workspaceID)
+		}
+	}
+
+	result, err := r.RenderPreview(ctx, &port.RenderPreviewRequest{SealWorkspaceID: "ws-sealed"})
+	if err != nil {
+		t.Fatalf("sealed render failed: %v", err)
+	}
+	if !bytes.Contains(result.PDF, []byte("/ETSI.CAdES.detached")) {
+		t.Fatal("expected a PAdES signature dictionary")
+	}
+	if !bytes.Equal(inner.pdf, minimalPDF("")) {
+		t.Fatal("sealing must not modify the inner renderer's result")
+	}
+}
+
+func TestSealingRenderer_SealsSignaturePagesLikeTheDocument(t *testing.T) {
+	cert := testSealCertificate(t)
+	inner := &fixedRenderer{pdf: minimalPDF(""), signaturePagesPDF: xrefStreamPDF(t, minimalPDF(""))}
+	r := NewSealingRenderer(inner, StaticSealCertificates{"ws": cert}, SealOptions{Reason: "Issued by Acme"})
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	result, err := r.RenderPreview(context.Background(), &port.RenderPreviewRequest{
+		SealWorkspaceID:       "ws",
+		ExtractSignaturePages: true,
+	})
+	if err != nil {
+		t.Fatalf("sealed render failed: %v", err)
+	}
+	if !bytes.HasPrefix(result.SignaturePagesPDF, inner.signaturePagesPDF) {
+		t.Fatal("the signature pages must be sealed with an incremental update of the extracted pages")
+	}
+
+	doc, pages := sealSignature(t, result.PDF), sealSignature(t, result.SignaturePagesPDF)
+	for _, key := range []string{"M", "Reason", "SubFilter"} {
+		if doc[key].String() != pages[key].String() {
+			t.Errorf("/%s differs: document %v, signature pages %v", key, doc[key], pages[key])
+		}
+	}
+}
+
+// fixedRenderer returns the same PDF for every request.
+type fixedRenderer struct {
+	pdf               []byte
+	signaturePagesPDF []byte
+}
+
+func (r *fixedRenderer) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	result := &port.RenderPreviewResult{PDF: r.pdf, Filename: "doc.pdf"}
+	if req.ExtractSignaturePages {
+		result.SignaturePages = []int{1}
+		result.SignaturePagesPDF = r.signaturePagesPDF
+	}
+	return result, nil
+}
+
+func (r *fixedRenderer) Close() error { return nil }
@@ -0,0 +1,68 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// pdfcpuConfigOnce stops pdfcpu from creating a config dir under the user's home.
+var pdfcpuConfigOnce sync.Once
+
+// validateEncryption checks encryption options before rendering.
+func validateEncryption(req *port.RenderPreviewRequest) error {
+	if req.Encryption == nil {
+		return nil
+	}
+	if req.PDFA {
+		return entity.ErrPDFAEncrypted
+	}
+	if req.Encryption.UserPassword == "" && req.Encryption.OwnerPassword == "" {
+		return entity.ErrPDFPasswordRequired
+	}
+	return nil
+}
+
+// encryptPDF encrypts the PDF with AES-256. Without an owner password a random one is
+// used, so the permission flags cannot be lifted by opening with an empty password.
+func encryptPDF(pdfBytes []byte, enc *port.PDFEncryption) ([]byte, error) {
+	pdfcpuConfigOnce.Do(api.DisableConfigDir)
+
+	ownerPassword := enc.OwnerPassword
+	if ownerPassword == "" {
+		random := make([]byte, 16)
+		if _, err := rand.Read(random); err != nil {
+			return nil, fmt.Errorf("generating owner password: %w", err)
+		}
+		ownerPassword = hex.EncodeToString(random)
+	}
+
+	conf := model.NewAESConfiguration(enc.UserPassword, ownerPassword, 256)
+	conf.Permissions = encryptionPermissions(enc)
+
+	var out bytes.Buffer
+	if err := api.Encrypt(bytes.NewReader(pdfBytes), &out, conf); err != nil {
+		return nil, fmt.Errorf("encrypting PDF: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// encryptionPermissions starts from full access and clears the denied capabilities.
+func encryptionPermissions(enc *port.PDFEncryption) model.PermissionFlags {
+	perms := model.PermissionsAll
+	if enc.NoPrint {
+		perms &^= model.PermissionPrintRev2 | model.PermissionPrintRev3
+	}
+	if enc.NoCopy {
+		perms &^= model.PermissionExtract | model.PermissionExtractRev3
+	}
+	return perms
+}
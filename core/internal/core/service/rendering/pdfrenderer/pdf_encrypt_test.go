@@ -0,0 +1,103 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func openPDF(pdfBytes []byte, userPassword string) error {
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = userPassword
+	_, err := api.ReadContext(bytes.NewReader(pdfBytes), conf)
+	return err
+}
+
+func TestEncryptPDF_RequiresUserPasswordToOpen(t *testing.T) {
+	plain := minimalPDF("")
+	if err := openPDF(plain, ""); err != nil {
+		t.Fatalf("fixture should open without a password: %v", err)
+	}
+
+	encrypted, err := encryptPDF(plain, &port.PDFEncryption{UserPassword: "s3cret", OwnerPassword: "owner"})
+	if err != nil {
+		t.Fatalf("encryptPDF failed: %v", err)
+	}
+
+	if !bytes.Contains(encrypted, []byte("/Encrypt")) {
+		t.Fatal("expected an /Encrypt dictionary in the output")
+	}
+	if err := openPDF(encrypted, ""); err == nil {
+		t.Fatal("expected opening without the password to fail")
+	}
+	if err := openPDF(encrypted, "wrong"); err == nil {
+		t.Fatal("expected opening with a wrong password to fail")
+	}
+	if err := openPDF(encrypted, "s3cret"); err != nil {
+		t.Fatalf("expected the user password to open the PDF: %v", err)
+	}
+}
+
+func TestEncryptPDF_AppliesPermissionFlags(t *testing.T) {
+	encrypted, err := encryptPDF(minimalPDF(""), &port.PDFEncryption{UserPassword: "s3cret", NoPrint: true, NoCopy: true})
+	if err != nil {
+		t.Fatalf("encryptPDF failed: %v", err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = "s3cret"
+	perms, err := api.GetPermissions(bytes.NewReader(encrypted), conf)
+	if err != nil {
+		t.Fatalf("reading permissions: %v", err)
+	}
+	if perms == nil {
+		t.Fatal("expected permissions to be set")
+	}
+	p := model.PermissionFlags(uint16(*perms))
+	if p&(model.PermissionPrintRev2|model.PermissionPrintRev3) != 0 {
+		t.Fatalf("expected printing to be denied, got %016b", uint16(p))
+	}
+	if p&model.PermissionExtract != 0 {
+		t.Fatalf("expected copying to be denied, got %016b", uint16(p))
+	}
+	if p&model.PermissionModAnnFillForm == 0 {
+		t.Fatalf("expected form filling to stay allowed, got %016b", uint16(p))
+	}
+}
+
+func TestValidateEncryption(t *testing.T) {
+	cases := map[string]struct {
+		req  port.RenderPreviewRequest
+		want error
+	}{
+		"none":          {req: port.RenderPreviewRequest{}},
+		"user password": {req: port.RenderPreviewRequest{Encryption: &port.PDFEncryption{UserPassword: "x"}}},
+		"no password":   {req: port.RenderPreviewRequest{Encryption: &port.PDFEncryption{NoPrint: true}}, want: entity.ErrPDFPasswordRequired},
+		"with pdf/a":    {req: port.RenderPreviewRequest{PDFA: true, Encryption: &port.PDFEncryption{UserPassword: "x"}}, want: entity.ErrPDFAEncrypted},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := validateEncryption(&tc.req); !errors.Is(err, tc.want) {
+				t.Fatalf("got %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPDFEncryption_LogValueRedactsPasswords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Info("render", slog.Any("encryption", &port.PDFEncryption{UserPassword: "user-s3cret", OwnerPassword: "owner-s3cret", NoCopy: true}))
+
+	if bytes.Contains(buf.Bytes(), []byte("s3cret")) {
+		t.Fatalf("expected passwords to be redacted, got %s", buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/dslipak/pdf"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// pdfStandardA2b is the Typst --pdf-standard value for PDF/A-2b.
+const pdfStandardA2b = "a-2b"
+
+var (
+	pdfaPartPattern        = regexp.MustCompile(`pdfaid:part(?:>|=["'])\s*(\d)`)
+	pdfaConformancePattern = regexp.MustCompile(`pdfaid:conformance(?:>|=["'])\s*([ABUabu])`)
+)
+
+// verifyPDFA checks that the PDF declares PDF/A-2b conformance in its XMP metadata.
+// Typst embeds fonts and the output intent itself; this guards against a Typst
+// build that silently ignored the standard.
+func verifyPDFA(pdfBytes []byte) error {
+	part, conformance, err := pdfaIdentification(pdfBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrPDFANonConformant, err)
+	}
+	if part != "2" || (conformance != "B" && conformance != "b") {
+		return fmt.Errorf("%w: declares part %q conformance %q", entity.ErrPDFANonConformant, part, conformance)
+	}
+	return nil
+}
+
+// pdfaIdentification reads the pdfaid part and conformance from the document catalog's
+// XMP metadata stream. Empty values mean the PDF makes no PDF/A claim.
+func pdfaIdentification(pdfBytes []byte) (part, conformance string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pdf metadata parsing panicked: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(pdfBytes), int64(len(pdfBytes)))
+	if err != nil {
+		return "", "", fmt.Errorf("parsing pdf: %w", err)
+	}
+	metadata := r.Trailer().Key("Root").Key("Metadata")
+	if metadata.Kind() != pdf.Stream {
+		return "", "", fmt.Errorf("pdf has no XMP metadata")
+	}
+	rc := metadata.Reader()
+	defer rc.Close()
+	xmp, err := io.ReadAll(rc)
+	if err != nil {
+		return "", "", fmt.Errorf("reading XMP metadata: %w", err)
+	}
+
+	if m := pdfaPartPattern.FindSubmatch(xmp); m != nil {
+		part = string(m[1])
+	}
+	if m := pdfaConformancePattern.FindSubmatch(xmp); m != nil {
+		conformance = string(m[1])
+	}
+	return part, conformance, nil
+}
@@ -0,0 +1,75 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// minimalPDF builds a single blank-page PDF whose XMP metadata stream is xmp.
+// An empty xmp omits the metadata stream entirely.
+func minimalPDF(xmp string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << >> >>",
+	}
+	if xmp != "" {
+		objects[0] = "<< /Type /Catalog /Pages 2 0 R /Metadata 4 0 R >>"
+		objects = append(objects, fmt.Sprintf("<< /Type /Metadata /Subtype /XML /Length %d >>\nstream\n%s\nendstream", len(xmp), xmp))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func TestVerifyPDFA_AcceptsPDFA2bMarker(t *testing.T) {
+	for name, xmp := range map[string]string{
+		"elements":   `<rdf:Description><pdfaid:part>2</pdfaid:part><pdfaid:conformance>B</pdfaid:conformance></rdf:Description>`,
+		"attributes": `<rdf:Description pdfaid:part="2" pdfaid:conformance="B"/>`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			part, conformance, err := pdfaIdentification(minimalPDF(xmp))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if part != "2" || conformance != "B" {
+				t.Fatalf("got part %q conformance %q", part, conformance)
+			}
+			if err := verifyPDFA(minimalPDF(xmp)); err != nil {
+				t.Fatalf("expected conformant PDF, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyPDFA_RejectsMissingOrOtherConformance(t *testing.T) {
+	cases := map[string][]byte{
+		"no metadata":   minimalPDF(""),
+		"no pdfa claim": minimalPDF(`<rdf:Description><dc:title>Contract</dc:title></rdf:Description>`),
+		"pdfa-1b":       minimalPDF(`<rdf:Description pdfaid:part="1" pdfaid:conformance="B"/>`),
+		"not a pdf":     []byte("hello"),
+	}
+	for name, pdfBytes := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := verifyPDFA(pdfBytes); !errors.Is(err, entity.ErrPDFANonConformant) {
+				t.Fatalf("expected ErrPDFANonConformant, got %v", err)
+			}
+		})
+	}
+}
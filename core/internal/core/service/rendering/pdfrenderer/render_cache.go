@@ -0,0 +1,231 @@
+package pdfrenderer
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// CachedRenderer serves repeated renders from a cache instead of recompiling Typst.
+// Only renders with a VersionID and no encryption are cached, and not renders reading
+// the render time. The key covers the version, its content and every input, so
+// editing a version or changing any injectable is a miss. Results that relied on
+// DefaultResolver (live provider values) are never stored. Static documents (see
+// portabledoc.Document.IsStatic) are keyed by version and content only, so renders
+// with any injectable values share one entry.
+type CachedRenderer struct {
+	inner port.PDFRenderer
+	cache port.RenderCache
+	ttl   time.Duration
+}
+
+// NewCachedRenderer wraps a renderer with a result cache.
+func NewCachedRenderer(inner port.PDFRenderer, cache port.RenderCache, ttl time.Duration) *CachedRenderer {
+	return &CachedRenderer{inner: inner, cache: cache, ttl: ttl}
+}
+
+// RenderPreview returns the cached PDF for identical inputs, rendering and caching it otherwise.
+func (r *CachedRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	if req.VersionID == "" || req.Document == nil || req.Encryption != nil || usesRenderTime(req) {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	key, err := RenderCacheKey(req)
+	if err != nil {
+		slog.WarnContext(ctx, "render cache key failed, rendering uncached",
+			slog.String("version_id", req.VersionID),
+			slog.Any("error", err),
+		)
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	cached, ok, err := r.cache.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "render cache get failed", slog.String("version_id", req.VersionID), slog.Any("error", err))
+	}
+	if ok {
+		slog.DebugContext(ctx, "render cache hit", slog.String("version_id", req.VersionID))
+		return cached, nil
+	}
+
+	var resolverUsed atomic.Bool
+	if resolve := req.DefaultResolver; resolve != nil {
+		tracked := *req
+		tracked.DefaultResolver = func(ctx context.Context, code string) (any, bool) {
+			resolverUsed.Store(true)
+			return resolve(ctx, code)
+		}
+		req = &tracked
+	}
+
+	result, err := r.inner.RenderPreview(ctx, req)
+	if err != nil || resolverUsed.Load() {
+		return result, err
+	}
+	if err := r.cache.Set(ctx, key, result, r.ttl); err != nil {
+		slog.WarnContext(ctx, "render cache set failed", slog.String("version_id", req.VersionID), slog.Any("error", err))
+	}
+	return result, nil
+}
+
+// RenderPoolStats reports the wrapped renderer's pool, if it has one.
+func (r *CachedRenderer) RenderPoolStats() port.RenderPoolStats {
+	if monitor, ok := r.inner.(port.RenderPoolMonitor); ok {
+		return monitor.RenderPoolStats()
+	}
+	return port.RenderPoolStats{}
+}
+
+// Close closes the wrapped renderer.
+func (r *CachedRenderer) Close() error {
+	return r.inner.Close()
+}
+
+// RenderCacheKey hashes the version ID, document content and all render inputs.
+// Inputs are left out for static documents, which never read them.
+// Map keys are sorted by encoding/json, so equal inputs always yield the same key.
+func RenderCacheKey(req *port.RenderPreviewRequest) (string, error) {
+	inputs := *req
+	if req.Document != nil && req.Document.IsStatic() {
+		inputs.Injectables, inputs.InjectableDefaults, inputs.SignerRoleValues, inputs.FieldResponses = nil, nil, nil, nil
+		inputs.Slots = nil
+	}
+	canonical, err := json.Marshal(struct {
+		VersionID          string                          `json:"v"`
+		Document           *portabledoc.Document           `json:"d"`
+		Injectables        map[string]any                  `json:"i"`
+		InjectableDefaults map[string]string               `json:"id"`
+		SignerRoleValues   map[string]port.SignerRoleValue `json:"s"`
+		FieldResponses     map[string]json.RawMessage      `json:"f"`
+		Slots              map[string][]portabledoc.Node   `json:"sl,omitempty"`
+		BlockIndex         *int                            `json:"b,omitempty"`
+		DraftMode          bool                            `json:"dm,omitempty"`
+		ShowPlaceholders   bool                            `json:"ph,omitempty"`
+		ShowHidden         bool                            `json:"hc,omitempty"`
+		PDFA               bool                            `json:"pa,omitempty"`
+		SignaturePages     bool                            `json:"sp,omitempty"`
+		Theme              string                          `json:"th,omitempty"`
+		FontKeys           []string                        `json:"fk,omitempty"`
+		Image              *port.ImageOptions              `json:"img,omitempty"`
+	}{
+		VersionID:          inputs.VersionID,
+		Document:           inputs.Document,
+		Injectables:        inputs.Injectables,
+		InjectableDefaults: inputs.InjectableDefaults,
+		SignerRoleValues:   inputs.SignerRoleValues,
+		FieldResponses:     inputs.FieldResponses,
+		Slots:              inputs.Slots,
+		BlockIndex:         inputs.BlockIndex,
+		DraftMode:          inputs.DraftMode,
+		ShowPlaceholders:   inputs.ShowPlaceholders,
+		ShowHidden:         inputs.ShowHiddenConditionals,
+		PDFA:               inputs.PDFA,
+		SignaturePages:     inputs.ExtractSignaturePages,
+		Theme:              inputs.Theme,
+		FontKeys:           inputs.FontKeys,
+		Image:              inputs.Image,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encoding render inputs: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MemoryRenderCache is an in-process LRU render cache with per-entry expiry.
+type MemoryRenderCache struct {
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key       string
+	result    *port.RenderPreviewResult
+	expiresAt time.Time
+}
+
+// NewMemoryRenderCache creates an in-memory render cache holding at most maxEntries results.
+func NewMemoryRenderCache(maxEntries int) *MemoryRenderCache {
+	if maxEntries <= 0 {
+		maxEntries = 100
+	}
+	return &MemoryRenderCache{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached result for key, or false when absent or expired.
+func (c *MemoryRenderCache) Get(_ context.Context, key string) (*port.RenderPreviewResult, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(el)
+	return entry.result, true, nil
+}
+
+// Set stores a result for key, evicting the least recently used entry when full.
+// A non-positive ttl keeps the entry until it is evicted.
+func (c *MemoryRenderCache) Set(_ context.Context, key string, result *port.RenderPreviewResult, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.result, entry.expiresAt = result, expiresAt
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheEntry{key: key, result: result, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, including expired ones not yet evicted.
+func (c *MemoryRenderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+var (
+	_ port.PDFRenderer       = (*CachedRenderer)(nil)
+	_ port.RenderPoolMonitor = (*CachedRenderer)(nil)
+	_ port.RenderCache       = (*MemoryRenderCache)(nil)
+)
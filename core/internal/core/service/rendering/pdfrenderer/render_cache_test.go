@@ -0,0 +1,248 @@
+package pdfrenderer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// countingRenderer returns a distinct PDF per call so cache hits are observable.
+type countingRenderer struct {
+	calls int
+	// resolveCode, when set, is looked up through the request's DefaultResolver.
+	resolveCode string
+}
+
+func (r *countingRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	r.calls++
+	if r.resolveCode != "" && req.DefaultResolver != nil {
+		req.DefaultResolver(ctx, r.resolveCode)
+	}
+	return &port.RenderPreviewResult{PDF: []byte{byte(r.calls)}, Filename: "doc.pdf"}, nil
+}
+
+func (r *countingRenderer) Close() error { return nil }
+
+func cacheTestRequest(versionID, name string) *port.RenderPreviewRequest {
+	return &port.RenderPreviewRequest{
+		VersionID: versionID,
+		Document: &portabledoc.Document{
+			Version: "1.1.0",
+			Meta:    portabledoc.Meta{Title: "Contract"},
+			Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "client_name"}},
+			}},
+		},
+		Injectables:        map[string]any{"client_name": name, "amount": 1200.5},
+		InjectableDefaults: map[string]string{"city": "Santiago"},
+	}
+}
+
+func TestCachedRenderer_HitOnIdenticalInputs(t *testing.T) {
+	inner := &countingRenderer{}
+	r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+	ctx := context.Background()
+
+	first, err := r.RenderPreview(ctx, cacheTestRequest("v1", "Ada"))
+	require.NoError(t, err)
+	second, err := r.RenderPreview(ctx, cacheTestRequest("v1", "Ada"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, inner.calls, "second render must bypass Typst")
+	assert.Equal(t, first.PDF, second.PDF)
+}
+
+func TestCachedRenderer_MissWhenInputsChange(t *testing.T) {
+	inner := &countingRenderer{}
+	r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+	ctx := context.Background()
+
+	_, err := r.RenderPreview(ctx, cacheTestRequest("v1", "Ada"))
+	require.NoError(t, err)
+
+	t.Run("injectable value", func(t *testing.T) {
+		before := inner.calls
+		_, err := r.RenderPreview(ctx, cacheTestRequest("v1", "Grace"))
+		require.NoError(t, err)
+		assert.Equal(t, before+1, inner.calls)
+	})
+
+	t.Run("version id", func(t *testing.T) {
+		before := inner.calls
+		_, err := r.RenderPreview(ctx, cacheTestRequest("v2", "Ada"))
+		require.NoError(t, err)
+		assert.Equal(t, before+1, inner.calls)
+	})
+
+	t.Run("version content", func(t *testing.T) {
+		before := inner.calls
+		req := cacheTestRequest("v1", "Ada")
+		req.Document.Meta.Title = "Contract (edited)"
+		_, err := r.RenderPreview(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, inner.calls, "editing a draft invalidates its cached renders")
+	})
+
+	t.Run("block index", func(t *testing.T) {
+		before := inner.calls
+		req := cacheTestRequest("v1", "Ada")
+		block := 0
+		req.BlockIndex = &block
+		_, err := r.RenderPreview(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, inner.calls, "a single-block render is cached apart from the full document")
+	})
+}
+
+func TestCachedRenderer_StaticDocumentIgnoresInputs(t *testing.T) {
+	inner := &countingRenderer{}
+	r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+	ctx := context.Background()
+
+	for _, name := range []string{"Ada", "Grace", "Hedy"} {
+		req := cacheTestRequest("v1", name)
+		req.Document.Content = nil
+		_, err := r.RenderPreview(ctx, req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, inner.calls, "a static document renders once per version and content")
+}
+
+func TestCachedRenderer_BypassesUncacheableRenders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no version id", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			_, err := r.RenderPreview(ctx, cacheTestRequest("", "Ada"))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("encrypted output", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.Encryption = &port.PDFEncryption{UserPassword: "s3cret"}
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("document reads the render time", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.Document.VariableIDs = []string{"client_name", entity.RenderTodayInjectable}
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("slot content reads the render time", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.Slots = map[string][]portabledoc.Node{"closing": {
+				{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": entity.RenderNowInjectable}},
+			}}
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("undeclared condition reads the render time", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.Document.Content.Content = append(req.Document.Content.Content, portabledoc.Node{
+				Type: portabledoc.NodeTypeConditional,
+				Attrs: map[string]any{"conditions": map[string]any{
+					"type": "group", "logic": "AND",
+					"children": []any{map[string]any{"type": "rule", "variableId": entity.RenderTodayInjectable, "operator": "not_empty"}},
+				}},
+			})
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("live default resolved", func(t *testing.T) {
+		inner := &countingRenderer{resolveCode: "exchange_rate"}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.DefaultResolver = func(context.Context, string) (any, bool) { return 950.1, true }
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("resolver present but unused", func(t *testing.T) {
+		inner := &countingRenderer{}
+		r := NewCachedRenderer(inner, NewMemoryRenderCache(10), time.Minute)
+		for range 2 {
+			req := cacheTestRequest("v1", "Ada")
+			req.DefaultResolver = func(context.Context, string) (any, bool) { return nil, false }
+			_, err := r.RenderPreview(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, inner.calls)
+	})
+}
+
+func TestRenderCacheKey_IgnoresMapOrder(t *testing.T) {
+	a := cacheTestRequest("v1", "Ada")
+	b := cacheTestRequest("v1", "Ada")
+	b.Injectables = map[string]any{"amount": 1200.5, "client_name": "Ada"}
+
+	keyA, err := RenderCacheKey(a)
+	require.NoError(t, err)
+	keyB, err := RenderCacheKey(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, keyA, keyB)
+}
+
+func TestMemoryRenderCache_ExpiresAndEvicts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewMemoryRenderCache(2)
+	c.now = func() time.Time { return now }
+	result := &port.RenderPreviewResult{PDF: []byte("%PDF")}
+
+	require.NoError(t, c.Set(ctx, "a", result, time.Minute))
+	now = now.Add(time.Minute)
+	_, ok, _ := c.Get(ctx, "a")
+	assert.False(t, ok, "entry expires after its ttl")
+
+	require.NoError(t, c.Set(ctx, "a", result, 0))
+	require.NoError(t, c.Set(ctx, "b", result, 0))
+	_, _, _ = c.Get(ctx, "a") // a becomes most recently used
+	require.NoError(t, c.Set(ctx, "c", result, 0))
+
+	_, okA, _ := c.Get(ctx, "a")
+	_, okB, _ := c.Get(ctx, "b")
+	assert.True(t, okA)
+	assert.False(t, okB, "least recently used entry is evicted")
+	assert.Equal(t, 2, c.Len())
+}
@@ -0,0 +1,95 @@
+package pdfrenderer
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+const defaultAcquireTimeout = 5 * time.Second
+
+// RenderPool bounds concurrent renders. Up to maxConcurrent renders run at once and
+// up to maxQueue more wait for a slot, each for at most acquireTimeout. Renders beyond
+// the queue, or that time out waiting, fail with entity.ErrRendererBusy.
+// A nil pool runs every render immediately.
+type RenderPool struct {
+	slots          chan struct{}
+	maxQueue       int
+	acquireTimeout time.Duration
+
+	queued    atomic.Int64
+	rejected  atomic.Uint64
+	completed atomic.Uint64
+}
+
+// NewRenderPool creates a render pool. Returns nil when maxConcurrent <= 0 (unlimited).
+// maxQueue <= 0 leaves the queue unbounded, so waiting is limited only by acquireTimeout.
+func NewRenderPool(maxConcurrent, maxQueue int, acquireTimeout time.Duration) *RenderPool {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	if acquireTimeout <= 0 {
+		acquireTimeout = defaultAcquireTimeout
+	}
+	return &RenderPool{
+		slots:          make(chan struct{}, maxConcurrent),
+		maxQueue:       maxQueue,
+		acquireTimeout: acquireTimeout,
+	}
+}
+
+// Acquire takes a render slot, queueing if all are busy. The returned release func
+// must be called once the render finishes.
+func (p *RenderPool) Acquire(ctx context.Context) (release func(), err error) {
+	if p == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	default:
+	}
+
+	if queued := p.queued.Add(1); p.maxQueue > 0 && queued > int64(p.maxQueue) {
+		p.queued.Add(-1)
+		p.rejected.Add(1)
+		return nil, entity.ErrRendererBusy
+	}
+	defer p.queued.Add(-1)
+
+	timer := time.NewTimer(p.acquireTimeout)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		p.rejected.Add(1)
+		return nil, entity.ErrRendererBusy
+	}
+}
+
+func (p *RenderPool) release() {
+	<-p.slots
+	p.completed.Add(1)
+}
+
+// Stats returns a snapshot of the pool. A nil pool reports zero capacity.
+func (p *RenderPool) Stats() port.RenderPoolStats {
+	if p == nil {
+		return port.RenderPoolStats{}
+	}
+	return port.RenderPoolStats{
+		MaxConcurrent: cap(p.slots),
+		MaxQueue:      p.maxQueue,
+		InFlight:      len(p.slots),
+		Queued:        int(p.queued.Load()),
+		Rejected:      p.rejected.Load(),
+		Completed:     p.completed.Load(),
+	}
+}
@@ -0,0 +1,146 @@
+package pdfrenderer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// acquireAsync starts an Acquire in the background and reports its result.
+func acquireAsync(ctx context.Context, p *RenderPool) <-chan func() {
+	done := make(chan func(), 1)
+	go func() {
+		release, err := p.Acquire(ctx)
+		if err != nil {
+			close(done)
+			return
+		}
+		done <- release
+	}()
+	return done
+}
+
+func waitQueued(t *testing.T, p *RenderPool, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return p.Stats().Queued == n }, time.Second, time.Millisecond)
+}
+
+func TestRenderPool_QueuesBeyondSlotsAndRejectsBeyondQueue(t *testing.T) {
+	p := NewRenderPool(2, 1, time.Minute)
+	ctx := context.Background()
+
+	release1, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	release2, err := p.Acquire(ctx)
+	require.NoError(t, err)
+
+	// Third request waits in the queue.
+	queued := acquireAsync(ctx, p)
+	waitQueued(t, p, 1)
+
+	// Fourth exceeds the queue and is rejected immediately.
+	_, err = p.Acquire(ctx)
+	require.ErrorIs(t, err, entity.ErrRendererBusy)
+
+	stats := p.Stats()
+	assert.Equal(t, 2, stats.MaxConcurrent)
+	assert.Equal(t, 1, stats.MaxQueue)
+	assert.Equal(t, 2, stats.InFlight)
+	assert.Equal(t, 1, stats.Queued)
+	assert.Equal(t, uint64(1), stats.Rejected)
+
+	// Finishing a render hands its slot to the queued request.
+	release1()
+	release3, ok := <-queued
+	require.True(t, ok, "queued request should get a slot")
+	assert.Equal(t, 0, p.Stats().Queued)
+	assert.Equal(t, 2, p.Stats().InFlight)
+
+	release2()
+	release3()
+	stats = p.Stats()
+	assert.Equal(t, 0, stats.InFlight)
+	assert.Equal(t, uint64(3), stats.Completed)
+}
+
+func TestRenderPool_RejectsAfterAcquireTimeout(t *testing.T) {
+	p := NewRenderPool(1, 5, 20*time.Millisecond)
+	release, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	_, err = p.Acquire(context.Background())
+
+	require.ErrorIs(t, err, entity.ErrRendererBusy)
+	assert.Equal(t, uint64(1), p.Stats().Rejected)
+	assert.Equal(t, 0, p.Stats().Queued)
+}
+
+func TestRenderPool_CancelledWhileQueued(t *testing.T) {
+	p := NewRenderPool(1, 5, time.Minute)
+	release, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := acquireAsync(ctx, p)
+	waitQueued(t, p, 1)
+	cancel()
+
+	_, ok := <-queued
+	assert.False(t, ok)
+	assert.Equal(t, 0, p.Stats().Queued)
+	assert.Equal(t, uint64(0), p.Stats().Rejected, "cancellation is not a rejection")
+}
+
+func TestRenderPool_NeverExceedsMaxConcurrent(t *testing.T) {
+	const slots, requests = 3, 20
+	p := NewRenderPool(slots, 0, time.Minute)
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+		wg      sync.WaitGroup
+	)
+	for range requests {
+		wg.Go(func() {
+			release, err := p.Acquire(context.Background())
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(2 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			release()
+		})
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, slots)
+	assert.Equal(t, uint64(requests), p.Stats().Completed)
+	assert.Equal(t, uint64(0), p.Stats().Rejected)
+}
+
+func TestRenderPool_NilIsUnlimited(t *testing.T) {
+	p := NewRenderPool(0, 10, time.Second)
+	require.Nil(t, p)
+
+	release, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	assert.Zero(t, p.Stats())
+}
@@ -0,0 +1,134 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/formatter"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// validateTimezone rejects a render timezone that isn't a known IANA zone.
+func validateTimezone(req *port.RenderPreviewRequest) error {
+	if req.Timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return fmt.Errorf("%w: %q", entity.ErrInvalidTimezone, req.Timezone)
+	}
+	return nil
+}
+
+// renderTime returns the current time in the render's timezone, or UTC when it has none.
+func renderTime(timezone string) time.Time {
+	now := time.Now().UTC()
+	if timezone == "" {
+		return now
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return now
+	}
+	return now.In(loc)
+}
+
+// usesRenderTime reports whether a render reads a render time injectable, so its
+// output changes from one render to the next. Besides the declared variable IDs it
+// checks the content actually rendered, which includes expanded includes and slot
+// content that the declarations do not list.
+func usesRenderTime(req *port.RenderPreviewRequest) bool {
+	doc := req.Document
+	if slices.ContainsFunc(doc.VariableIDs, entity.IsRenderTimeInjectable) {
+		return true
+	}
+	if doc.Watermark.HasInjectable() && entity.IsRenderTimeInjectable(*doc.Watermark.InjectableID) {
+		return true
+	}
+	if doc.Header != nil && nodesUseRenderTime(doc.Header.TextNodes()) {
+		return true
+	}
+	if doc.Content != nil && nodesUseRenderTime(doc.Content.Content) {
+		return true
+	}
+	for _, nodes := range req.Slots {
+		if nodesUseRenderTime(nodes) {
+			return true
+		}
+	}
+	for _, refs := range doc.CoverPage.InjectableRefs() {
+		if slices.ContainsFunc(refs, entity.IsRenderTimeInjectable) {
+			return true
+		}
+	}
+	if doc.Meta.Properties != nil {
+		for _, refs := range doc.Meta.Properties.InjectableRefs() {
+			if slices.ContainsFunc(refs, entity.IsRenderTimeInjectable) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodesUseRenderTime reports whether any node or mark attr, at any depth (injector
+// variable IDs, condition rules), names a render time injectable.
+func nodesUseRenderTime(nodes []portabledoc.Node) bool {
+	for _, node := range nodes {
+		if attrUsesRenderTime(node.Attrs) {
+			return true
+		}
+		for _, mark := range node.Marks {
+			if attrUsesRenderTime(mark.Attrs) {
+				return true
+			}
+		}
+		if nodesUseRenderTime(node.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+func attrUsesRenderTime(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return entity.IsRenderTimeInjectable(v)
+	case map[string]any:
+		for _, item := range v {
+			if attrUsesRenderTime(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if attrUsesRenderTime(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderTimeValue resolves the built-in render time injectables. The injector's
+// format attr (e.g. "DD/MM/YYYY HH:mm") wins over the locale's date pattern; the
+// time of day is appended to it for system.now.
+func (c *typstConverter) renderTimeValue(variableID string, attrs map[string]any) (string, bool) {
+	if !entity.IsRenderTimeInjectable(variableID) {
+		return "", false
+	}
+	now := c.renderTime
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	if format, _ := attrs["format"].(string); format != "" {
+		return formatter.FormatTime(now, format), true
+	}
+	date := c.locale().formatDate(now)
+	if variableID == entity.RenderNowInjectable {
+		return date + " " + now.Format("15:04"), true
+	}
+	return date, true
+}
@@ -0,0 +1,84 @@
+package pdfrenderer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func renderTimeInjector(variableID string, attrs map[string]any) portabledoc.Node {
+	merged := map[string]any{"variableId": variableID}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	return portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: merged}
+}
+
+func TestTypstConverter_RenderTimeInjectables(t *testing.T) {
+	at := time.Date(2026, time.March, 9, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		lang   string
+		node   portabledoc.Node
+		values map[string]any
+		want   string
+	}{
+		{"today in the default locale", "", renderTimeInjector(entity.RenderTodayInjectable, nil), nil, "2026-03-09"},
+		{"today in the document locale", "es", renderTimeInjector(entity.RenderTodayInjectable, nil), nil, "09/03/2026"},
+		{"now adds the time of day", "es", renderTimeInjector(entity.RenderNowInjectable, nil), nil, "09/03/2026 14:30"},
+		{"format attr", "", renderTimeInjector(entity.RenderNowInjectable, map[string]any{"format": "DD/MM/YYYY HH:mm"}), nil, "09/03/2026 14:30"},
+		{"sent value wins", "", renderTimeInjector(entity.RenderTodayInjectable, nil), map[string]any{entity.RenderTodayInjectable: "yesterday"}, "yesterday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConverter(tt.values, nil)
+			c.SetLanguage(tt.lang)
+			c.SetRenderTime(at)
+			if got := c.convertNode(tt.node); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypstConverter_RenderTimeUsesTimezone(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// 01:30 UTC on March 10 is still March 9 in Santiago.
+	c := newTestConverter(nil, nil)
+	c.SetRenderTime(time.Date(2026, time.March, 10, 1, 30, 0, 0, time.UTC).In(santiago))
+
+	if got := c.convertNode(renderTimeInjector(entity.RenderNowInjectable, nil)); got != "2026-03-09 22:30" {
+		t.Errorf("expected the render time in the render's timezone, got %q", got)
+	}
+}
+
+func TestRenderTime(t *testing.T) {
+	if loc := renderTime("").Location(); loc != time.UTC {
+		t.Errorf("expected UTC without a timezone, got %v", loc)
+	}
+	if _, err := time.LoadLocation("America/Santiago"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	if loc := renderTime("America/Santiago").Location(); loc.String() != "America/Santiago" {
+		t.Errorf("expected the request timezone, got %v", loc)
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	if err := validateTimezone(&port.RenderPreviewRequest{}); err != nil {
+		t.Errorf("expected no error without a timezone, got %v", err)
+	}
+	err := validateTimezone(&port.RenderPreviewRequest{Timezone: "Mars/Olympus_Mons"})
+	if !errors.Is(err, entity.ErrInvalidTimezone) || !strings.Contains(err.Error(), "Mars/Olympus_Mons") {
+		t.Errorf("expected ErrInvalidTimezone naming the zone, got %v", err)
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
@@ -30,12 +33,16 @@ type ConverterFactory func(
 type Service struct {
 	typst            *TypstRenderer
 	httpClient       *http.Client
-	sem              chan struct{}
-	acquireTimeout   time.Duration
+	pool             *RenderPool
+	limits           DocumentLimits
+	debugAnchors     bool
+	maxImageDim      int
 	imageCache       *ImageCache
 	converterFactory ConverterFactory
 	tokens           TypstDesignTokens
+	themes           Themes
 	storageAdapter   port.StorageAdapter
+	warmupRenders    int
 }
 
 // NewService creates a new Typst-based PDF renderer service.
@@ -52,37 +59,173 @@ func NewService(opts TypstOptions, imageCache *ImageCache, factory ConverterFact
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
-		acquireTimeout:   opts.AcquireTimeout,
+		pool:             NewRenderPool(opts.MaxConcurrent, opts.MaxQueue, opts.AcquireTimeout),
+		limits:           opts.Limits,
+		debugAnchors:     opts.DebugAnchors,
+		maxImageDim:      opts.MaxImageDimension,
 		imageCache:       imageCache,
 		converterFactory: factory,
 		tokens:           tokens,
+		themes:           opts.Themes,
 		storageAdapter:   storageAdapter,
-	}
-
-	if opts.MaxConcurrent > 0 {
-		s.sem = make(chan struct{}, opts.MaxConcurrent)
-	}
-	if s.acquireTimeout == 0 {
-		s.acquireTimeout = 5 * time.Second
+		warmupRenders:    warmupCount(opts),
 	}
 
 	return s, nil
 }
 
 // RenderPreview generates a preview PDF with injected values.
-func (s *Service) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
-	if err := s.acquireSlot(ctx); err != nil {
+// Logs are written against ctx, so they carry the caller's request attributes (e.g. operation_id).
+// Each stage is traced as a child of a "render" span. Cancelling ctx aborts image
+// fetching and the Typst compile and returns the context error, freeing the pool slot.
+func (s *Service) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (_ *port.RenderPreviewResult, err error) {
+	ctx, span := startSpan(ctx, "render", renderAttributes(req)...)
+	defer func() { endSpan(span, err) }()
+
+	release, err := s.pool.Acquire(ctx)
+	if err != nil {
 		return nil, err
 	}
-	defer s.releaseSlot()
+	defer release()
 
 	if req.Document == nil {
 		return nil, fmt.Errorf("document is required")
 	}
+	if err := validateEncryption(req); err != nil {
+		return nil, err
+	}
+	if err := validateImage(req); err != nil {
+		return nil, err
+	}
+	if err := validateTimezone(req); err != nil {
+		return nil, err
+	}
+
+	// Build Typst document
+	builder, typstSource, pageCount, signatureFields, err := s.convert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Resolve remote images
+	rootDir, renames, cleanup, err := s.fetchImages(ctx, builder.RemoteImages())
+	if err != nil {
+		return nil, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	for oldName, newName := range renames {
+		typstSource = strings.ReplaceAll(typstSource, oldName, newName)
+	}
 
-	// Resolve signer role values if not provided
-	signerRoleValues := req.SignerRoleValues
-	if signerRoleValues == nil {
+	fontDir, cleanupFonts, err := s.fetchFonts(ctx, req.FontKeys)
+	if err != nil {
+		return nil, err
+	}
+	var fontDirs []string
+	if fontDir != "" {
+		defer cleanupFonts()
+		fontDirs = append(fontDirs, fontDir)
+	}
+
+	if req.Image != nil {
+		return s.renderImage(ctx, req, typstSource, rootDir, pageCount, fontDirs)
+	}
+
+	// Generate PDF using Typst
+	var pdfStandard string
+	if req.PDFA {
+		pdfStandard = pdfStandardA2b
+	}
+	compileCtx, compileSpan := startSpan(ctx, "render.typst_compile", attribute.String("pdf.standard", pdfStandard))
+	pdfBytes, err := s.typst.GeneratePDF(compileCtx, typstSource, rootDir, pdfStandard, fontDirs...)
+	endSpan(compileSpan, err)
+	if err != nil {
+		return nil, compileError(ctx, "failed to generate PDF", err)
+	}
+
+	result, err := s.postProcess(ctx, req, pdfBytes, signatureFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate filename from document title
+	result.Filename = s.generateFilename(req.Document.Meta.Title)
+	if result.PageCount == 0 {
+		result.PageCount = pageCount
+	}
+	return result, nil
+}
+
+// renderImage rasterizes the requested page of the compiled document to PNG.
+func (s *Service) renderImage(ctx context.Context, req *port.RenderPreviewRequest, typstSource, rootDir string, pageCount int, fontDirs []string) (
+	_ *port.RenderPreviewResult, err error,
+) {
+	page, ppi := imageParams(req.Image)
+	compileCtx, compileSpan := startSpan(ctx, "render.typst_compile",
+		attribute.String("render.format", "png"),
+		attribute.Int("render.page", page),
+		attribute.Int("render.ppi", ppi),
+	)
+	png, err := s.typst.GeneratePNG(compileCtx, typstSource, rootDir, page, ppi, fontDirs...)
+	endSpan(compileSpan, err)
+	if err != nil {
+		return nil, compileError(ctx, "failed to generate image", err)
+	}
+	if len(png) == 0 {
+		return nil, fmt.Errorf("%w: page %d", entity.ErrRenderPageNotFound, page)
+	}
+
+	return &port.RenderPreviewResult{
+		Image:     png,
+		Filename:  strings.TrimSuffix(s.generateFilename(req.Document.Meta.Title), ".pdf") + ".png",
+		PageCount: pageCount,
+	}, nil
+}
+
+// compileError categorizes a failed Typst compile. A compile killed because ctx was
+// cancelled reports the context error rather than a render failure.
+func compileError(ctx context.Context, msg string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%s: %w", msg, ctxErr)
+	}
+	return entity.WithCategory(entity.ErrRenderFailed, fmt.Errorf("%s: %w", msg, err))
+}
+
+// convert resolves injectables and builds the Typst source for the request.
+func (s *Service) convert(ctx context.Context, req *port.RenderPreviewRequest) (
+	builder *TypstBuilder, typstSource string, pageCount int, signatureFields []port.SignatureField, err error,
+) {
+	ctx, span := startSpan(ctx, "render.convert")
+	defer func() { endSpan(span, err) }()
+
+	// Reject oversized documents before anything else walks them
+	if err := s.limits.CheckExpanded(req.Document, req.Slots); err != nil {
+		return nil, "", 0, nil, err
+	}
+	// Slot content is also checked alone, so an oversized slot fails even when no insertion point shows it
+	for _, nodes := range req.Slots {
+		if err := s.limits.CheckNodes(nodes); err != nil {
+			return nil, "", 0, nil, err
+		}
+	}
+	tokens, err := s.themeTokens(req.Theme)
+	if err != nil {
+		return nil, "", 0, nil, err
+	}
+	static := req.Document.IsStatic()
+	span.SetAttributes(attribute.Bool("render.static", static))
+
+	// Static documents read no render inputs, so injectable resolution is skipped entirely
+	injectables, signerRoleValues := req.Injectables, req.SignerRoleValues
+	switch {
+	case static:
+		injectables, signerRoleValues = nil, map[string]port.SignerRoleValue{}
+	case signerRoleValues == nil:
 		signerRoleValues = s.resolveSignerRoleValues(req.Document.SignerRoles, req.Injectables)
 	}
 
@@ -99,46 +242,165 @@ func (s *Service) RenderPreview(ctx context.Context, req *port.RenderPreviewRequ
 	}
 
 	// Create converter for this request
-	converter := s.converterFactory(req.Injectables, injectableDefaults, signerRoleValues, req.Document.SignerRoles, fieldResponses)
+	converter := s.converterFactory(injectables, injectableDefaults, signerRoleValues, req.Document.SignerRoles, fieldResponses)
+	if req.DefaultResolver != nil && !static {
+		converter.SetDefaultResolver(func(code string) (any, bool) {
+			value, ok := req.DefaultResolver(ctx, code)
+			slog.DebugContext(ctx, "injectable resolved from provider default",
+				slog.String("code", code),
+				slog.Bool("found", ok),
+			)
+			return value, ok
+		})
+	}
 
-	// Build Typst document
-	builder := NewTypstBuilder(converter, s.tokens)
-	typstSource, pageCount, signatureFields := builder.Build(req.Document)
+	converter.SetDraftMode(req.DraftMode)
+	converter.SetShowPlaceholders(req.ShowPlaceholders)
+	converter.SetShowHiddenConditionals(req.ShowHiddenConditionals)
+	converter.SetRenderTime(renderTime(req.Timezone))
+	converter.SetDebugAnchors(s.debugAnchors)
+	converter.SetSlots(req.Slots)
+	if req.Theme != "" {
+		converter.SetDesignTokens(tokens)
+	}
 
-	// Resolve remote images
-	remoteImages := builder.RemoteImages()
-	remoteImages = s.resolveStorageEntries(ctx, remoteImages)
-	rootDir, renames, cleanup, err := s.resolveRemoteImages(ctx, remoteImages)
-	if err != nil {
-		return nil, err
+	builder = NewTypstBuilder(converter, tokens)
+	if req.BlockIndex != nil {
+		typstSource, signatureFields, err = builder.BuildBlock(req.Document, *req.BlockIndex)
+		if err != nil {
+			return nil, "", 0, nil, err
+		}
+		pageCount = 1
+	} else {
+		typstSource, pageCount, signatureFields = builder.Build(req.Document)
 	}
-	if cleanup != nil {
-		defer cleanup()
+	span.SetAttributes(attribute.Int("render.signature_fields", len(signatureFields)))
+	return builder, typstSource, pageCount, signatureFields, nil
+}
+
+// themeTokens returns the design tokens of the named theme, or the default tokens for "".
+func (s *Service) themeTokens(theme string) (TypstDesignTokens, error) {
+	if theme == "" {
+		return s.tokens, nil
 	}
-	for oldName, newName := range renames {
-		typstSource = strings.ReplaceAll(typstSource, oldName, newName)
+	tokens, ok := s.themes[theme]
+	if !ok {
+		return TypstDesignTokens{}, fmt.Errorf("%w: %q", entity.ErrUnknownTheme, theme)
 	}
+	return tokens, nil
+}
 
-	// Generate PDF using Typst
-	pdfBytes, err := s.typst.GeneratePDF(ctx, typstSource, rootDir)
+// HasTheme reports whether the renderer was configured with the named theme.
+func (s *Service) HasTheme(name string) bool {
+	return s.themes.HasTheme(name)
+}
+
+// fetchImages resolves storage and remote images into a directory Typst can read.
+func (s *Service) fetchImages(ctx context.Context, images map[string]string) (
+	rootDir string, renames map[string]string, cleanup func(), err error,
+) {
+	ctx, span := startSpan(ctx, "render.images", attribute.Int("render.images", len(images)))
+	defer func() { endSpan(span, err) }()
+
+	images = s.resolveStorageEntries(ctx, images)
+	if err := ctx.Err(); err != nil {
+		return "", nil, nil, err
+	}
+	return s.resolveRemoteImages(ctx, images)
+}
+
+// fetchFonts downloads the request's font files from storage into a temporary directory
+// for Typst to search. Returns "" when there is nothing to fetch. Fonts that fail to
+// download or aren't TrueType/OpenType are skipped, so text falls back to other fonts.
+func (s *Service) fetchFonts(ctx context.Context, keys []string) (dir string, cleanup func(), err error) {
+	if len(keys) == 0 || s.storageAdapter == nil {
+		return "", nil, nil
+	}
+	ctx, span := startSpan(ctx, "render.fonts", attribute.Int("render.fonts", len(keys)))
+	defer func() { endSpan(span, err) }()
+
+	dir, err = os.MkdirTemp("", "typst-fonts-*")
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+		return "", nil, fmt.Errorf("failed to create font dir: %w", err)
+	}
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			os.RemoveAll(dir)
+			return "", nil, err
+		}
+		data, err := s.storageAdapter.Download(ctx, &port.StorageRequest{Key: key, Environment: entity.EnvironmentProd})
+		if err != nil {
+			slog.WarnContext(ctx, "font not found for PDF render", slog.String("key", key), slog.Any("error", err))
+			continue
+		}
+		ext, ok := fontFileExtensions[http.DetectContentType(data)]
+		if !ok {
+			slog.WarnContext(ctx, "skipping unsupported font file", slog.String("key", key))
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("font-%d%s", i, ext)), data, 0o600); err != nil {
+			os.RemoveAll(dir)
+			return "", nil, fmt.Errorf("failed to write font: %w", err)
+		}
+	}
+	return dir, func() { os.RemoveAll(dir) }, nil
+}
+
+// fontFileExtensions maps sniffed font content types to the file extensions Typst loads.
+var fontFileExtensions = map[string]string{
+	"font/ttf":        ".ttf",
+	"font/otf":        ".otf",
+	"font/collection": ".ttc",
+}
+
+// postProcess checks PDF/A conformance, locates signature anchors and applies encryption.
+func (s *Service) postProcess(ctx context.Context, req *port.RenderPreviewRequest, pdfBytes []byte, signatureFields []port.SignatureField) (
+	_ *port.RenderPreviewResult, err error,
+) {
+	ctx, span := startSpan(ctx, "render.post_process")
+	defer func() { endSpan(span, err) }()
+
+	if req.PDFA {
+		if err := verifyPDFA(pdfBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	// The compiled PDF is the source of truth for pages: the converter only counts explicit breaks
+	pageCount, countErr := pdfPageCount(pdfBytes)
+	if countErr != nil {
+		slog.WarnContext(ctx, "counting PDF pages failed, using the estimate", slog.Any("error", countErr))
 	}
 
 	// Extract actual anchor positions from generated PDF
 	if len(signatureFields) > 0 {
 		signatureFields = s.extractAndUpdatePositions(ctx, pdfBytes, signatureFields)
+		signatureFields = clampFieldPages(signatureFields, pageCount)
 	}
+	result := &port.RenderPreviewResult{SignatureFields: signatureFields, PageCount: pageCount}
 
-	// Generate filename from document title
-	filename := s.generateFilename(req.Document.Meta.Title)
+	// Extract after anchor positions so pages reflect where the signatures landed
+	if req.ExtractSignaturePages {
+		if result.SignaturePages = signaturePageNumbers(signatureFields); len(result.SignaturePages) > 0 {
+			if result.SignaturePagesPDF, err = extractPages(pdfBytes, result.SignaturePages); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	return &port.RenderPreviewResult{
-		PDF:             pdfBytes,
-		Filename:        filename,
-		PageCount:       pageCount,
-		SignatureFields: signatureFields,
-	}, nil
+	// Encrypt last: anchor extraction above reads the unencrypted PDF
+	if req.Encryption != nil {
+		if pdfBytes, err = encryptPDF(pdfBytes, req.Encryption); err != nil {
+			return nil, err
+		}
+		if result.SignaturePagesPDF != nil {
+			if result.SignaturePagesPDF, err = encryptPDF(result.SignaturePagesPDF, req.Encryption); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result.PDF = pdfBytes
+	return result, nil
 }
 
 // resolveStorageEntries converts storage:// entries in the images map to data: URIs
@@ -180,7 +442,10 @@ func (s *Service) resolveRemoteImages(ctx context.Context, images map[string]str
 	}
 
 	if s.imageCache != nil {
-		renames := s.imageCache.ResolveImages(ctx, images, s.httpClient)
+		renames, err := s.imageCache.ResolveImages(ctx, images, s.httpClient)
+		if err != nil {
+			return "", nil, nil, err
+		}
 		return s.imageCache.Dir(), renames, nil, nil
 	}
 
@@ -189,7 +454,11 @@ func (s *Service) resolveRemoteImages(ctx context.Context, images map[string]str
 		return "", nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 
-	renames, dlErr := downloadImages(ctx, images, tmpDir, s.httpClient)
+	renames, dlErr := downloadImages(ctx, images, tmpDir, s.httpClient, s.maxImageDim)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		os.RemoveAll(tmpDir)
+		return "", nil, nil, ctxErr
+	}
 	if dlErr != nil {
 		slog.WarnContext(ctx, "some images failed to download", slog.Any("error", dlErr))
 	}
@@ -351,6 +620,8 @@ func (s *Service) updateFieldPositions(
 	for i := range updated {
 		pos, ok := positions[updated[i].AnchorString]
 		if !ok {
+			slog.DebugContext(ctx, "anchor not found, keeping estimated page",
+				"anchor", updated[i].AnchorString, "page", updated[i].Page)
 			continue
 		}
 		s.setRawPosition(ctx, &updated[i], pos)
@@ -370,29 +641,9 @@ func (s *Service) setRawPosition(ctx context.Context, field *port.SignatureField
 		"x", pos.X, "y", pos.Y, "anchorW", pos.Width, "page", pos.Page)
 }
 
-// acquireSlot blocks until a render slot is available or the timeout expires.
-func (s *Service) acquireSlot(ctx context.Context) error {
-	if s.sem == nil {
-		return nil
-	}
-	timer := time.NewTimer(s.acquireTimeout)
-	defer timer.Stop()
-	select {
-	case s.sem <- struct{}{}:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-timer.C:
-		return entity.ErrRendererBusy
-	}
-}
-
-// releaseSlot returns a render slot to the pool.
-func (s *Service) releaseSlot() {
-	if s.sem == nil {
-		return
-	}
-	<-s.sem
+// RenderPoolStats returns a snapshot of the render pool.
+func (s *Service) RenderPoolStats() port.RenderPoolStats {
+	return s.pool.Stats()
 }
 
 // Close releases resources held by the service.
@@ -407,4 +658,7 @@ func (s *Service) Close() error {
 }
 
 // Ensure Service implements port.PDFRenderer
-var _ port.PDFRenderer = (*Service)(nil)
+var (
+	_ port.PDFRenderer       = (*Service)(nil)
+	_ port.RenderPoolMonitor = (*Service)(nil)
+)
@@ -2,7 +2,12 @@ package pdfrenderer
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
@@ -283,3 +288,230 @@ func TestRenderPreview_RoleVariableFromInjectables(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestRenderPreview_PDFAEmbedsConformanceMarker(t *testing.T) {
+	service, err := NewService(DefaultTypstOptions(), nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer service.Close()
+
+	doc := &portabledoc.Document{
+		Version: portabledoc.CurrentVersion,
+		Meta:    portabledoc.Meta{Title: "Archived contract", Language: "en"},
+		PageConfig: portabledoc.PageConfig{
+			FormatID: portabledoc.PageFormatA4,
+			Width:    794,
+			Height:   1123,
+			Margins:  portabledoc.Margins{Top: 96, Bottom: 96, Left: 72, Right: 72},
+		},
+		Content: &portabledoc.ProseMirrorDoc{
+			Type: "doc",
+			Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeText, Text: strPtr("Kept for ten years.")},
+				}},
+			},
+		},
+	}
+
+	result, err := service.RenderPreview(context.Background(), &port.RenderPreviewRequest{Document: doc, PDFA: true})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+
+	part, conformance, err := pdfaIdentification(result.PDF)
+	if err != nil {
+		t.Fatalf("reading PDF/A identification: %v", err)
+	}
+	if part != "2" || conformance != "B" {
+		t.Fatalf("expected PDF/A-2b marker, got part %q conformance %q", part, conformance)
+	}
+}
+
+func TestRenderPreview_SignaturePageAfterOverflow(t *testing.T) {
+	service, err := NewService(DefaultTypstOptions(), nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer service.Close()
+
+	// No explicit page breaks: the clauses overflow onto later pages on their own.
+	clause := strings.Repeat("The parties agree to the terms set out in this clause. ", 12)
+	content := make([]portabledoc.Node, 0, 41)
+	for range 40 {
+		content = append(content, portabledoc.Node{
+			Type:    portabledoc.NodeTypeParagraph,
+			Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: strPtr(clause)}},
+		})
+	}
+	content = append(content, portabledoc.Node{
+		Type: portabledoc.NodeTypeSignature,
+		Attrs: map[string]any{
+			"count":      float64(1),
+			"layout":     "single-center",
+			"lineWidth":  "md",
+			"signatures": []any{map[string]any{"id": "sig_1", "roleId": "role_1", "label": "Client"}},
+		},
+	})
+
+	doc := &portabledoc.Document{
+		Version: portabledoc.CurrentVersion,
+		Meta:    portabledoc.Meta{Title: "Long Contract", Language: "en"},
+		PageConfig: portabledoc.PageConfig{
+			FormatID: portabledoc.PageFormatA4,
+			Width:    794,
+			Height:   1123,
+			Margins:  portabledoc.Margins{Top: 96, Bottom: 96, Left: 72, Right: 72},
+		},
+		SignerRoles: []portabledoc.SignerRole{{
+			ID:    "role_1",
+			Label: "Client",
+			Name:  portabledoc.FieldValue{Type: "text", Value: "Client"},
+			Email: portabledoc.FieldValue{Type: "text", Value: "client@example.com"},
+			Order: 1,
+		}},
+		Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: content},
+	}
+
+	result, err := service.RenderPreview(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+
+	pages, err := pdfPageCount(result.PDF)
+	if err != nil {
+		t.Fatalf("counting pages: %v", err)
+	}
+	if pages < 2 {
+		t.Fatalf("expected the clauses to overflow, got %d page(s)", pages)
+	}
+	if result.PageCount != pages {
+		t.Errorf("PageCount = %d, want %d", result.PageCount, pages)
+	}
+	if len(result.SignatureFields) != 1 {
+		t.Fatalf("expected one signature field, got %d", len(result.SignatureFields))
+	}
+	if got := result.SignatureFields[0].Page; got != pages {
+		t.Errorf("signature page = %d, want the last page %d", got, pages)
+	}
+}
+
+func TestRenderPreview_CoverPageAddsUnnumberedFirstPage(t *testing.T) {
+	service, err := NewService(DefaultTypstOptions(), nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer service.Close()
+
+	doc := staticDocument()
+	doc.CoverPage = &portabledoc.CoverPage{
+		Enabled: true,
+		Title:   &portabledoc.FieldValue{Type: portabledoc.FieldTypeText, Value: "Proposal"},
+		Margins: &portabledoc.Margins{},
+	}
+	doc.Content.Content = append(doc.Content.Content, portabledoc.Node{
+		Type: portabledoc.NodeTypeSignature,
+		Attrs: map[string]any{
+			"count":      float64(1),
+			"layout":     "single-center",
+			"lineWidth":  "md",
+			"signatures": []any{map[string]any{"id": "sig_1", "roleId": "role_1", "label": "Client"}},
+		},
+	})
+
+	result, err := service.RenderPreview(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+	if result.PageCount != 2 {
+		t.Fatalf("PageCount = %d, want the cover plus one body page", result.PageCount)
+	}
+	if len(result.SignatureFields) != 1 || result.SignatureFields[0].Page != 2 {
+		t.Fatalf("expected the signature on the body page after the cover, got %+v", result.SignatureFields)
+	}
+}
+
+// slowTransport stands in for a slow image host: each request blocks until its
+// context is cancelled.
+type slowTransport struct {
+	started chan string
+}
+
+func (t *slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.started <- req.URL.String()
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+// imageDocument is a static document referencing the given remote images.
+func imageDocument(urls ...string) *portabledoc.Document {
+	doc := staticDocument()
+	for _, url := range urls {
+		doc.Content.Content = append(doc.Content.Content, portabledoc.Node{
+			Type:  portabledoc.NodeTypeImage,
+			Attrs: map[string]any{"src": url, "width": float64(100)},
+		})
+	}
+	return doc
+}
+
+func TestRenderPreview_CancelDuringImageFetch(t *testing.T) {
+	transport := &slowTransport{started: make(chan string, 4)}
+	s := themedService()
+	s.httpClient = &http.Client{Transport: transport}
+	s.pool = NewRenderPool(1, 0, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-transport.started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		// s.typst is nil, so reaching the compile step would panic
+		_, err := s.RenderPreview(ctx, &port.RenderPreviewRequest{
+			Document: imageDocument("https://example.com/a.png", "https://example.com/b.png"),
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("render did not return after cancellation")
+	}
+	if n := len(transport.started); n != 0 {
+		t.Errorf("expected no image fetches after cancellation, got %d", n)
+	}
+	if stats := s.pool.Stats(); stats.InFlight != 0 {
+		t.Errorf("expected the render slot to be released, got %d in flight", stats.InFlight)
+	}
+}
+
+func TestRenderPreview_CancelledBeforeFetch(t *testing.T) {
+	transport := &slowTransport{started: make(chan string, 4)}
+	s := themedService()
+	s.httpClient = &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.converterFactory = func(injectables map[string]any, defaults map[string]string, roles map[string]port.SignerRoleValue,
+		signerRoles []portabledoc.SignerRole, responses map[string]json.RawMessage,
+	) TypstConverter {
+		// Cancel while the document is being converted
+		cancel()
+		return NewTypstConverterFactory(s.tokens)(injectables, defaults, roles, signerRoles, responses)
+	}
+
+	_, err := s.RenderPreview(ctx, &port.RenderPreviewRequest{Document: imageDocument("https://example.com/a.png")})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := len(transport.started); n != 0 {
+		t.Errorf("expected no image fetches, got %d", n)
+	}
+}
@@ -0,0 +1,67 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// signaturePageNumbers returns the sorted, distinct pages holding signature fields.
+func signaturePageNumbers(fields []port.SignatureField) []int {
+	pages := make([]int, 0, len(fields))
+	for _, f := range fields {
+		if f.Page > 0 && !slices.Contains(pages, f.Page) {
+			pages = append(pages, f.Page)
+		}
+	}
+	slices.Sort(pages)
+	return pages
+}
+
+// pdfPageCount returns the number of pages in the PDF.
+func pdfPageCount(pdfBytes []byte) (int, error) {
+	pdfcpuConfigOnce.Do(api.DisableConfigDir)
+
+	n, err := api.PageCount(bytes.NewReader(pdfBytes), model.NewDefaultConfiguration())
+	if err != nil {
+		return 0, fmt.Errorf("counting PDF pages: %w", err)
+	}
+	return n, nil
+}
+
+// clampFieldPages keeps estimated field pages within the compiled document. Fields whose
+// anchor was not found keep the converter's estimate, which can overshoot when weak page
+// breaks were dropped. A pageCount of 0 (unknown) leaves the fields as they are.
+func clampFieldPages(fields []port.SignatureField, pageCount int) []port.SignatureField {
+	if pageCount <= 0 {
+		return fields
+	}
+	clamped := make([]port.SignatureField, len(fields))
+	for i, f := range fields {
+		f.Page = min(f.Page, pageCount)
+		clamped[i] = f
+	}
+	return clamped
+}
+
+// extractPages returns a PDF holding only the given 1-indexed pages, in document order.
+func extractPages(pdfBytes []byte, pages []int) ([]byte, error) {
+	pdfcpuConfigOnce.Do(api.DisableConfigDir)
+
+	selected := make([]string, len(pages))
+	for i, p := range pages {
+		selected[i] = strconv.Itoa(p)
+	}
+
+	var out bytes.Buffer
+	if err := api.Trim(bytes.NewReader(pdfBytes), &out, selected, model.NewDefaultConfiguration()); err != nil {
+		return nil, fmt.Errorf("extracting signature pages: %w", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,172 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// multiPagePDF builds an n-page PDF whose page i (1-indexed) is 100+i points wide,
+// so extracted pages can be told apart by their width. Pages share one empty content stream.
+func multiPagePDF(n int) []byte {
+	kids := make([]string, n)
+	objects := []string{"<< /Type /Catalog /Pages 2 0 R >>", "", "<< /Length 0 >>\nstream\n\nendstream"}
+	for i := range n {
+		kids[i] = fmt.Sprintf("%d 0 R", i+4)
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d 842] /Contents 3 0 R /Resources << >> >>", 101+i))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pageNumbersByWidth maps each page of a multiPagePDF-derived PDF back to its original page number.
+func pageNumbersByWidth(t *testing.T, pdfBytes []byte) []int {
+	t.Helper()
+	dims, err := api.PageDims(bytes.NewReader(pdfBytes), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("reading page dimensions: %v", err)
+	}
+	pages := make([]int, len(dims))
+	for i, d := range dims {
+		pages[i] = int(d.Width) - 100
+	}
+	return pages
+}
+
+func TestSignaturePageNumbers(t *testing.T) {
+	fields := []port.SignatureField{{Page: 4}, {Page: 2}, {Page: 4}, {Page: 0}, {Page: 7}}
+	if got, want := signaturePageNumbers(fields), []int{2, 4, 7}; !slices.Equal(got, want) {
+		t.Errorf("signaturePageNumbers = %v, want %v", got, want)
+	}
+	if got := signaturePageNumbers(nil); len(got) != 0 {
+		t.Errorf("expected no pages, got %v", got)
+	}
+}
+
+func TestExtractPages_KeepsOnlySignaturePages(t *testing.T) {
+	fields := []port.SignatureField{{RoleID: "buyer", Page: 5}, {RoleID: "seller", Page: 2}, {RoleID: "witness", Page: 5}}
+	pages := signaturePageNumbers(fields)
+
+	extracted, err := extractPages(multiPagePDF(6), pages)
+	if err != nil {
+		t.Fatalf("extractPages failed: %v", err)
+	}
+
+	if got := pageNumbersByWidth(t, extracted); !slices.Equal(got, []int{2, 5}) {
+		t.Errorf("extracted pages = %v, want [2 5]", got)
+	}
+}
+
+func TestPostProcess_ExtractSignaturePages(t *testing.T) {
+	s := &Service{}
+	pdfBytes := multiPagePDF(3)
+	fields := []port.SignatureField{{RoleID: "buyer", AnchorString: "__sig_buyer__", Page: 3}}
+
+	t.Run("requested", func(t *testing.T) {
+		result, err := s.postProcess(context.Background(), &port.RenderPreviewRequest{ExtractSignaturePages: true}, pdfBytes, fields)
+		if err != nil {
+			t.Fatalf("postProcess failed: %v", err)
+		}
+		if !bytes.Equal(result.PDF, pdfBytes) {
+			t.Error("the full document must be returned unchanged")
+		}
+		if !slices.Equal(result.SignaturePages, []int{3}) {
+			t.Errorf("SignaturePages = %v, want [3]", result.SignaturePages)
+		}
+		if got := pageNumbersByWidth(t, result.SignaturePagesPDF); !slices.Equal(got, []int{3}) {
+			t.Errorf("extracted pages = %v, want [3]", got)
+		}
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		result, err := s.postProcess(context.Background(), &port.RenderPreviewRequest{}, pdfBytes, fields)
+		if err != nil {
+			t.Fatalf("postProcess failed: %v", err)
+		}
+		if result.SignaturePages != nil || result.SignaturePagesPDF != nil {
+			t.Errorf("expected no extract, got pages %v", result.SignaturePages)
+		}
+	})
+
+	t.Run("no signatures", func(t *testing.T) {
+		result, err := s.postProcess(context.Background(), &port.RenderPreviewRequest{ExtractSignaturePages: true}, pdfBytes, nil)
+		if err != nil {
+			t.Fatalf("postProcess failed: %v", err)
+		}
+		if result.SignaturePagesPDF != nil {
+			t.Error("expected no extract for a document without signatures")
+		}
+	})
+}
+
+func TestPDFPageCount(t *testing.T) {
+	n, err := pdfPageCount(multiPagePDF(4))
+	if err != nil {
+		t.Fatalf("pdfPageCount failed: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("pdfPageCount = %d, want 4", n)
+	}
+
+	if _, err := pdfPageCount([]byte("not a pdf")); err == nil {
+		t.Error("expected an error for an invalid PDF")
+	}
+}
+
+func TestClampFieldPages(t *testing.T) {
+	fields := []port.SignatureField{{RoleID: "buyer", Page: 2}, {RoleID: "seller", Page: 6}}
+
+	clamped := clampFieldPages(fields, 4)
+	if clamped[0].Page != 2 || clamped[1].Page != 4 {
+		t.Errorf("pages = [%d %d], want [2 4]", clamped[0].Page, clamped[1].Page)
+	}
+	if fields[1].Page != 6 {
+		t.Error("the input fields must not be modified")
+	}
+
+	if got := clampFieldPages(fields, 0); got[1].Page != 6 {
+		t.Errorf("an unknown page count must keep the estimate, got %d", got[1].Page)
+	}
+}
+
+func TestPostProcess_PageCountFromPDF(t *testing.T) {
+	s := &Service{}
+	// Anchors are absent from the fixture, so the estimated pages are kept within the document.
+	fields := []port.SignatureField{
+		{RoleID: "buyer", AnchorString: "__sig_buyer__", Page: 2},
+		{RoleID: "seller", AnchorString: "__sig_seller__", Page: 7},
+	}
+
+	result, err := s.postProcess(context.Background(), &port.RenderPreviewRequest{}, multiPagePDF(5), fields)
+	if err != nil {
+		t.Fatalf("postProcess failed: %v", err)
+	}
+	if result.PageCount != 5 {
+		t.Errorf("PageCount = %d, want 5", result.PageCount)
+	}
+	if got := []int{result.SignatureFields[0].Page, result.SignatureFields[1].Page}; !slices.Equal(got, []int{2, 5}) {
+		t.Errorf("field pages = %v, want [2 5]", got)
+	}
+}
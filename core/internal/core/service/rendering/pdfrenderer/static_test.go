@@ -0,0 +1,88 @@
+package pdfrenderer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func staticDocument() *portabledoc.Document {
+	paragraphs := make([]portabledoc.Node, 0, 40)
+	for i := range 40 {
+		paragraphs = append(paragraphs, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeText, Text: strPtr(fmt.Sprintf("Clause %d applies to every customer.", i+1))},
+		}})
+	}
+	return &portabledoc.Document{
+		Version:    portabledoc.CurrentVersion,
+		Meta:       portabledoc.Meta{Title: "Terms", Language: "en"},
+		PageConfig: portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123},
+		Content:    &portabledoc.ProseMirrorDoc{Type: "doc", Content: paragraphs},
+	}
+}
+
+func dynamicDocument() *portabledoc.Document {
+	doc := staticDocument()
+	doc.Content.Content = append(doc.Content.Content, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+		{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "client_name"}},
+	}})
+	return doc
+}
+
+func TestConvert_StaticFastPathMatchesFullResolution(t *testing.T) {
+	tokens := DefaultDesignTokens()
+	factory := NewTypstConverterFactory(tokens)
+	s := &Service{converterFactory: factory, tokens: tokens}
+	doc := staticDocument()
+	if !doc.IsStatic() {
+		t.Fatal("fixture must be static")
+	}
+	injectables := map[string]any{"client_name": "Ada"}
+
+	full := NewTypstBuilder(factory(injectables, map[string]string{}, s.resolveSignerRoleValues(nil, injectables), nil, nil), tokens)
+	want, wantPages, _ := full.Build(doc)
+
+	resolverCalled := false
+	_, got, gotPages, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{
+		Document:    doc,
+		Injectables: injectables,
+		DefaultResolver: func(context.Context, string) (any, bool) {
+			resolverCalled = true
+			return nil, false
+		},
+	})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	if got != want || gotPages != wantPages {
+		t.Fatalf("static fast path changed the output:\n--- full ---\n%s\n--- fast ---\n%s", want, got)
+	}
+	if resolverCalled {
+		t.Fatal("static documents must not consult the default resolver")
+	}
+}
+
+// BenchmarkRenderBatch compares a batch of renders with distinct injectable values:
+// dynamic documents render each one, static documents render once and hit the cache.
+func BenchmarkRenderBatch(b *testing.B) {
+	for name, doc := range map[string]*portabledoc.Document{"dynamic": dynamicDocument(), "static": staticDocument()} {
+		b.Run(name, func(b *testing.B) {
+			r := NewCachedRenderer(fakeTypstService(b), NewMemoryRenderCache(b.N+1), 0)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := range b.N {
+				_, err := r.RenderPreview(ctx, &port.RenderPreviewRequest{
+					VersionID:   "v1",
+					Document:    doc,
+					Injectables: map[string]any{"client_name": fmt.Sprintf("Customer %d", i)},
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pdfrenderer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// ThemingRenderer applies the rendered version's workspace default theme to renders
+// that don't select a theme themselves. A default theme that is no longer registered
+// is skipped with a warning so the render falls back to the default tokens.
+type ThemingRenderer struct {
+	inner      port.PDFRenderer
+	themes     port.ThemeRegistry
+	templates  port.TemplateRepository
+	versions   port.TemplateVersionRepository
+	workspaces port.WorkspaceRepository
+}
+
+// NewThemingRenderer wraps a renderer with workspace default themes.
+func NewThemingRenderer(
+	inner port.PDFRenderer,
+	themes port.ThemeRegistry,
+	templates port.TemplateRepository,
+	versions port.TemplateVersionRepository,
+	workspaces port.WorkspaceRepository,
+) *ThemingRenderer {
+	return &ThemingRenderer{inner: inner, themes: themes, templates: templates, versions: versions, workspaces: workspaces}
+}
+
+// RenderPreview sets the workspace default theme on the request when it has none, then renders it.
+func (r *ThemingRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	if req.Theme != "" || req.VersionID == "" {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	theme, err := r.workspaceTheme(ctx, req.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	if theme == "" {
+		return r.inner.RenderPreview(ctx, req)
+	}
+	if !r.themes.HasTheme(theme) {
+		slog.WarnContext(ctx, "workspace default theme is not registered, rendering with default tokens",
+			slog.String("version_id", req.VersionID),
+			slog.String("theme", theme),
+		)
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	themed := *req
+	themed.Theme = theme
+	return r.inner.RenderPreview(ctx, &themed)
+}
+
+// workspaceTheme returns the default theme of the workspace owning the version, or "".
+func (r *ThemingRenderer) workspaceTheme(ctx context.Context, versionID string) (string, error) {
+	workspaceID, err := renderWorkspaceID(ctx, r.templates, r.versions, versionID)
+	if err != nil {
+		return "", err
+	}
+	workspace, err := r.workspaces.FindByID(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("loading rendered workspace: %w", err)
+	}
+	if workspace.DefaultTheme == nil {
+		return "", nil
+	}
+	return *workspace.DefaultTheme, nil
+}
+
+// RenderPoolStats reports the wrapped renderer's pool, if it has one.
+func (r *ThemingRenderer) RenderPoolStats() port.RenderPoolStats {
+	if monitor, ok := r.inner.(port.RenderPoolMonitor); ok {
+		return monitor.RenderPoolStats()
+	}
+	return port.RenderPoolStats{}
+}
+
+// Close closes the wrapped renderer.
+func (r *ThemingRenderer) Close() error {
+	return r.inner.Close()
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package pdfrenderer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	templaterepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateversionrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_repo"
+	workspacerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_repo"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// sourceRenderer returns the Typst source instead of compiling it, so tests can
+// inspect token-dependent markup without the typst binary.
+type sourceRenderer struct {
+	svc *Service
+}
+
+func (r *sourceRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	_, source, _, _, err := r.svc.convert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &port.RenderPreviewResult{PDF: []byte(source)}, nil
+}
+
+func (r *sourceRenderer) Close() error { return nil }
+
+const themedContent = `{"version":"1.1.0","meta":{"title":"Doc","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":[],"signerRoles":[],"content":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Terms"}]},{"type":"horizontalRule"}]}}`
+
+func TestThemingRenderer_AppliesWorkspaceDefaultTheme(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Theme Tenant", "THEM01")
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Theme Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Themed", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, templateID) })
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, versionID, []byte(themedContent))
+
+	workspaces := workspacerepo.New(pool)
+	renderer := NewThemingRenderer(&sourceRenderer{svc: themedService()}, Themes{"brand": brandTokens()},
+		templaterepo.New(pool), templateversionrepo.New(pool), workspaces)
+
+	setDefaultTheme := func(theme *string) {
+		workspace, err := workspaces.FindByID(ctx, workspaceID)
+		require.NoError(t, err)
+		workspace.DefaultTheme = theme
+		require.NoError(t, workspaces.Update(ctx, workspace))
+	}
+	render := func(theme string) string {
+		result, err := renderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+			VersionID: versionID,
+			Document:  portabledoc.MustParse([]byte(themedContent)),
+			Theme:     theme,
+		})
+		require.NoError(t, err)
+		return string(result.PDF)
+	}
+
+	t.Run("no default theme", func(t *testing.T) {
+		source := render("")
+		assert.Contains(t, source, `fill: rgb("#333333")`)
+		assert.NotContains(t, source, "#0055aa")
+	})
+
+	t.Run("workspace default theme", func(t *testing.T) {
+		brand := "brand"
+		setDefaultTheme(&brand)
+		t.Cleanup(func() { setDefaultTheme(nil) })
+
+		source := render("")
+		assert.Contains(t, source, `fill: rgb("#0055aa")`)
+		assert.Contains(t, source, `stroke: 0.5pt + rgb("#0055aa")`)
+	})
+
+	t.Run("unregistered default theme falls back", func(t *testing.T) {
+		retired := "retired"
+		setDefaultTheme(&retired)
+		t.Cleanup(func() { setDefaultTheme(nil) })
+
+		source := render("")
+		assert.Contains(t, source, `fill: rgb("#333333")`)
+	})
+
+	t.Run("explicit theme is validated", func(t *testing.T) {
+		_, err := renderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+			VersionID: versionID,
+			Document:  portabledoc.MustParse([]byte(themedContent)),
+			Theme:     "missing",
+		})
+		require.ErrorIs(t, err, entity.ErrUnknownTheme)
+	})
+}
@@ -0,0 +1,81 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// brandTokens returns default tokens with a distinctive base text color.
+func brandTokens() TypstDesignTokens {
+	tokens := DefaultDesignTokens()
+	tokens.BaseTextColor = "#0055aa"
+	tokens.HRStrokeColor = "rgb(\"#0055aa\")"
+	return tokens
+}
+
+func themedService() *Service {
+	tokens := DefaultDesignTokens()
+	return &Service{
+		converterFactory: NewTypstConverterFactory(tokens),
+		tokens:           tokens,
+		themes:           Themes{"brand": brandTokens()},
+	}
+}
+
+func TestConvert_AppliesTheme(t *testing.T) {
+	s := themedService()
+	doc := staticDocument()
+	doc.Content.Content = append(doc.Content.Content, portabledoc.Node{Type: portabledoc.NodeTypeHR})
+
+	_, plain, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	_, themed, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: doc, Theme: "brand"})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	if !strings.Contains(plain, `fill: rgb("#333333")`) || strings.Contains(plain, "#0055aa") {
+		t.Fatalf("unthemed render must use the default tokens:\n%s", plain)
+	}
+	if !strings.Contains(themed, `fill: rgb("#0055aa")`) {
+		t.Fatalf("themed render must use the theme's text color:\n%s", themed)
+	}
+	if !strings.Contains(themed, `stroke: 0.5pt + rgb("#0055aa")`) {
+		t.Fatalf("themed render must pass the theme to the converter:\n%s", themed)
+	}
+}
+
+func TestConvert_RejectsUnknownTheme(t *testing.T) {
+	s := themedService()
+
+	_, _, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{Document: staticDocument(), Theme: "missing"})
+
+	if !errors.Is(err, entity.ErrUnknownTheme) {
+		t.Fatalf("expected ErrUnknownTheme, got %v", err)
+	}
+}
+
+func TestRenderCacheKey_CoversTheme(t *testing.T) {
+	req := &port.RenderPreviewRequest{VersionID: "v1", Document: staticDocument()}
+	plain, err := RenderCacheKey(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	themed := *req
+	themed.Theme = "brand"
+	branded, err := RenderCacheKey(&themed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain == branded {
+		t.Fatal("renders with different themes must not share a cache entry")
+	}
+}
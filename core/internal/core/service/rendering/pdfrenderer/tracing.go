@@ -0,0 +1,47 @@
+package pdfrenderer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+const tracerName = "github.com/rendis/doc-assembly/core/pdfrenderer"
+
+// startSpan starts a span on the global tracer provider, a no-op unless tracing is configured.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// renderAttributes describes a render without its injectable values, which may hold personal data.
+func renderAttributes(req *port.RenderPreviewRequest) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Bool("render.draft", req.DraftMode),
+		attribute.Bool("render.pdfa", req.PDFA),
+		attribute.Bool("render.encrypted", req.Encryption != nil),
+	}
+	if req.VersionID != "" {
+		attrs = append(attrs, attribute.String("version.id", req.VersionID))
+	}
+	if req.SealWorkspaceID != "" {
+		attrs = append(attrs, attribute.String("workspace.id", req.SealWorkspaceID))
+	}
+	if req.BlockIndex != nil {
+		attrs = append(attrs, attribute.Int("render.block_index", *req.BlockIndex))
+	}
+	return attrs
+}
@@ -0,0 +1,102 @@
+package pdfrenderer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// recordSpans installs an in-memory span recorder as the global tracer provider.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+// fakeTypstService builds a Service whose typst binary is a script printing a fixed PDF.
+func fakeTypstService(t testing.TB) *Service {
+	t.Helper()
+	dir := t.TempDir()
+	pdfPath := filepath.Join(dir, "out.pdf")
+	if err := os.WriteFile(pdfPath, minimalPDF(""), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(dir, "typst")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\ncat >/dev/null\ncat "+pdfPath+"\n"), 0o700); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+	service, err := NewService(TypstOptions{BinPath: bin}, nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Fatalf("NewService failed: %v", err)
+	}
+	t.Cleanup(func() { _ = service.Close() })
+	return service
+}
+
+func TestRenderPreview_EmitsStageSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	service := fakeTypstService(t)
+
+	doc := &portabledoc.Document{
+		Version: portabledoc.CurrentVersion,
+		Meta:    portabledoc.Meta{Title: "Contract", Language: "en"},
+		Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "client_name"}},
+			}},
+		}},
+	}
+	_, err := service.RenderPreview(context.Background(), &port.RenderPreviewRequest{
+		VersionID:       "ver-1",
+		SealWorkspaceID: "ws-1",
+		Document:        doc,
+		Injectables:     map[string]any{"client_name": "Ada Lovelace"},
+	})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	root, ok := spans["render"]
+	if !ok {
+		t.Fatalf("expected a render span, got %v", recorder.Ended())
+	}
+	for _, name := range []string{"render.convert", "render.images", "render.typst_compile", "render.post_process"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("missing span %q", name)
+		}
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of render", name)
+		}
+	}
+
+	attrs := make(map[string]string)
+	for _, span := range recorder.Ended() {
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+	}
+	if attrs["version.id"] != "ver-1" || attrs["workspace.id"] != "ws-1" {
+		t.Errorf("expected version and workspace attributes, got %v", attrs)
+	}
+	for key, value := range attrs {
+		if value == "Ada Lovelace" {
+			t.Errorf("attribute %q carries an injectable value", key)
+		}
+	}
+}
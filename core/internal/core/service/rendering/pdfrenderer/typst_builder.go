@@ -2,9 +2,12 @@ package pdfrenderer
 
 import (
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"strings"
 
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 )
@@ -23,6 +26,11 @@ const (
 	headerSurfaceMinPx    = headerTextHeightPx + (headerSurfacePadPx * 2)
 )
 
+const (
+	defaultWatermarkOpacity = 0.15
+	defaultWatermarkAngle   = -45.0 // bottom-left to top-right
+)
+
 type headerRenderMetrics struct {
 	surfaceMinHeightPt   float64
 	surfaceVerticalPadPt float64
@@ -51,38 +59,185 @@ func NewTypstBuilder(converter TypstConverter, tokens TypstDesignTokens) *TypstB
 // Returns the Typst source, page count, and signature fields.
 func (b *TypstBuilder) Build(doc *portabledoc.Document) (string, int, []port.SignatureField) {
 	var sb strings.Builder
+	pageCount, signatureFields, _ := b.BuildTo(&sb, doc) // strings.Builder never fails
+	return sb.String(), pageCount, signatureFields
+}
+
+// BuildTo writes a complete Typst document to w, streaming the content node by node
+// so large documents can go straight to a file or the compiler's stdin.
+// Returns the page count, signature fields and the first write error.
+func (b *TypstBuilder) BuildTo(w io.Writer, doc *portabledoc.Document) (int, []port.SignatureField, error) {
+	var sb strings.Builder
+
+	hasHeader := doc.Header != nil && doc.Header.Enabled
+	b.writePreamble(&sb, doc, hasHeader)
+
+	// Render cover page (own page and margins, not numbered)
+	pagesBeforeBody := 0
+	if doc.CoverPage.IsEnabled() {
+		sb.WriteString(b.coverPageBlock(doc.CoverPage, &doc.PageConfig))
+		pagesBeforeBody = coverPageCount
+	}
+
+	// Render header block (letterhead, first body page only)
+	if doc.Header != nil && doc.Header.Enabled {
+		sb.WriteString(b.headerBlock(doc.Header, &doc.PageConfig))
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return 0, nil, err
+	}
+
+	// Render content via converter; the converter counts body pages only
+	if doc.Content != nil {
+		signatureFields, err := b.converter.WriteNodes(w, doc.Content.Content)
+		return b.converter.GetCurrentPage() + pagesBeforeBody, offsetFieldPages(signatureFields, pagesBeforeBody), err
+	}
+
+	return 1 + pagesBeforeBody, nil, nil
+}
+
+// BuildBlock creates a Typst document containing only the top-level content block at
+// index, for live preview of an edited region. It keeps the page width, margins and
+// typography but drops the header and cover page and sizes the page to the block.
+// List numbering and page counters restart, since the block is rendered on its own.
+// Returns ErrRenderBlockNotFound for a bad index.
+func (b *TypstBuilder) BuildBlock(doc *portabledoc.Document, index int) (string, []port.SignatureField, error) {
+	if doc.Content == nil || index < 0 || index >= len(doc.Content.Content) {
+		return "", nil, fmt.Errorf("%w: index %d", entity.ErrRenderBlockNotFound, index)
+	}
+
+	var sb strings.Builder
+	b.writePreamble(&sb, doc, false)
+	sb.WriteString("#set page(height: auto)\n\n")
+
+	typstContent, signatureFields := b.converter.ConvertNodes(doc.Content.Content[index : index+1])
+	sb.WriteString(typstContent)
+	return sb.String(), signatureFields, nil
+}
+
+// writePreamble sizes the converter to the page and sets its language, then writes
+// imports, page setup, typography and heading styles.
+func (b *TypstBuilder) writePreamble(sb *strings.Builder, doc *portabledoc.Document, hasHeader bool) {
+	// Set page dimensions for column and signature field calculations, and the language
+	// first: document properties and the watermark format injectable values with it
+	b.converter.SetPageWidthPx(doc.PageConfig.Width)
+	b.converter.SetContentWidthPx(doc.PageConfig.Width - doc.PageConfig.Margins.Left - doc.PageConfig.Margins.Right)
+	b.converter.SetLanguage(doc.Meta.Language)
+
+	// Document properties (PDF info dictionary)
+	sb.WriteString(b.documentSetup(&doc.Meta))
 
 	// Package imports
 	sb.WriteString("#import \"@preview/wrap-it:0.1.1\": wrap-content\n\n")
 
 	// Page configuration
-	hasHeader := doc.Header != nil && doc.Header.Enabled
 	sb.WriteString(b.pageSetup(&doc.PageConfig, hasHeader))
+	sb.WriteString(b.watermarkSetup(doc.Watermark))
 
 	// Base typography
 	sb.WriteString(b.typographySetup())
+	sb.WriteString(textFlowSetup(doc.TextFlow))
 
 	// Heading styles
 	sb.WriteString(b.headingStyles())
+	sb.WriteString(headingNumberingSetup(doc.HeadingNumbering))
+}
 
-	// Set page dimensions for column and signature field calculations
-	b.converter.SetPageWidthPx(doc.PageConfig.Width)
-	b.converter.SetContentWidthPx(doc.PageConfig.Width - doc.PageConfig.Margins.Left - doc.PageConfig.Margins.Right)
+// documentSetup generates the #set document(...) rule carrying the PDF title, author,
+// subject and keywords. Returns "" when no property resolves to a value.
+func (b *TypstBuilder) documentSetup(meta *portabledoc.Meta) string {
+	title := meta.Title
+	var author, subject string
+	var keywords []string
+	if props := meta.Properties; props != nil {
+		if t := b.resolveFieldValue(props.Title); t != "" {
+			title = t
+		}
+		author = b.resolveFieldValue(props.Author)
+		subject = b.resolveFieldValue(props.Subject)
+		for i := range props.Keywords {
+			if k := b.resolveFieldValue(&props.Keywords[i]); k != "" {
+				keywords = append(keywords, k)
+			}
+		}
+	}
 
-	// Render header block (letterhead, first page only)
-	if doc.Header != nil && doc.Header.Enabled {
-		sb.WriteString(b.headerBlock(doc.Header, &doc.PageConfig))
+	var args []string
+	if title != "" {
+		args = append(args, "title: "+typstString(title))
+	}
+	if author != "" {
+		args = append(args, "author: "+typstString(author))
+	}
+	if subject != "" {
+		// Typst writes description to the PDF Subject entry.
+		args = append(args, "description: "+typstString(subject))
+	}
+	if len(keywords) > 0 {
+		quoted := make([]string, len(keywords))
+		for i, k := range keywords {
+			quoted[i] = typstString(k)
+		}
+		// Trailing comma keeps a single keyword an array.
+		args = append(args, "keywords: ("+strings.Join(quoted, ", ")+",)")
+	}
+	if len(args) == 0 {
+		return ""
 	}
+	return "#set document(" + strings.Join(args, ", ") + ")\n\n"
+}
 
-	// Render content via converter
-	if doc.Content != nil {
-		typstContent, signatureFields := b.converter.ConvertNodes(doc.Content.Content)
-		sb.WriteString(typstContent)
-		pageCount := b.converter.GetCurrentPage()
-		return sb.String(), pageCount, signatureFields
+// resolveFieldValue resolves literal text or joins the values of its injectable references.
+func (b *TypstBuilder) resolveFieldValue(f *portabledoc.FieldValue) string {
+	if f == nil {
+		return ""
+	}
+	if !f.IsInjectable() {
+		return strings.TrimSpace(f.Value)
+	}
+	parts := make([]string, 0, len(f.InjectableRefs()))
+	for _, ref := range f.InjectableRefs() {
+		if v := strings.TrimSpace(b.converter.ResolveInjectableText(ref)); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, f.ResolveSeparator())
+}
+
+// watermarkSetup places the watermark text, rotated, in the background of every page.
+// Returns "" when no watermark is configured or its text resolves empty.
+func (b *TypstBuilder) watermarkSetup(wm *portabledoc.Watermark) string {
+	if wm == nil {
+		return ""
+	}
+	text := wm.Text
+	if wm.HasInjectable() {
+		if value := b.converter.ResolveInjectableText(*wm.InjectableID); value != "" {
+			text = value
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+
+	opacity := defaultWatermarkOpacity
+	if wm.Opacity != nil {
+		opacity = math.Min(math.Max(*wm.Opacity, 0), 1)
+	}
+	angle := defaultWatermarkAngle
+	if wm.Angle != nil {
+		angle = *wm.Angle
+	}
+	color := b.tokens.WatermarkColor
+	if wmColor := hexColor(wm.Color); wmColor != "" {
+		color = wmColor
 	}
 
-	return sb.String(), 1, nil
+	return fmt.Sprintf(
+		"#set page(background: rotate(%.1fdeg, text(size: %s, weight: \"bold\", fill: rgb(\"%s\").transparentize(%.0f%%))[%s]))\n\n",
+		angle, b.tokens.WatermarkFontSize, escapeTypstString(color), (1-opacity)*100, escapeTypst(text),
+	)
 }
 
 // pageSetup generates #set page(...) directive from PageConfig.
@@ -159,6 +314,25 @@ func (b *TypstBuilder) typographySetup() string {
 	return sb.String()
 }
 
+// textFlowSetup sets the widow and orphan costs used when paragraphs break across pages.
+// Returns "" when no cost is configured, leaving Typst's defaults untouched.
+func textFlowSetup(flow *portabledoc.TextFlow) string {
+	if flow == nil {
+		return ""
+	}
+	var costs []string
+	if flow.WidowCost != nil {
+		costs = append(costs, fmt.Sprintf("widow: %d%%", max(*flow.WidowCost, 0)))
+	}
+	if flow.OrphanCost != nil {
+		costs = append(costs, fmt.Sprintf("orphan: %d%%", max(*flow.OrphanCost, 0)))
+	}
+	if len(costs) == 0 {
+		return ""
+	}
+	return "#set text(costs: (" + strings.Join(costs, ", ") + "))\n\n"
+}
+
 // headingStyles generates show rules for heading sizes matching the CSS styles.
 func (b *TypstBuilder) headingStyles() string {
 	var sb strings.Builder
@@ -169,6 +343,19 @@ func (b *TypstBuilder) headingStyles() string {
 	return sb.String()
 }
 
+// headingNumberingSetup numbers headings with the configured pattern.
+// Returns "" when numbering is off or the pattern has no counting symbol.
+func headingNumberingSetup(numbering *portabledoc.HeadingNumbering) string {
+	if numbering == nil {
+		return ""
+	}
+	pattern := numbering.ResolvePattern()
+	if !portabledoc.IsValidHeadingNumberingPattern(pattern) {
+		return ""
+	}
+	return "#set heading(numbering: " + typstString(pattern) + ")\n\n"
+}
+
 // RemoteImages returns the map of remote image URLs to local filenames
 // collected during build by the converter.
 func (b *TypstBuilder) RemoteImages() map[string]string {
@@ -213,19 +400,11 @@ func (b *TypstBuilder) renderHeaderImage(header *portabledoc.DocumentHeader, max
 		attrs["injectableId"] = *header.ImageInjectableID
 	}
 
-	src := b.converter.ResolveImageSource(attrs)
-	if src == "" {
+	imageFilename := b.imagePath(b.converter.ResolveImageSource(attrs))
+	if imageFilename == "" {
 		return ""
 	}
 
-	imageFilename := src
-	if strings.HasPrefix(src, "http://") ||
-		strings.HasPrefix(src, "https://") ||
-		strings.HasPrefix(src, "data:") ||
-		strings.HasPrefix(src, "storage://") {
-		imageFilename = b.converter.RegisterRemoteImage(src)
-	}
-
 	heightPx := headerImageHeightPx
 	if header.ImageHeight != nil && *header.ImageHeight > 0 {
 		heightPx = float64(*header.ImageHeight)
@@ -249,6 +428,18 @@ func (b *TypstBuilder) renderHeaderImage(header *portabledoc.DocumentHeader, max
 	return fmt.Sprintf("#image(%s)", strings.Join(args, ", "))
 }
 
+// imagePath returns the path Typst loads an image source from, registering remote,
+// data and storage sources for download. Local paths are returned as they are.
+func (b *TypstBuilder) imagePath(src string) string {
+	if strings.HasPrefix(src, "http://") ||
+		strings.HasPrefix(src, "https://") ||
+		strings.HasPrefix(src, "data:") ||
+		strings.HasPrefix(src, "storage://") {
+		return b.converter.RegisterRemoteImage(src)
+	}
+	return src
+}
+
 func (b *TypstBuilder) renderHeaderText(nodes []portabledoc.Node, metrics headerRenderMetrics) string {
 	if len(nodes) == 0 {
 		return ""
@@ -1,9 +1,14 @@
 package pdfrenderer
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 )
@@ -11,6 +16,7 @@ import (
 type typstBuilderConverterStub struct {
 	convertedInputs [][]portabledoc.Node
 	remoteImages    map[string]string
+	injectables     map[string]string
 }
 
 func (s *typstBuilderConverterStub) ConvertNodes(nodes []portabledoc.Node) (string, []port.SignatureField) {
@@ -18,6 +24,12 @@ func (s *typstBuilderConverterStub) ConvertNodes(nodes []portabledoc.Node) (stri
 	return "HEADER_TEXT", nil
 }
 
+func (s *typstBuilderConverterStub) WriteNodes(w io.Writer, nodes []portabledoc.Node) ([]port.SignatureField, error) {
+	out, fields := s.ConvertNodes(nodes)
+	_, err := io.WriteString(w, out)
+	return fields, err
+}
+
 func (s *typstBuilderConverterStub) GetCurrentPage() int {
 	return 1
 }
@@ -33,6 +45,24 @@ func (s *typstBuilderConverterStub) SetContentWidthPx(float64) {}
 
 func (s *typstBuilderConverterStub) SetPageWidthPx(float64) {}
 
+func (s *typstBuilderConverterStub) SetLanguage(string) {}
+
+func (s *typstBuilderConverterStub) SetDefaultResolver(func(string) (any, bool)) {}
+
+func (s *typstBuilderConverterStub) SetDraftMode(bool) {}
+
+func (s *typstBuilderConverterStub) SetShowPlaceholders(bool) {}
+
+func (s *typstBuilderConverterStub) SetShowHiddenConditionals(bool) {}
+
+func (s *typstBuilderConverterStub) SetRenderTime(time.Time) {}
+
+func (s *typstBuilderConverterStub) SetDebugAnchors(bool) {}
+
+func (s *typstBuilderConverterStub) SetSlots(map[string][]portabledoc.Node) {}
+
+func (s *typstBuilderConverterStub) SetDesignTokens(TypstDesignTokens) {}
+
 func (s *typstBuilderConverterStub) RegisterRemoteImage(url string) string {
 	if s.remoteImages == nil {
 		s.remoteImages = map[string]string{}
@@ -42,6 +72,10 @@ func (s *typstBuilderConverterStub) RegisterRemoteImage(url string) string {
 	return filename
 }
 
+func (s *typstBuilderConverterStub) ResolveInjectableText(variableID string) string {
+	return s.injectables[variableID]
+}
+
 func (s *typstBuilderConverterStub) ResolveImageSource(attrs map[string]any) string {
 	if src, ok := attrs["src"].(string); ok && src != "" {
 		return src
@@ -430,3 +464,436 @@ func TestTypstBuilderTypographySetup_ConfiguresTextEdgesForCssLikeLineHeight(t *
 		t.Fatalf("expected header base font size override to stay scoped to header text only, got %q", got)
 	}
 }
+
+func blockTestDocument() *portabledoc.Document {
+	headerText := "Letterhead"
+	return &portabledoc.Document{
+		PageConfig: portabledoc.PageConfig{
+			FormatID: portabledoc.PageFormatA4,
+			Width:    794,
+			Height:   1123,
+			Margins:  portabledoc.Margins{Top: 72, Bottom: 72, Left: 72, Right: 72},
+		},
+		Header: &portabledoc.DocumentHeader{
+			Enabled: true,
+			Layout:  portabledoc.HeaderLayoutImageLeft,
+			Content: &portabledoc.ProseMirrorDoc{Content: []portabledoc.Node{paragraphNode(textNode(headerText))}},
+		},
+		Content: &portabledoc.ProseMirrorDoc{
+			Type: "doc",
+			Content: []portabledoc.Node{
+				paragraphNode(textNode("First clause")),
+				paragraphNode(textNode("Second clause")),
+				paragraphNode(textNode("Third clause")),
+			},
+		},
+	}
+}
+
+func TestTypstBuilderBuildBlock_ConvertsOnlySelectedBlock(t *testing.T) {
+	converter := &typstBuilderConverterStub{}
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, err := builder.BuildBlock(doc, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(converter.convertedInputs) != 1 {
+		t.Fatalf("expected a single conversion without header, got %d", len(converter.convertedInputs))
+	}
+	if input := converter.convertedInputs[0]; len(input) != 1 || input[0].Content[0].Text == nil || *input[0].Content[0].Text != "Second clause" {
+		t.Fatalf("expected only the second block to be converted, got %+v", input)
+	}
+	if !strings.Contains(got, "#set page(height: auto)") {
+		t.Fatalf("expected single-block page to size to content, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuildBlock_SubtreeMarkupContainsOnlyThatBlock(t *testing.T) {
+	builder := NewTypstBuilder(newTestConverter(nil, nil), DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, err := builder.BuildBlock(doc, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(got, "Third clause") {
+		t.Fatalf("expected markup for the selected block, got %q", got)
+	}
+	for _, other := range []string{"First clause", "Second clause", "Letterhead"} {
+		if strings.Contains(got, other) {
+			t.Fatalf("expected %q to be omitted from single-block markup, got %q", other, got)
+		}
+	}
+}
+
+func TestTypstBuilderBuildBlock_RejectsOutOfRangeIndex(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	for _, index := range []int{-1, 3} {
+		if _, _, err := builder.BuildBlock(doc, index); !errors.Is(err, entity.ErrRenderBlockNotFound) {
+			t.Fatalf("index %d: expected ErrRenderBlockNotFound, got %v", index, err)
+		}
+	}
+}
+
+func TestTypstBuilderBuild_EmitsWatermarkBackground(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Watermark = &portabledoc.Watermark{
+		Text:    "DRAFT",
+		Opacity: ptrTo(0.3),
+		Angle:   ptrTo(-30.0),
+		Color:   "#cc0000",
+	}
+
+	got, _, _ := builder.Build(doc)
+
+	want := `#set page(background: rotate(-30.0deg, text(size: 96pt, weight: "bold", fill: rgb("#cc0000").transparentize(70%))[DRAFT]))`
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected watermark %q, got %q", want, got)
+	}
+}
+
+func TestTypstBuilderBuild_WatermarkIgnoresInvalidColor(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Watermark = &portabledoc.Watermark{Text: "DRAFT", Color: `red").transparentize(0%))[x]))#panic("x`}
+
+	got, _, _ := builder.Build(doc)
+
+	want := `fill: rgb("#9e9e9e").transparentize(85%))[DRAFT]`
+	if !strings.Contains(got, want) || strings.Contains(got, "panic") {
+		t.Fatalf("expected an invalid watermark color to fall back to the token color %q, got %q", want, got)
+	}
+}
+
+func TestTypstBuilderBuild_WatermarkResolvesInjectable(t *testing.T) {
+	converter := &typstBuilderConverterStub{injectables: map[string]string{"recipient_name": "Ada #1"}}
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Watermark = &portabledoc.Watermark{Text: "CONFIDENTIAL", InjectableID: ptrTo("recipient_name")}
+
+	got, _, _ := builder.Build(doc)
+
+	if !strings.Contains(got, `transparentize(85%))[Ada \#1]))`) {
+		t.Fatalf("expected watermark with resolved injectable and defaults, got %q", got)
+	}
+
+	converter.injectables = nil
+	got, _, _ = builder.Build(doc)
+	if !strings.Contains(got, "[CONFIDENTIAL]") {
+		t.Fatalf("expected fallback text when injectable has no value, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_WatermarkFormatsInDocumentLanguage(t *testing.T) {
+	converter := newTestConverter(map[string]any{"amount": 1234.5}, nil)
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Meta.Language = "es"
+	doc.Watermark = &portabledoc.Watermark{Text: "DRAFT", InjectableID: ptrTo("amount")}
+
+	got, _, _ := builder.Build(doc)
+
+	if !strings.Contains(got, "[1234,5]))") {
+		t.Fatalf("expected watermark formatted with the document language, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_NoWatermarkWhenUnset(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, _ := builder.Build(doc)
+	if strings.Contains(got, "background:") {
+		t.Fatalf("expected no watermark markup, got %q", got)
+	}
+
+	doc.Watermark = &portabledoc.Watermark{Text: "  "}
+	got, _, _ = builder.Build(doc)
+	if strings.Contains(got, "background:") {
+		t.Fatalf("expected blank watermark text to be skipped, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_EmitsTextFlowCosts(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, _ := builder.Build(doc)
+	if strings.Contains(got, "costs:") {
+		t.Fatalf("expected default pagination without text flow config, got %q", got)
+	}
+
+	doc.TextFlow = &portabledoc.TextFlow{WidowCost: ptrTo(1000), OrphanCost: ptrTo(-5)}
+	got, _, _ = builder.Build(doc)
+	if !strings.Contains(got, "#set text(costs: (widow: 1000%, orphan: 0%))") {
+		t.Fatalf("expected widow/orphan costs, got %q", got)
+	}
+
+	doc.TextFlow = &portabledoc.TextFlow{OrphanCost: ptrTo(300)}
+	got, _, _ = builder.Build(doc)
+	if !strings.Contains(got, "#set text(costs: (orphan: 300%))") {
+		t.Fatalf("expected orphan cost only, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_EmitsHeadingNumbering(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, _ := builder.Build(doc)
+	if strings.Contains(got, "#set heading(numbering:") {
+		t.Fatalf("expected headings unnumbered by default, got %q", got)
+	}
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"I.A.1", `#set heading(numbering: "I.A.1")`},
+		{"1.1.1", `#set heading(numbering: "1.1.1")`},
+		{"", `#set heading(numbering: "1.1.1")`},
+		{"1.a)", `#set heading(numbering: "1.a)")`},
+	}
+	for _, tt := range tests {
+		doc.HeadingNumbering = &portabledoc.HeadingNumbering{Pattern: tt.pattern}
+		if got, _, _ := builder.Build(doc); !strings.Contains(got, tt.want) {
+			t.Errorf("pattern %q: expected %q, got %q", tt.pattern, tt.want, got)
+		}
+	}
+
+	doc.HeadingNumbering = &portabledoc.HeadingNumbering{Pattern: "§"}
+	if got, _, _ := builder.Build(doc); strings.Contains(got, "#set heading(numbering:") {
+		t.Fatalf("expected a pattern without counting symbols to be skipped, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_SetsDocumentProperties(t *testing.T) {
+	converter := &typstBuilderConverterStub{injectables: map[string]string{
+		"client_name":  "Ada \"The Countess\"",
+		"company_name": "Analytical Engines",
+		"contract_id":  "C-42",
+	}}
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Meta.Title = "Service Agreement"
+	doc.Meta.Properties = &portabledoc.DocumentProperties{
+		Author:  &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Values: []string{"company_name", "missing"}},
+		Subject: &portabledoc.FieldValue{Type: portabledoc.FieldTypeText, Value: "Services for the\nfiscal year"},
+		Keywords: []portabledoc.FieldValue{
+			{Type: portabledoc.FieldTypeText, Value: "contract"},
+			{Type: portabledoc.FieldTypeInjectable, Value: "contract_id"},
+			{Type: portabledoc.FieldTypeInjectable, Value: "missing"},
+		},
+	}
+
+	got, _, _ := builder.Build(doc)
+
+	want := `#set document(title: "Service Agreement", author: "Analytical Engines", description: "Services for the fiscal year", keywords: ("contract", "C-42",))`
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	doc.Meta.Properties.Title = &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Value: "client_name"}
+	got, _, _ = builder.Build(doc)
+	if !strings.Contains(got, `#set document(title: "Ada \"The Countess\"", `) {
+		t.Fatalf("expected injectable title to override meta title, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_DocumentPropertiesFormatInDocumentLanguage(t *testing.T) {
+	converter := newTestConverter(map[string]any{"amount": 1234.5}, nil)
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.Meta.Language = "es"
+	doc.Meta.Properties = &portabledoc.DocumentProperties{
+		Subject: &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Value: "amount"},
+	}
+
+	got, _, _ := builder.Build(doc)
+
+	if !strings.Contains(got, `#set document(description: "1234,5")`) {
+		t.Fatalf("expected subject formatted with the document language, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_DocumentPropertiesDefaultToTitle(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+
+	got, _, _ := builder.Build(doc)
+	if strings.Contains(got, "#set document(") {
+		t.Fatalf("expected no document rule without title or properties, got %q", got)
+	}
+
+	doc.Meta.Title = "Contract"
+	got, _, _ = builder.Build(doc)
+	if !strings.Contains(got, "#set document(title: \"Contract\")\n") {
+		t.Fatalf("expected title-only document rule, got %q", got)
+	}
+}
+
+// chunkWriter records each write so tests can check output is streamed.
+type chunkWriter struct {
+	buf    bytes.Buffer
+	writes int
+	failAt int // 1-based write that fails; 0 never fails
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes == w.failAt {
+		return 0, errors.New("disk full")
+	}
+	return w.buf.Write(p)
+}
+
+func TestTypstBuilder_BuildToMatchesBuild(t *testing.T) {
+	factory := NewTypstConverterFactory(DefaultDesignTokens())
+	injectables := map[string]any{"client_name": "Ada"}
+	doc := dynamicDocument()
+	doc.Content.Content = append(doc.Content.Content,
+		portabledoc.Node{Type: portabledoc.NodeTypePageBreak},
+		paragraphNode(textNode("After the break")),
+	)
+
+	want, wantPages, wantFields := NewTypstBuilder(factory(injectables, nil, nil, nil, nil), DefaultDesignTokens()).Build(doc)
+
+	var w chunkWriter
+	gotPages, gotFields, err := NewTypstBuilder(factory(injectables, nil, nil, nil, nil), DefaultDesignTokens()).BuildTo(&w, doc)
+	if err != nil {
+		t.Fatalf("BuildTo failed: %v", err)
+	}
+	if got := w.buf.String(); got != want {
+		t.Fatalf("streamed output differs from buffered output:\n--- buffered ---\n%s\n--- streamed ---\n%s", want, got)
+	}
+	if gotPages != wantPages || len(gotFields) != len(wantFields) {
+		t.Fatalf("pages/fields = %d/%d, want %d/%d", gotPages, len(gotFields), wantPages, len(wantFields))
+	}
+	if w.writes <= len(doc.Content.Content)/2 {
+		t.Fatalf("expected content to be written node by node, got %d writes", w.writes)
+	}
+}
+
+func TestTypstBuilder_BuildToReturnsWriteError(t *testing.T) {
+	factory := NewTypstConverterFactory(DefaultDesignTokens())
+	w := &chunkWriter{failAt: 3}
+
+	_, _, err := NewTypstBuilder(factory(nil, nil, nil, nil, nil), DefaultDesignTokens()).BuildTo(w, staticDocument())
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("expected write error, got %v", err)
+	}
+	if w.writes != 3 {
+		t.Fatalf("conversion must stop at the failed write, got %d writes", w.writes)
+	}
+}
+
+func TestTypstBuilderPageSetupMarginUnits(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := portabledoc.MustParse([]byte(`{"pageConfig":{"formatId":"A4","width":794,"height":1123,` +
+		`"margins":{"top":"20mm","bottom":"1in","left":"2.54cm","right":72}}}`))
+
+	got := builder.pageSetup(&doc.PageConfig, false)
+	if !strings.Contains(got, "margin: (top: 56.7pt, bottom: 72.0pt, left: 72.0pt, right: 54.0pt)") {
+		t.Fatalf("expected margins converted to pt, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_CoverPageBeforeBody(t *testing.T) {
+	converter := &typstBuilderConverterStub{injectables: map[string]string{"client_name": "Ada #1"}}
+	builder := NewTypstBuilder(converter, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.CoverPage = &portabledoc.CoverPage{
+		Enabled:  true,
+		ImageURL: ptrTo("https://example.com/cover.jpg"),
+		Title:    &portabledoc.FieldValue{Type: portabledoc.FieldTypeText, Value: "Proposal"},
+		Subtitle: &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Value: "client_name"},
+		Date:     &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Value: "missing"},
+		Margins:  &portabledoc.Margins{Top: 40, Bottom: 40, Left: 40, Right: 40},
+	}
+
+	got, pages, _ := builder.Build(doc)
+
+	cover := strings.Index(got, "#page(margin: (top: 30.0pt, bottom: 30.0pt, left: 30.0pt, right: 30.0pt), numbering: none)[")
+	reset := strings.Index(got, "#counter(page).update(1)")
+	body := strings.Index(got, "HEADER_TEXT")
+	if cover < 0 || reset < cover || body < reset {
+		t.Fatalf("expected cover page, then counter reset, then body; got %q", got)
+	}
+	for _, want := range []string{
+		`image("remote-image-1", width: 595.5pt, height: 842.2pt, fit: "cover")`,
+		"[Proposal]",
+		`[Ada \#1]`,
+	} {
+		if !strings.Contains(got[cover:reset], want) {
+			t.Errorf("expected cover to contain %q, got %q", want, got[cover:reset])
+		}
+	}
+	if strings.Count(got[cover:reset], "#text(size:") != 2 {
+		t.Errorf("expected an unresolved date to be left out, got %q", got[cover:reset])
+	}
+	if pages != 2 {
+		t.Errorf("pages = %d, want the cover plus one body page", pages)
+	}
+
+	doc.CoverPage.Enabled = false
+	if got, _, _ := builder.Build(doc); strings.Contains(got, "numbering: none") {
+		t.Fatalf("expected no cover page when disabled, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_CoverPageTextColor(t *testing.T) {
+	builder := NewTypstBuilder(&typstBuilderConverterStub{}, DefaultDesignTokens())
+	doc := blockTestDocument()
+	doc.CoverPage = &portabledoc.CoverPage{
+		Enabled:   true,
+		Title:     &portabledoc.FieldValue{Type: portabledoc.FieldTypeText, Value: "Proposal"},
+		TextColor: " #FFFFFF ",
+	}
+
+	if got, _, _ := builder.Build(doc); !strings.Contains(got, `#set text(fill: rgb("#FFFFFF"))`) {
+		t.Fatalf("expected the cover text color, got %q", got)
+	}
+
+	doc.CoverPage.TextColor = `white"))#panic("x`
+	if got, _, _ := builder.Build(doc); !strings.Contains(got, `#set text(fill: rgb("#333333"))`) {
+		t.Fatalf("expected an invalid cover color to fall back to the base text color, got %q", got)
+	}
+}
+
+func TestTypstBuilderBuild_CoverPageOffsetsBodyPages(t *testing.T) {
+	factory := NewTypstConverterFactory(DefaultDesignTokens())
+	doc := staticDocument()
+	doc.Content.Content = append(doc.Content.Content,
+		portabledoc.Node{Type: portabledoc.NodeTypePageBreak},
+		portabledoc.Node{
+			Type: portabledoc.NodeTypeSignature,
+			Attrs: map[string]any{
+				"count":      float64(1),
+				"layout":     "single-center",
+				"lineWidth":  "md",
+				"signatures": []any{map[string]any{"id": "sig_1", "roleId": "role_1", "label": "Client"}},
+			},
+		},
+	)
+
+	_, bodyPages, bodyFields := NewTypstBuilder(factory(nil, nil, nil, nil, nil), DefaultDesignTokens()).Build(doc)
+	if bodyPages != 2 || len(bodyFields) != 1 || bodyFields[0].Page != 2 {
+		t.Fatalf("fixture must sign on the second body page, got %d pages and fields %+v", bodyPages, bodyFields)
+	}
+
+	doc.CoverPage = &portabledoc.CoverPage{Enabled: true, Title: &portabledoc.FieldValue{Type: portabledoc.FieldTypeText, Value: "Proposal"}}
+	_, pages, fields := NewTypstBuilder(factory(nil, nil, nil, nil, nil), DefaultDesignTokens()).Build(doc)
+	if pages != bodyPages+1 {
+		t.Errorf("pages = %d, want %d", pages, bodyPages+1)
+	}
+	if len(fields) != 1 || fields[0].Page != 3 {
+		t.Errorf("expected the signature on PDF page 3 after the cover, got %+v", fields)
+	}
+}
@@ -2,40 +2,62 @@ package pdfrenderer
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 )
 
 // evaluateCondition evaluates a conditional node's conditions against injected values.
+// A node without conditions is always visible. A condition that cannot be evaluated
+// because a group or rule has missing or wrong-typed fields counts as false, so content
+// guarded by a broken rule is hidden rather than shown by accident; a warning is logged.
 func (c *typstConverter) evaluateCondition(attrs map[string]any) bool {
 	conditionsRaw, ok := attrs["conditions"]
-	if !ok {
+	if !ok || conditionsRaw == nil {
 		return true
 	}
 	conditionsMap, ok := conditionsRaw.(map[string]any)
 	if !ok {
-		return true
+		return malformedCondition("conditions is not an object", conditionsRaw)
 	}
 	return c.evaluateLogicGroup(conditionsMap)
 }
 
-func (c *typstConverter) evaluateLogicGroup(group map[string]any) bool {
-	logic, _ := group["logic"].(string)
-	childrenRaw, _ := group["children"].([]any)
+// malformedCondition logs a condition that cannot be evaluated and returns false.
+func malformedCondition(reason string, value any) bool {
+	slog.Warn("malformed conditional rule, treating as false",
+		slog.String("reason", reason),
+		slog.String("value", fmt.Sprintf("%.200v", value)),
+	)
+	return false
+}
 
-	if len(childrenRaw) == 0 {
+func (c *typstConverter) evaluateLogicGroup(group map[string]any) bool {
+	childrenRaw, ok := group["children"]
+	if !ok || childrenRaw == nil {
+		return true
+	}
+	children, ok := childrenRaw.([]any)
+	if !ok {
+		return malformedCondition("group children is not an array", childrenRaw)
+	}
+	if len(children) == 0 {
 		return true
 	}
+	logic, _ := group["logic"].(string)
+	if !portabledoc.ValidLogicOperators.Contains(logic) {
+		return malformedCondition("group logic is not AND or OR", group["logic"])
+	}
 
-	for _, childRaw := range childrenRaw {
-		child, ok := childRaw.(map[string]any)
-		if !ok {
-			continue
+	for _, childRaw := range children {
+		result := false
+		if child, ok := childRaw.(map[string]any); ok {
+			result = c.evaluateChild(child)
+		} else {
+			malformedCondition("group child is not an object", childRaw)
 		}
 
-		result := c.evaluateChild(child)
-
 		if logic == portabledoc.LogicAND && !result {
 			return false
 		}
@@ -55,16 +77,28 @@ func (c *typstConverter) evaluateChild(child map[string]any) bool {
 	case portabledoc.LogicTypeRule:
 		return c.evaluateRule(child)
 	default:
-		return false
+		return malformedCondition("child type is not group or rule", child["type"])
 	}
 }
 
 func (c *typstConverter) evaluateRule(rule map[string]any) bool {
 	variableID, _ := rule["variableId"].(string)
+	if variableID == "" {
+		return malformedCondition("rule has no variableId", rule["variableId"])
+	}
 	operator, _ := rule["operator"].(string)
-	valueObj, _ := rule["value"].(map[string]any)
+	if !portabledoc.ValidOperators.Contains(operator) {
+		return malformedCondition("rule operator is unknown", rule["operator"])
+	}
+	var valueObj map[string]any
+	if raw, present := rule["value"]; present && raw != nil {
+		var ok bool
+		if valueObj, ok = raw.(map[string]any); !ok {
+			return malformedCondition("rule value is not an object", raw)
+		}
+	}
 
-	actualValue := c.injectables[variableID]
+	actualValue, _ := c.injectable(variableID)
 	compareValue := c.resolveCompareValue(valueObj)
 
 	return c.compareValues(actualValue, compareValue, operator)
@@ -76,7 +110,8 @@ func (c *typstConverter) resolveCompareValue(valueObj map[string]any) any {
 
 	if valueMode == portabledoc.RuleModeVariable {
 		compareVarID, _ := compareValue.(string)
-		return c.injectables[compareVarID]
+		value, _ := c.injectable(compareVarID)
+		return value
 	}
 	return compareValue
 }
@@ -143,3 +178,74 @@ func (c *typstConverter) compareNumeric(a, b any) int {
 	}
 	return 0
 }
+
+// conditionOperatorLabels are the readable forms of rule operators in condition summaries.
+var conditionOperatorLabels = map[string]string{
+	portabledoc.OpEqual:      "=",
+	portabledoc.OpNotEqual:   "!=",
+	portabledoc.OpEmpty:      "is empty",
+	portabledoc.OpNotEmpty:   "is not empty",
+	portabledoc.OpStartsWith: "starts with",
+	portabledoc.OpEndsWith:   "ends with",
+	portabledoc.OpContains:   "contains",
+	portabledoc.OpGreater:    ">",
+	portabledoc.OpLess:       "<",
+	portabledoc.OpGreaterEq:  ">=",
+	portabledoc.OpLessEq:     "<=",
+	portabledoc.OpBefore:     "before",
+	portabledoc.OpAfter:      "after",
+	portabledoc.OpIsTrue:     "is true",
+	portabledoc.OpIsFalse:    "is false",
+}
+
+// conditionSummary describes a conditional's conditions for hidden-content notes. The
+// editor stores its own summary in the expression attr; documents without one get a
+// summary built from the rules, e.g. "status != active AND amount > 100".
+func conditionSummary(attrs map[string]any) string {
+	if expr, _ := attrs["expression"].(string); strings.TrimSpace(expr) != "" {
+		return strings.TrimSpace(expr)
+	}
+	group, _ := attrs["conditions"].(map[string]any)
+	if summary := summarizeLogicGroup(group); summary != "" {
+		return summary
+	}
+	return "invalid condition"
+}
+
+func summarizeLogicGroup(group map[string]any) string {
+	children, _ := group["children"].([]any)
+	logic, _ := group["logic"].(string)
+	parts := make([]string, 0, len(children))
+	for _, childRaw := range children {
+		child, _ := childRaw.(map[string]any)
+		switch child["type"] {
+		case portabledoc.LogicTypeGroup:
+			if sub := summarizeLogicGroup(child); sub != "" {
+				parts = append(parts, "("+sub+")")
+			}
+		case portabledoc.LogicTypeRule:
+			if rule := summarizeRule(child); rule != "" {
+				parts = append(parts, rule)
+			}
+		}
+	}
+	return strings.Join(parts, " "+logic+" ")
+}
+
+func summarizeRule(rule map[string]any) string {
+	variableID, _ := rule["variableId"].(string)
+	operator, _ := rule["operator"].(string)
+	label, ok := conditionOperatorLabels[operator]
+	if variableID == "" || !ok {
+		return ""
+	}
+	if portabledoc.NoValueOperators.Contains(operator) {
+		return variableID + " " + label
+	}
+	valueObj, _ := rule["value"].(map[string]any)
+	value, ok := valueObj["value"]
+	if !ok || value == nil {
+		value = ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %v", variableID, label, value))
+}
@@ -3,7 +3,7 @@ package pdfrenderer
 // TypstDesignTokens holds all configurable design values for Typst output.
 type TypstDesignTokens struct {
 	// Base typography
-	FontStack        []string // Default font family chain
+	FontStack        []string // Default font family chain; ends with an emoji font so emoji never render as tofu
 	BaseFontSize     string   // Base font size (e.g., "12pt")
 	BaseTextColor    string   // Default text color hex (e.g., "#333333")
 	ParagraphLeading string   // Line spacing within paragraphs (e.g., "0.50em")
@@ -14,10 +14,25 @@ type TypstDesignTokens struct {
 	HeadingWeight string    // Font weight for all headings
 
 	// Block elements
-	BlockquoteFill        string // Blockquote background color
-	BlockquoteStrokeColor string // Blockquote left border color
-	HRStrokeColor         string // Horizontal rule color
-	HighlightDefaultColor string // Default highlight/marker color
+	BlockquoteFill        string          // Blockquote background color
+	BlockquoteStrokeColor string          // Blockquote left border color
+	BlockquoteCiteColor   string          // Blockquote attribution line color
+	BlockquoteStyle       BlockquoteStyle // Default blockquote treatment; a node "style" attr overrides it
+	HRStrokeColor         string          // Horizontal rule color
+	HighlightDefaultColor string          // Default highlight/marker color
+
+	// Images (frame attrs borderWidth/borderRadius/shadow and the credit line)
+	ImageBorderColor string // Border color when the image sets no borderColor
+	ImageShadowColor string // Drop shadow fill, usually translucent
+	ImageCreditColor string // Credit line text color
+
+	// Watermark defaults (used when the document watermark leaves them unset)
+	WatermarkColor    string // Watermark text color
+	WatermarkFontSize string // Watermark font size (e.g., "96pt")
+
+	// Reviewer comments (draft mode only)
+	CommentAnchorFill string // Background of commented text
+	CommentNoteColor  string // Text color of the comment note
 
 	// Table defaults
 	TableStrokeColor       string // Table border color
@@ -30,12 +45,51 @@ type TypstDesignTokens struct {
 	PlaceholderFillBg    string // Placeholder block background
 	PlaceholderStroke    string // Placeholder block border color
 	PlaceholderTextColor string // Placeholder text color
+
+	// i18n label fallbacks: language -> ordered fallback languages tried for list
+	// and table labels when the document language has no label (e.g. "pt": {"es", "en"}).
+	// Regional tags fall back to their base language first; English is always tried last.
+	LabelFallbacks map[string][]string
+
+	// Locale formatting defaults keyed by language ("en", "es", "pt-BR"). Picked by
+	// the document language using the label fallback chain.
+	Locales map[string]LocaleFormat
+
+	// Rounding for currency amounts and two-decimal table/list numbers (default half-up)
+	RoundingMode RoundingMode
+}
+
+// BlockquoteStyle selects how a blockquote is set off from the surrounding text.
+type BlockquoteStyle string
+
+const (
+	BlockquoteBorder BlockquoteStyle = "border" // Left border over a light fill, italic text
+	BlockquoteFilled BlockquoteStyle = "filled" // Light fill without a border, italic text
+	BlockquotePlain  BlockquoteStyle = "plain"  // Indented only: no border, fill, or italic
+)
+
+// LocaleFormat holds the per-language defaults used when a value has no explicit format.
+type LocaleFormat struct {
+	Boolean            string // "True/False" words (e.g. "Sí/No")
+	DecimalSeparator   string // Decimal separator for numbers and currency (e.g. ",")
+	ThousandsSeparator string // Separator between digit groups of integers (e.g. "."); empty disables grouping
+	DateFormat         string // Date pattern for table/list cells (e.g. "DD/MM/YYYY")
+	SignatureLabel     string // Label under a signature line that has none (e.g. "Firma")
+	DateLabel          string // Label of a signature date line that has none (e.g. "Fecha")
+}
+
+// DefaultLocales returns the built-in locale formatting defaults.
+func DefaultLocales() map[string]LocaleFormat {
+	return map[string]LocaleFormat{
+		"en": {Boolean: "Yes/No", DecimalSeparator: ".", ThousandsSeparator: ",", DateFormat: "YYYY-MM-DD", SignatureLabel: "Signature", DateLabel: "Date"},
+		"es": {Boolean: "Sí/No", DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", SignatureLabel: "Firma", DateLabel: "Fecha"},
+	}
 }
 
 // DefaultDesignTokens returns the built-in design tokens matching the current rendering output.
 func DefaultDesignTokens() TypstDesignTokens {
 	return TypstDesignTokens{
-		FontStack:        []string{"Inter", "Arial", "Helvetica Neue", "Liberation Sans", "Libertinus Serif"},
+		FontStack:        []string{"Inter", "Arial", "Helvetica Neue", "Liberation Sans", "Libertinus Serif", "Noto Color Emoji"},
 		BaseFontSize:     "12pt",
 		BaseTextColor:    "#333333",
 		ParagraphLeading: "0.50em",
@@ -46,9 +100,21 @@ func DefaultDesignTokens() TypstDesignTokens {
 
 		BlockquoteFill:        "#f9f9f9",
 		BlockquoteStrokeColor: "luma(200)",
+		BlockquoteCiteColor:   "#757575",
+		BlockquoteStyle:       BlockquoteBorder,
 		HRStrokeColor:         "luma(200)",
 		HighlightDefaultColor: "#ffeb3b",
 
+		ImageBorderColor: "luma(200)",
+		ImageShadowColor: "rgb(0, 0, 0, 64)",
+		ImageCreditColor: "#757575",
+
+		WatermarkColor:    "#9e9e9e",
+		WatermarkFontSize: "96pt",
+
+		CommentAnchorFill: "#fde2b8",
+		CommentNoteColor:  "#8a5300",
+
 		TableStrokeColor:       "luma(200)",
 		TableHeaderFillDefault: "#f5f5f5",
 		TableCellInset:         "(x: 6pt, y: 12pt)",
@@ -58,5 +124,20 @@ func DefaultDesignTokens() TypstDesignTokens {
 		PlaceholderFillBg:    "#fff3cd",
 		PlaceholderStroke:    "#ffc107",
 		PlaceholderTextColor: "#856404",
+
+		LabelFallbacks: map[string][]string{
+			"pt": {"es", "en"},
+		},
+		Locales:      DefaultLocales(),
+		RoundingMode: RoundHalfUp,
 	}
 }
+
+// Themes maps theme names to the design tokens renders use when they select that theme.
+type Themes map[string]TypstDesignTokens
+
+// HasTheme reports whether name is a registered theme.
+func (t Themes) HasTheme(name string) bool {
+	_, ok := t[name]
+	return ok
+}
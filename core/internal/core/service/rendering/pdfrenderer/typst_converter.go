@@ -1,6 +1,9 @@
 package pdfrenderer
 
 import (
+	"io"
+	"time"
+
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 )
@@ -11,6 +14,10 @@ type TypstConverter interface {
 	// Returns the Typst source string and any signature fields found during conversion.
 	ConvertNodes(nodes []portabledoc.Node) (string, []port.SignatureField)
 
+	// WriteNodes is the streaming form of ConvertNodes: each top-level node is written
+	// to w as it is converted. Returns the signature fields and the first write error.
+	WriteNodes(w io.Writer, nodes []portabledoc.Node) ([]port.SignatureField, error)
+
 	// GetCurrentPage returns the current page number (1-indexed).
 	// This accounts for page breaks encountered during conversion.
 	GetCurrentPage() int
@@ -28,10 +35,50 @@ type TypstConverter interface {
 	// Used for computing signature field width as a percentage of full page.
 	SetPageWidthPx(width float64)
 
+	// SetLanguage sets the document language. List and table labels are looked up
+	// in this language (unless the node sets its own) following the label fallback chain.
+	SetLanguage(lang string)
+
+	// SetDefaultResolver sets a fallback consulted for injectables that have neither
+	// an injected value nor a static default. Results are cached for the render.
+	SetDefaultResolver(resolver func(code string) (any, bool))
+
+	// SetDraftMode toggles draft rendering. In draft mode reviewer comments are
+	// rendered as notes next to their anchored text; otherwise they are omitted.
+	SetDraftMode(draft bool)
+
+	// SetShowPlaceholders renders unresolved inline injectors as a muted
+	// [[variableId]] placeholder instead of nothing. Meant for editor previews.
+	SetShowPlaceholders(show bool)
+
+	// SetShowHiddenConditionals renders conditionals whose conditions are false as
+	// a muted [hidden: condition] note instead of nothing. Meant for template QA.
+	SetShowHiddenConditionals(show bool)
+
+	// SetRenderTime sets the moment the built-in render time injectables
+	// (system.now, system.today) resolve to, in the render's timezone.
+	SetRenderTime(now time.Time)
+
+	// SetSlots supplies the content of insertion points, keyed by slot name.
+	// Insertion points whose slot has no content render nothing.
+	SetSlots(slots map[string][]portabledoc.Node)
+
+	// SetDebugAnchors renders signature anchor strings visibly (normal size, red)
+	// so field placement can be checked in the PDF. Anchors are invisible by default.
+	SetDebugAnchors(debug bool)
+
+	// SetDesignTokens replaces the design tokens the converter was created with,
+	// e.g. to render with a theme other than the default.
+	SetDesignTokens(tokens TypstDesignTokens)
+
 	// RegisterRemoteImage registers a URL or data URI for deferred download and
 	// returns the local filename to reference in the Typst source.
 	RegisterRemoteImage(url string) string
 
+	// ResolveInjectableText resolves a variable to display text, falling back to its
+	// static default. Returns "" when the variable has no value.
+	ResolveInjectableText(variableID string) string
+
 	// ResolveImageSource resolves the final image source after injectable substitution.
 	ResolveImageSource(attrs map[string]any) string
 }
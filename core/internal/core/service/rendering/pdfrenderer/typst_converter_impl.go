@@ -3,7 +3,10 @@ package pdfrenderer
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -24,12 +27,25 @@ type typstConverter struct {
 	contentWidthPx           float64                           // page content area width in pixels (for table column calculations)
 	pageWidthPx              float64                           // full page width in pixels (for signature field percentage calculations)
 	currentPage              int
+	pageHasContent           bool // top-level content was written since the last page break
 	signatureFields          []port.SignatureField
 	currentTableHeaderStyles *entity.TableStyles
 	currentTableBodyStyles   *entity.TableStyles
 	remoteImages             map[string]string // URL -> local filename
 	imageCounter             int
-	listDepth                int // tracks nesting depth for user-built lists
+	listDepth                int           // tracks nesting depth for user-built lists
+	lang                     string        // document language, used for i18n label lookups
+	localeFormat             *LocaleFormat // resolved locale defaults for lang (lazily computed)
+	defaultResolver          func(code string) (any, bool)
+	resolvedDefaults         map[string]any                // render-scoped cache of defaultResolver results (nil = miss)
+	draftMode                bool                          // render reviewer comments as notes
+	showPlaceholders         bool                          // render unresolved injectors as [[variableId]]
+	showHiddenConditionals   bool                          // render false conditionals as [hidden: condition]
+	renderTime               time.Time                     // moment the render time injectables resolve to
+	signatureColumns         int                           // columns of the signature block being rendered
+	debugAnchors             bool                          // render signature anchors visibly
+	slots                    map[string][]portabledoc.Node // insertion point content by slot name
+	fillingSlots             map[string]bool               // slots being converted, to stop self-insertion
 }
 
 // NewTypstConverterFactory returns a ConverterFactory that creates real Typst converters.
@@ -75,6 +91,15 @@ func (c *typstConverter) ResolveImageSource(attrs map[string]any) string {
 	return c.resolveImageSource(attrs)
 }
 
+// ResolveInjectableText resolves a variable to display text, falling back to its static default.
+func (c *typstConverter) ResolveInjectableText(variableID string) string {
+	isRoleVar := strings.HasPrefix(variableID, portabledoc.RoleVariablePrefix)
+	if value := c.resolveInjectorValue(variableID, isRoleVar, nil); value != "" {
+		return value
+	}
+	return c.getDefaultValue(variableID)
+}
+
 // SetContentWidthPx sets the page content area width in pixels.
 func (c *typstConverter) SetContentWidthPx(width float64) {
 	c.contentWidthPx = width
@@ -85,6 +110,55 @@ func (c *typstConverter) SetPageWidthPx(width float64) {
 	c.pageWidthPx = width
 }
 
+// SetLanguage sets the document language used as the starting point of i18n label lookups.
+func (c *typstConverter) SetLanguage(lang string) {
+	c.lang = lang
+	c.localeFormat = nil
+}
+
+// SetDefaultResolver sets the fallback used for injectables with no value and no static default.
+func (c *typstConverter) SetDefaultResolver(resolver func(code string) (any, bool)) {
+	c.defaultResolver = resolver
+	c.resolvedDefaults = make(map[string]any)
+}
+
+// SetDraftMode toggles rendering of reviewer comments.
+func (c *typstConverter) SetDraftMode(draft bool) {
+	c.draftMode = draft
+}
+
+// SetShowPlaceholders toggles visible placeholders for unresolved injectors.
+func (c *typstConverter) SetShowPlaceholders(show bool) {
+	c.showPlaceholders = show
+}
+
+// SetShowHiddenConditionals toggles visible notes for conditionals that evaluate to false.
+func (c *typstConverter) SetShowHiddenConditionals(show bool) {
+	c.showHiddenConditionals = show
+}
+
+// SetRenderTime sets the moment the render time injectables resolve to.
+func (c *typstConverter) SetRenderTime(now time.Time) {
+	c.renderTime = now
+}
+
+// SetSlots sets the content of insertion points.
+func (c *typstConverter) SetSlots(slots map[string][]portabledoc.Node) {
+	c.slots = slots
+}
+
+// SetDebugAnchors toggles visible signature anchors.
+func (c *typstConverter) SetDebugAnchors(debug bool) {
+	c.debugAnchors = debug
+}
+
+// SetDesignTokens replaces the converter's design tokens and drops the locale
+// resolved from the previous ones.
+func (c *typstConverter) SetDesignTokens(tokens TypstDesignTokens) {
+	c.tokens = tokens
+	c.localeFormat = nil
+}
+
 // RegisterRemoteImage registers a remote URL or data URL and returns a local filename.
 func (c *typstConverter) RegisterRemoteImage(url string) string {
 	if existing, ok := c.remoteImages[url]; ok {
@@ -98,13 +172,22 @@ func (c *typstConverter) RegisterRemoteImage(url string) string {
 }
 
 // ConvertNodes converts a slice of nodes to Typst markup.
-// It uses look-ahead to group inline images with their following paragraphs
-// for text wrapping via the wrap-it package.
 // Returns the Typst source string and any signature fields found during conversion.
 func (c *typstConverter) ConvertNodes(nodes []portabledoc.Node) (string, []port.SignatureField) {
 	var sb strings.Builder
+	fields, _ := c.WriteNodes(&sb, nodes) // strings.Builder never fails
+	return sb.String(), fields
+}
+
+// WriteNodes converts a slice of nodes to Typst markup, writing each top-level
+// node to w as soon as it is converted so the full source is never held in memory.
+// It uses look-ahead to group inline images with their following paragraphs
+// for text wrapping via the wrap-it package.
+// Returns any signature fields found during conversion and the first write error.
+func (c *typstConverter) WriteNodes(w io.Writer, nodes []portabledoc.Node) ([]port.SignatureField, error) {
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
+		var out string
 		if c.isInlineImage(node) {
 			// Collect consecutive paragraphs after the inline image as wrap body
 			var body []portabledoc.Node
@@ -112,22 +195,28 @@ func (c *typstConverter) ConvertNodes(nodes []portabledoc.Node) (string, []port.
 				body = append(body, nodes[j])
 			}
 			if len(body) > 0 {
-				sb.WriteString(c.wrapImage(node, body))
+				out = c.wrapImage(node, body)
 				i += len(body) // skip consumed paragraphs
 			} else {
-				sb.WriteString(c.image(node)) // no body, fallback to block
+				out = c.image(node) // no body, fallback to block
 			}
 		} else {
-			sb.WriteString(c.convertNode(node))
+			out = c.convertNode(node)
+		}
+		if out != "" && node.Type != portabledoc.NodeTypePageBreak {
+			c.pageHasContent = true
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return c.signatureFields, err
 		}
 	}
-	return sb.String(), c.signatureFields
+	return c.signatureFields, nil
 }
 
 // convertNode converts a single node to Typst markup.
 func (c *typstConverter) convertNode(node portabledoc.Node) string {
-	if handler := c.getNodeHandler(node.Type); handler != nil {
-		return handler(node)
+	if handler, ok := typstNodeHandlers[node.Type]; ok {
+		return handler(c, node)
 	}
 	return c.handleUnknownNode(node)
 }
@@ -141,37 +230,48 @@ func (c *typstConverter) convertNodes(nodes []portabledoc.Node) string {
 	return sb.String()
 }
 
-type typstNodeHandler func(node portabledoc.Node) string
-
-func (c *typstConverter) getNodeHandler(nodeType string) typstNodeHandler {
-	handlers := map[string]typstNodeHandler{
-		portabledoc.NodeTypeParagraph:        c.paragraph,
-		portabledoc.NodeTypeHeading:          c.heading,
-		portabledoc.NodeTypeBlockquote:       c.blockquote,
-		portabledoc.NodeTypeCodeBlock:        c.codeBlock,
-		portabledoc.NodeTypeHR:               c.horizontalRule,
-		portabledoc.NodeTypeBulletList:       c.bulletList,
-		portabledoc.NodeTypeOrderedList:      c.orderedList,
-		portabledoc.NodeTypeTaskList:         c.taskList,
-		portabledoc.NodeTypeListItem:         c.listItem,
-		portabledoc.NodeTypeTaskItem:         c.taskItem,
-		portabledoc.NodeTypeInjector:         c.injector,
-		portabledoc.NodeTypeConditional:      c.conditional,
-		portabledoc.NodeTypeSignature:        c.signature,
-		portabledoc.NodeTypePageBreak:        c.pageBreak,
-		portabledoc.NodeTypeImage:            c.image,
-		portabledoc.NodeTypeCustomImage:      c.image,
-		portabledoc.NodeTypeText:             c.text,
-		portabledoc.NodeTypeListInjector:     c.listInjector,
-		portabledoc.NodeTypeTableInjector:    c.tableInjector,
-		portabledoc.NodeTypeTable:            c.table,
-		portabledoc.NodeTypeTableRow:         c.tableRow,
-		portabledoc.NodeTypeTableCell:        c.tableCellData,
-		portabledoc.NodeTypeTableHeader:      c.tableCellHeader,
-		portabledoc.NodeTypeHardBreak:        c.hardBreak,
-		portabledoc.NodeTypeInteractiveField: c.interactiveField,
-	}
-	return handlers[nodeType]
+// typstNodeHandler converts a node of one type; handlers are method expressions
+// so the dispatch table is built once rather than per node.
+type typstNodeHandler func(c *typstConverter, node portabledoc.Node) string
+
+// typstNodeHandlers maps node types to their converters. It is filled in init
+// because the handlers themselves dispatch through it.
+var typstNodeHandlers map[string]typstNodeHandler
+
+func init() {
+	typstNodeHandlers = map[string]typstNodeHandler{
+		portabledoc.NodeTypeParagraph:        (*typstConverter).paragraph,
+		portabledoc.NodeTypeHeading:          (*typstConverter).heading,
+		portabledoc.NodeTypeBlockquote:       (*typstConverter).blockquote,
+		portabledoc.NodeTypeCodeBlock:        (*typstConverter).codeBlock,
+		portabledoc.NodeTypeHR:               (*typstConverter).horizontalRule,
+		portabledoc.NodeTypeBulletList:       (*typstConverter).bulletList,
+		portabledoc.NodeTypeOrderedList:      (*typstConverter).orderedList,
+		portabledoc.NodeTypeTaskList:         (*typstConverter).taskList,
+		portabledoc.NodeTypeListItem:         (*typstConverter).listItem,
+		portabledoc.NodeTypeTaskItem:         (*typstConverter).taskItem,
+		portabledoc.NodeTypeInjector:         (*typstConverter).injector,
+		portabledoc.NodeTypeConditional:      (*typstConverter).conditional,
+		portabledoc.NodeTypeSignature:        (*typstConverter).signature,
+		portabledoc.NodeTypePageBreak:        (*typstConverter).pageBreak,
+		portabledoc.NodeTypeImage:            (*typstConverter).image,
+		portabledoc.NodeTypeCustomImage:      (*typstConverter).image,
+		portabledoc.NodeTypeText:             (*typstConverter).text,
+		portabledoc.NodeTypeListInjector:     (*typstConverter).listInjector,
+		portabledoc.NodeTypeTableInjector:    (*typstConverter).tableInjector,
+		portabledoc.NodeTypeTable:            (*typstConverter).table,
+		portabledoc.NodeTypeTableRow:         (*typstConverter).tableRow,
+		portabledoc.NodeTypeTableCell:        (*typstConverter).tableCellData,
+		portabledoc.NodeTypeTableHeader:      (*typstConverter).tableCellHeader,
+		portabledoc.NodeTypeHardBreak:        (*typstConverter).hardBreak,
+		portabledoc.NodeTypeInteractiveField: (*typstConverter).interactiveField,
+		portabledoc.NodeTypeInsertionPoint:   (*typstConverter).insertionPoint,
+		portabledoc.NodeTypeMath:             (*typstConverter).math,
+		portabledoc.NodeTypeMathBlock:        (*typstConverter).mathBlock,
+		portabledoc.NodeTypeCrossReference:   (*typstConverter).crossReference,
+		portabledoc.NodeTypeDetails:          (*typstConverter).details,
+		portabledoc.NodeTypeDefinitionList:   (*typstConverter).definitionList,
+	}
 }
 
 func (c *typstConverter) handleUnknownNode(node portabledoc.Node) string {
@@ -184,24 +284,49 @@ func (c *typstConverter) handleUnknownNode(node portabledoc.Node) string {
 // --- Content Nodes ---
 
 func (c *typstConverter) paragraph(node portabledoc.Node) string {
+	brk := c.pageBreakBefore(node.Attrs)
 	content := c.convertNodes(node.Content)
 	if content == "" {
-		return fmt.Sprintf("#v(%s)\n", c.tokens.ParagraphSpacing)
+		return brk + fmt.Sprintf("#v(%s)\n", c.tokens.ParagraphSpacing)
 	}
 
 	leading := c.resolveLineSpacing(node.Attrs)
-	body := c.applyLocalParagraphFormatting(content, node.Attrs, leading)
-	return body + "\n\n"
+	align, _ := node.Attrs["textAlign"].(string)
+	body := c.applyLocalParagraphFormatting(content, align, leading)
+	return brk + body + "\n\n"
 }
 
 func (c *typstConverter) heading(node portabledoc.Node) string {
+	brk := c.pageBreakBefore(node.Attrs)
 	level := c.parseHeadingLevel(node.Attrs)
 	content := c.convertNodes(node.Content)
-	prefix := strings.Repeat("=", level)
-	heading := fmt.Sprintf("%s %s", prefix, content)
+	heading := fmt.Sprintf("%s %s", strings.Repeat("=", level), content)
+	// A heading opts out of document heading numbering with numbered: false
+	if numbered, ok := node.Attrs["numbered"].(bool); ok && !numbered {
+		heading = fmt.Sprintf("#heading(level: %d, numbering: none)[%s]", level, content)
+	}
 	leading := c.resolveLineSpacing(node.Attrs)
-	body := c.applyLocalParagraphFormatting(heading, node.Attrs, leading)
-	return body + "\n"
+	// A justified heading is a single short line: justification adds nothing, so it stays left.
+	align, _ := node.Attrs["textAlign"].(string)
+	if align == "justify" {
+		align = ""
+	}
+	body := c.applyLocalParagraphFormatting(withNodeLabel(node, heading), align, leading)
+	return brk + body + "\n"
+}
+
+// pageBreakBefore returns a weak page break when the block sets pageBreakBefore.
+// A weak break is dropped by Typst at the top of a page, so the page count only
+// advances when content was already written on the current page.
+func (c *typstConverter) pageBreakBefore(attrs map[string]any) string {
+	if on, _ := attrs["pageBreakBefore"].(bool); !on {
+		return ""
+	}
+	if c.pageHasContent {
+		c.currentPage++
+		c.pageHasContent = false
+	}
+	return "#pagebreak(weak: true)\n"
 }
 
 func (c *typstConverter) parseHeadingLevel(attrs map[string]any) int {
@@ -238,11 +363,9 @@ func (c *typstConverter) resolveLineSpacing(attrs map[string]any) string {
 
 func (c *typstConverter) applyLocalParagraphFormatting(
 	content string,
-	attrs map[string]any,
+	align string,
 	leading string,
 ) string {
-	align, _ := attrs["textAlign"].(string)
-
 	if align == "justify" {
 		body := fmt.Sprintf("#par(justify: true)[%s]", content)
 		return wrapTypstBlockWithLineSpacing(body, leading)
@@ -266,20 +389,151 @@ func wrapTypstBlockWithLineSpacing(content, leading string) string {
 
 func (c *typstConverter) blockquote(node portabledoc.Node) string {
 	content := c.convertNodes(node.Content)
-	return fmt.Sprintf(
-		"#block(width: 100%%, inset: (left: 1em, top: 0.5em, bottom: 0.5em, right: 1em), stroke: (left: 2pt + %s), fill: rgb(\"%s\"), above: 0.75em, below: 0.75em)[#emph[%s]]\n",
-		c.tokens.BlockquoteStrokeColor, c.tokens.BlockquoteFill, content,
-	)
+	attribution := c.blockquoteAttribution(node.Attrs)
+	const inset = "inset: (left: 1em, top: 0.5em, bottom: 0.5em, right: 1em)"
+	switch c.blockquoteStyle(node.Attrs) {
+	case BlockquotePlain:
+		return fmt.Sprintf("#block(width: 100%%, %s, above: 0.75em, below: 0.75em)[%s%s]\n", inset, content, attribution)
+	case BlockquoteFilled:
+		return fmt.Sprintf(
+			"#block(width: 100%%, %s, fill: rgb(\"%s\"), above: 0.75em, below: 0.75em)[#emph[%s]%s]\n",
+			inset, c.tokens.BlockquoteFill, content, attribution,
+		)
+	default:
+		return fmt.Sprintf(
+			"#block(width: 100%%, %s, stroke: (left: 2pt + %s), fill: rgb(\"%s\"), above: 0.75em, below: 0.75em)[#emph[%s]%s]\n",
+			inset, c.tokens.BlockquoteStrokeColor, c.tokens.BlockquoteFill, content, attribution,
+		)
+	}
+}
+
+// blockquoteStyle resolves the node's style attr, falling back to the design token.
+// Unknown values render with the default border style.
+func (c *typstConverter) blockquoteStyle(attrs map[string]any) BlockquoteStyle {
+	if style, ok := attrs["style"].(string); ok && style != "" {
+		return BlockquoteStyle(strings.ToLower(strings.TrimSpace(style)))
+	}
+	return c.tokens.BlockquoteStyle
+}
+
+// blockquoteAttribution renders the quote's source ("— Author, Source") from the
+// attribution or cite attr, right-aligned and muted beneath the quote.
+func (c *typstConverter) blockquoteAttribution(attrs map[string]any) string {
+	source, _ := attrs["attribution"].(string)
+	if strings.TrimSpace(source) == "" {
+		source, _ = attrs["cite"].(string)
+	}
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n#align(right)[#text(size: 0.9em, fill: rgb(%q))[— %s]]", c.tokens.BlockquoteCiteColor, escapeTypst(source))
+}
+
+// details renders a collapsible section fully expanded: the summary in bold, then the body.
+func (c *typstConverter) details(node portabledoc.Node) string {
+	summary, _ := node.Attrs["summary"].(string)
+	summary = escapeTypst(strings.TrimSpace(summary))
+	var body []portabledoc.Node
+	for _, child := range node.Content {
+		switch child.Type {
+		case portabledoc.NodeTypeDetailsSummary:
+			summary = strings.TrimSpace(c.convertNodes(child.Content))
+		case portabledoc.NodeTypeDetailsContent:
+			body = append(body, child.Content...)
+		default:
+			body = append(body, child)
+		}
+	}
+
+	var sb strings.Builder
+	if summary != "" {
+		fmt.Fprintf(&sb, "#block(above: 0.75em, below: 0.5em)[#strong[%s]]\n", summary)
+	}
+	sb.WriteString(c.convertNodes(body))
+	return sb.String()
+}
+
+// definitionList renders term/description pairs as a Typst term list. Descriptions
+// before the first term get an empty term; consecutive descriptions share a term.
+func (c *typstConverter) definitionList(node portabledoc.Node) string {
+	type entry struct {
+		term         string
+		descriptions []string
+	}
+	var entries []entry
+	for _, child := range node.Content {
+		switch child.Type {
+		case portabledoc.NodeTypeDefinitionTerm:
+			entries = append(entries, entry{term: strings.TrimSpace(c.convertNodes(child.Content))})
+		case portabledoc.NodeTypeDefinitionDescription:
+			if len(entries) == 0 {
+				entries = append(entries, entry{})
+			}
+			last := &entries[len(entries)-1]
+			last.descriptions = append(last.descriptions, strings.TrimSpace(c.convertNodes(child.Content)))
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#terms(\n")
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "  terms.item[%s][%s],\n", e.term, strings.Join(e.descriptions, "\n\n"))
+	}
+	sb.WriteString(")\n")
+	return sb.String()
 }
 
 func (c *typstConverter) codeBlock(node portabledoc.Node) string {
 	language, _ := node.Attrs["language"].(string)
-	content := c.convertNodes(node.Content)
+	var sb strings.Builder
+	writeCodeText(&sb, node.Content)
+	return rawBlock(sb.String(), language)
+}
+
+// writeCodeText writes the unescaped text of code block content; marks are ignored
+// and hard breaks become newlines.
+func writeCodeText(sb *strings.Builder, nodes []portabledoc.Node) {
+	for _, n := range nodes {
+		switch {
+		case n.Type == portabledoc.NodeTypeHardBreak:
+			sb.WriteByte('\n')
+		case n.Text != nil:
+			sb.WriteString(*n.Text)
+		default:
+			writeCodeText(sb, n.Content)
+		}
+	}
+}
 
-	if language != "" {
-		return fmt.Sprintf("```%s\n%s\n```\n", language, content)
+// math renders an inline equation. Typst reads $...$ without inner padding as inline math.
+func (c *typstConverter) math(node portabledoc.Node) string {
+	src := mathSource(node.Attrs)
+	if src == "" {
+		return ""
+	}
+	return "$" + src + "$"
+}
+
+// mathBlock renders a display equation; the padding inside $ ... $ makes it a block.
+func (c *typstConverter) mathBlock(node portabledoc.Node) string {
+	src := mathSource(node.Attrs)
+	if src == "" {
+		return ""
 	}
-	return fmt.Sprintf("```\n%s\n```\n", content)
+	return "$ " + src + " $\n"
+}
+
+// mathSource returns the Typst math of an equation node: attrs.typst as-is, or attrs.latex translated.
+func mathSource(attrs map[string]any) string {
+	if src, _ := attrs["typst"].(string); strings.TrimSpace(src) != "" {
+		return escapeTypstMath(src)
+	}
+	latex, _ := attrs["latex"].(string)
+	return latexToTypstMath(latex)
 }
 
 func (c *typstConverter) horizontalRule(_ portabledoc.Node) string {
@@ -393,10 +647,24 @@ func (c *typstConverter) injector(node portabledoc.Node) string {
 	suffix, _ := node.Attrs["suffix"].(string)
 	showLabelIfEmpty, _ := node.Attrs["showLabelIfEmpty"].(bool)
 	nodeDefaultValue, _ := node.Attrs["defaultValue"].(string)
-	widthPx, hasWidth := node.Attrs["width"].(float64)
+	widthPx, hasWidth := portabledoc.LengthPx(node.Attrs["width"])
 
-	// Resolve value with priority: injected > node default > global default
+	// Resolve value with priority: injected > fallback variables > fallback defaults >
+	// node default > global default
 	value := c.resolveInjectorValue(variableID, isRoleVar, node.Attrs)
+	fallbackIDs := getStringsAttr(node.Attrs, "fallbackVariableIds")
+	for _, fallbackID := range fallbackIDs {
+		if value != "" {
+			break
+		}
+		value = c.resolveRegularInjectable(fallbackID, node.Attrs)
+	}
+	for _, fallbackID := range fallbackIDs {
+		if value != "" {
+			break
+		}
+		value = c.getDefaultValue(fallbackID)
+	}
 	if value == "" {
 		if nodeDefaultValue != "" {
 			value = nodeDefaultValue
@@ -404,17 +672,28 @@ func (c *typstConverter) injector(node portabledoc.Node) string {
 			value = c.getDefaultValue(variableID)
 		}
 	}
+	transform, _ := node.Attrs["transform"].(string)
+	value = c.transformInjectorValue(value, transform)
 
 	// Empty value handling
 	if value == "" {
+		placeholder := ""
+		if c.showPlaceholders {
+			placeholder = fmt.Sprintf("#text(fill: rgb(\"%s\"))[\\[\\[%s\\]\\]]", c.tokens.PlaceholderTextColor, escapeTypst(variableID))
+		}
 		if showLabelIfEmpty {
-			return escapeTypst(prefix) + escapeTypst(suffix)
+			return escapeTypst(prefix) + placeholder + escapeTypst(suffix)
 		}
-		return ""
+		return placeholder
 	}
 
 	// Build output: prefix + value + suffix
 	content := c.buildInjectorContent(prefix, value, suffix)
+	for _, mark := range node.Marks {
+		if mark.Type == portabledoc.MarkTypeConditionalStyle {
+			content = c.applyConditionalStyleMark(content, mark)
+		}
+	}
 
 	if hasWidth && widthPx > 0 {
 		widthPt := widthPx * pxToPt
@@ -444,12 +723,119 @@ func (c *typstConverter) resolveInjectorValue(variableID string, isRoleVar bool,
 }
 
 func (c *typstConverter) resolveRegularInjectable(variableID string, attrs map[string]any) string {
-	if v, ok := c.injectables[variableID]; ok {
+	if v, ok := c.injectable(variableID); ok {
+		return c.formatInjectableValue(v, attrs)
+	}
+	if v, ok := c.renderTimeValue(variableID, attrs); ok {
+		return v
+	}
+	// Static defaults are applied by the caller and take precedence over the resolver.
+	if c.getDefaultValue(variableID) != "" {
+		return ""
+	}
+	if v, ok := c.resolveFallbackValue(variableID); ok {
 		return c.formatInjectableValue(v, attrs)
 	}
 	return ""
 }
 
+// injectable returns the render value of an injectable key. A namespaced key
+// (e.g. "crm.first_name") matches a flat entry first, then the nested entry
+// {"crm": {"first_name": ...}}, so callers may send either shape.
+func (c *typstConverter) injectable(key string) (any, bool) {
+	return lookupInjectable(c.injectables, key)
+}
+
+// lookupInjectable resolves a key against values. Keys that don't match an entry
+// are read as a path of map keys and slice indexes, e.g. "items[0].name" or
+// "a.b[1].c". Missing keys, out-of-range indexes and paths through the wrong
+// type resolve to nothing.
+func lookupInjectable(values map[string]any, key string) (any, bool) {
+	if v, ok := values[key]; ok {
+		return v, true
+	}
+	end := strings.IndexAny(key, ".[")
+	if end <= 0 {
+		return nil, false
+	}
+	v, ok := values[key[:end]]
+	if !ok {
+		return nil, false
+	}
+	return lookupPath(v, key[end:])
+}
+
+// lookupPath follows the rest of a path (e.g. "[1].name" or ".name") into v.
+func lookupPath(v any, path string) (any, bool) {
+	for strings.HasPrefix(path, "[") {
+		closing := strings.IndexByte(path, ']')
+		if closing < 0 {
+			return nil, false
+		}
+		index, err := strconv.Atoi(path[1:closing])
+		if err != nil {
+			return nil, false
+		}
+		var ok bool
+		if v, ok = indexValue(v, index); !ok {
+			return nil, false
+		}
+		path = path[closing+1:]
+	}
+	if path == "" {
+		return v, true
+	}
+	rest, ok := strings.CutPrefix(path, entity.InjectableNamespaceSeparator)
+	if !ok {
+		return nil, false
+	}
+	nested, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookupInjectable(nested, rest)
+}
+
+// indexValue returns element i of a slice or array value.
+func indexValue(v any, i int) (any, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	if i < 0 || i >= rv.Len() {
+		return nil, false
+	}
+	return rv.Index(i).Interface(), true
+}
+
+// resolveFallbackValue asks the default resolver for the injectable a variable reads and
+// follows the rest of the path into its value: "items[0].name" asks for "items".
+func (c *typstConverter) resolveFallbackValue(variableID string) (any, bool) {
+	if c.defaultResolver == nil {
+		return nil, false
+	}
+	for _, key := range entity.InjectablePathKeys(variableID) {
+		if v, ok := c.resolveFallbackKey(key); ok {
+			return lookupPath(v, variableID[len(key):])
+		}
+	}
+	return nil, false
+}
+
+// resolveFallbackKey queries the default resolver once per code and caches the outcome,
+// including misses, for the rest of the render.
+func (c *typstConverter) resolveFallbackKey(variableID string) (any, bool) {
+	if v, cached := c.resolvedDefaults[variableID]; cached {
+		return v, v != nil
+	}
+	v, ok := c.defaultResolver(variableID)
+	if !ok {
+		v = nil
+	}
+	c.resolvedDefaults[variableID] = v
+	return v, v != nil
+}
+
 func (c *typstConverter) resolveRoleVariable(variableID string, attrs map[string]any) string {
 	roleID, _ := attrs["roleId"].(string)
 	propertyKey, _ := attrs["propertyKey"].(string)
@@ -461,7 +847,7 @@ func (c *typstConverter) resolveRoleVariable(variableID string, attrs map[string
 	}
 
 	// Fallback: try injectables directly for cases like ROLE.Rol_1.email
-	if v, ok := c.injectables[variableID]; ok {
+	if v, ok := c.injectable(variableID); ok {
 		return c.formatInjectableValue(v, attrs)
 	}
 	return ""
@@ -486,48 +872,106 @@ func (c *typstConverter) getDefaultValue(variableID string) string {
 }
 
 func (c *typstConverter) formatInjectableValue(value any, attrs map[string]any) string {
-	injectorType, _ := attrs["type"].(string)
-	format, _ := attrs["format"].(string)
-
 	switch v := value.(type) {
 	case string:
 		return v
 	case float64:
-		return c.formatFloat64(v, injectorType, format)
+		return c.formatFloat64(v, attrs)
 	case int:
-		return strconv.Itoa(v)
+		return c.formatInteger(strconv.Itoa(v), attrs)
 	case int64:
-		return strconv.FormatInt(v, 10)
+		return c.formatInteger(strconv.FormatInt(v, 10), attrs)
 	case bool:
-		return formatBool(v)
+		return c.locale().formatBool(v)
+	case json.Number:
+		// Integers keep their exact digits; float64 loses precision beyond 2^53.
+		// Currency amounts still go through formatFloat64 for their decimals.
+		if i, err := v.Int64(); err == nil && attrs["type"] != portabledoc.InjectorTypeCurrency {
+			return c.formatInteger(strconv.FormatInt(i, 10), attrs)
+		}
+		if f, err := v.Float64(); err == nil {
+			return c.formatFloat64(f, attrs)
+		}
+		return v.String()
+	case time.Time:
+		return c.locale().formatDate(v)
+	case nil:
+		return ""
 	default:
-		return fmt.Sprintf("%v", v)
+		return c.coerceInjectableValue(reflect.ValueOf(v), attrs)
 	}
 }
 
-func (c *typstConverter) formatFloat64(v float64, injectorType, format string) string {
+// coerceInjectableValue handles the types formatInjectableValue does not list: other
+// numeric widths, named scalar types and pointers. Nil pointers render empty, and
+// composite values (maps, slices, structs) use their String method when they have
+// one and render empty otherwise rather than as Go syntax.
+func (c *typstConverter) coerceInjectableValue(rv reflect.Value, attrs map[string]any) string {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return ""
+		}
+		return c.formatInjectableValue(rv.Elem().Interface(), attrs)
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return c.locale().formatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.formatInteger(strconv.FormatInt(rv.Int(), 10), attrs)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.formatInteger(strconv.FormatUint(rv.Uint(), 10), attrs)
+	case reflect.Float32, reflect.Float64:
+		return c.formatInjectableValue(rv.Float(), attrs)
+	default:
+		if s, ok := rv.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		return ""
+	}
+}
+
+func (c *typstConverter) formatFloat64(v float64, attrs map[string]any) string {
+	injectorType, _ := attrs["type"].(string)
+	format, _ := attrs["format"].(string)
+	locale := c.locale()
 	if injectorType == portabledoc.InjectorTypeCurrency {
+		amount := locale.localizeDecimal(roundDecimal(v, 2, c.tokens.RoundingMode))
 		if format != "" {
-			return fmt.Sprintf("%s %.2f", format, v)
+			return format + " " + amount
 		}
-		return fmt.Sprintf("%.2f", v)
+		return amount
 	}
 
 	if v == float64(int64(v)) {
-		return strconv.FormatInt(int64(v), 10)
+		return c.formatInteger(strconv.FormatInt(int64(v), 10), attrs)
 	}
-	return strconv.FormatFloat(v, 'f', -1, 64)
+	return locale.localizeDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// formatInteger groups the digits of an integer with the locale's thousands
+// separator, unless the injector sets grouping: false (IDs, years).
+func (c *typstConverter) formatInteger(digits string, attrs map[string]any) string {
+	if grouping, ok := attrs["grouping"].(bool); ok && !grouping {
+		return digits
+	}
+	return groupDigits(digits, c.locale().ThousandsSeparator)
 }
 
 func (c *typstConverter) conditional(node portabledoc.Node) string {
 	if c.evaluateCondition(node.Attrs) {
 		return c.convertNodes(node.Content)
 	}
+	if c.showHiddenConditionals {
+		return fmt.Sprintf("#text(size: 0.8em, fill: rgb(\"%s\"), style: \"italic\")[\\[hidden: %s\\]]\n\n",
+			c.tokens.PlaceholderTextColor, escapeTypst(conditionSummary(node.Attrs)))
+	}
 	return ""
 }
 
 func (c *typstConverter) pageBreak(_ portabledoc.Node) string {
 	c.currentPage++
+	c.pageHasContent = false
 	return "#pagebreak()\n"
 }
 
@@ -539,8 +983,8 @@ func (c *typstConverter) resolveImageSource(attrs map[string]any) string {
 	src, _ := attrs["src"].(string)
 
 	if injectableId, ok := attrs["injectableId"].(string); ok && injectableId != "" {
-		if resolved, exists := c.injectables[injectableId]; exists {
-			src = fmt.Sprintf("%v", resolved)
+		if resolved, exists := c.injectable(injectableId); exists {
+			src = injectedImageSource(resolved)
 		} else if defaultVal, exists := c.injectableDefaults[injectableId]; exists {
 			src = defaultVal
 		}
@@ -585,8 +1029,9 @@ func (c *typstConverter) imageMarkup(node portabledoc.Node) string {
 		return ""
 	}
 
-	width, _ := node.Attrs["width"].(float64)
-	height, _ := node.Attrs["height"].(float64)
+	width, _ := portabledoc.LengthPx(node.Attrs["width"])
+	height, _ := portabledoc.LengthPx(node.Attrs["height"])
+	width, height = c.fitImageToContent(width, height)
 	shape, _ := node.Attrs["shape"].(string)
 	injectableID, _ := node.Attrs["injectableId"].(string)
 	isInjectableImage := injectableID != ""
@@ -605,8 +1050,13 @@ func (c *typstConverter) imageMarkup(node portabledoc.Node) string {
 		markup = typstImageCall(imgPath, "width: 100%")
 	}
 
+	frame := c.imageFrame(node.Attrs)
 	if shape != "circle" {
-		return markup
+		boxArgs := frame.strokeArgs()
+		if frame.radiusPt > 0 {
+			boxArgs = append(boxArgs, "clip: true", fmt.Sprintf("radius: %.1fpt", frame.radiusPt))
+		}
+		return frame.wrap(markup, boxArgs, fmt.Sprintf("%.1fpt", frame.radiusPt))
 	}
 
 	if height <= 0 {
@@ -615,7 +1065,7 @@ func (c *typstConverter) imageMarkup(node portabledoc.Node) string {
 
 	size := math.Min(width, height) * pxToPt
 	if size <= 0 {
-		return markup
+		return frame.wrap(markup, frame.strokeArgs(), "0pt")
 	}
 
 	imageArgs := []string{"width: 100%", "height: 100%"}
@@ -623,15 +1073,92 @@ func (c *typstConverter) imageMarkup(node portabledoc.Node) string {
 		imageArgs = append(imageArgs, `fit: "contain"`)
 	}
 
+	boxArgs := append([]string{
+		fmt.Sprintf("width: %.0fpt", size),
+		fmt.Sprintf("height: %.0fpt", size),
+		"clip: true",
+		"radius: 50%",
+	}, frame.strokeArgs()...)
+	return frame.wrap(typstImageCall(imgPath, imageArgs...), boxArgs, "50%")
+}
+
+// withImageCredit stacks the image node's credit attr beneath its markup in small muted
+// text. The stack follows the surrounding alignment, so the credit lines up with the image.
+func (c *typstConverter) withImageCredit(node portabledoc.Node, markup string) string {
+	credit, _ := node.Attrs["credit"].(string)
+	if markup == "" || strings.TrimSpace(credit) == "" {
+		return markup
+	}
+	return fmt.Sprintf("#stack(spacing: 4pt, [%s], [#text(size: 0.8em, fill: rgb(\"%s\"))[%s]])",
+		markup, escapeTypstString(c.tokens.ImageCreditColor), escapeTypst(credit))
+}
+
+// imageFrameShadowOffset is how far an image's drop shadow is shifted right and down.
+const imageFrameShadowOffset = "3pt"
+
+// imageFrameStyle is an image's optional border, rounded corners and drop shadow.
+type imageFrameStyle struct {
+	borderPt    float64
+	borderColor string // Typst color expression
+	radiusPt    float64
+	shadow      bool
+	shadowColor string
+}
+
+// imageFrame reads the frame attrs of an image node: borderWidth and borderRadius
+// as lengths, borderColor as a CSS hex color, and shadow.
+func (c *typstConverter) imageFrame(attrs map[string]any) imageFrameStyle {
+	frame := imageFrameStyle{borderColor: c.tokens.ImageBorderColor, shadowColor: c.tokens.ImageShadowColor}
+	if width, _ := portabledoc.LengthPx(attrs["borderWidth"]); width > 0 {
+		frame.borderPt = width * pxToPt
+	}
+	if color := hexColorAttr(attrs, "borderColor"); color != "" {
+		frame.borderColor = fmt.Sprintf("rgb(\"%s\")", color)
+	}
+	if radius, _ := portabledoc.LengthPx(attrs["borderRadius"]); radius > 0 {
+		frame.radiusPt = radius * pxToPt
+	}
+	frame.shadow, _ = attrs["shadow"].(bool)
+	return frame
+}
+
+// strokeArgs returns the #box stroke argument drawing the border, if any.
+func (f imageFrameStyle) strokeArgs() []string {
+	if f.borderPt <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("stroke: %.1fpt + %s", f.borderPt, f.borderColor)}
+}
+
+// wrap puts the image in a #box with boxArgs and, for shadowed images, lays an offset
+// box of the same size and corner radius behind it. Typst has no shadows, so the
+// framed image is measured to size the shadow.
+func (f imageFrameStyle) wrap(markup string, boxArgs []string, radius string) string {
+	if len(boxArgs) > 0 {
+		markup = fmt.Sprintf("#box(%s)[%s]", strings.Join(boxArgs, ", "), markup)
+	}
+	if !f.shadow {
+		return markup
+	}
 	return fmt.Sprintf(
-		"#box(width: %.0fpt, height: %.0fpt, clip: true, radius: 50%%)[%s]",
-		size, size, typstImageCall(imgPath, imageArgs...),
+		"#layout(region => { let framed = [%s]; let size = measure(framed, width: region.width); "+
+			"box(width: size.width, height: size.height)[#place(dx: %s, dy: %s)[#box(width: size.width, height: size.height, radius: %s, fill: %s)]#framed] })",
+		markup, imageFrameShadowOffset, imageFrameShadowOffset, radius, f.shadowColor,
 	)
 }
 
+// fitImageToContent scales an image wider than the page content area down to fit it,
+// keeping its aspect ratio. Smaller widths, and any width when the content area is unknown, are kept.
+func (c *typstConverter) fitImageToContent(width, height float64) (float64, float64) {
+	if c.contentWidthPx <= 0 || width <= c.contentWidthPx {
+		return width, height
+	}
+	return c.contentWidthPx, height * c.contentWidthPx / width
+}
+
 // wrapImage generates a wrap-content block: image + following paragraphs as body.
 func (c *typstConverter) wrapImage(imgNode portabledoc.Node, bodyNodes []portabledoc.Node) string {
-	markup := c.imageMarkup(imgNode)
+	markup := c.withImageCredit(imgNode, c.imageMarkup(imgNode))
 	if markup == "" {
 		return ""
 	}
@@ -652,7 +1179,7 @@ func (c *typstConverter) wrapImage(imgNode portabledoc.Node, bodyNodes []portabl
 
 // image converts an image node to block-mode Typst markup.
 func (c *typstConverter) image(node portabledoc.Node) string {
-	markup := c.imageMarkup(node)
+	markup := withFigure(node, c.withImageCredit(node, c.imageMarkup(node)))
 	if markup == "" {
 		return ""
 	}
@@ -710,7 +1237,7 @@ func (c *typstConverter) interactiveField(node portabledoc.Node) string {
 
 // renderCheckboxField renders checkbox options with checked/unchecked indicators.
 func (c *typstConverter) renderCheckboxField(attrs *portabledoc.InteractiveFieldAttrs) string {
-	selectedIDs := c.resolveSelectedOptionIDs(attrs.ID)
+	selectedIDs := c.resolveSelectedOptionIDs(attrs)
 
 	items := make([]string, 0, len(attrs.Options))
 	for _, opt := range attrs.Options {
@@ -725,13 +1252,14 @@ func (c *typstConverter) renderCheckboxField(attrs *portabledoc.InteractiveField
 }
 
 // renderRadioField renders radio options with selected/unselected indicators.
+// At most one option is selected: a malformed response naming several keeps the first known one.
 func (c *typstConverter) renderRadioField(attrs *portabledoc.InteractiveFieldAttrs) string {
-	selectedIDs := c.resolveSelectedOptionIDs(attrs.ID)
+	selectedID := c.resolveRadioSelection(attrs)
 
 	items := make([]string, 0, len(attrs.Options))
 	for _, opt := range attrs.Options {
 		sym := "○"
-		if selectedIDs[opt.ID] {
+		if selectedID != "" && opt.ID == selectedID {
 			sym = "◉"
 		}
 		items = append(items, fmt.Sprintf("[%s %s]", sym, escapeTypst(opt.Label)))
@@ -753,6 +1281,10 @@ func (c *typstConverter) renderOptionsStack(layout string, items []string) strin
 
 // renderTextField renders a text field with its response value or placeholder.
 func (c *typstConverter) renderTextField(attrs *portabledoc.InteractiveFieldAttrs) string {
+	if attrs.Multiline {
+		return c.renderMultilineTextField(attrs)
+	}
+
 	text := c.resolveTextResponse(attrs.ID)
 	if text != "" {
 		return fmt.Sprintf("  %s\n", escapeTypst(text))
@@ -766,6 +1298,26 @@ func (c *typstConverter) renderTextField(attrs *portabledoc.InteractiveFieldAttr
 	return ""
 }
 
+// renderMultilineTextField renders a multiline response in a bordered box keeping its
+// line breaks, or, without a response, the placeholder over blank ruled rows to write on.
+func (c *typstConverter) renderMultilineTextField(attrs *portabledoc.InteractiveFieldAttrs) string {
+	if text := c.resolveTextResponse(attrs.ID); text != "" {
+		body := strings.ReplaceAll(escapeTypst(strings.ReplaceAll(text, "\r\n", "\n")), "\n", "\\\n")
+		return fmt.Sprintf("  #block(width: 100%%, inset: 6pt, radius: 2pt, stroke: 0.5pt + luma(200))[%s]\n", body)
+	}
+
+	var sb strings.Builder
+	if attrs.Placeholder != "" {
+		fmt.Fprintf(&sb, "  #text(fill: gray)[%s]\n", escapeTypst(attrs.Placeholder))
+	}
+	rows := make([]string, attrs.GetLines())
+	for i := range rows {
+		rows[i] = "block(width: 100%, height: 1.5em, stroke: (bottom: 0.5pt + luma(150)))"
+	}
+	fmt.Fprintf(&sb, "  #stack(dir: ttb, spacing: 0pt, %s)\n", strings.Join(rows, ", "))
+	return sb.String()
+}
+
 // checkboxResponse is used to unmarshal checkbox/radio field responses.
 type checkboxResponse struct {
 	SelectedOptionIDs []string `json:"selectedOptionIds"`
@@ -776,23 +1328,42 @@ type textResponse struct {
 	Text string `json:"text"`
 }
 
-// resolveSelectedOptionIDs looks up and parses selectedOptionIds from field responses.
-func (c *typstConverter) resolveSelectedOptionIDs(fieldID string) map[string]bool {
-	raw, ok := c.fieldResponses[fieldID]
+// resolveSelectedOptionIDs returns the set of selected option ids for a checkbox field.
+func (c *typstConverter) resolveSelectedOptionIDs(attrs *portabledoc.InteractiveFieldAttrs) map[string]bool {
+	selected := c.selectedOptionIDs(attrs)
+	result := make(map[string]bool, len(selected))
+	for _, id := range selected {
+		result[id] = true
+	}
+	return result
+}
+
+// resolveRadioSelection returns the first selected id, in selection order, that names one
+// of the field's options, or "" when none does.
+func (c *typstConverter) resolveRadioSelection(attrs *portabledoc.InteractiveFieldAttrs) string {
+	for _, id := range c.selectedOptionIDs(attrs) {
+		for _, opt := range attrs.Options {
+			if opt.ID == id {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// selectedOptionIDs looks up and parses selectedOptionIds from field responses.
+// Without a response (e.g. template previews) the field's default selection is used.
+func (c *typstConverter) selectedOptionIDs(attrs *portabledoc.InteractiveFieldAttrs) []string {
+	raw, ok := c.fieldResponses[attrs.ID]
 	if !ok || len(raw) == 0 {
-		return nil
+		return attrs.DefaultSelectedOptionIDs
 	}
 
 	var resp checkboxResponse
 	if err := json.Unmarshal(raw, &resp); err != nil {
 		return nil
 	}
-
-	result := make(map[string]bool, len(resp.SelectedOptionIDs))
-	for _, id := range resp.SelectedOptionIDs {
-		result[id] = true
-	}
-	return result
+	return resp.SelectedOptionIDs
 }
 
 // resolveTextResponse looks up and parses text from field responses.
@@ -817,12 +1388,25 @@ func (c *typstConverter) text(node portabledoc.Node) string {
 	}
 
 	txt := escapeTypst(*node.Text)
+	if hasMark(node.Marks, portabledoc.MarkTypeCode) {
+		// Code is the innermost mark and takes the unescaped text.
+		txt = rawInline(*node.Text)
+	}
 	for _, mark := range node.Marks {
 		txt = c.applyMark(txt, mark)
 	}
 	return txt
 }
 
+func hasMark(marks []portabledoc.Mark, markType string) bool {
+	for _, m := range marks {
+		if m.Type == markType {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *typstConverter) applyMark(txt string, mark portabledoc.Mark) string {
 	switch mark.Type {
 	case portabledoc.MarkTypeBold:
@@ -832,8 +1416,7 @@ func (c *typstConverter) applyMark(txt string, mark portabledoc.Mark) string {
 	case portabledoc.MarkTypeStrike:
 		return fmt.Sprintf("#strike[%s]", txt)
 	case portabledoc.MarkTypeCode:
-		// Undo escaping for raw code
-		return fmt.Sprintf("`%s`", unescapeTypst(txt))
+		return txt // already rendered raw by text
 	case portabledoc.MarkTypeUnderline:
 		return fmt.Sprintf("#underline[%s]", txt)
 	case portabledoc.MarkTypeHighlight:
@@ -842,6 +1425,10 @@ func (c *typstConverter) applyMark(txt string, mark portabledoc.Mark) string {
 		return c.applyLinkMark(txt, mark)
 	case portabledoc.MarkTypeTextStyle:
 		return c.applyTextStyleMark(txt, mark)
+	case portabledoc.MarkTypeComment:
+		return c.applyCommentMark(txt, mark)
+	case portabledoc.MarkTypeConditionalStyle:
+		return c.applyConditionalStyleMark(txt, mark)
 	default:
 		return txt
 	}
@@ -863,6 +1450,25 @@ func (c *typstConverter) applyLinkMark(txt string, mark portabledoc.Mark) string
 	return fmt.Sprintf("#link(\"%s\")[%s]", escapeTypstString(href), txt)
 }
 
+// applyCommentMark shades the commented text and attaches the comment as a footnote
+// in draft mode. Final renders leave the text untouched.
+func (c *typstConverter) applyCommentMark(txt string, mark portabledoc.Mark) string {
+	if !c.draftMode {
+		return txt
+	}
+	anchored := fmt.Sprintf("#highlight(fill: rgb(\"%s\"))[%s]", escapeTypstString(c.tokens.CommentAnchorFill), txt)
+
+	body, _ := mark.Attrs["text"].(string)
+	if body == "" {
+		return anchored
+	}
+	note := escapeTypst(body)
+	if author, _ := mark.Attrs["author"].(string); author != "" {
+		note = fmt.Sprintf("#strong[%s:] %s", escapeTypst(author), note)
+	}
+	return fmt.Sprintf("%s#footnote[#text(fill: rgb(\"%s\"))[%s]]", anchored, escapeTypstString(c.tokens.CommentNoteColor), note)
+}
+
 func (c *typstConverter) applyTextStyleMark(txt string, mark portabledoc.Mark) string {
 	var params []string
 
@@ -889,6 +1495,22 @@ func (c *typstConverter) applyTextStyleMark(txt string, mark portabledoc.Mark) s
 	return fmt.Sprintf("#text(%s)[%s]", strings.Join(params, ", "), txt)
 }
 
+// applyConditionalStyleMark styles txt when the mark's conditions hold and leaves it
+// unchanged otherwise. Conditions are evaluated like a conditional node's, except
+// that a mark without conditions never applies.
+func (c *typstConverter) applyConditionalStyleMark(txt string, mark portabledoc.Mark) string {
+	if mark.Attrs["conditions"] == nil || !c.evaluateCondition(mark.Attrs) {
+		return txt
+	}
+	if bold, _ := mark.Attrs["bold"].(bool); bold {
+		txt = fmt.Sprintf("#strong[%s]", txt)
+	}
+	if italic, _ := mark.Attrs["italic"].(bool); italic {
+		txt = fmt.Sprintf("#emph[%s]", txt)
+	}
+	return c.applyTextStyleMark(txt, mark)
+}
+
 // --- Signature Nodes ---
 
 func (c *typstConverter) signature(node portabledoc.Node) string {
@@ -902,7 +1524,8 @@ func (c *typstConverter) collectSignatureFields(attrs portabledoc.SignatureAttrs
 	const defaultHeight = 8.0 // 8% of page height
 
 	// Calculate field width from line width in points.
-	fieldWidth := c.signatureFieldWidthPercent(attrs.LineWidth)
+	blockWidthPt := c.capSignatureLineWidth(c.signatureLineWidth(attrs.LineWidth))
+	columns := signatureLayoutColumns(attrs.Layout, len(attrs.Signatures))
 
 	// Calculate X positions based on layout
 	xPositions := c.calculateXPositions(attrs.Layout, attrs.Count)
@@ -930,19 +1553,16 @@ func (c *typstConverter) collectSignatureFields(attrs portabledoc.SignatureAttrs
 			Page:         c.currentPage,
 			PositionX:    xPos,
 			PositionY:    yPosition,
-			Width:        fieldWidth,
+			Width:        c.signatureFieldWidthPercent(c.signatureItemLineWidth(&sig, blockWidthPt, columns)),
 			Height:       defaultHeight,
 		})
 	}
 }
 
-// signatureFieldWidthPercent converts the signature line width (pt) to a page-width percentage.
-func (c *typstConverter) signatureFieldWidthPercent(lineWidth string) float64 {
+// signatureFieldWidthPercent converts a signature line width (e.g. "180pt") to a page-width percentage.
+func (c *typstConverter) signatureFieldWidthPercent(lwPt string) float64 {
 	const fallbackWidth = 30.0 // 30% fallback
 
-	lwPt := c.signatureLineWidth(lineWidth)
-	lwPt = c.capSignatureLineWidth(lwPt)
-
 	val, err := strconv.ParseFloat(strings.TrimSuffix(lwPt, "pt"), 64)
 	if err != nil || val <= 0 {
 		return fallbackWidth
@@ -1018,10 +1638,41 @@ func (c *typstConverter) parseSignatureItem(sigMap map[string]any) portabledoc.S
 	item.ImageScale = getFloat64PtrAttr(sigMap, "imageScale")
 	item.ImageX = getFloat64PtrAttr(sigMap, "imageX")
 	item.ImageY = getFloat64PtrAttr(sigMap, "imageY")
+	item.InjectableID = getStringPtrAttr(sigMap, "injectableId")
+	item.LineWidth = getFloat64PtrAttr(sigMap, "lineWidth")
+	item.Captions = parseSignatureCaptions(sigMap["captions"])
+	if dateMap, ok := sigMap["dateLine"].(map[string]any); ok {
+		item.DateLine = &portabledoc.SignatureDateLine{Label: getStringAttr(dateMap, "label", "")}
+	}
+	item.SignedAt = getStringPtrAttr(sigMap, "signedAt")
 
 	return item
 }
 
+func parseSignatureCaptions(raw any) []portabledoc.SignatureCaption {
+	list, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	captions := make([]portabledoc.SignatureCaption, 0, len(list))
+	for _, entry := range list {
+		capMap, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		bold, _ := capMap["bold"].(bool)
+		italic, _ := capMap["italic"].(bool)
+		captions = append(captions, portabledoc.SignatureCaption{
+			Text:   getStringAttr(capMap, "text", ""),
+			Size:   getFloat64PtrAttr(capMap, "size"),
+			Bold:   bold,
+			Italic: italic,
+			Color:  getStringPtrAttr(capMap, "color"),
+		})
+	}
+	return captions
+}
+
 func (c *typstConverter) getAnchorString(sig *portabledoc.SignatureItem) string {
 	if sig.RoleID != nil && *sig.RoleID != "" {
 		if role, ok := c.signerRoles[*sig.RoleID]; ok {
@@ -1033,11 +1684,22 @@ func (c *typstConverter) getAnchorString(sig *portabledoc.SignatureItem) string
 	return fmt.Sprintf("__sig_%s__", sig.ID)
 }
 
-// renderSignatureBlock renders a signature block in Typst.
+// renderSignatureBlock renders a signature block in Typst. Index and total placeholders
+// in the signatures' text count the signatures of this block.
 func (c *typstConverter) renderSignatureBlock(attrs portabledoc.SignatureAttrs) string {
+	numbered := make([]portabledoc.SignatureItem, len(attrs.Signatures))
+	for i, sig := range attrs.Signatures {
+		numbered[i] = sig.Numbered(i+1, len(attrs.Signatures))
+	}
+	attrs.Signatures = numbered
+
 	lwPt := c.signatureLineWidth(attrs.LineWidth)
 	lwPt = c.capSignatureLineWidth(lwPt)
+	c.signatureColumns = signatureLayoutColumns(attrs.Layout, len(attrs.Signatures))
 	body := c.signatureLayoutBody(attrs, lwPt)
+	if body == "" {
+		return ""
+	}
 	return "#v(2em)\n" + body
 }
 
@@ -1054,10 +1716,8 @@ func (c *typstConverter) capSignatureLineWidth(lwPt string) string {
 		return lwPt
 	}
 
-	contentWidthPt := c.contentWidthPx * pxToPt
-	const gutterPt = 11.0 // 1em at 11pt base text size
-	const maxCols = 3     // worst case: 3 columns
-	maxWidth := (contentWidthPt - float64(maxCols-1)*gutterPt) / float64(maxCols)
+	const maxCols = 3 // worst case: 3 columns
+	maxWidth := c.signatureColumnWidthPt(maxCols)
 
 	if lwVal > maxWidth {
 		return fmt.Sprintf("%.1fpt", maxWidth)
@@ -1066,8 +1726,16 @@ func (c *typstConverter) capSignatureLineWidth(lwPt string) string {
 }
 
 // signatureLayoutBody dispatches to the appropriate layout renderer.
+// A block with fewer signatures than its layout expects falls back to a grid of the
+// signatures it has, and an empty block renders nothing.
 func (c *typstConverter) signatureLayoutBody(attrs portabledoc.SignatureAttrs, lwPt string) string {
 	sigs := attrs.Signatures
+	if len(sigs) == 0 {
+		return ""
+	}
+	if len(sigs) < layoutSignatureCount(attrs.Layout) {
+		return c.renderSignatureGrid(sigs, len(sigs), lwPt)
+	}
 
 	switch attrs.Layout {
 	// Single signature layouts
@@ -1109,6 +1777,57 @@ func (c *typstConverter) signatureLayoutBody(attrs portabledoc.SignatureAttrs, l
 	}
 }
 
+// signatureColumnWidthPt returns the width of one column when the content area is
+// split into cols signature columns.
+func (c *typstConverter) signatureColumnWidthPt(cols int) float64 {
+	const gutterPt = 11.0 // 1em at 11pt base text size
+	cols = max(cols, 1)
+	return (c.contentWidthPx*pxToPt - float64(cols-1)*gutterPt) / float64(cols)
+}
+
+// signatureItemLineWidth returns the line width for one signature: its own lineWidth
+// override, bounded by the column its layout gives it, or else the block's capped width.
+func (c *typstConverter) signatureItemLineWidth(sig *portabledoc.SignatureItem, blockWidthPt string, columns int) string {
+	if sig.LineWidth == nil || *sig.LineWidth <= 0 {
+		return blockWidthPt
+	}
+	widthPt := *sig.LineWidth * pxToPt
+	if c.contentWidthPx > 0 {
+		widthPt = math.Min(widthPt, c.signatureColumnWidthPt(columns))
+	}
+	return fmt.Sprintf("%.1fpt", widthPt)
+}
+
+// signatureLayoutColumns returns the widest row of a layout, which bounds how wide
+// each of its signatures can be. Blocks that fall back to a grid use one column per signature.
+func signatureLayoutColumns(layout string, signatures int) int {
+	if signatures < layoutSignatureCount(layout) {
+		return max(signatures, 1)
+	}
+	switch layout {
+	case portabledoc.LayoutSingleLeft, portabledoc.LayoutSingleCenter, portabledoc.LayoutSingleRight,
+		portabledoc.LayoutDualCenter, portabledoc.LayoutDualLeft, portabledoc.LayoutDualRight:
+		return 1
+	case portabledoc.LayoutDualSides, portabledoc.LayoutQuadGrid,
+		portabledoc.LayoutTriplePyramid, portabledoc.LayoutTripleInverted:
+		return 2
+	case portabledoc.LayoutTripleRow, portabledoc.LayoutQuadTopHeavy, portabledoc.LayoutQuadBottomHeavy:
+		return 3
+	default:
+		return max(signatures, 1)
+	}
+}
+
+// layoutSignatureCount returns how many signatures a layout places, or 0 for unknown layouts.
+func layoutSignatureCount(layout string) int {
+	for count, layouts := range portabledoc.ValidLayoutsForCount {
+		if layouts.Contains(layout) {
+			return count
+		}
+	}
+	return 0
+}
+
 // signatureLineWidth returns the line width in pt from the lineWidth name.
 func (c *typstConverter) signatureLineWidth(lineWidth string) string {
 	switch lineWidth {
@@ -1189,6 +1908,7 @@ func (c *typstConverter) renderSignatureGroup(sigs []portabledoc.SignatureItem,
 // The caller is responsible for wrapping in [...] content blocks when needed (e.g., grid items).
 func (c *typstConverter) renderTypstSignatureItemContent(sig *portabledoc.SignatureItem, lineWidthPt string) string {
 	var sb strings.Builder
+	lineWidthPt = c.signatureItemLineWidth(sig, lineWidthPt, c.signatureColumns)
 	anchorString := c.getAnchorString(sig)
 
 	// Always reserve a fixed slot above the line so all lines in a grid row align.
@@ -1201,10 +1921,9 @@ func (c *typstConverter) renderTypstSignatureItemContent(sig *portabledoc.Signat
 	fmt.Fprintf(&sb, "    #v(%.1fpt)\n", slotHeightPt)
 	fmt.Fprintf(&sb, "    #v(%.1fpt)\n", gapPt)
 
-	// Build image markup ahead of the line block (only when signed)
+	// Build image markup ahead of the line block (only when signed or bound to an image)
 	imgMarkup := ""
-	if sig.IsSigned() {
-		imgFile := c.RegisterRemoteImage(*sig.ImageData)
+	if imgFile := c.signatureImagePath(sig); imgFile != "" {
 		scale := 1.0
 		if sig.ImageScale != nil && *sig.ImageScale > 0 {
 			scale = *sig.ImageScale
@@ -1221,7 +1940,7 @@ func (c *typstConverter) renderTypstSignatureItemContent(sig *portabledoc.Signat
 	// Anchor text (invisible but present for PDF anchor extraction).
 	// It must be out-of-flow so it marks the signature line position without
 	// adding an invisible text row above the line.
-	fmt.Fprintf(&sb, "      #place(top + center, dy: %.1fpt)[#text(size: 0.1pt, fill: white)[%s]]\n", anchorLineOffsetPt, escapeTypst(anchorString))
+	fmt.Fprintf(&sb, "      #place(top + center, dy: %.1fpt)[#text(%s)[%s]]\n", anchorLineOffsetPt, c.anchorTextParams(), escapeTypst(anchorString))
 	if imgMarkup != "" {
 		// Float the image out-of-flow, centered above the line.
 		// dy moves the image's top edge up into the reserved slot so it sits over the line.
@@ -1230,25 +1949,110 @@ func (c *typstConverter) renderTypstSignatureItemContent(sig *portabledoc.Signat
 	sb.WriteString("      #line(length: 100%, stroke: 0.5pt)\n")
 	label := sig.Label
 	if label == "" {
-		label = "Firma"
+		label = c.locale().SignatureLabel
 	}
 	fmt.Fprintf(&sb, "      #align(center)[#text(size: 9pt)[%s]]\n", escapeTypst(label))
 	if sig.Subtitle != nil && *sig.Subtitle != "" {
 		fmt.Fprintf(&sb, "      #align(center)[#text(size: 8pt, fill: luma(100))[%s]]\n", escapeTypst(*sig.Subtitle))
 	}
+	for _, caption := range sig.Captions {
+		if caption.Text == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "      #align(center)[#text(%s)[%s]]\n", signatureCaptionParams(caption), escapeTypst(caption.Text))
+	}
+	if sig.DateLine != nil {
+		fmt.Fprintf(&sb, "      #align(center)[#text(size: 8pt)[%s]]\n", escapeTypst(c.signatureDateLine(sig)))
+	}
 	sb.WriteString("    ]\n")
 
 	return sb.String()
 }
 
+// signatureImagePath returns the image to draw above the line: the signer-drawn image
+// once signed, otherwise a facsimile resolved from the item's injectable. "" when neither.
+func (c *typstConverter) signatureImagePath(sig *portabledoc.SignatureItem) string {
+	if sig.IsSigned() {
+		return c.RegisterRemoteImage(*sig.ImageData)
+	}
+	if sig.HasInjectableImage() {
+		return c.resolveImagePath(map[string]any{"injectableId": *sig.InjectableID})
+	}
+	return ""
+}
+
+// anchorTextParams returns the #text arguments for signature anchors: invisible,
+// or normal-sized red text when debugging field placement.
+func (c *typstConverter) anchorTextParams() string {
+	if c.debugAnchors {
+		return "fill: red"
+	}
+	return "size: 0.1pt, fill: white"
+}
+
+// signatureCaptionParams returns the #text arguments for a caption line.
+func signatureCaptionParams(caption portabledoc.SignatureCaption) string {
+	size := 8.0
+	if caption.Size != nil && *caption.Size > 0 {
+		size = *caption.Size
+	}
+	params := []string{fmt.Sprintf("size: %spt", strconv.FormatFloat(size, 'f', -1, 64))}
+	if caption.Bold {
+		params = append(params, `weight: "bold"`)
+	}
+	if caption.Italic {
+		params = append(params, `style: "italic"`)
+	}
+	if caption.Color != nil && *caption.Color != "" {
+		params = append(params, fmt.Sprintf("fill: rgb(\"%s\")", escapeTypstString(*caption.Color)))
+	}
+	return strings.Join(params, ", ")
+}
+
+// signatureDateLine returns the date line text: a blank to fill in by hand, or the
+// signed date in the document locale once the signature is signed.
+func (c *typstConverter) signatureDateLine(sig *portabledoc.SignatureItem) string {
+	label := sig.DateLine.Label
+	if label == "" {
+		label = c.locale().DateLabel
+	}
+	value := "____________"
+	if sig.IsSigned() && sig.SignedAt != nil && *sig.SignedAt != "" {
+		value = *sig.SignedAt
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				value = c.locale().formatDate(t)
+				break
+			}
+		}
+	}
+	return label + ": " + value
+}
+
+// --- Insertion Points ---
+
+// insertionPoint renders the nodes supplied for its slot. A slot whose content
+// contains the same slot again is not expanded a second time.
+func (c *typstConverter) insertionPoint(node portabledoc.Node) string {
+	slot := getStringAttr(node.Attrs, "slot", "")
+	nodes := c.slots[slot]
+	if len(nodes) == 0 || c.fillingSlots[slot] {
+		return ""
+	}
+	if c.fillingSlots == nil {
+		c.fillingSlots = make(map[string]bool)
+	}
+	c.fillingSlots[slot] = true
+	defer delete(c.fillingSlots, slot)
+	return c.convertNodes(nodes)
+}
+
 // --- List Injector Nodes ---
 
 func (c *typstConverter) listInjector(node portabledoc.Node) string {
 	variableID, _ := node.Attrs["variableId"].(string)
-	lang, _ := node.Attrs["lang"].(string)
-	if lang == "" {
-		lang = "en"
-	}
+	nodeLang, _ := node.Attrs["lang"].(string)
+	lang := c.labelLang(nodeLang)
 
 	listData := c.resolveListValue(variableID)
 	if listData == nil {
@@ -1290,7 +2094,7 @@ func (c *typstConverter) listInjector(node portabledoc.Node) string {
 }
 
 func (c *typstConverter) resolveListValue(variableID string) *entity.ListValue {
-	if v, ok := c.injectables[variableID]; ok {
+	if v, ok := c.injectable(variableID); ok {
 		if listVal, ok := v.(*entity.ListValue); ok {
 			return listVal
 		}
@@ -1413,16 +2217,8 @@ func (c *typstConverter) renderListItem(sb *strings.Builder, item entity.ListIte
 }
 
 func (c *typstConverter) getListHeaderLabel(labels map[string]string, lang string) string {
-	if label, ok := labels[lang]; ok {
-		return label
-	}
-	if label, ok := labels["en"]; ok {
-		return label
-	}
-	for _, label := range labels {
-		return label
-	}
-	return ""
+	label, _ := c.localizedLabel(labels, lang)
+	return label
 }
 
 // --- Table Nodes ---
@@ -1437,10 +2233,8 @@ func (c *typstConverter) tableCellHeader(node portabledoc.Node) string {
 
 func (c *typstConverter) tableInjector(node portabledoc.Node) string {
 	variableID, _ := node.Attrs["variableId"].(string)
-	lang, _ := node.Attrs["lang"].(string)
-	if lang == "" {
-		lang = "en"
-	}
+	nodeLang, _ := node.Attrs["lang"].(string)
+	lang := c.labelLang(nodeLang)
 
 	tableData := c.resolveTableValue(variableID)
 	if tableData == nil {
@@ -1464,11 +2258,12 @@ func (c *typstConverter) tableInjector(node portabledoc.Node) string {
 		bodyStyles = c.mergeTableStyles(tableData.BodyStyles, bodyStyles)
 	}
 
-	return c.renderTypstTable(tableData, lang, headerStyles, bodyStyles)
+	insets := parseTableCellInsets(node.Attrs, c.tokens.TableHeaderCellInset, c.tokens.TableBodyCellInset)
+	return c.renderTypstTable(tableData, lang, headerStyles, bodyStyles, insets, tableBorderStyle(node.Attrs))
 }
 
 func (c *typstConverter) resolveTableValue(variableID string) *entity.TableValue {
-	if v, ok := c.injectables[variableID]; ok {
+	if v, ok := c.injectable(variableID); ok {
 		if tableVal, ok := v.(*entity.TableValue); ok {
 			return tableVal
 		}
@@ -1635,13 +2430,19 @@ func (c *typstConverter) parseDataType(s string) entity.ValueType {
 }
 
 // renderTypstTable generates Typst table markup for a TableValue (tableInjector).
-func (c *typstConverter) renderTypstTable(tableData *entity.TableValue, lang string, headerStyles, bodyStyles *entity.TableStyles) string {
+func (c *typstConverter) renderTypstTable(
+	tableData *entity.TableValue,
+	lang string,
+	headerStyles, bodyStyles *entity.TableStyles,
+	insets tableCellInsets,
+	border string,
+) string {
 	if len(tableData.Columns) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("#block[\n") // content block to scope #show rules
+	sb.WriteString(c.tableBlockOpen(border)) // content block to scope #show rules
 	sb.WriteString("#show table.cell: set par(spacing: 0pt, leading: 0.65em)\n")
 
 	sb.WriteString(c.buildTableStyleRules(headerStyles))
@@ -1649,56 +2450,68 @@ func (c *typstConverter) renderTypstTable(tableData *entity.TableValue, lang str
 
 	colWidths := c.buildTypstColumnWidths(tableData.Columns)
 	headerFill := c.getTableHeaderFillColor(headerStyles)
-	fmt.Fprintf(&sb, "#table(\n  columns: (%s),\n  inset: (x: 0pt, y: 0pt),\n  stroke: 0.5pt + %s,\n  fill: (x, y) => if y == 0 { rgb(%q) },\n", colWidths, c.tokens.TableStrokeColor, headerFill)
+	fmt.Fprintf(&sb, "#table(\n  columns: (%s),\n  inset: (x: 0pt, y: 0pt),\n  stroke: %s,\n  fill: (x, y) => if y == 0 { rgb(%q) },\n", colWidths, c.tableStroke(border), headerFill)
 	sb.WriteString(c.buildTableAlignParam(headerStyles, bodyStyles))
-	sb.WriteString(c.renderTypstTableHeader(tableData.Columns, lang))
-	sb.WriteString(c.renderTypstTableRows(tableData))
+	sb.WriteString(c.renderTypstTableHeader(tableData.Columns, lang, insets.header))
+	sb.WriteString(c.renderTypstTableRows(tableData, insets.body))
 	sb.WriteString(")\n")
 	sb.WriteString("]\n") // close content block
 	return sb.String()
 }
 
-func (c *typstConverter) renderTypstTableHeader(columns []entity.TableColumn, lang string) string {
+func (c *typstConverter) renderTypstTableHeader(columns []entity.TableColumn, lang, inset string) string {
 	var sb strings.Builder
 	sb.WriteString("  table.header(")
 	for i, col := range columns {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		fmt.Fprintf(&sb, "table.cell(inset: %s)[%s]", c.tokens.TableHeaderCellInset, escapeTypst(c.getColumnLabel(col, lang)))
+		fmt.Fprintf(&sb, "table.cell(inset: %s)[%s]", inset, escapeTypst(c.getColumnLabel(col, lang)))
 	}
 	sb.WriteString("),\n")
 	return sb.String()
 }
 
-func (c *typstConverter) renderTypstTableRows(tableData *entity.TableValue) string {
+func (c *typstConverter) renderTypstTableRows(tableData *entity.TableValue, inset string) string {
 	var sb strings.Builder
-	for _, row := range tableData.Rows {
+	for r, row := range tableData.Rows {
 		for i, cell := range row.Cells {
 			if cell.Value == nil && cell.Colspan == 0 && cell.Rowspan == 0 {
 				continue
 			}
-			format := c.getColumnFormat(tableData.Columns, i)
-			sb.WriteString(c.renderTypstDataCell(cell, format))
+			// Cells line up with columns, so spans can't reach past the last column or row.
+			cell.Colspan = clampSpan(cell.Colspan, len(tableData.Columns)-i)
+			cell.Rowspan = clampSpan(cell.Rowspan, len(tableData.Rows)-r)
+			sb.WriteString(c.renderTypstDataCell(cell, c.formatColumnCell(cell.Value, tableData.Columns, i), inset))
 		}
 	}
 	return sb.String()
 }
 
-func (c *typstConverter) getColumnFormat(columns []entity.TableColumn, idx int) string {
-	if idx < len(columns) && columns[idx].Format != nil {
-		return *columns[idx].Format
+// formatColumnCell formats a cell value with its column's Format, or, for numbers
+// in a column without one, with the column's Precision.
+func (c *typstConverter) formatColumnCell(value *entity.InjectableValue, columns []entity.TableColumn, idx int) string {
+	if idx >= len(columns) {
+		return c.formatCellValue(value, "")
 	}
-	return ""
+	col := columns[idx]
+	if col.Format != nil {
+		return c.formatCellValue(value, *col.Format)
+	}
+	if col.Precision != nil && *col.Precision >= 0 && value != nil && value.Type() == entity.ValueTypeNumber {
+		n, _ := value.Number()
+		return c.locale().localizeDecimal(roundDecimal(n, *col.Precision, c.tokens.RoundingMode))
+	}
+	return c.formatCellValue(value, "")
 }
 
-func (c *typstConverter) renderTypstDataCell(cell entity.TableCell, format string) string {
-	content := escapeTypst(c.formatCellValue(cell.Value, format))
+func (c *typstConverter) renderTypstDataCell(cell entity.TableCell, formatted, inset string) string {
+	content := escapeTypst(formatted)
 	if cell.Colspan > 1 || cell.Rowspan > 1 {
 		attrs := c.buildTypstCellSpanAttrs(cell.Colspan, cell.Rowspan)
-		return fmt.Sprintf("  table.cell(%s, inset: %s)[%s],\n", attrs, c.tokens.TableBodyCellInset, content)
+		return fmt.Sprintf("  table.cell(%s, inset: %s)[%s],\n", attrs, inset, content)
 	}
-	return fmt.Sprintf("  table.cell(inset: %s)[%s],\n", c.tokens.TableBodyCellInset, content)
+	return fmt.Sprintf("  table.cell(inset: %s)[%s],\n", inset, content)
 }
 
 func (c *typstConverter) buildTypstColumnWidths(columns []entity.TableColumn) string {
@@ -1740,13 +2553,7 @@ func (c *typstConverter) buildTypstCellSpanAttrs(colspan, rowspan int) string {
 }
 
 func (c *typstConverter) getColumnLabel(col entity.TableColumn, lang string) string {
-	if label, ok := col.Labels[lang]; ok {
-		return label
-	}
-	if label, ok := col.Labels["en"]; ok {
-		return label
-	}
-	for _, label := range col.Labels {
+	if label, ok := c.localizedLabel(col.Labels, lang); ok {
 		return label
 	}
 	return col.Key
@@ -1769,19 +2576,16 @@ func (c *typstConverter) formatCellValue(value *entity.InjectableValue, format s
 		if n == float64(int64(n)) {
 			return strconv.FormatInt(int64(n), 10)
 		}
-		return strconv.FormatFloat(n, 'f', 2, 64)
+		return c.locale().localizeDecimal(roundDecimal(n, 2, c.tokens.RoundingMode))
 	case entity.ValueTypeBool:
 		b, _ := value.Bool()
-		if b {
-			return "Yes"
-		}
-		return "No"
+		return c.locale().formatBool(b)
 	case entity.ValueTypeTime:
 		t, _ := value.Time()
 		if format != "" {
 			return t.Format(format)
 		}
-		return t.Format("2006-01-02")
+		return c.locale().formatDate(t)
 	default:
 		return ""
 	}
@@ -1801,24 +2605,38 @@ func (c *typstConverter) table(node portabledoc.Node) string {
 
 	var sb strings.Builder
 
-	sb.WriteString("#block[\n") // scope #show rules to this table
+	border := tableBorderStyle(node.Attrs)
+	sb.WriteString(c.tableBlockOpen(border)) // scope #show rules to this table
 	sb.WriteString("#show table.cell: set par(spacing: 0pt, leading: 0.65em)\n")
 	sb.WriteString(c.buildTableStyleRules(c.currentTableHeaderStyles))
 	sb.WriteString(c.buildTableBodyStyleRules(c.currentTableBodyStyles))
 
 	headerFill := c.getTableHeaderFillColor(c.currentTableHeaderStyles)
-	fmt.Fprintf(&sb, "#table(\n  columns: (%s),\n  inset: %s,\n  stroke: 0.5pt + %s,\n  fill: (x, y) => if y == 0 { rgb(%q) },\n", colWidths, c.tokens.TableCellInset, c.tokens.TableStrokeColor, headerFill)
+	inset := parseTableCellInsets(node.Attrs, c.tokens.TableCellInset, c.tokens.TableCellInset).editableTableInset()
+	fmt.Fprintf(&sb, "#table(\n  columns: (%s),\n  inset: %s,\n  stroke: %s,\n  fill: %s,\n", colWidths, inset, c.tableStroke(border), editableTableFill(headerFill, hexColorAttr(node.Attrs, "stripeColor")))
 	sb.WriteString(c.buildTableAlignParam(c.currentTableHeaderStyles, c.currentTableBodyStyles))
 
-	isFirstRow := true
+	numRows := 0
+	for _, row := range node.Content {
+		if row.Type == portabledoc.NodeTypeTableRow {
+			numRows++
+		}
+	}
+	grid := newTableGrid(numCols)
+	rowIdx := 0
 	for _, row := range node.Content {
 		if row.Type != portabledoc.NodeTypeTableRow {
 			continue
 		}
 		for _, cell := range row.Content {
-			sb.WriteString(c.renderEditableTableCell(cell, isFirstRow))
+			col := grid.nextFree()
+			colspan := clampSpan(getIntAttr(cell.Attrs, "colspan", 1), numCols-col)
+			rowspan := clampSpan(getIntAttr(cell.Attrs, "rowspan", 1), numRows-rowIdx)
+			sb.WriteString(c.renderEditableTableCell(cell, colspan, rowspan))
+			grid.place(col, colspan, rowspan)
 		}
-		isFirstRow = false
+		grid.endRow()
+		rowIdx++
 	}
 
 	sb.WriteString(")\n")
@@ -1826,21 +2644,119 @@ func (c *typstConverter) table(node portabledoc.Node) string {
 	return sb.String()
 }
 
+// editableTableFill returns the fill closure of an editable table. Row 0 is the
+// header; with a stripe color, every other body row from row 1 is shaded.
+func editableTableFill(headerFill, stripeColor string) string {
+	if stripeColor == "" {
+		return fmt.Sprintf("(x, y) => if y == 0 { rgb(%q) }", headerFill)
+	}
+	return fmt.Sprintf("(x, y) => if y == 0 { rgb(%q) } else if calc.odd(y) { rgb(%q) }", headerFill, stripeColor)
+}
+
+// maxTableColumns bounds the columns of an editable table, so a cell declaring an
+// absurd colspan can't blow up the grid.
+const maxTableColumns = 64
+
+// countTableColumns returns the column count of an editable table. Rows without
+// merged columns are trusted first, then the first row's colwidths, so a cell
+// declaring an absurd colspan can't widen the table; only a table where every row
+// merges columns is sized by its widest row.
 func (c *typstConverter) countTableColumns(node portabledoc.Node) int {
-	maxCols := 1
+	grid := newTableGrid(maxTableColumns)
+	plainCols, spannedCols := 0, 0
 	for _, row := range node.Content {
-		cols := 0
+		if row.Type != portabledoc.NodeTypeTableRow {
+			continue
+		}
+		spanned := false
 		for _, cell := range row.Content {
-			cols += getIntAttr(cell.Attrs, "colspan", 1)
+			colspan := clampSpan(getIntAttr(cell.Attrs, "colspan", 1), maxTableColumns)
+			spanned = spanned || colspan > 1
+			grid.place(grid.nextFree(), colspan, getIntAttr(cell.Attrs, "rowspan", 1))
+		}
+		if spanned {
+			spannedCols = max(spannedCols, grid.rowWidth())
+		} else {
+			plainCols = max(plainCols, grid.rowWidth())
+		}
+		grid.endRow()
+	}
+
+	numCols := plainCols
+	if numCols == 0 {
+		if firstRow := c.findFirstTableRow(node); firstRow != nil {
+			if colwidths, missingIdx, hasAny := c.extractColwidths(firstRow); hasAny && len(missingIdx) == 0 {
+				numCols = len(colwidths)
+			}
+		}
+	}
+	if numCols == 0 {
+		numCols = spannedCols
+	}
+	return clampSpan(numCols, maxTableColumns)
+}
+
+// tableGrid tracks which columns of an editable table are covered by rowspans from
+// earlier rows, so each cell is placed in the next free column like Typst does.
+type tableGrid struct {
+	covered []int // rows each column stays covered, the current row included
+	width   int   // columns used by the current row
+}
+
+func newTableGrid(numCols int) *tableGrid {
+	return &tableGrid{covered: make([]int, numCols)}
+}
+
+// nextFree returns the first column of the current row that is not covered and
+// not yet filled, or the column count when the row is full.
+func (g *tableGrid) nextFree() int {
+	col := g.width
+	for col < len(g.covered) && g.covered[col] > 0 {
+		col++
+	}
+	return col
+}
+
+// place fills the columns a cell spans in the current row and the rows below it.
+func (g *tableGrid) place(col, colspan, rowspan int) {
+	end := min(col+colspan, len(g.covered))
+	for i := col; i < end; i++ {
+		g.covered[i] = max(rowspan, 1)
+	}
+	g.width = max(g.width, end)
+}
+
+// rowWidth returns the columns the current row occupies, including columns covered
+// from above.
+func (g *tableGrid) rowWidth() int {
+	width := g.width
+	for i := width; i < len(g.covered); i++ {
+		if g.covered[i] > 0 {
+			width = i + 1
 		}
-		if cols > maxCols {
-			maxCols = cols
+	}
+	return width
+}
+
+// endRow moves to the next row.
+func (g *tableGrid) endRow() {
+	for i := range g.covered {
+		if g.covered[i] > 0 {
+			g.covered[i]--
 		}
 	}
-	return maxCols
+	g.width = 0
+}
+
+// clampSpan bounds a cell's colspan or rowspan to the columns or rows left after its
+// position. Typst rejects a cell spanning past the grid.
+func clampSpan(span, limit int) int {
+	return max(1, min(span, limit))
 }
 
-func (c *typstConverter) renderEditableTableCell(cell portabledoc.Node, _ bool) string {
+// renderEditableTableCell renders a cell of an editable table with its spans already
+// clamped to the table.
+func (c *typstConverter) renderEditableTableCell(cell portabledoc.Node, colspan, rowspan int) string {
 	content := c.convertNodes(cell.Content)
 	// Strip empty-paragraph vertical spacing -- #v() inflates cell height in tables
 	content = strings.ReplaceAll(content, fmt.Sprintf("#v(%s)", c.tokens.ParagraphSpacing), "")
@@ -1854,17 +2770,57 @@ func (c *typstConverter) renderEditableTableCell(cell portabledoc.Node, _ bool)
 	if content == "" {
 		content = "~" // Typst non-breaking space -- has text line height
 	}
+	content = cellTextStyle(cell.Attrs, content)
 
-	colspan := getIntAttr(cell.Attrs, "colspan", 1)
-	rowspan := getIntAttr(cell.Attrs, "rowspan", 1)
-
-	if colspan > 1 || rowspan > 1 {
-		attrs := c.buildTypstCellSpanAttrs(colspan, rowspan)
+	attrs := c.buildTypstCellSpanAttrs(colspan, rowspan)
+	// A cell fill takes precedence over the table's header fill for that cell.
+	if bg := hexColorAttr(cell.Attrs, "backgroundColor"); bg != "" {
+		if attrs != "" {
+			attrs += ", "
+		}
+		attrs += fmt.Sprintf("fill: rgb(%q)", bg)
+	}
+	if attrs != "" {
 		return fmt.Sprintf("  table.cell(%s)[%s],\n", attrs, content)
 	}
 	return fmt.Sprintf("  [%s],\n", content)
 }
 
+// hexColorPattern matches the hex colors Typst's rgb() accepts.
+var hexColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3,4}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$`)
+
+// hexColorAttr returns the color attr under key, or "" when it is unset or not
+// a hex color.
+func hexColorAttr(attrs map[string]any, key string) string {
+	color, _ := attrs[key].(string)
+	return hexColor(color)
+}
+
+// hexColor returns color trimmed, or "" when it is not a hex color.
+func hexColor(color string) string {
+	color = strings.TrimSpace(color)
+	if !hexColorPattern.MatchString(color) {
+		return ""
+	}
+	return color
+}
+
+// cellTextStyle wraps cell content in #text when the cell sets a color or bold
+// for its whole content. Marks on the inner text still apply on top.
+func cellTextStyle(attrs map[string]any, content string) string {
+	var params []string
+	if color := hexColorAttr(attrs, "color"); color != "" {
+		params = append(params, fmt.Sprintf("fill: rgb(%q)", color))
+	}
+	if bold, _ := attrs["bold"].(bool); bold {
+		params = append(params, `weight: "bold"`)
+	}
+	if len(params) == 0 {
+		return content
+	}
+	return fmt.Sprintf("#text(%s)[%s]", strings.Join(params, ", "), content)
+}
+
 // parseEditableTableColumnWidths extracts colwidth from first-row cells and converts to proportional Typst fr units.
 // TipTap stores colwidth on each cell node (not the table node) as an array of pixel widths (length = colspan).
 // prosemirror-tables only sets colwidth on explicitly resized columns; unresized columns stay nil.
@@ -1,10 +1,14 @@
 package pdfrenderer
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
@@ -100,8 +104,16 @@ func TestTypstConverter_MarkStrike(t *testing.T) {
 func TestTypstConverter_MarkCode(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	got := c.convertNode(markedTextNode("x := 1", markOf(portabledoc.MarkTypeCode)))
-	if got != "`x := 1`" {
-		t.Errorf("got %q, want %q", got, "`x := 1`")
+	if want := `#raw("x := 1");`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTypstConverter_MarkCodeInsideBold(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	got := c.convertNode(markedTextNode("a`b", markOf(portabledoc.MarkTypeBold), markOf(portabledoc.MarkTypeCode)))
+	if want := "#strong[#raw(\"a`b\");]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
 	}
 }
 
@@ -129,6 +141,31 @@ func TestTypstConverter_MarkHighlightCustomColor(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_MarkCommentDraftModeShowsNote(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	c.SetDraftMode(true)
+	got := c.convertNode(markedTextNode("30 days", markOf(portabledoc.MarkTypeComment, map[string]any{
+		"commentId": "c-1",
+		"author":    "Legal",
+		"text":      "Should be 60 days #review",
+	})))
+	want := `#highlight(fill: rgb("#fde2b8"))[30 days]#footnote[#text(fill: rgb("#8a5300"))[#strong[Legal:] Should be 60 days \#review]]`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTypstConverter_MarkCommentFinalModeSuppressesNote(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	got := c.convertNode(markedTextNode("30 days", markOf(portabledoc.MarkTypeComment, map[string]any{
+		"author": "Legal",
+		"text":   "Should be 60 days",
+	})))
+	if got != "30 days" {
+		t.Errorf("got %q, want plain text", got)
+	}
+}
+
 func TestTypstConverter_MarkLink(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	got := c.convertNode(markedTextNode("click", markOf(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com"})))
@@ -377,6 +414,24 @@ func TestTypstConverter_HeadingDefaultLevel(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_HeadingOptsOutOfNumbering(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type:    portabledoc.NodeTypeHeading,
+		Attrs:   map[string]any{"level": float64(2), "numbered": false},
+		Content: []portabledoc.Node{textNode("Recitals")},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "#heading(level: 2, numbering: none)[Recitals]") {
+		t.Errorf("expected an unnumbered level 2 heading, got %q", got)
+	}
+
+	node.Attrs["numbered"] = true
+	if got := c.convertNode(node); !strings.Contains(got, "== Recitals\n") {
+		t.Errorf("expected a numbered heading to use markup, got %q", got)
+	}
+}
+
 func TestTypstConverter_HeadingWithLocalLineSpacing(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
@@ -426,6 +481,37 @@ func TestTypstConverter_HeadingWithAlignAndLocalLineSpacing(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_HeadingAlignment(t *testing.T) {
+	tests := []struct {
+		align     string
+		wantAlign string
+	}{
+		{"center", "#align(center)["},
+		{"right", "#align(right)["},
+		{"justify", ""},
+		{"left", ""},
+	}
+
+	for _, tt := range tests {
+		c := newTestConverter(nil, nil)
+		got := c.convertNode(portabledoc.Node{
+			Type:    portabledoc.NodeTypeHeading,
+			Attrs:   map[string]any{"level": float64(2), "textAlign": tt.align},
+			Content: []portabledoc.Node{textNode("Title")},
+		})
+
+		if tt.wantAlign != "" && !strings.HasPrefix(got, tt.wantAlign) {
+			t.Errorf("%s: expected %s wrapper, got %q", tt.align, tt.wantAlign, got)
+		}
+		if tt.wantAlign == "" && (strings.Contains(got, "#align(") || strings.Contains(got, "#par(")) {
+			t.Errorf("%s: expected a plain left-aligned heading, got %q", tt.align, got)
+		}
+		if !strings.Contains(got, "\n== Title\n") {
+			t.Errorf("%s: heading marker must start its own line, got %q", tt.align, got)
+		}
+	}
+}
+
 // --- Blockquote ---
 
 func TestTypstConverter_Blockquote(t *testing.T) {
@@ -440,6 +526,158 @@ func TestTypstConverter_Blockquote(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_BlockquoteStyles(t *testing.T) {
+	quote := func(c *typstConverter, attrs map[string]any) string {
+		return c.convertNode(portabledoc.Node{
+			Type:    portabledoc.NodeTypeBlockquote,
+			Attrs:   attrs,
+			Content: []portabledoc.Node{paragraphNode(textNode("quote"))},
+		})
+	}
+	c := newTestConverter(nil, nil)
+
+	tests := []struct {
+		style                          string
+		wantStroke, wantFill, wantEmph bool
+	}{
+		{"", true, true, true},
+		{"border", true, true, true},
+		{"filled", false, true, true},
+		{"plain", false, false, false},
+		{"bogus", true, true, true},
+	}
+	seen := map[string]string{}
+	for _, tt := range tests {
+		got := quote(c, map[string]any{"style": tt.style})
+		if has := strings.Contains(got, "stroke: (left:"); has != tt.wantStroke {
+			t.Errorf("%q: stroke = %v, want %v in %q", tt.style, has, tt.wantStroke, got)
+		}
+		if has := strings.Contains(got, `fill: rgb("#f9f9f9")`); has != tt.wantFill {
+			t.Errorf("%q: fill = %v, want %v in %q", tt.style, has, tt.wantFill, got)
+		}
+		if has := strings.Contains(got, "#emph["); has != tt.wantEmph {
+			t.Errorf("%q: emph = %v, want %v in %q", tt.style, has, tt.wantEmph, got)
+		}
+		if !strings.Contains(got, "quote") {
+			t.Errorf("%q: missing content in %q", tt.style, got)
+		}
+		seen[got] = tt.style
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected three distinct renderings, got %d", len(seen))
+	}
+
+	tokens := DefaultDesignTokens()
+	tokens.BlockquoteStyle = BlockquotePlain
+	plain := NewTypstConverterFactory(tokens)(map[string]any{}, map[string]string{}, nil, nil, nil).(*typstConverter)
+	if got := quote(plain, nil); strings.Contains(got, "stroke:") || strings.Contains(got, "#emph[") {
+		t.Errorf("expected token default plain style, got %q", got)
+	}
+	if got := quote(plain, map[string]any{"style": "border"}); !strings.Contains(got, "stroke: (left:") {
+		t.Errorf("expected node style to override token, got %q", got)
+	}
+}
+
+func TestTypstConverter_BlockquoteAttribution(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	quote := func(attrs map[string]any) string {
+		return c.convertNode(portabledoc.Node{
+			Type:    portabledoc.NodeTypeBlockquote,
+			Attrs:   attrs,
+			Content: []portabledoc.Node{paragraphNode(textNode("To be or not to be"))},
+		})
+	}
+
+	if got := quote(nil); strings.Contains(got, "#align(right)") || strings.Contains(got, "—") {
+		t.Errorf("expected no attribution line, got %q", got)
+	}
+
+	got := quote(map[string]any{"attribution": "Shakespeare, Hamlet #3"})
+	want := `#align(right)[#text(size: 0.9em, fill: rgb("#757575"))[— Shakespeare, Hamlet \#3]]`
+	if !strings.Contains(got, want) {
+		t.Errorf("expected escaped attribution %q, got %q", want, got)
+	}
+	if strings.Index(got, "To be") > strings.Index(got, "Shakespeare") {
+		t.Errorf("attribution must follow the quote, got %q", got)
+	}
+
+	if got := quote(map[string]any{"cite": "Anonymous"}); !strings.Contains(got, "[— Anonymous]") {
+		t.Errorf("expected cite attr as attribution, got %q", got)
+	}
+}
+
+// --- Details ---
+
+func TestTypstConverter_Details(t *testing.T) {
+	c := newTestConverter(nil, nil)
+
+	t.Run("summary and content children", func(t *testing.T) {
+		node := portabledoc.Node{Type: portabledoc.NodeTypeDetails, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeDetailsSummary, Content: []portabledoc.Node{textNode("Termination")}},
+			{Type: portabledoc.NodeTypeDetailsContent, Content: []portabledoc.Node{paragraphNode(textNode("Either party may terminate."))}},
+		}}
+		got := c.convertNode(node)
+
+		summary := strings.Index(got, "#strong[Termination]")
+		body := strings.Index(got, "Either party may terminate.")
+		if summary < 0 || body < 0 {
+			t.Fatalf("summary and body must both render, got %q", got)
+		}
+		if summary > body {
+			t.Errorf("summary must lead the body, got %q", got)
+		}
+	})
+
+	t.Run("summary attribute", func(t *testing.T) {
+		node := portabledoc.Node{
+			Type:    portabledoc.NodeTypeDetails,
+			Attrs:   map[string]any{"summary": "Notes #1"},
+			Content: []portabledoc.Node{paragraphNode(textNode("Body"))},
+		}
+		got := c.convertNode(node)
+		if !strings.Contains(got, `#strong[Notes \#1]`) || !strings.Contains(got, "Body") {
+			t.Errorf("got %q", got)
+		}
+	})
+}
+
+// --- Definition List ---
+
+func TestTypstConverter_DefinitionList(t *testing.T) {
+	c := newTestConverter(map[string]any{"company": "Acme Corp"}, nil)
+	node := portabledoc.Node{Type: portabledoc.NodeTypeDefinitionList, Content: []portabledoc.Node{
+		{Type: portabledoc.NodeTypeDefinitionTerm, Content: []portabledoc.Node{textNode("Provider")}},
+		{Type: portabledoc.NodeTypeDefinitionDescription, Content: []portabledoc.Node{
+			paragraphNode(injectorNode("company"), textNode(" and its affiliates")),
+		}},
+		{Type: portabledoc.NodeTypeDefinitionTerm, Content: []portabledoc.Node{textNode("Fee #1")}},
+		{Type: portabledoc.NodeTypeDefinitionDescription, Content: []portabledoc.Node{paragraphNode(textNode("The monthly charge"))}},
+	}}
+	got := c.convertNode(node)
+
+	if !strings.HasPrefix(got, "#terms(\n") || strings.Count(got, "terms.item[") != 2 {
+		t.Fatalf("expected a two-entry term list, got %q", got)
+	}
+	provider := strings.Index(got, "terms.item[Provider][")
+	fee := strings.Index(got, "terms.item[Fee \\#1][")
+	if provider < 0 || fee < 0 || provider > fee {
+		t.Errorf("expected escaped terms in document order, got %q", got)
+	}
+	if !strings.Contains(got, "Acme Corp and its affiliates") || !strings.Contains(got, "The monthly charge") {
+		t.Errorf("expected resolved descriptions, got %q", got)
+	}
+	if strings.Index(got, "Acme Corp") > fee {
+		t.Errorf("description must belong to its term, got %q", got)
+	}
+}
+
+func TestTypstConverter_DefinitionListEmpty(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	if got := c.convertNode(portabledoc.Node{Type: portabledoc.NodeTypeDefinitionList}); got != "" {
+		t.Errorf("expected empty output, got %q", got)
+	}
+}
+
 // --- CodeBlock ---
 
 func TestTypstConverter_CodeBlock(t *testing.T) {
@@ -454,6 +692,24 @@ func TestTypstConverter_CodeBlock(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_CodeBlockKeepsSourceText(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeCodeBlock,
+		Attrs: map[string]any{"language": "md"},
+		Content: []portabledoc.Node{
+			textNode("# Title with $vars and [links]"),
+			{Type: portabledoc.NodeTypeHardBreak},
+			textNode("```go\nfmt.Println(\"hi\")\n```"),
+		},
+	}
+	got := c.convertNode(node)
+	want := "````md\n# Title with $vars and [links]\n```go\nfmt.Println(\"hi\")\n```\n````\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestTypstConverter_CodeBlockWithLanguage(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
@@ -562,6 +818,59 @@ func TestTypstConverter_MultiplePageBreaks(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_HeadingPageBreakBefore(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	heading := portabledoc.Node{
+		Type:    portabledoc.NodeTypeHeading,
+		Attrs:   map[string]any{"level": float64(1), "pageBreakBefore": true},
+		Content: []portabledoc.Node{textNode("Annex")},
+	}
+	got, _ := c.ConvertNodes([]portabledoc.Node{paragraphNode(textNode("Terms")), heading})
+	brk := strings.Index(got, "#pagebreak(weak: true)\n")
+	if brk < 0 || brk > strings.Index(got, "= Annex") {
+		t.Errorf("expected weak page break before the heading, got %q", got)
+	}
+	if c.GetCurrentPage() != 2 {
+		t.Errorf("page count should be 2 after the heading break, got %d", c.GetCurrentPage())
+	}
+}
+
+func TestTypstConverter_PageBreakBeforeAtPageStart(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	para := portabledoc.Node{
+		Type:    portabledoc.NodeTypeParagraph,
+		Attrs:   map[string]any{"pageBreakBefore": true},
+		Content: []portabledoc.Node{textNode("Schedule")},
+	}
+	got, _ := c.ConvertNodes([]portabledoc.Node{
+		para,
+		paragraphNode(textNode("Terms")),
+		{Type: portabledoc.NodeTypePageBreak},
+		para,
+	})
+	if strings.Count(got, "#pagebreak(weak: true)") != 2 {
+		t.Errorf("expected a weak page break before each paragraph, got %q", got)
+	}
+	// Both weak breaks fall at the top of a page, so only the hard break counts.
+	if c.GetCurrentPage() != 2 {
+		t.Errorf("expected page 2, got %d", c.GetCurrentPage())
+	}
+}
+
+func TestTypstConverter_PageBreakBeforeDefaultsOff(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	got, _ := c.ConvertNodes([]portabledoc.Node{
+		paragraphNode(textNode("Terms")),
+		{Type: portabledoc.NodeTypeHeading, Attrs: map[string]any{"level": float64(2)}, Content: []portabledoc.Node{textNode("Annex")}},
+	})
+	if strings.Contains(got, "#pagebreak") {
+		t.Errorf("expected no page break, got %q", got)
+	}
+	if c.GetCurrentPage() != 1 {
+		t.Errorf("expected page 1, got %d", c.GetCurrentPage())
+	}
+}
+
 // --- Image ---
 
 func TestTypstConverter_Image(t *testing.T) {
@@ -639,6 +948,44 @@ func TestTypstConverter_ImageInjectable(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_ImageInjectableBase64(t *testing.T) {
+	png := testPNG(t)
+	c := newTestConverter(map[string]any{"photo": base64.StdEncoding.EncodeToString(png)}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "", "injectableId": "photo", "width": float64(120)},
+	}
+
+	got := c.convertNode(node)
+	if !strings.Contains(got, `#image("img_1.png", width: 90pt`) {
+		t.Fatalf("expected image markup for the registered file, got %q", got)
+	}
+	images := c.RemoteImages()
+	if len(images) != 1 {
+		t.Fatalf("expected 1 registered image, got %d", len(images))
+	}
+	for src := range images {
+		payload, ok := strings.CutPrefix(src, "data:image/png;base64,")
+		if !ok {
+			t.Fatalf("expected a PNG data URI, got %.40q", src)
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(payload); err != nil || !bytes.Equal(decoded, png) {
+			t.Fatalf("data URI does not decode to the original bytes (err %v)", err)
+		}
+	}
+}
+
+func TestTypstConverter_ImageInjectableRawBytes(t *testing.T) {
+	c := newTestConverter(map[string]any{"photo": testPNG(t)}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "", "injectableId": "photo"},
+	}
+	if got := c.convertNode(node); !strings.Contains(got, "img_1.png") {
+		t.Fatalf("expected raw image bytes to be registered, got %q", got)
+	}
+}
+
 func TestTypstConverter_ImageInjectableUsesContainForFixedBox(t *testing.T) {
 	c := newTestConverter(map[string]any{"img1": "https://resolved.com/photo.jpg"}, nil)
 	node := portabledoc.Node{
@@ -660,102 +1007,478 @@ func TestTypstConverter_ImageInjectableUsesContainForFixedBox(t *testing.T) {
 	}
 }
 
-func TestTypstConverter_ImageStorageURL(t *testing.T) {
-	c := newTestConverter(nil, nil)
+func TestTypstConverter_ImageClampsToContentWidth(t *testing.T) {
+	c := newTestConverter(map[string]any{"img1": "https://resolved.com/photo.jpg"}, nil)
+	c.SetContentWidthPx(600)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeImage,
-		Attrs: map[string]any{"src": "storage://asset-key", "width": float64(200)},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":          "",
+			"injectableId": "img1",
+			"width":        float64(1200),
+			"height":       float64(400),
+		},
 	}
+
 	got := c.convertNode(node)
-	if !strings.Contains(got, "img_1") {
-		t.Fatalf("expected generated local filename, got %q", got)
-	}
-	if _, ok := c.RemoteImages()["storage://asset-key"]; !ok {
-		t.Fatalf("expected storage URL to be tracked as remote image")
+	// 600px content width → 450pt; height scales by the same 0.5 → 200px → 150pt
+	if !strings.Contains(got, `width: 450pt`) || !strings.Contains(got, `height: 150pt`) {
+		t.Fatalf("expected oversized image scaled down to the content width, got %q", got)
 	}
 }
 
-// --- Injector ---
-
-func TestTypstConverter_InjectorWithValue(t *testing.T) {
-	c := newTestConverter(map[string]any{"var1": "John Doe"}, nil)
+func TestTypstConverter_ImageWidthUnits(t *testing.T) {
+	c := newTestConverter(map[string]any{"img1": "https://resolved.com/photo.jpg"}, nil)
+	c.SetContentWidthPx(600)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":          "",
+			"injectableId": "img1",
+			"width":        "2in",
+			"height":       "20mm",
+		},
 	}
+
 	got := c.convertNode(node)
-	if got != "John Doe" {
-		t.Errorf("got %q, want %q", got, "John Doe")
+	// 2in → 192px → 144pt; 20mm → 75.6px → 57pt
+	if !strings.Contains(got, `width: 144pt`) || !strings.Contains(got, `height: 57pt`) {
+		t.Fatalf("expected unit lengths converted to pt, got %q", got)
 	}
 }
 
-func TestTypstConverter_InjectorWithDefault(t *testing.T) {
-	c := newTestConverter(nil, map[string]string{"var1": "Default Name"})
+func TestTypstConverter_ImageWithinContentWidthUnchanged(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	c.SetContentWidthPx(600)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "https://example.com/img.png", "width": float64(200)},
 	}
+
 	got := c.convertNode(node)
-	if got != "Default Name" {
-		t.Errorf("got %q, want %q", got, "Default Name")
+	if !strings.Contains(got, `width: 150pt`) {
+		t.Fatalf("expected in-bounds image to keep its width, got %q", got)
 	}
 }
 
-func TestTypstConverter_InjectorEmpty(t *testing.T) {
+func TestTypstConverter_ImageBorderedRounded(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":          "https://example.com/img.png",
+			"width":        float64(200),
+			"borderWidth":  float64(2),
+			"borderColor":  "#336699",
+			"borderRadius": float64(8),
+		},
 	}
+
 	got := c.convertNode(node)
-	if got != "" {
-		t.Errorf("expected empty string for missing injectable, got %q", got)
+	want := `#box(stroke: 1.5pt + rgb("#336699"), clip: true, radius: 6.0pt)[#image("img_1.png", width: 150pt`
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected image inside a bordered rounded box %q, got %q", want, got)
+	}
+	if strings.Contains(got, "#layout(") {
+		t.Fatalf("expected no shadow without the shadow attr, got %q", got)
 	}
 }
 
-func TestTypstConverter_InjectorCurrency(t *testing.T) {
-	c := newTestConverter(map[string]any{"price": float64(99.5)}, nil)
+func TestTypstConverter_ImageBorderDefaultsToTokenColor(t *testing.T) {
+	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "price", "type": "CURRENCY", "format": "$"},
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "https://example.com/img.png", "borderWidth": float64(1)},
 	}
+
 	got := c.convertNode(node)
-	if !strings.Contains(got, "99.50") {
-		t.Errorf("expected currency formatted value, got %q", got)
+	if !strings.Contains(got, "#box(stroke: 0.8pt + "+c.tokens.ImageBorderColor+")[") {
+		t.Fatalf("expected border in the default token color without clipping, got %q", got)
+	}
+
+	node.Attrs["borderColor"] = "rgb(0, 0, 0)"
+	got = c.convertNode(node)
+	if !strings.Contains(got, "#box(stroke: 0.8pt + "+c.tokens.ImageBorderColor+")[") {
+		t.Fatalf("expected a non-hex border color to fall back to the token color, got %q", got)
 	}
 }
 
-func TestTypstConverter_InjectorBoolean(t *testing.T) {
-	c := newTestConverter(map[string]any{"active": true}, nil)
+func TestTypstConverter_ImageCircleWithBorder(t *testing.T) {
+	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "active"},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":         "https://example.com/img.png",
+			"width":       float64(100),
+			"shape":       "circle",
+			"borderWidth": float64(4),
+			"borderColor": "#ffffff",
+		},
 	}
+
 	got := c.convertNode(node)
-	if !strings.Contains(got, "S") { // "Si" or "Sí" depending on locale
-		t.Errorf("expected boolean true value, got %q", got)
+	if !strings.Contains(got, `#box(width: 75pt, height: 75pt, clip: true, radius: 50%, stroke: 3.0pt + rgb("#ffffff"))`) {
+		t.Fatalf("expected bordered circle clip, got %q", got)
 	}
 }
 
-func TestTypstConverter_InjectorNumber(t *testing.T) {
-	c := newTestConverter(map[string]any{"count": float64(42)}, nil)
+func TestTypstConverter_ImageShadow(t *testing.T) {
+	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "count"},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":          "https://example.com/img.png",
+			"width":        float64(200),
+			"borderRadius": float64(8),
+			"shadow":       true,
+		},
 	}
+
 	got := c.convertNode(node)
-	if got != "42" {
-		t.Errorf("got %q, want %q", got, "42")
+	for _, want := range []string{
+		"#layout(region => { let framed = [#box(clip: true, radius: 6.0pt)[#image(",
+		"let size = measure(framed, width: region.width)",
+		"#place(dx: 3pt, dy: 3pt)[#box(width: size.width, height: size.height, radius: 6.0pt, fill: " + c.tokens.ImageShadowColor + ")]#framed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected shadow markup %q, got %q", want, got)
+		}
 	}
 }
 
-// --- Injector with Labels ---
-
-func TestTypstConverter_InjectorWithPrefix(t *testing.T) {
-	c := newTestConverter(map[string]any{"name": "John"}, nil)
+func TestTypstConverter_ImageCredit(t *testing.T) {
+	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
-		Type:  portabledoc.NodeTypeInjector,
-		Attrs: map[string]any{"variableId": "name", "prefix": "Name: "},
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":    "https://example.com/img.png",
+			"width":  float64(200),
+			"align":  "right",
+			"credit": "Photo: J. Doe #1",
+		},
+	}
+
+	got := c.convertNode(node)
+	if !strings.HasPrefix(got, "#align(right)[#stack(spacing: 4pt, [#image(") {
+		t.Fatalf("expected image and credit stacked inside the image alignment, got %q", got)
+	}
+	if !strings.Contains(got, `[#text(size: 0.8em, fill: rgb("#757575"))[Photo: J. Doe \#1]]`) {
+		t.Fatalf("expected escaped muted credit line beneath the image, got %q", got)
+	}
+}
+
+func TestTypstConverter_ImageWithoutCredit(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "https://example.com/img.png", "align": "center", "credit": "  "},
+	}
+
+	got := c.convertNode(node)
+	if strings.Contains(got, "#stack(") {
+		t.Fatalf("expected a blank credit to render the image alone, got %q", got)
+	}
+}
+
+func TestTypstConverter_ImageStorageURL(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeImage,
+		Attrs: map[string]any{"src": "storage://asset-key", "width": float64(200)},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "img_1") {
+		t.Fatalf("expected generated local filename, got %q", got)
+	}
+	if _, ok := c.RemoteImages()["storage://asset-key"]; !ok {
+		t.Fatalf("expected storage URL to be tracked as remote image")
+	}
+}
+
+// --- Injector ---
+
+func TestTypstConverter_InjectorWithValue(t *testing.T) {
+	c := newTestConverter(map[string]any{"var1": "John Doe"}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+	}
+	got := c.convertNode(node)
+	if got != "John Doe" {
+		t.Errorf("got %q, want %q", got, "John Doe")
+	}
+}
+
+func TestTypstConverter_InjectorNamespacedKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		injectables map[string]any
+	}{
+		{"flat namespaced entry", "crm.first_name", map[string]any{"crm.first_name": "Ana"}},
+		{"nested namespace map", "crm.first_name", map[string]any{"crm": map[string]any{"first_name": "Ana"}}},
+		{"nested two levels", "crm.contact.first_name", map[string]any{"crm": map[string]any{"contact": map[string]any{"first_name": "Ana"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConverter(tt.injectables, nil)
+			if got := c.convertNode(injectorNode(tt.key)); got != "Ana" {
+				t.Errorf("got %q, want %q", got, "Ana")
+			}
+		})
+	}
+}
+
+func TestTypstConverter_InjectorNamespacesResolveCollision(t *testing.T) {
+	c := newTestConverter(map[string]any{
+		"first_name":    "Flat",
+		"crm":           map[string]any{"first_name": "Ana"},
+		"hr.first_name": "Beatriz",
+	}, nil)
+
+	for key, want := range map[string]string{
+		"first_name":     "Flat",
+		"crm.first_name": "Ana",
+		"hr.first_name":  "Beatriz",
+	} {
+		if got := c.convertNode(injectorNode(key)); got != want {
+			t.Errorf("%s: got %q, want %q", key, got, want)
+		}
+	}
+	if got := c.convertNode(injectorNode("erp.first_name")); got != "" {
+		t.Errorf("unknown namespace should resolve empty, got %q", got)
+	}
+}
+
+func TestTypstConverter_InjectorArrayPath(t *testing.T) {
+	c := newTestConverter(map[string]any{
+		"a": map[string]any{
+			"b": []any{
+				map[string]any{"c": "first"},
+				map[string]any{"c": "second"},
+			},
+		},
+		"items":  []map[string]any{{"name": "Widget", "qty": float64(3)}},
+		"matrix": [][]string{{"x", "y"}},
+		"title":  "Contract",
+	}, nil)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"a.b[1].c", "second"},
+		{"a.b[0].c", "first"},
+		{"items[0].name", "Widget"},
+		{"items[0].qty", "3"},
+		{"matrix[0][1]", "y"},
+		{"a.b[2].c", ""},
+		{"items[-1].name", ""},
+		{"title[0]", ""},
+		{"a[0].b", ""},
+		{"a.b.c", ""},
+		{"items[x].name", ""},
+		{"items[0", ""},
+		{"a.b[1]c", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := c.convertNode(injectorNode(tt.key)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypstConverter_InjectorFallbackVariables(t *testing.T) {
+	fallbackNode := func() portabledoc.Node {
+		node := injectorNode("preferred_name")
+		node.Attrs["fallbackVariableIds"] = []any{"legal_name", "nickname"}
+		node.Attrs["defaultValue"] = "Customer"
+		return node
+	}
+
+	tests := []struct {
+		name        string
+		injectables map[string]any
+		want        string
+	}{
+		{"primary present", map[string]any{"preferred_name": "Ana", "legal_name": "Ana María López"}, "Ana"},
+		{"primary empty, secondary present", map[string]any{"preferred_name": "", "legal_name": "Ana María López"}, "Ana María López"},
+		{"first fallback missing, second present", map[string]any{"nickname": "Anita"}, "Anita"},
+		{"all empty", map[string]any{"legal_name": ""}, "Customer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConverter(tt.injectables, nil)
+			if got := c.convertNode(fallbackNode()); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("all empty uses the global default", func(t *testing.T) {
+		c := newTestConverter(nil, map[string]string{"preferred_name": "Valued customer"})
+		node := injectorNode("preferred_name")
+		node.Attrs["fallbackVariableIds"] = []string{"legal_name"}
+		if got := c.convertNode(node); got != "Valued customer" {
+			t.Errorf("got %q, want %q", got, "Valued customer")
+		}
+	})
+
+	t.Run("fallback with only a static default uses it before the node default", func(t *testing.T) {
+		c := newTestConverter(map[string]any{"nickname": ""}, map[string]string{"legal_name": "Legal name on file"})
+		if got := c.convertNode(fallbackNode()); got != "Legal name on file" {
+			t.Errorf("got %q, want %q", got, "Legal name on file")
+		}
+	})
+
+	t.Run("later fallback value wins over an earlier fallback default", func(t *testing.T) {
+		c := newTestConverter(map[string]any{"nickname": "Anita"}, map[string]string{"legal_name": "Legal name on file"})
+		if got := c.convertNode(fallbackNode()); got != "Anita" {
+			t.Errorf("got %q, want %q", got, "Anita")
+		}
+	})
+}
+
+func TestTypstConverter_InjectorWithDefault(t *testing.T) {
+	c := newTestConverter(nil, map[string]string{"var1": "Default Name"})
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+	}
+	got := c.convertNode(node)
+	if got != "Default Name" {
+		t.Errorf("got %q, want %q", got, "Default Name")
+	}
+}
+
+func TestTypstConverter_InjectorEmpty(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "var1", "label": "Name"},
+	}
+	got := c.convertNode(node)
+	if got != "" {
+		t.Errorf("expected empty string for missing injectable, got %q", got)
+	}
+}
+
+func TestTypstConverter_InjectorCurrency(t *testing.T) {
+	c := newTestConverter(map[string]any{"price": float64(99.5)}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "price", "type": "CURRENCY", "format": "$"},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "99.50") {
+		t.Errorf("expected currency formatted value, got %q", got)
+	}
+}
+
+func TestTypstConverter_InjectorBoolean(t *testing.T) {
+	c := newTestConverter(map[string]any{"active": true}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "active"},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "S") { // "Si" or "Sí" depending on locale
+		t.Errorf("expected boolean true value, got %q", got)
+	}
+}
+
+func TestTypstConverter_InjectorNumber(t *testing.T) {
+	c := newTestConverter(map[string]any{"count": float64(42)}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "count"},
+	}
+	got := c.convertNode(node)
+	if got != "42" {
+		t.Errorf("got %q, want %q", got, "42")
+	}
+}
+
+func TestTypstConverter_InjectorCoercesProviderTypes(t *testing.T) {
+	name := "Ada Lovelace"
+	var missing *string
+	var missingTime *time.Time
+	var missingNumber *json.Number
+	issued := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		attrs map[string]any
+		want  string
+	}{
+		{"json number", json.Number("1234.5"), nil, "1234.5"},
+		{"json number currency", json.Number("99.5"), map[string]any{"type": "CURRENCY", "format": "$"}, "\\$ 99.50"},
+		{"json number integer", json.Number("42"), nil, "42"},
+		{"json number large integer", json.Number("9007199254740993"), nil, "9,007,199,254,740,993"},
+		{"json number integer currency", json.Number("99"), map[string]any{"type": "CURRENCY", "format": "$"}, "\\$ 99.00"},
+		{"string pointer", &name, nil, "Ada Lovelace"},
+		{"nil pointer", missing, nil, ""},
+		{"nil time pointer", missingTime, nil, ""},
+		{"nil json number pointer", missingNumber, nil, ""},
+		{"time", issued, nil, "2024-03-05"},
+		{"time pointer", &issued, nil, "2024-03-05"},
+		{"float32", float32(2.5), nil, "2.5"},
+		{"uint", uint(7), nil, "7"},
+		{"nested map", map[string]any{"street": "Main", "number": 1}, nil, ""},
+		{"slice", []any{"a", "b"}, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConverter(map[string]any{"value": tt.value}, nil)
+			attrs := map[string]any{"variableId": "value"}
+			for k, v := range tt.attrs {
+				attrs[k] = v
+			}
+			got := c.convertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: attrs})
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypstConverter_InjectorPlaceholders(t *testing.T) {
+	placeholder := `#text(fill: rgb("#856404"))[\[\[client\_name\]\]]`
+
+	t.Run("preview shows placeholder", func(t *testing.T) {
+		c := newTestConverter(map[string]any{"city": "Santiago"}, nil)
+		c.SetShowPlaceholders(true)
+
+		if got := c.convertNode(injectorNode("client_name")); got != placeholder {
+			t.Errorf("got %q, want %q", got, placeholder)
+		}
+		labelled := injectorNode("client_name")
+		labelled.Attrs["prefix"], labelled.Attrs["suffix"], labelled.Attrs["showLabelIfEmpty"] = "Name: ", ".", true
+		if got, want := c.convertNode(labelled), "Name: "+placeholder+"."; got != want {
+			t.Errorf("with label: got %q, want %q", got, want)
+		}
+		if got := c.convertNode(injectorNode("city")); got != "Santiago" {
+			t.Errorf("resolved injector must not show a placeholder, got %q", got)
+		}
+	})
+
+	t.Run("final render stays empty", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		if got := c.convertNode(injectorNode("client_name")); got != "" {
+			t.Errorf("got %q, want empty", got)
+		}
+	})
+}
+
+// --- Injector with Labels ---
+
+func TestTypstConverter_InjectorWithPrefix(t *testing.T) {
+	c := newTestConverter(map[string]any{"name": "John"}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "name", "prefix": "Name: "},
 	}
 	got := c.convertNode(node)
 	want := "Name: John"
@@ -894,6 +1617,130 @@ func TestTypstConverter_InjectorWidth(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_InjectorWidthUnits(t *testing.T) {
+	c := newTestConverter(map[string]any{"name": "John"}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": "name", "width": "0.5in"},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "#box(width: 36.0pt)") {
+		t.Errorf("expected box with 36pt width (0.5in), got %q", got)
+	}
+}
+
+// --- Injector provider defaults ---
+
+// fakeDefaultProvider simulates a workspace provider consulted on injectable misses.
+type fakeDefaultProvider struct {
+	values map[string]any
+	calls  map[string]int
+}
+
+func (f *fakeDefaultProvider) resolve(code string) (any, bool) {
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[code]++
+	v, ok := f.values[code]
+	return v, ok
+}
+
+func injectorNode(variableID string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"variableId": variableID},
+	}
+}
+
+func TestTypstConverter_InjectorProviderDefaultOnMiss(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{"company": "Acme Corp"}}
+	c := newTestConverter(nil, nil)
+	c.SetDefaultResolver(provider.resolve)
+
+	got := c.convertNode(injectorNode("company"))
+	if got != "Acme Corp" {
+		t.Errorf("got %q, want %q", got, "Acme Corp")
+	}
+}
+
+func TestTypstConverter_InjectorProviderDefaultResolvesPathRoot(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{
+		"items": []any{map[string]any{"name": "Widget"}, map[string]any{"name": "Gadget"}},
+	}}
+	c := newTestConverter(nil, nil)
+	c.SetDefaultResolver(provider.resolve)
+
+	first := c.convertNode(injectorNode("items[0].name"))
+	second := c.convertNode(injectorNode("items[1].name"))
+	if first != "Widget" || second != "Gadget" {
+		t.Errorf("got %q and %q, want Widget and Gadget", first, second)
+	}
+	if provider.calls["items"] != 1 || len(provider.calls) != 1 {
+		t.Errorf("expected the provider asked once for items, got %v", provider.calls)
+	}
+}
+
+func TestTypstConverter_InjectorProviderDefaultFormatsValue(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{"total": 1500.5}}
+	c := newTestConverter(nil, nil)
+	c.SetDefaultResolver(provider.resolve)
+
+	node := injectorNode("total")
+	node.Attrs["type"] = portabledoc.InjectorTypeCurrency
+	node.Attrs["format"] = "USD"
+	got := c.convertNode(node)
+	if got != "USD 1500.50" {
+		t.Errorf("got %q, want %q", got, "USD 1500.50")
+	}
+}
+
+func TestTypstConverter_InjectorProviderDefaultNotUsedWhenValueInjected(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{"company": "Acme Corp"}}
+	c := newTestConverter(map[string]any{"company": "Injected Inc"}, nil)
+	c.SetDefaultResolver(provider.resolve)
+
+	got := c.convertNode(injectorNode("company"))
+	if got != "Injected Inc" {
+		t.Errorf("got %q, want %q", got, "Injected Inc")
+	}
+	if provider.calls["company"] != 0 {
+		t.Errorf("provider should not be called for injected values, got %d calls", provider.calls["company"])
+	}
+}
+
+func TestTypstConverter_InjectorStaticDefaultBeforeProvider(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{"company": "Acme Corp"}}
+	c := newTestConverter(nil, map[string]string{"company": "Static Default"})
+	c.SetDefaultResolver(provider.resolve)
+
+	got := c.convertNode(injectorNode("company"))
+	if got != "Static Default" {
+		t.Errorf("got %q, want %q", got, "Static Default")
+	}
+	if provider.calls["company"] != 0 {
+		t.Errorf("provider should not be called when a static default exists, got %d calls", provider.calls["company"])
+	}
+}
+
+func TestTypstConverter_InjectorProviderDefaultCachedWithinRender(t *testing.T) {
+	provider := &fakeDefaultProvider{values: map[string]any{"company": "Acme Corp"}}
+	c := newTestConverter(nil, nil)
+	c.SetDefaultResolver(provider.resolve)
+
+	c.ConvertNodes([]portabledoc.Node{
+		paragraphNode(injectorNode("company"), injectorNode("missing")),
+		paragraphNode(injectorNode("company"), injectorNode("missing")),
+	})
+
+	if provider.calls["company"] != 1 {
+		t.Errorf("expected 1 provider call for hit, got %d", provider.calls["company"])
+	}
+	if provider.calls["missing"] != 1 {
+		t.Errorf("expected 1 provider call for miss, got %d", provider.calls["missing"])
+	}
+}
+
 // --- Conditional ---
 
 func TestTypstConverter_ConditionalTrue(t *testing.T) {
@@ -921,6 +1768,69 @@ func TestTypstConverter_ConditionalTrue(t *testing.T) {
 	}
 }
 
+// overdueStyle is a conditionalStyle mark that turns text red while status is "overdue".
+func overdueStyle() portabledoc.Mark {
+	return portabledoc.Mark{
+		Type: portabledoc.MarkTypeConditionalStyle,
+		Attrs: map[string]any{
+			"conditions": map[string]any{
+				"logic": "AND",
+				"children": []any{
+					map[string]any{
+						"type":       "rule",
+						"variableId": "status",
+						"operator":   "eq",
+						"value":      map[string]any{"mode": "text", "value": "overdue"},
+					},
+				},
+			},
+			"color": "#D32F2F",
+			"bold":  true,
+		},
+	}
+}
+
+func TestTypstConverter_ConditionalStyleMark(t *testing.T) {
+	text := "Amount due"
+	node := portabledoc.Node{Type: portabledoc.NodeTypeText, Text: &text, Marks: []portabledoc.Mark{overdueStyle()}}
+
+	overdue := newTestConverter(map[string]any{"status": "overdue"}, nil).convertNode(node)
+	if want := `#text(fill: rgb("#D32F2F"))[#strong[Amount due]]`; overdue != want {
+		t.Errorf("condition true: got %q, want %q", overdue, want)
+	}
+
+	current := newTestConverter(map[string]any{"status": "current"}, nil).convertNode(node)
+	if current != "Amount due" {
+		t.Errorf("condition false: got %q, want unstyled text", current)
+	}
+}
+
+func TestTypstConverter_ConditionalStyleWithoutConditions(t *testing.T) {
+	mark := overdueStyle()
+	delete(mark.Attrs, "conditions")
+	text := "Amount due"
+	node := portabledoc.Node{Type: portabledoc.NodeTypeText, Text: &text, Marks: []portabledoc.Mark{mark}}
+
+	if got := newTestConverter(nil, nil).convertNode(node); got != "Amount due" {
+		t.Errorf("got %q, want unstyled text", got)
+	}
+}
+
+func TestTypstConverter_ConditionalStyleOnInjector(t *testing.T) {
+	node := injectorNode("amount")
+	node.Marks = []portabledoc.Mark{overdueStyle()}
+
+	overdue := newTestConverter(map[string]any{"status": "overdue", "amount": "1.200"}, nil).convertNode(node)
+	if !strings.Contains(overdue, `fill: rgb("#D32F2F")`) || !strings.Contains(overdue, "#strong[1.200]") {
+		t.Errorf("condition true: expected red bold amount, got %q", overdue)
+	}
+
+	current := newTestConverter(map[string]any{"status": "current", "amount": "1.200"}, nil).convertNode(node)
+	if current != "1.200" {
+		t.Errorf("condition false: got %q, want unstyled amount", current)
+	}
+}
+
 func TestTypstConverter_ConditionalFalse(t *testing.T) {
 	c := newTestConverter(map[string]any{"status": "inactive"}, nil)
 	node := portabledoc.Node{
@@ -946,6 +1856,56 @@ func TestTypstConverter_ConditionalFalse(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_ConditionalHiddenNote(t *testing.T) {
+	rule := func(variableID, operator, value string) map[string]any {
+		return map[string]any{
+			"type":       "rule",
+			"variableId": variableID,
+			"operator":   operator,
+			"value":      map[string]any{"mode": "text", "value": value},
+		}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeConditional,
+		Attrs: map[string]any{
+			"conditions": map[string]any{
+				"logic": "AND",
+				"children": []any{
+					rule("status", "neq", "active"),
+					map[string]any{"type": "group", "logic": "OR", "children": []any{
+						rule("amount", "gt", "100"),
+						rule("vip", "is_true", ""),
+					}},
+				},
+			},
+		},
+		Content: []portabledoc.Node{paragraphNode(textNode("Hidden"))},
+	}
+	c := newTestConverter(map[string]any{"status": "active", "amount": 50, "vip": false}, nil)
+
+	if got := c.convertNode(node); got != "" {
+		t.Fatalf("expected final mode to render nothing, got %q", got)
+	}
+
+	c.SetShowHiddenConditionals(true)
+	got := c.convertNode(node)
+	want := `\[hidden: status != active AND (amount \> 100 OR vip is true)\]`
+	if !strings.Contains(got, want) || strings.Contains(got, "Hidden") {
+		t.Errorf("expected a hidden note with the built summary %q, got %q", want, got)
+	}
+
+	node.Attrs["expression"] = "Status is not active"
+	if got := c.convertNode(node); !strings.Contains(got, `\[hidden: Status is not active\]`) {
+		t.Errorf("expected the editor expression as the summary, got %q", got)
+	}
+
+	c = newTestConverter(map[string]any{"status": "draft", "amount": 500}, nil)
+	c.SetShowHiddenConditionals(true)
+	if got := c.convertNode(node); !strings.Contains(got, "Hidden") || strings.Contains(got, "hidden:") {
+		t.Errorf("expected a true conditional to render its content, got %q", got)
+	}
+}
+
 func TestTypstConverter_ConditionalOR(t *testing.T) {
 	c := newTestConverter(map[string]any{"a": "no", "b": "yes"}, nil)
 	node := portabledoc.Node{
@@ -1000,6 +1960,51 @@ func TestTypstConverter_ConditionalNoConditions(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_ConditionalMalformed(t *testing.T) {
+	validRule := map[string]any{"type": "rule", "variableId": "status", "operator": "eq", "value": map[string]any{"mode": "text", "value": "active"}}
+	tests := []struct {
+		name        string
+		conditions  any
+		wantVisible bool
+	}{
+		{"conditions is a string", "status == active", false},
+		{"conditions is an array", []any{validRule}, false},
+		{"children is not an array", map[string]any{"logic": "AND", "children": map[string]any{"0": validRule}}, false},
+		{"missing logic", map[string]any{"children": []any{validRule}}, false},
+		{"unknown logic", map[string]any{"logic": "XOR", "children": []any{validRule}}, false},
+		{"child is a number", map[string]any{"logic": "AND", "children": []any{float64(42)}}, false},
+		{"child has unknown type", map[string]any{"logic": "AND", "children": []any{map[string]any{"type": "formula"}}}, false},
+		{"variableId is a number", map[string]any{"logic": "AND", "children": []any{
+			map[string]any{"type": "rule", "variableId": float64(5), "operator": "eq"},
+		}}, false},
+		{"unknown operator", map[string]any{"logic": "AND", "children": []any{
+			map[string]any{"type": "rule", "variableId": "status", "operator": "~="},
+		}}, false},
+		{"value is a string", map[string]any{"logic": "AND", "children": []any{
+			map[string]any{"type": "rule", "variableId": "status", "operator": "eq", "value": "active"},
+		}}, false},
+		{"nested group with bad children", map[string]any{"logic": "AND", "children": []any{
+			map[string]any{"type": "group", "logic": "OR", "children": "oops"},
+		}}, false},
+		{"OR with one malformed child", map[string]any{"logic": "OR", "children": []any{"garbage", validRule}}, true},
+		{"null conditions", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConverter(map[string]any{"status": "active"}, nil)
+			node := portabledoc.Node{
+				Type:    portabledoc.NodeTypeConditional,
+				Attrs:   map[string]any{"conditions": tt.conditions},
+				Content: []portabledoc.Node{paragraphNode(textNode("Guarded"))},
+			}
+			got := c.convertNode(node)
+			if visible := strings.Contains(got, "Guarded"); visible != tt.wantVisible {
+				t.Errorf("visible = %v, want %v (output %q)", visible, tt.wantVisible, got)
+			}
+		})
+	}
+}
+
 // --- Table (user-created) ---
 
 func TestTypstConverter_Table(t *testing.T) {
@@ -1035,6 +2040,39 @@ func TestTypstConverter_Table(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_TableCellPadding(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeTableHeader, Content: []portabledoc.Node{paragraphNode(textNode("Name"))}},
+			}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeTableCell, Content: []portabledoc.Node{paragraphNode(textNode("Alice"))}},
+			}},
+		},
+	}
+
+	if got := c.convertNode(node); !strings.Contains(got, "  inset: (x: 6pt, y: 12pt),\n") {
+		t.Fatalf("expected the token inset without cellPadding, got %q", got)
+	}
+
+	node.Attrs = map[string]any{"cellPadding": map[string]any{"body": map[string]any{"x": float64(4), "y": float64(4)}}}
+	want := "  inset: (x, y) => if y == 0 { (x: 6pt, y: 12pt) } else { (x: 3.0pt, y: 3.0pt) },\n"
+	if got := c.convertNode(node); !strings.Contains(got, want) {
+		t.Errorf("expected compact body rows under the default header, got %q", got)
+	}
+
+	node.Attrs["cellPadding"] = map[string]any{
+		"header": map[string]any{"x": float64(4), "y": float64(4)},
+		"body":   map[string]any{"x": float64(4), "y": float64(4)},
+	}
+	if got := c.convertNode(node); !strings.Contains(got, "  inset: (x: 3.0pt, y: 3.0pt),\n") {
+		t.Errorf("expected a single inset when header and body match, got %q", got)
+	}
+}
+
 func TestTypstConverter_TableWithColspan(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
@@ -1054,6 +2092,226 @@ func TestTypstConverter_TableWithColspan(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_TableCellBackgroundColor(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	cell := func(text string, attrs map[string]any) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableCell, Attrs: attrs, Content: []portabledoc.Node{paragraphNode(textNode(text))}}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeTableHeader, Attrs: map[string]any{"backgroundColor": "#FFEEAA"}, Content: []portabledoc.Node{paragraphNode(textNode("Flagged"))}},
+				{Type: portabledoc.NodeTypeTableHeader, Content: []portabledoc.Node{paragraphNode(textNode("Plain"))}},
+			}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				cell("Hot", map[string]any{"backgroundColor": "#f00", "colspan": float64(2)}),
+			}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				cell("Bad", map[string]any{"backgroundColor": `red") + rgb("#000`}),
+				cell("None", nil),
+			}},
+		},
+	}
+
+	got := c.convertNode(node)
+	if !strings.Contains(got, `  table.cell(fill: rgb("#FFEEAA"))[`) {
+		t.Errorf("expected the header cell fill to override the header fill, got %q", got)
+	}
+	if !strings.Contains(got, `  table.cell(colspan: 2, fill: rgb("#f00"))[`) {
+		t.Errorf("expected the fill combined with the span, got %q", got)
+	}
+	if n := strings.Count(got, "table.cell("); n != 2 {
+		t.Errorf("expected only the two colored cells as table.cell, got %d in %q", n, got)
+	}
+	if strings.Contains(got, `rgb("#000`) {
+		t.Errorf("expected the invalid color to be dropped, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableCellTextStyle(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	row := func(attrs map[string]any, text string) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableCell, Attrs: attrs, Content: []portabledoc.Node{paragraphNode(textNode(text))}},
+		}}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			row(map[string]any{"color": "#C00000", "bold": true}, "Overdue"),
+			row(map[string]any{"color": "crimson", "bold": false}, "Plain"),
+		},
+	}
+
+	got := c.convertNode(node)
+	if !strings.Contains(got, `  [#text(fill: rgb("#C00000"), weight: "bold")[#[`) {
+		t.Errorf("expected the bold colored cell wrapped in #text, got %q", got)
+	}
+	if n := strings.Count(got, "#text("); n != 1 {
+		t.Errorf("expected only the styled cell to be wrapped, got %d in %q", n, got)
+	}
+}
+
+func TestTypstConverter_TableBorderStyle(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("name", map[string]string{"en": "Name"}, entity.ValueTypeString)
+	tv.AddRow(entity.Cell(entity.StringValue("Item A")))
+	c := newTestConverter(map[string]any{"table1": tv}, nil)
+
+	editable := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeTableCell, Content: []portabledoc.Node{paragraphNode(textNode("Alice"))}},
+			}},
+		},
+	}
+	injector := portabledoc.Node{Type: portabledoc.NodeTypeTableInjector, Attrs: map[string]any{"variableId": "table1"}}
+
+	tests := []struct {
+		style     any
+		stroke    string
+		blockOpen string
+	}{
+		{nil, "  stroke: 0.5pt + luma(200),\n", "#block[\n"},
+		{"all", "  stroke: 0.5pt + luma(200),\n", "#block[\n"},
+		{"unknown", "  stroke: 0.5pt + luma(200),\n", "#block[\n"},
+		{"horizontal", "  stroke: (x: none, y: 0.5pt + luma(200)),\n", "#block[\n"},
+		{"none", "  stroke: none,\n", "#block[\n"},
+		{"outer", "  stroke: none,\n", "#block(stroke: 0.5pt + luma(200))[\n"},
+	}
+	for _, tt := range tests {
+		for name, node := range map[string]portabledoc.Node{"table": editable, "tableInjector": injector} {
+			node.Attrs = map[string]any{"variableId": "table1"}
+			if tt.style != nil {
+				node.Attrs["borderStyle"] = tt.style
+			}
+			got := c.convertNode(node)
+			if !strings.Contains(got, tt.stroke) {
+				t.Errorf("%s with borderStyle %v: expected %q, got %q", name, tt.style, tt.stroke, got)
+			}
+			if !strings.HasPrefix(got, tt.blockOpen) {
+				t.Errorf("%s with borderStyle %v: expected block %q, got %q", name, tt.style, tt.blockOpen, got)
+			}
+		}
+	}
+}
+
+func TestTypstConverter_TableStripeColor(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	row := func(text string) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableCell, Content: []portabledoc.Node{paragraphNode(textNode(text))}},
+		}}
+	}
+	node := portabledoc.Node{
+		Type:    portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{row("Header"), row("One"), row("Two")},
+	}
+
+	plain := "  fill: (x, y) => if y == 0 { rgb(\"#f5f5f5\") },\n"
+	if got := c.convertNode(node); !strings.Contains(got, plain) {
+		t.Fatalf("expected header-only fill without stripeColor, got %q", got)
+	}
+
+	node.Attrs = map[string]any{"stripeColor": "#F0F4FA"}
+	striped := "  fill: (x, y) => if y == 0 { rgb(\"#f5f5f5\") } else if calc.odd(y) { rgb(\"#F0F4FA\") },\n"
+	if got := c.convertNode(node); !strings.Contains(got, striped) {
+		t.Errorf("expected striped body rows, got %q", got)
+	}
+
+	node.Attrs = map[string]any{"stripeColor": "blue\") }"}
+	if got := c.convertNode(node); !strings.Contains(got, plain) {
+		t.Errorf("expected an invalid stripeColor to be ignored, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableClampsOverSpanningCells(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	cell := func(attrs map[string]any) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableCell, Attrs: attrs, Content: []portabledoc.Node{paragraphNode(textNode("x"))}}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{cell(nil), cell(nil)}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{cell(map[string]any{"rowspan": float64(9)}), cell(nil)}},
+		},
+	}
+	got := c.convertNode(node)
+	if strings.Contains(got, "rowspan") {
+		t.Errorf("expected a rowspan past the last row to be dropped, got %q", got)
+	}
+
+	node.Content[0].Content = []portabledoc.Node{cell(map[string]any{"colspan": float64(1000)})}
+	got = c.convertNode(node)
+	if !strings.Contains(got, "table.cell(colspan: 2)[") {
+		t.Errorf("expected the colspan clamped to the table's columns, got %q", got)
+	}
+	if !strings.Contains(got, "columns: (1fr, 1fr)") {
+		t.Errorf("expected the rows without merged columns to set 2 columns, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableColumnsFromColwidths(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	cell := func(attrs map[string]any) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableCell, Attrs: attrs, Content: []portabledoc.Node{paragraphNode(textNode("x"))}}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{
+				cell(map[string]any{"colspan": float64(2), "colwidth": []any{float64(100), float64(100)}}),
+				cell(map[string]any{"colwidth": []any{float64(200)}}),
+			}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{cell(nil), cell(map[string]any{"colspan": float64(1000)})}},
+		},
+	}
+	if got := c.countTableColumns(node); got != 3 {
+		t.Errorf("expected the first row's colwidths to set 3 columns, got %d", got)
+	}
+}
+
+func TestTypstConverter_TableSkipsColumnsCoveredByRowspans(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	cell := func(attrs map[string]any, text string) portabledoc.Node {
+		return portabledoc.Node{Type: portabledoc.NodeTypeTableCell, Attrs: attrs, Content: []portabledoc.Node{paragraphNode(textNode(text))}}
+	}
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTable,
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{cell(map[string]any{"rowspan": float64(2)}, "A"), cell(nil, "B"), cell(nil, "C")}},
+			{Type: portabledoc.NodeTypeTableRow, Content: []portabledoc.Node{cell(map[string]any{"colspan": float64(5)}, "D")}},
+		},
+	}
+	got := c.convertNode(node)
+	if !strings.Contains(got, "columns: (1fr, 1fr, 1fr)") {
+		t.Errorf("expected 3 columns, got %q", got)
+	}
+	if !strings.Contains(got, "table.cell(colspan: 2)[") || strings.Contains(got, "colspan: 3") {
+		t.Errorf("expected the colspan clamped to the columns left after the rowspan, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableInjectorClampsOverSpanningCells(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("a", map[string]string{"en": "A"}, entity.ValueTypeString)
+	tv.AddColumn("b", map[string]string{"en": "B"}, entity.ValueTypeString)
+	tv.AddRow(entity.Cell(entity.StringValue("A1")), entity.CellWithSpan(entity.StringValue("B1"), 5, 0))
+	tv.AddRow(entity.CellWithSpan(entity.StringValue("A2"), 2, 4))
+	c := newTestConverter(map[string]any{"table1": tv}, nil)
+
+	got := c.convertNode(portabledoc.Node{Type: portabledoc.NodeTypeTableInjector, Attrs: map[string]any{"variableId": "table1"}})
+	if !strings.Contains(got, "table.cell(inset: (x: 6pt, y: 10pt))[B1]") {
+		t.Errorf("expected the colspan past the last column to be dropped, got %q", got)
+	}
+	if !strings.Contains(got, "table.cell(colspan: 2, inset: (x: 6pt, y: 10pt))[A2]") {
+		t.Errorf("expected the rowspan past the last row to be dropped, got %q", got)
+	}
+}
+
 // --- Table Column Widths ---
 
 func TestTypstConverter_TableWithExplicitColwidths(t *testing.T) {
@@ -1169,18 +2427,46 @@ func TestTypstConverter_TableInjector(t *testing.T) {
 	c := newTestConverter(map[string]any{"table1": tv}, nil)
 	node := portabledoc.Node{
 		Type:  portabledoc.NodeTypeTableInjector,
-		Attrs: map[string]any{"variableId": "table1", "lang": "en"},
+		Attrs: map[string]any{"variableId": "table1", "lang": "en"},
+	}
+	got := c.convertNode(node)
+
+	if !strings.Contains(got, "#table(") {
+		t.Errorf("expected #table(, got %q", got)
+	}
+	if !strings.Contains(got, "Name") || !strings.Contains(got, "Amount") {
+		t.Errorf("expected column headers, got %q", got)
+	}
+	if !strings.Contains(got, "Item A") || !strings.Contains(got, "Item B") {
+		t.Errorf("expected row data, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableInjectorCellPadding(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("name", map[string]string{"en": "Name"}, entity.ValueTypeString)
+	tv.AddRow(entity.Cell(entity.StringValue("Item A")))
+	c := newTestConverter(map[string]any{"table1": tv}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{"variableId": "table1"},
 	}
+
 	got := c.convertNode(node)
+	if !strings.Contains(got, "table.cell(inset: (x: 6pt, y: 12pt))[Name]") || !strings.Contains(got, "table.cell(inset: (x: 6pt, y: 10pt))[Item A]") {
+		t.Fatalf("expected token insets without cellPadding, got %q", got)
+	}
 
-	if !strings.Contains(got, "#table(") {
-		t.Errorf("expected #table(, got %q", got)
+	node.Attrs["cellPadding"] = map[string]any{
+		"header": map[string]any{"x": float64(4), "y": "2mm"},
+		"body":   map[string]any{"y": float64(2)},
 	}
-	if !strings.Contains(got, "Name") || !strings.Contains(got, "Amount") {
-		t.Errorf("expected column headers, got %q", got)
+	got = c.convertNode(node)
+	if !strings.Contains(got, "table.cell(inset: (x: 3.0pt, y: 5.7pt))[Name]") {
+		t.Errorf("expected header padding override, got %q", got)
 	}
-	if !strings.Contains(got, "Item A") || !strings.Contains(got, "Item B") {
-		t.Errorf("expected row data, got %q", got)
+	if !strings.Contains(got, "table.cell(inset: (..(x: 6pt, y: 10pt), y: 1.5pt))[Item A]") {
+		t.Errorf("expected body y override keeping the token x, got %q", got)
 	}
 }
 
@@ -1200,6 +2486,31 @@ func TestTypstConverter_TableInjectorSpanish(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_TableInjectorColumnPrecision(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumnWithPrecision("units", map[string]string{"en": "Units"}, 0)
+	tv.AddColumnWithPrecision("rate", map[string]string{"en": "Rate"}, 4)
+	tv.AddColumnWithPrecision("total", map[string]string{"en": "Total"}, 4)
+	tv.Columns[2].Format = ptrTo("%.1f") // an explicit format wins over precision
+	tv.AddRow(
+		entity.Cell(entity.NumberValue(12.5)),
+		entity.Cell(entity.NumberValue(0.12345)),
+		entity.Cell(entity.NumberValue(42.25)),
+	)
+
+	c := newTestConverter(map[string]any{"t1": tv}, nil)
+	got := c.convertNode(portabledoc.Node{
+		Type:  portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{"variableId": "t1", "lang": "en"},
+	})
+
+	for _, want := range []string{"[13]", "[0.1235]", "[42.2]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected cell %s, got %q", want, got)
+		}
+	}
+}
+
 func TestTypstConverter_TableInjectorMissing(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	node := portabledoc.Node{
@@ -1237,12 +2548,14 @@ func TestEscapeTypst(t *testing.T) {
 	}
 }
 
-func TestUnescapeTypst(t *testing.T) {
-	original := "$100 #tag *bold* _underscored_"
-	escaped := escapeTypst(original)
-	unescaped := unescapeTypst(escaped)
-	if unescaped != original {
-		t.Errorf("round-trip failed: %q -> %q -> %q", original, escaped, unescaped)
+func TestTypstConverter_EmojiSurviveConversion(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	// Skin-tone modifier, ZWJ family sequence, flag and a variation selector.
+	text := "Signed 👍🏽 by 👨‍👩‍👧 in 🇨🇱 ✔️"
+
+	got := c.convertNode(paragraphNode(textNode(text)))
+	if !strings.Contains(got, text) {
+		t.Errorf("emoji must pass through unescaped, got %q", got)
 	}
 }
 
@@ -1268,6 +2581,203 @@ func TestTypstConverter_ConvertNodes(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_WriteNodesMatchesConvertNodes(t *testing.T) {
+	nodes := []portabledoc.Node{
+		{Type: portabledoc.NodeTypeHeading, Attrs: map[string]any{"level": float64(1)}, Content: []portabledoc.Node{textNode("Title")}},
+		{Type: portabledoc.NodeTypeImage, Attrs: map[string]any{"src": "https://example.com/a.png", "displayMode": "inline", "align": "left"}},
+		paragraphNode(textNode("Wrapped text")),
+		paragraphNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "name"}}),
+		{Type: portabledoc.NodeTypePageBreak},
+		paragraphNode(textNode("Second page")),
+	}
+
+	want, wantFields := newTestConverter(map[string]any{"name": "Ada"}, nil).ConvertNodes(nodes)
+
+	streamed := newTestConverter(map[string]any{"name": "Ada"}, nil)
+	var sb strings.Builder
+	gotFields, err := streamed.WriteNodes(&sb, nodes)
+	if err != nil {
+		t.Fatalf("WriteNodes failed: %v", err)
+	}
+	if sb.String() != want {
+		t.Errorf("streamed output differs:\n--- buffered ---\n%s\n--- streamed ---\n%s", want, sb.String())
+	}
+	if len(gotFields) != len(wantFields) || streamed.GetCurrentPage() != 2 {
+		t.Errorf("fields = %d (want %d), page = %d (want 2)", len(gotFields), len(wantFields), streamed.GetCurrentPage())
+	}
+}
+
+func TestTypstConverter_DispatchRoutesEveryNodeType(t *testing.T) {
+	want := map[string]typstNodeHandler{
+		portabledoc.NodeTypeParagraph:        (*typstConverter).paragraph,
+		portabledoc.NodeTypeHeading:          (*typstConverter).heading,
+		portabledoc.NodeTypeBlockquote:       (*typstConverter).blockquote,
+		portabledoc.NodeTypeCodeBlock:        (*typstConverter).codeBlock,
+		portabledoc.NodeTypeHR:               (*typstConverter).horizontalRule,
+		portabledoc.NodeTypeBulletList:       (*typstConverter).bulletList,
+		portabledoc.NodeTypeOrderedList:      (*typstConverter).orderedList,
+		portabledoc.NodeTypeTaskList:         (*typstConverter).taskList,
+		portabledoc.NodeTypeListItem:         (*typstConverter).listItem,
+		portabledoc.NodeTypeTaskItem:         (*typstConverter).taskItem,
+		portabledoc.NodeTypeInjector:         (*typstConverter).injector,
+		portabledoc.NodeTypeConditional:      (*typstConverter).conditional,
+		portabledoc.NodeTypeSignature:        (*typstConverter).signature,
+		portabledoc.NodeTypePageBreak:        (*typstConverter).pageBreak,
+		portabledoc.NodeTypeImage:            (*typstConverter).image,
+		portabledoc.NodeTypeCustomImage:      (*typstConverter).image,
+		portabledoc.NodeTypeText:             (*typstConverter).text,
+		portabledoc.NodeTypeListInjector:     (*typstConverter).listInjector,
+		portabledoc.NodeTypeTableInjector:    (*typstConverter).tableInjector,
+		portabledoc.NodeTypeTable:            (*typstConverter).table,
+		portabledoc.NodeTypeTableRow:         (*typstConverter).tableRow,
+		portabledoc.NodeTypeTableCell:        (*typstConverter).tableCellData,
+		portabledoc.NodeTypeTableHeader:      (*typstConverter).tableCellHeader,
+		portabledoc.NodeTypeHardBreak:        (*typstConverter).hardBreak,
+		portabledoc.NodeTypeInteractiveField: (*typstConverter).interactiveField,
+		portabledoc.NodeTypeInsertionPoint:   (*typstConverter).insertionPoint,
+		portabledoc.NodeTypeMath:             (*typstConverter).math,
+		portabledoc.NodeTypeMathBlock:        (*typstConverter).mathBlock,
+		portabledoc.NodeTypeCrossReference:   (*typstConverter).crossReference,
+		portabledoc.NodeTypeDetails:          (*typstConverter).details,
+		portabledoc.NodeTypeDefinitionList:   (*typstConverter).definitionList,
+	}
+	if len(typstNodeHandlers) != len(want) {
+		t.Fatalf("handler table has %d entries, want %d", len(typstNodeHandlers), len(want))
+	}
+
+	text := "inner"
+	for typ, handler := range want {
+		if _, ok := typstNodeHandlers[typ]; !ok {
+			t.Errorf("%s: no handler registered", typ)
+			continue
+		}
+		node := portabledoc.Node{
+			Type: typ,
+			Text: &text,
+			Attrs: map[string]any{
+				"level": float64(2), "variableId": "name", "label": "Field",
+				"count": float64(1), "signatures": []any{map[string]any{"id": "sig-1", "label": "Signer"}},
+			},
+			Content: []portabledoc.Node{paragraphNode(textNode("inner"))},
+		}
+		got := newTestConverter(map[string]any{"name": "Ada"}, nil).convertNode(node)
+		direct := handler(newTestConverter(map[string]any{"name": "Ada"}, nil), node)
+		if got != direct {
+			t.Errorf("%s: dispatch produced %q, handler produced %q", typ, got, direct)
+		}
+	}
+}
+
+func BenchmarkTypstConverter_ConvertNodes(b *testing.B) {
+	nodes := make([]portabledoc.Node, 0, 200)
+	for i := range 50 {
+		nodes = append(nodes,
+			portabledoc.Node{Type: portabledoc.NodeTypeHeading, Attrs: map[string]any{"level": float64(2)}, Content: []portabledoc.Node{textNode(fmt.Sprintf("Section %d", i))}},
+			paragraphNode(textNode("Lorem ipsum "), markedTextNode("dolor", markOf(portabledoc.MarkTypeBold)), textNode(" sit amet.")),
+			portabledoc.Node{Type: portabledoc.NodeTypeBulletList, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeListItem, Content: []portabledoc.Node{paragraphNode(textNode("one"))}},
+				{Type: portabledoc.NodeTypeListItem, Content: []portabledoc.Node{paragraphNode(textNode("two"))}},
+			}},
+			paragraphNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "name"}}),
+		)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		c := newTestConverter(map[string]any{"name": "Ada"}, nil)
+		c.ConvertNodes(nodes)
+	}
+}
+
+// --- Insertion Points ---
+
+func insertionPointNode(slot string) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeInsertionPoint, Attrs: map[string]any{"slot": slot}}
+}
+
+func TestTypstConverter_InsertionPoint(t *testing.T) {
+	nodes := []portabledoc.Node{
+		paragraphNode(textNode("Terms")),
+		insertionPointNode("appendix"),
+		insertionPointNode("annex"),
+		paragraphNode(textNode("End")),
+	}
+	// inOrder reports whether each part appears after the previous one.
+	inOrder := func(got string, parts ...string) bool {
+		pos := 0
+		for _, p := range parts {
+			i := strings.Index(got[pos:], p)
+			if i < 0 {
+				return false
+			}
+			pos += i + len(p)
+		}
+		return true
+	}
+
+	t.Run("fills a slot and leaves another empty", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		c.SetSlots(map[string][]portabledoc.Node{
+			"appendix": {paragraphNode(textNode("Transaction 1")), paragraphNode(textNode("Transaction 2"))},
+		})
+		got, _ := c.ConvertNodes(nodes)
+		if !inOrder(got, "Terms", "Transaction 1", "Transaction 2", "End") {
+			t.Errorf("expected the slot content between the surrounding paragraphs:\n%s", got)
+		}
+		if n := strings.Count(got, "#set par("); n != 4 {
+			t.Errorf("expected 4 paragraphs and nothing for the empty slot, got %d:\n%s", n, got)
+		}
+	})
+
+	t.Run("no slots renders nothing", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		got, _ := c.ConvertNodes(nodes)
+		want, _ := newTestConverter(nil, nil).ConvertNodes([]portabledoc.Node{nodes[0], nodes[3]})
+		if got != want {
+			t.Errorf("unfilled slots must render nothing:\n%s", got)
+		}
+	})
+
+	t.Run("slot content cannot insert itself", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		c.SetSlots(map[string][]portabledoc.Node{
+			"appendix": {paragraphNode(textNode("Row")), insertionPointNode("appendix")},
+		})
+		if got := c.convertNode(insertionPointNode("appendix")); strings.Count(got, "Row") != 1 {
+			t.Errorf("expected a single expansion:\n%s", got)
+		}
+	})
+}
+
+// --- Math ---
+
+func TestTypstConverter_Math(t *testing.T) {
+	c := newTestConverter(nil, nil)
+
+	inline := paragraphNode(
+		textNode("Energy: "),
+		portabledoc.Node{Type: portabledoc.NodeTypeMath, Attrs: map[string]any{"latex": `E = mc^2`}},
+		textNode(" holds."),
+	)
+	if got := c.convertNode(inline); !strings.Contains(got, "Energy: $E = m c^(2)$ holds.") {
+		t.Errorf("inline equation: got %q", got)
+	}
+
+	block := portabledoc.Node{Type: portabledoc.NodeTypeMathBlock, Attrs: map[string]any{"latex": `\frac{a}{b}`}}
+	if got, want := c.convertNode(block), "$ frac(a, b) $\n"; got != want {
+		t.Errorf("block equation: got %q, want %q", got, want)
+	}
+
+	typst := portabledoc.Node{Type: portabledoc.NodeTypeMathBlock, Attrs: map[string]any{"typst": "sum_(k=0)^n k", "latex": "ignored"}}
+	if got, want := c.convertNode(typst), "$ sum_(k=0)^n k $\n"; got != want {
+		t.Errorf("typst source: got %q, want %q", got, want)
+	}
+
+	if got := c.convertNode(portabledoc.Node{Type: portabledoc.NodeTypeMath}); got != "" {
+		t.Errorf("empty equation: got %q, want empty", got)
+	}
+}
+
 // --- Unknown node ---
 
 func TestTypstConverter_UnknownNode(t *testing.T) {
@@ -1489,6 +2999,40 @@ func TestRenderSignatureBlock_TripleRow(t *testing.T) {
 	}
 }
 
+func TestRenderSignatureBlock_IndexAndTotalPlaceholders(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	sigs := makeSigs(3)
+	for i := range sigs {
+		sub := "Party {index}"
+		sigs[i].Label = "Signatory {index} of {total}"
+		sigs[i].Subtitle = &sub
+		sigs[i].Captions = []portabledoc.SignatureCaption{{Text: "{index}/{total}"}}
+	}
+	attrs := portabledoc.SignatureAttrs{Count: 3, Layout: portabledoc.LayoutTriplePyramid, LineWidth: "md", Signatures: sigs}
+	got := c.renderSignatureBlock(attrs)
+
+	var last int
+	for i := 1; i <= 3; i++ {
+		label := fmt.Sprintf("[Signatory %d of 3]", i)
+		pos := strings.Index(got, label)
+		if pos < last {
+			t.Fatalf("expected %q after the previous signature, got:\n%s", label, got)
+		}
+		last = pos
+		for _, want := range []string{fmt.Sprintf("[Party %d]", i), fmt.Sprintf("[%d/3]", i)} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected %q in output:\n%s", want, got)
+			}
+		}
+	}
+	if strings.Contains(got, "{index}") || strings.Contains(got, "{total}") {
+		t.Errorf("expected every placeholder replaced:\n%s", got)
+	}
+	if sigs[0].Label != "Signatory {index} of {total}" || *sigs[0].Subtitle != "Party {index}" || sigs[0].Captions[0].Text != "{index}/{total}" {
+		t.Errorf("expected the block's signatures left untouched, got %+v", sigs[0])
+	}
+}
+
 func TestRenderSignatureBlock_TriplePyramid(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	attrs := portabledoc.SignatureAttrs{Count: 3, Layout: portabledoc.LayoutTriplePyramid, LineWidth: "md", Signatures: makeSigs(3)}
@@ -1629,6 +3173,24 @@ func TestRenderSignatureBlock_PlacesAnchorOnSignatureLineOutOfFlow(t *testing.T)
 	}
 }
 
+func TestRenderSignatureBlock_DebugAnchors(t *testing.T) {
+	attrs := portabledoc.SignatureAttrs{Count: 1, Layout: portabledoc.LayoutSingleCenter, LineWidth: "md", Signatures: makeSigs(1)}
+	const hidden = `#text(size: 0.1pt, fill: white)[\_\_sig\_sig\_0\_\_]`
+	const visible = `#text(fill: red)[\_\_sig\_sig\_0\_\_]`
+
+	c := newTestConverter(nil, nil)
+	got := c.renderSignatureBlock(attrs)
+	if !strings.Contains(got, hidden) || strings.Contains(got, visible) {
+		t.Errorf("anchors must be invisible by default:\n%s", got)
+	}
+
+	c.SetDebugAnchors(true)
+	got = c.renderSignatureBlock(attrs)
+	if !strings.Contains(got, visible) || strings.Contains(got, hidden) {
+		t.Errorf("debug anchors must be visible:\n%s", got)
+	}
+}
+
 func TestCapSignatureLineWidth(t *testing.T) {
 	c := newTestConverter(nil, nil)
 	// A4: contentWidthPx=642.5 → contentWidthPt=481.875 → 3-col max=(481.875-22)/3≈153.3pt
@@ -1662,6 +3224,247 @@ func TestCapSignatureLineWidth(t *testing.T) {
 	})
 }
 
+func TestRenderSignatureBlock_FewerSignaturesThanLayout(t *testing.T) {
+	c := newTestConverter(nil, nil)
+
+	t.Run("quad grid with two", func(t *testing.T) {
+		attrs := portabledoc.SignatureAttrs{Count: 4, Layout: portabledoc.LayoutQuadGrid, LineWidth: "md", Signatures: makeSigs(2)}
+		got := c.renderSignatureBlock(attrs)
+		if !strings.Contains(got, "columns: (1fr, 1fr)") {
+			t.Errorf("expected a grid of the two signatures:\n%s", got)
+		}
+		if strings.Count(got, "Signer ") != 2 {
+			t.Errorf("expected both signatures rendered:\n%s", got)
+		}
+	})
+
+	t.Run("quad top heavy with one", func(t *testing.T) {
+		attrs := portabledoc.SignatureAttrs{Count: 4, Layout: portabledoc.LayoutQuadTopHeavy, LineWidth: "md", Signatures: makeSigs(1)}
+		got := c.renderSignatureBlock(attrs)
+		if !strings.Contains(got, "columns: (1fr)") || !strings.Contains(got, "Signer 0") {
+			t.Errorf("expected a single-column grid:\n%s", got)
+		}
+	})
+
+	t.Run("single with zero", func(t *testing.T) {
+		attrs := portabledoc.SignatureAttrs{Count: 1, Layout: portabledoc.LayoutSingleCenter, LineWidth: "md"}
+		if got := c.renderSignatureBlock(attrs); got != "" {
+			t.Errorf("expected no output for an empty block, got:\n%s", got)
+		}
+	})
+}
+
+func TestRenderSignatureBlock_LineWidthOverride(t *testing.T) {
+	// A4: contentWidthPx=642.5 → contentWidthPt=481.875; the block width is capped at 153.3pt.
+	newConverter := func() *typstConverter {
+		c := newTestConverter(nil, nil)
+		c.SetContentWidthPx(642.5)
+		c.SetPageWidthPx(794)
+		return c
+	}
+	wide := 400.0 // px → 300pt
+
+	t.Run("single signature exceeds the cap", func(t *testing.T) {
+		sigs := makeSigs(1)
+		sigs[0].LineWidth = &wide
+		got := newConverter().renderSignatureBlock(portabledoc.SignatureAttrs{
+			Count: 1, Layout: portabledoc.LayoutSingleCenter, LineWidth: "lg", Signatures: sigs,
+		})
+		if !strings.Contains(got, "#block(width: 300.0pt)") {
+			t.Errorf("expected the overridden 300pt line:\n%s", got)
+		}
+	})
+
+	t.Run("bounded by the page", func(t *testing.T) {
+		huge := 2000.0
+		sigs := makeSigs(1)
+		sigs[0].LineWidth = &huge
+		got := newConverter().renderSignatureBlock(portabledoc.SignatureAttrs{
+			Count: 1, Layout: portabledoc.LayoutSingleLeft, LineWidth: "md", Signatures: sigs,
+		})
+		if !strings.Contains(got, "#block(width: 481.9pt)") {
+			t.Errorf("expected the line bounded by the content width:\n%s", got)
+		}
+	})
+
+	t.Run("bounded by the layout column", func(t *testing.T) {
+		sigs := makeSigs(2)
+		sigs[0].LineWidth = &wide
+		got := newConverter().renderSignatureBlock(portabledoc.SignatureAttrs{
+			Count: 2, Layout: portabledoc.LayoutDualSides, LineWidth: "md", Signatures: sigs,
+		})
+		if !strings.Contains(got, "#block(width: 235.4pt)") {
+			t.Errorf("expected the override bounded to half the content width:\n%s", got)
+		}
+		if !strings.Contains(got, "#block(width: 153.3pt)") {
+			t.Errorf("expected the other signature to keep the capped width:\n%s", got)
+		}
+	})
+
+	t.Run("field width follows the override", func(t *testing.T) {
+		role := "role_1"
+		sigs := makeSigs(1)
+		sigs[0].RoleID = &role
+		sigs[0].LineWidth = &wide
+		c := newConverter()
+		c.collectSignatureFields(portabledoc.SignatureAttrs{
+			Count: 1, Layout: portabledoc.LayoutSingleCenter, LineWidth: "lg", Signatures: sigs,
+		})
+		if len(c.signatureFields) != 1 {
+			t.Fatalf("expected one field, got %d", len(c.signatureFields))
+		}
+		if got, want := c.signatureFields[0].Width, 300/(794*pxToPt)*100; math.Abs(got-want) > 0.01 {
+			t.Errorf("field width = %.2f%%, want %.2f%%", got, want)
+		}
+	})
+}
+
+func TestRenderSignatureItem_CaptionLines(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	size, color := 10.0, "#555555"
+	sig := portabledoc.SignatureItem{
+		ID:    "sig_1",
+		Label: "Employer",
+		Captions: []portabledoc.SignatureCaption{
+			{Text: "Name: Jane Doe", Bold: true},
+			{Text: "Title: CEO", Size: &size, Italic: true, Color: &color},
+			{Text: ""},
+		},
+	}
+
+	got := c.renderTypstSignatureItemContent(&sig, "150pt")
+
+	for _, want := range []string{
+		`#align(center)[#text(size: 8pt, weight: "bold")[Name: Jane Doe]]`,
+		`#align(center)[#text(size: 10pt, style: "italic", fill: rgb("#555555"))[Title: CEO]]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "Employer") > strings.Index(got, "Name: Jane Doe") ||
+		strings.Index(got, "Name: Jane Doe") > strings.Index(got, "Title: CEO") {
+		t.Errorf("captions must follow the label in order:\n%s", got)
+	}
+	if strings.Count(got, "#align(center)") != 3 {
+		t.Errorf("empty captions must be skipped:\n%s", got)
+	}
+}
+
+func TestRenderSignatureItem_DateLine(t *testing.T) {
+	signedAt := "2026-03-05T14:30:00Z"
+	image := "https://example.com/sig.png"
+
+	t.Run("unsigned shows a blank", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		c.SetLanguage("es")
+		sig := portabledoc.SignatureItem{ID: "sig_1", DateLine: &portabledoc.SignatureDateLine{}, SignedAt: &signedAt}
+		got := c.renderTypstSignatureItemContent(&sig, "150pt")
+		if !strings.Contains(got, `Fecha: \_\_\_\_\_\_\_\_\_\_\_\_`) {
+			t.Errorf("expected a blank date line:\n%s", got)
+		}
+		if strings.Contains(got, "2026") {
+			t.Errorf("unsigned signature must not show the date:\n%s", got)
+		}
+	})
+
+	t.Run("signed shows the signed date", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		c.SetLanguage("en")
+		sig := portabledoc.SignatureItem{
+			ID: "sig_1", ImageData: &image, SignedAt: &signedAt,
+			DateLine: &portabledoc.SignatureDateLine{Label: "Date"},
+		}
+		got := c.renderTypstSignatureItemContent(&sig, "150pt")
+		want := "Date: " + c.locale().formatDate(time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC))
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	})
+
+	t.Run("unlabeled uses the locale's date label", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		c.SetLanguage("en")
+		sig := portabledoc.SignatureItem{ID: "sig_1", DateLine: &portabledoc.SignatureDateLine{}}
+		if got := c.renderTypstSignatureItemContent(&sig, "150pt"); !strings.Contains(got, "Date: ") {
+			t.Errorf("expected the English date label:\n%s", got)
+		}
+	})
+
+	t.Run("no date line by default", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		sig := portabledoc.SignatureItem{ID: "sig_1", ImageData: &image, SignedAt: &signedAt}
+		if got := c.renderTypstSignatureItemContent(&sig, "150pt"); strings.Contains(got, "Fecha") {
+			t.Errorf("unexpected date line:\n%s", got)
+		}
+	})
+}
+
+func TestRenderSignatureItem_InjectableImage(t *testing.T) {
+	officer := "officer_signature"
+
+	t.Run("renders the facsimile above the line", func(t *testing.T) {
+		c := newTestConverter(map[string]any{officer: "https://example.com/officer.png"}, nil)
+		sig := c.parseSignatureItem(map[string]any{"id": "sig_1", "label": "CEO", "injectableId": officer})
+		got := c.renderTypstSignatureItemContent(&sig, "150pt")
+
+		file := c.remoteImages["https://example.com/officer.png"]
+		if file == "" {
+			t.Fatalf("expected the injectable image to be registered, got %v", c.remoteImages)
+		}
+		img := strings.Index(got, fmt.Sprintf(`#image("%s", height: 60.0pt`, file))
+		line := strings.Index(got, "#line(")
+		if img < 0 || img > line {
+			t.Errorf("expected facsimile image before the line:\n%s", got)
+		}
+		if sig.IsSigned() {
+			t.Error("a facsimile must not mark the signature as signed")
+		}
+	})
+
+	t.Run("signer image wins over the facsimile", func(t *testing.T) {
+		drawn := "https://example.com/drawn.png"
+		c := newTestConverter(map[string]any{officer: "https://example.com/officer.png"}, nil)
+		sig := portabledoc.SignatureItem{ID: "sig_1", ImageData: &drawn, InjectableID: &officer}
+		c.renderTypstSignatureItemContent(&sig, "150pt")
+		if _, ok := c.remoteImages[drawn]; !ok || len(c.remoteImages) != 1 {
+			t.Errorf("expected only the drawn image, got %v", c.remoteImages)
+		}
+	})
+
+	t.Run("unresolved injectable draws no image", func(t *testing.T) {
+		c := newTestConverter(nil, nil)
+		sig := portabledoc.SignatureItem{ID: "sig_1", InjectableID: &officer}
+		if got := c.renderTypstSignatureItemContent(&sig, "150pt"); strings.Contains(got, "#image(") {
+			t.Errorf("unexpected image:\n%s", got)
+		}
+	})
+}
+
+func TestParseSignatureItem_CaptionsAndDateLine(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	item := c.parseSignatureItem(map[string]any{
+		"id": "sig_1",
+		"captions": []any{
+			map[string]any{"text": "Jane Doe", "bold": true, "size": float64(9)},
+			"ignored",
+		},
+		"dateLine": map[string]any{"label": "Date"},
+		"signedAt": "2026-03-05",
+	})
+
+	if len(item.Captions) != 1 || item.Captions[0].Text != "Jane Doe" || !item.Captions[0].Bold ||
+		item.Captions[0].Size == nil || *item.Captions[0].Size != 9 {
+		t.Errorf("unexpected captions: %+v", item.Captions)
+	}
+	if item.DateLine == nil || item.DateLine.Label != "Date" {
+		t.Errorf("unexpected date line: %+v", item.DateLine)
+	}
+	if item.SignedAt == nil || *item.SignedAt != "2026-03-05" {
+		t.Errorf("unexpected signedAt: %v", item.SignedAt)
+	}
+}
+
 // --- Interactive Field Tests ---
 
 func newTestConverterWithFieldResponses(fieldResponses map[string]json.RawMessage) *typstConverter {
@@ -1803,6 +3606,85 @@ func TestTypstConverter_InteractiveFieldRadio_WithResponse(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_InteractiveFieldRadio_SingleSelection(t *testing.T) {
+	responses := map[string]json.RawMessage{
+		"field_2": json.RawMessage(`{"selectedOptionIds":["opt_unknown","opt_y","opt_x"]}`),
+	}
+	c := newTestConverterWithFieldResponses(responses)
+	node := interactiveFieldNode(map[string]any{
+		"id":        "field_2",
+		"fieldType": "radio",
+		"options": []any{
+			map[string]any{"id": "opt_x", "label": "Option X"},
+			map[string]any{"id": "opt_y", "label": "Option Y"},
+		},
+	})
+	got := c.convertNode(node)
+
+	if n := strings.Count(got, "◉"); n != 1 {
+		t.Errorf("expected exactly one selected radio, got %d in:\n%s", n, got)
+	}
+	if !strings.Contains(got, "◉ Option Y") || !strings.Contains(got, "○ Option X") {
+		t.Errorf("expected the first known id to win, got:\n%s", got)
+	}
+
+	checkbox := interactiveFieldNode(map[string]any{
+		"id":        "field_2",
+		"fieldType": "checkbox",
+		"options": []any{
+			map[string]any{"id": "opt_x", "label": "Option X"},
+			map[string]any{"id": "opt_y", "label": "Option Y"},
+		},
+	})
+	if got := c.convertNode(checkbox); strings.Count(got, "☑") != 2 {
+		t.Errorf("checkboxes keep every selection, got:\n%s", got)
+	}
+}
+
+func TestTypstConverter_InteractiveFieldDefaultSelection(t *testing.T) {
+	checkbox := interactiveFieldNode(map[string]any{
+		"id":        "field_1",
+		"fieldType": "checkbox",
+		"options": []any{
+			map[string]any{"id": "opt_a", "label": "A"},
+			map[string]any{"id": "opt_b", "label": "B"},
+		},
+		"defaultSelectedOptionIds": []any{"opt_a"},
+	})
+	radio := interactiveFieldNode(map[string]any{
+		"id":        "field_2",
+		"fieldType": "radio",
+		"options": []any{
+			map[string]any{"id": "opt_x", "label": "X"},
+			map[string]any{"id": "opt_y", "label": "Y"},
+		},
+		"defaultSelectedOptionIds": []any{"opt_y"},
+	})
+
+	t.Run("preview uses defaults", func(t *testing.T) {
+		c := newTestConverterWithFieldResponses(nil)
+		if got := c.convertNode(checkbox); !strings.Contains(got, "☑ A") || !strings.Contains(got, "☐ B") {
+			t.Errorf("expected default checkbox selection, got:\n%s", got)
+		}
+		if got := c.convertNode(radio); !strings.Contains(got, "○ X") || !strings.Contains(got, "◉ Y") {
+			t.Errorf("expected default radio selection, got:\n%s", got)
+		}
+	})
+
+	t.Run("response overrides defaults", func(t *testing.T) {
+		c := newTestConverterWithFieldResponses(map[string]json.RawMessage{
+			"field_1": json.RawMessage(`{"selectedOptionIds":[]}`),
+			"field_2": json.RawMessage(`{"selectedOptionIds":["opt_x"]}`),
+		})
+		if got := c.convertNode(checkbox); strings.Contains(got, "☑") {
+			t.Errorf("expected empty response to clear defaults, got:\n%s", got)
+		}
+		if got := c.convertNode(radio); !strings.Contains(got, "◉ X") || !strings.Contains(got, "○ Y") {
+			t.Errorf("expected response selection, got:\n%s", got)
+		}
+	})
+}
+
 func TestTypstConverter_InteractiveFieldText_NoResponse(t *testing.T) {
 	c := newTestConverterWithFieldResponses(nil)
 	node := interactiveFieldNode(map[string]any{
@@ -1859,6 +3741,57 @@ func TestTypstConverter_InteractiveFieldText_EmptyPlaceholder(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_InteractiveFieldText_Multiline(t *testing.T) {
+	const row = "block(width: 100%, height: 1.5em, stroke: (bottom: 0.5pt + luma(150)))"
+	field := func(attrs map[string]any) map[string]any {
+		attrs["id"] = "field_6"
+		attrs["fieldType"] = "text"
+		return attrs
+	}
+
+	t.Run("blank rows without response", func(t *testing.T) {
+		c := newTestConverterWithFieldResponses(nil)
+		got := c.convertNode(interactiveFieldNode(field(map[string]any{"multiline": true, "lines": float64(4), "placeholder": "Address"})))
+		if n := strings.Count(got, row); n != 4 {
+			t.Errorf("expected 4 ruled rows, got %d in:\n%s", n, got)
+		}
+		if !strings.Contains(got, "#text(fill: gray)[Address]") {
+			t.Errorf("expected placeholder above rows, got:\n%s", got)
+		}
+	})
+
+	t.Run("rows derived from maxLength", func(t *testing.T) {
+		c := newTestConverterWithFieldResponses(nil)
+		got := c.convertNode(interactiveFieldNode(field(map[string]any{"multiline": true, "maxLength": float64(161)})))
+		if n := strings.Count(got, row); n != 3 {
+			t.Errorf("expected 3 ruled rows, got %d in:\n%s", n, got)
+		}
+	})
+
+	t.Run("response keeps line breaks in a box", func(t *testing.T) {
+		c := newTestConverterWithFieldResponses(map[string]json.RawMessage{
+			"field_6": json.RawMessage(`{"text":"1 Main St\n- Suite 4"}`),
+		})
+		got := c.convertNode(interactiveFieldNode(field(map[string]any{"multiline": true})))
+		if !strings.Contains(got, "stroke: 0.5pt + luma(200))[1 Main St\\\n\\- Suite 4]") {
+			t.Errorf("expected boxed response with a line break, got:\n%s", got)
+		}
+		if strings.Contains(got, row) {
+			t.Errorf("expected no blank rows with a response, got:\n%s", got)
+		}
+	})
+}
+
+func TestTypstConverter_InteractiveFieldText_SingleLineUnchanged(t *testing.T) {
+	c := newTestConverterWithFieldResponses(map[string]json.RawMessage{
+		"field_7": json.RawMessage(`{"text":"John Doe"}`),
+	})
+	got := c.convertNode(interactiveFieldNode(map[string]any{"id": "field_7", "fieldType": "text", "lines": float64(3)}))
+	if got != "#block[\n  John Doe\n]\n" {
+		t.Errorf("expected plain single-line response, got %q", got)
+	}
+}
+
 func TestTypstConverter_InteractiveFieldNoLabel(t *testing.T) {
 	c := newTestConverterWithFieldResponses(nil)
 	node := interactiveFieldNode(map[string]any{
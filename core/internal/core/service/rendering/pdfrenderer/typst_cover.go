@@ -0,0 +1,91 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// coverPageCount is the number of pages the cover adds ahead of the body.
+const coverPageCount = 1
+
+// coverPageBlock renders the cover as its own unnumbered page with its own margins,
+// then restarts the page counter so the body is numbered from 1. The image, if any,
+// is placed behind the text and stretched over the whole page (full bleed).
+func (b *TypstBuilder) coverPageBlock(cover *portabledoc.CoverPage, pageConfig *portabledoc.PageConfig) string {
+	margins := pageConfig.Margins
+	if cover.Margins != nil {
+		margins = *cover.Margins
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#page(margin: (top: %.1fpt, bottom: %.1fpt, left: %.1fpt, right: %.1fpt), numbering: none)[\n",
+		margins.Top*pxToPt, margins.Bottom*pxToPt, margins.Left*pxToPt, margins.Right*pxToPt,
+	)
+
+	if path := b.coverImagePath(cover); path != "" {
+		fmt.Fprintf(&sb, "  #place(top + left, dx: -%.1fpt, dy: -%.1fpt, image(%q, width: %.1fpt, height: %.1fpt, fit: \"cover\"))\n",
+			margins.Left*pxToPt, margins.Top*pxToPt, path, pageConfig.Width*pxToPt, pageConfig.Height*pxToPt,
+		)
+	}
+
+	color := b.tokens.BaseTextColor
+	if textColor := hexColor(cover.TextColor); textColor != "" {
+		color = textColor
+	}
+	fmt.Fprintf(&sb, "  #set text(fill: rgb(\"%s\"))\n", escapeTypstString(color))
+
+	var lines []string
+	if title := b.resolveFieldValue(cover.Title); title != "" {
+		lines = append(lines, fmt.Sprintf("#text(size: %s, weight: %s)[%s]", b.tokens.HeadingSizes[0], b.tokens.HeadingWeight, escapeTypst(title)))
+	}
+	if subtitle := b.resolveFieldValue(cover.Subtitle); subtitle != "" {
+		lines = append(lines, fmt.Sprintf("#text(size: %s)[%s]", b.tokens.HeadingSizes[2], escapeTypst(subtitle)))
+	}
+	if date := b.resolveFieldValue(cover.Date); date != "" {
+		lines = append(lines, fmt.Sprintf("#text(size: %s)[%s]", b.tokens.BaseFontSize, escapeTypst(date)))
+	}
+	if len(lines) > 0 {
+		sb.WriteString("  #align(horizon)[\n")
+		for i, line := range lines {
+			if i > 0 {
+				sb.WriteString("    #v(0.8em)\n")
+			}
+			sb.WriteString("    " + line + "\n")
+		}
+		sb.WriteString("  ]\n")
+	}
+
+	sb.WriteString("]\n#counter(page).update(1)\n\n")
+	return sb.String()
+}
+
+// coverImagePath resolves the cover image and registers remote sources for download.
+// Returns "" when the cover has no image or it resolves empty.
+func (b *TypstBuilder) coverImagePath(cover *portabledoc.CoverPage) string {
+	if !cover.HasImage() {
+		return ""
+	}
+
+	attrs := map[string]any{}
+	if cover.ImageURL != nil {
+		attrs["src"] = *cover.ImageURL
+	}
+	if cover.ImageInjectableID != nil {
+		attrs["injectableId"] = *cover.ImageInjectableID
+	}
+	return b.imagePath(b.converter.ResolveImageSource(attrs))
+}
+
+// offsetFieldPages shifts signature field pages past pages rendered ahead of the body.
+func offsetFieldPages(fields []port.SignatureField, offset int) []port.SignatureField {
+	if offset == 0 {
+		return fields
+	}
+	for i := range fields {
+		fields[i].Page += offset
+	}
+	return fields
+}
@@ -0,0 +1,68 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+// crossReferenceFallback is shown for a reference without fallback text whose
+// target is missing or unnumbered.
+const crossReferenceFallback = "??"
+
+// nodeLabel returns the Typst label for a node ID. Characters Typst labels don't
+// allow are replaced with "-".
+func nodeLabel(id string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ':':
+			return r
+		default:
+			return '-'
+		}
+	}, id)
+	return "<node-" + label + ">"
+}
+
+// withNodeLabel attaches the node's label to markup when the node is a cross-reference target.
+func withNodeLabel(node portabledoc.Node, markup string) string {
+	if !node.IsCrossReferenceTarget() {
+		return markup
+	}
+	return markup + " " + nodeLabel(node.ID())
+}
+
+// withFigure wraps an image with a caption in a numbered figure so it can be referenced.
+func withFigure(node portabledoc.Node, markup string) string {
+	caption, _ := node.Attrs[portabledoc.AttrImageCaption].(string)
+	if markup == "" || strings.TrimSpace(caption) == "" {
+		return markup
+	}
+	return withNodeLabel(node, fmt.Sprintf("#figure([%s], caption: [%s])", markup, escapeTypst(caption)))
+}
+
+// crossReference renders a reference to a labeled heading or figure. Typst resolves the
+// number ("Section 3.2") at compile time; a missing, duplicated or unnumbered target
+// renders the fallback text instead of failing the compile, linked to the target when
+// there is exactly one.
+func (c *typstConverter) crossReference(node portabledoc.Node) string {
+	fallback, _ := node.Attrs[portabledoc.AttrCrossReferenceFallback].(string)
+	if strings.TrimSpace(fallback) == "" {
+		fallback = crossReferenceFallback
+	}
+	targetID := node.CrossReferenceTarget()
+	if targetID == "" {
+		return escapeTypst(fallback)
+	}
+
+	label := nodeLabel(targetID)
+	ref := label
+	if supplement, _ := node.Attrs[portabledoc.AttrCrossReferenceSupplement].(string); supplement != "" {
+		ref += ", supplement: [" + escapeTypst(supplement) + "]"
+	}
+	return fmt.Sprintf(
+		"#context { let found = query(%s); if found.len() != 1 [%s] else if found.first().numbering == none { link(found.first().location())[%s] } else { ref(%s) } }",
+		label, escapeTypst(fallback), escapeTypst(fallback), ref,
+	)
+}
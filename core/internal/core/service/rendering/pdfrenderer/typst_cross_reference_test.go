@@ -0,0 +1,121 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dslipak/pdf"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func labeledHeading(id, text string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:    portabledoc.NodeTypeHeading,
+		Attrs:   map[string]any{"level": float64(1), portabledoc.AttrNodeID: id},
+		Content: []portabledoc.Node{textNode(text)},
+	}
+}
+
+func crossReferenceNode(attrs map[string]any) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeCrossReference, Attrs: attrs}
+}
+
+func TestTypstConverter_HeadingWithIDGetsLabel(t *testing.T) {
+	c := newTestConverter(nil, nil)
+
+	got := c.convertNode(labeledHeading("sec/pricing 1", "Pricing"))
+	if !strings.Contains(got, "= Pricing <node-sec-pricing-1>") {
+		t.Errorf("expected the heading to carry its sanitized label, got %q", got)
+	}
+
+	if got := c.convertNode(paragraphNode(textNode("Body"))); strings.Contains(got, "<node-") {
+		t.Errorf("expected no label without a node ID, got %q", got)
+	}
+}
+
+func TestTypstConverter_CrossReference(t *testing.T) {
+	c := newTestConverter(nil, nil)
+
+	got := c.convertNode(crossReferenceNode(map[string]any{"targetId": "sec-pricing"}))
+	want := "#context { let found = query(<node-sec-pricing>); if found.len() != 1 [??] else if found.first().numbering == none { link(found.first().location())[??] } else { ref(<node-sec-pricing>) } }"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = c.convertNode(crossReferenceNode(map[string]any{"targetId": "sec-pricing", "supplement": "Clause", "text": "pricing #1"}))
+	if !strings.Contains(got, "ref(<node-sec-pricing>, supplement: [Clause])") || !strings.Contains(got, `[pricing \#1]`) {
+		t.Errorf("expected supplement and escaped fallback text, got %q", got)
+	}
+
+	if got := c.convertNode(crossReferenceNode(map[string]any{"text": "see above"})); got != "see above" {
+		t.Errorf("expected only the fallback without a target, got %q", got)
+	}
+}
+
+func TestTypstConverter_CaptionedImageIsLabeledFigure(t *testing.T) {
+	c := newTestConverter(nil, nil)
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeImage,
+		Attrs: map[string]any{
+			"src":                  "chart.png",
+			"caption":              "Quarterly revenue",
+			portabledoc.AttrNodeID: "fig-revenue",
+		},
+	}
+
+	got := c.convertNode(node)
+	if !strings.Contains(got, "#figure([") || !strings.Contains(got, "caption: [Quarterly revenue]) <node-fig-revenue>") {
+		t.Errorf("expected a labeled figure, got %q", got)
+	}
+
+	delete(node.Attrs, "caption")
+	if got := c.convertNode(node); strings.Contains(got, "#figure(") || strings.Contains(got, "<node-") {
+		t.Errorf("expected a plain image without a caption, got %q", got)
+	}
+}
+
+func TestRenderPreview_CrossReferenceResolvesHeadingNumber(t *testing.T) {
+	service, err := NewService(DefaultTypstOptions(), nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer service.Close()
+
+	doc := staticDocument()
+	doc.HeadingNumbering = &portabledoc.HeadingNumbering{Pattern: "1.1"}
+	doc.Content.Content = append(doc.Content.Content,
+		labeledHeading("sec-scope", "Scope"),
+		labeledHeading("sec-pricing", "Pricing"),
+		paragraphNode(textNode("As agreed in "), crossReferenceNode(map[string]any{"targetId": "sec-pricing"}), textNode(".")),
+		paragraphNode(textNode("Missing: "), crossReferenceNode(map[string]any{"targetId": "sec-gone", "text": "removed"})),
+	)
+
+	result, err := service.RenderPreview(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	if err != nil {
+		t.Fatalf("RenderPreview failed: %v", err)
+	}
+
+	reader, err := pdf.NewReader(bytes.NewReader(result.PDF), int64(len(result.PDF)))
+	if err != nil {
+		t.Fatalf("reading PDF: %v", err)
+	}
+	plain, err := reader.GetPlainText()
+	if err != nil {
+		t.Fatalf("extracting text: %v", err)
+	}
+	text, err := io.ReadAll(plain)
+	if err != nil {
+		t.Fatalf("extracting text: %v", err)
+	}
+	if !strings.Contains(string(text), "Section 2") {
+		t.Errorf("expected the reference to resolve to Section 2, got %q", text)
+	}
+	if !strings.Contains(string(text), "removed") {
+		t.Errorf("expected a missing target to render its fallback, got %q", text)
+	}
+}
@@ -1,7 +1,10 @@
 package pdfrenderer
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -12,47 +15,213 @@ import (
 
 // --- Typst escaping ---
 
-// escapeTypst escapes special Typst characters in content text.
+// typstMarkupEscaper escapes characters with markup meaning anywhere in a line:
+// emphasis, code, math, labels, references, content brackets and the non-breaking
+// space shorthand. Comment-starting slashes are handled by escapeCommentSlashes.
+var typstMarkupEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"#", "\\#",
+	"*", "\\*",
+	"_", "\\_",
+	"@", "\\@",
+	"$", "\\$",
+	"<", "\\<",
+	">", "\\>",
+	"[", "\\[",
+	"]", "\\]",
+	"`", "\\`",
+	"~", "\\~",
+)
+
+// escapeTypst escapes special Typst characters in content text, so the result can
+// sit inside any content block without ending it or starting new markup.
 func escapeTypst(s string) string {
-	replacer := strings.NewReplacer(
-		"\\", "\\\\",
-		"#", "\\#",
-		"*", "\\*",
-		"_", "\\_",
-		"@", "\\@",
-		"$", "\\$",
-		"<", "\\<",
-		">", "\\>",
-		"[", "\\[",
-		"]", "\\]",
-	)
-	return replacer.Replace(s)
-}
-
-// unescapeTypst reverses escapeTypst (used for code blocks where we want raw content).
-func unescapeTypst(s string) string {
-	replacer := strings.NewReplacer(
-		"\\\\", "\\",
-		"\\#", "#",
-		"\\*", "*",
-		"\\_", "_",
-		"\\@", "@",
-		"\\$", "$",
-		"\\<", "<",
-		"\\>", ">",
-		"\\[", "[",
-		"\\]", "]",
-	)
-	return replacer.Replace(s)
+	return escapeLineStarts(escapeCommentSlashes(typstMarkupEscaper.Replace(s)))
+}
+
+// escapeCommentSlashes escapes each slash that could open a // comment: one followed
+// by another slash, or one ending the text, which the next text node may continue.
+// A /* opener is already broken by the escaped asterisk.
+func escapeCommentSlashes(s string) string {
+	if !strings.Contains(s, "/") {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s) + 2)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '/' && (i+1 == len(s) || s[i+1] == '/') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// escapeLineStarts escapes the markers that only have meaning at the start of a line
+// and before whitespace: headings (=), list and enum items (-, +, 1.) and term lists (/).
+func escapeLineStarts(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for line := range strings.SplitAfterSeq(s, "\n") {
+		body := strings.TrimLeft(line, " \t")
+		sb.WriteString(line[:len(line)-len(body)])
+		if marker := lineMarkerLen(body); marker > 0 {
+			// Escaping the last marker character is enough to break the marker.
+			sb.WriteString(body[:marker-1])
+			sb.WriteByte('\\')
+			body = body[marker-1:]
+		}
+		sb.WriteString(body)
+	}
+	return sb.String()
+}
+
+// lineMarkerLen returns the length of the block marker that starts line, or 0.
+func lineMarkerLen(line string) int {
+	n := 0
+	switch {
+	case line == "":
+		return 0
+	case line[0] == '=':
+		n = len(line) - len(strings.TrimLeft(line, "="))
+	case line[0] == '-', line[0] == '+', line[0] == '/':
+		n = 1
+	case line[0] >= '0' && line[0] <= '9':
+		n = len(line) - len(strings.TrimLeft(line, "0123456789"))
+		if n == len(line) || line[n] != '.' {
+			return 0
+		}
+		n++
+	default:
+		return 0
+	}
+	if n < len(line) && !strings.ContainsRune(" \t\r\n", rune(line[n])) {
+		return 0
+	}
+	return n
 }
 
+// typstStringEscaper escapes a string for a double-quoted Typst string literal.
+var typstStringEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"\"", "\\\"",
+	"\n", "\\n",
+	"\r", "\\r",
+	"\t", "\\t",
+)
+
 // escapeTypstString escapes a string for use inside Typst string literals (double-quoted).
 func escapeTypstString(s string) string {
-	return strings.ReplaceAll(strings.ReplaceAll(s, "\\", "\\\\"), "\"", "\\\"")
+	return typstStringEscaper.Replace(s)
+}
+
+// typstString returns s as a quoted single-line Typst string literal.
+func typstString(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return "\"" + escapeTypstString(s) + "\""
+}
+
+// rawInline returns s as an inline raw (code) element. The text goes through a string
+// literal, so backticks and markup characters never end the span early; the trailing
+// semicolon ends the expression so following text cannot extend the call.
+func rawInline(s string) string {
+	return "#raw(\"" + escapeTypstString(s) + "\");"
+}
+
+// rawBlock returns s as a fenced raw block. The fence is one backtick longer than
+// the longest backtick run in s, so code containing backticks cannot close it.
+func rawBlock(s, lang string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + rawLanguage(lang) + "\n" + s + "\n" + fence + "\n"
+}
+
+// rawLanguage keeps a code block language tag only when it is a plain identifier
+// (letters, digits, +, -, #, .), since the tag runs up to the first newline.
+func rawLanguage(lang string) string {
+	for _, r := range lang {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("+-#.", r)) {
+			return ""
+		}
+	}
+	return lang
 }
 
 // --- Image utilities ---
 
+// injectedImageSource turns an image injectable value into an image source. Providers
+// may return a URL, a data URI, raw image bytes or bare base64; the last two become
+// data URIs with the format sniffed from the decoded bytes.
+func injectedImageSource(value any) string {
+	switch v := value.(type) {
+	case []byte:
+		if mime := sniffImageType(v); mime != "" {
+			return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(v)
+		}
+		return ""
+	case string:
+		if dataURL, ok := base64ImageDataURL(v); ok {
+			return dataURL
+		}
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// base64ImageDataURL converts bare base64 (standard or URL alphabet, padded or not,
+// possibly wrapped across lines) to a data URI when it decodes to a known image format.
+// Only a prefix is decoded to sniff the format; the image cache decodes the rest.
+func base64ImageDataURL(s string) (string, bool) {
+	const minLen = 16 // shorter strings are file names or keys, not images
+	payload := strings.Join(strings.Fields(s), "")
+	if len(payload) < minLen || strings.Contains(payload, ":") {
+		return "", false
+	}
+	payload = strings.TrimRight(payload, "=")
+	for _, r := range payload {
+		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("+/-_", r)) {
+			return "", false
+		}
+	}
+	payload = strings.NewReplacer("-", "+", "_", "/").Replace(payload)
+
+	prefix := payload[:min(len(payload), 684)&^3] // 512 decoded bytes, on a 4-char boundary
+	head, err := base64.RawStdEncoding.DecodeString(prefix)
+	if err != nil {
+		return "", false
+	}
+	mime := sniffImageType(head)
+	if mime == "" {
+		return "", false
+	}
+	if pad := len(payload) % 4; pad != 0 {
+		payload += strings.Repeat("=", 4-pad)
+	}
+	return "data:" + mime + ";base64," + payload, true
+}
+
+// sniffImageType returns the MIME type of an image Typst can render, or "".
+func sniffImageType(data []byte) string {
+	switch mime := http.DetectContentType(data); mime {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+		return mime
+	}
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("<svg")) ||
+		(bytes.HasPrefix(trimmed, []byte("<?xml")) && bytes.Contains(trimmed, []byte("<svg"))) {
+		return "image/svg+xml"
+	}
+	return ""
+}
+
 // detectExtFromURL detects the image extension from a URL or data URL.
 func detectExtFromURL(url string) string {
 	if strings.HasPrefix(url, "data:image/") {
@@ -149,14 +318,6 @@ func clamp(v, min, max int) int {
 	return v
 }
 
-// formatBool returns a localized string for a boolean value.
-func formatBool(v bool) string {
-	if v {
-		return "Sí"
-	}
-	return "No"
-}
-
 // toFloat64 converts a value to float64, returning 0 on failure.
 func toFloat64(v any) float64 {
 	switch val := v.(type) {
@@ -227,6 +388,22 @@ func getIntPtrAttr(attrs map[string]any, key string) *int {
 	return nil
 }
 
+func getStringsAttr(attrs map[string]any, key string) []string {
+	switch v := attrs[key].(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
 // --- Signature layout positions ---
 
 // layoutPositions maps layout types to X positions (as percentage of page width).
@@ -0,0 +1,241 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// rawFixtures are code snippets that break naive backtick delimiting.
+var rawFixtures = []string{
+	"",
+	"`",
+	"``",
+	"```",
+	"a ``` b",
+	"`x`",
+	"func() { return `raw` }",
+	"{{ .Values }} {}}{",
+	`"quoted" \ backslash \" mixed`,
+	"#let x = [content]; $math$ <label> @ref",
+	");#panic(\"x\")",
+	"line one\nline two\n```\n",
+}
+
+func FuzzRawInline(f *testing.F) {
+	for _, s := range rawFixtures {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got := rawInline(s)
+		body, ok := strings.CutPrefix(got, `#raw("`)
+		if !ok {
+			t.Fatalf("unexpected prefix: %q", got)
+		}
+		body, ok = strings.CutSuffix(body, `");`)
+		if !ok {
+			t.Fatalf("unexpected suffix: %q", got)
+		}
+		if decoded, closed := decodeTypstString(body); closed || decoded != s {
+			t.Fatalf("string literal does not round-trip: %q -> %q (closed early: %v)", s, decoded, closed)
+		}
+	})
+}
+
+func FuzzRawBlock(f *testing.F) {
+	for _, s := range rawFixtures {
+		f.Add(s, "go")
+	}
+	f.Add("code", "go\n#panic()")
+	f.Fuzz(func(t *testing.T, s, lang string) {
+		got := rawBlock(s, lang)
+		firstLine, rest, _ := strings.Cut(got, "\n")
+		fence := firstLine[:len(firstLine)-len(strings.TrimLeft(firstLine, "`"))]
+		if len(fence) < 3 {
+			t.Fatalf("fence too short: %q", got)
+		}
+		if strings.ContainsAny(firstLine[len(fence):], "`\n") {
+			t.Fatalf("language tag escapes the fence line: %q", firstLine)
+		}
+		if want := s + "\n" + fence + "\n"; rest != want {
+			t.Fatalf("block body changed: got %q, want %q", rest, want)
+		}
+		if strings.Contains(s, fence) {
+			t.Fatalf("content contains the closing fence %q: %q", fence, s)
+		}
+	})
+}
+
+// decodeTypstString decodes the body of a double-quoted Typst string literal and
+// reports whether an unescaped quote would have closed it early.
+func decodeTypstString(body string) (string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '"':
+			return sb.String(), true
+		case '\\':
+			if i+1 < len(body) {
+				i++
+				if r, ok := map[byte]byte{'n': '\n', 'r': '\r', 't': '\t'}[body[i]]; ok {
+					sb.WriteByte(r)
+					continue
+				}
+			}
+		}
+		sb.WriteByte(body[i])
+	}
+	return sb.String(), false
+}
+
+// escapeFixtures try to close or open markup from user text.
+var escapeFixtures = []string{
+	"plain text",
+	"]#panic(\"pwned\")[",
+	"] + [injected",
+	"#text(fill: red)[x]",
+	`\]`,
+	`\\]`,
+	`ends with backslash \`,
+	"a // comment eats the bracket ]",
+	"a /* block comment",
+	"`raw ] span`",
+	"$x^2$ <label> @ref *bold* _em_ ~nbsp",
+	"= Heading\n- item\n+ enum\n/ Term: desc\n12. numbered\n  - indented",
+	"== deeper\n1.5 is a number\n-5 degrees",
+	"\")#panic(\"x",
+	"line\r\nbreak\ttab",
+	"ünïcödé ✓ [ok]",
+}
+
+func FuzzEscapeTypst(f *testing.F) {
+	for _, s := range escapeFixtures {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		escaped := escapeTypst(s)
+		for _, wrap := range []struct{ open, close string }{
+			{"#text[", "]"},
+			{"#strong[", "]"},
+			{"  table.cell(inset: 5pt)[", "],\n"},
+		} {
+			doc := wrap.open + escaped + wrap.close
+			body := strings.TrimSuffix(strings.TrimPrefix(doc, wrap.open), wrap.close)
+			decoded, problem := scanTypstMarkup(body)
+			if problem != "" {
+				t.Fatalf("%q escaped to %q: %s", s, escaped, problem)
+			}
+			if decoded != s {
+				t.Fatalf("%q escaped to %q, which reads back as %q", s, escaped, decoded)
+			}
+		}
+	})
+}
+
+func FuzzEscapeTypstString(f *testing.F) {
+	for _, s := range escapeFixtures {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		escaped := escapeTypstString(s)
+		if strings.ContainsAny(escaped, "\n\r") {
+			t.Fatalf("%q escaped to a multi-line literal %q", s, escaped)
+		}
+		decoded, closed := decodeTypstString(escaped)
+		if closed {
+			t.Fatalf("%q escaped to %q, which closes #link(\"...\") early", s, escaped)
+		}
+		if decoded != s {
+			t.Fatalf("%q escaped to %q, which reads back as %q", s, escaped, decoded)
+		}
+	})
+}
+
+// scanTypstMarkup reads content-block text the way Typst markup does for the constructs
+// escapeTypst guards against. It returns the text with escapes resolved, or a description
+// of the first unescaped construct that would end the block or start new markup.
+func scanTypstMarkup(body string) (string, string) {
+	var sb strings.Builder
+	lineStart := true
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if lineStart && ch != ' ' && ch != '\t' {
+			if n := lineMarkerLen(body[i:]); n > 0 {
+				return "", fmt.Sprintf("line starts with marker %q at %d", body[i:i+n], i)
+			}
+			lineStart = false
+		}
+		switch {
+		case ch == '\\':
+			if i+1 == len(body) || strings.ContainsRune(" \t\r\n", rune(body[i+1])) {
+				return "", fmt.Sprintf("dangling backslash at %d", i)
+			}
+			i++
+			sb.WriteByte(body[i])
+			continue
+		case strings.IndexByte("[]#*_@$<>`~", ch) >= 0:
+			return "", fmt.Sprintf("unescaped %q at %d", ch, i)
+		case ch == '/' && (i+1 == len(body) || body[i+1] == '/' || body[i+1] == '*'):
+			return "", fmt.Sprintf("unescaped slash at %d could start a comment", i)
+		case ch == '\n':
+			lineStart = true
+		}
+		sb.WriteByte(ch)
+	}
+	return sb.String(), ""
+}
+
+// testPNG encodes a 2x2 PNG.
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("encoding png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBase64ImageDataURL(t *testing.T) {
+	pngData := testPNG(t)
+	std := base64.StdEncoding.EncodeToString(pngData)
+	jpegHead := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, make([]byte, 40)...)
+
+	tests := []struct {
+		name     string
+		in       string
+		wantMIME string
+	}{
+		{"padded png", std, "image/png"},
+		{"unpadded png", strings.TrimRight(std, "="), "image/png"},
+		{"url alphabet", base64.RawURLEncoding.EncodeToString(pngData), "image/png"},
+		{"wrapped lines", std[:20] + "\n" + std[20:40] + "\r\n " + std[40:], "image/png"},
+		{"jpeg", base64.StdEncoding.EncodeToString(jpegHead), "image/jpeg"},
+		{"svg", base64.StdEncoding.EncodeToString([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)), "image/svg+xml"},
+		{"base64 text", base64.StdEncoding.EncodeToString([]byte("just some plain text here")), ""},
+		{"short", "aGVsbG8=", ""},
+		{"relative path", "images/logos/companyLogo", ""},
+		{"url", "https://example.com/logo.png", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := base64ImageDataURL(tt.in)
+			if tt.wantMIME == "" {
+				if ok {
+					t.Fatalf("expected no data URI, got %.60q", got)
+				}
+				return
+			}
+			payload, found := strings.CutPrefix(got, "data:"+tt.wantMIME+";base64,")
+			if !ok || !found {
+				t.Fatalf("expected a %s data URI, got %.60q", tt.wantMIME, got)
+			}
+			if _, err := base64.StdEncoding.DecodeString(payload); err != nil {
+				t.Fatalf("payload is not standard base64: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package pdfrenderer
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// latexMathSymbols maps LaTeX commands to their Typst math equivalents.
+// Greek letters and most operator names share the LaTeX name and are listed as-is.
+var latexMathSymbols = map[string]string{
+	"alpha": "alpha", "beta": "beta", "gamma": "gamma", "delta": "delta", "epsilon": "epsilon.alt",
+	"varepsilon": "epsilon", "zeta": "zeta", "eta": "eta", "theta": "theta", "vartheta": "theta.alt",
+	"iota": "iota", "kappa": "kappa", "lambda": "lambda", "mu": "mu", "nu": "nu", "xi": "xi",
+	"pi": "pi", "rho": "rho", "sigma": "sigma", "tau": "tau", "upsilon": "upsilon", "phi": "phi.alt",
+	"varphi": "phi", "chi": "chi", "psi": "psi", "omega": "omega",
+	"Gamma": "Gamma", "Delta": "Delta", "Theta": "Theta", "Lambda": "Lambda", "Xi": "Xi", "Pi": "Pi",
+	"Sigma": "Sigma", "Upsilon": "Upsilon", "Phi": "Phi", "Psi": "Psi", "Omega": "Omega",
+
+	"sum": "sum", "prod": "product", "int": "integral", "iint": "integral.double", "oint": "integral.cont",
+	"lim": "lim", "sin": "sin", "cos": "cos", "tan": "tan", "log": "log", "ln": "ln", "exp": "exp",
+	"max": "max", "min": "min", "det": "det",
+
+	"infty": "infinity", "cdot": "dot.op", "times": "times", "div": "div", "pm": "plus.minus",
+	"mp": "minus.plus", "leq": "<=", "le": "<=", "geq": ">=", "ge": ">=", "neq": "!=", "ne": "!=",
+	"approx": "approx", "equiv": "equiv", "sim": "tilde.op", "propto": "prop",
+	"to": "->", "rightarrow": "->", "leftarrow": "<-", "Rightarrow": "=>", "Leftarrow": "arrow.l.double",
+	"leftrightarrow": "<->", "Leftrightarrow": "<=>", "mapsto": "|->",
+	"ldots": "dots.h", "dots": "dots.h", "cdots": "dots.c",
+	"partial": "diff", "nabla": "nabla", "forall": "forall", "exists": "exists",
+	"in": "in", "notin": "in.not", "subset": "subset", "subseteq": "subset.eq", "supset": "supset",
+	"cup": "union", "cap": "sect", "emptyset": "emptyset", "neg": "not", "land": "and", "lor": "or",
+	"quad": "quad", "qquad": "wide", "prime": "prime", "circ": "compose", "degree": "degree",
+}
+
+// latexMathStyles maps LaTeX font commands to the Typst function taking their argument.
+var latexMathStyles = map[string]string{
+	"mathbf": "bold", "boldsymbol": "bold", "mathit": "italic", "mathrm": "upright",
+	"mathbb": "bb", "mathcal": "cal", "mathfrak": "frak", "mathsf": "sans",
+	"hat": "hat", "bar": "overline", "overline": "overline", "underline": "underline",
+	"vec": "arrow", "tilde": "tilde", "dot": "dot",
+}
+
+// latexToTypstMath translates the common subset of LaTeX math into Typst math:
+// fractions, roots, sub/superscripts, Greek letters, operators, font commands and
+// \text. Unknown commands render as their name in upright text.
+func latexToTypstMath(src string) string {
+	p := &latexMathParser{src: []rune(src)}
+	return strings.Join(strings.Fields(p.parse(false)), " ")
+}
+
+type latexMathParser struct {
+	src []rune
+	pos int
+}
+
+// parse translates until the end of input, or the closing brace of the current group.
+func (p *latexMathParser) parse(inGroup bool) string {
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '}':
+			p.pos++
+			if inGroup {
+				return sb.String()
+			}
+		case r == '{':
+			p.pos++
+			sb.WriteString(" " + p.parse(true) + " ")
+		case r == '^' || r == '_':
+			// Attach to the preceding base: Typst does not allow a space before ^ and _.
+			base := strings.TrimRight(sb.String(), " ")
+			sb.Reset()
+			sb.WriteString(base)
+			p.pos++
+			sb.WriteRune(r)
+			sb.WriteString("(" + p.argument() + ")")
+		case r == '\\':
+			sb.WriteString(" " + p.command() + " ")
+		case unicode.IsLetter(r):
+			// LaTeX reads adjacent letters as a product; Typst would read a variable name.
+			if out := sb.String(); out != "" && unicode.IsLetter(rune(out[len(out)-1])) {
+				sb.WriteByte(' ')
+			}
+			p.pos++
+			sb.WriteRune(r)
+		case r == '#' || r == '$' || r == '"':
+			p.pos++
+			sb.WriteString(`\` + string(r))
+		case r == '&':
+			p.pos++
+			sb.WriteString(" & ")
+		case inGroup && (r == ',' || r == ';'):
+			// Inside a group the text may land in a function argument, where these separate arguments
+			p.pos++
+			sb.WriteString(`\` + string(r))
+		default:
+			p.pos++
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// argument translates the next command argument: a braced group or a single token.
+func (p *latexMathParser) argument() string {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return ""
+	}
+	switch r := p.src[p.pos]; {
+	case r == '{':
+		p.pos++
+		return strings.TrimSpace(p.parse(true))
+	case r == '\\':
+		return strings.TrimSpace(p.command())
+	default:
+		p.pos++
+		if r == '#' || r == '$' || r == '"' || r == ',' || r == ';' {
+			return `\` + string(r)
+		}
+		return string(r)
+	}
+}
+
+// rawArgument returns the next braced argument untranslated, for \text.
+func (p *latexMathParser) rawArgument() string {
+	p.skipSpaces()
+	if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+		return ""
+	}
+	start, depth := p.pos+1, 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return string(p.src[start : p.pos-1])
+			}
+		}
+	}
+	return string(p.src[start:])
+}
+
+// command translates a backslash command starting at the current position.
+func (p *latexMathParser) command() string {
+	p.pos++ // backslash
+	if p.pos >= len(p.src) {
+		return ""
+	}
+	if !unicode.IsLetter(p.src[p.pos]) {
+		r := p.src[p.pos]
+		p.pos++
+		switch r {
+		case ',', ';', ':', '!', ' ':
+			return " "
+		case '\\':
+			return `\`
+		case '{', '}', '#', '$', '"', '%', '_', '&':
+			return `\` + string(r)
+		default:
+			return string(r)
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && unicode.IsLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	name := string(p.src[start:p.pos])
+
+	switch name {
+	case "frac", "dfrac", "tfrac":
+		num := p.argument()
+		return "frac(" + num + ", " + p.argument() + ")"
+	case "sqrt":
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == '[' {
+			if end := slices.Index(p.src[p.pos:], ']'); end > 0 {
+				index := latexToTypstMath(string(p.src[p.pos+1 : p.pos+end]))
+				p.pos += end + 1
+				return "root(" + index + ", " + p.argument() + ")"
+			}
+		}
+		return "sqrt(" + p.argument() + ")"
+	case "text", "textrm", "textit", "textbf", "mbox":
+		return `"` + escapeTypstString(p.rawArgument()) + `"`
+	case "operatorname":
+		return "op(" + typstString(p.rawArgument()) + ")"
+	case "left", "right":
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == '.' {
+			p.pos++
+		}
+		return ""
+	case "displaystyle", "textstyle", "limits", "nolimits":
+		return ""
+	}
+	if fn, ok := latexMathStyles[name]; ok {
+		return fn + "(" + p.argument() + ")"
+	}
+	if sym, ok := latexMathSymbols[name]; ok {
+		return sym
+	}
+	return "upright(" + typstString(name) + ")"
+}
+
+func (p *latexMathParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// escapeTypstMath neutralizes the characters that would end math mode or start
+// code in Typst math source taken as-is. A $ or # counts as already escaped only
+// after an odd run of backslashes; after an even run the backslashes escape each other.
+func escapeTypstMath(src string) string {
+	var sb strings.Builder
+	backslashes := 0
+	for _, r := range src {
+		if (r == '$' || r == '#') && backslashes%2 == 0 {
+			sb.WriteByte('\\')
+		}
+		if r == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		sb.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
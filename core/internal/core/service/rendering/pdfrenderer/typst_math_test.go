@@ -0,0 +1,45 @@
+package pdfrenderer
+
+import "testing"
+
+func TestLatexToTypstMath(t *testing.T) {
+	tests := []struct{ latex, want string }{
+		{`E = mc^2`, `E = m c^(2)`},
+		{`\frac{a+b}{2}`, `frac(a+b, 2)`},
+		{`\sqrt{x^2 + y^2}`, `sqrt(x^(2) + y^(2))`},
+		{`\sqrt[3]{8}`, `root(3, 8)`},
+		{`\sum_{i=1}^{n} i`, `sum_(i=1)^(n) i`},
+		{`\alpha \leq \beta \cdot \pi`, `alpha <= beta dot.op pi`},
+		{`\mathbf{v} \to \infty`, `bold(v) -> infinity`},
+		{`x \text{ if } x > 0`, `x " if " x > 0`},
+		{`\left( \frac{1}{n} \right)`, `( frac(1, n) )`},
+		{`\foo`, `upright("foo")`},
+		{`a # b $`, `a \# b \$`},
+		{`\frac{a,b}{c}`, `frac(a\,b, c)`},
+		{`\sqrt{x;y}`, `sqrt(x\;y)`},
+		{`f(a, b)`, `f(a, b)`},
+		{`\frac,b`, `frac(\,, b)`},
+	}
+
+	for _, tt := range tests {
+		if got := latexToTypstMath(tt.latex); got != tt.want {
+			t.Errorf("latexToTypstMath(%q) = %q, want %q", tt.latex, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeTypstMath(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"x^2 $ #sys \\$", `x^2 \$ \#sys \$`},
+		// An even run of backslashes escapes itself, so the $ and # that follow still need escaping
+		{`a \\$ #read("/etc/passwd") $`, `a \\\$ \#read("/etc/passwd") \$`},
+		{`a \\# b`, `a \\\# b`},
+		{`a \\\$ b`, `a \\\$ b`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeTypstMath(tt.src); got != tt.want {
+			t.Errorf("escapeTypstMath(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,8 +30,28 @@ type TypstOptions struct {
 	// MaxConcurrent limits simultaneous typst processes (0 = unlimited).
 	MaxConcurrent int
 
+	// MaxQueue limits renders waiting for a slot; beyond it they are rejected (0 = unbounded).
+	MaxQueue int
+
 	// AcquireTimeout is the max wait time to acquire a render slot.
 	AcquireTimeout time.Duration
+
+	// Limits bounds document size and nesting, checked before conversion.
+	Limits DocumentLimits
+
+	// DebugAnchors renders signature anchors visibly to check field placement.
+	DebugAnchors bool
+
+	// MaxImageDimension downscales fetched raster images whose width or height exceeds it (0 = unlimited).
+	// It applies when no image cache is configured; the cache takes ImageCacheOptions.MaxDimension.
+	MaxImageDimension int
+
+	// Themes are named design token sets renders can select instead of the default tokens.
+	Themes Themes
+
+	// WarmupRenders is how many compiles Service.Warmup runs in parallel at startup,
+	// capped at MaxConcurrent (0 = no warmup).
+	WarmupRenders int
 }
 
 // DefaultTypstOptions returns sensible default options.
@@ -58,11 +81,23 @@ func NewTypstRenderer(opts TypstOptions) (*TypstRenderer, error) {
 
 // GeneratePDF compiles Typst source to PDF bytes.
 // rootDir is optional; if set, it is passed as --root to typst for resolving local file paths.
-func (r *TypstRenderer) GeneratePDF(ctx context.Context, typstSource string, rootDir string) ([]byte, error) {
+// pdfStandard is optional; if set (e.g. "a-2b"), it is passed as --pdf-standard.
+// extraFontDirs are searched for fonts after the configured FontDirs.
+func (r *TypstRenderer) GeneratePDF(ctx context.Context, typstSource, rootDir, pdfStandard string, extraFontDirs ...string) ([]byte, error) {
+	return r.compile(ctx, typstSource, r.buildArgs(rootDir, pdfStandard, extraFontDirs...))
+}
+
+// GeneratePNG compiles Typst source and rasterizes the 1-indexed page at ppi pixels per inch.
+// Returns no bytes when the document has no such page.
+func (r *TypstRenderer) GeneratePNG(ctx context.Context, typstSource, rootDir string, page, ppi int, extraFontDirs ...string) ([]byte, error) {
+	return r.compile(ctx, typstSource, r.buildImageArgs(rootDir, page, ppi, extraFontDirs...))
+}
+
+// compile runs typst with args, feeding the source on stdin and returning stdout.
+func (r *TypstRenderer) compile(ctx context.Context, typstSource string, args []string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
 	defer cancel()
 
-	args := r.buildArgs(rootDir)
 	cmd := exec.CommandContext(ctx, r.opts.BinPath, args...) //nolint:gosec // BinPath is validated at init
 	cmd.Stdin = bytes.NewReader([]byte(typstSource))
 
@@ -70,18 +105,44 @@ func (r *TypstRenderer) GeneratePDF(ctx context.Context, typstSource string, roo
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	start := time.Now()
 	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("typst compile aborted: %w", ctxErr)
+		}
 		return nil, fmt.Errorf("typst compile failed: %w\nstderr: %s", err, stderr.String())
 	}
 
+	slog.DebugContext(ctx, "typst compiled",
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("bytes", stdout.Len()),
+	)
+	if warnings := strings.TrimSpace(stderr.String()); warnings != "" {
+		slog.WarnContext(ctx, "typst compile warnings", slog.String("stderr", warnings))
+	}
 	return stdout.Bytes(), nil
 }
 
 // buildArgs constructs the CLI arguments for typst compile.
-func (r *TypstRenderer) buildArgs(rootDir string) []string {
-	args := make([]string, 0, 3+2*len(r.opts.FontDirs)+4)
+func (r *TypstRenderer) buildArgs(rootDir, pdfStandard string, extraFontDirs ...string) []string {
+	args := make([]string, 0, 3+2*(len(r.opts.FontDirs)+len(extraFontDirs))+6)
 	args = append(args, "compile", "--format", "pdf")
 
+	if pdfStandard != "" {
+		args = append(args, "--pdf-standard", pdfStandard)
+	}
+	return r.appendSourceArgs(args, rootDir, extraFontDirs)
+}
+
+// buildImageArgs constructs the CLI arguments for rasterizing a single page to PNG.
+func (r *TypstRenderer) buildImageArgs(rootDir string, page, ppi int, extraFontDirs ...string) []string {
+	args := make([]string, 0, 7+2*(len(r.opts.FontDirs)+len(extraFontDirs))+4)
+	args = append(args, "compile", "--format", "png", "--pages", strconv.Itoa(page), "--ppi", strconv.Itoa(ppi))
+	return r.appendSourceArgs(args, rootDir, extraFontDirs)
+}
+
+// appendSourceArgs adds the root, font paths and stdin/stdout arguments shared by every format.
+func (r *TypstRenderer) appendSourceArgs(args []string, rootDir string, extraFontDirs []string) []string {
 	if rootDir != "" {
 		args = append(args, "--root", rootDir)
 	}
@@ -89,6 +150,9 @@ func (r *TypstRenderer) buildArgs(rootDir string) []string {
 	for _, dir := range r.opts.FontDirs {
 		args = append(args, "--font-path", dir)
 	}
+	for _, dir := range extraFontDirs {
+		args = append(args, "--font-path", dir)
+	}
 
 	// Read from stdin, write to stdout
 	args = append(args, "-", "-")
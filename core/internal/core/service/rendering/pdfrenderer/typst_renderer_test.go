@@ -1,6 +1,8 @@
 package pdfrenderer
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -13,7 +15,7 @@ func TestTypstRenderer_BuildArgsIncludesConfiguredFontPaths(t *testing.T) {
 		},
 	}
 
-	args := renderer.buildArgs("/tmp/root")
+	args := renderer.buildArgs("/tmp/root", "")
 	got := strings.Join(args, " ")
 
 	if !strings.Contains(got, "--root /tmp/root") {
@@ -27,4 +29,54 @@ func TestTypstRenderer_BuildArgsIncludesConfiguredFontPaths(t *testing.T) {
 	if !strings.Contains(got, "--font-path /tmp/fonts-b") {
 		t.Fatalf("expected second font path in build args, got %q", got)
 	}
+
+	if strings.Contains(got, "--pdf-standard") {
+		t.Fatalf("expected no pdf standard by default, got %q", got)
+	}
+}
+
+func TestTypstRenderer_BuildArgsAppendsExtraFontPaths(t *testing.T) {
+	renderer := &TypstRenderer{opts: TypstOptions{BinPath: "typst", FontDirs: []string{"/tmp/fonts"}}}
+
+	got := strings.Join(renderer.buildArgs("", "", "/tmp/workspace-fonts"), " ")
+
+	if got != "compile --format pdf --font-path /tmp/fonts --font-path /tmp/workspace-fonts - -" {
+		t.Fatalf("unexpected build args %q", got)
+	}
+}
+
+func TestTypstRenderer_BuildArgsIncludesPDFStandard(t *testing.T) {
+	renderer := &TypstRenderer{opts: TypstOptions{BinPath: "typst"}}
+
+	got := strings.Join(renderer.buildArgs("", pdfStandardA2b), " ")
+
+	if got != "compile --format pdf --pdf-standard a-2b - -" {
+		t.Fatalf("unexpected build args %q", got)
+	}
+}
+
+func TestTypstRenderer_BuildImageArgs(t *testing.T) {
+	renderer := &TypstRenderer{opts: TypstOptions{BinPath: "typst", FontDirs: []string{"/tmp/fonts"}}}
+
+	got := strings.Join(renderer.buildImageArgs("/tmp/root", 2, 150), " ")
+
+	if got != "compile --format png --pages 2 --ppi 150 --root /tmp/root --font-path /tmp/fonts - -" {
+		t.Fatalf("unexpected build args %q", got)
+	}
+}
+
+func TestTypstRenderer_CompileReturnsContextError(t *testing.T) {
+	renderer, err := NewTypstRenderer(DefaultTypstOptions())
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer renderer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = renderer.GeneratePDF(ctx, "Hello", "", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
 }
@@ -152,7 +152,7 @@ func (c *typstConverter) collectListStyleParts(styles *entity.ListStyles) []stri
 		parts = append(parts, "weight: \"bold\"")
 	}
 	if styles.TextColor != nil {
-		parts = append(parts, fmt.Sprintf("fill: rgb(\"%s\")", *styles.TextColor))
+		parts = append(parts, fmt.Sprintf("fill: rgb(\"%s\")", escapeTypstString(*styles.TextColor)))
 	}
 	if styles.FontFamily != nil {
 		parts = append(parts, fmt.Sprintf("font: %s", fontWithFallbacks(*styles.FontFamily)))
@@ -179,11 +179,11 @@ func (c *typstConverter) buildTableStyleRules(headerStyles *entity.TableStyles)
 
 	if headerStyles.FontWeight != nil {
 		//nolint:staticcheck
-		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: 0): set text(weight: \"%s\")\n", *headerStyles.FontWeight))
+		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: 0): set text(weight: \"%s\")\n", escapeTypstString(*headerStyles.FontWeight)))
 	}
 	if headerStyles.TextColor != nil {
 		//nolint:staticcheck
-		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: 0): set text(fill: rgb(\"%s\"))\n", *headerStyles.TextColor))
+		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: 0): set text(fill: rgb(\"%s\"))\n", escapeTypstString(*headerStyles.TextColor)))
 	}
 	if headerStyles.FontSize != nil {
 		//nolint:staticcheck
@@ -205,10 +205,10 @@ func (c *typstConverter) buildTableBodyStyleRules(bodyStyles *entity.TableStyles
 	var sb strings.Builder
 
 	if bodyStyles.FontWeight != nil {
-		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: range(1, none)): set text(weight: \"%s\")\n", *bodyStyles.FontWeight))
+		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: range(1, none)): set text(weight: \"%s\")\n", escapeTypstString(*bodyStyles.FontWeight)))
 	}
 	if bodyStyles.TextColor != nil {
-		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: range(1, none)): set text(fill: rgb(\"%s\"))\n", *bodyStyles.TextColor))
+		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: range(1, none)): set text(fill: rgb(\"%s\"))\n", escapeTypstString(*bodyStyles.TextColor)))
 	}
 	if bodyStyles.FontSize != nil {
 		sb.WriteString(fmt.Sprintf("#show table.cell.where(y: range(1, none)): set text(size: %dpt)\n", *bodyStyles.FontSize))
@@ -0,0 +1,50 @@
+package pdfrenderer
+
+import "fmt"
+
+// Table border styles selected by a table node's borderStyle attr.
+const (
+	tableBorderAll        = "all"        // every cell edge (the default)
+	tableBorderHorizontal = "horizontal" // row rules only
+	tableBorderOuter      = "outer"      // a frame around the table
+	tableBorderNone       = "none"       // no lines at all
+)
+
+// tableBorderStyle returns the table's borderStyle attr, falling back to all lines
+// when it is unset or unknown.
+func tableBorderStyle(attrs map[string]any) string {
+	style, _ := attrs["borderStyle"].(string)
+	switch style {
+	case tableBorderHorizontal, tableBorderOuter, tableBorderNone:
+		return style
+	default:
+		return tableBorderAll
+	}
+}
+
+// tableBorderLine is the stroke of a table line in the token color.
+func (c *typstConverter) tableBorderLine() string {
+	return "0.5pt + " + c.tokens.TableStrokeColor
+}
+
+// tableStroke returns the #table stroke argument for the border style.
+func (c *typstConverter) tableStroke(style string) string {
+	switch style {
+	case tableBorderHorizontal:
+		return fmt.Sprintf("(x: none, y: %s)", c.tableBorderLine())
+	case tableBorderOuter, tableBorderNone:
+		return "none"
+	default:
+		return c.tableBorderLine()
+	}
+}
+
+// tableBlockOpen opens the block scoping a table's #show rules. The outer style draws
+// its frame on the block rather than on edge cells, so merged cells and page breaks
+// still get a closed frame.
+func (c *typstConverter) tableBlockOpen(style string) string {
+	if style == tableBorderOuter {
+		return fmt.Sprintf("#block(stroke: %s)[\n", c.tableBorderLine())
+	}
+	return "#block[\n"
+}
@@ -0,0 +1,67 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+// tableCellInsets are the Typst insets of a table's header and body cells.
+type tableCellInsets struct {
+	header string
+	body   string
+}
+
+// parseTableCellInsets reads a table node's cellPadding attrs, e.g.
+// {"header": {"x": 8, "y": 12}, "body": {"x": "2mm", "y": 4}}, over the given defaults.
+// Values are lengths in pixels or with a unit; an axis left out keeps its default.
+func parseTableCellInsets(attrs map[string]any, headerDefault, bodyDefault string) tableCellInsets {
+	padding, _ := attrs["cellPadding"].(map[string]any)
+	return tableCellInsets{
+		header: overrideInset(padding["header"], headerDefault),
+		body:   overrideInset(padding["body"], bodyDefault),
+	}
+}
+
+// overrideInset applies the x/y lengths in v over the default Typst inset. The default
+// may be a dictionary such as "(x: 6pt, y: 12pt)", which is spread under the override,
+// or a single length used for the missing axis.
+func overrideInset(v any, def string) string {
+	side, _ := v.(map[string]any)
+	x, hasX := insetAxis(side, "x")
+	y, hasY := insetAxis(side, "y")
+	switch {
+	case hasX && hasY:
+		return fmt.Sprintf("(x: %s, y: %s)", x, y)
+	case !hasX && !hasY:
+		return def
+	case strings.HasPrefix(strings.TrimSpace(def), "("):
+		if hasX {
+			return fmt.Sprintf("(..%s, x: %s)", def, x)
+		}
+		return fmt.Sprintf("(..%s, y: %s)", def, y)
+	case hasX:
+		return fmt.Sprintf("(x: %s, y: %s)", x, def)
+	default:
+		return fmt.Sprintf("(x: %s, y: %s)", def, y)
+	}
+}
+
+// insetAxis returns one padding axis as a Typst length in points.
+func insetAxis(side map[string]any, axis string) (string, bool) {
+	px, ok := portabledoc.LengthPx(side[axis])
+	if !ok || px < 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%.1fpt", px*pxToPt), true
+}
+
+// editableTableInset returns the table-level inset of an editable table: one inset for
+// every cell, or a function giving the first (header) row its own.
+func (i tableCellInsets) editableTableInset() string {
+	if i.header == i.body {
+		return i.body
+	}
+	return fmt.Sprintf("(x, y) => if y == 0 { %s } else { %s }", i.header, i.body)
+}
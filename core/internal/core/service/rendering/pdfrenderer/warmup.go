@@ -0,0 +1,59 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// warmupSource is the minimal document compiled by Warmup.
+const warmupSource = "warmup"
+
+// warmupCount returns how many warmup compiles to run, capped at the render concurrency
+// so warming never queues behind itself or trips the queue limit.
+func warmupCount(opts TypstOptions) int {
+	if opts.WarmupRenders <= 0 {
+		return 0
+	}
+	if opts.MaxConcurrent > 0 {
+		return min(opts.WarmupRenders, opts.MaxConcurrent)
+	}
+	return opts.WarmupRenders
+}
+
+// Warmup compiles a minimal document in parallel, once per configured warmup render,
+// each holding a render slot. The Typst CLI has no resident or compile-server mode, so
+// every render still starts its own process and shares no state with the others; warming
+// loads the binary, scans the font directories and fills the OS page cache before the
+// first request pays for them. Failures are returned but leave the service usable.
+func (s *Service) Warmup(ctx context.Context) error {
+	if s.warmupRenders <= 0 || s.typst == nil {
+		return nil
+	}
+
+	start := time.Now()
+	errs := make([]error, s.warmupRenders)
+	var wg sync.WaitGroup
+	for i := range s.warmupRenders {
+		wg.Go(func() {
+			release, err := s.pool.Acquire(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer release()
+			_, errs[i] = s.typst.GeneratePDF(ctx, warmupSource, "", "")
+		})
+	}
+	wg.Wait()
+
+	err := errors.Join(errs...)
+	slog.InfoContext(ctx, "typst warmup finished",
+		slog.Int("renders", s.warmupRenders),
+		slog.Duration("duration", time.Since(start)),
+		slog.Bool("ok", err == nil),
+	)
+	return err
+}
@@ -0,0 +1,132 @@
+package pdfrenderer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func TestWarmupCount(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TypstOptions
+		want int
+	}{
+		{"disabled", TypstOptions{MaxConcurrent: 4}, 0},
+		{"negative", TypstOptions{WarmupRenders: -1}, 0},
+		{"below concurrency", TypstOptions{WarmupRenders: 2, MaxConcurrent: 4}, 2},
+		{"capped at concurrency", TypstOptions{WarmupRenders: 8, MaxConcurrent: 4}, 4},
+		{"unlimited concurrency", TypstOptions{WarmupRenders: 8}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := warmupCount(tt.opts); got != tt.want {
+				t.Fatalf("warmupCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarmup_NoopWithoutRenders(t *testing.T) {
+	s := &Service{}
+	if err := s.Warmup(context.Background()); err != nil {
+		t.Fatalf("expected no-op warmup, got %v", err)
+	}
+}
+
+func TestWarmup_CompilesThroughThePool(t *testing.T) {
+	opts := DefaultTypstOptions()
+	opts.MaxConcurrent = 2
+	opts.WarmupRenders = 2
+	s, err := NewService(opts, nil, NewTypstConverterFactory(DefaultDesignTokens()), DefaultDesignTokens(), nil)
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Warmup(context.Background()); err != nil {
+		t.Fatalf("warmup failed: %v", err)
+	}
+	if stats := s.RenderPoolStats(); stats.InFlight != 0 {
+		t.Fatalf("expected every warmup slot released, got %+v", stats)
+	}
+}
+
+// TestConvert_NoStateBleedBetweenRenders converts two documents back to back on one service
+// and checks the second source carries nothing of the first.
+func TestConvert_NoStateBleedBetweenRenders(t *testing.T) {
+	s := themedService()
+
+	first := staticDocument()
+	first.Content.Content = append(first.Content.Content, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+		{Type: portabledoc.NodeTypeText, Text: strPtr("First customer only")},
+	}})
+	_, firstSource, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{
+		Document: first, Theme: "brand", ShowPlaceholders: true,
+	})
+	if err != nil {
+		t.Fatalf("first convert failed: %v", err)
+	}
+	if !strings.Contains(firstSource, "First customer only") {
+		t.Fatalf("expected first source to carry its own text, got:\n%s", firstSource)
+	}
+
+	second := dynamicDocument()
+	_, secondSource, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{
+		Document:    second,
+		Injectables: map[string]any{"client_name": "Second Co"},
+	})
+	if err != nil {
+		t.Fatalf("second convert failed: %v", err)
+	}
+
+	if strings.Contains(secondSource, "First customer only") {
+		t.Fatal("second source carries text from the first render")
+	}
+	if strings.Contains(secondSource, brandTokens().BaseTextColor) {
+		t.Fatal("second source carries the first render's theme")
+	}
+	if !strings.Contains(secondSource, "Second Co") {
+		t.Fatalf("expected second source to carry its own injectable, got:\n%s", secondSource)
+	}
+
+	// Converting the first document again gives the same source as before
+	_, again, _, _, err := s.convert(context.Background(), &port.RenderPreviewRequest{
+		Document: first, Theme: "brand", ShowPlaceholders: true,
+	})
+	if err != nil {
+		t.Fatalf("repeat convert failed: %v", err)
+	}
+	if again != firstSource {
+		t.Fatal("repeating the first render changed its source")
+	}
+}
+
+// BenchmarkTypstRenderer_ColdVsWarm reports the first compile's latency as cold-ms
+// and the mean latency of the compiles after it as ns/op.
+func BenchmarkTypstRenderer_ColdVsWarm(b *testing.B) {
+	renderer, err := NewTypstRenderer(DefaultTypstOptions())
+	if err != nil {
+		b.Skipf("Typst not available, skipping benchmark: %v", err)
+	}
+	defer renderer.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+	if _, err := renderer.GeneratePDF(ctx, warmupSource, "", ""); err != nil {
+		b.Fatalf("cold compile failed: %v", err)
+	}
+	cold := time.Since(start)
+
+	for b.Loop() {
+		if _, err := renderer.GeneratePDF(ctx, "Hello", "", ""); err != nil {
+			b.Fatalf("warm compile failed: %v", err)
+		}
+	}
+	b.ReportMetric(float64(cold.Microseconds())/1000, "cold-ms")
+}
@@ -0,0 +1,93 @@
+package pdfrenderer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// WorkspaceAssetRenderer makes the rendered version's workspace brand assets available
+// to the render: the logo as the entity.WorkspaceLogoInjectable injectable (unless the
+// request supplies its own value) and fonts as extra font files.
+type WorkspaceAssetRenderer struct {
+	inner     port.PDFRenderer
+	assets    port.WorkspaceAssetRepository
+	templates port.TemplateRepository
+	versions  port.TemplateVersionRepository
+}
+
+// NewWorkspaceAssetRenderer wraps a renderer with workspace brand assets.
+func NewWorkspaceAssetRenderer(
+	inner port.PDFRenderer,
+	assets port.WorkspaceAssetRepository,
+	templates port.TemplateRepository,
+	versions port.TemplateVersionRepository,
+) *WorkspaceAssetRenderer {
+	return &WorkspaceAssetRenderer{inner: inner, assets: assets, templates: templates, versions: versions}
+}
+
+// RenderPreview adds the workspace's logo and fonts to the request, then renders it.
+func (r *WorkspaceAssetRenderer) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	if req.VersionID == "" {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	workspaceID, err := renderWorkspaceID(ctx, r.templates, r.versions, req.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	assets, err := r.assets.List(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("loading workspace assets: %w", err)
+	}
+	if len(assets) == 0 {
+		return r.inner.RenderPreview(ctx, req)
+	}
+
+	branded := *req
+	for _, asset := range assets {
+		switch asset.Kind {
+		case entity.WorkspaceAssetKindLogo:
+			if _, ok := req.Injectables[entity.WorkspaceLogoInjectable]; ok {
+				continue
+			}
+			injectables := make(map[string]any, len(req.Injectables)+1)
+			maps.Copy(injectables, req.Injectables)
+			injectables[entity.WorkspaceLogoInjectable] = "storage://" + asset.Key
+			branded.Injectables = injectables
+		case entity.WorkspaceAssetKindFont:
+			branded.FontKeys = append(slices.Clip(branded.FontKeys), asset.Key)
+		}
+	}
+	return r.inner.RenderPreview(ctx, &branded)
+}
+
+// RenderPoolStats reports the wrapped renderer's pool, if it has one.
+func (r *WorkspaceAssetRenderer) RenderPoolStats() port.RenderPoolStats {
+	if monitor, ok := r.inner.(port.RenderPoolMonitor); ok {
+		return monitor.RenderPoolStats()
+	}
+	return port.RenderPoolStats{}
+}
+
+// Close closes the wrapped renderer.
+func (r *WorkspaceAssetRenderer) Close() error {
+	return r.inner.Close()
+}
+
+// renderWorkspaceID returns the workspace owning the rendered version.
+func renderWorkspaceID(ctx context.Context, templates port.TemplateRepository, versions port.TemplateVersionRepository, versionID string) (string, error) {
+	version, err := versions.FindByID(ctx, versionID)
+	if err != nil {
+		return "", fmt.Errorf("loading rendered version: %w", err)
+	}
+	tmpl, err := templates.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return "", fmt.Errorf("loading rendered template: %w", err)
+	}
+	return tmpl.WorkspaceID, nil
+}
@@ -0,0 +1,103 @@
+//go:build integration
+
+package pdfrenderer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	templaterepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateversionrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_repo"
+	workspaceassetrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_asset_repo"
+	localstorage "github.com/rendis/doc-assembly/core/internal/adapters/secondary/storage/local"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	organizationsvc "github.com/rendis/doc-assembly/core/internal/core/service/organization"
+	organizationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/organization"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// requestRecorder records the request it receives instead of rendering it.
+type requestRecorder struct {
+	req *port.RenderPreviewRequest
+}
+
+func (r *requestRecorder) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	r.req = req
+	return &port.RenderPreviewResult{}, nil
+}
+
+func (r *requestRecorder) Close() error { return nil }
+
+const logoContent = `{"version":"1.1.0","meta":{"title":"Doc","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":["workspace.logo"],"signerRoles":[],"content":{"type":"doc","content":[{"type":"image","attrs":{"src":"","injectableId":"workspace.logo","width":120}},{"type":"paragraph","content":[{"type":"text","text":"Terms"}]}]}}`
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestWorkspaceAssetRenderer_ResolvesWorkspaceLogo(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Asset Tenant", "WSAR01")
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Asset Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Branded", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, templateID) })
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, versionID, []byte(logoContent))
+	admin := testhelper.CreateTestUser(t, pool, "asset-admin@test.com", "Asset Admin", nil)
+	t.Cleanup(func() { testhelper.CleanupUser(t, pool, admin.ID) })
+
+	storage, err := localstorage.New(t.TempDir())
+	require.NoError(t, err)
+	assets := workspaceassetrepo.New(pool)
+
+	logo, err := organizationsvc.NewWorkspaceAssetService(assets, storage).UploadAsset(ctx, organizationuc.UploadWorkspaceAssetCommand{
+		WorkspaceID: workspaceID,
+		UserID:      admin.ID,
+		Kind:        entity.WorkspaceAssetKindLogo,
+		Filename:    "logo.png",
+		ContentType: "image/png",
+		Data:        pngHeader,
+	})
+	require.NoError(t, err)
+
+	inner := &requestRecorder{}
+	renderer := NewWorkspaceAssetRenderer(inner, assets, templaterepo.New(pool), templateversionrepo.New(pool))
+
+	_, err = renderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+		VersionID: versionID,
+		Document:  portabledoc.MustParse([]byte(logoContent)),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, inner.req)
+	assert.Equal(t, "storage://"+logo.Key, inner.req.Injectables[entity.WorkspaceLogoInjectable])
+
+	svc := themedService()
+	svc.storageAdapter = storage
+	builder, _, _, _, err := svc.convert(ctx, inner.req)
+	require.NoError(t, err)
+	require.Contains(t, builder.RemoteImages(), "storage://"+logo.Key, "the logo image must be registered for download")
+
+	resolved := svc.resolveStorageEntries(ctx, builder.RemoteImages())
+	require.Len(t, resolved, 1)
+	for src := range resolved {
+		assert.True(t, strings.HasPrefix(src, "data:image/png;base64,"), "logo must resolve from storage, got %q", src)
+	}
+
+	t.Run("request value wins", func(t *testing.T) {
+		_, err := renderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+			VersionID:   versionID,
+			Document:    portabledoc.MustParse([]byte(logoContent)),
+			Injectables: map[string]any{entity.WorkspaceLogoInjectable: "https://example.com/logo.png"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/logo.png", inner.req.Injectables[entity.WorkspaceLogoInjectable])
+	})
+}
@@ -0,0 +1,223 @@
+package rendering
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	injectablesvc "github.com/rendis/doc-assembly/core/internal/core/service/injectable"
+	injectableuc "github.com/rendis/doc-assembly/core/internal/core/usecase/injectable"
+	renderinguc "github.com/rendis/doc-assembly/core/internal/core/usecase/rendering"
+)
+
+// Service implements the RenderUseCase input port.
+type Service struct {
+	versions     port.TemplateVersionRepository
+	templates    port.TemplateRepository
+	injectableUC injectableuc.InjectableUseCase
+	renderer     port.PDFRenderer
+}
+
+// New creates a new render service. injectableUC may be nil to render without provider defaults.
+func New(
+	versions port.TemplateVersionRepository,
+	templates port.TemplateRepository,
+	injectableUC injectableuc.InjectableUseCase,
+	renderer port.PDFRenderer,
+) renderinguc.RenderUseCase {
+	return &Service{versions: versions, templates: templates, injectableUC: injectableUC, renderer: renderer}
+}
+
+// RenderVersion renders a template version with its injectable and provider defaults.
+func (s *Service) RenderVersion(ctx context.Context, cmd renderinguc.RenderVersionCmd) (*port.RenderPreviewResult, error) {
+	details, doc, err := s.loadVersion(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, entity.ErrVersionHasNoContent
+	}
+
+	req, err := renderRequest(doc, cmd.Injectables, cmd.Options)
+	if err != nil {
+		return nil, err
+	}
+	req.VersionID = details.ID
+	// Static documents read no injectables, so they skip the defaults lookup
+	if !doc.IsStatic() {
+		req.InjectableDefaults = injectableDefaults(details.Injectables)
+		req.Injectables = withComputedValues(ctx, doc, details.Injectables, req.Injectables, req.InjectableDefaults)
+		req.DefaultResolver = s.defaultResolver(ctx, details.TemplateID, cmd)
+	}
+	return s.renderer.RenderPreview(ctx, req)
+}
+
+// loadVersion loads a version and parses its content structure into a portable document.
+func (s *Service) loadVersion(ctx context.Context, versionID string) (*entity.TemplateVersionWithDetails, *portabledoc.Document, error) {
+	ctx, span := startSpan(ctx, "render.parse", attribute.String("version.id", versionID))
+	details, err := s.versions.FindByIDWithDetails(ctx, versionID)
+	if err != nil {
+		err = fmt.Errorf("finding version details %s: %w", versionID, err)
+		endSpan(span, err)
+		return nil, nil, err
+	}
+	doc, err := portabledoc.Parse(details.ContentStructure)
+	if err != nil {
+		err = fmt.Errorf("parsing content of version %s: %w", details.ID, err)
+	}
+	endSpan(span, err)
+	return details, doc, err
+}
+
+// RenderDocument renders an in-memory document without defaults.
+func (s *Service) RenderDocument(ctx context.Context, cmd renderinguc.RenderDocumentCmd) (*port.RenderPreviewResult, error) {
+	if cmd.Document == nil {
+		return nil, fmt.Errorf("%w: document is required", entity.ErrRequiredField)
+	}
+	req, err := renderRequest(cmd.Document, cmd.Injectables, cmd.Options)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderer.RenderPreview(ctx, req)
+}
+
+// renderRequest builds the renderer request for a document and its options.
+func renderRequest(doc *portabledoc.Document, injectables map[string]any, opts renderinguc.RenderOptions) (*port.RenderPreviewRequest, error) {
+	blockIndex := opts.BlockIndex
+	if blockIndex == nil && opts.BlockID != "" {
+		idx, ok := doc.BlockIndex(opts.BlockID)
+		if !ok {
+			return nil, fmt.Errorf("%w: id %q", entity.ErrRenderBlockNotFound, opts.BlockID)
+		}
+		blockIndex = &idx
+	}
+	if injectables == nil {
+		injectables = make(map[string]any)
+	}
+	return &port.RenderPreviewRequest{
+		Document:               doc,
+		Injectables:            injectables,
+		Slots:                  opts.Slots,
+		BlockIndex:             blockIndex,
+		DraftMode:              opts.DraftMode,
+		ShowPlaceholders:       opts.ShowPlaceholders,
+		ShowHiddenConditionals: opts.ShowHiddenConditionals,
+		Timezone:               opts.Timezone,
+		PDFA:                   opts.PDFA,
+		Theme:                  opts.Theme,
+		Encryption:             opts.Encryption,
+		Image:                  opts.Image,
+		ExtractSignaturePages:  opts.ExtractSignaturePages,
+	}, nil
+}
+
+// defaultResolver builds the provider default resolver for the version's workspace.
+// Failures are logged and render without provider defaults.
+func (s *Service) defaultResolver(ctx context.Context, templateID string, cmd renderinguc.RenderVersionCmd) port.InjectableDefaultResolver {
+	if s.injectableUC == nil {
+		return nil
+	}
+	workspaceID := cmd.WorkspaceID
+	if workspaceID == "" {
+		template, err := s.templates.FindByID(ctx, templateID)
+		if err != nil {
+			slog.WarnContext(ctx, "rendered template not found, skipping provider defaults",
+				slog.String("template_id", templateID),
+				slog.Any("error", err),
+			)
+			return nil
+		}
+		workspaceID = template.WorkspaceID
+	}
+	resolver, err := s.injectableUC.NewDefaultResolver(ctx, &injectableuc.DefaultResolverRequest{
+		WorkspaceID: workspaceID,
+		TemplateID:  templateID,
+		Environment: cmd.Environment,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "provider default resolver unavailable",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	return resolver
+}
+
+// injectableDefaults builds a map of default values from version injectables.
+// Priority: TemplateVersionInjectable.DefaultValue > InjectableDefinition.DefaultValue
+func injectableDefaults(injectables []*entity.VersionInjectableWithDefinition) map[string]string {
+	defaults := make(map[string]string)
+
+	for _, injectable := range injectables {
+		// Get the variable ID (either from definition key or system key)
+		var variableID string
+		if injectable.Definition != nil {
+			variableID = injectable.Definition.Key
+		} else if injectable.SystemInjectableKey != nil {
+			variableID = *injectable.SystemInjectableKey
+		} else {
+			continue
+		}
+
+		// First, try template version specific default
+		if injectable.DefaultValue != nil && *injectable.DefaultValue != "" {
+			defaults[variableID] = *injectable.DefaultValue
+			continue
+		}
+
+		// Fallback to injectable definition default
+		if injectable.Definition != nil && injectable.Definition.DefaultValue != nil && *injectable.Definition.DefaultValue != "" {
+			defaults[variableID] = *injectable.Definition.DefaultValue
+		}
+	}
+
+	return defaults
+}
+
+// withComputedValues evaluates the computed injectables the document references over
+// the supplied values and defaults, returning a copy of injectables with the results
+// added. Explicitly supplied values win. Operands only available from providers are
+// not resolved in previews, so computed values depending on them stay empty.
+func withComputedValues(
+	ctx context.Context,
+	doc *portabledoc.Document,
+	versionInjectables []*entity.VersionInjectableWithDefinition,
+	injectables map[string]any,
+	defaults map[string]string,
+) map[string]any {
+	defs := make([]*entity.InjectableDefinition, 0, len(versionInjectables))
+	for _, injectable := range versionInjectables {
+		if injectable.Definition != nil {
+			defs = append(defs, injectable.Definition)
+		}
+	}
+	computed := injectablesvc.CollectComputedExpressions(defs, doc.VariableIDs)
+	if len(computed) == 0 {
+		return injectables
+	}
+
+	inputs := make(map[string]any, len(defaults)+len(injectables))
+	for key, value := range defaults {
+		inputs[key] = value
+	}
+	for key, value := range injectables {
+		inputs[key] = value
+	}
+
+	result := make(map[string]any, len(injectables)+len(computed))
+	for key, value := range injectables {
+		result[key] = value
+	}
+	for code, value := range injectablesvc.EvaluateComputed(ctx, computed, inputs) {
+		if _, supplied := result[code]; !supplied {
+			result[code] = value
+		}
+	}
+	return result
+}
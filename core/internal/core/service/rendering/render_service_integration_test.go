@@ -0,0 +1,193 @@
+//go:build integration
+
+package rendering_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	templaterepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateversionrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/template_version_repo"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+	renderingsvc "github.com/rendis/doc-assembly/core/internal/core/service/rendering"
+	renderinguc "github.com/rendis/doc-assembly/core/internal/core/usecase/rendering"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// requestRecorder records the request it receives instead of rendering it.
+type requestRecorder struct {
+	req *port.RenderPreviewRequest
+}
+
+func (r *requestRecorder) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	r.req = req
+	return &port.RenderPreviewResult{PDF: []byte("%PDF-1.7"), PageCount: 1}, nil
+}
+
+func (r *requestRecorder) Close() error { return nil }
+
+const clientContent = `{"version":"1.1.0","meta":{"title":"Doc","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":["client_name"],"signerRoles":[],"content":{"type":"doc","content":[{"type":"paragraph","attrs":{"nodeId":"intro"},"content":[{"type":"text","text":"Dear "},{"type":"injector","attrs":{"type":"TEXT","variableId":"client_name"}}]},{"type":"paragraph","attrs":{"nodeId":"terms"},"content":[{"type":"text","text":"Terms"}]}]}}`
+
+func TestRenderService_RenderVersion(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Render Tenant", "RNDR01")
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Render Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Letter", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, templateID) })
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, versionID, []byte(clientContent))
+
+	injectableID := testhelper.CreateTestInjectable(t, pool, &workspaceID, "client_name", "Client", entity.InjectableDataTypeText)
+	t.Cleanup(func() { testhelper.CleanupInjectable(t, pool, injectableID) })
+	versionInjectableID := testhelper.CreateTestVersionInjectable(t, pool, versionID, injectableID, false)
+	t.Cleanup(func() { testhelper.CleanupVersionInjectable(t, pool, versionInjectableID) })
+	_, err := pool.Exec(ctx, `UPDATE content.template_version_injectables SET default_value = $1 WHERE id = $2`, "Jane Doe", versionInjectableID)
+	require.NoError(t, err)
+
+	recorder := &requestRecorder{}
+	svc := renderingsvc.New(templateversionrepo.New(pool), templaterepo.New(pool), nil, recorder)
+
+	t.Run("renders with version defaults", func(t *testing.T) {
+		result, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{
+			VersionID:   versionID,
+			Injectables: map[string]any{"client_name": "ACME"},
+			Options:     renderinguc.RenderOptions{DraftMode: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.PageCount)
+
+		require.NotNil(t, recorder.req)
+		assert.Equal(t, versionID, recorder.req.VersionID)
+		assert.Equal(t, "ACME", recorder.req.Injectables["client_name"])
+		assert.Equal(t, "Jane Doe", recorder.req.InjectableDefaults["client_name"])
+		assert.True(t, recorder.req.DraftMode)
+		assert.Nil(t, recorder.req.BlockIndex)
+	})
+
+	t.Run("traces loading the version", func(t *testing.T) {
+		spans := tracetest.NewSpanRecorder()
+		prev := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+		t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{VersionID: versionID})
+		require.NoError(t, err)
+
+		ended := spans.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, "render.parse", ended[0].Name())
+	})
+
+	t.Run("selects a block by id", func(t *testing.T) {
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{
+			VersionID: versionID,
+			Options:   renderinguc.RenderOptions{BlockID: "terms"},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, recorder.req.BlockIndex)
+		assert.Equal(t, 1, *recorder.req.BlockIndex)
+		assert.NotNil(t, recorder.req.Injectables, "missing inputs render as an empty map")
+
+		_, err = svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{
+			VersionID: versionID,
+			Options:   renderinguc.RenderOptions{BlockID: "missing"},
+		})
+		assert.ErrorIs(t, err, entity.ErrRenderBlockNotFound)
+		assert.ErrorIs(t, err, entity.ErrValidation)
+	})
+
+	t.Run("version without content", func(t *testing.T) {
+		emptyID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 2, "v2", entity.VersionStatusDraft)
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{VersionID: emptyID})
+		assert.ErrorIs(t, err, entity.ErrVersionHasNoContent)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{VersionID: "00000000-0000-0000-0000-000000000000"})
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+
+	t.Run("renders an in-memory document", func(t *testing.T) {
+		_, err := svc.RenderDocument(ctx, renderinguc.RenderDocumentCmd{
+			Document:    portabledoc.MustParse([]byte(clientContent)),
+			Injectables: map[string]any{"client_name": "ACME"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, recorder.req.VersionID)
+		assert.Nil(t, recorder.req.InjectableDefaults)
+		assert.Equal(t, "ACME", recorder.req.Injectables["client_name"])
+
+		_, err = svc.RenderDocument(ctx, renderinguc.RenderDocumentCmd{})
+		assert.ErrorIs(t, err, entity.ErrValidation)
+	})
+}
+
+const totalContent = `{"version":"1.1.0","meta":{"title":"Invoice","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":["total"],"signerRoles":[],"content":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Total "},{"type":"injector","attrs":{"type":"NUMBER","variableId":"total"}}]}]}}`
+
+func TestRenderService_RenderVersionEvaluatesComputedInjectables(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "Computed Tenant", "RNDR02")
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "Computed Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Invoice", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, templateID) })
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, versionID, []byte(totalContent))
+
+	quantityID := testhelper.CreateTestInjectable(t, pool, &workspaceID, "quantity", "Quantity", entity.InjectableDataTypeNumber)
+	t.Cleanup(func() { testhelper.CleanupInjectable(t, pool, quantityID) })
+	totalID := testhelper.CreateTestInjectable(t, pool, &workspaceID, "total", "Total", entity.InjectableDataTypeNumber)
+	t.Cleanup(func() { testhelper.CleanupInjectable(t, pool, totalID) })
+	_, err := pool.Exec(ctx, `UPDATE content.injectable_definitions SET metadata = $1 WHERE id = $2`,
+		map[string]any{entity.InjectableMetadataExpression: "quantity * unit_price"}, totalID)
+	require.NoError(t, err)
+
+	quantityVersionID := testhelper.CreateTestVersionInjectable(t, pool, versionID, quantityID, false)
+	t.Cleanup(func() { testhelper.CleanupVersionInjectable(t, pool, quantityVersionID) })
+	_, err = pool.Exec(ctx, `UPDATE content.template_version_injectables SET default_value = $1 WHERE id = $2`, "3", quantityVersionID)
+	require.NoError(t, err)
+	totalVersionID := testhelper.CreateTestVersionInjectable(t, pool, versionID, totalID, false)
+	t.Cleanup(func() { testhelper.CleanupVersionInjectable(t, pool, totalVersionID) })
+
+	recorder := &requestRecorder{}
+	svc := renderingsvc.New(templateversionrepo.New(pool), templaterepo.New(pool), nil, recorder)
+
+	t.Run("evaluates over supplied values and defaults", func(t *testing.T) {
+		injectables := map[string]any{"unit_price": 2.5}
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{VersionID: versionID, Injectables: injectables})
+		require.NoError(t, err)
+
+		assert.Equal(t, 7.5, recorder.req.Injectables["total"])
+		assert.NotContains(t, injectables, "total", "the caller's injectables must not be modified")
+	})
+
+	t.Run("supplied values win", func(t *testing.T) {
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{
+			VersionID:   versionID,
+			Injectables: map[string]any{"unit_price": 2.5, "total": 10.0},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, recorder.req.Injectables["total"])
+	})
+
+	t.Run("missing operands leave the value empty", func(t *testing.T) {
+		_, err := svc.RenderVersion(ctx, renderinguc.RenderVersionCmd{VersionID: versionID})
+		require.NoError(t, err)
+		assert.NotContains(t, recorder.req.Injectables, "total")
+	})
+}
@@ -17,6 +17,36 @@ func (s *Service) validateConditionals(vctx *validationContext) {
 		path := fmt.Sprintf("content.conditional[%d]", i)
 		validateConditionalNode(vctx, node, path, s.maxNestingDepth)
 	}
+
+	// Conditional style marks carry the same conditions as conditional blocks
+	i := 0
+	for node := range doc.AllNodes() {
+		for _, mark := range node.Marks {
+			if mark.Type != portabledoc.MarkTypeConditionalStyle {
+				continue
+			}
+			path := fmt.Sprintf("content.conditionalStyle[%d]", i)
+			validateConditionalStyleMark(vctx, mark, path, s.maxNestingDepth)
+			i++
+		}
+	}
+}
+
+// validateConditionalStyleMark validates the conditions of a conditional style mark.
+// A mark without conditions would never apply its style.
+func validateConditionalStyleMark(vctx *validationContext, mark portabledoc.Mark, path string, maxDepth int) {
+	if mark.Attrs["conditions"] == nil {
+		vctx.addError(ErrCodeInvalidConditionAttrs, path+".conditions",
+			"Conditional style requires conditions")
+		return
+	}
+	attrs, err := portabledoc.ParseConditionalAttrs(mark.Attrs)
+	if err != nil {
+		vctx.addErrorf(ErrCodeInvalidConditionAttrs, path+".attrs",
+			"Invalid conditional style attributes: %s", err.Error())
+		return
+	}
+	validateLogicGroup(vctx, &attrs.Conditions, path+".conditions", 0, maxDepth)
 }
 
 // validateConditionalNode validates a single conditional block node.
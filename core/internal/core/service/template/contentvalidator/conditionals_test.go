@@ -0,0 +1,78 @@
+package contentvalidator
+
+import (
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// styledText returns a text node carrying a conditional style mark with the given attrs.
+func styledText(attrs map[string]any) portabledoc.Node {
+	text := "Amount due"
+	return portabledoc.Node{
+		Type:  portabledoc.NodeTypeText,
+		Text:  &text,
+		Marks: []portabledoc.Mark{{Type: portabledoc.MarkTypeConditionalStyle, Attrs: attrs}},
+	}
+}
+
+func TestValidateConditionals_ChecksConditionalStyleMarks(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrs    map[string]any
+		wantCode string
+		wantPath string
+	}{
+		{
+			name:     "missing conditions",
+			attrs:    map[string]any{"color": "#D32F2F"},
+			wantCode: ErrCodeInvalidConditionAttrs,
+			wantPath: "content.conditionalStyle[0].conditions",
+		},
+		{
+			name: "unknown variable",
+			attrs: map[string]any{"conditions": map[string]any{
+				"type": "group", "logic": "AND",
+				"children": []any{map[string]any{
+					"type": "rule", "variableId": "missing", "operator": "eq",
+					"value": map[string]any{"mode": "text", "value": "overdue"},
+				}},
+			}},
+			wantCode: ErrCodeInvalidConditionVar,
+			wantPath: "content.conditionalStyle[0].conditions.children[0].variableId",
+		},
+		{
+			name: "unknown operator",
+			attrs: map[string]any{"conditions": map[string]any{
+				"type": "group", "logic": "AND",
+				"children": []any{map[string]any{
+					"type": "rule", "variableId": "status", "operator": "~=",
+					"value": map[string]any{"mode": "text", "value": "overdue"},
+				}},
+			}},
+			wantCode: ErrCodeInvalidOperator,
+			wantPath: "content.conditionalStyle[0].conditions.children[0].operator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := port.NewValidationResult()
+			vctx := &validationContext{
+				doc: &portabledoc.Document{
+					Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{
+						{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{styledText(tt.attrs)}},
+					}},
+				},
+				result:      result,
+				variableSet: portabledoc.NewSet([]string{"status"}),
+			}
+
+			(&Service{maxNestingDepth: 3}).validateConditionals(vctx)
+
+			if result.ErrorCount() != 1 || result.Errors[0].Code != tt.wantCode || result.Errors[0].Path != tt.wantPath {
+				t.Fatalf("expected %s at %s, got %#v", tt.wantCode, tt.wantPath, result.Errors)
+			}
+		})
+	}
+}
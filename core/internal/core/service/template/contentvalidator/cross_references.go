@@ -0,0 +1,28 @@
+package contentvalidator
+
+import (
+	"fmt"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+// validateCrossReferences warns about references whose target is missing or can't be
+// referenced. Such references still render, showing their fallback text.
+func (s *Service) validateCrossReferences(vctx *validationContext) {
+	if !vctx.doc.HasNodeOfType(portabledoc.NodeTypeCrossReference) {
+		return
+	}
+
+	targets := vctx.doc.CrossReferenceTargets()
+	for i, node := range vctx.doc.NodesOfType(portabledoc.NodeTypeCrossReference) {
+		path := fmt.Sprintf("content.crossReference[%d].attrs.targetId", i)
+		targetID := node.CrossReferenceTarget()
+		switch {
+		case targetID == "":
+			vctx.addWarning(WarnCodeDanglingCrossReference, path, "Cross-reference has no target")
+		case !targets.Contains(targetID):
+			vctx.addWarningf(WarnCodeDanglingCrossReference, path,
+				"Cross-reference target '%s' is not a heading or captioned image in this document", targetID)
+		}
+	}
+}
@@ -18,6 +18,8 @@ const (
 	ErrCodeInvalidPageSize   = "INVALID_PAGE_SIZE"
 	ErrCodeInvalidMargins    = "INVALID_MARGINS"
 
+	ErrCodeInvalidHeadingNumbering = "INVALID_HEADING_NUMBERING"
+
 	// Signer role errors
 	ErrCodeEmptyRoleID            = "EMPTY_SIGNER_ROLE_ID"
 	ErrCodeDuplicateRoleID        = "DUPLICATE_SIGNER_ROLE_ID"
@@ -82,6 +84,10 @@ const (
 	ErrCodeInvalidMaxLength          = "INVALID_MAX_LENGTH"
 	ErrCodeDuplicateInteractiveField = "DUPLICATE_INTERACTIVE_FIELD"
 
+	// Node errors
+	ErrCodeMissingNodeType    = "MISSING_NODE_TYPE"
+	ErrCodeMissingImageSource = "MISSING_IMAGE_SOURCE"
+
 	// Context errors
 	ErrCodeValidationCancelled = "VALIDATION_CANCELLED"
 )
@@ -95,6 +101,9 @@ const (
 	WarnCodeNoSignerRoles                   = "NO_SIGNER_ROLES"
 	WarnCodeNoSignatures                    = "NO_SIGNATURES"
 	WarnCodeInteractiveFieldsNoUnsignedRole = "INTERACTIVE_FIELDS_NO_UNSIGNED_ROLE"
+	WarnCodeUnknownNodeType                 = "UNKNOWN_NODE_TYPE"
+	WarnCodeEmptyConditional                = "EMPTY_CONDITIONAL"
+	WarnCodeDanglingCrossReference          = "DANGLING_CROSS_REFERENCE"
 )
 
 // sanitizeJSONError converts raw JSON parse errors to user-friendly messages.
@@ -109,3 +109,39 @@ func TestValidateVariables_FlagsImageRefsMissingFromVariableIDs(t *testing.T) {
 		t.Fatalf("expected header image binding error path, got %#v", errors)
 	}
 }
+
+func TestValidateVariables_FlagsSignatureImageRefMissingFromVariableIDs(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			Content: &portabledoc.ProseMirrorDoc{
+				Type: "doc",
+				Content: []portabledoc.Node{
+					{
+						Type: portabledoc.NodeTypeSignature,
+						Attrs: map[string]any{
+							"count": float64(1),
+							"signatures": []any{
+								map[string]any{"id": "sig_1", "label": "CEO", "injectableId": "ceo_signature"},
+							},
+						},
+					},
+				},
+			},
+		},
+		result:                result,
+		variableSet:           make(portabledoc.Set[string]),
+		accessibleInjectables: portabledoc.NewSet([]string{"ceo_signature"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 1 || result.Errors[0].Path != "content.signature[0].attrs.signatures[0].injectableId" {
+		t.Fatalf("expected signature image binding error, got %#v", result.Errors)
+	}
+	if refs := collectInjectableRefsFromImages(vctx.doc); len(refs) != 1 || refs[0] != "ceo_signature" {
+		t.Fatalf("expected signature image ref to be collected, got %v", refs)
+	}
+}
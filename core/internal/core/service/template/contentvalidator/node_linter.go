@@ -0,0 +1,258 @@
+package contentvalidator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// knownNodeTypes contains every node type the PDF renderer knows how to convert.
+var knownNodeTypes = portabledoc.Set[string]{
+	portabledoc.NodeTypeParagraph:             {},
+	portabledoc.NodeTypeHeading:               {},
+	portabledoc.NodeTypeBlockquote:            {},
+	portabledoc.NodeTypeCodeBlock:             {},
+	portabledoc.NodeTypeHR:                    {},
+	portabledoc.NodeTypeBulletList:            {},
+	portabledoc.NodeTypeOrderedList:           {},
+	portabledoc.NodeTypeTaskList:              {},
+	portabledoc.NodeTypeListItem:              {},
+	portabledoc.NodeTypeTaskItem:              {},
+	portabledoc.NodeTypeInjector:              {},
+	portabledoc.NodeTypeConditional:           {},
+	portabledoc.NodeTypeSignature:             {},
+	portabledoc.NodeTypePageBreak:             {},
+	portabledoc.NodeTypeImage:                 {},
+	portabledoc.NodeTypeCustomImage:           {},
+	portabledoc.NodeTypeText:                  {},
+	portabledoc.NodeTypeHardBreak:             {},
+	portabledoc.NodeTypeListInjector:          {},
+	portabledoc.NodeTypeTableInjector:         {},
+	portabledoc.NodeTypeTable:                 {},
+	portabledoc.NodeTypeTableRow:              {},
+	portabledoc.NodeTypeTableCell:             {},
+	portabledoc.NodeTypeTableHeader:           {},
+	portabledoc.NodeTypeInteractiveField:      {},
+	portabledoc.NodeTypeInsertionPoint:        {},
+	portabledoc.NodeTypeInclude:               {},
+	portabledoc.NodeTypeMath:                  {},
+	portabledoc.NodeTypeMathBlock:             {},
+	portabledoc.NodeTypeCrossReference:        {},
+	portabledoc.NodeTypeDetails:               {},
+	portabledoc.NodeTypeDetailsSummary:        {},
+	portabledoc.NodeTypeDetailsContent:        {},
+	portabledoc.NodeTypeDefinitionList:        {},
+	portabledoc.NodeTypeDefinitionTerm:        {},
+	portabledoc.NodeTypeDefinitionDescription: {},
+}
+
+// LintNodes walks a node tree and reports problems that would make it fail
+// or render incorrectly, without rendering it.
+// Paths use the form "content[0].content[2]" relative to the given slice.
+func (s *Service) LintNodes(ctx context.Context, nodes []portabledoc.Node) *port.ContentValidationResult {
+	vctx := &validationContext{
+		ctx:    ctx,
+		result: port.NewValidationResult(),
+	}
+	lintNodeList(vctx, nodes, "content")
+	return vctx.result
+}
+
+// lintNodeList lints each node of a slice, stopping early when the context is cancelled.
+func lintNodeList(vctx *validationContext, nodes []portabledoc.Node, path string) {
+	for i, node := range nodes {
+		if vctx.checkCancelled() {
+			return
+		}
+		lintNode(vctx, node, fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+// lintNode dispatches per-type checks and recurses into child content.
+func lintNode(vctx *validationContext, node portabledoc.Node, path string) {
+	switch node.Type {
+	case portabledoc.NodeTypeSignature:
+		lintSignatureNode(vctx, node, path)
+	case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+		lintImageNode(vctx, node, path)
+	case portabledoc.NodeTypeConditional:
+		lintConditionalNode(vctx, node, path)
+	case portabledoc.NodeTypeInjector, portabledoc.NodeTypeListInjector, portabledoc.NodeTypeTableInjector:
+		lintInjectorNode(vctx, node, path)
+	case portabledoc.NodeTypeInteractiveField:
+		lintInteractiveFieldNode(vctx, node, path)
+	case "":
+		vctx.addError(ErrCodeMissingNodeType, path+".type", "Node type is required")
+	default:
+		if !knownNodeTypes.Contains(node.Type) {
+			vctx.addWarningf(WarnCodeUnknownNodeType, path+".type",
+				"Unknown node type '%s' will be rendered as its children only", node.Type)
+		}
+	}
+
+	if len(node.Content) > 0 {
+		lintNodeList(vctx, node.Content, path+".content")
+	}
+}
+
+// lintSignatureNode checks that a signature block has items and every item has a role.
+func lintSignatureNode(vctx *validationContext, node portabledoc.Node, path string) {
+	attrs, err := portabledoc.ParseSignatureAttrs(node.Attrs)
+	if err != nil {
+		vctx.addErrorf(ErrCodeInvalidSignatureCount, path+".attrs",
+			"Invalid signature attributes: %s", err.Error())
+		return
+	}
+
+	if len(attrs.Signatures) == 0 {
+		vctx.addError(ErrCodeInvalidSignatureCount, path+".attrs.signatures",
+			"Signature block has no signatures")
+		return
+	}
+
+	for i, sig := range attrs.Signatures {
+		if sig.HasRole() {
+			continue
+		}
+		label := sig.Label
+		if label == "" {
+			label = "(unnamed)"
+		}
+		vctx.addErrorf(ErrCodeMissingSignatureRole, fmt.Sprintf("%s.attrs.signatures[%d].roleId", path, i),
+			"Signature '%s' must have a role assigned", label)
+	}
+
+	if attrs.Count != len(attrs.Signatures) {
+		vctx.addWarningf(WarnCodeNoSignatures, path+".attrs.count",
+			"Signature count (%d) doesn't match actual signatures (%d)", attrs.Count, len(attrs.Signatures))
+	}
+}
+
+// lintImageNode checks that an image has either a source or an injectable binding.
+func lintImageNode(vctx *validationContext, node portabledoc.Node, path string) {
+	src, _ := node.Attrs["src"].(string)
+	injectableID, _ := node.Attrs["injectableId"].(string)
+	if src == "" && injectableID == "" {
+		vctx.addError(ErrCodeMissingImageSource, path+".attrs.src",
+			"Image must have a source or an injectable binding")
+	}
+}
+
+// lintConditionalNode checks that a conditional's rule tree is well-formed.
+// Variable existence is not checked here since the tree is linted without its document.
+func lintConditionalNode(vctx *validationContext, node portabledoc.Node, path string) {
+	raw, ok := node.Attrs["conditions"]
+	if !ok {
+		vctx.addError(ErrCodeInvalidConditionAttrs, path+".attrs.conditions",
+			"Conditional is missing its conditions")
+		return
+	}
+	if _, ok := raw.(map[string]any); !ok {
+		vctx.addError(ErrCodeInvalidConditionAttrs, path+".attrs.conditions",
+			"Conditions must be an object")
+		return
+	}
+
+	group, err := portabledoc.ParseLogicGroup(raw)
+	if err != nil {
+		vctx.addErrorf(ErrCodeInvalidConditionAttrs, path+".attrs.conditions",
+			"Invalid conditional attributes: %s", err.Error())
+		return
+	}
+	lintLogicGroup(vctx, group, path+".attrs.conditions", 0)
+
+	if len(node.Content) == 0 {
+		vctx.addWarning(WarnCodeEmptyConditional, path+".content",
+			"Conditional block has no content")
+	}
+}
+
+// lintLogicGroup checks a logic group and its children recursively.
+func lintLogicGroup(vctx *validationContext, group *portabledoc.LogicGroup, path string, depth int) {
+	if depth > portabledoc.MaxNestingDepth {
+		vctx.addErrorf(ErrCodeMaxNestingExceeded, path,
+			"Condition nesting exceeds maximum depth of %d", portabledoc.MaxNestingDepth)
+		return
+	}
+
+	if !portabledoc.ValidLogicOperators.Contains(group.Logic) {
+		vctx.addErrorf(ErrCodeInvalidLogicOperator, path+".logic",
+			"Logic must be AND or OR, got: %s", group.Logic)
+	}
+
+	for i, child := range group.Children {
+		childPath := fmt.Sprintf("%s.children[%d]", path, i)
+		childMap, ok := child.(map[string]any)
+		if !ok {
+			vctx.addError(ErrCodeInvalidConditionAttrs, childPath, "Child must be a 'rule' or 'group'")
+			continue
+		}
+
+		switch childMap["type"] {
+		case portabledoc.LogicTypeRule:
+			rule, err := portabledoc.ParseLogicRule(childMap)
+			if err != nil {
+				vctx.addErrorf(ErrCodeInvalidConditionAttrs, childPath, "Invalid rule: %s", err.Error())
+				continue
+			}
+			lintLogicRule(vctx, rule, childPath)
+		case portabledoc.LogicTypeGroup:
+			nested, err := portabledoc.ParseLogicGroup(childMap)
+			if err != nil {
+				vctx.addErrorf(ErrCodeInvalidConditionAttrs, childPath, "Invalid group: %s", err.Error())
+				continue
+			}
+			lintLogicGroup(vctx, nested, childPath, depth+1)
+		default:
+			vctx.addError(ErrCodeInvalidConditionAttrs, childPath, "Child must be a 'rule' or 'group'")
+		}
+	}
+}
+
+// lintLogicRule checks a single rule for a variable and a known operator.
+func lintLogicRule(vctx *validationContext, rule *portabledoc.LogicRule, path string) {
+	if rule.VariableID == "" {
+		vctx.addError(ErrCodeInvalidConditionVar, path+".variableId", "Rule variableId is required")
+	}
+	if !portabledoc.ValidOperators.Contains(rule.Operator) {
+		vctx.addErrorf(ErrCodeInvalidOperator, path+".operator", "Invalid operator: %s", rule.Operator)
+	}
+}
+
+// lintInjectorNode checks that injector-like nodes reference a variable.
+func lintInjectorNode(vctx *validationContext, node portabledoc.Node, path string) {
+	variableID, _ := node.Attrs["variableId"].(string)
+	if variableID == "" {
+		vctx.addErrorf(ErrCodeUnknownVariable, path+".attrs.variableId",
+			"%s must reference a variable", node.Type)
+		return
+	}
+
+	if node.Type != portabledoc.NodeTypeInjector {
+		return
+	}
+	if injType, _ := node.Attrs["type"].(string); injType != "" && !portabledoc.ValidInjectorTypes.Contains(injType) {
+		vctx.addErrorf(ErrCodeInvalidInjectorType, path+".attrs.type",
+			"Unknown injector type '%s'", injType)
+	}
+}
+
+// lintInteractiveFieldNode checks the minimum attributes needed to render an interactive field.
+func lintInteractiveFieldNode(vctx *validationContext, node portabledoc.Node, path string) {
+	attrs, err := portabledoc.ParseInteractiveFieldAttrs(node.Attrs)
+	if err != nil {
+		vctx.addErrorf(ErrCodeInvalidInteractiveAttrs, path+".attrs",
+			"Invalid interactive field attributes: %s", err.Error())
+		return
+	}
+	if !portabledoc.ValidInteractiveFieldTypes.Contains(attrs.FieldType) {
+		vctx.addErrorf(ErrCodeInvalidInteractiveType, path+".attrs.fieldType",
+			"Invalid interactive field type: %s", attrs.FieldType)
+	}
+	if attrs.RoleID == "" {
+		vctx.addError(ErrCodeEmptyInteractiveRoleID, path+".attrs.roleId",
+			"Interactive field must have a role assigned")
+	}
+}
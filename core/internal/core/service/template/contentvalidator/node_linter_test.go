@@ -0,0 +1,246 @@
+package contentvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func lint(nodes ...portabledoc.Node) *port.ContentValidationResult {
+	return (&Service{}).LintNodes(context.Background(), nodes)
+}
+
+func hasError(result *port.ContentValidationResult, code, path string) bool {
+	for _, e := range result.Errors {
+		if e.Code == code && e.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasWarning(result *port.ContentValidationResult, code, path string) bool {
+	for _, w := range result.Warnings {
+		if w.Code == code && w.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func validRule() map[string]any {
+	return map[string]any{
+		"id":         "r1",
+		"type":       portabledoc.LogicTypeRule,
+		"variableId": "client_name",
+		"operator":   portabledoc.OpNotEmpty,
+		"value":      map[string]any{"mode": portabledoc.RuleModeText, "value": ""},
+	}
+}
+
+func conditionalNode(conditions any, content ...portabledoc.Node) portabledoc.Node {
+	return portabledoc.Node{
+		Type:    portabledoc.NodeTypeConditional,
+		Attrs:   map[string]any{"conditions": conditions},
+		Content: content,
+	}
+}
+
+func TestLintNodes_ValidTree(t *testing.T) {
+	text := "hello"
+	result := lint(
+		portabledoc.Node{
+			Type:    portabledoc.NodeTypeParagraph,
+			Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: &text}},
+		},
+		conditionalNode(
+			map[string]any{"id": "g1", "type": "group", "logic": "AND", "children": []any{validRule()}},
+			portabledoc.Node{Type: portabledoc.NodeTypeParagraph},
+		),
+		portabledoc.Node{
+			Type: portabledoc.NodeTypeSignature,
+			Attrs: map[string]any{
+				"count":      1,
+				"layout":     portabledoc.LayoutSingleCenter,
+				"signatures": []any{map[string]any{"id": "s1", "roleId": "role-1", "label": "Client"}},
+			},
+		},
+		portabledoc.Node{Type: portabledoc.NodeTypeImage, Attrs: map[string]any{"src": "https://example.com/a.png"}},
+	)
+
+	if !result.Valid {
+		t.Fatalf("expected valid result, got errors: %+v", result.Errors)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %+v", result.Warnings)
+	}
+}
+
+func TestLintNodes_SignatureWithoutRole(t *testing.T) {
+	result := lint(portabledoc.Node{
+		Type: portabledoc.NodeTypeSignature,
+		Attrs: map[string]any{
+			"count":      1,
+			"layout":     portabledoc.LayoutSingleCenter,
+			"signatures": []any{map[string]any{"id": "s1", "label": "Client"}},
+		},
+	})
+
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	if !hasError(result, ErrCodeMissingSignatureRole, "content[0].attrs.signatures[0].roleId") {
+		t.Errorf("expected missing role error, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_SignatureWithoutItems(t *testing.T) {
+	result := lint(portabledoc.Node{
+		Type:  portabledoc.NodeTypeSignature,
+		Attrs: map[string]any{"count": 1, "signatures": []any{}},
+	})
+
+	if !hasError(result, ErrCodeInvalidSignatureCount, "content[0].attrs.signatures") {
+		t.Errorf("expected empty signatures error, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_ImageWithoutSource(t *testing.T) {
+	result := lint(
+		portabledoc.Node{Type: portabledoc.NodeTypeImage, Attrs: map[string]any{}},
+		portabledoc.Node{Type: portabledoc.NodeTypeCustomImage, Attrs: map[string]any{"injectableId": "logo"}},
+	)
+
+	if !hasError(result, ErrCodeMissingImageSource, "content[0].attrs.src") {
+		t.Errorf("expected missing source error, got %+v", result.Errors)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("expected image bound to an injectable to pass, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_MalformedConditional(t *testing.T) {
+	tests := []struct {
+		name string
+		node portabledoc.Node
+		code string
+		path string
+	}{
+		{
+			name: "missing conditions",
+			node: portabledoc.Node{Type: portabledoc.NodeTypeConditional, Attrs: map[string]any{}},
+			code: ErrCodeInvalidConditionAttrs,
+			path: "content[0].attrs.conditions",
+		},
+		{
+			name: "conditions not an object",
+			node: conditionalNode("a == b"),
+			code: ErrCodeInvalidConditionAttrs,
+			path: "content[0].attrs.conditions",
+		},
+		{
+			name: "invalid logic",
+			node: conditionalNode(map[string]any{"type": "group", "logic": "XOR", "children": []any{validRule()}}),
+			code: ErrCodeInvalidLogicOperator,
+			path: "content[0].attrs.conditions.logic",
+		},
+		{
+			name: "rule without variable",
+			node: conditionalNode(map[string]any{"type": "group", "logic": "AND", "children": []any{
+				map[string]any{"type": "rule", "operator": portabledoc.OpEqual},
+			}}),
+			code: ErrCodeInvalidConditionVar,
+			path: "content[0].attrs.conditions.children[0].variableId",
+		},
+		{
+			name: "rule with unknown operator",
+			node: conditionalNode(map[string]any{"type": "group", "logic": "AND", "children": []any{
+				map[string]any{"type": "rule", "variableId": "x", "operator": "approx"},
+			}}),
+			code: ErrCodeInvalidOperator,
+			path: "content[0].attrs.conditions.children[0].operator",
+		},
+		{
+			name: "child of unknown kind",
+			node: conditionalNode(map[string]any{"type": "group", "logic": "OR", "children": []any{"rule"}}),
+			code: ErrCodeInvalidConditionAttrs,
+			path: "content[0].attrs.conditions.children[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := lint(tt.node)
+			if !hasError(result, tt.code, tt.path) {
+				t.Errorf("expected %s at %s, got %+v", tt.code, tt.path, result.Errors)
+			}
+		})
+	}
+}
+
+func TestLintNodes_EmptyConditionalWarns(t *testing.T) {
+	result := lint(conditionalNode(
+		map[string]any{"type": "group", "logic": "AND", "children": []any{validRule()}},
+	))
+
+	if !result.Valid {
+		t.Fatalf("expected valid result, got errors: %+v", result.Errors)
+	}
+	if !hasWarning(result, WarnCodeEmptyConditional, "content[0].content") {
+		t.Errorf("expected empty conditional warning, got %+v", result.Warnings)
+	}
+}
+
+func TestLintNodes_InjectorWithoutVariable(t *testing.T) {
+	result := lint(
+		portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"type": "TEXT"}},
+		portabledoc.Node{Type: portabledoc.NodeTypeTableInjector, Attrs: map[string]any{}},
+	)
+
+	if !hasError(result, ErrCodeUnknownVariable, "content[0].attrs.variableId") {
+		t.Errorf("expected injector error, got %+v", result.Errors)
+	}
+	if !hasError(result, ErrCodeUnknownVariable, "content[1].attrs.variableId") {
+		t.Errorf("expected table injector error, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_InteractiveFieldWithoutRole(t *testing.T) {
+	result := lint(portabledoc.Node{
+		Type:  portabledoc.NodeTypeInteractiveField,
+		Attrs: map[string]any{"id": "f1", "fieldType": "text", "label": "Name"},
+	})
+
+	if !hasError(result, ErrCodeEmptyInteractiveRoleID, "content[0].attrs.roleId") {
+		t.Errorf("expected missing role error, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_UnknownAndMissingTypes(t *testing.T) {
+	result := lint(portabledoc.Node{
+		Type: portabledoc.NodeTypeBlockquote,
+		Content: []portabledoc.Node{
+			{Type: "mysteryWidget"},
+			{},
+		},
+	})
+
+	if !hasWarning(result, WarnCodeUnknownNodeType, "content[0].content[0].type") {
+		t.Errorf("expected unknown type warning, got %+v", result.Warnings)
+	}
+	if !hasError(result, ErrCodeMissingNodeType, "content[0].content[1].type") {
+		t.Errorf("expected missing type error, got %+v", result.Errors)
+	}
+}
+
+func TestLintNodes_StopsOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := (&Service{}).LintNodes(ctx, []portabledoc.Node{{Type: portabledoc.NodeTypeImage}})
+	if !hasError(result, ErrCodeValidationCancelled, "") {
+		t.Errorf("expected cancellation error, got %+v", result.Errors)
+	}
+}
@@ -132,6 +132,7 @@ func (s *Service) validatePublish(
 		s.validateSignatures,
 		s.validateInteractiveFields,
 		s.validateConditionals,
+		s.validateCrossReferences,
 		s.validateWorkflow,
 	}
 	for _, validate := range validators {
@@ -222,12 +222,19 @@ func extractInjectables(vctx *validationContext) []*entity.TemplateVersionInject
 			continue
 		}
 
-		inj, exists := injectableMap[varID]
-		if !exists {
+		// A path such as "items[0].name" stores the injectable it reads from
+		key, inj := injectableForPath(injectableMap, varID)
+		if inj == nil {
 			continue
 		}
+		if key != varID {
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+		}
 
-		injectables = append(injectables, buildInjectable(vctx.versionID, varID, inj))
+		injectables = append(injectables, buildInjectable(vctx.versionID, key, inj))
 	}
 	return injectables
 }
@@ -256,23 +263,49 @@ func collectInjectableRefsFromImages(doc *portabledoc.Document) []string {
 
 	refs := make([]string, 0)
 	for node := range doc.AllNodes() {
-		if node.Type != portabledoc.NodeTypeImage && node.Type != portabledoc.NodeTypeCustomImage {
-			continue
-		}
-
-		injectableID, _ := node.Attrs["injectableId"].(string)
-		if injectableID != "" {
-			refs = append(refs, injectableID)
+		switch node.Type {
+		case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+			injectableID, _ := node.Attrs["injectableId"].(string)
+			if injectableID != "" {
+				refs = append(refs, injectableID)
+			}
+		case portabledoc.NodeTypeSignature:
+			attrs, err := portabledoc.ParseSignatureAttrs(node.Attrs)
+			if err != nil {
+				continue
+			}
+			for _, sig := range attrs.Signatures {
+				if sig.HasInjectableImage() {
+					refs = append(refs, *sig.InjectableID)
+				}
+			}
 		}
 	}
 
 	if doc.Header != nil && doc.Header.ImageInjectableID != nil && *doc.Header.ImageInjectableID != "" {
 		refs = append(refs, *doc.Header.ImageInjectableID)
 	}
+	if doc.CoverPage.HasImageInjectable() {
+		refs = append(refs, *doc.CoverPage.ImageInjectableID)
+	}
 
 	return refs
 }
 
+// injectableForPath returns the definition a variable reads and its key: the variable's
+// own definition, or for a path the definition of the injectable it reads from.
+func injectableForPath(injectableMap map[string]*entity.InjectableDefinition, varID string) (string, *entity.InjectableDefinition) {
+	if inj, ok := injectableMap[varID]; ok {
+		return varID, inj
+	}
+	for _, key := range entity.InjectablePathKeys(varID) {
+		if inj, ok := injectableMap[key]; ok {
+			return key, inj
+		}
+	}
+	return "", nil
+}
+
 // buildInjectableMap creates a key -> definition lookup map.
 func buildInjectableMap(list []*entity.InjectableDefinition) map[string]*entity.InjectableDefinition {
 	m := make(map[string]*entity.InjectableDefinition, len(list))
@@ -9,7 +9,7 @@ import (
 // versionRegex validates semantic version format.
 var versionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
 
-// validateStructure validates document structure (version, meta).
+// validateStructure validates document structure (version, meta, heading numbering).
 func (s *Service) validateStructure(vctx *validationContext) {
 	doc := vctx.doc
 
@@ -23,6 +23,12 @@ func (s *Service) validateStructure(vctx *validationContext) {
 
 	// Validate meta
 	validateMeta(vctx)
+
+	// Validate heading numbering pattern
+	if hn := doc.HeadingNumbering; hn != nil && !portabledoc.IsValidHeadingNumberingPattern(hn.ResolvePattern()) {
+		vctx.addErrorf(ErrCodeInvalidHeadingNumbering, "headingNumbering.pattern",
+			"Heading numbering pattern must contain a counting symbol (1, a, A, i or I), got: %s", hn.Pattern)
+	}
 }
 
 // validateMeta validates document metadata.
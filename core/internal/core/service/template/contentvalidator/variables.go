@@ -2,8 +2,11 @@ package contentvalidator
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 )
 
@@ -15,8 +18,17 @@ func (s *Service) validateVariables(vctx *validationContext) {
 	// Validate injector nodes in content
 	validateInjectorNodes(vctx)
 
-	// Validate image injector bindings in content and header
+	// Validate image injector bindings in content, header and cover page
 	validateImageBindings(vctx)
+
+	// Validate watermark text binding
+	validateWatermarkBinding(vctx)
+
+	// Validate document property bindings
+	validatePropertyBindings(vctx)
+
+	// Validate cover page text bindings
+	validateCoverPageBindings(vctx)
 }
 
 // validateDeclaredVariables validates that all declared variableIds are accessible.
@@ -29,13 +41,14 @@ func validateDeclaredVariables(vctx *validationContext) {
 	for i, varID := range vctx.doc.VariableIDs {
 		path := fmt.Sprintf("variableIds[%d]", i)
 
-		// Skip role variables (they're generated, not from backend)
-		if strings.HasPrefix(varID, portabledoc.RoleVariablePrefix) {
+		// Skip role variables (they're generated, not from backend) and the
+		// built-in injectables every render resolves.
+		if strings.HasPrefix(varID, portabledoc.RoleVariablePrefix) || entity.IsBuiltinInjectable(varID) {
 			continue
 		}
 
 		// Check if variable is accessible to workspace
-		if !vctx.accessibleInjectables.Contains(varID) {
+		if _, ok := pathKeyIn(vctx.accessibleInjectables, varID); !ok {
 			vctx.addErrorf(ErrCodeInaccessibleVariable, path,
 				"Variable '%s' is not accessible to this workspace", varID)
 		}
@@ -64,9 +77,55 @@ func validateImageBindings(vctx *validationContext) {
 		validateImageBinding(vctx, node.Attrs, fmt.Sprintf("content.customImage[%d].attrs.injectableId", i))
 	}
 
+	for i, node := range doc.NodesOfType(portabledoc.NodeTypeSignature) {
+		attrs, err := portabledoc.ParseSignatureAttrs(node.Attrs)
+		if err != nil {
+			continue
+		}
+		for j, sig := range attrs.Signatures {
+			if sig.HasInjectableImage() {
+				validateVariableReference(vctx, *sig.InjectableID,
+					fmt.Sprintf("content.signature[%d].attrs.signatures[%d].injectableId", i, j), true)
+			}
+		}
+	}
+
 	if doc.Header != nil && doc.Header.ImageInjectableID != nil && *doc.Header.ImageInjectableID != "" {
 		validateVariableReference(vctx, *doc.Header.ImageInjectableID, "header.imageInjectableId", true)
 	}
+
+	if doc.CoverPage.HasImageInjectable() {
+		validateVariableReference(vctx, *doc.CoverPage.ImageInjectableID, "coverPage.imageInjectableId", true)
+	}
+}
+
+// validatePropertyBindings validates the variables referenced by PDF document properties.
+func validatePropertyBindings(vctx *validationContext) {
+	refs := vctx.doc.Meta.Properties.InjectableRefs()
+	for _, path := range slices.Sorted(maps.Keys(refs)) {
+		for _, varID := range refs[path] {
+			validateVariableReference(vctx, varID, "meta.properties."+path, !strings.HasPrefix(varID, portabledoc.RoleVariablePrefix))
+		}
+	}
+}
+
+// validateCoverPageBindings validates the variables referenced by the cover page text fields.
+func validateCoverPageBindings(vctx *validationContext) {
+	refs := vctx.doc.CoverPage.InjectableRefs()
+	for _, path := range slices.Sorted(maps.Keys(refs)) {
+		for _, varID := range refs[path] {
+			validateVariableReference(vctx, varID, "coverPage."+path, !strings.HasPrefix(varID, portabledoc.RoleVariablePrefix))
+		}
+	}
+}
+
+// validateWatermarkBinding validates the variable the watermark text is bound to.
+func validateWatermarkBinding(vctx *validationContext) {
+	if !vctx.doc.Watermark.HasInjectable() {
+		return
+	}
+	varID := *vctx.doc.Watermark.InjectableID
+	validateVariableReference(vctx, varID, "watermark.injectableId", !strings.HasPrefix(varID, portabledoc.RoleVariablePrefix))
 }
 
 func validateImageBinding(vctx *validationContext, attrs map[string]any, path string) {
@@ -83,18 +142,33 @@ func validateImageBinding(vctx *validationContext, attrs map[string]any, path st
 }
 
 func validateVariableReference(vctx *validationContext, variableID, path string, requireAccess bool) {
-	if !vctx.variableSet.Contains(variableID) {
+	if _, ok := pathKeyIn(vctx.variableSet, variableID); !ok {
 		vctx.addErrorf(ErrCodeUnknownVariable, path,
 			"Variable '%s' not found in document variableIds", variableID)
 		return
 	}
 
-	if requireAccess && vctx.accessibleInjectables.Len() > 0 && !vctx.accessibleInjectables.Contains(variableID) {
+	if _, accessible := pathKeyIn(vctx.accessibleInjectables, variableID); requireAccess && vctx.accessibleInjectables.Len() > 0 &&
+		!accessible && !entity.IsBuiltinInjectable(variableID) {
 		vctx.addErrorf(ErrCodeInaccessibleVariable, path,
 			"Variable '%s' is not accessible to this workspace", variableID)
 	}
 }
 
+// pathKeyIn returns the key in set a variable reads: the variable itself, or for a path
+// such as "items[0].name" the injectable it reads from ("items").
+func pathKeyIn(set portabledoc.Set[string], variableID string) (string, bool) {
+	if set.Contains(variableID) {
+		return variableID, true
+	}
+	for _, key := range entity.InjectablePathKeys(variableID) {
+		if set.Contains(key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
 // validateInjectorNode validates a single injector node.
 func validateInjectorNode(vctx *validationContext, node portabledoc.Node, path string) {
 	attrs, err := portabledoc.ParseInjectorAttrs(node.Attrs)
@@ -125,6 +199,9 @@ func validateInjectorNode(vctx *validationContext, node portabledoc.Node, path s
 
 	// Regular variable: must be in variableIds and in variableSet
 	validateVariableReference(vctx, attrs.VariableID, path+".attrs.variableId", false)
+	for i, fallbackID := range attrs.FallbackVariableIDs {
+		validateVariableReference(vctx, fallbackID, fmt.Sprintf("%s.attrs.fallbackVariableIds[%d]", path, i), false)
+	}
 }
 
 // validateRoleVariable validates a role variable (ROLE.{label}.{property}).
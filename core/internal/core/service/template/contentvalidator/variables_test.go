@@ -0,0 +1,171 @@
+package contentvalidator
+
+import (
+	"testing"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+func TestValidateVariables_FlagsWatermarkRefMissingFromVariableIDs(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			Content: &portabledoc.ProseMirrorDoc{Type: "doc"},
+			Watermark: &portabledoc.Watermark{
+				Text:         "CONFIDENTIAL",
+				InjectableID: ptrTo("recipient_name"),
+			},
+		},
+		result:                result,
+		variableSet:           make(portabledoc.Set[string]),
+		accessibleInjectables: portabledoc.NewSet([]string{"recipient_name"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 1 || result.Errors[0].Path != "watermark.injectableId" {
+		t.Fatalf("expected watermark binding error, got %#v", result.Errors)
+	}
+}
+
+func TestValidateVariables_FlagsPropertyRefsMissingFromVariableIDs(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			Meta: portabledoc.Meta{Properties: &portabledoc.DocumentProperties{
+				Author:   &portabledoc.FieldValue{Type: portabledoc.FieldTypeInjectable, Value: "company_name"},
+				Keywords: []portabledoc.FieldValue{{Type: portabledoc.FieldTypeInjectable, Value: "contract_id"}},
+			}},
+			Content: &portabledoc.ProseMirrorDoc{Type: "doc"},
+		},
+		result:                result,
+		variableSet:           portabledoc.NewSet([]string{"company_name"}),
+		accessibleInjectables: portabledoc.NewSet([]string{"company_name", "contract_id"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 1 || result.Errors[0].Path != "meta.properties.keywords[0]" {
+		t.Fatalf("expected keyword binding error, got %#v", result.Errors)
+	}
+}
+
+func TestValidateVariables_AllowsRenderTimeInjectables(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			VariableIDs: []string{entity.RenderTodayInjectable, entity.RenderNowInjectable},
+			Content: &portabledoc.ProseMirrorDoc{
+				Type: "doc",
+				Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": entity.RenderTodayInjectable, "type": portabledoc.InjectorTypeDate}},
+				},
+			},
+		},
+		result:                result,
+		variableSet:           portabledoc.NewSet([]string{entity.RenderTodayInjectable, entity.RenderNowInjectable}),
+		accessibleInjectables: portabledoc.NewSet([]string{"customer_name"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 0 {
+		t.Fatalf("expected render time injectables to need no workspace access, got %#v", result.Errors)
+	}
+}
+
+func TestValidateVariables_AllowsWorkspaceLogo(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			VariableIDs: []string{entity.WorkspaceLogoInjectable},
+			Content: &portabledoc.ProseMirrorDoc{
+				Type: "doc",
+				Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeImage, Attrs: map[string]any{"injectableId": entity.WorkspaceLogoInjectable}},
+				},
+			},
+		},
+		result:                result,
+		variableSet:           portabledoc.NewSet([]string{entity.WorkspaceLogoInjectable}),
+		accessibleInjectables: portabledoc.NewSet([]string{"customer_name"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 0 {
+		t.Fatalf("expected an image bound to the workspace logo to need no workspace access, got %#v", result.Errors)
+	}
+}
+
+func TestValidateVariables_AcceptsInjectablePaths(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		versionID: "version-1",
+		doc: &portabledoc.Document{
+			VariableIDs: []string{"items", "crm.items[0].qty"},
+			Content: &portabledoc.ProseMirrorDoc{
+				Type: "doc",
+				Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "items[0].name", "type": portabledoc.InjectorTypeText}},
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "items[1].name", "type": portabledoc.InjectorTypeText}},
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "crm.items[0].qty", "type": portabledoc.InjectorTypeText}},
+				},
+			},
+		},
+		result:                result,
+		variableSet:           portabledoc.NewSet([]string{"items", "crm.items[0].qty"}),
+		accessibleInjectables: portabledoc.NewSet([]string{"items", "crm.items"}),
+		accessibleInjectableList: []*entity.InjectableDefinition{
+			{ID: "items", Key: "items", SourceType: entity.InjectableSourceTypeExternal},
+			{ID: "crm.items", Key: "crm.items", SourceType: entity.InjectableSourceTypeExternal},
+		},
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 0 {
+		t.Fatalf("expected paths into accessible injectables to validate, got %#v", result.Errors)
+	}
+
+	keys := make([]string, 0, 2)
+	for _, injectable := range extractInjectables(vctx) {
+		keys = append(keys, *injectable.SystemInjectableKey)
+	}
+	if len(keys) != 2 || keys[0] != "items" || keys[1] != "crm.items" {
+		t.Fatalf("expected the version to store the injectables the paths read, got %v", keys)
+	}
+}
+
+func TestValidateVariables_FlagsPathsIntoInaccessibleInjectables(t *testing.T) {
+	service := &Service{}
+	result := port.NewValidationResult()
+
+	vctx := &validationContext{
+		doc: &portabledoc.Document{
+			VariableIDs: []string{"orders[0].total"},
+			Content:     &portabledoc.ProseMirrorDoc{Type: "doc"},
+		},
+		result:                result,
+		variableSet:           portabledoc.NewSet([]string{"orders[0].total"}),
+		accessibleInjectables: portabledoc.NewSet([]string{"items"}),
+	}
+
+	service.validateVariables(vctx)
+
+	if result.ErrorCount() != 1 || result.Errors[0].Code != ErrCodeInaccessibleVariable {
+		t.Fatalf("expected an inaccessible variable error, got %#v", result.Errors)
+	}
+}
@@ -0,0 +1,128 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+)
+
+// ListInjectableFields returns the injectables referenced in a version's content.
+func (s *TemplateVersionService) ListInjectableFields(ctx context.Context, versionID string) ([]*entity.VersionInjectableField, error) {
+	details, err := s.versionRepo.FindByIDWithDetails(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version details %s: %w", versionID, err)
+	}
+
+	doc, err := portabledoc.Parse(details.ContentStructure)
+	if err != nil {
+		return nil, fmt.Errorf("parsing content: %w", entity.ErrInvalidContentStructure)
+	}
+	if doc == nil {
+		return []*entity.VersionInjectableField{}, nil
+	}
+
+	return collectInjectableFields(doc, details.Injectables), nil
+}
+
+// collectInjectableFields walks the document depth-first and reports each referenced
+// injectable once, in the order it first appears. Node attributes take precedence
+// over the version configuration, which in turn overrides the definition.
+func collectInjectableFields(
+	doc *portabledoc.Document,
+	versionInjectables []*entity.VersionInjectableWithDefinition,
+) []*entity.VersionInjectableField {
+	configs := make(map[string]*entity.VersionInjectableWithDefinition, len(versionInjectables))
+	for _, vi := range versionInjectables {
+		if key := versionInjectableKey(vi); key != "" {
+			configs[key] = vi
+		}
+	}
+
+	fields := make([]*entity.VersionInjectableField, 0)
+	seen := make(portabledoc.Set[string])
+	for node := range doc.AllNodesRecursive() {
+		field := injectableFieldFromNode(node)
+		if field == nil || seen.Contains(field.Key) {
+			continue
+		}
+		seen.Add(field.Key)
+		applyVersionInjectableConfig(field, configs[field.Key])
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// injectableFieldFromNode builds the node-level metadata for injector-like nodes.
+func injectableFieldFromNode(node portabledoc.Node) *entity.VersionInjectableField {
+	variableID, _ := node.Attrs["variableId"].(string)
+	if variableID == "" {
+		return nil
+	}
+	label, _ := node.Attrs["label"].(string)
+
+	switch node.Type {
+	case portabledoc.NodeTypeListInjector:
+		return &entity.VersionInjectableField{Key: variableID, Type: string(entity.InjectableDataTypeList), Label: label}
+	case portabledoc.NodeTypeTableInjector:
+		return &entity.VersionInjectableField{Key: variableID, Type: string(entity.InjectableDataTypeTable), Label: label}
+	case portabledoc.NodeTypeInjector:
+	default:
+		return nil
+	}
+
+	attrs, err := portabledoc.ParseInjectorAttrs(node.Attrs)
+	if err != nil {
+		return nil
+	}
+	field := &entity.VersionInjectableField{
+		Key:      variableID,
+		Type:     attrs.Type,
+		Label:    attrs.Label,
+		Required: attrs.Required != nil && *attrs.Required,
+	}
+	if def, _ := node.Attrs["defaultValue"].(string); def != "" {
+		field.DefaultValue = &def
+	}
+	if attrs.IsRoleVar() {
+		field.RoleID = attrs.RoleID
+		field.PropertyKey = attrs.PropertyKey
+	}
+	return field
+}
+
+// applyVersionInjectableConfig fills gaps in the node metadata from the version configuration.
+func applyVersionInjectableConfig(field *entity.VersionInjectableField, vi *entity.VersionInjectableWithDefinition) {
+	if vi == nil {
+		return
+	}
+	field.Required = field.Required || vi.IsRequired
+
+	if field.DefaultValue == nil {
+		switch {
+		case vi.DefaultValue != nil && *vi.DefaultValue != "":
+			field.DefaultValue = vi.DefaultValue
+		case vi.Definition != nil && vi.Definition.DefaultValue != nil && *vi.Definition.DefaultValue != "":
+			field.DefaultValue = vi.Definition.DefaultValue
+		}
+	}
+
+	if vi.Definition == nil {
+		return
+	}
+	if field.Label == "" {
+		field.Label = vi.Definition.Label
+	}
+	if field.Type == "" {
+		field.Type = string(vi.Definition.DataType)
+	}
+}
+
+// versionInjectableKey returns the variable key a version injectable is bound to.
+func versionInjectableKey(vi *entity.VersionInjectableWithDefinition) string {
+	if vi.Definition != nil {
+		return vi.Definition.Key
+	}
+	return vi.GetKey()
+}
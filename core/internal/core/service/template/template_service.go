@@ -2,6 +2,7 @@ package template
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -18,22 +19,31 @@ func NewTemplateService(
 	templateRepo port.TemplateRepository,
 	versionRepo port.TemplateVersionRepository,
 	tagRepo port.TemplateTagRepository,
+	workspaceTagRepo port.TagRepository,
+	versionInjectableRepo port.TemplateVersionInjectableRepository,
+	injectableRepo port.InjectableRepository,
 	processResolver port.ProcessResolver,
 ) templateuc.TemplateUseCase {
 	return &TemplateService{
-		templateRepo:    templateRepo,
-		versionRepo:     versionRepo,
-		tagRepo:         tagRepo,
-		processResolver: processResolver,
+		templateRepo:          templateRepo,
+		versionRepo:           versionRepo,
+		tagRepo:               tagRepo,
+		workspaceTagRepo:      workspaceTagRepo,
+		versionInjectableRepo: versionInjectableRepo,
+		injectableRepo:        injectableRepo,
+		processResolver:       processResolver,
 	}
 }
 
 // TemplateService implements template business logic.
 type TemplateService struct {
-	templateRepo    port.TemplateRepository
-	versionRepo     port.TemplateVersionRepository
-	tagRepo         port.TemplateTagRepository
-	processResolver port.ProcessResolver
+	templateRepo          port.TemplateRepository
+	versionRepo           port.TemplateVersionRepository
+	tagRepo               port.TemplateTagRepository
+	workspaceTagRepo      port.TagRepository
+	versionInjectableRepo port.TemplateVersionInjectableRepository
+	injectableRepo        port.InjectableRepository
+	processResolver       port.ProcessResolver
 }
 
 // CreateTemplate creates a new template with an initial draft version.
@@ -151,9 +161,9 @@ func (s *TemplateService) ListTemplatesByFolder(ctx context.Context, folderID st
 	return templates, nil
 }
 
-// ListPublicLibrary lists all public library templates.
-func (s *TemplateService) ListPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error) {
-	templates, err := s.templateRepo.FindPublicLibrary(ctx, workspaceID)
+// ListPublicLibrary lists the published public library templates visible from a workspace.
+func (s *TemplateService) ListPublicLibrary(ctx context.Context, workspaceID string, filters port.TemplateFilters) ([]*entity.TemplateListItem, error) {
+	templates, err := s.templateRepo.FindPublicLibrary(ctx, workspaceID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("listing public library: %w", err)
 	}
@@ -218,7 +228,7 @@ func (s *TemplateService) CloneTemplate(ctx context.Context, cmd templateuc.Clon
 		return nil, nil, err
 	}
 
-	newTemplate, err := s.createClonedTemplate(ctx, source, cmd.NewTitle, cmd.TargetFolderID)
+	newTemplate, err := s.createClonedTemplate(ctx, source.WorkspaceID, cmd.NewTitle, cmd.TargetFolderID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -264,8 +274,8 @@ func (s *TemplateService) validateCloneSource(ctx context.Context, templateID, v
 	return source, sourceVersion, nil
 }
 
-func (s *TemplateService) createClonedTemplate(ctx context.Context, source *entity.TemplateWithDetails, newTitle string, targetFolderID *string) (*entity.Template, error) {
-	exists, err := s.templateRepo.ExistsByTitle(ctx, source.WorkspaceID, newTitle)
+func (s *TemplateService) createClonedTemplate(ctx context.Context, workspaceID, newTitle string, targetFolderID *string) (*entity.Template, error) {
+	exists, err := s.templateRepo.ExistsByTitle(ctx, workspaceID, newTitle)
 	if err != nil {
 		return nil, fmt.Errorf("checking template title: %w", err)
 	}
@@ -275,7 +285,7 @@ func (s *TemplateService) createClonedTemplate(ctx context.Context, source *enti
 
 	newTemplate := &entity.Template{
 		ID:              uuid.NewString(),
-		WorkspaceID:     source.WorkspaceID,
+		WorkspaceID:     workspaceID,
 		FolderID:        targetFolderID,
 		Title:           newTitle,
 		IsPublicLibrary: false,
@@ -304,6 +314,175 @@ func (s *TemplateService) cloneTags(ctx context.Context, newTemplateID string, t
 	}
 }
 
+// CloneFromLibrary copies the published version of a public library template into the target workspace.
+// Tags are matched by name and workspace injectables by key, creating the missing ones in the target
+// workspace. System and provider keys get (inactive) definitions backed before being referenced (FK).
+// Everything the clone writes is persisted in one transaction.
+func (s *TemplateService) CloneFromLibrary(ctx context.Context, cmd templateuc.CloneFromLibraryCommand) (*entity.Template, *entity.TemplateVersion, error) {
+	inLibrary, err := s.templateRepo.ExistsInPublicLibrary(ctx, cmd.TargetWorkspaceID, cmd.SourceTemplateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking public library: %w", err)
+	}
+	if !inLibrary {
+		return nil, nil, entity.ErrTemplateNotFound
+	}
+
+	source, err := s.templateRepo.FindByIDWithDetails(ctx, cmd.SourceTemplateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding source template: %w", err)
+	}
+	sourceVersion, err := s.versionRepo.FindPublishedByTemplateID(ctx, cmd.SourceTemplateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding published version: %w", err)
+	}
+	sourceInjectables, err := s.versionInjectableRepo.FindByVersionID(ctx, sourceVersion.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding source injectables: %w", err)
+	}
+
+	exists, err := s.templateRepo.ExistsByTitle(ctx, cmd.TargetWorkspaceID, cmd.NewTitle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking template title: %w", err)
+	}
+	if exists {
+		return nil, nil, entity.ErrTemplateAlreadyExists
+	}
+
+	version := entity.NewTemplateVersion("", 1, "Initial Version", &cmd.ClonedBy)
+	version.ContentStructure = sourceVersion.ContentStructure
+	clone := &entity.TemplateClone{
+		Template: &entity.Template{
+			WorkspaceID:     cmd.TargetWorkspaceID,
+			FolderID:        cmd.TargetFolderID,
+			Title:           cmd.NewTitle,
+			IsPublicLibrary: false,
+			Process:         entity.DefaultProcess,
+			ProcessType:     entity.DefaultProcessType,
+			CreatedAt:       time.Now().UTC(),
+		},
+		Version: version,
+	}
+	if err := s.cloneInjectables(ctx, clone, cmd.TargetWorkspaceID, sourceInjectables); err != nil {
+		return nil, nil, err
+	}
+	if err := s.remapTags(ctx, clone, cmd.TargetWorkspaceID, source.Tags); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.templateRepo.CreateClone(ctx, clone); err != nil {
+		return nil, nil, fmt.Errorf("cloning template: %w", err)
+	}
+
+	slog.InfoContext(ctx, "template cloned from library",
+		slog.String("source_id", cmd.SourceTemplateID),
+		slog.String("source_version_id", sourceVersion.ID),
+		slog.String("source_workspace_id", source.WorkspaceID),
+		slog.String("target_workspace_id", cmd.TargetWorkspaceID),
+		slog.String("new_id", clone.Template.ID),
+		slog.String("version_id", clone.Version.ID),
+	)
+
+	return clone.Template, clone.Version, nil
+}
+
+// cloneInjectables adds the source version injectables to the clone, resolved against the
+// target workspace.
+func (s *TemplateService) cloneInjectables(
+	ctx context.Context,
+	clone *entity.TemplateClone,
+	targetWorkspaceID string,
+	sources []*entity.VersionInjectableWithDefinition,
+) error {
+	copies := make(map[string]string) // source definition key -> copied definition ID
+	for _, src := range sources {
+		injectable := &entity.TemplateVersionInjectable{
+			SystemInjectableKey: src.SystemInjectableKey,
+			IsRequired:          src.IsRequired,
+			DefaultValue:        src.DefaultValue,
+			CreatedAt:           time.Now().UTC(),
+		}
+		switch {
+		case src.IsSystemInjectable():
+			clone.SystemKeys = append(clone.SystemKeys, *src.SystemInjectableKey)
+		case src.Definition == nil:
+			continue
+		default:
+			defID, ok := copies[src.Definition.Key]
+			if !ok {
+				var copied *entity.InjectableDefinition
+				var err error
+				defID, copied, err = s.resolveTargetDefinition(ctx, targetWorkspaceID, src.Definition)
+				if err != nil {
+					return err
+				}
+				if copied != nil {
+					clone.Definitions = append(clone.Definitions, copied)
+					copies[src.Definition.Key] = defID
+				}
+			}
+			injectable.InjectableDefinitionID = &defID
+		}
+		clone.Injectables = append(clone.Injectables, injectable)
+	}
+	return nil
+}
+
+// resolveTargetDefinition returns the ID of the definition with the same key visible from the
+// target workspace (own or global). When none exists it returns a copy of the source definition
+// for the target workspace, to be created with the clone.
+func (s *TemplateService) resolveTargetDefinition(
+	ctx context.Context,
+	targetWorkspaceID string,
+	def *entity.InjectableDefinition,
+) (string, *entity.InjectableDefinition, error) {
+	if def.WorkspaceID == nil {
+		return def.ID, nil, nil
+	}
+
+	existing, err := s.injectableRepo.FindByKey(ctx, &targetWorkspaceID, def.Key)
+	if err == nil {
+		return existing.ID, nil, nil
+	}
+	if !errors.Is(err, entity.ErrInjectableNotFound) {
+		return "", nil, fmt.Errorf("finding injectable %s: %w", def.Key, err)
+	}
+
+	copied := entity.NewInjectableDefinition(&targetWorkspaceID, def.Key, def.Label, def.DataType)
+	copied.ID = uuid.NewString()
+	copied.Description = def.Description
+	copied.FormatConfig = def.FormatConfig
+	if def.Metadata != nil {
+		copied.Metadata = def.Metadata
+	}
+	return copied.ID, copied, nil
+}
+
+// remapTags attaches the target workspace tags matching the source tag names to the clone,
+// adding the missing ones to be created with it.
+func (s *TemplateService) remapTags(ctx context.Context, clone *entity.TemplateClone, targetWorkspaceID string, tags []*entity.Tag) error {
+	for _, tag := range tags {
+		if tag.WorkspaceID == targetWorkspaceID {
+			clone.TagIDs = append(clone.TagIDs, tag.ID)
+			continue
+		}
+
+		existing, err := s.workspaceTagRepo.FindByName(ctx, targetWorkspaceID, tag.Name)
+		if err == nil {
+			clone.TagIDs = append(clone.TagIDs, existing.ID)
+			continue
+		}
+		if !errors.Is(err, entity.ErrTagNotFound) {
+			return fmt.Errorf("finding tag %s: %w", tag.Name, err)
+		}
+
+		created := entity.NewTag(targetWorkspaceID, tag.Name, tag.Color)
+		created.ID = uuid.NewString()
+		clone.Tags = append(clone.Tags, created)
+		clone.TagIDs = append(clone.TagIDs, created.ID)
+	}
+	return nil
+}
+
 // DeleteTemplate deletes a template and all its versions.
 func (s *TemplateService) DeleteTemplate(ctx context.Context, id string) error {
 	// Delete tag associations
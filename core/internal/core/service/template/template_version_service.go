@@ -3,6 +3,7 @@ package template
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
 	templateuc "github.com/rendis/doc-assembly/core/internal/core/usecase/template"
 )
@@ -184,8 +186,8 @@ func (s *TemplateVersionService) UpdateVersion(ctx context.Context, cmd template
 }
 
 // PublishVersion publishes a version (archives current published if exists).
-func (s *TemplateVersionService) PublishVersion(ctx context.Context, id string, userID string) error {
-	version, err := s.versionRepo.FindByID(ctx, id)
+func (s *TemplateVersionService) PublishVersion(ctx context.Context, cmd templateuc.PublishVersionCommand) error {
+	version, err := s.versionRepo.FindByID(ctx, cmd.VersionID)
 	if err != nil {
 		return fmt.Errorf("finding version: %w", err)
 	}
@@ -212,22 +214,74 @@ func (s *TemplateVersionService) PublishVersion(ctx context.Context, id string,
 		return err
 	}
 
-	if err := s.archiveCurrentPublished(ctx, version.TemplateID, id, userID); err != nil {
+	if err := s.pinIncludes(ctx, version); err != nil {
 		return err
 	}
 
-	version.Publish(userID)
-	if err := s.versionRepo.Update(ctx, version); err != nil {
-		return fmt.Errorf("publishing version: %w", err)
+	archived := s.findCurrentPublished(ctx, version.TemplateID)
+	if archived != nil {
+		archived.Archive(cmd.UserID)
+	}
+
+	version.Publish(cmd.UserID)
+	if cmd.ChangeNotes != nil {
+		version.SetChangeNotes(*cmd.ChangeNotes)
+	}
+	event, err := entity.NewTemplateVersionPublishedEvent(template, version)
+	if err != nil {
+		return err
+	}
+	if err := s.versionRepo.Publish(ctx, version, archived, event); err != nil {
+		return err
 	}
 
+	if archived != nil {
+		slog.InfoContext(ctx, "previous version archived",
+			slog.String("archived_version_id", archived.ID),
+			slog.String("new_version_id", version.ID),
+		)
+	}
 	slog.InfoContext(ctx, "template version published",
-		slog.String("version_id", id),
+		slog.String("version_id", version.ID),
 		slog.String("template_id", version.TemplateID),
+		slog.String("event_id", event.ID),
 	)
 	return nil
 }
 
+// PreviewPublish reports what publishing a version would change relative to the published version.
+func (s *TemplateVersionService) PreviewPublish(ctx context.Context, versionID string) (*templateuc.PublishPreviewResult, error) {
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+	if err := version.CanPublish(); err != nil {
+		return nil, err
+	}
+
+	published, err := s.versionRepo.FindPublishedByTemplateIDWithDetails(ctx, version.TemplateID)
+	if err != nil && !errors.Is(err, entity.ErrNoPublishedVersion) {
+		return nil, fmt.Errorf("finding published version: %w", err)
+	}
+
+	to, err := portabledoc.Parse(version.ContentStructure)
+	if err != nil {
+		return nil, fmt.Errorf("parsing version content: %w", entity.ErrInvalidContentStructure)
+	}
+	var from *portabledoc.Document
+	if published != nil {
+		if from, err = portabledoc.Parse(published.ContentStructure); err != nil {
+			return nil, fmt.Errorf("parsing published content: %w", entity.ErrInvalidContentStructure)
+		}
+	}
+
+	return &templateuc.PublishPreviewResult{
+		Version:   version,
+		Published: published,
+		Diff:      portabledoc.Diff(from, to),
+	}, nil
+}
+
 // SchedulePublish schedules a version for future publication.
 func (s *TemplateVersionService) SchedulePublish(ctx context.Context, cmd templateuc.SchedulePublishCommand) error {
 	version, err := s.versionRepo.FindByID(ctx, cmd.VersionID)
@@ -425,15 +479,17 @@ func (s *TemplateVersionService) RemoveInjectable(ctx context.Context, id string
 	return nil
 }
 
-// ProcessScheduledPublications publishes all versions whose scheduled time has passed.
-func (s *TemplateVersionService) ProcessScheduledPublications(ctx context.Context) error {
-	versions, err := s.versionRepo.FindScheduledToPublish(ctx, time.Now().UTC())
+// ProcessScheduledPublications publishes all versions scheduled at or before now.
+// Each one goes through PublishVersion, so it is validated and its injectables extracted
+// exactly as a manual publication.
+func (s *TemplateVersionService) ProcessScheduledPublications(ctx context.Context, now time.Time) error {
+	versions, err := s.versionRepo.FindScheduledToPublish(ctx, now)
 	if err != nil {
 		return fmt.Errorf("finding scheduled versions: %w", err)
 	}
 
 	for _, version := range versions {
-		if err := s.PublishVersion(ctx, version.ID, ""); err != nil {
+		if err := s.PublishVersion(ctx, templateuc.PublishVersionCommand{VersionID: version.ID}); err != nil {
 			slog.ErrorContext(ctx, "failed to process scheduled publication",
 				slog.String("version_id", version.ID),
 				slog.Any("error", err),
@@ -446,9 +502,9 @@ func (s *TemplateVersionService) ProcessScheduledPublications(ctx context.Contex
 	return nil
 }
 
-// ProcessScheduledArchivals archives all published versions whose scheduled archive time has passed.
-func (s *TemplateVersionService) ProcessScheduledArchivals(ctx context.Context) error {
-	versions, err := s.versionRepo.FindScheduledToArchive(ctx, time.Now().UTC())
+// ProcessScheduledArchivals archives all published versions scheduled for archival at or before now.
+func (s *TemplateVersionService) ProcessScheduledArchivals(ctx context.Context, now time.Time) error {
+	versions, err := s.versionRepo.FindScheduledToArchive(ctx, now)
 	if err != nil {
 		return fmt.Errorf("finding scheduled archivals: %w", err)
 	}
@@ -504,6 +560,23 @@ func (s *TemplateVersionService) UpdateVersionContent(ctx context.Context, versi
 	return nil
 }
 
+// ValidateContent lints a document tree without saving or rendering it.
+func (s *TemplateVersionService) ValidateContent(ctx context.Context, content json.RawMessage) (*entity.ContentValidationError, error) {
+	doc, err := portabledoc.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing content: %w", entity.ErrInvalidContentStructure)
+	}
+	if doc == nil || doc.Content == nil {
+		return nil, nil
+	}
+
+	result := s.contentValidator.LintNodes(ctx, doc.Content.Content)
+	if len(result.Errors) == 0 && len(result.Warnings) == 0 {
+		return nil, nil
+	}
+	return toContentValidationError(result), nil
+}
+
 // PromoteVersion promotes a published version from sandbox to production.
 func (s *TemplateVersionService) PromoteVersion(ctx context.Context, cmd templateuc.PromoteVersionCommand) (*templateuc.PromoteVersionResult, error) {
 	sourceVersion, err := s.versionRepo.FindByID(ctx, cmd.SourceVersionID)
@@ -811,30 +884,49 @@ func (s *TemplateVersionService) replaceInjectables(ctx context.Context, version
 	return nil
 }
 
-// archiveCurrentPublished archives the currently published version if one exists.
-func (s *TemplateVersionService) archiveCurrentPublished(ctx context.Context, templateID, newVersionID, userID string) error {
-	currentPublished, err := s.versionRepo.FindPublishedByTemplateID(ctx, templateID)
+// pinIncludes pins the version's includes to the currently published version of each
+// included template, so re-rendering this version reproduces its published output.
+func (s *TemplateVersionService) pinIncludes(ctx context.Context, version *entity.TemplateVersion) error {
+	doc, err := portabledoc.Parse(version.ContentStructure)
 	if err != nil {
-		// No published version exists - this is expected for first publish
-		return nil //nolint:nilerr // Not finding a published version is not an error
+		return fmt.Errorf("parsing content: %w", entity.ErrInvalidContentStructure)
 	}
-	if currentPublished == nil {
+	if doc == nil {
 		return nil
 	}
 
-	currentPublished.Archive(userID)
-	if err := s.versionRepo.Update(ctx, currentPublished); err != nil {
-		return fmt.Errorf("archiving current version: %w", err)
+	changed, err := doc.PinIncludes(func(templateID string) (string, error) {
+		published, err := s.versionRepo.FindPublishedByTemplateID(ctx, templateID)
+		if errors.Is(err, entity.ErrNoPublishedVersion) {
+			return "", fmt.Errorf("%w: template %s has no published version", entity.ErrIncludeNotFound, templateID)
+		}
+		if err != nil {
+			return "", fmt.Errorf("finding included version of template %s: %w", templateID, err)
+		}
+		return published.ID, nil
+	})
+	if err != nil || !changed {
+		return err
 	}
 
-	slog.InfoContext(ctx, "previous version archived",
-		slog.String("archived_version_id", currentPublished.ID),
-		slog.String("new_version_id", newVersionID),
-	)
-
+	content, err := doc.Serialize()
+	if err != nil {
+		return fmt.Errorf("serializing pinned content: %w", err)
+	}
+	version.ContentStructure = content
 	return nil
 }
 
+// findCurrentPublished returns the currently published version of a template, or nil if none exists.
+func (s *TemplateVersionService) findCurrentPublished(ctx context.Context, templateID string) *entity.TemplateVersion {
+	currentPublished, err := s.versionRepo.FindPublishedByTemplateID(ctx, templateID)
+	if err != nil {
+		// No published version exists - this is expected for first publish
+		return nil
+	}
+	return currentPublished
+}
+
 // validatePromotionSource validates the source version for promotion.
 func (s *TemplateVersionService) validatePromotionSource(sourceVersion *entity.TemplateVersion, sourceTemplateID string) error {
 	if sourceVersion.Status != entity.VersionStatusPublished {
@@ -18,11 +18,11 @@ const (
 
 var (
 	// ErrQueryRequired indicates that the search query is required.
-	ErrQueryRequired = errors.New("query parameter 'q' is required")
+	ErrQueryRequired = entity.WithCategory(entity.ErrValidation, errors.New("query parameter 'q' is required"))
 	// ErrAssetKeyRequired indicates that the asset key is required.
-	ErrAssetKeyRequired = errors.New("query parameter 'key' is required")
+	ErrAssetKeyRequired = entity.WithCategory(entity.ErrValidation, errors.New("query parameter 'key' is required"))
 	// ErrUploadEmpty indicates that an upload body was empty.
-	ErrUploadEmpty = errors.New("gallery upload is empty")
+	ErrUploadEmpty = entity.WithCategory(entity.ErrValidation, errors.New("gallery upload is empty"))
 )
 
 // AssetsPage holds a paginated list of gallery assets.
@@ -19,6 +19,39 @@ type ListInjectablesResult struct {
 	Groups      []port.GroupConfig
 }
 
+// DefaultResolverRequest contains parameters for building a render-time default resolver.
+type DefaultResolverRequest struct {
+	WorkspaceID string
+	TemplateID  string
+	Environment entity.Environment
+}
+
+// AutocompleteKind identifies what an autocomplete suggestion refers to.
+type AutocompleteKind string
+
+const (
+	// AutocompleteKindInjectable is an injectable definition (DB, system or provider).
+	AutocompleteKindInjectable AutocompleteKind = "injectable"
+	// AutocompleteKindRole is a signer role name used by the workspace's templates.
+	AutocompleteKindRole AutocompleteKind = "role"
+)
+
+// AutocompleteRequest contains parameters for listing editor autocomplete suggestions.
+type AutocompleteRequest struct {
+	WorkspaceID string
+	Environment entity.Environment
+	Prefix      string // Case-insensitive prefix matched against keys and labels; empty matches all
+	Limit       int    // Maximum number of suggestions; <= 0 means no limit
+}
+
+// AutocompleteSuggestion is a compact entry for the editor autocomplete dropdown.
+type AutocompleteSuggestion struct {
+	Kind     AutocompleteKind
+	Key      string
+	Labels   map[string]string // i18n labels; "_" holds untranslated labels
+	DataType entity.InjectableDataType
+}
+
 // InjectableUseCase defines the input port for injectable definition operations.
 // Note: Injectables are read-only - they are managed via database migrations/seeds.
 type InjectableUseCase interface {
@@ -27,4 +60,12 @@ type InjectableUseCase interface {
 
 	// ListInjectables lists all injectable definitions for a workspace (including global, system, and provider).
 	ListInjectables(ctx context.Context, req *ListInjectablesRequest) (*ListInjectablesResult, error)
+
+	// Autocomplete lists the workspace's injectable keys and signer role names matching a prefix,
+	// injectables first, each group sorted by key.
+	Autocomplete(ctx context.Context, req *AutocompleteRequest) ([]*AutocompleteSuggestion, error)
+
+	// NewDefaultResolver builds a render-time fallback that resolves provider injectables
+	// on demand when no value was injected. Returns nil if no workspace provider is registered.
+	NewDefaultResolver(ctx context.Context, req *DefaultResolverRequest) (port.InjectableDefaultResolver, error)
 }
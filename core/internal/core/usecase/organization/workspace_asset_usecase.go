@@ -0,0 +1,33 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+)
+
+// MaxWorkspaceAssetSize is the maximum accepted asset size in bytes (10 MB).
+const MaxWorkspaceAssetSize = 10 << 20
+
+// UploadWorkspaceAssetCommand represents the command to upload a workspace brand asset.
+type UploadWorkspaceAssetCommand struct {
+	WorkspaceID string
+	UserID      string
+	Kind        entity.WorkspaceAssetKind
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// WorkspaceAssetUseCase defines the input port for workspace brand asset operations.
+type WorkspaceAssetUseCase interface {
+	// UploadAsset stores a logo or font for the workspace.
+	// Uploading a logo replaces the workspace's current logo.
+	UploadAsset(ctx context.Context, cmd UploadWorkspaceAssetCommand) (*entity.WorkspaceAsset, error)
+
+	// ListAssets lists the workspace's assets, logo first.
+	ListAssets(ctx context.Context, workspaceID string) ([]*entity.WorkspaceAsset, error)
+
+	// DeleteAsset removes an asset from storage and from the workspace.
+	DeleteAsset(ctx context.Context, workspaceID, id string) error
+}
@@ -21,6 +21,10 @@ type UpdateWorkspaceCommand struct {
 	ID   string
 	Name *string // nil = don't change
 	Code *string // nil = don't change
+
+	// DefaultTheme names the design theme used for renders that don't select one.
+	// nil = don't change, "" = clear.
+	DefaultTheme *string
 }
 
 // UpdateWorkspaceStatusCommand represents the command to update a workspace's status.
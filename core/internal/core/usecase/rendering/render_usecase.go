@@ -0,0 +1,53 @@
+package rendering
+
+import (
+	"context"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// RenderOptions selects what to render and how. The zero value renders the whole
+// document as a PDF.
+type RenderOptions struct {
+	Slots                  map[string][]portabledoc.Node
+	BlockIndex             *int
+	BlockID                string // resolved to BlockIndex when BlockIndex is nil
+	DraftMode              bool
+	ShowPlaceholders       bool
+	ShowHiddenConditionals bool
+	Timezone               string
+	PDFA                   bool
+	Theme                  string
+	Encryption             *port.PDFEncryption
+	Image                  *port.ImageOptions
+	ExtractSignaturePages  bool
+}
+
+// RenderVersionCmd is the command for rendering a stored template version.
+type RenderVersionCmd struct {
+	VersionID string
+	// WorkspaceID scopes provider defaults; empty uses the workspace owning the version.
+	WorkspaceID string
+	Environment entity.Environment
+	Injectables map[string]any
+	Options     RenderOptions
+}
+
+// RenderDocumentCmd is the command for rendering an in-memory document.
+type RenderDocumentCmd struct {
+	Document    *portabledoc.Document
+	Injectables map[string]any
+	Options     RenderOptions
+}
+
+// RenderUseCase defines the input port for rendering documents.
+type RenderUseCase interface {
+	// RenderVersion renders a template version with the given values, falling back
+	// to the version's injectable defaults and provider defaults.
+	RenderVersion(ctx context.Context, cmd RenderVersionCmd) (*port.RenderPreviewResult, error)
+
+	// RenderDocument renders a document that is not stored, e.g. an editor draft.
+	RenderDocument(ctx context.Context, cmd RenderDocumentCmd) (*port.RenderPreviewResult, error)
+}
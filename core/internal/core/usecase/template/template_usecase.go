@@ -38,6 +38,16 @@ type CloneTemplateCommand struct {
 	ClonedBy         string
 }
 
+// CloneFromLibraryCommand represents the command to clone a public library template
+// into another workspace.
+type CloneFromLibraryCommand struct {
+	SourceTemplateID  string
+	TargetWorkspaceID string
+	NewTitle          string
+	TargetFolderID    *string
+	ClonedBy          string
+}
+
 // SetProcessFieldsCommand represents the command to set process fields on a template.
 type SetProcessFieldsCommand struct {
 	TemplateID  string
@@ -86,8 +96,9 @@ type TemplateUseCase interface {
 	// ListTemplatesByFolder lists all templates in a folder.
 	ListTemplatesByFolder(ctx context.Context, folderID string) ([]*entity.TemplateListItem, error)
 
-	// ListPublicLibrary lists all public library templates.
-	ListPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error)
+	// ListPublicLibrary lists the published public library templates visible from a workspace:
+	// those of every active workspace in its tenant.
+	ListPublicLibrary(ctx context.Context, workspaceID string, filters port.TemplateFilters) ([]*entity.TemplateListItem, error)
 
 	// UpdateTemplate updates a template's metadata.
 	UpdateTemplate(ctx context.Context, cmd UpdateTemplateCommand) (*entity.Template, error)
@@ -95,6 +106,10 @@ type TemplateUseCase interface {
 	// CloneTemplate creates a copy of an existing template from its published version.
 	CloneTemplate(ctx context.Context, cmd CloneTemplateCommand) (*entity.Template, *entity.TemplateVersion, error)
 
+	// CloneFromLibrary copies the published version of a public library template into the
+	// target workspace, remapping its tags and injectables to the target workspace.
+	CloneFromLibrary(ctx context.Context, cmd CloneFromLibraryCommand) (*entity.Template, *entity.TemplateVersion, error)
+
 	// DeleteTemplate deletes a template and all its versions.
 	DeleteTemplate(ctx context.Context, id string) error
 
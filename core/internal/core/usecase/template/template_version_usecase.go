@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 )
 
 // CreateVersionCommand represents the command to create a new template version.
@@ -32,6 +33,13 @@ type AddVersionInjectableCommand struct {
 	DefaultValue           *string
 }
 
+// PublishVersionCommand represents the command to publish a version.
+type PublishVersionCommand struct {
+	VersionID   string
+	UserID      string  // Empty for system publications (scheduler, automation)
+	ChangeNotes *string // Optional notes on what changed, shown in the version history
+}
+
 // SchedulePublishCommand represents the command to schedule version publication.
 type SchedulePublishCommand struct {
 	VersionID string
@@ -62,6 +70,13 @@ type PromoteVersionResult struct {
 	Version  *entity.TemplateVersion // Always set
 }
 
+// PublishPreviewResult compares a version with the template's currently published version.
+type PublishPreviewResult struct {
+	Version   *entity.TemplateVersionWithDetails
+	Published *entity.TemplateVersionWithDetails // nil when the template has no published version
+	Diff      portabledoc.DocumentDiff           // changes publishing Version would introduce
+}
+
 // TemplateVersionUseCase defines the input port for template version operations.
 type TemplateVersionUseCase interface {
 	// CreateVersion creates a new version for a template.
@@ -86,7 +101,10 @@ type TemplateVersionUseCase interface {
 	UpdateVersion(ctx context.Context, cmd UpdateVersionCommand) (*entity.TemplateVersion, error)
 
 	// PublishVersion publishes a version (archives current published if exists).
-	PublishVersion(ctx context.Context, id string, userID string) error
+	PublishVersion(ctx context.Context, cmd PublishVersionCommand) error
+
+	// PreviewPublish reports what publishing a version would change relative to the published version.
+	PreviewPublish(ctx context.Context, versionID string) (*PublishPreviewResult, error)
 
 	// SchedulePublish schedules a version for future publication.
 	SchedulePublish(ctx context.Context, cmd SchedulePublishCommand) error
@@ -109,11 +127,11 @@ type TemplateVersionUseCase interface {
 	// RemoveInjectable removes an injectable from a version.
 	RemoveInjectable(ctx context.Context, id string) error
 
-	// ProcessScheduledPublications publishes all versions whose scheduled time has passed.
-	ProcessScheduledPublications(ctx context.Context) error
+	// ProcessScheduledPublications publishes all versions scheduled at or before now.
+	ProcessScheduledPublications(ctx context.Context, now time.Time) error
 
-	// ProcessScheduledArchivals archives all published versions whose scheduled archive time has passed.
-	ProcessScheduledArchivals(ctx context.Context) error
+	// ProcessScheduledArchivals archives all published versions scheduled for archival at or before now.
+	ProcessScheduledArchivals(ctx context.Context, now time.Time) error
 
 	// PromoteVersion promotes a published version from sandbox to production.
 	// Can create a new template (NEW_TEMPLATE mode) or add as a new version to existing template (NEW_VERSION mode).
@@ -122,4 +140,13 @@ type TemplateVersionUseCase interface {
 	// UpdateVersionContent updates the content of a DRAFT version after validating injectables.
 	// Returns an error if the version is not in DRAFT status or if injectable validation fails.
 	UpdateVersionContent(ctx context.Context, versionID string, content json.RawMessage) error
+
+	// ListInjectableFields returns the injectables referenced in a version's content, in
+	// document order, with type, label, default, required flag and role binding.
+	ListInjectableFields(ctx context.Context, versionID string) ([]*entity.VersionInjectableField, error)
+
+	// ValidateContent lints a document tree without saving or rendering it.
+	// Returns the validation report (nil when no problems were found), or
+	// ErrInvalidContentStructure if the content cannot be parsed.
+	ValidateContent(ctx context.Context, content json.RawMessage) (*entity.ContentValidationError, error)
 }
@@ -128,6 +128,37 @@ func setDefaults(v *viper.Viper) {
 	// Internal API defaults
 	v.SetDefault("internal_api.enabled", true)
 
+	// Events defaults
+	v.SetDefault("events.poll_interval_sec", 5)
+	v.SetDefault("events.batch_size", 50)
+	v.SetDefault("events.retry_base_sec", 5)
+	v.SetDefault("events.retry_max_sec", 600)
+	v.SetDefault("events.retention_days", 7)
+	v.SetDefault("events.purge_interval_min", 60)
+
+	// Render cache defaults
+	v.SetDefault("render_cache.mode", "off")
+	v.SetDefault("render_cache.ttl_seconds", 600)
+	v.SetDefault("render_cache.max_entries", 200)
+
+	// PAdES seal defaults
+	v.SetDefault("pades.reason", "Issued by doc-assembly")
+	v.SetDefault("pades.location", "")
+
+	// Tracing defaults
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.endpoint", "")
+	v.SetDefault("tracing.insecure", false)
+	v.SetDefault("tracing.service_name", "doc-assembly")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Rate limit defaults
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.per_ip.requests", 30)
+	v.SetDefault("rate_limit.per_ip.window_seconds", 60)
+	v.SetDefault("rate_limit.per_workspace.requests", 120)
+	v.SetDefault("rate_limit.per_workspace.window_seconds", 60)
+
 	// Worker defaults
 	v.SetDefault("worker.enabled", false)
 	v.SetDefault("worker.max_workers", 10)
@@ -30,10 +30,21 @@ type GroupConfig struct {
 	Order int
 }
 
+// LocaleDefaults holds per-language value formatting defaults from the `locales` section.
+type LocaleDefaults struct {
+	Boolean            string `yaml:"boolean"`            // "True/False" words (e.g. "Sí/No")
+	DecimalSeparator   string `yaml:"decimalSeparator"`   // e.g. ","
+	ThousandsSeparator string `yaml:"thousandsSeparator"` // e.g. "."
+	DateFormat         string `yaml:"dateFormat"`         // e.g. "DD/MM/YYYY"
+	SignatureLabel     string `yaml:"signatureLabel"`     // e.g. "Firma"
+	DateLabel          string `yaml:"dateLabel"`          // e.g. "Fecha"
+}
+
 // InjectorI18nConfig contiene todas las traducciones de inyectores.
 type InjectorI18nConfig struct {
 	entries map[string]injectorI18n
 	groups  []groupI18n
+	locales map[string]LocaleDefaults
 }
 
 // configPaths are the paths to search for config files.
@@ -44,9 +55,13 @@ var configPaths = []string{
 	".",
 }
 
+// reservedI18nKeys are top-level keys that are not injector entries.
+var reservedI18nKeys = map[string]bool{"groups": true, "locales": true}
+
 // rawI18nConfig represents the raw YAML structure with groups as array.
 type rawI18nConfig struct {
-	Groups []groupI18n `yaml:"groups"`
+	Groups  []groupI18n               `yaml:"groups"`
+	Locales map[string]LocaleDefaults `yaml:"locales"`
 }
 
 // LoadInjectorI18nFromFile loads injector translations from a specific file path.
@@ -100,10 +115,10 @@ func parseI18nData(data []byte) (*InjectorI18nConfig, error) {
 		return nil, err
 	}
 
-	// Extract injector entries (skip 'groups' key)
+	// Extract injector entries (skip reserved keys)
 	entries := make(map[string]injectorI18n)
 	for key, value := range rawMap {
-		if key == "groups" {
+		if reservedI18nKeys[key] {
 			continue
 		}
 
@@ -120,7 +135,7 @@ func parseI18nData(data []byte) (*InjectorI18nConfig, error) {
 		entries[key] = entry
 	}
 
-	return &InjectorI18nConfig{entries: entries, groups: rawConfig.Groups}, nil
+	return &InjectorI18nConfig{entries: entries, groups: rawConfig.Groups, locales: rawConfig.Locales}, nil
 }
 
 // GetName retorna el nombre traducido del inyector.
@@ -266,6 +281,14 @@ func (c *InjectorI18nConfig) GetAllGroups() []GroupConfig {
 	return result
 }
 
+// GetLocales returns the per-language formatting defaults keyed by language.
+func (c *InjectorI18nConfig) GetLocales() map[string]LocaleDefaults {
+	if c == nil {
+		return nil
+	}
+	return maps.Clone(c.locales)
+}
+
 // Merge combines another config into this one. The other config's entries
 // override this config's entries for the same code. Groups from other are
 // appended after this config's groups.
@@ -279,6 +302,12 @@ func (c *InjectorI18nConfig) Merge(other *InjectorI18nConfig) {
 	for code, entry := range other.entries {
 		c.entries[code] = entry
 	}
+	for lang, defaults := range other.locales {
+		if c.locales == nil {
+			c.locales = make(map[string]LocaleDefaults)
+		}
+		c.locales[lang] = defaults
+	}
 	// Append new groups (avoid duplicates by key)
 	existingKeys := make(map[string]bool)
 	for _, g := range c.groups {
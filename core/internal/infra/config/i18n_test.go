@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseI18nData_Locales(t *testing.T) {
+	cfg, err := parseI18nData([]byte(`
+locales:
+  es:
+    boolean: "Sí/No"
+    decimalSeparator: ","
+    thousandsSeparator: "."
+    dateFormat: "DD/MM/YYYY"
+  en:
+    boolean: "Yes/No"
+example_value:
+  name:
+    en: "Example"
+`))
+	require.NoError(t, err)
+
+	locales := cfg.GetLocales()
+	assert.Equal(t, LocaleDefaults{Boolean: "Sí/No", DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY"}, locales["es"])
+	assert.Equal(t, "Yes/No", locales["en"].Boolean)
+
+	assert.False(t, cfg.HasEntry("locales"), "locales must not be parsed as an injector entry")
+	assert.Equal(t, []string{"example_value"}, cfg.Codes())
+}
+
+func TestInjectorI18nConfig_MergeLocales(t *testing.T) {
+	base, err := parseI18nData([]byte(`
+locales:
+  en:
+    boolean: "Yes/No"
+  es:
+    boolean: "Sí/No"
+`))
+	require.NoError(t, err)
+	user, err := parseI18nData([]byte(`
+locales:
+  es:
+    boolean: "Verdadero/Falso"
+  pt:
+    boolean: "Sim/Não"
+`))
+	require.NoError(t, err)
+
+	base.Merge(user)
+
+	locales := base.GetLocales()
+	assert.Equal(t, "Yes/No", locales["en"].Boolean)
+	assert.Equal(t, "Verdadero/Falso", locales["es"].Boolean)
+	assert.Equal(t, "Sim/Não", locales["pt"].Boolean)
+}
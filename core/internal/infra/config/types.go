@@ -22,9 +22,14 @@ type Config struct {
 	Bootstrap          BootstrapConfig          `mapstructure:"bootstrap"`
 	Scheduler          SchedulerConfig          `mapstructure:"scheduler"`
 	Notification       NotificationConfig       `mapstructure:"notification"`
+	Events             EventsConfig             `mapstructure:"events"`
 	PublicAccess       PublicAccessConfig       `mapstructure:"public_access"`
 	Worker             WorkerConfig             `mapstructure:"worker"`
 	InjectableSources  InjectableSourcesConfig  `mapstructure:"injectable_sources"`
+	RateLimit          RateLimitConfig          `mapstructure:"rate_limit"`
+	RenderCache        RenderCacheConfig        `mapstructure:"render_cache"`
+	PAdES              PAdESConfig              `mapstructure:"pades"`
+	Tracing            TracingConfig            `mapstructure:"tracing"`
 
 	// DummyAuthUserID is the internal DB user ID for dummy auth mode.
 	// Set at runtime after seeding the dummy user (not loaded from YAML).
@@ -208,12 +213,22 @@ type TypstConfig struct {
 	TimeoutSeconds               int      `mapstructure:"timeout_seconds"`
 	FontDirs                     []string `mapstructure:"font_dirs"`
 	MaxConcurrent                int      `mapstructure:"max_concurrent"`
+	WarmupRenders                int      `mapstructure:"warmup_renders"`
+	MaxQueue                     int      `mapstructure:"max_queue"`
 	AcquireTimeoutSeconds        int      `mapstructure:"acquire_timeout_seconds"`
 	TemplateCacheTTL             int      `mapstructure:"template_cache_ttl_seconds"`
 	TemplateCacheMax             int      `mapstructure:"template_cache_max_entries"`
 	ImageCacheDir                string   `mapstructure:"image_cache_dir"`
 	ImageCacheMaxAgeSeconds      int      `mapstructure:"image_cache_max_age_seconds"`
 	ImageCacheCleanupIntervalSec int      `mapstructure:"image_cache_cleanup_interval_seconds"`
+	MaxImageDimension            int      `mapstructure:"max_image_dimension"`
+	MaxDocumentNodes             int      `mapstructure:"max_document_nodes"`
+	MaxDocumentDepth             int      `mapstructure:"max_document_depth"`
+	DebugAnchors                 bool     `mapstructure:"debug_anchors"`
+	RoundingMode                 string   `mapstructure:"rounding_mode"` // "half_up", "half_even" or "truncate"
+
+	// DetectedVersion is set by the startup preflight from `typst --version` and is not loaded directly.
+	DetectedVersion string `mapstructure:"-"`
 }
 
 // TimeoutDuration returns the compilation timeout as time.Duration.
@@ -274,6 +289,58 @@ type NotificationConfig struct {
 	Password string `mapstructure:"password"` // SMTP password //nolint:gosec
 }
 
+// EventsConfig holds configuration for relaying outbox events (template version published,
+// signing status changed) to other services. The relay runs as a scheduler job and delivers
+// to every consumer independently, at least once and in order per aggregate.
+type EventsConfig struct {
+	Consumers        []EventConsumerConfig `mapstructure:"consumers"`
+	PollIntervalSec  int                   `mapstructure:"poll_interval_sec"`  // Relay job interval
+	BatchSize        int                   `mapstructure:"batch_size"`         // Events per consumer per run
+	RetryBaseSec     int                   `mapstructure:"retry_base_sec"`     // First retry delay; doubles per failed attempt
+	RetryMaxSec      int                   `mapstructure:"retry_max_sec"`      // Upper bound for the retry delay
+	RetentionDays    int                   `mapstructure:"retention_days"`     // Purge events delivered to every consumer after this age; 0 keeps them
+	PurgeIntervalMin int                   `mapstructure:"purge_interval_min"` // Purge job interval
+}
+
+// EventConsumerConfig configures one destination for outbox events.
+type EventConsumerConfig struct {
+	Name                  string `mapstructure:"name"`                    // Unique; delivery progress is tracked per name
+	Sink                  string `mapstructure:"sink"`                    // "webhook" or "noop"
+	WebhookURL            string `mapstructure:"webhook_url"`             // Receives a POST per event
+	WebhookSecret         string `mapstructure:"webhook_secret"`          // Optional HMAC-SHA256 signing secret //nolint:gosec
+	WebhookTimeoutSeconds int    `mapstructure:"webhook_timeout_seconds"` // Per-delivery timeout (default 10)
+}
+
+// WebhookTimeoutDuration returns the webhook timeout as time.Duration.
+func (c EventConsumerConfig) WebhookTimeoutDuration() time.Duration {
+	return time.Duration(c.WebhookTimeoutSeconds) * time.Second
+}
+
+// PollIntervalDuration returns the relay job interval as time.Duration.
+func (e EventsConfig) PollIntervalDuration() time.Duration {
+	return time.Duration(e.PollIntervalSec) * time.Second
+}
+
+// RetryBaseDuration returns the first retry delay as time.Duration.
+func (e EventsConfig) RetryBaseDuration() time.Duration {
+	return time.Duration(e.RetryBaseSec) * time.Second
+}
+
+// RetryMaxDuration returns the retry delay bound as time.Duration.
+func (e EventsConfig) RetryMaxDuration() time.Duration {
+	return time.Duration(e.RetryMaxSec) * time.Second
+}
+
+// RetentionDuration returns the delivered event retention as time.Duration.
+func (e EventsConfig) RetentionDuration() time.Duration {
+	return time.Duration(e.RetentionDays) * 24 * time.Hour
+}
+
+// PurgeIntervalDuration returns the purge job interval as time.Duration.
+func (e EventsConfig) PurgeIntervalDuration() time.Duration {
+	return time.Duration(e.PurgeIntervalMin) * time.Minute
+}
+
 // PublicAccessConfig holds configuration for public document access (email-verification gate).
 type PublicAccessConfig struct {
 	RateLimitMax       int `mapstructure:"rate_limit_max"`        // Max access requests per recipient per window
@@ -281,6 +348,61 @@ type PublicAccessConfig struct {
 	TokenTTLHours      int `mapstructure:"token_ttl_hours"`       // Access token TTL in hours
 }
 
+// RenderCacheConfig configures caching of rendered PDFs keyed by version and render inputs.
+type RenderCacheConfig struct {
+	Mode       string `mapstructure:"mode"`        // "off", "memory" or "external" (engine.SetRenderCache)
+	TTLSeconds int    `mapstructure:"ttl_seconds"` // Entry lifetime; 0 keeps entries until evicted
+	MaxEntries int    `mapstructure:"max_entries"` // Memory mode capacity (LRU eviction)
+}
+
+// TTLDuration returns the entry lifetime as time.Duration.
+func (r RenderCacheConfig) TTLDuration() time.Duration {
+	return time.Duration(r.TTLSeconds) * time.Second
+}
+
+// PAdESConfig configures the digital seal applied to PDFs sent for signing.
+// Workspaces without a certificate are not sealed.
+type PAdESConfig struct {
+	Reason       string                   `mapstructure:"reason"`   // Signature dictionary /Reason
+	Location     string                   `mapstructure:"location"` // Signature dictionary /Location
+	Certificates []PAdESCertificateConfig `mapstructure:"certificates"`
+}
+
+// PAdESCertificateConfig maps a workspace to a PKCS#12 (.p12/.pfx) certificate.
+type PAdESCertificateConfig struct {
+	WorkspaceID string `mapstructure:"workspace_id"` // "*" applies to workspaces without their own
+	P12Path     string `mapstructure:"p12_path"`
+	P12Password string `mapstructure:"p12_password"`
+}
+
+// TracingConfig configures OpenTelemetry trace export over OTLP/HTTP.
+type TracingConfig struct {
+	Enabled     bool              `mapstructure:"enabled"`
+	Endpoint    string            `mapstructure:"endpoint"`     // Collector host:port or full URL (e.g. https://otel.example.com/v1/traces)
+	Insecure    bool              `mapstructure:"insecure"`     // Plain HTTP, for host:port endpoints
+	Headers     map[string]string `mapstructure:"headers"`      // Extra export headers (e.g. vendor API keys)
+	ServiceName string            `mapstructure:"service_name"` // Resource service.name
+	SampleRatio float64           `mapstructure:"sample_ratio"` // Fraction of new traces sampled, 0..1; parent decisions are honored
+}
+
+// RateLimitConfig holds rate limits for the CPU-heavy render and preview endpoints.
+type RateLimitConfig struct {
+	Enabled      bool            `mapstructure:"enabled"`
+	PerIP        RateLimitWindow `mapstructure:"per_ip"`        // Keyed by client IP
+	PerWorkspace RateLimitWindow `mapstructure:"per_workspace"` // Keyed by workspace, on workspace-scoped routes
+}
+
+// RateLimitWindow allows Requests per window; tokens refill continuously. Requests <= 0 disables the limit.
+type RateLimitWindow struct {
+	Requests      int `mapstructure:"requests"`
+	WindowSeconds int `mapstructure:"window_seconds"`
+}
+
+// WindowDuration returns the window as time.Duration.
+func (w RateLimitWindow) WindowDuration() time.Duration {
+	return time.Duration(w.WindowSeconds) * time.Second
+}
+
 // WorkerConfig holds River job queue worker configuration.
 type WorkerConfig struct {
 	Enabled           bool     `mapstructure:"enabled"`
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Validate checks that required settings are present and well-formed.
+// It does not contact any external service; connectivity is verified by the
+// engine's preflight and doctor checks. All problems are returned, not just the first.
+func (c *Config) Validate() []error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if port, err := strconv.Atoi(strings.TrimSpace(c.Server.Port)); err != nil || port <= 0 || port > 65535 {
+		add("server.port must be a valid TCP port, got %q", c.Server.Port)
+	}
+
+	if strings.TrimSpace(c.Database.Host) == "" {
+		add("missing required config: database.host")
+	}
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		add("database.port must be a valid TCP port, got %d", c.Database.Port)
+	}
+	if strings.TrimSpace(c.Database.User) == "" {
+		add("missing required config: database.user")
+	}
+	if strings.TrimSpace(c.Database.Name) == "" {
+		add("missing required config: database.name")
+	}
+	if c.Database.MinPoolSize > 0 && c.Database.MaxPoolSize > 0 && c.Database.MinPoolSize > c.Database.MaxPoolSize {
+		add("database.min_pool_size (%d) exceeds database.max_pool_size (%d)", c.Database.MinPoolSize, c.Database.MaxPoolSize)
+	}
+
+	switch mode := strings.ToLower(strings.TrimSpace(c.SigningSessionAuth.Mode)); mode {
+	case "", SigningSessionAuthModeOIDC, SigningSessionAuthModeCustom:
+	default:
+		add("invalid signing_session_auth.mode=%q (expected 'oidc' or 'custom')", c.SigningSessionAuth.Mode)
+	}
+
+	errs = append(errs, c.Signing.validate()...)
+	errs = append(errs, c.Storage.validate()...)
+	errs = append(errs, c.Events.validate()...)
+	switch strings.TrimSpace(c.RenderCache.Mode) {
+	case "", "off", "memory", "external":
+	default:
+		add("unsupported render_cache.mode=%q (expected 'off', 'memory' or 'external')", c.RenderCache.Mode)
+	}
+	if c.RenderCache.TTLSeconds < 0 {
+		add("render_cache.ttl_seconds must not be negative, got %d", c.RenderCache.TTLSeconds)
+	}
+	errs = append(errs, c.PAdES.validate()...)
+	if c.Tracing.Enabled {
+		if strings.TrimSpace(c.Tracing.Endpoint) == "" {
+			add("missing required config: tracing.endpoint (required when tracing is enabled)")
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			add("tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+		}
+	}
+	if c.RateLimit.Enabled {
+		errs = append(errs, c.RateLimit.PerIP.validate("rate_limit.per_ip")...)
+		errs = append(errs, c.RateLimit.PerWorkspace.validate("rate_limit.per_workspace")...)
+	}
+
+	if c.Typst.TimeoutSeconds < 0 {
+		add("typst.timeout_seconds must not be negative, got %d", c.Typst.TimeoutSeconds)
+	}
+	if c.Typst.MaxConcurrent < 0 {
+		add("typst.max_concurrent must not be negative, got %d", c.Typst.MaxConcurrent)
+	}
+	if c.Typst.MaxQueue < 0 {
+		add("typst.max_queue must not be negative, got %d", c.Typst.MaxQueue)
+	}
+	if c.Typst.MaxDocumentNodes < 0 {
+		add("typst.max_document_nodes must not be negative, got %d", c.Typst.MaxDocumentNodes)
+	}
+	if c.Typst.MaxDocumentDepth < 0 {
+		add("typst.max_document_depth must not be negative, got %d", c.Typst.MaxDocumentDepth)
+	}
+	if c.Typst.WarmupRenders < 0 {
+		add("typst.warmup_renders must not be negative, got %d", c.Typst.WarmupRenders)
+	}
+	if c.Typst.MaxImageDimension < 0 {
+		add("typst.max_image_dimension must not be negative, got %d", c.Typst.MaxImageDimension)
+	}
+	switch strings.TrimSpace(c.Typst.RoundingMode) {
+	case "", "half_up", "half_even", "truncate":
+	default:
+		add("unsupported typst.rounding_mode=%q (expected 'half_up', 'half_even' or 'truncate')", c.Typst.RoundingMode)
+	}
+
+	return errs
+}
+
+// validate checks the signing provider settings. An empty provider selects Documenso,
+// whose base URL defaults to the hosted API.
+func (s SigningConfig) validate() []error {
+	var errs []error
+	switch strings.TrimSpace(s.Provider) {
+	case "mock":
+		return nil
+	case "", "documenso":
+		if strings.TrimSpace(s.APIKey) == "" {
+			errs = append(errs, fmt.Errorf("missing required config: signing.api_key"))
+		}
+		if err := validateHTTPURL("signing.base_url", s.BaseURL); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateHTTPURL("signing.signing_base_url", s.SigningBaseURL); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateHTTPURL("signing.webhook_url", s.WebhookURL); err != nil {
+			errs = append(errs, err)
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported signing.provider=%q (expected 'documenso' or 'mock')", s.Provider))
+	}
+	return errs
+}
+
+// validate checks the storage settings. Disabled storage falls back to the local directory.
+func (s StorageConfig) validate() []error {
+	provider := strings.TrimSpace(s.Provider)
+	if !s.Enabled || provider == "" {
+		provider = "local"
+	}
+
+	switch provider {
+	case "local":
+		if strings.TrimSpace(s.LocalDir) == "" {
+			return []error{fmt.Errorf("missing required config: storage.local_dir")}
+		}
+	case "s3":
+		var errs []error
+		if strings.TrimSpace(s.Bucket) == "" {
+			errs = append(errs, fmt.Errorf("missing required config: storage.bucket (required for provider s3)"))
+		}
+		if err := validateHTTPURL("storage.endpoint", s.Endpoint); err != nil {
+			errs = append(errs, err)
+		}
+		return errs
+	default:
+		return []error{fmt.Errorf("unsupported storage.provider=%q (expected 'local' or 's3')", s.Provider)}
+	}
+	return nil
+}
+
+func (e EventsConfig) validate() []error {
+	var errs []error
+	if e.RetentionDays < 0 {
+		errs = append(errs, fmt.Errorf("events.retention_days must not be negative, got %d", e.RetentionDays))
+	}
+	if e.RetentionDays > 0 && e.PurgeIntervalMin <= 0 {
+		errs = append(errs, fmt.Errorf("events.purge_interval_min must be positive when events.retention_days is set, got %d", e.PurgeIntervalMin))
+	}
+	seen := make(map[string]bool, len(e.Consumers))
+	for i, c := range e.Consumers {
+		field := fmt.Sprintf("events.consumers[%d]", i)
+		name := strings.TrimSpace(c.Name)
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("missing required config: %s.name", field))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("duplicate %s.name %q", field, name))
+		}
+		seen[name] = true
+
+		switch strings.TrimSpace(c.Sink) {
+		case "noop":
+		case "webhook":
+			if strings.TrimSpace(c.WebhookURL) == "" {
+				errs = append(errs, fmt.Errorf("missing required config: %s.webhook_url (required for sink webhook)", field))
+			} else if err := validateHTTPURL(field+".webhook_url", c.WebhookURL); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unsupported %s.sink=%q (expected 'webhook' or 'noop')", field, c.Sink))
+		}
+	}
+	return errs
+}
+
+// validate checks a rate limit window. A limit with no requests is disabled and needs no window.
+func (w RateLimitWindow) validate(field string) []error {
+	if w.Requests < 0 {
+		return []error{fmt.Errorf("%s.requests must not be negative, got %d", field, w.Requests)}
+	}
+	if w.Requests > 0 && w.WindowSeconds <= 0 {
+		return []error{fmt.Errorf("%s.window_seconds must be positive when requests is set, got %d", field, w.WindowSeconds)}
+	}
+	return nil
+}
+
+// validateHTTPURL checks that raw, when set, is an absolute http(s) URL.
+func validateHTTPURL(field, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute http(s) URL, got %q", field, raw)
+	}
+	return nil
+}
+
+func (p PAdESConfig) validate() []error {
+	var errs []error
+	seen := make(map[string]bool, len(p.Certificates))
+	for i, c := range p.Certificates {
+		field := fmt.Sprintf("pades.certificates[%d]", i)
+		workspaceID := strings.TrimSpace(c.WorkspaceID)
+		switch {
+		case workspaceID == "":
+			errs = append(errs, fmt.Errorf("missing required config: %s.workspace_id", field))
+		case seen[workspaceID]:
+			errs = append(errs, fmt.Errorf("duplicate %s.workspace_id %q", field, workspaceID))
+		}
+		seen[workspaceID] = true
+
+		if strings.TrimSpace(c.P12Path) == "" {
+			errs = append(errs, fmt.Errorf("missing required config: %s.p12_path", field))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validConfig() *Config {
+	return &Config{
+		Server: ServerConfig{Port: "8080"},
+		Database: DatabaseConfig{
+			Host:        "localhost",
+			Port:        5432,
+			User:        "postgres",
+			Name:        "doc_engine",
+			MaxPoolSize: 10,
+			MinPoolSize: 2,
+		},
+		SigningSessionAuth: SigningSessionAuthConfig{Mode: SigningSessionAuthModeOIDC},
+		Signing: SigningConfig{
+			Provider: "documenso",
+			APIKey:   "api-key",
+			BaseURL:  "https://app.documenso.com/api/v2",
+		},
+		Storage: StorageConfig{Enabled: true, Provider: "local", LocalDir: "./data/storage"},
+		Typst:   TypstConfig{TimeoutSeconds: 10},
+	}
+}
+
+func TestConfigValidate_ValidConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+	}{
+		{"documenso with local storage", func(*Config) {}},
+		{"mock signing needs no credentials", func(c *Config) { c.Signing = SigningConfig{Provider: "mock"} }},
+		{"empty signing base url uses the hosted api", func(c *Config) { c.Signing.BaseURL = "" }},
+		{"signing session mode may come from the engine", func(c *Config) { c.SigningSessionAuth.Mode = "" }},
+		{"s3 storage with bucket", func(c *Config) {
+			c.Storage = StorageConfig{Enabled: true, Provider: "s3", Bucket: "docs", Endpoint: "http://localhost:9000"}
+		}},
+		{"event consumers", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{
+				{Name: "search", Sink: "webhook", WebhookURL: "https://hooks.example.com/doc-assembly"},
+				{Name: "log", Sink: "noop"},
+			}
+		}},
+		{"rate limits", func(c *Config) {
+			c.RateLimit = RateLimitConfig{Enabled: true, PerIP: RateLimitWindow{Requests: 30, WindowSeconds: 60}}
+		}},
+		{"disabled rate limits ignore windows", func(c *Config) {
+			c.RateLimit = RateLimitConfig{PerIP: RateLimitWindow{Requests: 30}}
+		}},
+		{"memory render cache", func(c *Config) { c.RenderCache = RenderCacheConfig{Mode: "memory", TTLSeconds: 60} }},
+		{"disabled storage ignores provider", func(c *Config) {
+			c.Storage = StorageConfig{Enabled: false, Provider: "gcs", LocalDir: "./data"}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+			assert.Empty(t, cfg.Validate())
+		})
+	}
+}
+
+func TestConfigValidate_InvalidConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		want   string
+	}{
+		{"invalid server port", func(c *Config) { c.Server.Port = "http" }, "server.port"},
+		{"missing database host", func(c *Config) { c.Database.Host = " " }, "database.host"},
+		{"database port out of range", func(c *Config) { c.Database.Port = 70000 }, "database.port"},
+		{"missing database user", func(c *Config) { c.Database.User = "" }, "database.user"},
+		{"missing database name", func(c *Config) { c.Database.Name = "" }, "database.name"},
+		{"min pool above max pool", func(c *Config) { c.Database.MinPoolSize = 20 }, "database.min_pool_size"},
+		{"unknown signing session mode", func(c *Config) { c.SigningSessionAuth.Mode = "basic" }, "signing_session_auth.mode"},
+		{"documenso without api key", func(c *Config) { c.Signing.APIKey = "" }, "signing.api_key"},
+		{"relative signing base url", func(c *Config) { c.Signing.BaseURL = "app.documenso.com" }, "signing.base_url"},
+		{"unknown signing provider", func(c *Config) { c.Signing.Provider = "docusign" }, "signing.provider"},
+		{"local storage without directory", func(c *Config) { c.Storage.LocalDir = "" }, "storage.local_dir"},
+		{"s3 storage without bucket", func(c *Config) { c.Storage = StorageConfig{Enabled: true, Provider: "s3"} }, "storage.bucket"},
+		{"unknown storage provider", func(c *Config) { c.Storage.Provider = "gcs" }, "storage.provider"},
+		{"webhook event consumer without url", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{{Name: "search", Sink: "webhook"}}
+		}, "events.consumers[0].webhook_url"},
+		{"relative webhook event consumer url", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{{Name: "search", Sink: "webhook", WebhookURL: "hooks.example.com"}}
+		}, "events.consumers[0].webhook_url"},
+		{"unknown event consumer sink", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{{Name: "bus", Sink: "kafka"}}
+		}, "events.consumers[0].sink"},
+		{"unnamed event consumer", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{{Sink: "noop"}}
+		}, "events.consumers[0].name"},
+		{"duplicate event consumer", func(c *Config) {
+			c.Events.Consumers = []EventConsumerConfig{{Name: "log", Sink: "noop"}, {Name: "log", Sink: "noop"}}
+		}, "events.consumers[1].name"},
+		{"negative event retention", func(c *Config) {
+			c.Events.RetentionDays = -1
+		}, "events.retention_days"},
+		{"event retention without purge interval", func(c *Config) {
+			c.Events.RetentionDays = 7
+		}, "events.purge_interval_min"},
+		{"rate limit without window", func(c *Config) {
+			c.RateLimit = RateLimitConfig{Enabled: true, PerWorkspace: RateLimitWindow{Requests: 10}}
+		}, "rate_limit.per_workspace.window_seconds"},
+		{"negative rate limit", func(c *Config) {
+			c.RateLimit = RateLimitConfig{Enabled: true, PerIP: RateLimitWindow{Requests: -1, WindowSeconds: 60}}
+		}, "rate_limit.per_ip.requests"},
+		{"pades certificate without path", func(c *Config) {
+			c.PAdES.Certificates = []PAdESCertificateConfig{{WorkspaceID: "ws-1"}}
+		}, "pades.certificates[0].p12_path"},
+		{"duplicate pades workspace", func(c *Config) {
+			c.PAdES.Certificates = []PAdESCertificateConfig{
+				{WorkspaceID: "*", P12Path: "seal.p12"},
+				{WorkspaceID: "*", P12Path: "other.p12"},
+			}
+		}, "pades.certificates[1].workspace_id"},
+		{"tracing without endpoint", func(c *Config) {
+			c.Tracing = TracingConfig{Enabled: true, SampleRatio: 1}
+		}, "tracing.endpoint"},
+		{"tracing sample ratio out of range", func(c *Config) {
+			c.Tracing = TracingConfig{Enabled: true, Endpoint: "localhost:4318", SampleRatio: 2}
+		}, "tracing.sample_ratio"},
+		{"unknown render cache mode", func(c *Config) { c.RenderCache.Mode = "redis" }, "render_cache.mode"},
+		{"negative typst timeout", func(c *Config) { c.Typst.TimeoutSeconds = -1 }, "typst.timeout_seconds"},
+		{"negative typst queue", func(c *Config) { c.Typst.MaxQueue = -1 }, "typst.max_queue"},
+		{"negative document node limit", func(c *Config) { c.Typst.MaxDocumentNodes = -1 }, "typst.max_document_nodes"},
+		{"negative document depth limit", func(c *Config) { c.Typst.MaxDocumentDepth = -1 }, "typst.max_document_depth"},
+		{"negative warmup renders", func(c *Config) { c.Typst.WarmupRenders = -1 }, "typst.warmup_renders"},
+		{"negative image dimension", func(c *Config) { c.Typst.MaxImageDimension = -1 }, "typst.max_image_dimension"},
+		{"unknown rounding mode", func(c *Config) { c.Typst.RoundingMode = "bankers" }, "typst.rounding_mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			errs := cfg.Validate()
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidate_ReportsAllProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Host = ""
+	cfg.Database.Name = ""
+	cfg.Signing.APIKey = ""
+
+	errs := cfg.Validate()
+
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	joined := strings.Join(msgs, "\n")
+	assert.Len(t, errs, 3)
+	assert.Contains(t, joined, "database.host")
+	assert.Contains(t, joined, "database.name")
+	assert.Contains(t, joined, "signing.api_key")
+}
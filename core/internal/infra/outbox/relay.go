@@ -0,0 +1,200 @@
+// Package outbox relays events recorded in the transactional outbox to the configured consumers.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+const (
+	defaultBatchSize  = 50
+	defaultRetryBase  = 5 * time.Second
+	defaultRetryMax   = 10 * time.Minute
+	defaultSettle     = time.Minute
+	maxBackoffDoubles = 20
+	purgeBatchSize    = 1000
+)
+
+// Consumer is a named destination for outbox events. Delivery progress is
+// tracked per name, so renaming a consumer redelivers every retained event.
+type Consumer struct {
+	Name string
+	Sink port.EventSink
+}
+
+// RelayOptions tunes batching, retry backoff and retention. Zero values use the defaults.
+type RelayOptions struct {
+	BatchSize   int
+	RetryBase   time.Duration // Delay before the first retry; doubles per failed attempt
+	RetryMax    time.Duration // Upper bound for the retry delay
+	SettleDelay time.Duration // How long a recorded event may wait for a lower seq to commit
+	Retention   time.Duration // Age after which events delivered to every consumer are purged; 0 keeps them
+}
+
+// Relay delivers outbox events to every consumer at least once, in Seq order per aggregate.
+// Progress lives in the database, so events committed before a crash or restart are
+// picked up by the next run, and an event delivered but not yet acknowledged is sent again.
+type Relay struct {
+	repo      port.OutboxRepository
+	consumers []Consumer
+	batchSize int
+	retryBase time.Duration
+	retryMax  time.Duration
+	settle    time.Duration
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewRelay creates a new outbox relay.
+func NewRelay(repo port.OutboxRepository, consumers []Consumer, opts RelayOptions) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.RetryBase <= 0 {
+		opts.RetryBase = defaultRetryBase
+	}
+	if opts.RetryMax < opts.RetryBase {
+		opts.RetryMax = max(defaultRetryMax, opts.RetryBase)
+	}
+	if opts.SettleDelay <= 0 {
+		opts.SettleDelay = defaultSettle
+	}
+	return &Relay{
+		repo:      repo,
+		consumers: consumers,
+		batchSize: opts.BatchSize,
+		retryBase: opts.RetryBase,
+		retryMax:  opts.RetryMax,
+		settle:    opts.SettleDelay,
+		retention: max(opts.Retention, 0),
+		now:       time.Now,
+	}
+}
+
+// RelayPending delivers up to one batch of due events to each consumer. Consumers are
+// independent: one failing does not hold back the others. A consumer already being
+// relayed by another instance is skipped until the next run.
+func (r *Relay) RelayPending(ctx context.Context) error {
+	var errs []error
+	for _, c := range r.consumers {
+		locked, err := r.repo.WithConsumerLock(ctx, c.Name, func(ctx context.Context) error {
+			return r.relayConsumer(ctx, c)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("relaying outbox events to %s: %w", c.Name, err))
+			continue
+		}
+		if !locked {
+			slog.DebugContext(ctx, "outbox consumer locked by another relay", slog.String("consumer", c.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// relayConsumer delivers one batch to a consumer and advances its watermark. A failed
+// event is scheduled for retry and the rest of its aggregate is held back; other
+// aggregates continue.
+func (r *Relay) relayConsumer(ctx context.Context, c Consumer) error {
+	pending, err := r.repo.FindDeliverable(ctx, c.Name, r.batchSize)
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[string]bool)
+	failures := 0
+	for _, p := range pending {
+		event := p.Event
+		aggregate := event.AggregateType + ":" + event.AggregateID
+		if blocked[aggregate] {
+			continue
+		}
+
+		if err := c.Sink.Deliver(ctx, event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			blocked[aggregate] = true
+			failures++
+
+			next := r.now().Add(r.backoff(p.Attempts))
+			if markErr := r.repo.MarkFailed(ctx, event.ID, c.Name, err.Error(), next); markErr != nil {
+				return markErr
+			}
+			slog.WarnContext(ctx, "outbox delivery failed",
+				slog.String("consumer", c.Name),
+				slog.String("event_id", event.ID),
+				slog.Int("attempt", p.Attempts+1),
+				slog.Time("next_attempt_at", next),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		if err := r.repo.MarkDelivered(ctx, event.ID, c.Name); err != nil {
+			return err
+		}
+		slog.DebugContext(ctx, "outbox event delivered",
+			slog.String("consumer", c.Name),
+			slog.String("event_id", event.ID),
+			slog.String("event_type", event.EventType),
+		)
+	}
+
+	if err := r.repo.AdvanceWatermark(ctx, c.Name, r.now().Add(-r.settle)); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d events failed", failures, len(pending))
+	}
+	return nil
+}
+
+// PurgeDelivered deletes events older than the retention that every consumer has
+// delivered. Events still pending for any consumer are kept. It does nothing when
+// no retention is configured.
+func (r *Relay) PurgeDelivered(ctx context.Context) error {
+	if r.retention == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.consumers))
+	for _, c := range r.consumers {
+		names = append(names, c.Name)
+	}
+	cutoff := r.now().Add(-r.retention)
+
+	var purged int64
+	for {
+		n, err := r.repo.DeleteDelivered(ctx, names, cutoff, purgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("purging outbox events: %w", err)
+		}
+		purged += n
+		if n < purgeBatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		slog.InfoContext(ctx, "outbox events purged",
+			slog.Int64("count", purged),
+			slog.Time("recorded_before", cutoff),
+		)
+	}
+	return nil
+}
+
+// backoff returns the retry delay after the given number of previous failures.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.retryBase
+	for i := 0; i < attempts && i < maxBackoffDoubles && delay < r.retryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, r.retryMax)
+}
@@ -0,0 +1,244 @@
+//go:build integration
+
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outboxrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/outbox_repo"
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/infra/outbox"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// recordingSink records deliveries for the test's own aggregates, ignoring events left by other tests.
+type recordingSink struct {
+	mu         sync.Mutex
+	aggregates map[string]bool
+	received   []string
+	fail       func(event *entity.OutboxEvent) error
+}
+
+func newSink(aggregateIDs ...string) *recordingSink {
+	s := &recordingSink{aggregates: map[string]bool{}}
+	for _, id := range aggregateIDs {
+		s.aggregates[id] = true
+	}
+	return s
+}
+
+func (s *recordingSink) Deliver(_ context.Context, event *entity.OutboxEvent) error {
+	if !s.aggregates[event.AggregateID] {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail != nil {
+		if err := s.fail(event); err != nil {
+			return err
+		}
+	}
+	s.received = append(s.received, event.ID)
+	return nil
+}
+
+func (s *recordingSink) Received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+// recordEvents commits one event per aggregate ID, in order, as domain code would.
+func recordEvents(t *testing.T, pool *pgxpool.Pool, aggregateIDs ...string) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ids := make([]string, 0, len(aggregateIDs))
+	for _, aggregateID := range aggregateIDs {
+		event := &entity.OutboxEvent{
+			AggregateType: entity.AggregateTemplate,
+			AggregateID:   aggregateID,
+			EventType:     entity.EventTemplateVersionPublished,
+			Payload:       []byte(`{}`),
+			CreatedAt:     time.Now().UTC(),
+		}
+		require.NoError(t, outboxrepo.InsertTx(ctx, tx, event))
+		ids = append(ids, event.ID)
+	}
+	require.NoError(t, tx.Commit(ctx))
+
+	t.Cleanup(func() {
+		for _, aggregateID := range aggregateIDs {
+			_, _ = pool.Exec(context.Background(), "DELETE FROM outbox.events WHERE aggregate_id = $1", aggregateID)
+		}
+	})
+	return ids
+}
+
+func newRelay(pool *pgxpool.Pool, consumer string, sink *recordingSink) *outbox.Relay {
+	return outbox.NewRelay(outboxrepo.New(pool), []outbox.Consumer{{Name: consumer, Sink: sink}}, outbox.RelayOptions{
+		BatchSize: 1000,
+		RetryBase: time.Millisecond,
+		RetryMax:  time.Millisecond,
+	})
+}
+
+func TestRelay_DeliversEventsCommittedBeforeRestart(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	consumer := "test-" + uuid.NewString()
+	aggregate := uuid.NewString()
+
+	// The process commits the state change and its events, then crashes before any relay run.
+	ids := recordEvents(t, pool, aggregate, aggregate)
+
+	// After the restart, a fresh relay picks the events up from the table.
+	sink := newSink(aggregate)
+	require.NoError(t, newRelay(pool, consumer, sink).RelayPending(context.Background()))
+	assert.Equal(t, ids, sink.Received())
+
+	deliveries, err := outboxrepo.New(pool).FindDeliveries(context.Background(), ids[0])
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, consumer, deliveries[0].Consumer)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+
+	// Delivered events are not sent again.
+	require.NoError(t, newRelay(pool, consumer, sink).RelayPending(context.Background()))
+	assert.Equal(t, ids, sink.Received())
+}
+
+func TestRelay_RedeliversEventsNotAcknowledgedBeforeCrash(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	consumer := "test-" + uuid.NewString()
+	aggregate := uuid.NewString()
+	ids := recordEvents(t, pool, aggregate)
+
+	// The sink receives the event, but the process dies before recording the delivery.
+	ctx, crash := context.WithCancel(context.Background())
+	sink := newSink(aggregate)
+	sink.fail = func(*entity.OutboxEvent) error {
+		crash()
+		return nil
+	}
+	require.Error(t, newRelay(pool, consumer, sink).RelayPending(ctx))
+	assert.Equal(t, ids, sink.Received())
+
+	// The restarted relay delivers it again: at-least-once.
+	sink.fail = nil
+	require.NoError(t, newRelay(pool, consumer, sink).RelayPending(context.Background()))
+	assert.Equal(t, []string{ids[0], ids[0]}, sink.Received())
+}
+
+func TestRelay_RetriesInOrderPerAggregate(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	repo := outboxrepo.New(pool)
+	consumer := "test-" + uuid.NewString()
+	a, b := uuid.NewString(), uuid.NewString()
+	ids := recordEvents(t, pool, a, b, a)
+	a1, b1, a2 := ids[0], ids[1], ids[2]
+
+	sink := newSink(a, b)
+	sink.fail = func(event *entity.OutboxEvent) error {
+		if event.ID == a1 {
+			return errors.New("consumer unavailable")
+		}
+		return nil
+	}
+	relay := newRelay(pool, consumer, sink)
+
+	require.Error(t, relay.RelayPending(context.Background()))
+	assert.Equal(t, []string{b1}, sink.Received(), "the failure holds back aggregate a only")
+
+	failed, err := repo.FindDeliveries(context.Background(), a1)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Attempts)
+	assert.Nil(t, failed[0].DeliveredAt)
+	require.NotNil(t, failed[0].LastError)
+	assert.Contains(t, *failed[0].LastError, "consumer unavailable")
+
+	// Once the consumer recovers, a1 is retried and a2 follows it.
+	sink.fail = nil
+	time.Sleep(10 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		_ = relay.RelayPending(context.Background())
+		return len(sink.Received()) == 3
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, []string{b1, a1, a2}, sink.Received())
+}
+
+func TestRelay_PurgesEventsDeliveredToEveryConsumer(t *testing.T) {
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+	repo := outboxrepo.New(pool)
+	a, b := uuid.NewString(), uuid.NewString()
+	ids := recordEvents(t, pool, a, b)
+	a1, b1 := ids[0], ids[1]
+
+	// Both events are past the retention; the recent one is never purged.
+	_, err := pool.Exec(ctx, "UPDATE outbox.events SET recorded_at = NOW() - INTERVAL '30 days' WHERE id = ANY($1)", ids)
+	require.NoError(t, err)
+	a2 := recordEvents(t, pool, a)[0]
+
+	audit := newSink(a, b)
+	audit.fail = func(event *entity.OutboxEvent) error {
+		if event.ID == b1 {
+			return errors.New("consumer unavailable")
+		}
+		return nil
+	}
+	relay := outbox.NewRelay(repo, []outbox.Consumer{
+		{Name: "test-" + uuid.NewString(), Sink: audit},
+		{Name: "test-" + uuid.NewString(), Sink: newSink(a, b)},
+	}, outbox.RelayOptions{
+		BatchSize: 1000,
+		RetryBase: time.Millisecond,
+		RetryMax:  time.Millisecond,
+		Retention: 7 * 24 * time.Hour,
+	})
+
+	retained := func(aggregateID string) []string {
+		events, err := repo.FindByAggregate(ctx, entity.AggregateTemplate, aggregateID)
+		require.NoError(t, err)
+		found := make([]string, 0, len(events))
+		for _, e := range events {
+			found = append(found, e.ID)
+		}
+		return found
+	}
+
+	// a1 reaches both consumers and is purged; b1 is still pending for audit and is kept.
+	require.Eventually(t, func() bool {
+		_ = relay.RelayPending(ctx)
+		require.NoError(t, relay.PurgeDelivered(ctx))
+		return len(retained(a)) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, []string{a2}, retained(a))
+	assert.Equal(t, []string{b1}, retained(b))
+
+	deliveries, err := repo.FindDeliveries(ctx, a1)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "delivery rows go with their event")
+
+	// Once audit catches up, b1 is purged too.
+	audit.fail = nil
+	require.Eventually(t, func() bool {
+		_ = relay.RelayPending(ctx)
+		require.NoError(t, relay.PurgeDelivered(ctx))
+		return len(retained(b)) == 0
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, []string{a2}, retained(a))
+}
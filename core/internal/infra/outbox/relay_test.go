@@ -0,0 +1,291 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
+
+// memoryRepo mirrors the outbox repository's delivery rules in memory.
+type memoryRepo struct {
+	now        time.Time
+	events     []*entity.OutboxEvent
+	deliveries map[string]*entity.OutboxDelivery // keyed by consumer + "/" + event ID
+	watermarks map[string]int64
+	locked     map[string]bool
+	settledAt  time.Time // Last settledBefore passed to AdvanceWatermark
+	purgedAt   time.Time // Last recordedBefore passed to DeleteDelivered
+}
+
+func newRepo(events ...*entity.OutboxEvent) *memoryRepo {
+	for i, e := range events {
+		e.Seq = int64(i + 1)
+	}
+	return &memoryRepo{
+		now:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		events:     events,
+		deliveries: map[string]*entity.OutboxDelivery{},
+		watermarks: map[string]int64{},
+		locked:     map[string]bool{},
+	}
+}
+
+func event(id, aggregateID string) *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		ID:            id,
+		AggregateType: entity.AggregateTemplate,
+		AggregateID:   aggregateID,
+		EventType:     entity.EventTemplateVersionPublished,
+	}
+}
+
+func (r *memoryRepo) delivery(eventID, consumer string) *entity.OutboxDelivery {
+	key := consumer + "/" + eventID
+	d, ok := r.deliveries[key]
+	if !ok {
+		d = &entity.OutboxDelivery{EventID: eventID, Consumer: consumer}
+		r.deliveries[key] = d
+	}
+	return d
+}
+
+func (r *memoryRepo) InsertTx(context.Context, pgx.Tx, *entity.OutboxEvent) error { return nil }
+
+func (r *memoryRepo) FindDeliverable(_ context.Context, consumer string, limit int) ([]*port.DeliverableEvent, error) {
+	var pending []*port.DeliverableEvent
+	failedAggregates := map[string]bool{}
+	for _, e := range r.events {
+		d, attempted := r.deliveries[consumer+"/"+e.ID]
+		if attempted && d.DeliveredAt != nil {
+			continue
+		}
+		if failedAggregates[e.AggregateID] {
+			continue
+		}
+		if attempted {
+			failedAggregates[e.AggregateID] = true
+			if d.NextAttemptAt.After(r.now) {
+				continue
+			}
+		}
+		if len(pending) < limit {
+			attempts := 0
+			if attempted {
+				attempts = d.Attempts
+			}
+			pending = append(pending, &port.DeliverableEvent{Event: e, Attempts: attempts})
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryRepo) FindByAggregate(context.Context, string, string) ([]*entity.OutboxEvent, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) FindDeliveries(context.Context, string) ([]*entity.OutboxDelivery, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) MarkDelivered(_ context.Context, eventID, consumer string) error {
+	d := r.delivery(eventID, consumer)
+	d.Attempts++
+	d.DeliveredAt = &r.now
+	return nil
+}
+
+func (r *memoryRepo) MarkFailed(_ context.Context, eventID, consumer, reason string, next time.Time) error {
+	d := r.delivery(eventID, consumer)
+	d.Attempts++
+	d.LastError = &reason
+	d.NextAttemptAt = &next
+	return nil
+}
+
+func (r *memoryRepo) AdvanceWatermark(_ context.Context, consumer string, settledBefore time.Time) error {
+	r.settledAt = settledBefore
+	for _, e := range r.events {
+		if e.Seq <= r.watermarks[consumer] {
+			continue
+		}
+		if d, ok := r.deliveries[consumer+"/"+e.ID]; !ok || d.DeliveredAt == nil {
+			break
+		}
+		r.watermarks[consumer] = e.Seq
+	}
+	return nil
+}
+
+func (r *memoryRepo) DeleteDelivered(_ context.Context, consumers []string, recordedBefore time.Time, limit int) (int64, error) {
+	r.purgedAt = recordedBefore
+	var kept []*entity.OutboxEvent
+	var deleted int64
+	for _, e := range r.events {
+		purgeable := deleted < int64(limit)
+		for _, c := range consumers {
+			if e.Seq > r.watermarks[c] {
+				purgeable = false
+			}
+		}
+		if purgeable {
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.events = kept
+	return deleted, nil
+}
+
+func (r *memoryRepo) WithConsumerLock(ctx context.Context, consumer string, fn func(context.Context) error) (bool, error) {
+	if r.locked[consumer] {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+type recordingSink struct {
+	received []string
+	failOn   map[string]bool
+}
+
+func (s *recordingSink) Deliver(_ context.Context, event *entity.OutboxEvent) error {
+	if s.failOn[event.ID] {
+		return errors.New("sink unavailable")
+	}
+	s.received = append(s.received, event.ID)
+	return nil
+}
+
+func newRelay(repo *memoryRepo, consumers ...Consumer) *Relay {
+	relay := NewRelay(repo, consumers, RelayOptions{BatchSize: 10, RetryBase: time.Second, RetryMax: 4 * time.Second})
+	relay.now = func() time.Time { return repo.now }
+	return relay
+}
+
+func TestRelay_DeliversPendingInOrder(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "b"), event("e3", "a"))
+	sink := &recordingSink{}
+	relay := newRelay(repo, Consumer{Name: "search", Sink: sink})
+
+	require.NoError(t, relay.RelayPending(context.Background()))
+	assert.Equal(t, []string{"e1", "e2", "e3"}, sink.received)
+
+	// Nothing left to deliver on the next run.
+	require.NoError(t, relay.RelayPending(context.Background()))
+	assert.Len(t, sink.received, 3)
+}
+
+func TestRelay_FailureHoldsBackOnlyItsAggregate(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "b"), event("e3", "a"), event("e4", "b"))
+	sink := &recordingSink{failOn: map[string]bool{"e1": true}}
+	relay := newRelay(repo, Consumer{Name: "search", Sink: sink})
+
+	err := relay.RelayPending(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"e2", "e4"}, sink.received, "aggregate b continues while a is blocked")
+	failed := repo.deliveries["search/e1"]
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Contains(t, *failed.LastError, "sink unavailable")
+	assert.Equal(t, repo.now.Add(time.Second), *failed.NextAttemptAt)
+
+	// Not due yet: nothing is retried.
+	sink.failOn = nil
+	require.NoError(t, relay.RelayPending(context.Background()))
+	assert.Equal(t, []string{"e2", "e4"}, sink.received)
+
+	// Once due, the failed event is retried; later events of its aggregate follow on the next run.
+	repo.now = repo.now.Add(time.Second)
+	require.NoError(t, relay.RelayPending(context.Background()))
+	assert.Equal(t, []string{"e2", "e4", "e1"}, sink.received)
+	require.NoError(t, relay.RelayPending(context.Background()))
+	assert.Equal(t, []string{"e2", "e4", "e1", "e3"}, sink.received)
+}
+
+func TestRelay_ConsumersAreIndependent(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "a"))
+	failing := &recordingSink{failOn: map[string]bool{"e1": true}}
+	healthy := &recordingSink{}
+	relay := newRelay(repo, Consumer{Name: "audit", Sink: failing}, Consumer{Name: "search", Sink: healthy})
+
+	require.Error(t, relay.RelayPending(context.Background()))
+
+	assert.Empty(t, failing.received)
+	assert.Equal(t, []string{"e1", "e2"}, healthy.received)
+}
+
+func TestRelay_SkipsConsumerLockedElsewhere(t *testing.T) {
+	repo := newRepo(event("e1", "a"))
+	repo.locked["search"] = true
+	sink := &recordingSink{}
+
+	require.NoError(t, newRelay(repo, Consumer{Name: "search", Sink: sink}).RelayPending(context.Background()))
+
+	assert.Empty(t, sink.received)
+}
+
+func TestRelay_RespectsBatchSize(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "a"), event("e3", "a"))
+	sink := &recordingSink{}
+	relay := NewRelay(repo, []Consumer{{Name: "search", Sink: sink}}, RelayOptions{BatchSize: 2})
+
+	require.NoError(t, relay.RelayPending(context.Background()))
+
+	assert.Equal(t, []string{"e1", "e2"}, sink.received)
+}
+
+func TestRelay_BackoffDoublesUpToMax(t *testing.T) {
+	relay := NewRelay(nil, nil, RelayOptions{RetryBase: time.Second, RetryMax: 5 * time.Second})
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 2*time.Second, relay.backoff(1))
+	assert.Equal(t, 4*time.Second, relay.backoff(2))
+	assert.Equal(t, 5*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Second, relay.backoff(1000))
+}
+
+func TestRelay_AdvancesWatermarkBehindSettleDelay(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "b"), event("e3", "a"))
+	sink := &recordingSink{failOn: map[string]bool{"e2": true}}
+	relay := newRelay(repo, Consumer{Name: "search", Sink: sink})
+
+	require.Error(t, relay.RelayPending(context.Background()))
+
+	assert.Equal(t, int64(1), repo.watermarks["search"], "the watermark stops before the failed event")
+	assert.Equal(t, repo.now.Add(-defaultSettle), repo.settledAt)
+}
+
+func TestRelay_PurgeKeepsEventsPendingForAnyConsumer(t *testing.T) {
+	repo := newRepo(event("e1", "a"), event("e2", "a"), event("e3", "b"))
+	audit := &recordingSink{failOn: map[string]bool{"e2": true}}
+	relay := NewRelay(repo, []Consumer{{Name: "audit", Sink: audit}, {Name: "search", Sink: &recordingSink{}}},
+		RelayOptions{Retention: 24 * time.Hour})
+	relay.now = func() time.Time { return repo.now }
+
+	require.Error(t, relay.RelayPending(context.Background()))
+	require.NoError(t, relay.PurgeDelivered(context.Background()))
+
+	require.Len(t, repo.events, 2, "only e1 was delivered to every consumer")
+	assert.Equal(t, "e2", repo.events[0].ID)
+	assert.Equal(t, repo.now.Add(-24*time.Hour), repo.purgedAt)
+}
+
+func TestRelay_PurgeDisabledWithoutRetention(t *testing.T) {
+	repo := newRepo(event("e1", "a"))
+	relay := newRelay(repo, Consumer{Name: "search", Sink: &recordingSink{}})
+
+	require.NoError(t, relay.RelayPending(context.Background()))
+	require.NoError(t, relay.PurgeDelivered(context.Background()))
+
+	assert.Len(t, repo.events, 1)
+	assert.True(t, repo.purgedAt.IsZero())
+}
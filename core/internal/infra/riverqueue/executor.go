@@ -14,10 +14,13 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/riverqueue/river"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/rendis/doc-assembly/core/internal/core/entity"
 	"github.com/rendis/doc-assembly/core/internal/core/entity/portabledoc"
 	"github.com/rendis/doc-assembly/core/internal/core/port"
+	"github.com/rendis/doc-assembly/core/internal/infra/logging"
+	"github.com/rendis/doc-assembly/core/internal/infra/tracing"
 )
 
 const maxReconciliationAttempts = 3
@@ -77,11 +80,17 @@ func NewSigningAttemptExecutor(cfg SigningAttemptExecutorConfig) *SigningAttempt
 }
 
 //nolint:funlen
-func (e *SigningAttemptExecutor) RenderAttemptPDF(ctx context.Context, attemptID string) error {
+func (e *SigningAttemptExecutor) RenderAttemptPDF(ctx context.Context, attemptID string) (err error) {
+	// Background renders have no operation_id; tag the render pipeline's logs with the attempt.
+	ctx = logging.WithAttrs(ctx, slog.String("attempt_id", attemptID))
+	ctx, span := tracing.Start(ctx, "signing.render_attempt", attribute.String("attempt.id", attemptID))
+	defer func() { tracing.End(span, err) }()
+
 	attempt, doc, stale, err := e.loadActiveAttempt(ctx, attemptID, entity.SigningAttemptStatusCreated, entity.SigningAttemptStatusRendering)
 	if err != nil || stale {
 		return err
 	}
+	span.SetAttributes(documentSpanAttributes(doc)...)
 	if attempt.IsTerminal() {
 		return nil
 	}
@@ -150,11 +159,15 @@ func (e *SigningAttemptExecutor) RenderAttemptPDF(ctx context.Context, attemptID
 }
 
 //nolint:funlen,gocognit,gocyclo
-func (e *SigningAttemptExecutor) SubmitAttemptToProvider(ctx context.Context, attemptID string) error {
+func (e *SigningAttemptExecutor) SubmitAttemptToProvider(ctx context.Context, attemptID string) (err error) {
+	ctx, span := tracing.Start(ctx, "signing.submit_attempt", attribute.String("attempt.id", attemptID))
+	defer func() { tracing.End(span, err) }()
+
 	attempt, doc, stale, err := e.loadActiveAttempt(ctx, attemptID, entity.SigningAttemptStatusReadyToSubmit, entity.SigningAttemptStatusProviderRetryWaiting, entity.SigningAttemptStatusSubmittingProvider)
 	if err != nil || stale {
 		return err
 	}
+	span.SetAttributes(documentSpanAttributes(doc)...)
 	if attempt.IsTerminal() {
 		return nil
 	}
@@ -405,14 +418,25 @@ func (e *SigningAttemptExecutor) renderPDF(ctx context.Context, doc *entity.Docu
 		_ = json.Unmarshal(doc.InjectedValuesSnapshot, &injectables)
 	}
 	renderResult, err := e.pdfRenderer.RenderPreview(ctx, &port.RenderPreviewRequest{
+		VersionID:        doc.TemplateVersionID,
 		Document:         portableDoc,
 		Injectables:      injectables,
 		SignerRoleValues: buildSignerRoleValues(recipients, signerRoles, portableDoc.SignerRoles),
 		FieldResponses:   loadFieldResponseMap(ctx, e.fieldResponseRepo, doc.ID),
+		SealWorkspaceID:  doc.WorkspaceID,
 	})
 	return renderResult, signerRoles, portableDoc, err
 }
 
+// documentSpanAttributes identifies a document's workspace and template version, without its content.
+func documentSpanAttributes(doc *entity.Document) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("document.id", doc.ID),
+		attribute.String("workspace.id", doc.WorkspaceID),
+		attribute.String("version.id", doc.TemplateVersionID),
+	}
+}
+
 func (e *SigningAttemptExecutor) handleProviderError(ctx context.Context, attempt *entity.SigningAttempt, providerErr *port.ProviderError) error {
 	attempt.ProviderSubmitPhase = &providerErr.Phase
 	attempt.LastErrorClass = &providerErr.Class
@@ -85,9 +85,11 @@ func NewHTTPServer(
 	automationKeyController *controller.AutomationKeyController,
 	automationController *controller.AutomationController,
 	galleryController *controller.GalleryController,
+	workspaceAssetController *controller.WorkspaceAssetController,
 	publicDocAuthenticator port.PublicDocumentAccessAuthenticator,
 	signingSessionAuthenticator port.SigningSessionAuthenticator,
 	keyRepo port.AutomationAPIKeyRepository,
+	renderMonitor port.RenderPoolMonitor,
 	frontendFS fs.FS,
 ) *HTTPServer {
 	if cfg.Environment == "production" {
@@ -114,6 +116,7 @@ func NewHTTPServer(
 
 	base.GET("/health", healthHandler)
 	base.GET("/ready", readyHandler)
+	base.GET("/health/render", renderPoolHandler(renderMonitor, cfg.Typst.DetectedVersion))
 	base.GET("/api/v1/config", clientConfigHandler(cfg))
 	if cfg.Server.SwaggerUI {
 		base.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -129,7 +132,7 @@ func NewHTTPServer(
 	v1 := setupPanelRoutes(base, cfg, middlewareProvider, requestTimeout)
 	registerPanelControllers(v1, middlewareProvider, adminController, meController,
 		tenantController, documentTypeController, processController, workspaceController,
-		injectableController, templateController, documentController, galleryController)
+		injectableController, templateController, documentController, galleryController, workspaceAssetController)
 	automationKeyController.RegisterRoutes(v1)
 	registerSigningSessionRoutes(base, cfg, requestTimeout, signingSessionController, signingSessionAuthenticator)
 
@@ -274,6 +277,7 @@ func registerPanelControllers(
 	templateController *controller.ContentTemplateController,
 	documentController *controller.DocumentController,
 	galleryController *controller.GalleryController,
+	workspaceAssetController *controller.WorkspaceAssetController,
 ) {
 	v1.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "pong"})
@@ -295,6 +299,9 @@ func registerPanelControllers(
 	if galleryController != nil {
 		galleryController.RegisterRoutes(v1, middlewareProvider)
 	}
+	if workspaceAssetController != nil {
+		workspaceAssetController.RegisterRoutes(v1, middlewareProvider)
+	}
 }
 
 // Start starts the HTTP server.
@@ -359,6 +366,22 @@ func readyHandler(c *gin.Context) {
 	})
 }
 
+// renderPoolHandler reports the render worker pool (in-flight, queued and rejected renders)
+// and the Typst version detected at startup. Renderers without a pool report zero capacity.
+func renderPoolHandler(monitor port.RenderPoolMonitor, typstVersion string) gin.HandlerFunc {
+	type renderHealth struct {
+		port.RenderPoolStats
+		TypstVersion string `json:"typstVersion,omitempty"`
+	}
+	return func(c *gin.Context) {
+		health := renderHealth{TypstVersion: typstVersion}
+		if monitor != nil {
+			health.RenderPoolStats = monitor.RenderPoolStats()
+		}
+		c.JSON(http.StatusOK, health)
+	}
+}
+
 // clientConfigHandler returns a handler that exposes non-sensitive config to the frontend.
 func clientConfigHandler(cfg *config.Config) gin.HandlerFunc {
 	type providerInfo struct {
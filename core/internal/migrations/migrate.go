@@ -2,11 +2,14 @@ package migrations
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"net/url"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 
 	"github.com/rendis/doc-assembly/core/internal/infra/config"
@@ -17,9 +20,175 @@ var sqlFiles embed.FS
 
 // Run applies all pending migrations to the database.
 func Run(cfg *config.DatabaseConfig) error {
+	m, _, err := newMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("running migrations: %w", err)
+	}
+
+	v, err := cleanVersion(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrations applied successfully (version: %d)\n", v)
+	return nil
+}
+
+// Down rolls back the given number of applied migrations, newest first.
+func Down(cfg *config.DatabaseConfig, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("down steps must be at least 1, got %d", steps)
+	}
+
+	m, src, err := newMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	// Check up front: golang-migrate applies as many steps as it can before
+	// reporting a short limit, which would leave a partial rollback behind.
+	current, err := cleanVersion(m)
+	if err != nil {
+		return err
+	}
+	applied, err := countApplied(src, current)
+	if err != nil {
+		return err
+	}
+	if steps > applied {
+		return fmt.Errorf("cannot roll back %d migration(s): only %d applied", steps, applied)
+	}
+
+	if err := m.Steps(-steps); err != nil {
+		return fmt.Errorf("rolling back migrations: %w", err)
+	}
+
+	v, err := cleanVersion(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rolled back %d migration(s) (version: %d)\n", steps, v)
+	return nil
+}
+
+// To migrates up or down until the database is at the given version.
+func To(cfg *config.DatabaseConfig, version uint) error {
+	m, _, err := newMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("migration version %d does not exist", version)
+		}
+		return fmt.Errorf("migrating to version %d: %w", version, err)
+	}
+
+	v, err := cleanVersion(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated to version %d\n", v)
+	return nil
+}
+
+// Version returns the currently applied migration version and dirty flag.
+// A database without applied migrations reports version 0.
+func Version(cfg *config.DatabaseConfig) (uint, bool, error) {
+	m, _, err := newMigrate(cfg)
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	v, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return 0, false, fmt.Errorf("reading migration version: %w", err)
+	}
+	return v, dirty, nil
+}
+
+// Status describes the migration state of a database.
+type Status struct {
+	// Version is the applied migration version (0 when none is applied).
+	Version uint
+	// Dirty is set when a migration failed midway; it must be fixed by hand
+	// and cleared with Force before migrating again.
+	Dirty bool
+	// Latest is the newest embedded migration version.
+	Latest uint
+	// Pending is the number of embedded migrations newer than Version.
+	Pending int
+}
+
+// GetStatus reports the applied version, dirty flag and pending migrations.
+func GetStatus(cfg *config.DatabaseConfig) (*Status, error) {
+	m, src, err := newMigrate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	v, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, fmt.Errorf("reading migration version: %w", err)
+	}
+
+	status := &Status{Version: v, Dirty: dirty}
+	next, err := src.First()
+	for err == nil {
+		status.Latest = next
+		if next > v {
+			status.Pending++
+		}
+		next, err = src.Next(next)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading embedded migrations: %w", err)
+	}
+	return status, nil
+}
+
+// Force records version as applied and clears the dirty flag without running
+// any migration. Version 0 marks the database as having no migrations applied.
+// Use it only after fixing the schema by hand.
+func Force(cfg *config.DatabaseConfig, version uint) error {
+	m, src, err := newMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	target := -1
+	if version > 0 {
+		if _, err := countApplied(src, version); err != nil {
+			return fmt.Errorf("migration version %d does not exist", version)
+		}
+		target = int(version)
+	}
+
+	if err := m.Force(target); err != nil {
+		return fmt.Errorf("forcing migration version %d: %w", version, err)
+	}
+
+	fmt.Printf("Forced migration version %d (dirty flag cleared)\n", version)
+	return nil
+}
+
+func newMigrate(cfg *config.DatabaseConfig) (*migrate.Migrate, source.Driver, error) {
 	src, err := iofs.New(sqlFiles, "sql")
 	if err != nil {
-		return fmt.Errorf("loading embedded migrations: %w", err)
+		return nil, nil, fmt.Errorf("loading embedded migrations: %w", err)
 	}
 
 	connURL := fmt.Sprintf("pgx5://%s:%s@%s:%d/%s?sslmode=%s",
@@ -28,19 +197,40 @@ func Run(cfg *config.DatabaseConfig) error {
 
 	m, err := migrate.NewWithSourceInstance("iofs", src, connURL)
 	if err != nil {
-		return fmt.Errorf("creating migrate instance: %w", err)
-	}
-	defer m.Close()
-
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("running migrations: %w", err)
+		return nil, nil, fmt.Errorf("creating migrate instance: %w", err)
 	}
+	return m, src, nil
+}
 
+// cleanVersion returns the current version (0 when none is applied), failing
+// when it is dirty.
+func cleanVersion(m *migrate.Migrate) (uint, error) {
 	v, dirty, _ := m.Version()
 	if dirty {
-		return fmt.Errorf("migration version %d is dirty — manual intervention required", v)
+		return v, fmt.Errorf("migration version %d is dirty — manual intervention required", v)
 	}
+	return v, nil
+}
 
-	fmt.Printf("Migrations applied successfully (version: %d)\n", v)
-	return nil
+// countApplied returns how many embedded migrations are applied when the
+// database is at version current.
+func countApplied(src source.Driver, current uint) (int, error) {
+	if current == 0 {
+		return 0, nil
+	}
+	v, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("reading embedded migrations: %w", err)
+	}
+	count := 1
+	for v < current {
+		if v, err = src.Next(v); err != nil {
+			return 0, fmt.Errorf("migration version %d not found in embedded migrations", current)
+		}
+		count++
+	}
+	if v != current {
+		return 0, fmt.Errorf("migration version %d not found in embedded migrations", current)
+	}
+	return count, nil
 }
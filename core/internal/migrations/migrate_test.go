@@ -0,0 +1,140 @@
+//go:build integration
+
+package migrations_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/infra/config"
+	"github.com/rendis/doc-assembly/core/internal/migrations"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+)
+
+// galleryMigration is the version that creates content.gallery_assets.
+const galleryMigration = 17
+
+func connect(t *testing.T, cfg *config.DatabaseConfig) *pgx.Conn {
+	t.Helper()
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(context.Background()) })
+	return conn
+}
+
+func galleryTableExists(t *testing.T, cfg *config.DatabaseConfig) bool {
+	t.Helper()
+	ctx := context.Background()
+	conn := connect(t, cfg)
+
+	var exists bool
+	require.NoError(t, conn.QueryRow(ctx,
+		`SELECT EXISTS (
+			SELECT FROM information_schema.tables
+			WHERE table_schema = 'content' AND table_name = 'gallery_assets'
+		)`,
+	).Scan(&exists))
+	return exists
+}
+
+func currentVersion(t *testing.T, cfg *config.DatabaseConfig) uint {
+	t.Helper()
+	v, dirty, err := migrations.Version(cfg)
+	require.NoError(t, err)
+	require.False(t, dirty)
+	return v
+}
+
+func TestMigrations_DownAndTo(t *testing.T) {
+	cfg := testhelper.NewTempDatabase(t)
+
+	assert.Equal(t, uint(0), currentVersion(t, cfg))
+
+	require.NoError(t, migrations.Run(cfg))
+	latest := currentVersion(t, cfg)
+	require.Greater(t, latest, uint(galleryMigration))
+	assert.True(t, galleryTableExists(t, cfg))
+
+	t.Run("down rolls back the last migration", func(t *testing.T) {
+		require.NoError(t, migrations.Down(cfg, 1))
+		assert.Equal(t, latest-1, currentVersion(t, cfg))
+
+		require.NoError(t, migrations.Run(cfg))
+		assert.Equal(t, latest, currentVersion(t, cfg))
+	})
+
+	t.Run("to rolls back and reapplies a targeted version", func(t *testing.T) {
+		require.NoError(t, migrations.To(cfg, galleryMigration-1))
+		assert.Equal(t, uint(galleryMigration-1), currentVersion(t, cfg))
+		assert.False(t, galleryTableExists(t, cfg))
+
+		require.NoError(t, migrations.To(cfg, latest))
+		assert.Equal(t, latest, currentVersion(t, cfg))
+		assert.True(t, galleryTableExists(t, cfg))
+	})
+
+	t.Run("to the current version is a no-op", func(t *testing.T) {
+		require.NoError(t, migrations.To(cfg, latest))
+		assert.Equal(t, latest, currentVersion(t, cfg))
+	})
+
+	t.Run("rejects unknown versions and over-long rollbacks", func(t *testing.T) {
+		assert.ErrorContains(t, migrations.To(cfg, latest+100), "does not exist")
+		assert.ErrorContains(t, migrations.Down(cfg, int(latest)+1), "only")
+		assert.Error(t, migrations.Down(cfg, 0))
+		assert.Equal(t, latest, currentVersion(t, cfg), "failed rollbacks must not change the version")
+	})
+}
+
+func TestMigrations_StatusAndForce(t *testing.T) {
+	cfg := testhelper.NewTempDatabase(t)
+
+	empty, err := migrations.GetStatus(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), empty.Version)
+	assert.False(t, empty.Dirty)
+	assert.Equal(t, int(empty.Latest), empty.Pending)
+
+	require.NoError(t, migrations.To(cfg, galleryMigration))
+	partial, err := migrations.GetStatus(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uint(galleryMigration), partial.Version)
+	assert.Equal(t, int(partial.Latest)-galleryMigration, partial.Pending)
+
+	// Simulate a migration that failed midway.
+	_, err = connect(t, cfg).Exec(context.Background(), "UPDATE schema_migrations SET dirty = true")
+	require.NoError(t, err)
+
+	dirty, err := migrations.GetStatus(cfg)
+	require.NoError(t, err)
+	assert.True(t, dirty.Dirty)
+	assert.Equal(t, uint(galleryMigration), dirty.Version)
+
+	err = migrations.Run(cfg)
+	require.Error(t, err, "migrating a dirty database must fail")
+	assert.Contains(t, err.Error(), "dirty")
+
+	t.Run("force rejects unknown versions", func(t *testing.T) {
+		assert.ErrorContains(t, migrations.Force(cfg, dirty.Latest+100), "does not exist")
+	})
+
+	require.NoError(t, migrations.Force(cfg, galleryMigration))
+	clean, err := migrations.GetStatus(cfg)
+	require.NoError(t, err)
+	assert.False(t, clean.Dirty)
+	assert.Equal(t, uint(galleryMigration), clean.Version)
+
+	require.NoError(t, migrations.Run(cfg))
+	done, err := migrations.GetStatus(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, done.Latest, done.Version)
+	assert.Zero(t, done.Pending)
+}
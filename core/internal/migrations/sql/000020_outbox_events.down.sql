@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS outbox.events;
+DROP SCHEMA IF EXISTS outbox;
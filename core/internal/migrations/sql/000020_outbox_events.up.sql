@@ -0,0 +1,24 @@
+-- ========== CREATE SCHEMA ==========
+CREATE SCHEMA IF NOT EXISTS outbox;
+
+-- ========== events: Table Creation ==========
+-- Events are written in the same transaction as the state change they describe
+-- and delivered to the configured sink afterwards. aggregate_id carries no foreign
+-- key so events outlive the rows they refer to.
+
+CREATE TABLE outbox.events (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    attempts INT DEFAULT 0 NOT NULL,
+    last_error TEXT
+);
+
+-- ========== events: Indexes ==========
+
+CREATE INDEX idx_outbox_events_pending ON outbox.events (created_at) WHERE delivered_at IS NULL;
+CREATE INDEX idx_outbox_events_aggregate ON outbox.events (aggregate_type, aggregate_id, created_at);
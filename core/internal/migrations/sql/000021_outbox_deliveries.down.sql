@@ -0,0 +1,15 @@
+ALTER TABLE outbox.events
+    ADD COLUMN delivered_at TIMESTAMPTZ,
+    ADD COLUMN attempts INT DEFAULT 0 NOT NULL,
+    ADD COLUMN last_error TEXT;
+
+UPDATE outbox.events e
+SET delivered_at = d.delivered_at, attempts = d.attempts, last_error = d.last_error
+FROM outbox.deliveries d
+WHERE d.event_id = e.id AND d.consumer = 'default';
+
+CREATE INDEX idx_outbox_events_pending ON outbox.events (created_at) WHERE delivered_at IS NULL;
+
+DROP TABLE IF EXISTS outbox.deliveries;
+DROP INDEX IF EXISTS outbox.idx_outbox_events_seq;
+ALTER TABLE outbox.events DROP COLUMN seq;
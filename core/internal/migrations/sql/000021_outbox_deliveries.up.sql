@@ -0,0 +1,39 @@
+-- ========== events: Ordering ==========
+-- seq gives a total order; events of the same aggregate are delivered in seq order.
+
+ALTER TABLE outbox.events ADD COLUMN seq BIGINT GENERATED ALWAYS AS IDENTITY;
+CREATE UNIQUE INDEX idx_outbox_events_seq ON outbox.events (seq);
+
+-- ========== deliveries: Table Creation ==========
+-- One row per (event, consumer) once delivery has been attempted. Events without
+-- a row for a consumer have never been attempted for it.
+
+CREATE TABLE outbox.deliveries (
+    event_id UUID NOT NULL,
+    consumer VARCHAR(100) NOT NULL,
+    attempts INT DEFAULT 0 NOT NULL,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (event_id, consumer),
+    CONSTRAINT fk_outbox_deliveries_event FOREIGN KEY (event_id) REFERENCES outbox.events(id) ON DELETE CASCADE
+);
+
+-- ========== deliveries: Indexes ==========
+
+CREATE INDEX idx_outbox_deliveries_pending ON outbox.deliveries (consumer, event_id) WHERE delivered_at IS NULL;
+
+-- ========== events: Move delivery state ==========
+-- Single-sink delivery state becomes the "default" consumer.
+
+INSERT INTO outbox.deliveries (event_id, consumer, attempts, last_error, delivered_at)
+SELECT id, 'default', attempts, last_error, delivered_at
+FROM outbox.events
+WHERE attempts > 0 OR delivered_at IS NOT NULL;
+
+DROP INDEX IF EXISTS outbox.idx_outbox_events_pending;
+ALTER TABLE outbox.events
+    DROP COLUMN delivered_at,
+    DROP COLUMN attempts,
+    DROP COLUMN last_error;
@@ -0,0 +1 @@
+ALTER TABLE content.template_versions DROP COLUMN IF EXISTS change_notes;
@@ -0,0 +1 @@
+ALTER TABLE content.template_versions ADD COLUMN change_notes TEXT;
@@ -0,0 +1 @@
+ALTER TABLE tenancy.workspaces DROP COLUMN IF EXISTS default_theme;
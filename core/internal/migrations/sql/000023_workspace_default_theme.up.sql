@@ -0,0 +1 @@
+ALTER TABLE tenancy.workspaces ADD COLUMN default_theme VARCHAR(100);
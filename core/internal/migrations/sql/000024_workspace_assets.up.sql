@@ -0,0 +1,21 @@
+-- ========== workspace_assets: Table Creation ==========
+
+CREATE TABLE tenancy.workspace_assets (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    key VARCHAR(500) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT chk_workspace_assets_kind CHECK (kind IN ('LOGO', 'FONT')),
+    CONSTRAINT fk_workspace_assets_workspace FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE,
+    CONSTRAINT fk_workspace_assets_user FOREIGN KEY (created_by) REFERENCES identity.users(id)
+);
+
+-- ========== workspace_assets: Indexes ==========
+
+CREATE INDEX idx_workspace_assets_workspace ON tenancy.workspace_assets (workspace_id);
+CREATE UNIQUE INDEX idx_workspace_assets_logo ON tenancy.workspace_assets (workspace_id) WHERE kind = 'LOGO';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbox.consumer_watermarks;
+DROP INDEX IF EXISTS outbox.idx_outbox_events_recorded_at;
+ALTER TABLE outbox.events DROP COLUMN IF EXISTS recorded_at;
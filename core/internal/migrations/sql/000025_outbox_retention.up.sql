@@ -0,0 +1,16 @@
+-- ========== events: Recording time ==========
+-- recorded_at is stamped by the database on insert, unlike created_at which carries
+-- the domain time of the change. It bounds how long a seq gap can stay open.
+
+ALTER TABLE outbox.events ADD COLUMN recorded_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL;
+CREATE INDEX idx_outbox_events_recorded_at ON outbox.events (recorded_at);
+
+-- ========== consumer_watermarks: Table Creation ==========
+-- Every event with seq <= delivered_seq has been delivered to the consumer. The relay
+-- scans only above the watermark and retention purges only below the lowest one.
+
+CREATE TABLE outbox.consumer_watermarks (
+    consumer VARCHAR(100) PRIMARY KEY,
+    delivered_seq BIGINT DEFAULT 0 NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
@@ -5,7 +5,9 @@ package testhelper
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -19,8 +21,10 @@ import (
 var (
 	testContainer *postgres.PostgresContainer
 	testPool      *pgxpool.Pool
+	testDBConfig  *config.DatabaseConfig
 	once          sync.Once
 	initErr       error
+	tempDBSeq     atomic.Int64
 )
 
 // GetTestPool returns a connection pool to a PostgreSQL testcontainer
@@ -31,7 +35,7 @@ func GetTestPool(t *testing.T) *pgxpool.Pool {
 	t.Helper()
 
 	once.Do(func() {
-		testContainer, testPool, initErr = setupTestContainer()
+		testContainer, testPool, testDBConfig, initErr = setupTestContainer()
 	})
 
 	if initErr != nil {
@@ -41,7 +45,7 @@ func GetTestPool(t *testing.T) *pgxpool.Pool {
 	return testPool
 }
 
-func setupTestContainer() (*postgres.PostgresContainer, *pgxpool.Pool, error) {
+func setupTestContainer() (*postgres.PostgresContainer, *pgxpool.Pool, *config.DatabaseConfig, error) {
 	ctx := context.Background()
 
 	// Start PostgreSQL container
@@ -54,26 +58,26 @@ func setupTestContainer() (*postgres.PostgresContainer, *pgxpool.Pool, error) {
 		postgres.BasicWaitStrategies(),
 	)
 	if err != nil {
-		return nil, nil, fmt.Errorf("starting postgres: %w", err)
+		return nil, nil, nil, fmt.Errorf("starting postgres: %w", err)
 	}
 
 	// Get connection info
 	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
 	if err != nil {
 		pgContainer.Terminate(ctx)
-		return nil, nil, fmt.Errorf("getting connection string: %w", err)
+		return nil, nil, nil, fmt.Errorf("getting connection string: %w", err)
 	}
 
 	host, err := pgContainer.Host(ctx)
 	if err != nil {
 		pgContainer.Terminate(ctx)
-		return nil, nil, fmt.Errorf("getting host: %w", err)
+		return nil, nil, nil, fmt.Errorf("getting host: %w", err)
 	}
 
 	port, err := pgContainer.MappedPort(ctx, "5432/tcp")
 	if err != nil {
 		pgContainer.Terminate(ctx)
-		return nil, nil, fmt.Errorf("getting port: %w", err)
+		return nil, nil, nil, fmt.Errorf("getting port: %w", err)
 	}
 
 	// Run embedded SQL migrations
@@ -87,17 +91,50 @@ func setupTestContainer() (*postgres.PostgresContainer, *pgxpool.Pool, error) {
 	}
 	if migErr := migrations.Run(dbCfg); migErr != nil {
 		pgContainer.Terminate(ctx)
-		return nil, nil, fmt.Errorf("running migrations: %w", migErr)
+		return nil, nil, nil, fmt.Errorf("running migrations: %w", migErr)
 	}
 
 	// Create connection pool
 	pool, err := pgxpool.New(ctx, connStr)
 	if err != nil {
 		pgContainer.Terminate(ctx)
-		return nil, nil, fmt.Errorf("creating pool: %w", err)
+		return nil, nil, nil, fmt.Errorf("creating pool: %w", err)
 	}
 
-	return pgContainer, pool, nil
+	return pgContainer, pool, dbCfg, nil
+}
+
+// GetTestDBConfig returns the connection config of the shared, migrated test database.
+func GetTestDBConfig(t *testing.T) *config.DatabaseConfig {
+	t.Helper()
+
+	GetTestPool(t)
+	cfg := *testDBConfig
+	return &cfg
+}
+
+// NewTempDatabase creates an empty database in the shared test container and
+// returns its connection config. No migrations are applied. The database is
+// dropped when the test finishes.
+func NewTempDatabase(t *testing.T) *config.DatabaseConfig {
+	t.Helper()
+
+	pool := GetTestPool(t)
+	ctx := context.Background()
+	name := fmt.Sprintf("doc_engine_tmp_%d_%d", os.Getpid(), tempDBSeq.Add(1))
+
+	if _, err := pool.Exec(ctx, "CREATE DATABASE "+name); err != nil {
+		t.Fatalf("creating temp database: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := pool.Exec(context.Background(), "DROP DATABASE IF EXISTS "+name+" WITH (FORCE)"); err != nil {
+			t.Logf("dropping temp database %s: %v", name, err)
+		}
+	})
+
+	cfg := *testDBConfig
+	cfg.Name = name
+	return &cfg
 }
 
 // CleanupContainers terminates all test containers.
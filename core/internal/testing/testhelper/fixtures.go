@@ -542,6 +542,14 @@ func SetTemplateDocumentType(t *testing.T, pool *pgxpool.Pool, templateID, docTy
 	require.NoError(t, err, "failed to set template document type")
 }
 
+// SetTemplatePublicLibrary marks or unmarks a template as part of the public library.
+func SetTemplatePublicLibrary(t *testing.T, pool *pgxpool.Pool, templateID string, isPublic bool) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, `UPDATE content.templates SET is_public_library = $2 WHERE id = $1`, templateID, isPublic)
+	require.NoError(t, err, "failed to set template public library flag")
+}
+
 // CreateTestTemplate creates a template in the database and returns its ID.
 // Schema: content.templates
 func CreateTestTemplate(t *testing.T, pool *pgxpool.Pool,
@@ -571,6 +579,7 @@ func CleanupTemplate(t *testing.T, pool *pgxpool.Pool, templateID string) {
 	_, _ = pool.Exec(ctx, "DELETE FROM content.template_versions WHERE template_id = $1", templateID)
 	_, _ = pool.Exec(ctx, "DELETE FROM content.template_tags WHERE template_id = $1", templateID)
 	_, _ = pool.Exec(ctx, "DELETE FROM content.templates WHERE id = $1", templateID)
+	_, _ = pool.Exec(ctx, "DELETE FROM outbox.events WHERE aggregate_id = $1", templateID)
 }
 
 // CreateTestTemplateVersion creates a template version in the database and returns its ID.
@@ -631,6 +640,38 @@ func CleanupVersionInjectable(t *testing.T, pool *pgxpool.Pool, id string) {
 	_, _ = pool.Exec(ctx, "DELETE FROM content.template_version_injectables WHERE id = $1", id)
 }
 
+// CreateTestSystemVersionInjectable links a system (or provider) injectable key to a version,
+// creating its definition when missing.
+// Schema: content.system_injectable_definitions, content.template_version_injectables
+func CreateTestSystemVersionInjectable(t *testing.T, pool *pgxpool.Pool, versionID, key string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO content.system_injectable_definitions (key, is_active, created_at)
+		VALUES ($1, true, NOW())
+		ON CONFLICT (key) DO NOTHING`, key)
+	require.NoError(t, err, "failed to create system injectable definition")
+
+	id := uuid.NewString()
+	_, err = pool.Exec(ctx, `
+		INSERT INTO content.template_version_injectables
+			(id, template_version_id, system_injectable_key, is_required, default_value, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		id, versionID, key, false, nil, time.Now().UTC())
+	require.NoError(t, err, "failed to create system version injectable")
+
+	return id
+}
+
+// CleanupSystemInjectableDefinition removes a system injectable definition and its references.
+func CleanupSystemInjectableDefinition(t *testing.T, pool *pgxpool.Pool, key string) {
+	t.Helper()
+	ctx := context.Background()
+	_, _ = pool.Exec(ctx, "DELETE FROM content.template_version_injectables WHERE system_injectable_key = $1", key)
+	_, _ = pool.Exec(ctx, "DELETE FROM content.system_injectable_definitions WHERE key = $1", key)
+}
+
 // CreateTestSignerRole creates a signer role for a version.
 // Schema: content.template_version_signer_roles
 func CreateTestSignerRole(t *testing.T, pool *pgxpool.Pool,
@@ -687,6 +728,15 @@ func CleanupDocument(t *testing.T, pool *pgxpool.Pool, documentID string) {
 	_, _ = pool.Exec(ctx, "DELETE FROM execution.document_events WHERE document_id = $1", documentID)
 	_, _ = pool.Exec(ctx, "DELETE FROM execution.document_recipients WHERE document_id = $1", documentID)
 	_, _ = pool.Exec(ctx, "DELETE FROM execution.documents WHERE id = $1", documentID)
+	_, _ = pool.Exec(ctx, "DELETE FROM outbox.events WHERE aggregate_id = $1", documentID)
+}
+
+// SetTestVersionContent replaces a template version's content structure.
+func SetTestVersionContent(t *testing.T, pool *pgxpool.Pool, versionID string, content []byte) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, `UPDATE content.template_versions SET content_structure = $2 WHERE id = $1`, versionID, content)
+	require.NoError(t, err, "failed to set version content")
 }
 
 // PublishTestVersion updates a template version status to PUBLISHED directly in the database.
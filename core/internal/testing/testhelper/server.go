@@ -42,6 +42,7 @@ import (
 	tenantrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/tenant_repo"
 	useraccesshistoryrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/user_access_history_repo"
 	userrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/user_repo"
+	workspaceassetrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_asset_repo"
 	workspaceinjectablerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_injectable_repo"
 	workspacememberrepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_member_repo"
 	workspacerepo "github.com/rendis/doc-assembly/core/internal/adapters/secondary/database/postgres/workspace_repo"
@@ -59,6 +60,7 @@ import (
 	templatesvc "github.com/rendis/doc-assembly/core/internal/core/service/template"
 	contentvalidator "github.com/rendis/doc-assembly/core/internal/core/service/template/contentvalidator"
 	automationuc "github.com/rendis/doc-assembly/core/internal/core/usecase/automation"
+	templateuc "github.com/rendis/doc-assembly/core/internal/core/usecase/template"
 	"github.com/rendis/doc-assembly/core/internal/infra/config"
 	"github.com/rendis/doc-assembly/core/internal/infra/registry"
 	"github.com/rendis/doc-assembly/core/internal/infra/riverqueue"
@@ -67,6 +69,15 @@ import (
 // TestInternalAPIKey is the API key used by integration tests for internal endpoints.
 const TestInternalAPIKey = "test-internal-api-key"
 
+// TestThemeName is the only design theme registered in the test server.
+const TestThemeName = "brand"
+
+// testThemes is the test server's theme registry.
+type testThemes struct{}
+
+// HasTheme reports whether name is TestThemeName.
+func (testThemes) HasTheme(name string) bool { return name == TestThemeName }
+
 // MockPDFRenderer implements port.PDFRenderer for testing.
 type MockPDFRenderer struct{}
 
@@ -104,7 +115,9 @@ type TestServer struct {
 	Engine             *gin.Engine
 	Pool               *pgxpool.Pool
 	MockSigningAdapter *mocksigning.Adapter
-	t                  *testing.T
+	// TemplateVersionUC lets tests drive the scheduled publish/archive jobs with an explicit clock.
+	TemplateVersionUC templateuc.TemplateVersionUseCase
+	t                 *testing.T
 }
 
 // NewTestServer creates a test HTTP server with all real dependencies.
@@ -150,6 +163,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 		tenantMemberRepo,
 		systemRoleRepo,
 		userAccessHistoryRepo,
+		testThemes{},
 	)
 	systemRoleService := accesssvc.NewSystemRoleService(systemRoleRepo, userRepo)
 	tenantMemberService := organizationsvc.NewTenantMemberService(tenantMemberRepo, userRepo, tenantRepo)
@@ -168,13 +182,17 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 	systemInjectableService := injectablesvc.NewSystemInjectableService(systemInjectableRepo, nil)
 
 	// Create services - Content
-	injectableService := injectablesvc.NewInjectableService(injectableRepo, systemInjectableRepo, nil, workspaceRepo, tenantRepo, nil)
+	injectableService := injectablesvc.NewInjectableService(injectableRepo, systemInjectableRepo, nil, workspaceRepo, tenantRepo, templateVersionSignerRoleRepo, nil)
 
 	// Create content validator
 	contentValidator := contentvalidator.New(injectableService)
 
 	// Create services - Content
-	templateService := templatesvc.NewTemplateService(templateRepo, templateVersionRepo, templateTagRepo, nil)
+	templateService := templatesvc.NewTemplateService(
+		templateRepo, templateVersionRepo, templateTagRepo, tagRepo,
+		templateVersionInjectableRepo, injectableRepo,
+		nil,
+	)
 	templateVersionService := templatesvc.NewTemplateVersionService(
 		templateVersionRepo,
 		templateVersionInjectableRepo,
@@ -211,6 +229,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 	noopNotifier := noopnotification.New()
 	testPublicURL := "http://localhost:8080"
 	galleryService := gallerysvc.New(storageAdapter, galleryRepo, testPublicURL)
+	workspaceAssetService := organizationsvc.NewWorkspaceAssetService(workspaceassetrepo.New(pool), storageAdapter)
 	notificationSvc := documentsvc.NewNotificationService(noopNotifier, docRecipientRepo, docRepo, docAccessTokenRepo, testPublicURL)
 
 	// River attempt UoW in insert-only mode for integration helpers.
@@ -326,6 +345,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 		templateVersionController,
 	)
 	galleryController := controller.NewGalleryController(galleryService)
+	workspaceAssetController := controller.NewWorkspaceAssetController(workspaceAssetService)
 
 	// Create controllers - Document & Webhook
 	documentController := controller.NewDocumentController(documentService, preSigningService, eventEmitter)
@@ -335,7 +355,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 		notificationSvc, testPublicURL, 3, 60, 48,
 	)
 	publicDocAccessController := controller.NewPublicDocumentAccessController(documentAccessService)
-	publicSigningController := controller.NewPublicSigningController(preSigningService, documentAccessService, testPublicURL)
+	publicSigningController := controller.NewPublicSigningController(preSigningService, documentAccessService, testPublicURL, nil)
 	webhookHandlers := map[string]port.WebhookHandler{
 		"mock": mockSigningAdapter,
 	}
@@ -372,6 +392,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 	wsGroup := v1.Group("", middlewareProvider.WorkspaceContext())
 	documentController.RegisterRoutes(wsGroup)
 	galleryController.RegisterRoutes(v1, middlewareProvider)
+	workspaceAssetController.RegisterRoutes(v1, middlewareProvider)
 
 	// --- Automation infrastructure (created early, needed by internal routes) ---
 	automationKeyRepo := automationapikeyrepo.New(pool)
@@ -418,6 +439,7 @@ func NewTestServerWithResolver(t *testing.T, pool *pgxpool.Pool, templateResolve
 		Engine:             engine,
 		Pool:               pool,
 		MockSigningAdapter: mockSigningAdapter,
+		TemplateVersionUC:  templateVersionService,
 		t:                  t,
 	}
 }
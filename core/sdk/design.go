@@ -7,3 +7,6 @@ type TypstDesignTokens = pdfrenderer.TypstDesignTokens
 
 // DefaultDesignTokens returns the default design tokens for PDF rendering.
 var DefaultDesignTokens = pdfrenderer.DefaultDesignTokens
+
+// LocaleFormat holds per-language value formatting defaults (boolean words, decimal separator, date pattern).
+type LocaleFormat = pdfrenderer.LocaleFormat
@@ -16,4 +16,9 @@
 //
 //	engine := sdk.New()
 //	engine.RunMigrations()
+//
+// Validate settings and connectivity without starting the server:
+//
+//	engine := sdk.New()
+//	engine.RunDoctor()
 package sdk
@@ -1,6 +1,9 @@
 package sdk
 
-import "github.com/rendis/doc-assembly/core/cmd/api/bootstrap"
+import (
+	"github.com/rendis/doc-assembly/core/cmd/api/bootstrap"
+	"github.com/rendis/doc-assembly/core/internal/core/port"
+)
 
 // Engine is the main entry point for doc-assembly.
 // Create with New(), register extensions, then call Run().
@@ -11,3 +14,18 @@ var New = bootstrap.New
 
 // NewWithConfig creates a new Engine that loads config from the given file path.
 var NewWithConfig = bootstrap.NewWithConfig
+
+// MigrationStatus reports the applied migration version, dirty flag and pending count.
+type MigrationStatus = bootstrap.MigrationStatus
+
+// RenderOptions selects what Engine.Render and Engine.RenderDocument produce.
+type RenderOptions = bootstrap.RenderOptions
+
+// RenderResult is the output of Engine.Render and Engine.RenderDocument.
+type RenderResult = bootstrap.RenderResult
+
+// PDFEncryption sets the passwords and permissions of an encrypted render.
+type PDFEncryption = port.PDFEncryption
+
+// ImageOptions requests a PNG of one page instead of a PDF.
+type ImageOptions = port.ImageOptions
@@ -0,0 +1,89 @@
+//go:build integration
+
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/doc-assembly/core/internal/core/entity"
+	"github.com/rendis/doc-assembly/core/internal/testing/testhelper"
+	"github.com/rendis/doc-assembly/core/sdk"
+)
+
+const letterContent = `{"version":"1.1.0","meta":{"title":"Letter","language":"en"},"pageConfig":{"formatId":"A4","width":595,"height":842,"margins":{"top":20,"bottom":20,"left":20,"right":20}},"variableIds":["client_name"],"signerRoles":[],"content":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Dear "},{"type":"injector","attrs":{"type":"TEXT","variableId":"client_name"}}]}]}}`
+
+// writeEngineConfig writes a config file pointing the engine at the test database.
+func writeEngineConfig(t *testing.T, typstBin string) string {
+	t.Helper()
+	db := testhelper.GetTestDBConfig(t)
+	dir := t.TempDir()
+	content := fmt.Sprintf(`database:
+  host: %s
+  port: %d
+  user: %s
+  password: %s
+  name: %s
+  ssl_mode: %s
+storage:
+  local_dir: %s
+typst:
+  bin_path: %s
+  image_cache_dir: %s
+`, db.Host, db.Port, db.User, db.Password, db.Name, db.SSLMode,
+		filepath.Join(dir, "storage"), typstBin, filepath.Join(dir, "images"))
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestEngine_Render(t *testing.T) {
+	typstBin, err := exec.LookPath("typst")
+	if err != nil {
+		t.Skipf("Typst not available, skipping test: %v", err)
+	}
+	pool := testhelper.GetTestPool(t)
+	ctx := context.Background()
+
+	tenantID := testhelper.CreateTestTenant(t, pool, "SDK Render Tenant", "SDKR01")
+	t.Cleanup(func() { testhelper.CleanupTenant(t, pool, tenantID) })
+	workspaceID := testhelper.CreateTestWorkspace(t, pool, &tenantID, "SDK Render Workspace", entity.WorkspaceTypeClient)
+	t.Cleanup(func() { testhelper.CleanupWorkspace(t, pool, workspaceID) })
+	templateID := testhelper.CreateTestTemplate(t, pool, workspaceID, "Letter", nil)
+	t.Cleanup(func() { testhelper.CleanupTemplate(t, pool, templateID) })
+	versionID := testhelper.CreateTestTemplateVersion(t, pool, templateID, 1, "v1", entity.VersionStatusDraft)
+	testhelper.SetTestVersionContent(t, pool, versionID, []byte(letterContent))
+
+	engine := sdk.NewWithConfig(writeEngineConfig(t, typstBin))
+	t.Cleanup(func() { assert.NoError(t, engine.Close()) })
+
+	t.Run("renders a stored version", func(t *testing.T) {
+		result, err := engine.Render(ctx, versionID, map[string]any{"client_name": "ACME"}, sdk.RenderOptions{})
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(result.PDF, []byte("%PDF-")), "result should be a PDF")
+		assert.Equal(t, 1, result.PageCount)
+	})
+
+	t.Run("renders an in-memory document", func(t *testing.T) {
+		result, err := engine.RenderDocument(ctx, []byte(letterContent), map[string]any{"client_name": "ACME"}, sdk.RenderOptions{})
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(result.PDF, []byte("%PDF-")), "result should be a PDF")
+	})
+
+	t.Run("close releases the runtime and render reopens it", func(t *testing.T) {
+		require.NoError(t, engine.Close())
+		require.NoError(t, engine.Close(), "closing twice is a no-op")
+
+		result, err := engine.Render(ctx, versionID, nil, sdk.RenderOptions{})
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(result.PDF, []byte("%PDF-")), "result should be a PDF")
+	})
+}
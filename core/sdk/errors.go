@@ -0,0 +1,25 @@
+package sdk
+
+import "github.com/rendis/doc-assembly/core/internal/core/entity"
+
+// Error categories. Errors returned by the engine match one of these with errors.Is,
+// and extensions can wrap them (fmt.Errorf("%w: ...", sdk.ErrNotFound)) to choose
+// the HTTP status of a failure:
+//
+//	ErrNotFound      404
+//	ErrValidation    400
+//	ErrConflict      409
+//	ErrUnauthorized  401
+//	ErrForbidden     403
+//	ErrRenderFailed  500
+var (
+	ErrNotFound     = entity.ErrNotFound
+	ErrValidation   = entity.ErrValidation
+	ErrConflict     = entity.ErrConflict
+	ErrUnauthorized = entity.ErrUnauthorized
+	ErrForbidden    = entity.ErrForbidden
+	ErrRenderFailed = entity.ErrRenderFailed
+)
+
+// WithCategory returns err with its message unchanged, additionally matching category with errors.Is.
+var WithCategory = entity.WithCategory
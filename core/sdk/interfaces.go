@@ -69,6 +69,18 @@ type StorageAdapter = port.StorageAdapter
 // NotificationProvider sends notifications (email, etc.).
 type NotificationProvider = port.NotificationProvider
 
+// RenderCache stores rendered PDFs keyed by version and render inputs (e.g. Redis-backed).
+type RenderCache = port.RenderCache
+
+// SealCertificateProvider returns the PAdES seal certificate of a workspace (e.g. from a KMS or vault).
+type SealCertificateProvider = port.SealCertificateProvider
+
+// SealCertificate is a seal certificate, its chain and the signer for its private key.
+type SealCertificate = port.SealCertificate
+
+// EventSink delivers outbox events (e.g. template version published) to other services.
+type EventSink = port.EventSink
+
 // --- Optional Schema Interfaces ---
 
 // TableSchemaProvider can be implemented by Injector to expose table column schema.
@@ -122,10 +134,24 @@ type (
 	NotificationAttachment = port.NotificationAttachment
 )
 
+// EventSink types
+type (
+	OutboxEvent                     = entity.OutboxEvent
+	TemplateVersionPublishedPayload = entity.TemplateVersionPublishedPayload
+	SigningStatusChangedPayload     = entity.SigningStatusChangedPayload
+)
+
+// Outbox event type constants.
+const (
+	EventTemplateVersionPublished = entity.EventTemplateVersionPublished
+	EventSigningStatusChanged     = entity.EventSigningStatusChanged
+)
+
 // PDF Renderer types
 type (
-	SignatureField  = port.SignatureField
-	SignerRoleValue = port.SignerRoleValue
+	SignatureField      = port.SignatureField
+	SignerRoleValue     = port.SignerRoleValue
+	RenderPreviewResult = port.RenderPreviewResult
 )
 
 // Registry types
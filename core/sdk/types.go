@@ -125,6 +125,18 @@ const (
 // InjectableDataType identifies the data type of an injectable definition.
 type InjectableDataType = entity.InjectableDataType
 
+// Injectable data type constants.
+const (
+	InjectableDataTypeText     = entity.InjectableDataTypeText
+	InjectableDataTypeNumber   = entity.InjectableDataTypeNumber
+	InjectableDataTypeDate     = entity.InjectableDataTypeDate
+	InjectableDataTypeCurrency = entity.InjectableDataTypeCurrency
+	InjectableDataTypeBoolean  = entity.InjectableDataTypeBoolean
+	InjectableDataTypeImage    = entity.InjectableDataTypeImage
+	InjectableDataTypeTable    = entity.InjectableDataTypeTable
+	InjectableDataTypeList     = entity.InjectableDataTypeList
+)
+
 // --- Document Status Enum ---
 
 // DocumentStatus represents the lifecycle state of a document.